@@ -0,0 +1,97 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"golang.org/x/sync/singleflight"
+)
+
+// codeOwnersMaxFileSize is the size above which CODEOWNERS files are
+// truncated, which is the maximum size GitHub supports.
+const codeOwnersMaxFileSize = 3 * 1024 * 1024
+
+// codeOwnersReadTimeout is the timeout for reading the CODEOWNERS file of a
+// commit. The read is shared by concurrent callers, so it isn't bound to the
+// context of any of them.
+const codeOwnersReadTimeout = time.Minute
+
+// codeOwnersCache caches the parsed CODEOWNERS file of recently requested
+// repository commits, so that it isn't read from gitserver for each search
+// result. Commits are immutable, so entries never need to be invalidated.
+var (
+	codeOwnersCacheMu sync.Mutex
+	codeOwnersCache   = lru.New(1000)
+	codeOwnersGroup   singleflight.Group
+)
+
+// CodeOwners returns the parsed CODEOWNERS file of the repository at the given
+// commit, or nil if it has none.
+func CodeOwners(ctx context.Context, repo gitserver.Repo, commitID api.CommitID) (*codeowners.Ruleset, error) {
+	if Mocks.CodeOwners != nil {
+		return Mocks.CodeOwners(ctx, repo, commitID)
+	}
+
+	if !git.IsAbsoluteRevision(string(commitID)) {
+		return nil, errors.Errorf("refusing to read CODEOWNERS for non-absolute commit ID %q", commitID)
+	}
+
+	key := string(repo.Name) + ":" + string(commitID)
+	codeOwnersCacheMu.Lock()
+	v, ok := codeOwnersCache.Get(key)
+	codeOwnersCacheMu.Unlock()
+	if ok {
+		return v.(*codeowners.Ruleset), nil
+	}
+
+	// Concurrent requests for the same commit, such as for the results of a
+	// search, read the file once. The read must not fail for all of them when
+	// the context of the first one is canceled, so it uses its own context.
+	ch := codeOwnersGroup.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), codeOwnersReadTimeout)
+		defer cancel()
+
+		rs, err := readCodeOwners(ctx, repo, commitID)
+		if err != nil {
+			return nil, err
+		}
+		codeOwnersCacheMu.Lock()
+		codeOwnersCache.Add(key, rs)
+		codeOwnersCacheMu.Unlock()
+		return rs, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*codeowners.Ruleset), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// readCodeOwners reads and parses the first CODEOWNERS file found in the
+// repository at the given commit.
+func readCodeOwners(ctx context.Context, repo gitserver.Repo, commitID api.CommitID) (*codeowners.Ruleset, error) {
+	for _, path := range codeowners.Paths {
+		data, err := git.ReadFile(ctx, repo, commitID, path, codeOwnersMaxFileSize)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", path)
+		}
+		return codeowners.Parse(path, bytes.NewReader(data))
+	}
+	return nil, nil
+}
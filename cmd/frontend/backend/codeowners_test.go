@@ -0,0 +1,101 @@
+package backend
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestCodeOwners(t *testing.T) {
+	ctx := testContext()
+	defer git.ResetMocks()
+
+	files := map[string]string{
+		"a": "CODEOWNERS",
+		"b": ".gitlab/CODEOWNERS",
+	}
+	var reads []string
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		reads = append(reads, name)
+		if files[string(commit[:1])] == name {
+			return []byte("*.go @go-team\n"), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+
+	repo := gitserver.Repo{Name: "github.com/foo/bar"}
+	for _, tc := range []struct {
+		commit    string
+		wantPath  string
+		wantReads []string
+	}{
+		{commit: "a", wantPath: "CODEOWNERS", wantReads: []string{".github/CODEOWNERS", "CODEOWNERS"}},
+		{commit: "b", wantPath: ".gitlab/CODEOWNERS", wantReads: []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}},
+		{commit: "c", wantReads: []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}},
+	} {
+		commitID := api.CommitID(strings.Repeat(tc.commit, 40))
+		for i := 0; i < 2; i++ {
+			reads = nil
+			rs, err := CodeOwners(ctx, repo, commitID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantPath == "" {
+				if rs != nil {
+					t.Errorf("%s: got CODEOWNERS %+v, want none", tc.commit, rs)
+				}
+			} else if rs == nil || rs.Path != tc.wantPath || !rs.IsOwnedBy("main.go", "@go-team") {
+				t.Errorf("%s: got CODEOWNERS %+v, want %s", tc.commit, rs, tc.wantPath)
+			}
+
+			// The parsed file is cached for the commit.
+			wantReads := tc.wantReads
+			if i > 0 {
+				wantReads = nil
+			}
+			if !reflect.DeepEqual(reads, wantReads) {
+				t.Errorf("%s: got reads %q, want %q", tc.commit, reads, wantReads)
+			}
+		}
+	}
+
+	if _, err := CodeOwners(ctx, repo, "master"); err == nil {
+		t.Error("got no error for a non-absolute commit ID")
+	}
+}
+
+func TestCodeOwners_canceledCaller(t *testing.T) {
+	ctx := testContext()
+	defer git.ResetMocks()
+
+	release := make(chan struct{})
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		<-release
+		return []byte("*.go @go-team\n"), nil
+	}
+
+	repo := gitserver.Repo{Name: "github.com/foo/canceled"}
+	commitID := api.CommitID(strings.Repeat("d", 40))
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := CodeOwners(canceledCtx, repo, commitID); err != context.Canceled {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+
+	// The read started for the canceled caller isn't canceled with it.
+	close(release)
+	rs, err := CodeOwners(ctx, repo, commitID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs == nil || !rs.IsOwnedBy("main.go", "@go-team") {
+		t.Errorf("got CODEOWNERS %+v, want one owned by @go-team", rs)
+	}
+}
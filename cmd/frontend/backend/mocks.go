@@ -4,7 +4,10 @@ import (
 	"context"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
@@ -12,7 +15,8 @@ import (
 var Mocks MockServices
 
 type MockServices struct {
-	Repos MockRepos
+	Repos      MockRepos
+	CodeOwners func(ctx context.Context, repo gitserver.Repo, commitID api.CommitID) (*codeowners.Ruleset, error)
 }
 
 // testContext creates a new context.Context for use by tests
@@ -2,6 +2,10 @@ package backend
 
 import (
 	"context"
+	"sort"
+	"strings"
+
+	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	symbolsclient "github.com/sourcegraph/sourcegraph/internal/symbols"
@@ -13,11 +17,75 @@ var Symbols = &symbols{}
 
 type symbols struct{}
 
-// ListTags returns symbols in a repository from ctags.
+// PreciseSymbols, if set, returns the symbols in a repository from precise code intelligence
+// data along with the roots of the uploads that provided them. An empty root covers the whole
+// repository. No roots are returned if there is no such data for the requested commit. This is
+// set by the enterprise frontend.
+var PreciseSymbols func(ctx context.Context, args search.SymbolsParameters) ([]protocol.Symbol, []string, error)
+
+// ListTags returns symbols in a repository. Precise symbols are preferred for paths covered by
+// the precise code intelligence data of the requested commit, and the symbols of all other paths
+// are read from ctags.
 func (symbols) ListTags(ctx context.Context, args search.SymbolsParameters) ([]protocol.Symbol, error) {
+	if PreciseSymbols != nil {
+		preciseSymbols, roots, err := PreciseSymbols(ctx, args)
+		if err != nil {
+			log15.Warn("Failed to search precise symbols, falling back to ctags", "repo", args.Repo, "commit", args.CommitID, "error", err)
+		} else if len(roots) > 0 {
+			if coversPath(roots, "") {
+				return preciseSymbols, nil
+			}
+
+			ctagsSymbols, err := searchCtags(ctx, args)
+			if err != nil {
+				return nil, err
+			}
+
+			return mergeSymbols(preciseSymbols, ctagsSymbols, roots, args.First), nil
+		}
+	}
+
+	return searchCtags(ctx, args)
+}
+
+func searchCtags(ctx context.Context, args search.SymbolsParameters) ([]protocol.Symbol, error) {
 	result, err := symbolsclient.DefaultClient.Search(ctx, args)
 	if result == nil {
 		return nil, err
 	}
 	return result.Symbols, err
 }
+
+// mergeSymbols combines precise symbols with the ctags symbols of paths not covered by any
+// of the given upload roots. The result is ordered by path and line and contains at most
+// first symbols (if positive).
+func mergeSymbols(preciseSymbols, ctagsSymbols []protocol.Symbol, roots []string, first int) []protocol.Symbol {
+	symbols := append([]protocol.Symbol(nil), preciseSymbols...)
+	for _, symbol := range ctagsSymbols {
+		if !coversPath(roots, symbol.Path) {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	sort.SliceStable(symbols, func(i, j int) bool {
+		if symbols[i].Path != symbols[j].Path {
+			return symbols[i].Path < symbols[j].Path
+		}
+		return symbols[i].Line < symbols[j].Line
+	})
+
+	if first > 0 && len(symbols) > first {
+		symbols = symbols[:first]
+	}
+	return symbols
+}
+
+// coversPath returns true if the given path is within one of the given upload roots.
+func coversPath(roots []string, path string) bool {
+	for _, root := range roots {
+		if strings.HasPrefix(path, root) {
+			return true
+		}
+	}
+	return false
+}
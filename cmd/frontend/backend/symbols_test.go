@@ -0,0 +1,35 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+func TestMergeSymbols(t *testing.T) {
+	preciseSymbols := []protocol.Symbol{
+		{Name: "App", Path: "web/app.ts", Line: 3},
+		{Name: "render", Path: "web/app.ts", Line: 10},
+	}
+	ctagsSymbols := []protocol.Symbol{
+		{Name: "main", Path: "cmd/main.go", Line: 5},
+		{Name: "App", Path: "web/app.ts", Line: 4},
+		{Name: "Server", Path: "z.go", Line: 1},
+	}
+
+	expected := []protocol.Symbol{
+		{Name: "main", Path: "cmd/main.go", Line: 5},
+		{Name: "App", Path: "web/app.ts", Line: 3},
+		{Name: "render", Path: "web/app.ts", Line: 10},
+		{Name: "Server", Path: "z.go", Line: 1},
+	}
+	if diff := cmp.Diff(expected, mergeSymbols(preciseSymbols, ctagsSymbols, []string{"web/"}, 0)); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(expected[:2], mergeSymbols(preciseSymbols, ctagsSymbols, []string{"web/"}, 2)); diff != "" {
+		t.Errorf("unexpected limited symbols (-want +got):\n%s", diff)
+	}
+}
@@ -6,13 +6,16 @@ var Mocks MockStores
 type MockStores struct {
 	AccessTokens MockAccessTokens
 
-	Repos         MockRepos
-	Orgs          MockOrgs
-	OrgMembers    MockOrgMembers
-	SavedSearches MockSavedSearches
-	Settings      MockSettings
-	Users         MockUsers
-	UserEmails    MockUserEmails
+	Repos            MockRepos
+	Orgs             MockOrgs
+	OrgMembers       MockOrgMembers
+	SavedSearches    MockSavedSearches
+	SearchContexts   MockSearchContexts
+	SearchExportJobs MockSearchExportJobs
+	SearchTrends     MockSearchTrends
+	Settings         MockSettings
+	Users            MockUsers
+	UserEmails       MockUserEmails
 
 	Phabricator MockPhabricator
 
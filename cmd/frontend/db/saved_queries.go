@@ -16,6 +16,10 @@ type SavedQueryInfo struct {
 	LastExecuted time.Time
 	LatestResult time.Time
 	ExecDuration time.Duration
+
+	// ResultFingerprint is the opaque fingerprint of the latest result set of
+	// the query, if any.
+	ResultFingerprint []byte
 }
 
 // Get gets the saved query information for the given query. nil
@@ -27,9 +31,9 @@ func (s *queryRunnerState) Get(ctx context.Context, query string) (*SavedQueryIn
 	var execDurationNs int64
 	err := dbconn.Global.QueryRowContext(
 		ctx,
-		"SELECT last_executed, latest_result, exec_duration_ns FROM query_runner_state WHERE query=$1",
+		"SELECT last_executed, latest_result, exec_duration_ns, result_fingerprint FROM query_runner_state WHERE query=$1",
 		query,
-	).Scan(&info.LastExecuted, &info.LatestResult, &execDurationNs)
+	).Scan(&info.LastExecuted, &info.LatestResult, &execDurationNs, &info.ResultFingerprint)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -47,10 +51,11 @@ func (s *queryRunnerState) Get(ctx context.Context, query string) (*SavedQueryIn
 func (s *queryRunnerState) Set(ctx context.Context, info *SavedQueryInfo) error {
 	res, err := dbconn.Global.ExecContext(
 		ctx,
-		"UPDATE query_runner_state SET last_executed=$1, latest_result=$2, exec_duration_ns=$3 WHERE query=$4",
+		"UPDATE query_runner_state SET last_executed=$1, latest_result=$2, exec_duration_ns=$3, result_fingerprint=$4 WHERE query=$5",
 		info.LastExecuted,
 		info.LatestResult,
 		int64(info.ExecDuration),
+		info.ResultFingerprint,
 		info.Query,
 	)
 	if err != nil {
@@ -64,11 +69,12 @@ func (s *queryRunnerState) Set(ctx context.Context, info *SavedQueryInfo) error
 		// Didn't update any row, so insert a new one.
 		_, err := dbconn.Global.ExecContext(
 			ctx,
-			"INSERT INTO query_runner_state(query, last_executed, latest_result, exec_duration_ns) VALUES($1, $2, $3, $4)",
+			"INSERT INTO query_runner_state(query, last_executed, latest_result, exec_duration_ns, result_fingerprint) VALUES($1, $2, $3, $4, $5)",
 			info.Query,
 			info.LastExecuted,
 			info.LatestResult,
 			int64(info.ExecDuration),
+			info.ResultFingerprint,
 		)
 		if err != nil {
 			return errors.Wrap(err, "INSERT")
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
@@ -56,7 +57,10 @@ func (s *savedSearches) ListAll(ctx context.Context) (savedSearches []api.SavedQ
 		notify_slack,
 		user_id,
 		org_id,
-		slack_webhook_url FROM saved_searches
+		slack_webhook_url,
+		notify_webhook,
+		webhook_url,
+		webhook_secret FROM saved_searches
 	`)
 	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar))
 	if err != nil {
@@ -73,7 +77,10 @@ func (s *savedSearches) ListAll(ctx context.Context) (savedSearches []api.SavedQ
 			&sq.Config.NotifySlack,
 			&sq.Config.UserID,
 			&sq.Config.OrgID,
-			&sq.Config.SlackWebhookURL); err != nil {
+			&sq.Config.SlackWebhookURL,
+			&sq.Config.NotifyWebhook,
+			&sq.Config.WebhookURL,
+			&sq.Config.WebhookSecret); err != nil {
 			return nil, errors.Wrap(err, "Scan")
 		}
 		sq.Spec.Key = sq.Config.Key
@@ -106,7 +113,10 @@ func (s *savedSearches) GetByID(ctx context.Context, id int32) (*api.SavedQueryS
 		notify_slack,
 		user_id,
 		org_id,
-		slack_webhook_url
+		slack_webhook_url,
+		notify_webhook,
+		webhook_url,
+		webhook_secret
 		FROM saved_searches WHERE id=$1`, id).Scan(
 		&sq.Config.Key,
 		&sq.Config.Description,
@@ -115,7 +125,10 @@ func (s *savedSearches) GetByID(ctx context.Context, id int32) (*api.SavedQueryS
 		&sq.Config.NotifySlack,
 		&sq.Config.UserID,
 		&sq.Config.OrgID,
-		&sq.Config.SlackWebhookURL)
+		&sq.Config.SlackWebhookURL,
+		&sq.Config.NotifyWebhook,
+		&sq.Config.WebhookURL,
+		&sq.Config.WebhookSecret)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +179,9 @@ func (s *savedSearches) ListSavedSearchesByUserID(ctx context.Context, userID in
 		notify_slack,
 		user_id,
 		org_id,
-		slack_webhook_url
+		slack_webhook_url,
+		notify_webhook,
+		webhook_url
 		FROM saved_searches %v`, conds)
 
 	rows, err := dbconn.Global.QueryContext(ctx, query.Query(sqlf.PostgresBindVar), query.Args()...)
@@ -175,7 +190,7 @@ func (s *savedSearches) ListSavedSearchesByUserID(ctx context.Context, userID in
 	}
 	for rows.Next() {
 		var ss types.SavedSearch
-		if err := rows.Scan(&ss.ID, &ss.Description, &ss.Query, &ss.Notify, &ss.NotifySlack, &ss.UserID, &ss.OrgID, &ss.SlackWebhookURL); err != nil {
+		if err := rows.Scan(&ss.ID, &ss.Description, &ss.Query, &ss.Notify, &ss.NotifySlack, &ss.UserID, &ss.OrgID, &ss.SlackWebhookURL, &ss.NotifyWebhook, &ss.WebhookURL); err != nil {
 			return nil, errors.Wrap(err, "Scan(2)")
 		}
 		savedSearches = append(savedSearches, &ss)
@@ -201,7 +216,9 @@ func (s *savedSearches) ListSavedSearchesByOrgID(ctx context.Context, orgID int3
 		notify_slack,
 		user_id,
 		org_id,
-		slack_webhook_url
+		slack_webhook_url,
+		notify_webhook,
+		webhook_url
 		FROM saved_searches %v`, conds)
 
 	rows, err := dbconn.Global.QueryContext(ctx, query.Query(sqlf.PostgresBindVar), query.Args()...)
@@ -210,7 +227,7 @@ func (s *savedSearches) ListSavedSearchesByOrgID(ctx context.Context, orgID int3
 	}
 	for rows.Next() {
 		var ss types.SavedSearch
-		if err := rows.Scan(&ss.ID, &ss.Description, &ss.Query, &ss.Notify, &ss.NotifySlack, &ss.UserID, &ss.OrgID, &ss.SlackWebhookURL); err != nil {
+		if err := rows.Scan(&ss.ID, &ss.Description, &ss.Query, &ss.Notify, &ss.NotifySlack, &ss.UserID, &ss.OrgID, &ss.SlackWebhookURL, &ss.NotifyWebhook, &ss.WebhookURL); err != nil {
 			return nil, errors.Wrap(err, "Scan")
 		}
 		savedSearches = append(savedSearches, &ss)
@@ -240,12 +257,14 @@ func (s *savedSearches) Create(ctx context.Context, newSavedSearch *types.SavedS
 	}()
 
 	savedQuery = &types.SavedSearch{
-		Description: newSavedSearch.Description,
-		Query:       newSavedSearch.Query,
-		Notify:      newSavedSearch.Notify,
-		NotifySlack: newSavedSearch.NotifySlack,
-		UserID:      newSavedSearch.UserID,
-		OrgID:       newSavedSearch.OrgID,
+		Description:   newSavedSearch.Description,
+		Query:         newSavedSearch.Query,
+		Notify:        newSavedSearch.Notify,
+		NotifySlack:   newSavedSearch.NotifySlack,
+		UserID:        newSavedSearch.UserID,
+		OrgID:         newSavedSearch.OrgID,
+		NotifyWebhook: newSavedSearch.NotifyWebhook,
+		WebhookURL:    newSavedSearch.WebhookURL,
 	}
 
 	err = dbconn.Global.QueryRowContext(ctx, `INSERT INTO saved_searches(
@@ -254,14 +273,20 @@ func (s *savedSearches) Create(ctx context.Context, newSavedSearch *types.SavedS
 			notify_owner,
 			notify_slack,
 			user_id,
-			org_id
-		) VALUES($1, $2, $3, $4, $5, $6) RETURNING id`,
+			org_id,
+			notify_webhook,
+			webhook_url,
+			webhook_secret
+		) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
 		newSavedSearch.Description,
 		newSavedSearch.Query,
 		newSavedSearch.Notify,
 		newSavedSearch.NotifySlack,
 		newSavedSearch.UserID,
 		newSavedSearch.OrgID,
+		newSavedSearch.NotifyWebhook,
+		newSavedSearch.WebhookURL,
+		newSavedSearch.WebhookSecret,
 	).Scan(&savedQuery.ID)
 	if err != nil {
 		return nil, err
@@ -269,7 +294,8 @@ func (s *savedSearches) Create(ctx context.Context, newSavedSearch *types.SavedS
 	return savedQuery, nil
 }
 
-// Update updates an existing saved search.
+// Update updates an existing saved search. The webhook secret of the saved
+// search is only updated if savedSearch.WebhookSecret is non-nil.
 //
 // 🚨 SECURITY: This method does NOT verify the user's identity or that the
 // user is an admin. It is the callers responsibility to ensure the user has
@@ -293,6 +319,8 @@ func (s *savedSearches) Update(ctx context.Context, savedSearch *types.SavedSear
 		UserID:          savedSearch.UserID,
 		OrgID:           savedSearch.OrgID,
 		SlackWebhookURL: savedSearch.SlackWebhookURL,
+		NotifyWebhook:   savedSearch.NotifyWebhook,
+		WebhookURL:      savedSearch.WebhookURL,
 	}
 
 	fieldUpdates := []*sqlf.Query{
@@ -304,6 +332,10 @@ func (s *savedSearches) Update(ctx context.Context, savedSearch *types.SavedSear
 		sqlf.Sprintf("user_id=%v", savedSearch.UserID),
 		sqlf.Sprintf("org_id=%v", savedSearch.OrgID),
 		sqlf.Sprintf("slack_webhook_url=%v", savedSearch.SlackWebhookURL),
+		sqlf.Sprintf("notify_webhook=%t", savedSearch.NotifyWebhook),
+		sqlf.Sprintf("webhook_url=%v", savedSearch.WebhookURL),
+		// A nil secret keeps the existing secret.
+		sqlf.Sprintf("webhook_secret=COALESCE(%v, webhook_secret)", savedSearch.WebhookSecret),
 	}
 
 	updateQuery := sqlf.Sprintf(`UPDATE saved_searches SET %s WHERE ID=%v RETURNING id`, sqlf.Join(fieldUpdates, ", "), savedSearch.ID)
@@ -334,3 +366,117 @@ func (s *savedSearches) Delete(ctx context.Context, id int32) (err error) {
 	}
 	return nil
 }
+
+// MaxSavedSearchWebhookDeliveries is the number of most recent webhook delivery
+// attempts retained for each saved search. Older attempts are removed when a
+// new attempt is recorded.
+const MaxSavedSearchWebhookDeliveries = 100
+
+// LogWebhookDelivery records an attempt to post a notification to the webhook
+// of a saved search and removes all but the MaxSavedSearchWebhookDeliveries most
+// recent attempts of the saved search.
+func (s *savedSearches) LogWebhookDelivery(ctx context.Context, delivery *types.SavedSearchWebhookDelivery) (err error) {
+	if Mocks.SavedSearches.LogWebhookDelivery != nil {
+		return Mocks.SavedSearches.LogWebhookDelivery(ctx, delivery)
+	}
+
+	tr, ctx := trace.New(ctx, "db.SavedSearches.LogWebhookDelivery", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	var statusCode *int32
+	if delivery.StatusCode != 0 {
+		statusCode = &delivery.StatusCode
+	}
+	var deliveryErr *string
+	if delivery.Error != "" {
+		deliveryErr = &delivery.Error
+	}
+
+	_, err = dbconn.Global.ExecContext(ctx, `INSERT INTO saved_search_webhook_deliveries(
+			saved_search_id,
+			url,
+			attempt,
+			status_code,
+			error,
+			duration_ms
+		) VALUES($1, $2, $3, $4, $5, $6)`,
+		delivery.SavedSearchID,
+		delivery.URL,
+		delivery.Attempt,
+		statusCode,
+		deliveryErr,
+		delivery.Duration.Milliseconds(),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = dbconn.Global.ExecContext(ctx, `DELETE FROM saved_search_webhook_deliveries
+		WHERE saved_search_id=$1 AND id NOT IN (
+			SELECT id FROM saved_search_webhook_deliveries
+			WHERE saved_search_id=$1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		)`,
+		delivery.SavedSearchID,
+		MaxSavedSearchWebhookDeliveries,
+	)
+	return err
+}
+
+// ListWebhookDeliveries lists the most recent webhook delivery attempts of a
+// saved search, newest first.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure only the owners
+// of the saved search can access the returned deliveries.
+func (s *savedSearches) ListWebhookDeliveries(ctx context.Context, savedSearchID int32, limit int) (deliveries []*types.SavedSearchWebhookDelivery, err error) {
+	if Mocks.SavedSearches.ListWebhookDeliveries != nil {
+		return Mocks.SavedSearches.ListWebhookDeliveries(ctx, savedSearchID, limit)
+	}
+
+	tr, ctx := trace.New(ctx, "db.SavedSearches.ListWebhookDeliveries", "")
+	defer func() {
+		tr.SetError(err)
+		tr.LogFields(otlog.Int("count", len(deliveries)))
+		tr.Finish()
+	}()
+
+	rows, err := dbconn.Global.QueryContext(ctx, `SELECT
+		id,
+		saved_search_id,
+		url,
+		attempt,
+		status_code,
+		error,
+		duration_ms,
+		created_at
+		FROM saved_search_webhook_deliveries
+		WHERE saved_search_id=$1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`, savedSearchID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "QueryContext")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			d           types.SavedSearchWebhookDelivery
+			statusCode  sql.NullInt32
+			deliveryErr sql.NullString
+			durationMs  int64
+		)
+		if err := rows.Scan(&d.ID, &d.SavedSearchID, &d.URL, &d.Attempt, &statusCode, &deliveryErr, &durationMs, &d.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "Scan")
+		}
+		d.StatusCode = statusCode.Int32
+		d.Error = deliveryErr.String
+		d.Duration = time.Duration(durationMs) * time.Millisecond
+		deliveries = append(deliveries, &d)
+	}
+	return deliveries, rows.Err()
+}
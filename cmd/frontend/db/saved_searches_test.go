@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -388,3 +389,71 @@ func TestListSavedSearchesByUserID(t *testing.T) {
 		t.Errorf("got %v, want %v", savedSearches, want)
 	}
 }
+
+func TestSavedSearchesWebhookDeliveries(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+	_, err := Users.Create(ctx, NewUser{DisplayName: "test", Email: "test@test.com", Username: "test", Password: "test", EmailVerificationCode: "c2"})
+	if err != nil {
+		t.Fatal("can't create user", err)
+	}
+	userID := int32(1)
+	webhookURL := "https://example.com/hook"
+	fake := &types.SavedSearch{
+		Query:         "test",
+		Description:   "test",
+		NotifyWebhook: true,
+		WebhookURL:    &webhookURL,
+		UserID:        &userID,
+	}
+	ss, err := SavedSearches.Create(ctx, fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range []*types.SavedSearchWebhookDelivery{
+		{SavedSearchID: ss.ID, URL: webhookURL, Attempt: 1, Error: "connection refused", Duration: time.Second},
+		{SavedSearchID: ss.ID, URL: webhookURL, Attempt: 2, StatusCode: 200, Duration: 2 * time.Second},
+	} {
+		if err := SavedSearches.LogWebhookDelivery(ctx, d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deliveries, err := SavedSearches.ListWebhookDeliveries(ctx, ss.ID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("got %d deliveries, want 2", len(deliveries))
+	}
+	if got := deliveries[0]; got.Attempt != 2 || got.StatusCode != 200 || got.Error != "" || got.Duration != 2*time.Second {
+		t.Errorf("unexpected latest delivery %+v", got)
+	}
+	if got := deliveries[1]; got.Attempt != 1 || got.StatusCode != 0 || got.Error != "connection refused" {
+		t.Errorf("unexpected first delivery %+v", got)
+	}
+
+	// Only the most recent deliveries are retained.
+	for i := 0; i < MaxSavedSearchWebhookDeliveries; i++ {
+		if err := SavedSearches.LogWebhookDelivery(ctx, &types.SavedSearchWebhookDelivery{SavedSearchID: ss.ID, URL: webhookURL, Attempt: 1, StatusCode: 200}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deliveries, err = SavedSearches.ListWebhookDeliveries(ctx, ss.ID, 2*MaxSavedSearchWebhookDeliveries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != MaxSavedSearchWebhookDeliveries {
+		t.Fatalf("got %d deliveries, want %d", len(deliveries), MaxSavedSearchWebhookDeliveries)
+	}
+	for _, d := range deliveries {
+		if d.Error != "" {
+			t.Errorf("expected older deliveries to be removed, got %+v", d)
+		}
+	}
+}
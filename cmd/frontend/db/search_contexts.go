@@ -0,0 +1,356 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+type searchContexts struct{}
+
+// SearchContextNotFoundError occurs when a search context is not found.
+type SearchContextNotFoundError struct {
+	args []interface{}
+}
+
+func (err *SearchContextNotFoundError) Error() string {
+	return fmt.Sprintf("search context not found: %v", err.args)
+}
+
+func (err *SearchContextNotFoundError) NotFound() bool {
+	return true
+}
+
+var errSearchContextNameAlreadyExists = errors.New("a search context with this name already exists in this namespace")
+
+// SearchContextsListOptions specifies the options for listing search contexts.
+type SearchContextsListOptions struct {
+	// UserID, if non-zero, lists only the contexts owned by this user.
+	UserID int32
+	// OrgID, if non-zero, lists only the contexts owned by this organization.
+	OrgID int32
+}
+
+// Create creates a new search context with the given repository revisions.
+// The ID field must be zero, or an error will be returned.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to create the context in its namespace.
+func (s *searchContexts) Create(ctx context.Context, newContext *types.SearchContext, revisions []*types.SearchContextRepositoryRevision) (sc *types.SearchContext, err error) {
+	if Mocks.SearchContexts.Create != nil {
+		return Mocks.SearchContexts.Create(ctx, newContext, revisions)
+	}
+
+	if newContext.ID != 0 {
+		return nil, errors.New("newContext.ID must be zero")
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchContexts.Create", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := dbconn.Global.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if rollErr := tx.Rollback(); rollErr != nil {
+				err = multierror.Append(err, rollErr)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	sc = &types.SearchContext{
+		Name:        newContext.Name,
+		Description: newContext.Description,
+		UserID:      newContext.UserID,
+		OrgID:       newContext.OrgID,
+		Public:      newContext.Public,
+		Query:       newContext.Query,
+	}
+	err = tx.QueryRowContext(ctx, `INSERT INTO search_contexts(
+			name,
+			description,
+			user_id,
+			org_id,
+			public,
+			query
+		) VALUES($1, $2, $3, $4, $5, $6) RETURNING id, created_at, updated_at`,
+		sc.Name,
+		sc.Description,
+		nullInt32Column(sc.UserID),
+		nullInt32Column(sc.OrgID),
+		sc.Public,
+		sc.Query,
+	).Scan(&sc.ID, &sc.CreatedAt, &sc.UpdatedAt)
+	if err != nil {
+		return nil, searchContextError(err)
+	}
+
+	if err := setSearchContextRepositoryRevisions(ctx, tx, sc.ID, revisions); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// Update updates the name, description, visibility and query of a search
+// context, and replaces its repository revisions.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to update the context.
+func (s *searchContexts) Update(ctx context.Context, sc *types.SearchContext, revisions []*types.SearchContextRepositoryRevision) (err error) {
+	if Mocks.SearchContexts.Update != nil {
+		return Mocks.SearchContexts.Update(ctx, sc, revisions)
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchContexts.Update", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := dbconn.Global.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if rollErr := tx.Rollback(); rollErr != nil {
+				err = multierror.Append(err, rollErr)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	err = tx.QueryRowContext(ctx, `UPDATE search_contexts SET
+			name=$1,
+			description=$2,
+			public=$3,
+			query=$4,
+			updated_at=now()
+		WHERE id=$5 RETURNING updated_at`,
+		sc.Name,
+		sc.Description,
+		sc.Public,
+		sc.Query,
+		sc.ID,
+	).Scan(&sc.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return &SearchContextNotFoundError{args: []interface{}{sc.ID}}
+	}
+	if err != nil {
+		return searchContextError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM search_context_repos WHERE search_context_id=$1`, sc.ID); err != nil {
+		return err
+	}
+	return setSearchContextRepositoryRevisions(ctx, tx, sc.ID, revisions)
+}
+
+func setSearchContextRepositoryRevisions(ctx context.Context, tx *sql.Tx, id int32, revisions []*types.SearchContextRepositoryRevision) error {
+	for _, rev := range revisions {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO search_context_repos(search_context_id, repo_id, revision)
+			VALUES($1, $2, $3) ON CONFLICT DO NOTHING`, id, rev.RepoID, rev.Revision); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func nullInt32Column(n int32) *int32 {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+func searchContextError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Constraint {
+		case "search_contexts_name_user_id_unique", "search_contexts_name_org_id_unique", "search_contexts_name_global_unique":
+			return errSearchContextNameAlreadyExists
+		case "search_contexts_name_valid_chars", "search_contexts_name_max_length":
+			return fmt.Errorf("search context name invalid: %s", pqErr.Constraint)
+		}
+	}
+	return err
+}
+
+// GetByID returns the search context with the given ID.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure this response
+// only makes it to users with proper permissions to access the context.
+func (s *searchContexts) GetByID(ctx context.Context, id int32) (*types.SearchContext, error) {
+	if Mocks.SearchContexts.GetByID != nil {
+		return Mocks.SearchContexts.GetByID(ctx, id)
+	}
+	return s.getOne(ctx, sqlf.Sprintf("id=%d", id), id)
+}
+
+// GetByName returns the search context with the given name owned by the user
+// or organization with the given ID. If both IDs are zero, it returns the
+// instance-wide search context with the given name.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure this response
+// only makes it to users with proper permissions to access the context.
+func (s *searchContexts) GetByName(ctx context.Context, userID, orgID int32, name string) (*types.SearchContext, error) {
+	if Mocks.SearchContexts.GetByName != nil {
+		return Mocks.SearchContexts.GetByName(ctx, userID, orgID, name)
+	}
+
+	conds := []*sqlf.Query{sqlf.Sprintf("name=%s", name)}
+	switch {
+	case userID != 0:
+		conds = append(conds, sqlf.Sprintf("user_id=%d", userID))
+	case orgID != 0:
+		conds = append(conds, sqlf.Sprintf("org_id=%d", orgID))
+	default:
+		conds = append(conds, sqlf.Sprintf("user_id IS NULL AND org_id IS NULL"))
+	}
+	return s.getOne(ctx, sqlf.Join(conds, "AND"), userID, orgID, name)
+}
+
+func (s *searchContexts) getOne(ctx context.Context, cond *sqlf.Query, args ...interface{}) (*types.SearchContext, error) {
+	contexts, err := s.list(ctx, cond)
+	if err != nil {
+		return nil, err
+	}
+	if len(contexts) == 0 {
+		return nil, &SearchContextNotFoundError{args: args}
+	}
+	return contexts[0], nil
+}
+
+// List lists the search contexts matching opt.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure that only users
+// with the proper permissions can access the returned contexts.
+func (s *searchContexts) List(ctx context.Context, opt SearchContextsListOptions) ([]*types.SearchContext, error) {
+	if Mocks.SearchContexts.List != nil {
+		return Mocks.SearchContexts.List(ctx, opt)
+	}
+
+	conds := []*sqlf.Query{sqlf.Sprintf("true")}
+	if opt.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id=%d", opt.UserID))
+	}
+	if opt.OrgID != 0 {
+		conds = append(conds, sqlf.Sprintf("org_id=%d", opt.OrgID))
+	}
+	return s.list(ctx, sqlf.Join(conds, "AND"))
+}
+
+func (s *searchContexts) list(ctx context.Context, cond *sqlf.Query) ([]*types.SearchContext, error) {
+	q := sqlf.Sprintf(`SELECT
+		id,
+		name,
+		description,
+		user_id,
+		org_id,
+		public,
+		query,
+		created_at,
+		updated_at
+		FROM search_contexts WHERE %s ORDER BY id`, cond)
+
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "QueryContext")
+	}
+	defer rows.Close()
+
+	var contexts []*types.SearchContext
+	for rows.Next() {
+		var sc types.SearchContext
+		if err := rows.Scan(
+			&sc.ID,
+			&sc.Name,
+			&sc.Description,
+			&dbutil.NullInt32{N: &sc.UserID},
+			&dbutil.NullInt32{N: &sc.OrgID},
+			&sc.Public,
+			&sc.Query,
+			&sc.CreatedAt,
+			&sc.UpdatedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "Scan")
+		}
+		contexts = append(contexts, &sc)
+	}
+	return contexts, rows.Err()
+}
+
+// ListRepositoryRevisions lists the repository revisions of a search context,
+// ordered by repository name and revision.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure that only users
+// with the proper permissions can access the returned revisions.
+func (s *searchContexts) ListRepositoryRevisions(ctx context.Context, id int32) ([]*types.SearchContextRepositoryRevision, error) {
+	if Mocks.SearchContexts.ListRepositoryRevisions != nil {
+		return Mocks.SearchContexts.ListRepositoryRevisions(ctx, id)
+	}
+
+	rows, err := dbconn.Global.QueryContext(ctx, `SELECT
+		search_context_repos.repo_id,
+		repo.name,
+		search_context_repos.revision
+		FROM search_context_repos
+		JOIN repo ON repo.id = search_context_repos.repo_id
+		WHERE search_context_repos.search_context_id=$1 AND repo.deleted_at IS NULL
+		ORDER BY repo.name, search_context_repos.revision`, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "QueryContext")
+	}
+	defer rows.Close()
+
+	var revisions []*types.SearchContextRepositoryRevision
+	for rows.Next() {
+		var rev types.SearchContextRepositoryRevision
+		if err := rows.Scan(&rev.RepoID, &rev.RepoName, &rev.Revision); err != nil {
+			return nil, errors.Wrap(err, "Scan")
+		}
+		revisions = append(revisions, &rev)
+	}
+	return revisions, rows.Err()
+}
+
+// Delete hard-deletes a search context and its repository revisions.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to perform the delete.
+func (s *searchContexts) Delete(ctx context.Context, id int32) (err error) {
+	if Mocks.SearchContexts.Delete != nil {
+		return Mocks.SearchContexts.Delete(ctx, id)
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchContexts.Delete", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	_, err = dbconn.Global.ExecContext(ctx, `DELETE FROM search_contexts WHERE id=$1`, id)
+	return err
+}
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockSearchContexts struct {
+	Create                  func(ctx context.Context, newContext *types.SearchContext, revisions []*types.SearchContextRepositoryRevision) (*types.SearchContext, error)
+	Update                  func(ctx context.Context, sc *types.SearchContext, revisions []*types.SearchContextRepositoryRevision) error
+	GetByID                 func(ctx context.Context, id int32) (*types.SearchContext, error)
+	GetByName               func(ctx context.Context, userID, orgID int32, name string) (*types.SearchContext, error)
+	List                    func(ctx context.Context, opt SearchContextsListOptions) ([]*types.SearchContext, error)
+	ListRepositoryRevisions func(ctx context.Context, id int32) ([]*types.SearchContextRepositoryRevision, error)
+	Delete                  func(ctx context.Context, id int32) error
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestSearchContexts(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+	user, err := Users.Create(ctx, NewUser{DisplayName: "test", Email: "test@test.com", Username: "test", Password: "test", EmailVerificationCode: "c2"})
+	if err != nil {
+		t.Fatal("can't create user", err)
+	}
+	org, err := Orgs.Create(ctx, "o1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repos := mustCreate(ctx, t, &types.Repo{Name: "github.com/a/b"}, &types.Repo{Name: "github.com/a/c"})
+
+	userContext, err := SearchContexts.Create(ctx, &types.SearchContext{Name: "ctx", UserID: user.ID, Description: "d"}, []*types.SearchContextRepositoryRevision{
+		{RepoID: repos[1].ID, Revision: "v1"},
+		{RepoID: repos[0].ID, Revision: ""},
+		{RepoID: repos[1].ID, Revision: "main"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgContext, err := SearchContexts.Create(ctx, &types.SearchContext{Name: "ctx", OrgID: org.ID, Public: true, Query: "repo:^github\\.com/a/"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalContext, err := SearchContexts.Create(ctx, &types.SearchContext{Name: "ctx"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SearchContexts.Create(ctx, &types.SearchContext{Name: "ctx", UserID: user.ID}, nil); err != errSearchContextNameAlreadyExists {
+		t.Errorf("got error %v creating a context with a duplicate name, want %v", err, errSearchContextNameAlreadyExists)
+	}
+	if _, err := SearchContexts.Create(ctx, &types.SearchContext{Name: "a b"}, nil); err == nil {
+		t.Error("expected error creating a context with an invalid name")
+	}
+
+	for _, tc := range []struct {
+		userID, orgID int32
+		want          int32
+	}{
+		{user.ID, 0, userContext.ID},
+		{0, org.ID, orgContext.ID},
+		{0, 0, globalContext.ID},
+	} {
+		got, err := SearchContexts.GetByName(ctx, tc.userID, tc.orgID, "CTX")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != tc.want {
+			t.Errorf("user %d org %d: got context %d, want %d", tc.userID, tc.orgID, got.ID, tc.want)
+		}
+	}
+	if _, err := SearchContexts.GetByName(ctx, user.ID, 0, "other"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+
+	got, err := SearchContexts.GetByID(ctx, orgContext.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.OrgID != org.ID || got.UserID != 0 || !got.Public || got.Query != orgContext.Query {
+		t.Errorf("unexpected context %+v", got)
+	}
+
+	list, err := SearchContexts.List(ctx, SearchContextsListOptions{UserID: user.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ID != userContext.ID {
+		t.Errorf("unexpected contexts of user %+v", list)
+	}
+
+	revisions, err := SearchContexts.ListRepositoryRevisions(ctx, userContext.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*types.SearchContextRepositoryRevision{
+		{RepoID: repos[0].ID, RepoName: repos[0].Name, Revision: ""},
+		{RepoID: repos[1].ID, RepoName: repos[1].Name, Revision: "main"},
+		{RepoID: repos[1].ID, RepoName: repos[1].Name, Revision: "v1"},
+	}
+	if !reflect.DeepEqual(revisions, want) {
+		t.Errorf("got revisions %+v, want %+v", revisions, want)
+	}
+
+	userContext.Name = "renamed"
+	if err := SearchContexts.Update(ctx, userContext, []*types.SearchContextRepositoryRevision{{RepoID: repos[0].ID, Revision: "v2"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := SearchContexts.GetByName(ctx, user.ID, 0, "renamed"); err != nil || got.ID != userContext.ID {
+		t.Errorf("expected renamed context, got %+v (error %v)", got, err)
+	}
+	if revisions, err := SearchContexts.ListRepositoryRevisions(ctx, userContext.ID); err != nil || len(revisions) != 1 || revisions[0].Revision != "v2" {
+		t.Errorf("expected revisions to be replaced, got %+v (error %v)", revisions, err)
+	}
+
+	if err := SearchContexts.Delete(ctx, userContext.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SearchContexts.GetByID(ctx, userContext.ID); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v getting deleted context, want not found", err)
+	}
+}
@@ -0,0 +1,286 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+type searchExportJobs struct{}
+
+// Create creates a new queued search export job. The ID field must be zero, or
+// an error will be returned.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to create the job.
+func (s *searchExportJobs) Create(ctx context.Context, newJob *types.SearchExportJob) (job *types.SearchExportJob, err error) {
+	if Mocks.SearchExportJobs.Create != nil {
+		return Mocks.SearchExportJobs.Create(ctx, newJob)
+	}
+
+	if newJob.ID != 0 {
+		return nil, errors.New("newJob.ID must be zero")
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchExportJobs.Create", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	q := sqlf.Sprintf(`INSERT INTO search_export_jobs(
+			user_id,
+			query,
+			format,
+			state
+		) VALUES(%s, %s, %s, %s) RETURNING %s`,
+		newJob.UserID,
+		newJob.Query,
+		newJob.Format,
+		types.SearchExportJobStateQueued,
+		searchExportJobColumns,
+	)
+	jobs, err := s.query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return jobs[0], nil
+}
+
+// GetByID returns the search export job with the given ID.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure this response
+// only makes it to users with proper permissions to access the job.
+func (s *searchExportJobs) GetByID(ctx context.Context, id int32) (*types.SearchExportJob, error) {
+	if Mocks.SearchExportJobs.GetByID != nil {
+		return Mocks.SearchExportJobs.GetByID(ctx, id)
+	}
+
+	jobs, err := s.list(ctx, sqlf.Sprintf("WHERE id=%d", id))
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, &searchExportJobNotFoundError{id: id}
+	}
+	return jobs[0], nil
+}
+
+type searchExportJobNotFoundError struct {
+	id int32
+}
+
+func (e *searchExportJobNotFoundError) Error() string {
+	return fmt.Sprintf("search export job %d not found", e.id)
+}
+
+func (e *searchExportJobNotFoundError) NotFound() bool {
+	return true
+}
+
+// ListByUserID lists the search export jobs of a user, most recent first.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure that only the
+// specified user or users with proper permissions can access the returned
+// jobs.
+func (s *searchExportJobs) ListByUserID(ctx context.Context, userID int32) ([]*types.SearchExportJob, error) {
+	if Mocks.SearchExportJobs.ListByUserID != nil {
+		return Mocks.SearchExportJobs.ListByUserID(ctx, userID)
+	}
+	return s.list(ctx, sqlf.Sprintf("WHERE user_id=%d ORDER BY id DESC", userID))
+}
+
+// ListFinishedBefore lists the completed or errored search export jobs that
+// finished before t.
+func (s *searchExportJobs) ListFinishedBefore(ctx context.Context, t time.Time) ([]*types.SearchExportJob, error) {
+	if Mocks.SearchExportJobs.ListFinishedBefore != nil {
+		return Mocks.SearchExportJobs.ListFinishedBefore(ctx, t)
+	}
+	return s.list(ctx, sqlf.Sprintf("WHERE finished_at < %s ORDER BY id", t))
+}
+
+// Dequeue marks the oldest queued search export job as processing and returns
+// it, or returns nil if there is none. Jobs that have been processing without
+// progress for longer than staleAfter are assumed to have been abandoned by a
+// process that died and are dequeued again, so that they resume from their
+// last recorded progress.
+func (s *searchExportJobs) Dequeue(ctx context.Context, staleAfter time.Duration) (job *types.SearchExportJob, err error) {
+	if Mocks.SearchExportJobs.Dequeue != nil {
+		return Mocks.SearchExportJobs.Dequeue(ctx, staleAfter)
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchExportJobs.Dequeue", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	q := sqlf.Sprintf(`UPDATE search_export_jobs SET
+			state=%s,
+			started_at=COALESCE(started_at, now()),
+			updated_at=now()
+		WHERE id=(
+			SELECT id FROM search_export_jobs
+			WHERE state=%s OR (state=%s AND updated_at < %s)
+			ORDER BY id
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		) RETURNING %s`,
+		types.SearchExportJobStateProcessing,
+		types.SearchExportJobStateQueued,
+		types.SearchExportJobStateProcessing,
+		time.Now().Add(-staleAfter),
+		searchExportJobColumns,
+	)
+	jobs, err := s.query(ctx, q)
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	return jobs[0], nil
+}
+
+// UpdateProgress records the progress of a processing search export job: the
+// cursor of the next page of results, the number of results and repositories
+// written so far and the size of the file. It returns false if the job no
+// longer exists.
+func (s *searchExportJobs) UpdateProgress(ctx context.Context, job *types.SearchExportJob) (exists bool, err error) {
+	if Mocks.SearchExportJobs.UpdateProgress != nil {
+		return Mocks.SearchExportJobs.UpdateProgress(ctx, job)
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchExportJobs.UpdateProgress", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	res, err := dbconn.Global.ExecContext(ctx, `UPDATE search_export_jobs SET
+			cursor=$1,
+			result_count=$2,
+			repositories_searched=$3,
+			file_size=$4,
+			updated_at=now()
+		WHERE id=$5`,
+		job.Cursor,
+		job.ResultCount,
+		job.RepoCount,
+		job.FileSize,
+		job.ID,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// MarkFinished marks a search export job as completed, or as errored with the
+// given failure message if it isn't empty.
+func (s *searchExportJobs) MarkFinished(ctx context.Context, id int32, failureMessage string) (err error) {
+	if Mocks.SearchExportJobs.MarkFinished != nil {
+		return Mocks.SearchExportJobs.MarkFinished(ctx, id, failureMessage)
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchExportJobs.MarkFinished", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	state := types.SearchExportJobStateCompleted
+	if failureMessage != "" {
+		state = types.SearchExportJobStateErrored
+	}
+	_, err = dbconn.Global.ExecContext(ctx, `UPDATE search_export_jobs SET
+			state=$1,
+			failure_message=NULLIF($2, ''),
+			finished_at=now(),
+			updated_at=now()
+		WHERE id=$3`,
+		state,
+		failureMessage,
+		id,
+	)
+	return err
+}
+
+// Delete hard-deletes a search export job. It doesn't delete its file.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to perform the delete.
+func (s *searchExportJobs) Delete(ctx context.Context, id int32) (err error) {
+	if Mocks.SearchExportJobs.Delete != nil {
+		return Mocks.SearchExportJobs.Delete(ctx, id)
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchExportJobs.Delete", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	_, err = dbconn.Global.ExecContext(ctx, `DELETE FROM search_export_jobs WHERE id=$1`, id)
+	return err
+}
+
+var searchExportJobColumns = sqlf.Sprintf(`id,
+		user_id,
+		query,
+		format,
+		state,
+		cursor,
+		result_count,
+		repositories_searched,
+		file_size,
+		failure_message,
+		created_at,
+		updated_at,
+		started_at,
+		finished_at`)
+
+func (s *searchExportJobs) list(ctx context.Context, conds *sqlf.Query) ([]*types.SearchExportJob, error) {
+	return s.query(ctx, sqlf.Sprintf("SELECT %s FROM search_export_jobs %s", searchExportJobColumns, conds))
+}
+
+func (s *searchExportJobs) query(ctx context.Context, q *sqlf.Query) ([]*types.SearchExportJob, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "QueryContext")
+	}
+	defer rows.Close()
+
+	var jobs []*types.SearchExportJob
+	for rows.Next() {
+		var j types.SearchExportJob
+		if err := rows.Scan(
+			&j.ID,
+			&j.UserID,
+			&j.Query,
+			&j.Format,
+			&j.State,
+			&dbutil.NullString{S: &j.Cursor},
+			&j.ResultCount,
+			&j.RepoCount,
+			&j.FileSize,
+			&dbutil.NullString{S: &j.FailureMessage},
+			&j.CreatedAt,
+			&j.UpdatedAt,
+			&j.StartedAt,
+			&j.FinishedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "Scan")
+		}
+		jobs = append(jobs, &j)
+	}
+	return jobs, rows.Err()
+}
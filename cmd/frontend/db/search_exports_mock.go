@@ -0,0 +1,19 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type MockSearchExportJobs struct {
+	Create             func(ctx context.Context, newJob *types.SearchExportJob) (*types.SearchExportJob, error)
+	GetByID            func(ctx context.Context, id int32) (*types.SearchExportJob, error)
+	ListByUserID       func(ctx context.Context, userID int32) ([]*types.SearchExportJob, error)
+	ListFinishedBefore func(ctx context.Context, t time.Time) ([]*types.SearchExportJob, error)
+	Dequeue            func(ctx context.Context, staleAfter time.Duration) (*types.SearchExportJob, error)
+	UpdateProgress     func(ctx context.Context, job *types.SearchExportJob) (bool, error)
+	MarkFinished       func(ctx context.Context, id int32, failureMessage string) error
+	Delete             func(ctx context.Context, id int32) error
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestSearchExportJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+	user, err := Users.Create(ctx, NewUser{DisplayName: "test", Email: "test@test.com", Username: "test", Password: "test", EmailVerificationCode: "c2"})
+	if err != nil {
+		t.Fatal("can't create user", err)
+	}
+
+	var jobs []*types.SearchExportJob
+	for _, format := range []types.SearchExportFormat{types.SearchExportFormatJSONLines, types.SearchExportFormatCSV} {
+		job, err := SearchExportJobs.Create(ctx, &types.SearchExportJob{UserID: user.ID, Query: "foo patternType:literal", Format: format})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.State != types.SearchExportJobStateQueued || job.Format != format || job.StartedAt != nil {
+			t.Errorf("unexpected new job %+v", job)
+		}
+		jobs = append(jobs, job)
+	}
+
+	list, err := SearchExportJobs.ListByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].ID != jobs[1].ID || list[1].ID != jobs[0].ID {
+		t.Errorf("unexpected jobs of user %+v", list)
+	}
+
+	// Jobs are dequeued in order.
+	dequeued, err := SearchExportJobs.Dequeue(ctx, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dequeued == nil || dequeued.ID != jobs[0].ID || dequeued.State != types.SearchExportJobStateProcessing || dequeued.StartedAt == nil {
+		t.Fatalf("unexpected dequeued job %+v", dequeued)
+	}
+
+	dequeued.Cursor = "c"
+	dequeued.ResultCount = 10
+	dequeued.RepoCount = 2
+	dequeued.FileSize = 1 << 40
+	if exists, err := SearchExportJobs.UpdateProgress(ctx, dequeued); err != nil || !exists {
+		t.Fatalf("got (%v, %v) updating progress, want (true, nil)", exists, err)
+	}
+
+	// The processing job is dequeued again once it is stale.
+	if job, err := SearchExportJobs.Dequeue(ctx, time.Hour); err != nil || job == nil || job.ID != jobs[1].ID {
+		t.Fatalf("got job %+v (error %v), want job %d", job, err, jobs[1].ID)
+	}
+	if job, err := SearchExportJobs.Dequeue(ctx, time.Hour); err != nil || job != nil {
+		t.Fatalf("got job %+v (error %v), want none", job, err)
+	}
+	job, err := SearchExportJobs.Dequeue(ctx, -time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job == nil || job.ID != jobs[0].ID || job.Cursor != "c" || job.ResultCount != 10 || job.RepoCount != 2 || job.FileSize != 1<<40 {
+		t.Fatalf("unexpected stale job %+v", job)
+	}
+
+	if err := SearchExportJobs.MarkFinished(ctx, jobs[0].ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := SearchExportJobs.MarkFinished(ctx, jobs[1].ID, "boom"); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		id             int32
+		state          types.SearchExportJobState
+		failureMessage string
+	}{
+		{jobs[0].ID, types.SearchExportJobStateCompleted, ""},
+		{jobs[1].ID, types.SearchExportJobStateErrored, "boom"},
+	} {
+		job, err := SearchExportJobs.GetByID(ctx, tc.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.State != tc.state || job.FailureMessage != tc.failureMessage || job.FinishedAt == nil {
+			t.Errorf("unexpected finished job %+v", job)
+		}
+	}
+
+	if finished, err := SearchExportJobs.ListFinishedBefore(ctx, time.Now().Add(-time.Hour)); err != nil || len(finished) != 0 {
+		t.Errorf("got jobs %+v (error %v), want none", finished, err)
+	}
+	if finished, err := SearchExportJobs.ListFinishedBefore(ctx, time.Now().Add(time.Hour)); err != nil || len(finished) != 2 {
+		t.Errorf("got jobs %+v (error %v), want 2", finished, err)
+	}
+
+	if err := SearchExportJobs.Delete(ctx, jobs[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SearchExportJobs.GetByID(ctx, jobs[0].ID); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v getting deleted job, want not found", err)
+	}
+	if exists, err := SearchExportJobs.UpdateProgress(ctx, jobs[0]); err != nil || exists {
+		t.Errorf("got (%v, %v) updating progress of deleted job, want (false, nil)", exists, err)
+	}
+}
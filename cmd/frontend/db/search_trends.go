@@ -0,0 +1,223 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+type searchTrends struct{}
+
+// Create creates a new search trend series. The ID field must be zero, or an
+// error will be returned.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to create the series.
+func (s *searchTrends) Create(ctx context.Context, newSeries *types.SearchTrendSeries) (series *types.SearchTrendSeries, err error) {
+	if Mocks.SearchTrends.Create != nil {
+		return Mocks.SearchTrends.Create(ctx, newSeries)
+	}
+
+	if newSeries.ID != 0 {
+		return nil, errors.New("newSeries.ID must be zero")
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchTrends.Create", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	series = &types.SearchTrendSeries{
+		UserID:   newSeries.UserID,
+		Query:    newSeries.Query,
+		Start:    newSeries.Start,
+		Interval: newSeries.Interval,
+	}
+	err = dbconn.Global.QueryRowContext(ctx, `INSERT INTO search_trend_series(
+			user_id,
+			query,
+			start_time,
+			interval
+		) VALUES($1, $2, $3, $4) RETURNING id, created_at, updated_at`,
+		newSeries.UserID,
+		newSeries.Query,
+		newSeries.Start,
+		newSeries.Interval,
+	).Scan(&series.ID, &series.CreatedAt, &series.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// GetByID returns the search trend series with the given ID.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure this response
+// only makes it to users with proper permissions to access the series.
+func (s *searchTrends) GetByID(ctx context.Context, id int32) (*types.SearchTrendSeries, error) {
+	if Mocks.SearchTrends.GetByID != nil {
+		return Mocks.SearchTrends.GetByID(ctx, id)
+	}
+
+	series, err := s.list(ctx, sqlf.Sprintf("WHERE id=%d", id))
+	if err != nil {
+		return nil, err
+	}
+	if len(series) == 0 {
+		return nil, errors.Errorf("search trend series %d not found", id)
+	}
+	return series[0], nil
+}
+
+// ListByUserID lists the search trend series of a user.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure that only the
+// specified user or users with proper permissions can access the returned
+// series.
+func (s *searchTrends) ListByUserID(ctx context.Context, userID int32) ([]*types.SearchTrendSeries, error) {
+	if Mocks.SearchTrends.ListByUserID != nil {
+		return Mocks.SearchTrends.ListByUserID(ctx, userID)
+	}
+	return s.list(ctx, sqlf.Sprintf("WHERE user_id=%d", userID))
+}
+
+// ListAll lists all the search trend series on an instance.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure that only users
+// with the proper permissions can access the returned series.
+func (s *searchTrends) ListAll(ctx context.Context) ([]*types.SearchTrendSeries, error) {
+	if Mocks.SearchTrends.ListAll != nil {
+		return Mocks.SearchTrends.ListAll(ctx)
+	}
+	return s.list(ctx, sqlf.Sprintf("WHERE true"))
+}
+
+func (s *searchTrends) list(ctx context.Context, conds *sqlf.Query) ([]*types.SearchTrendSeries, error) {
+	q := sqlf.Sprintf(`SELECT
+		id,
+		user_id,
+		query,
+		start_time,
+		interval,
+		created_at,
+		updated_at
+		FROM search_trend_series %v ORDER BY id`, conds)
+
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "QueryContext")
+	}
+	defer rows.Close()
+
+	var series []*types.SearchTrendSeries
+	for rows.Next() {
+		var s types.SearchTrendSeries
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Query, &s.Start, &s.Interval, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, errors.Wrap(err, "Scan")
+		}
+		series = append(series, &s)
+	}
+	return series, rows.Err()
+}
+
+// Delete hard-deletes a search trend series and its points.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to perform the delete.
+func (s *searchTrends) Delete(ctx context.Context, id int32) (err error) {
+	if Mocks.SearchTrends.Delete != nil {
+		return Mocks.SearchTrends.Delete(ctx, id)
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchTrends.Delete", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	_, err = dbconn.Global.ExecContext(ctx, `DELETE FROM search_trend_series WHERE id=$1`, id)
+	return err
+}
+
+// ListPoints lists the points of a search trend series, ordered by time. If
+// repoID is non-zero, only the points of that repository are listed.
+func (s *searchTrends) ListPoints(ctx context.Context, seriesID int32, repoID api.RepoID) ([]*types.SearchTrendPoint, error) {
+	if Mocks.SearchTrends.ListPoints != nil {
+		return Mocks.SearchTrends.ListPoints(ctx, seriesID, repoID)
+	}
+
+	conds := []*sqlf.Query{sqlf.Sprintf("series_id=%d", seriesID)}
+	if repoID != 0 {
+		conds = append(conds, sqlf.Sprintf("repo_id=%d", repoID))
+	}
+	q := sqlf.Sprintf(`SELECT
+		series_id,
+		repo_id,
+		time,
+		commit,
+		count,
+		recorded_at
+		FROM search_trend_points WHERE %s ORDER BY time, repo_id`, sqlf.Join(conds, "AND"))
+
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "QueryContext")
+	}
+	defer rows.Close()
+
+	var points []*types.SearchTrendPoint
+	for rows.Next() {
+		var p types.SearchTrendPoint
+		if err := rows.Scan(&p.SeriesID, &p.RepoID, &p.Time, &p.Commit, &p.Count, &p.RecordedAt); err != nil {
+			return nil, errors.Wrap(err, "Scan")
+		}
+		points = append(points, &p)
+	}
+	return points, rows.Err()
+}
+
+// UpsertPoint records a point of a search trend series, replacing any point
+// previously recorded for the same repository and time.
+func (s *searchTrends) UpsertPoint(ctx context.Context, point *types.SearchTrendPoint) (err error) {
+	if Mocks.SearchTrends.UpsertPoint != nil {
+		return Mocks.SearchTrends.UpsertPoint(ctx, point)
+	}
+
+	tr, ctx := trace.New(ctx, "db.SearchTrends.UpsertPoint", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	_, err = dbconn.Global.ExecContext(ctx, `INSERT INTO search_trend_points(
+			series_id,
+			repo_id,
+			time,
+			commit,
+			count,
+			recorded_at
+		) VALUES($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (series_id, repo_id, time) DO UPDATE SET
+			commit=EXCLUDED.commit,
+			count=EXCLUDED.count,
+			recorded_at=EXCLUDED.recorded_at`,
+		point.SeriesID,
+		point.RepoID,
+		point.Time,
+		point.Commit,
+		point.Count,
+		time.Now(),
+	)
+	return err
+}
@@ -0,0 +1,18 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+type MockSearchTrends struct {
+	Create       func(ctx context.Context, newSeries *types.SearchTrendSeries) (*types.SearchTrendSeries, error)
+	GetByID      func(ctx context.Context, id int32) (*types.SearchTrendSeries, error)
+	ListByUserID func(ctx context.Context, userID int32) ([]*types.SearchTrendSeries, error)
+	ListAll      func(ctx context.Context) ([]*types.SearchTrendSeries, error)
+	Delete       func(ctx context.Context, id int32) error
+	ListPoints   func(ctx context.Context, seriesID int32, repoID api.RepoID) ([]*types.SearchTrendPoint, error)
+	UpsertPoint  func(ctx context.Context, point *types.SearchTrendPoint) error
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestSearchTrends(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+	user, err := Users.Create(ctx, NewUser{DisplayName: "test", Email: "test@test.com", Username: "test", Password: "test", EmailVerificationCode: "c2"})
+	if err != nil {
+		t.Fatal("can't create user", err)
+	}
+	repos := mustCreate(ctx, t, &types.Repo{Name: "github.com/a/b"}, &types.Repo{Name: "github.com/a/c"})
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	series, err := SearchTrends.Create(ctx, &types.SearchTrendSeries{
+		UserID:   user.ID,
+		Query:    "deprecatedFunc( patternType:literal",
+		Start:    start,
+		Interval: types.SearchTrendIntervalMonth,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := SearchTrends.GetByID(ctx, series.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Query != series.Query || !got.Start.Equal(start) || got.Interval != types.SearchTrendIntervalMonth || got.UserID != user.ID {
+		t.Errorf("unexpected series %+v", got)
+	}
+
+	list, err := SearchTrends.ListByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ID != series.ID {
+		t.Errorf("unexpected series list %+v", list)
+	}
+
+	for _, p := range []*types.SearchTrendPoint{
+		{SeriesID: series.ID, RepoID: repos[0].ID, Time: start, Commit: "a", Count: 3},
+		{SeriesID: series.ID, RepoID: repos[1].ID, Time: start, Commit: "b", Count: 1},
+		{SeriesID: series.ID, RepoID: repos[0].ID, Time: start.AddDate(0, 1, 0), Commit: "c", Count: 2},
+		// Replaces the first point.
+		{SeriesID: series.ID, RepoID: repos[0].ID, Time: start, Commit: "d", Count: 4},
+	} {
+		if err := SearchTrends.UpsertPoint(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	points, err := SearchTrends.ListPoints(ctx, series.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 3 {
+		t.Fatalf("got %d points, want 3", len(points))
+	}
+	if p := points[0]; p.RepoID != repos[0].ID || p.Commit != "d" || p.Count != 4 {
+		t.Errorf("unexpected first point %+v", p)
+	}
+
+	points, err = SearchTrends.ListPoints(ctx, series.ID, repos[1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 1 || points[0].Commit != "b" {
+		t.Errorf("unexpected points of repository %+v", points)
+	}
+
+	if err := SearchTrends.Delete(ctx, series.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SearchTrends.GetByID(ctx, series.ID); err == nil {
+		t.Error("expected error getting deleted series")
+	}
+	if points, err := SearchTrends.ListPoints(ctx, series.ID, 0); err != nil || len(points) != 0 {
+		t.Errorf("expected points of deleted series to be deleted, got %d (error %v)", len(points), err)
+	}
+}
@@ -10,6 +10,9 @@ var (
 	Orgs             = &orgs{}
 	OrgMembers       = &orgMembers{}
 	SavedSearches    = &savedSearches{}
+	SearchContexts   = &searchContexts{}
+	SearchExportJobs = &searchExportJobs{}
+	SearchTrends     = &searchTrends{}
 	Settings         = &settings{}
 	Users            = &users{}
 	UserEmails       = &userEmails{}
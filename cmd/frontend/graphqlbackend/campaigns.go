@@ -87,6 +87,14 @@ type SyncChangesetArgs struct {
 	Changeset graphql.ID
 }
 
+type CreateActionExecutionArgs struct {
+	Definition JSONCString
+}
+
+type RetryActionExecutionArgs struct {
+	ActionExecution graphql.ID
+}
+
 type FileDiffsConnectionArgs struct {
 	First *int32
 	After *string
@@ -113,6 +121,10 @@ type CampaignsResolver interface {
 	PatchSetByID(ctx context.Context, id graphql.ID) (PatchSetResolver, error)
 
 	PatchByID(ctx context.Context, id graphql.ID) (PatchInterfaceResolver, error)
+
+	CreateActionExecution(ctx context.Context, args *CreateActionExecutionArgs) (ActionExecutionResolver, error)
+	RetryActionExecution(ctx context.Context, args *RetryActionExecutionArgs) (ActionExecutionResolver, error)
+	ActionExecutionByID(ctx context.Context, id graphql.ID) (ActionExecutionResolver, error)
 }
 
 var campaignsOnlyInEnterprise = errors.New("campaigns and changesets are only available in enterprise")
@@ -185,6 +197,18 @@ func (defaultCampaignsResolver) PatchByID(ctx context.Context, id graphql.ID) (P
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) CreateActionExecution(ctx context.Context, args *CreateActionExecutionArgs) (ActionExecutionResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) RetryActionExecution(ctx context.Context, args *RetryActionExecutionArgs) (ActionExecutionResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) ActionExecutionByID(ctx context.Context, id graphql.ID) (ActionExecutionResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 type ChangesetCountsArgs struct {
 	From *DateTime
 	To   *DateTime
@@ -352,6 +376,32 @@ type BackgroundProcessStatus interface {
 	Errors() []string
 }
 
+type ActionExecutionResolver interface {
+	ID() graphql.ID
+	Definition() JSONCString
+	Status(ctx context.Context) (BackgroundProcessStatus, error)
+	Jobs(ctx context.Context, args *graphqlutil.ConnectionArgs) ActionJobConnectionResolver
+	PatchSet(ctx context.Context) (PatchSetResolver, error)
+	CreatedAt() DateTime
+	FinishedAt() *DateTime
+}
+
+type ActionJobConnectionResolver interface {
+	Nodes(ctx context.Context) ([]ActionJobResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type ActionJobResolver interface {
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	State() campaigns.ActionJobState
+	Attempts() int32
+	Log() *string
+	Error() *string
+	StartedAt() *DateTime
+	FinishedAt() *DateTime
+}
+
 type PatchSetResolver interface {
 	ID() graphql.ID
 
@@ -15,6 +15,9 @@ type CodeIntelResolver interface {
 	LSIFUploads(ctx context.Context, args *LSIFUploadsQueryArgs) (LSIFUploadConnectionResolver, error)
 	LSIFUploadsByRepo(ctx context.Context, args *LSIFRepositoryUploadsQueryArgs) (LSIFUploadConnectionResolver, error)
 	DeleteLSIFUpload(ctx context.Context, id graphql.ID) (*EmptyResponse, error)
+	LSIFUploadRetentionPreview(ctx context.Context, repositoryID graphql.ID) ([]LSIFUploadExpirationResolver, error)
+	LSIFPackageDependents(ctx context.Context, args *LSIFRepositoryPackageDependentsQueryArgs) (LSIFPackageUsageConnectionResolver, error)
+	LSIFPackageDependencies(ctx context.Context, args *LSIFRepositoryPackageDependenciesQueryArgs) (LSIFPackageUsageConnectionResolver, error)
 	LSIFIndexByID(ctx context.Context, id graphql.ID) (LSIFIndexResolver, error)
 	LSIFIndexes(ctx context.Context, args *LSIFIndexesQueryArgs) (LSIFIndexConnectionResolver, error)
 	LSIFIndexesByRepo(ctx context.Context, args *LSIFRepositoryIndexesQueryArgs) (LSIFIndexConnectionResolver, error)
@@ -44,6 +47,18 @@ func (defaultCodeIntelResolver) DeleteLSIFUpload(ctx context.Context, id graphql
 	return nil, codeIntelOnlyInEnterprise
 }
 
+func (defaultCodeIntelResolver) LSIFUploadRetentionPreview(ctx context.Context, repositoryID graphql.ID) ([]LSIFUploadExpirationResolver, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
+func (defaultCodeIntelResolver) LSIFPackageDependents(ctx context.Context, args *LSIFRepositoryPackageDependentsQueryArgs) (LSIFPackageUsageConnectionResolver, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
+func (defaultCodeIntelResolver) LSIFPackageDependencies(ctx context.Context, args *LSIFRepositoryPackageDependenciesQueryArgs) (LSIFPackageUsageConnectionResolver, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
 func (defaultCodeIntelResolver) LSIFIndexByID(ctx context.Context, id graphql.ID) (LSIFIndexResolver, error) {
 	return nil, codeIntelOnlyInEnterprise
 }
@@ -108,6 +123,56 @@ type LSIFUploadResolver interface {
 	ProjectRoot(ctx context.Context) (*GitTreeEntryResolver, error)
 }
 
+type LSIFUploadExpirationResolver interface {
+	Upload() LSIFUploadResolver
+	Reason() string
+}
+
+type LSIFPackageDependentsQueryArgs struct {
+	graphqlutil.ConnectionArgs
+	Name            string
+	Version         string
+	IsLatestForRepo *bool
+	After           *string
+}
+
+type LSIFRepositoryPackageDependentsQueryArgs struct {
+	*LSIFPackageDependentsQueryArgs
+	RepositoryID graphql.ID
+}
+
+type LSIFPackageDependenciesQueryArgs struct {
+	graphqlutil.ConnectionArgs
+	Commit          *string
+	IsLatestForRepo *bool
+	After           *string
+}
+
+type LSIFRepositoryPackageDependenciesQueryArgs struct {
+	*LSIFPackageDependenciesQueryArgs
+	RepositoryID graphql.ID
+}
+
+type LSIFPackageResolver interface {
+	Scheme() string
+	Name() string
+	Version() string
+}
+
+type LSIFPackageUsageResolver interface {
+	Package() LSIFPackageResolver
+	Repository() *RepositoryResolver
+	Upload() LSIFUploadResolver
+	Provider(ctx context.Context) (LSIFUploadResolver, error)
+	IdentifierCount() *int32
+}
+
+type LSIFPackageUsageConnectionResolver interface {
+	Nodes(ctx context.Context) ([]LSIFPackageUsageResolver, error)
+	TotalCount(ctx context.Context) (*int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
 type LSIFUploadConnectionResolver interface {
 	Nodes(ctx context.Context) ([]LSIFUploadResolver, error)
 	TotalCount(ctx context.Context) (*int32, error)
@@ -155,6 +220,7 @@ type GitBlobLSIFDataResolver interface {
 
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 }
 
@@ -164,6 +230,7 @@ type GitBlobLSIFDataArgs struct {
 	Path      string
 	ExactPath bool
 	ToolName  string
+	OnlyExact bool
 }
 
 type LSIFQueryPositionArgs struct {
@@ -189,6 +256,7 @@ type LocationConnectionResolver interface {
 type HoverResolver interface {
 	Markdown() MarkdownResolver
 	Range() RangeResolver
+	AdjustmentConfidence() string
 }
 
 type DiagnosticConnectionResolver interface {
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 
@@ -16,6 +17,7 @@ import (
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/go-diff/diff"
+	"github.com/sourcegraph/sourcegraph/internal/comby"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
@@ -38,6 +40,8 @@ type args struct {
 	rewriteTemplate   string
 	includeFileFilter string
 	excludeFileFilter string
+	rule              string
+	matcher           string
 }
 
 // codemodResultResolver is a resolver for the GraphQL type `CodemodResult`
@@ -59,6 +63,10 @@ func (r *codemodResultResolver) ToCodemodResult() (*codemodResultResolver, bool)
 	return r, true
 }
 
+func (r *codemodResultResolver) ToCaptureGroupResult() (*captureGroupResultResolver, bool) {
+	return nil, false
+}
+
 func (r *codemodResultResolver) searchResultURIs() (string, string) {
 	return string(r.commit.repoResolver.repo.Name), r.path
 }
@@ -140,17 +148,38 @@ func validateQuery(q query.QueryInfo) (*args, error) {
 		}
 	}
 
-	return &args{matchTemplate, rewriteTemplate, includeFileFilterText, excludeFileFilterText}, nil
+	ruleValues, _ := q.StringValues(query.FieldCombyRule)
+	var rule string
+	if len(ruleValues) > 0 {
+		rule = ruleValues[0]
+	}
+
+	languages, _ := q.StringValues(query.FieldLang)
+	var matcher string
+	if len(languages) > 0 {
+		// Pick the first language, there is no support for applying
+		// multiple language matchers in a single query.
+		matcher = comby.LookupMatcher(languages[0])
+	}
+
+	return &args{matchTemplate, rewriteTemplate, includeFileFilterText, excludeFileFilterText, rule, matcher}, nil
 }
 
 // Calls the codemod backend replacer service for a set of repository revisions.
 func performCodemod(ctx context.Context, args *search.TextParameters) ([]SearchResultResolver, *searchResultsCommon, error) {
+	return performCodemodStream(ctx, args, nil)
+}
+
+// performCodemodStream is like performCodemod, but additionally sends the
+// per-file diffs of each repository to resultChannel, if non-nil, as soon as
+// the replacer returns them.
+func performCodemodStream(ctx context.Context, args *search.TextParameters, resultChannel chan<- SearchEvent) ([]SearchResultResolver, *searchResultsCommon, error) {
 	cmodArgs, err := validateQuery(args.Query)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	title := fmt.Sprintf("pattern: %+v, replace: %+v, includeFileFilter: %+v, excludeFileFilter: %+v, numRepoRevs: %d", cmodArgs.matchTemplate, cmodArgs.rewriteTemplate, cmodArgs.includeFileFilter, cmodArgs.excludeFileFilter, len(args.Repos))
+	title := fmt.Sprintf("pattern: %+v, replace: %+v, includeFileFilter: %+v, excludeFileFilter: %+v, rule: %+v, matcher: %+v, numRepoRevs: %d", cmodArgs.matchTemplate, cmodArgs.rewriteTemplate, cmodArgs.includeFileFilter, cmodArgs.excludeFileFilter, cmodArgs.rule, cmodArgs.matcher, len(args.Repos))
 	tr, ctx := trace.New(ctx, "callCodemod", title)
 	defer func() {
 		tr.SetError(err)
@@ -183,13 +212,16 @@ func performCodemod(ctx context.Context, args *search.TextParameters) ([]SearchR
 			}
 			mu.Lock()
 			defer mu.Unlock()
-			if fatalErr := handleRepoSearchResult(common, repoRev, false, repoTimedOut, searchErr); fatalErr != nil {
+			repoCommon := &searchResultsCommon{}
+			if fatalErr := handleRepoSearchResult(repoCommon, repoRev, false, repoTimedOut, searchErr); fatalErr != nil {
 				err = errors.Wrapf(searchErr, "failed to call codemod %s", repoRev)
 				cancel()
 			}
+			common.update(*repoCommon)
 			if len(results) > 0 {
 				unflattened = append(unflattened, results)
 			}
+			sendSearchEvent(resultChannel, SearchEvent{Results: codemodResultsToSearchResults(results), Stats: streamingStats(repoCommon)})
 		})
 	}
 	wg.Wait()
@@ -199,15 +231,21 @@ func performCodemod(ctx context.Context, args *search.TextParameters) ([]SearchR
 
 	var results []SearchResultResolver
 	for _, ur := range unflattened {
-		for _, resolver := range ur {
-			v := resolver
-			results = append(results, &v)
-		}
+		results = append(results, codemodResultsToSearchResults(ur)...)
 	}
 
 	return results, common, nil
 }
 
+func codemodResultsToSearchResults(results []codemodResultResolver) []SearchResultResolver {
+	var results2 []SearchResultResolver
+	for _, resolver := range results {
+		v := resolver
+		results2 = append(results2, &v)
+	}
+	return results2
+}
+
 var ReplacerURL = env.Get("REPLACER_URL", "http://replacer:3185", "replacer server URL")
 
 func toMatchResolver(fileURL string, raw *rawCodemodResult) ([]*searchResultMatchResolver, error) {
@@ -251,6 +289,8 @@ func callCodemodInRepo(ctx context.Context, repoRevs *search.RepositoryRevisions
 	q.Set("rewritetemplate", args.rewriteTemplate)
 	q.Set("fileextension", args.includeFileFilter)
 	q.Set("directoryexclude", args.excludeFileFilter)
+	q.Set("rule", args.rule)
+	q.Set("matcher", args.matcher)
 	u.RawQuery = q.Encode()
 
 	req, err := http.NewRequest("GET", u.String(), nil)
@@ -328,3 +368,77 @@ func callCodemodInRepo(ctx context.Context, repoRevs *search.RepositoryRevisions
 
 	return results, nil
 }
+
+// codemodPatchResolver is a resolver for the GraphQL type `CodemodPatch`. It
+// combines the codemod results of a single repository revision.
+type codemodPatchResolver struct {
+	commit  *GitCommitResolver
+	results []*codemodResultResolver
+}
+
+func (r *codemodPatchResolver) Repository() *RepositoryResolver { return r.commit.repoResolver }
+
+func (r *codemodPatchResolver) BaseRevision() string { return string(r.commit.oid) }
+
+// BaseRef returns the ref that the codemod was run against. Revisions that
+// are not fully qualified refs are assumed to be branch names.
+func (r *codemodPatchResolver) BaseRef(ctx context.Context) (string, error) {
+	if rev := r.commit.inputRev; rev != nil && *rev != "" {
+		if strings.HasPrefix(*rev, "refs/") {
+			return *rev, nil
+		}
+		return "refs/heads/" + *rev, nil
+	}
+
+	ref, err := r.commit.repoResolver.DefaultBranch(ctx)
+	if err != nil {
+		return "", err
+	}
+	if ref == nil {
+		return "", errors.Errorf("repository %s has no default branch", r.commit.repoResolver.Name())
+	}
+	return ref.Name(), nil
+}
+
+func (r *codemodPatchResolver) Patch() string {
+	var b strings.Builder
+	for _, result := range r.results {
+		b.WriteString(result.diff)
+		if !strings.HasSuffix(result.diff, "\n") {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// CodemodPatches combines the codemod results into one patch per repository
+// revision, ordered by repository name.
+func (sr *SearchResultsResolver) CodemodPatches() []*codemodPatchResolver {
+	byCommit := map[string]*codemodPatchResolver{}
+	var patches []*codemodPatchResolver
+	for _, result := range sr.SearchResults {
+		codemodResult, ok := result.ToCodemodResult()
+		if !ok {
+			continue
+		}
+
+		key := codemodResult.commit.repoResolver.Name() + "@" + string(codemodResult.commit.oid)
+		patch, ok := byCommit[key]
+		if !ok {
+			patch = &codemodPatchResolver{commit: codemodResult.commit}
+			byCommit[key] = patch
+			patches = append(patches, patch)
+		}
+		patch.results = append(patch.results, codemodResult)
+	}
+
+	sort.Slice(patches, func(i, j int) bool {
+		return patches[i].Repository().Name() < patches[j].Repository().Name()
+	})
+	for _, patch := range patches {
+		sort.Slice(patch.results, func(i, j int) bool {
+			return patch.results[i].path < patch.results[j].path
+		})
+	}
+	return patches
+}
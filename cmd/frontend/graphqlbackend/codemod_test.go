@@ -1,9 +1,13 @@
 package graphqlbackend
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 )
 
@@ -26,6 +30,57 @@ func TestCodemod_validateArgsOk(t *testing.T) {
 	}
 }
 
+func TestCodemod_validateArgsRuleAndLanguage(t *testing.T) {
+	q, err := query.ParseAndCheck(`"fmt.Println(:[x])" rule:'where :[x] != "foo"' lang:go`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err := validateQuery(q)
+	if err != nil {
+		t.Fatalf("Expected query %v to to be OK", q)
+	}
+	if want := `where :[x] != "foo"`; args.rule != want {
+		t.Errorf("got rule %q, want %q", args.rule, want)
+	}
+	if want := ".go"; args.matcher != want {
+		t.Errorf("got matcher %q, want %q", args.matcher, want)
+	}
+}
+
+func TestCodemod_patches(t *testing.T) {
+	rev := "feature"
+	repoA := NewRepositoryResolver(&types.Repo{ID: 1, Name: "a"})
+	repoB := NewRepositoryResolver(&types.Repo{ID: 2, Name: "b"})
+	commitA := &GitCommitResolver{repoResolver: repoA, oid: "aaa", inputRev: &rev}
+	commitB := &GitCommitResolver{repoResolver: repoB, oid: "bbb"}
+
+	sr := &SearchResultsResolver{SearchResults: []SearchResultResolver{
+		&codemodResultResolver{commit: commitB, path: "main.go", diff: "--- b/main.go\n"},
+		&codemodResultResolver{commit: commitA, path: "z.go", diff: "--- z.go\n"},
+		&codemodResultResolver{commit: commitA, path: "a.go", diff: "--- a.go"},
+	}}
+
+	var got []string
+	for _, patch := range sr.CodemodPatches() {
+		got = append(got, patch.Repository().Name()+"@"+patch.BaseRevision()+":\n"+patch.Patch())
+	}
+	want := []string{
+		"a@aaa:\n--- a.go\n--- z.go\n",
+		"b@bbb:\n--- b/main.go\n",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected patches (-want +got):\n%s", diff)
+	}
+
+	ref, err := sr.CodemodPatches()[0].BaseRef(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "refs/heads/feature"; ref != want {
+		t.Errorf("got base ref %q, want %q", ref, want)
+	}
+}
+
 func TestCodemod_resolver(t *testing.T) {
 	raw := &rawCodemodResult{
 		URI:  "",
@@ -292,7 +292,10 @@ func (r *GitTreeEntryResolver) IsSingleChild(ctx context.Context, args *gitTreeE
 	return len(entries) == 1, nil
 }
 
-func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct{ ToolName *string }) (GitBlobLSIFDataResolver, error) {
+func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct {
+	ToolName  *string
+	OnlyExact bool
+}) (GitBlobLSIFDataResolver, error) {
 	codeIntelRequests.WithLabelValues(trace.RequestOrigin(ctx)).Inc()
 
 	var toolName string
@@ -306,6 +309,7 @@ func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct{ ToolName
 		Path:      r.Path(),
 		ExactPath: !r.stat.IsDir(),
 		ToolName:  toolName,
+		OnlyExact: args.OnlyExact,
 	})
 }
 
@@ -387,6 +387,11 @@ func (r *NodeResolver) ToPatchSet() (PatchSetResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToActionExecution() (ActionExecutionResolver, bool) {
+	n, ok := r.Node.(ActionExecutionResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToExternalChangeset() (ExternalChangesetResolver, bool) {
 	n, ok := r.Node.(ChangesetResolver)
 	if !ok {
@@ -486,6 +491,21 @@ func (r *NodeResolver) ToSavedSearch() (*savedSearchResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToSearchExportJob() (*searchExportJobResolver, bool) {
+	n, ok := r.Node.(*searchExportJobResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToSearchContext() (*searchContextResolver, bool) {
+	n, ok := r.Node.(*searchContextResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToSearchTrendSeries() (*searchTrendSeriesResolver, bool) {
+	n, ok := r.Node.(*searchTrendSeriesResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToSite() (*siteResolver, bool) {
 	n, ok := r.Node.(*siteResolver)
 	return n, ok
@@ -549,6 +569,8 @@ func (r *schemaResolver) nodeByID(ctx context.Context, id graphql.ID) (Node, err
 		return r.CampaignByID(ctx, id)
 	case "PatchSet":
 		return r.PatchSetByID(ctx, id)
+	case "ActionExecution":
+		return r.ActionExecutionByID(ctx, id)
 	case "ExternalChangeset":
 		return r.ChangesetByID(ctx, id)
 	case "HiddenExternalChangeset":
@@ -587,6 +609,12 @@ func (r *schemaResolver) nodeByID(ctx context.Context, id graphql.ID) (Node, err
 		return RegistryExtensionByID(ctx, id)
 	case "SavedSearch":
 		return savedSearchByID(ctx, id)
+	case "SearchContext":
+		return searchContextByID(ctx, id)
+	case "SearchExportJob":
+		return searchExportJobByID(ctx, id)
+	case "SearchTrendSeries":
+		return searchTrendSeriesByID(ctx, id)
 	case "Site":
 		return siteByGQLID(ctx, id)
 	case "LSIFUpload":
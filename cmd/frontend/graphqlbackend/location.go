@@ -13,11 +13,13 @@ type LocationResolver interface {
 	Range() *rangeResolver
 	URL(ctx context.Context) (string, error)
 	CanonicalURL() (string, error)
+	AdjustmentConfidence() *string
 }
 
 type locationResolver struct {
-	resource *GitTreeEntryResolver
-	lspRange *lsp.Range
+	resource   *GitTreeEntryResolver
+	lspRange   *lsp.Range
+	confidence *string
 }
 
 var _ LocationResolver = &locationResolver{}
@@ -29,6 +31,16 @@ func NewLocationResolver(resource *GitTreeEntryResolver, lspRange *lsp.Range) Lo
 	}
 }
 
+// NewAdjustedLocationResolver creates a location resolver for a range that was translated from
+// a different commit with the given confidence (one of the PositionAdjustmentConfidence values).
+func NewAdjustedLocationResolver(resource *GitTreeEntryResolver, lspRange *lsp.Range, confidence string) LocationResolver {
+	return &locationResolver{
+		resource:   resource,
+		lspRange:   lspRange,
+		confidence: &confidence,
+	}
+}
+
 func (r *locationResolver) Resource() *GitTreeEntryResolver { return r.resource }
 
 func (r *locationResolver) Range() *rangeResolver {
@@ -38,6 +50,8 @@ func (r *locationResolver) Range() *rangeResolver {
 	return &rangeResolver{*r.lspRange}
 }
 
+func (r *locationResolver) AdjustmentConfidence() *string { return r.confidence }
+
 func (r *locationResolver) URL(ctx context.Context) (string, error) {
 	url, err := r.resource.URL(ctx)
 	if err != nil {
@@ -279,6 +279,10 @@ func (r *RepositoryResolver) ToCodemodResult() (*codemodResultResolver, bool) {
 	return nil, false
 }
 
+func (r *RepositoryResolver) ToCaptureGroupResult() (*captureGroupResultResolver, bool) {
+	return nil, false
+}
+
 func (r *RepositoryResolver) searchResultURIs() (string, string) {
 	return string(r.repo.Name), ""
 }
@@ -316,6 +320,24 @@ func (r *RepositoryResolver) LSIFUploads(ctx context.Context, args *LSIFUploadsQ
 	})
 }
 
+func (r *RepositoryResolver) LSIFUploadRetentionPreview(ctx context.Context) ([]LSIFUploadExpirationResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.LSIFUploadRetentionPreview(ctx, r.ID())
+}
+
+func (r *RepositoryResolver) LSIFPackageDependents(ctx context.Context, args *LSIFPackageDependentsQueryArgs) (LSIFPackageUsageConnectionResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.LSIFPackageDependents(ctx, &LSIFRepositoryPackageDependentsQueryArgs{
+		LSIFPackageDependentsQueryArgs: args,
+		RepositoryID:                   r.ID(),
+	})
+}
+
+func (r *RepositoryResolver) LSIFPackageDependencies(ctx context.Context, args *LSIFPackageDependenciesQueryArgs) (LSIFPackageUsageConnectionResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.LSIFPackageDependencies(ctx, &LSIFRepositoryPackageDependenciesQueryArgs{
+		LSIFPackageDependenciesQueryArgs: args,
+		RepositoryID:                     r.ID(),
+	})
+}
+
 func (r *RepositoryResolver) LSIFIndexes(ctx context.Context, args *LSIFIndexesQueryArgs) (LSIFIndexConnectionResolver, error) {
 	return EnterpriseResolvers.codeIntelResolver.LSIFIndexesByRepo(ctx, &LSIFRepositoryIndexesQueryArgs{
 		LSIFIndexesQueryArgs: args,
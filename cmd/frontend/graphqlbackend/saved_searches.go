@@ -3,6 +3,8 @@ package graphqlbackend
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
 
 	graphql "github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -59,6 +61,8 @@ func savedSearchByID(ctx context.Context, id graphql.ID) (*savedSearchResolver,
 			UserID:          ss.Config.UserID,
 			OrgID:           ss.Config.OrgID,
 			SlackWebhookURL: ss.Config.SlackWebhookURL,
+			NotifyWebhook:   ss.Config.NotifyWebhook,
+			WebhookURL:      ss.Config.WebhookURL,
 		},
 	}
 	return savedSearch, nil
@@ -100,6 +104,75 @@ func (r savedSearchResolver) Namespace(ctx context.Context) (*NamespaceResolver,
 
 func (r savedSearchResolver) SlackWebhookURL() *string { return r.s.SlackWebhookURL }
 
+func (r savedSearchResolver) NotifyWebhook() bool { return r.s.NotifyWebhook }
+
+func (r savedSearchResolver) WebhookURL() *string { return r.s.WebhookURL }
+
+func (r savedSearchResolver) WebhookDeliveries(ctx context.Context, args *struct {
+	First int32
+}) ([]*savedSearchWebhookDeliveryResolver, error) {
+	// 🚨 SECURITY: Only the owners of the saved search may see its webhook deliveries.
+	if r.s.UserID != nil {
+		if err := backend.CheckSiteAdminOrSameUser(ctx, *r.s.UserID); err != nil {
+			return nil, err
+		}
+	} else if r.s.OrgID != nil {
+		if err := backend.CheckOrgAccess(ctx, *r.s.OrgID); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, errors.New("failed to get webhook deliveries: no Org ID or User ID associated with saved search")
+	}
+
+	first := int(args.First)
+	if first < 0 {
+		first = 0
+	}
+	if first > db.MaxSavedSearchWebhookDeliveries {
+		first = db.MaxSavedSearchWebhookDeliveries
+	}
+
+	deliveries, err := db.SavedSearches.ListWebhookDeliveries(ctx, r.s.ID, first)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*savedSearchWebhookDeliveryResolver, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		resolvers = append(resolvers, &savedSearchWebhookDeliveryResolver{d: delivery})
+	}
+	return resolvers, nil
+}
+
+type savedSearchWebhookDeliveryResolver struct {
+	d *types.SavedSearchWebhookDelivery
+}
+
+func (r *savedSearchWebhookDeliveryResolver) URL() string { return r.d.URL }
+
+func (r *savedSearchWebhookDeliveryResolver) Attempt() int32 { return r.d.Attempt }
+
+func (r *savedSearchWebhookDeliveryResolver) StatusCode() *int32 {
+	if r.d.StatusCode == 0 {
+		return nil
+	}
+	return &r.d.StatusCode
+}
+
+func (r *savedSearchWebhookDeliveryResolver) Error() *string {
+	if r.d.Error == "" {
+		return nil
+	}
+	return &r.d.Error
+}
+
+func (r *savedSearchWebhookDeliveryResolver) DurationMs() int32 {
+	return int32(r.d.Duration.Milliseconds())
+}
+
+func (r *savedSearchWebhookDeliveryResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.d.CreatedAt}
+}
+
 func toSavedSearchResolver(entry types.SavedSearch) *savedSearchResolver {
 	return &savedSearchResolver{entry}
 }
@@ -145,12 +218,15 @@ func (r *schemaResolver) SendSavedSearchTestNotification(ctx context.Context, ar
 }
 
 func (r *schemaResolver) CreateSavedSearch(ctx context.Context, args *struct {
-	Description string
-	Query       string
-	NotifyOwner bool
-	NotifySlack bool
-	OrgID       *graphql.ID
-	UserID      *graphql.ID
+	Description   string
+	Query         string
+	NotifyOwner   bool
+	NotifySlack   bool
+	NotifyWebhook bool
+	WebhookURL    *string
+	WebhookSecret *string
+	OrgID         *graphql.ID
+	UserID        *graphql.ID
 }) (*savedSearchResolver, error) {
 	var userID, orgID *int32
 	// 🚨 SECURITY: Make sure the current user has permission to create a saved search for the specified user or org.
@@ -180,13 +256,20 @@ func (r *schemaResolver) CreateSavedSearch(ctx context.Context, args *struct {
 		return nil, errMissingPatternType
 	}
 
+	if err := validateWebhookURL(args.NotifyWebhook, args.WebhookURL); err != nil {
+		return nil, err
+	}
+
 	ss, err := db.SavedSearches.Create(ctx, &types.SavedSearch{
-		Description: args.Description,
-		Query:       args.Query,
-		Notify:      args.NotifyOwner,
-		NotifySlack: args.NotifySlack,
-		UserID:      userID,
-		OrgID:       orgID,
+		Description:   args.Description,
+		Query:         args.Query,
+		Notify:        args.NotifyOwner,
+		NotifySlack:   args.NotifySlack,
+		NotifyWebhook: args.NotifyWebhook,
+		WebhookURL:    args.WebhookURL,
+		WebhookSecret: args.WebhookSecret,
+		UserID:        userID,
+		OrgID:         orgID,
 	})
 	if err != nil {
 		return nil, err
@@ -196,13 +279,16 @@ func (r *schemaResolver) CreateSavedSearch(ctx context.Context, args *struct {
 }
 
 func (r *schemaResolver) UpdateSavedSearch(ctx context.Context, args *struct {
-	ID          graphql.ID
-	Description string
-	Query       string
-	NotifyOwner bool
-	NotifySlack bool
-	OrgID       *graphql.ID
-	UserID      *graphql.ID
+	ID            graphql.ID
+	Description   string
+	Query         string
+	NotifyOwner   bool
+	NotifySlack   bool
+	NotifyWebhook bool
+	WebhookURL    *string
+	WebhookSecret *string
+	OrgID         *graphql.ID
+	UserID        *graphql.ID
 }) (*savedSearchResolver, error) {
 	var userID, orgID *int32
 	// 🚨 SECURITY: Make sure the current user has permission to update a saved search for the specified user or org.
@@ -237,14 +323,21 @@ func (r *schemaResolver) UpdateSavedSearch(ctx context.Context, args *struct {
 		return nil, errMissingPatternType
 	}
 
+	if err := validateWebhookURL(args.NotifyWebhook, args.WebhookURL); err != nil {
+		return nil, err
+	}
+
 	ss, err := db.SavedSearches.Update(ctx, &types.SavedSearch{
-		ID:          id,
-		Description: args.Description,
-		Query:       args.Query,
-		Notify:      args.NotifyOwner,
-		NotifySlack: args.NotifySlack,
-		UserID:      userID,
-		OrgID:       orgID,
+		ID:            id,
+		Description:   args.Description,
+		Query:         args.Query,
+		Notify:        args.NotifyOwner,
+		NotifySlack:   args.NotifySlack,
+		NotifyWebhook: args.NotifyWebhook,
+		WebhookURL:    args.WebhookURL,
+		WebhookSecret: args.WebhookSecret,
+		UserID:        userID,
+		OrgID:         orgID,
 	})
 	if err != nil {
 		return nil, err
@@ -290,3 +383,19 @@ func queryHasPatternType(query string) bool {
 }
 
 var errMissingPatternType error = errors.New("a `patternType:` filter is required in the query for all saved searches. `patternType` can be \"literal\" or \"regexp\"")
+
+// validateWebhookURL checks that webhookURL is a valid HTTP(S) URL, if set. It
+// is required if notifyWebhook is true.
+func validateWebhookURL(notifyWebhook bool, webhookURL *string) error {
+	if webhookURL == nil || *webhookURL == "" {
+		if notifyWebhook {
+			return errors.New("a webhook URL is required to enable webhook notifications")
+		}
+		return nil
+	}
+	u, err := url.Parse(*webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q: must be an absolute http or https URL", *webhookURL)
+	}
+	return nil
+}
@@ -61,12 +61,15 @@ func TestCreateSavedSearch(t *testing.T) {
 	}
 	userID := MarshalUserID(key)
 	savedSearches, err := (&schemaResolver{}).CreateSavedSearch(ctx, &struct {
-		Description string
-		Query       string
-		NotifyOwner bool
-		NotifySlack bool
-		OrgID       *graphql.ID
-		UserID      *graphql.ID
+		Description   string
+		Query         string
+		NotifyOwner   bool
+		NotifySlack   bool
+		NotifyWebhook bool
+		WebhookURL    *string
+		WebhookSecret *string
+		OrgID         *graphql.ID
+		UserID        *graphql.ID
 	}{Description: "test query", Query: "test type:diff patternType:regexp", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err != nil {
 		t.Fatal(err)
@@ -91,18 +94,76 @@ func TestCreateSavedSearch(t *testing.T) {
 
 	// Ensure create saved search errors when patternType is not provided in the query.
 	_, err = (&schemaResolver{}).CreateSavedSearch(ctx, &struct {
-		Description string
-		Query       string
-		NotifyOwner bool
-		NotifySlack bool
-		OrgID       *graphql.ID
-		UserID      *graphql.ID
+		Description   string
+		Query         string
+		NotifyOwner   bool
+		NotifySlack   bool
+		NotifyWebhook bool
+		WebhookURL    *string
+		WebhookSecret *string
+		OrgID         *graphql.ID
+		UserID        *graphql.ID
 	}{Description: "test query", Query: "test type:diff", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err == nil {
 		t.Error("Expected error for createSavedSearch when query does not provide a patternType: field.")
 	}
 }
 
+func TestCreateSavedSearchWebhook(t *testing.T) {
+	ctx := context.Background()
+	defer resetMocks()
+
+	key := int32(1)
+	var created *types.SavedSearch
+	db.Mocks.SavedSearches.Create = func(ctx context.Context, newSavedSearch *types.SavedSearch) (*types.SavedSearch, error) {
+		created = newSavedSearch
+		return newSavedSearch, nil
+	}
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true, ID: key}, nil
+	}
+	userID := MarshalUserID(key)
+
+	create := func(notifyWebhook bool, webhookURL, webhookSecret *string) error {
+		_, err := (&schemaResolver{}).CreateSavedSearch(ctx, &struct {
+			Description   string
+			Query         string
+			NotifyOwner   bool
+			NotifySlack   bool
+			NotifyWebhook bool
+			WebhookURL    *string
+			WebhookSecret *string
+			OrgID         *graphql.ID
+			UserID        *graphql.ID
+		}{Description: "test query", Query: "test type:diff patternType:regexp", NotifyWebhook: notifyWebhook, WebhookURL: webhookURL, WebhookSecret: webhookSecret, UserID: &userID})
+		return err
+	}
+
+	if err := create(true, nil, nil); err == nil {
+		t.Error("Expected error for createSavedSearch when webhook notifications are enabled without a webhook URL.")
+	}
+	invalidURL := "ftp://example.com/hook"
+	if err := create(true, &invalidURL, nil); err == nil {
+		t.Error("Expected error for createSavedSearch when the webhook URL is not an http(s) URL.")
+	}
+
+	webhookURL, webhookSecret := "https://example.com/hook", "s3cr3t"
+	if err := create(true, &webhookURL, &webhookSecret); err != nil {
+		t.Fatal(err)
+	}
+	want := &types.SavedSearch{
+		Description:   "test query",
+		Query:         "test type:diff patternType:regexp",
+		NotifyWebhook: true,
+		WebhookURL:    &webhookURL,
+		WebhookSecret: &webhookSecret,
+		UserID:        &key,
+	}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("got %+v, want %+v", created, want)
+	}
+}
+
 func TestUpdateSavedSearch(t *testing.T) {
 	ctx := context.Background()
 	defer resetMocks()
@@ -119,13 +180,16 @@ func TestUpdateSavedSearch(t *testing.T) {
 	}
 	userID := MarshalUserID(key)
 	savedSearches, err := (&schemaResolver{}).UpdateSavedSearch(ctx, &struct {
-		ID          graphql.ID
-		Description string
-		Query       string
-		NotifyOwner bool
-		NotifySlack bool
-		OrgID       *graphql.ID
-		UserID      *graphql.ID
+		ID            graphql.ID
+		Description   string
+		Query         string
+		NotifyOwner   bool
+		NotifySlack   bool
+		NotifyWebhook bool
+		WebhookURL    *string
+		WebhookSecret *string
+		OrgID         *graphql.ID
+		UserID        *graphql.ID
 	}{ID: marshalSavedSearchID(key), Description: "updated query description", Query: "test type:diff patternType:regexp", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err != nil {
 		t.Fatal(err)
@@ -151,13 +215,16 @@ func TestUpdateSavedSearch(t *testing.T) {
 
 	// Ensure update saved search errors when patternType is not provided in the query.
 	_, err = (&schemaResolver{}).UpdateSavedSearch(ctx, &struct {
-		ID          graphql.ID
-		Description string
-		Query       string
-		NotifyOwner bool
-		NotifySlack bool
-		OrgID       *graphql.ID
-		UserID      *graphql.ID
+		ID            graphql.ID
+		Description   string
+		Query         string
+		NotifyOwner   bool
+		NotifySlack   bool
+		NotifyWebhook bool
+		WebhookURL    *string
+		WebhookSecret *string
+		OrgID         *graphql.ID
+		UserID        *graphql.ID
 	}{ID: marshalSavedSearchID(key), Description: "updated query description", Query: "test type:diff", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err == nil {
 		t.Error("Expected error for updateSavedSearch when query does not provide a patternType: field.")
@@ -195,3 +262,28 @@ func TestDeleteSavedSearch(t *testing.T) {
 		t.Errorf("Database method db.SavedSearches.Delete not called")
 	}
 }
+
+func TestSavedSearchWebhookDeliveriesFirst(t *testing.T) {
+	ctx := context.Background()
+	defer resetMocks()
+
+	key := int32(1)
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true, ID: key}, nil
+	}
+	var limit int
+	db.Mocks.SavedSearches.ListWebhookDeliveries = func(ctx context.Context, savedSearchID int32, l int) ([]*types.SavedSearchWebhookDelivery, error) {
+		limit = l
+		return nil, nil
+	}
+
+	r := savedSearchResolver{types.SavedSearch{ID: key, UserID: &key}}
+	for first, want := range map[int32]int{-5: 0, 20: 20, 1000: db.MaxSavedSearchWebhookDeliveries} {
+		if _, err := r.WebhookDeliveries(ctx, &struct{ First int32 }{First: first}); err != nil {
+			t.Fatal(err)
+		}
+		if limit != want {
+			t.Errorf("first %d: got limit %d, want %d", first, limit, want)
+		}
+	}
+}
@@ -47,6 +47,25 @@ type Mutation {
         # created from this PatchSet.
         patches: [PatchInput!]!
     ): PatchSet!
+    # Execute a campaign action on the server, as an alternative to executing it with src-cli and
+    # calling createPatchSetFromPatches. The action's scope query is run as the current user, and
+    # the action's steps are run in the background in each matched repository. Once all
+    # repositories have been processed, the resulting diffs are combined into the action
+    # execution's patchset, which can be used to create a campaign.
+    #
+    # Requires the campaigns.actionExecution.enabled site configuration property to be set. Only
+    # site admins may perform this mutation.
+    createActionExecution(
+        # The action definition, in the same format as the action files executed by src-cli. For
+        # example: {"scopeQuery": "repohasfile:go.mod", "steps": [{"type": "docker", "image":
+        # "golang:1.14-alpine", "args": ["gofmt", "-w", "."]}]}
+        definition: JSONCString!
+    ): ActionExecution!
+    # Run the failed jobs of a finished action execution again. Once they have finished, a new
+    # patchset is created for the action execution.
+    #
+    # Only site admins may perform this mutation.
+    retryActionExecution(actionExecution: ID!): ActionExecution!
     # Updates a campaign. Updating is not allowed when any of the following are true:
     #
     # - The campaign has been closed.
@@ -374,6 +393,12 @@ type Mutation {
         query: String!
         notifyOwner: Boolean!
         notifySlack: Boolean!
+        # Whether or not to post notifications to webhookURL.
+        notifyWebhook: Boolean = false
+        # The URL that notifications are posted to.
+        webhookURL: String
+        # The secret used to sign the notifications posted to webhookURL.
+        webhookSecret: String
         orgID: ID
         userID: ID
     ): SavedSearch!
@@ -384,11 +409,64 @@ type Mutation {
         query: String!
         notifyOwner: Boolean!
         notifySlack: Boolean!
+        # Whether or not to post notifications to webhookURL.
+        notifyWebhook: Boolean = false
+        # The URL that notifications are posted to.
+        webhookURL: String
+        # The secret used to sign the notifications posted to webhookURL. If omitted, the existing secret is
+        # kept. An empty string removes the secret.
+        webhookSecret: String
         orgID: ID
         userID: ID
     ): SavedSearch!
     # Deletes a saved search
     deleteSavedSearch(id: ID!): EmptyResponse
+    # Creates a search trend series for the current user. The number of matches of the query is recorded
+    # in the background for every interval since start, in each repository that the query matches, by
+    # searching the last commit before each point in time. New points are recorded as time passes.
+    createSearchTrendSeries(
+        # The search query. It must contain a patternType: filter and must not specify revisions.
+        query: String!
+        # The time of the first point of the series. It must be in the past.
+        start: DateTime!
+        # The time between two points of the series.
+        interval: SearchTrendInterval!
+    ): SearchTrendSeries!
+    # Deletes a search trend series and its recorded points.
+    deleteSearchTrendSeries(id: ID!): EmptyResponse
+    # Creates a search export job for the current user. All the results of the query are written to a
+    # file in the background, following pagination cursors until the search is exhausted. Only text
+    # matches are exported.
+    createSearchExportJob(
+        # The search query. It must contain a patternType: filter.
+        query: String!
+        # The format of the export file.
+        format: SearchExportFormat = JSON_LINES
+    ): SearchExportJob!
+    # Deletes a search export job and its file. If the job is processing, it is stopped.
+    deleteSearchExportJob(id: ID!): EmptyResponse
+    # Creates a search context. Users can create search contexts in their own namespace, organization
+    # members in the namespace of the organization, and site admins in any namespace or instance-wide.
+    createSearchContext(
+        # The search context to create.
+        searchContext: SearchContextInput!
+        # The repository revisions of the search context. It must be empty if the search context has a
+        # query, and non-empty otherwise.
+        repositories: [SearchContextRepositoryRevisionsInput!]!
+    ): SearchContext!
+    # Updates a search context and replaces its repository revisions. The namespace of a search context
+    # cannot be changed.
+    updateSearchContext(
+        # The ID of the search context to update.
+        id: ID!
+        # The new values of the search context.
+        searchContext: SearchContextEditInput!
+        # The repository revisions of the search context. It must be empty if the search context has a
+        # query, and non-empty otherwise.
+        repositories: [SearchContextRepositoryRevisionsInput!]!
+    ): SearchContext!
+    # Deletes a search context.
+    deleteSearchContext(id: ID!): EmptyResponse
 
     # (experimental) The LSIF API may change substantially in the near future as we
     # continue to adjust it for our use cases. Changes will not be documented in the
@@ -517,6 +595,80 @@ type PatchSet implements Node {
     diffStat: DiffStat!
 }
 
+# A server-side execution of a campaign action, which runs the action's steps in every repository
+# matched by its scope query.
+type ActionExecution implements Node {
+    # The unique ID of this action execution.
+    id: ID!
+
+    # The action definition that is executed.
+    definition: JSONCString!
+
+    # The status of the execution of the action's jobs.
+    status: BackgroundProcessStatus!
+
+    # The jobs that execute the action, one per repository.
+    jobs(first: Int): ActionJobConnection!
+
+    # The patchset created from the diffs of the jobs. It is null until all jobs have finished or
+    # if none of the jobs produced a diff.
+    patchSet: PatchSet
+
+    # The date and time when the action execution was created.
+    createdAt: DateTime!
+
+    # The date and time when all jobs of the action execution finished.
+    finishedAt: DateTime
+}
+
+# A list of action jobs.
+type ActionJobConnection {
+    # A list of action jobs.
+    nodes: [ActionJob!]!
+
+    # The total number of action jobs in the connection.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# The state of an action job.
+enum ActionJobState {
+    # The job is waiting to be run.
+    QUEUED
+    # The job is being run.
+    PROCESSING
+    # The job failed on its last attempt.
+    ERRORED
+    # The job successfully completed.
+    COMPLETED
+}
+
+# The execution of an action's steps in a single repository.
+type ActionJob {
+    # The repository the steps are run in.
+    repository: Repository!
+
+    # The state of the job.
+    state: ActionJobState!
+
+    # How many times the job has been run. Failing jobs are retried a limited number of times.
+    attempts: Int!
+
+    # The output of the steps of the last attempt.
+    log: String
+
+    # The error of the last attempt, if it failed.
+    error: String
+
+    # The date and time when the current attempt started.
+    startedAt: DateTime
+
+    # The date and time when the job finished.
+    finishedAt: DateTime
+}
+
 # A paginated list of repository diffs committed to git.
 type RepositoryComparisonConnection {
     # A list of repository diffs committed to git.
@@ -1250,9 +1402,20 @@ type Query {
     ): Search
     # All saved searches configured for the current user, merged from all configurations.
     savedSearches: [SavedSearch!]!
+    # The search trend series of the current user.
+    searchTrendSeries: [SearchTrendSeries!]!
+    # The search export jobs of the current user, most recent first.
+    searchExportJobs: [SearchExportJob!]!
     # All repository groups for the current user, merged from all configurations.
     repoGroups: [RepoGroup!]!
+    # The search contexts visible to the current user.
+    searchContexts(
+        # Returns only the search contexts owned by this user or organization.
+        namespace: ID
+    ): [SearchContext!]!
     # (experimental) All version contexts.
+    #
+    # Deprecated: use search contexts, which are stored in the database and can be created by users.
     versionContexts: [VersionContext!]!
     # The current site.
     site: Site!
@@ -1386,6 +1549,142 @@ type Search {
     # cached and thus quicker to query. Useful for e.g. querying sparkline
     # data.
     stats: SearchResultsStats!
+    # Counts of the matches of the search, grouped by the given dimension. The
+    # search is run exhaustively (as if a large "count:" was given) in the
+    # background. The first request starts it and returns its progress so far;
+    # subsequent requests for the same query and dimension return the progress
+    # of the running search until its state is COMPLETED or ERRORED. Finished
+    # aggregations are kept for an hour.
+    aggregation(
+        # The dimension to group matches by.
+        by: SearchAggregationDimension!
+        # The index of the capture group of the search pattern whose value
+        # matches are grouped by. Only used when grouping by CAPTURE_GROUP.
+        captureGroup: Int = 1
+    ): SearchAggregation!
+    # An explanation of how the search is planned and executed: the query
+    # after it was parsed and transformed, the repositories it resolved to and
+    # the calls made to each search backend. The search is run again for the
+    # explanation, separately from the one of the results field, without
+    # streaming or pagination.
+    explain: SearchExplanation!
+}
+
+# An explanation of how a search was planned and executed, for debugging slow
+# searches or surprising results.
+type SearchExplanation {
+    # The query after it was parsed and transformed (for example after field
+    # aliases were substituted and patterns were hoisted). Queries with and/or
+    # expressions are printed as S-expressions.
+    parseTree: String!
+    # The repositories and revisions that the query resolved to.
+    repositories: [SearchExplanationRepository!]!
+    # The repositories that the query resolved to, but whose revisions don't
+    # exist. They are not searched.
+    missingRepositories: [SearchExplanationRepository!]!
+    # The calls made to the text search backends, in the order they started.
+    # Repository, symbol, commit and diff searches are not included.
+    backends: [SearchBackendExplanation!]!
+    # Repositories that were skipped because they are still being cloned.
+    cloning: [Repository!]!
+    # Repositories that were skipped because they don't exist, or that were
+    # not searched because of limits on unindexed search.
+    missing: [Repository!]!
+    # Repositories whose search timed out.
+    timedout: [Repository!]!
+    # The search alert, if any. When an alert is returned, the search may not
+    # have reached the backends.
+    alert: SearchAlert
+    # The duration of the whole search, in milliseconds.
+    durationMilliseconds: Int!
+}
+
+# A repository and the revisions of it that a search resolved to.
+type SearchExplanationRepository {
+    # The repository.
+    repository: Repository!
+    # The revision specifiers to search, such as "main" or "*refs/heads/*". An
+    # empty string is the default branch.
+    revisions: [String!]!
+}
+
+# A backend that performs text searches.
+enum SearchBackend {
+    # Indexed search, for the default branch of indexed repositories.
+    ZOEKT
+    # Unindexed search, for other repositories and revisions.
+    SEARCHER
+}
+
+# A call of a search backend over a set of repositories.
+type SearchBackendExplanation {
+    # The backend.
+    backend: SearchBackend!
+    # The repositories and revisions that were sent to the backend.
+    repositories: [SearchExplanationRepository!]!
+    # The pattern sent to the backend. For structural searches, the include
+    # patterns sent to searcher are narrowed to the files in which zoekt found
+    # candidate matches.
+    patternInfo: JSONValue!
+    # The query sent to zoekt, or null for searcher.
+    zoektQuery: String
+    # The time from the start of the call until its last search finished, in
+    # milliseconds. Searcher is called once per repository revision.
+    durationMilliseconds: Int!
+}
+
+# A dimension that search matches can be grouped by.
+enum SearchAggregationDimension {
+    # The name of the repository of the match.
+    REPOSITORY
+    # The repository and path of the file of the match.
+    PATH
+    # The repository and directory of the file of the match.
+    DIRECTORY
+    # The extension of the file of the match, such as ".go". Files without an
+    # extension are grouped under the empty string.
+    FILE_EXTENSION
+    # The author of the commit of the match. Only commit and diff matches
+    # (type:commit and type:diff) are grouped.
+    AUTHOR
+    # The value of a capture group of the search pattern in the match. Only
+    # regexp searches with a single pattern can be grouped by capture group.
+    CAPTURE_GROUP
+}
+
+# The state of a search aggregation.
+enum SearchAggregationState {
+    # The search is running.
+    PROCESSING
+    # The search failed.
+    ERRORED
+    # The search completed and all of its matches were grouped.
+    COMPLETED
+}
+
+# Counts of the matches of a search, grouped by a dimension.
+type SearchAggregation {
+    # The state of the search.
+    state: SearchAggregationState!
+    # The number of matches that have been grouped so far.
+    matchCount: Int!
+    # Whether the search hit a limit and the groups are not exhaustive.
+    limitHit: Boolean!
+    # The error that the search failed with, if any.
+    error: String
+    # The groups found so far, ordered by descending count.
+    groups(
+        # Returns the first n groups.
+        first: Int
+    ): [SearchAggregationGroup!]!
+}
+
+# A group of search matches that share the same value for a dimension.
+type SearchAggregationGroup {
+    # The value of the dimension shared by the matches.
+    label: String!
+    # The number of matches in the group.
+    count: Int!
 }
 
 # Predefined suggestions for search filters when backfill.
@@ -1397,7 +1696,7 @@ type SearchFilterSuggestions {
 }
 
 # A search result.
-union SearchResult = FileMatch | CommitSearchResult | Repository | CodemodResult
+union SearchResult = FileMatch | CommitSearchResult | Repository | CodemodResult | CaptureGroupResult
 
 # An object representing a markdown string.
 type Markdown {
@@ -1464,6 +1763,10 @@ type SearchResults {
     limitHit: Boolean!
     # Integers representing the sparkline for the search results.
     sparkline: [Int!]!
+    # The results of a search-and-replace query (a query with the 'replace:' field), combined
+    # into one patch per repository. The patches can be passed to createPatchSetFromPatches to
+    # create a campaign from the replacements.
+    codemodPatches: [CodemodPatch!]!
     # Repositories that were eligible to be searched.
     repositories: [Repository!]!
     # The number of repositories that were eligible to be searched (for clients
@@ -1577,6 +1880,125 @@ type SavedSearch implements Node {
     namespace: Namespace!
     # The Slack webhook URL associated with this saved search, if any.
     slackWebhookURL: String
+    # Whether or not to post notifications to webhookURL.
+    notifyWebhook: Boolean!
+    # The URL that notifications are posted to, if any.
+    webhookURL: String
+    # The most recent attempts to post a notification to webhookURL, newest first. Only the 100 most recent
+    # attempts are retained.
+    webhookDeliveries(
+        # Returns the first n deliveries from the list (at most 100).
+        first: Int = 20
+    ): [SavedSearchWebhookDelivery!]!
+}
+
+# An attempt to post a notification to the webhook of a saved search.
+#
+# Notifications are JSON documents posted with the header "X-Sourcegraph-Signature: sha256=<signature>",
+# where the signature is the hex-encoded HMAC-SHA256 of the request body keyed with the webhook secret.
+type SavedSearchWebhookDelivery {
+    # The URL that the notification was posted to.
+    url: String!
+    # The 1-based number of the delivery attempt. Failed deliveries are retried.
+    attempt: Int!
+    # The HTTP status code of the response, if a response was received.
+    statusCode: Int
+    # Why the delivery failed, if it did.
+    error: String
+    # How long the delivery attempt took, in milliseconds.
+    durationMs: Int!
+    # When the delivery was attempted.
+    createdAt: DateTime!
+}
+
+# The time between two points of a search trend series.
+enum SearchTrendInterval {
+    DAY
+    WEEK
+    MONTH
+}
+
+# A search query whose number of matches is recorded at regular intervals of the commit history of the
+# repositories it matches.
+type SearchTrendSeries implements Node {
+    # The unique ID of the series.
+    id: ID!
+    # The search query.
+    query: String!
+    # The time of the first point.
+    start: DateTime!
+    # The time between two points.
+    interval: SearchTrendInterval!
+    # The points of the series recorded so far, ordered by time. Points are recorded in the
+    # background, so recent series may be incomplete.
+    points(
+        # If set, only the matches in this repository are counted.
+        repository: ID
+    ): [SearchTrendPoint!]!
+    # When the series was created.
+    createdAt: DateTime!
+}
+
+# The number of matches of a search trend series at a point in time.
+type SearchTrendPoint {
+    # The point in time.
+    time: DateTime!
+    # The number of matches, summed across repositories.
+    count: Int!
+    # The number of repositories whose matches have been recorded for this point.
+    repositoryCount: Int!
+}
+
+# The file format of a search export.
+enum SearchExportFormat {
+    # One JSON object per line, with the fields repository, revision, path, lineNumber and preview.
+    JSON_LINES
+    # Comma-separated values with a header row and the columns repository, revision, path, lineNumber and
+    # preview.
+    CSV
+}
+
+# The state of a search export job.
+enum SearchExportJobState {
+    # The job is waiting to be processed.
+    QUEUED
+    # The search is running and its results are being written to the export file.
+    PROCESSING
+    # All the results have been written and the export file can be downloaded.
+    COMPLETED
+    # The search failed. The failure message describes why.
+    ERRORED
+}
+
+# A search query whose results are exhaustively written to a file in the background.
+type SearchExportJob implements Node {
+    # The unique ID of the job.
+    id: ID!
+    # The search query.
+    query: String!
+    # The format of the export file.
+    format: SearchExportFormat!
+    # The state of the job.
+    state: SearchExportJobState!
+    # The number of results written to the export file so far. A result is a line match.
+    resultCount: Int!
+    # The number of repositories searched so far.
+    repositoriesSearched: Int!
+    # The size of the export file in bytes so far.
+    byteSize: Float!
+    # Why the job failed, if it did.
+    failureMessage: String
+    # The URL where the export file can be downloaded by the owner of the job, when the job is
+    # completed.
+    downloadURL: String
+    # When the job was created.
+    createdAt: DateTime!
+    # When the job started processing.
+    startedAt: DateTime
+    # When the job completed or failed.
+    finishedAt: DateTime
+    # When the job and its export file will be deleted, once the job is finished.
+    expiresAt: DateTime
 }
 
 # A search query description.
@@ -1645,6 +2067,38 @@ type CodemodResult implements GenericSearchResultInterface {
     rawDiff: String!
 }
 
+# A distinct value of a capture group of the search pattern, returned by queries with a
+# "select:group.<number or name>" filter.
+type CaptureGroupResult implements GenericSearchResultInterface {
+    # URL to an icon that is displayed with every search result.
+    icon: String!
+    # A markdown string that is rendered prominently.
+    label: Markdown!
+    # The URL of the first example match.
+    url: String!
+    # A markdown string that is rendered less prominently.
+    detail: Markdown!
+    # Example matches of the capture group with this value (at most 5).
+    matches: [SearchResultMatch!]!
+    # The value of the capture group.
+    value: String!
+    # The number of times the capture group matched this value.
+    count: Int!
+}
+
+# The replacements of a search-and-replace query in a single repository. Its fields match those
+# of PatchInput.
+type CodemodPatch {
+    # The repository that this patch is applied to.
+    repository: Repository!
+    # The base revision in the repository that this patch is based on.
+    baseRevision: String!
+    # The reference to the base revision (e.g., "refs/heads/master").
+    baseRef: String!
+    # The patch (in unified diff format, without 'a/' and 'b/' prefixes) to apply.
+    patch: String!
+}
+
 # A search result that is a diff between two diffable Git objects.
 type DiffSearchResult {
     # The diff that matched the search query.
@@ -1919,6 +2373,67 @@ type Repository implements Node & GenericSearchResultInterface {
         after: String
     ): LSIFIndexConnection!
 
+    # (experimental) The LSIF API may change substantially in the near future as we
+    # continue to adjust it for our use cases. Changes will not be documented in the
+    # CHANGELOG during this time.
+    # The repository's LSIF uploads that would be removed by the LSIF retention policy
+    # currently configured in the site configuration. No uploads are removed by this
+    # query. Only site admins may perform this query.
+    lsifUploadRetentionPreview: [LSIFUploadExpiration!]!
+
+    # (experimental) The LSIF API may change substantially in the near future as we
+    # continue to adjust it for our use cases. Changes will not be documented in the
+    # CHANGELOG during this time.
+    # The LSIF uploads of other repositories that reference a package published by an
+    # LSIF upload of this repository. Uploads of repositories the current user cannot
+    # access are omitted from the result.
+    lsifPackageDependents(
+        # The name of the package.
+        name: String!
+
+        # The version of the package.
+        version: String!
+
+        # When specified, shows only references from uploads that are latest for their repository.
+        isLatestForRepo: Boolean
+
+        # When specified, indicates that this request should be paginated and
+        # the first N results (relative to the cursor) should be returned. i.e.
+        # how many results to return per page. It must be in the range of 0-5000.
+        first: Int
+
+        # When specified, indicates that this request should be paginated and
+        # to fetch results starting at this cursor.
+        #
+        # A future request can be made for more results by passing in the
+        # 'LSIFPackageUsageConnection.pageInfo.endCursor' that is returned.
+        after: String
+    ): LSIFPackageUsageConnection!
+
+    # (experimental) The LSIF API may change substantially in the near future as we
+    # continue to adjust it for our use cases. Changes will not be documented in the
+    # CHANGELOG during this time.
+    # The packages referenced by the LSIF uploads of this repository.
+    lsifPackageDependencies(
+        # When specified, shows only references from uploads of this commit.
+        commit: String
+
+        # When specified, shows only references from uploads that are latest for this repository.
+        isLatestForRepo: Boolean
+
+        # When specified, indicates that this request should be paginated and
+        # the first N results (relative to the cursor) should be returned. i.e.
+        # how many results to return per page. It must be in the range of 0-5000.
+        first: Int
+
+        # When specified, indicates that this request should be paginated and
+        # to fetch results starting at this cursor.
+        #
+        # A future request can be made for more results by passing in the
+        # 'LSIFPackageUsageConnection.pageInfo.endCursor' that is returned.
+        after: String
+    ): LSIFPackageUsageConnection!
+
     # A list of authorized users to access this repository with the given permission.
     # This API currently only returns permissions from the Sourcegraph provider, i.e.
     # "permissions.userMapping" in site configuration.
@@ -2032,9 +2547,87 @@ type ExternalRepository {
     serviceID: String!
 }
 
+# A search context is a named set of repositories and revisions that searches are scoped to with the
+# "context:" search query field. It either lists repository revisions, or has a query of repo: and
+# -repo: filters selecting the repositories.
+type SearchContext implements Node {
+    # The unique ID of the search context.
+    id: ID!
+    # The name of the search context. It is unique in its namespace.
+    name: String!
+    # The value of the "context:" search query field that selects this search context, such as
+    # "@alice/my-context", or "my-context" for instance-wide search contexts.
+    spec: String!
+    # The description of the search context.
+    description: String!
+    # Whether the search context is visible to all users. Otherwise, it is only visible to the user who
+    # owns it, the members of the organization that owns it, and site admins.
+    public: Boolean!
+    # The user or organization that owns the search context, or null for instance-wide search contexts.
+    namespace: Namespace
+    # The repo: and -repo: filters selecting the repositories of the search context, or an empty string if
+    # the search context lists repository revisions.
+    query: String!
+    # The repository revisions of the search context, ordered by repository name.
+    repositories: [SearchContextRepositoryRevisions!]!
+    # Whether the viewer can update and delete the search context.
+    viewerCanAdminister: Boolean!
+    # The date and time when the search context was created.
+    createdAt: DateTime!
+    # The date and time when the search context was last updated.
+    updatedAt: DateTime!
+}
+
+# The revisions of a repository in a search context.
+type SearchContextRepositoryRevisions {
+    # The repository.
+    repository: Repository!
+    # The revisions that are searched. An empty string denotes the default branch.
+    revisions: [String!]!
+}
+
+# Input for creating a search context.
+input SearchContextInput {
+    # The name of the search context. It may only contain letters, digits, "_", "." and "-".
+    name: String!
+    # The description of the search context.
+    description: String!
+    # Whether the search context is visible to all users.
+    public: Boolean!
+    # The ID of the user or organization that owns the search context, or null for an instance-wide
+    # search context. Only site admins can create instance-wide search contexts.
+    namespace: ID
+    # The repo: and -repo: filters selecting the repositories of the search context, or an empty string
+    # if the search context lists repository revisions.
+    query: String!
+}
+
+# Input for updating a search context.
+input SearchContextEditInput {
+    # The name of the search context. It may only contain letters, digits, "_", "." and "-".
+    name: String!
+    # The description of the search context.
+    description: String!
+    # Whether the search context is visible to all users.
+    public: Boolean!
+    # The repo: and -repo: filters selecting the repositories of the search context, or an empty string
+    # if the search context lists repository revisions.
+    query: String!
+}
+
+# The revisions of a repository to add to a search context.
+input SearchContextRepositoryRevisionsInput {
+    # The ID of the repository.
+    repository: ID!
+    # The revisions to search. An empty list or an empty string denotes the default branch.
+    revisions: [String!]!
+}
+
 # (experimental) A version context. Used to change the set of default repository and
 # revisions searched.
 #
+# Deprecated: use search contexts, which are stored in the database and can be created by users.
+#
 # Note: We do not expose the list of repositories and revisions in the version
 # context. This is intentional. However, if a need arises we can add it in.
 type VersionContext implements Node {
@@ -2307,6 +2900,21 @@ type Location {
     url: String!
     # The canonical URL to this location (using an immutable revision specifier).
     canonicalURL: String!
+    # How closely the range of this location is expected to match the range in the LSIF upload
+    # that produced it, or null if this location was not produced by precise code intelligence.
+    adjustmentConfidence: PositionAdjustmentConfidence
+}
+
+# How closely a position translated from the commit of an LSIF upload into a different commit is
+# expected to match the position in the upload.
+enum PositionAdjustmentConfidence {
+    # The position did not need to be adjusted.
+    EXACT
+    # The position was moved to account for lines added or removed earlier in the file.
+    ADJUSTED
+    # The file was edited so heavily between the two commits that the position may not refer to
+    # the same code.
+    FILE_CHANGED_TOO_MUCH
 }
 
 # A range inside a file. The start position is inclusive, and the end position is exclusive.
@@ -2703,6 +3311,10 @@ interface TreeEntry {
     lsif(
         # An optional filter for the name of the tool that produced the upload data.
         toolName: String
+        # When true, only return results from uploads for which the requested position did not
+        # need to be adjusted. Locations that cannot be adjusted exactly into the requested commit
+        # are returned relative to the commit of the upload that produced them.
+        onlyExact: Boolean = false
     ): TreeEntryLSIFData
 }
 
@@ -2780,6 +3392,10 @@ type GitTree implements TreeEntry {
     lsif(
         # An optional filter for the name of the tool that produced the upload data.
         toolName: String
+        # When true, only return results from uploads for which the requested position did not
+        # need to be adjusted. Locations that cannot be adjusted exactly into the requested commit
+        # are returned relative to the commit of the upload that produced them.
+        onlyExact: Boolean = false
     ): TreeEntryLSIFData
 }
 
@@ -2939,6 +3555,10 @@ type GitBlob implements TreeEntry & File2 {
     lsif(
         # An optional filter for the name of the tool that produced the upload data.
         toolName: String
+        # When true, only return results from uploads for which the requested position did not
+        # need to be adjusted. Locations that cannot be adjusted exactly into the requested commit
+        # are returned relative to the commit of the upload that produced them.
+        onlyExact: Boolean = false
     ): GitBlobLSIFData
 }
 
@@ -2987,6 +3607,30 @@ type GitBlobLSIFData implements TreeEntryLSIFData {
         first: Int
     ): LocationConnection
 
+    # (experimental) The LSIF API may change substantially in the near future as we
+    # continue to adjust it for our use cases. Changes will not be documented in the
+    # CHANGELOG during this time.
+    # A list of implementations of the symbol under the given document position.
+    implementations(
+        # The line on which the symbol occurs (zero-based, inclusive).
+        line: Int!
+
+        # The character (not byte) of the start line on which the symbol occurs (zero-based, inclusive).
+        character: Int!
+
+        # When specified, indicates that this request should be paginated and
+        # to fetch results starting at this cursor.
+        #
+        # A future request can be made for more results by passing in the
+        # 'LocationConnection.pageInfo.endCursor' that is returned.
+        after: String
+
+        # When specified, indicates that this request should be paginated and
+        # the first N results (relative to the cursor) should be returned. i.e.
+        # how many results to return per page.
+        first: Int
+    ): LocationConnection
+
     # (experimental) The LSIF API may change substantially in the near future as we
     # continue to adjust it for our use cases. Changes will not be documented in the
     # CHANGELOG during this time.
@@ -3035,6 +3679,41 @@ type FileMatch {
     lineMatches: [LineMatch!]!
     # Whether or not the limit was hit.
     limitHit: Boolean!
+    # The ownership of the file according to the CODEOWNERS file of the
+    # repository at the revision of the match, or null if the repository has no
+    # CODEOWNERS file.
+    ownership: FileOwnership
+}
+
+# The ownership of a file according to the CODEOWNERS file of its repository.
+# Both the GitHub and GitLab formats of CODEOWNERS files are supported.
+type FileOwnership {
+    # The path of the CODEOWNERS file in the repository, such as
+    # ".github/CODEOWNERS".
+    codeOwnersPath: String!
+    # The owners of the file, such as "@user", "@org/team" or an email address.
+    # Empty if no rule matches the file, or if the matching rule has no owners.
+    owners: [String!]!
+    # The rules that determine the owners of the file: the last rule matching
+    # the file, or in GitLab CODEOWNERS files, the last matching rule of each
+    # section.
+    rules: [CodeOwnersRule!]!
+}
+
+# A rule of a CODEOWNERS file, which assigns owners to the files matching a
+# pattern.
+type CodeOwnersRule {
+    # The gitignore-style pattern of the paths of the files that the rule
+    # applies to.
+    pattern: String!
+    # The owners of the files. In GitLab sections, rules without owners have
+    # the default owners of the section.
+    owners: [String!]!
+    # The name of the GitLab section of the rule, or null if it isn't in a
+    # section.
+    section: String
+    # The 1-based line number of the rule in the CODEOWNERS file.
+    lineNumber: Int!
 }
 
 # A line match.
@@ -4119,6 +4798,10 @@ type Hover {
 
     # The range to highlight.
     range: Range!
+
+    # How closely the hover text and range are expected to match the requested position when they
+    # were translated from the commit of a different LSIF upload.
+    adjustmentConfidence: PositionAdjustmentConfidence!
 }
 
 # The state an LSIF upload can be in.
@@ -4181,6 +4864,71 @@ type LSIFUpload implements Node {
     placeInQueue: Int
 }
 
+# An LSIF upload that is no longer retained by the LSIF retention policy of its repository.
+type LSIFUploadExpiration {
+    # The expired upload.
+    upload: LSIFUpload!
+
+    # The reason the upload is no longer retained.
+    reason: LSIFUploadExpirationReason!
+}
+
+# The reason an LSIF upload is no longer retained.
+enum LSIFUploadExpirationReason {
+    # The upload is not among the most recent uploads of any branch from which its commit
+    # is reachable.
+    BRANCH_LIMIT
+
+    # The upload's commit is not reachable from any branch or tag and the upload is older
+    # than the configured maximum age of unreferenced uploads.
+    UNREFERENCED_AGE
+}
+
+# A package identified by the monikers of an LSIF upload.
+type LSIFPackage {
+    # The moniker scheme of the package (e.g. gomod or npm).
+    scheme: String!
+
+    # The name of the package.
+    name: String!
+
+    # The version of the package.
+    version: String!
+}
+
+# A reference from an LSIF upload to a package.
+type LSIFPackageUsage {
+    # The referenced package.
+    package: LSIFPackage!
+
+    # The repository of the referencing upload.
+    repository: Repository!
+
+    # The referencing upload.
+    upload: LSIFUpload!
+
+    # The most recent upload that provides the referenced package, if one exists.
+    provider: LSIFUpload
+
+    # The number of distinct identifiers of the package referenced by the upload. This
+    # is null for uploads processed before identifiers were counted.
+    identifierCount: Int
+}
+
+# A list of references from LSIF uploads to packages.
+type LSIFPackageUsageConnection {
+    # A list of package references.
+    nodes: [LSIFPackageUsage!]!
+
+    # The total number of package references in this result set. It is only returned
+    # to site admins, as it would otherwise include the package references of
+    # repositories the current user cannot access.
+    totalCount: Int
+
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
 # A list of LSIF uploads.
 type LSIFUploadConnection {
     # A list of LSIF uploads.
@@ -58,6 +58,11 @@ type SearchArgs struct {
 	After          *string
 	First          *int32
 	VersionContext *string
+
+	// ResultChannel, if non-nil, receives the results of the search as soon
+	// as they are found. It is not a GraphQL argument. The receiver must keep
+	// receiving until Results returns.
+	ResultChannel chan<- SearchEvent
 }
 
 type SearchImplementer interface {
@@ -65,6 +70,10 @@ type SearchImplementer interface {
 	Suggestions(context.Context, *searchSuggestionsArgs) ([]*searchSuggestionResolver, error)
 	//lint:ignore U1000 is used by graphql via reflection
 	Stats(context.Context) (*searchResultsStats, error)
+	//lint:ignore U1000 is used by graphql via reflection
+	Aggregation(context.Context, *searchAggregationArgs) (*searchAggregationResolver, error)
+	//lint:ignore U1000 is used by graphql via reflection
+	Explain(context.Context) (*searchExplanationResolver, error)
 }
 
 // NewSearchImplementer returns a SearchImplementer that provides search results and suggestions.
@@ -129,6 +138,7 @@ func NewSearchImplementer(args *SearchArgs) (SearchImplementer, error) {
 		patternType:    searchType,
 		zoekt:          search.Indexed(),
 		searcherURLs:   search.SearcherURLs(),
+		resultChannel:  args.ResultChannel,
 	}, nil
 }
 
@@ -251,6 +261,9 @@ type searchResolver struct {
 
 	zoekt        *searchbackend.Zoekt
 	searcherURLs *endpoint.Map
+
+	// resultChannel, if non-nil, receives results as soon as they are found.
+	resultChannel chan<- SearchEvent
 }
 
 // rawQuery returns the original query string input.
@@ -440,6 +453,21 @@ func (r *searchResolver) resolveRepositories(ctx context.Context, effectiveRepoF
 	}
 	repoGroupFilters, _ := r.query.StringValues(query.FieldRepoGroup)
 
+	// If a search context is specified, limit the search to its repositories.
+	var searchContextRepositoryRevisions map[api.RepoName][]search.RevisionSpecifier
+	if spec, _ := r.query.StringValue(query.FieldContext); spec != "" {
+		if r.versionContext != nil && *r.versionContext != "" {
+			return nil, nil, nil, false, errors.New("search contexts can't be combined with version contexts")
+		}
+		contextRepoFilters, contextMinusRepoFilters, revisions, err := resolveSearchContextRepositories(ctx, spec)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+		repoFilters = append(append([]string{}, repoFilters...), contextRepoFilters...)
+		minusRepoFilters = append(append([]string{}, minusRepoFilters...), contextMinusRepoFilters...)
+		searchContextRepositoryRevisions = revisions
+	}
+
 	settings, err := decodedViewerFinalSettings(ctx)
 	if err != nil {
 		return nil, nil, nil, false, err
@@ -482,18 +510,19 @@ func (r *searchResolver) resolveRepositories(ctx context.Context, effectiveRepoF
 
 	tr.LazyPrintf("resolveRepositories - start")
 	options := resolveRepoOp{
-		repoFilters:        repoFilters,
-		minusRepoFilters:   minusRepoFilters,
-		repoGroupFilters:   repoGroupFilters,
-		versionContextName: versionContextName,
-		onlyForks:          fork == Only || fork == True,
-		noForks:            fork == No || fork == False,
-		onlyArchived:       archived == Only || archived == True,
-		noArchived:         archived == No || archived == False,
-		onlyPrivate:        visibility == query.Private,
-		onlyPublic:         visibility == query.Public,
-		commitAfter:        commitAfter,
-		query:              r.query,
+		repoFilters:                      repoFilters,
+		minusRepoFilters:                 minusRepoFilters,
+		repoGroupFilters:                 repoGroupFilters,
+		versionContextName:               versionContextName,
+		searchContextRepositoryRevisions: searchContextRepositoryRevisions,
+		onlyForks:                        fork == Only || fork == True,
+		noForks:                          fork == No || fork == False,
+		onlyArchived:                     archived == Only || archived == True,
+		noArchived:                       archived == No || archived == False,
+		onlyPrivate:                      visibility == query.Private,
+		onlyPublic:                       visibility == query.Public,
+		commitAfter:                      commitAfter,
+		query:                            r.query,
 	}
 	repoRevs, missingRepoRevs, overLimit, excludedRepos, err = resolveRepositories(ctx, options)
 	tr.LazyPrintf("resolveRepositories - done")
@@ -605,14 +634,17 @@ type resolveRepoOp struct {
 	minusRepoFilters   []string
 	repoGroupFilters   []string
 	versionContextName string
-	noForks            bool
-	onlyForks          bool
-	noArchived         bool
-	onlyArchived       bool
-	commitAfter        string
-	onlyPrivate        bool
-	onlyPublic         bool
-	query              query.QueryInfo
+	// searchContextRepositoryRevisions are the repository revisions of the
+	// search context, if the search context lists them.
+	searchContextRepositoryRevisions map[api.RepoName][]search.RevisionSpecifier
+	noForks                          bool
+	onlyForks                        bool
+	noArchived                       bool
+	onlyArchived                     bool
+	commitAfter                      string
+	onlyPrivate                      bool
+	onlyPublic                       bool
+	query                            query.QueryInfo
 }
 
 func resolveRepositories(ctx context.Context, op resolveRepoOp) (repoRevisions, missingRepoRevisions []*search.RepositoryRevisions, overLimit bool, excludedRepos *excludedRepos, err error) {
@@ -677,8 +709,26 @@ func resolveRepositories(ctx context.Context, op resolveRepoOp) (repoRevisions,
 		}
 	}
 
+	// If the search context lists repository revisions, limit the results to
+	// these repositories.
+	var searchContextRepositories []string
+	if op.searchContextRepositoryRevisions != nil {
+		if len(op.searchContextRepositoryRevisions) == 0 {
+			return nil, nil, false, nil, nil
+		}
+		for name := range op.searchContextRepositoryRevisions {
+			searchContextRepositories = append(searchContextRepositories, string(name))
+		}
+		sort.Strings(searchContextRepositories)
+	}
+
+	names := versionContextRepositories
+	if searchContextRepositories != nil {
+		names = searchContextRepositories
+	}
+
 	var defaultRepos []*types.Repo
-	if envvar.SourcegraphDotComMode() && len(includePatterns) == 0 {
+	if envvar.SourcegraphDotComMode() && len(includePatterns) == 0 && op.searchContextRepositoryRevisions == nil {
 		getIndexedRepos := func(ctx context.Context, revs []*search.RepositoryRevisions) (indexed, unindexed []*search.RepositoryRevisions, err error) {
 			return zoektIndexedRepos(ctx, search.Indexed(), revs, nil)
 		}
@@ -699,7 +749,7 @@ func resolveRepositories(ctx context.Context, op resolveRepoOp) (repoRevisions,
 		options := db.ReposListOptions{
 			OnlyRepoIDs:     true,
 			IncludePatterns: includePatterns,
-			Names:           versionContextRepositories,
+			Names:           names,
 			ExcludePattern:  unionRegExps(excludePatterns),
 			// List N+1 repos so we can see if there are repos omitted due to our repo limit.
 			LimitOffset:  &db.LimitOffset{Limit: maxRepoListSize + 1},
@@ -737,6 +787,11 @@ func resolveRepositories(ctx context.Context, op resolveRepoOp) (repoRevisions,
 			var clashingRevs []search.RevisionSpecifier
 			revs, clashingRevs = getRevsForMatchedRepo(repo.Name, includePatternRevs)
 			repoRev.Repo = repo
+			// Search the revisions of the search context unless the query
+			// specifies revisions. Copy them because revs is filtered in place.
+			if contextRevs := op.searchContextRepositoryRevisions[repo.Name]; len(contextRevs) > 0 && len(includePatternRevs) == 0 {
+				revs = append([]search.RevisionSpecifier{}, contextRevs...)
+			}
 			// if multiple specified revisions clash, report this usefully:
 			if len(revs) == 0 && clashingRevs != nil {
 				missingRepoRevisions = append(missingRepoRevisions, &search.RepositoryRevisions{
@@ -0,0 +1,375 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// Search aggregation dimensions, as defined by the SearchAggregationDimension
+// GraphQL enum.
+const (
+	searchAggregationByRepository    = "REPOSITORY"
+	searchAggregationByPath          = "PATH"
+	searchAggregationByDirectory     = "DIRECTORY"
+	searchAggregationByFileExtension = "FILE_EXTENSION"
+	searchAggregationByAuthor        = "AUTHOR"
+	searchAggregationByCaptureGroup  = "CAPTURE_GROUP"
+)
+
+// Search aggregation states, as defined by the SearchAggregationState GraphQL
+// enum.
+const (
+	searchAggregationStateProcessing = "PROCESSING"
+	searchAggregationStateErrored    = "ERRORED"
+	searchAggregationStateCompleted  = "COMPLETED"
+)
+
+const (
+	// searchAggregationMaxResults is the count used for aggregation searches
+	// whose query doesn't specify one, so that they are exhaustive for all but
+	// the largest result sets.
+	searchAggregationMaxResults = 100000
+
+	// searchAggregationTTL is how long finished aggregations are kept.
+	searchAggregationTTL = time.Hour
+)
+
+type searchAggregationArgs struct {
+	By           string
+	CaptureGroup int32
+}
+
+// Aggregation returns the counts of the matches of the search grouped by
+// args.By. The search runs in the background: the first call starts it and
+// later calls for the same query return its progress.
+func (r *searchResolver) Aggregation(ctx context.Context, args *searchAggregationArgs) (*searchAggregationResolver, error) {
+	captureGroup, err := r.aggregationCaptureGroup(args)
+	if err != nil {
+		return nil, err
+	}
+
+	a := actor.FromContext(ctx)
+	key := searchAggregationKey{
+		uid:         a.UID,
+		internal:    a.Internal,
+		query:       r.rawQuery(),
+		patternType: r.patternType,
+		dimension:   args.By,
+	}
+	if r.versionContext != nil {
+		key.versionContext = *r.versionContext
+	}
+	if captureGroup != nil {
+		key.group = captureGroup.group
+	}
+
+	job := searchAggregations.getOrStart(key, func() *searchAggregationJob {
+		return newSearchAggregationJob(args.By, captureGroup)
+	}, func(job *searchAggregationJob) {
+		job.run(actor.WithActor(context.Background(), a), r.aggregationSearch)
+	})
+	return &searchAggregationResolver{job.snapshot()}, nil
+}
+
+// aggregationCaptureGroup validates args and returns the capture group that
+// matches are grouped by, or nil if args.By isn't CAPTURE_GROUP.
+func (r *searchResolver) aggregationCaptureGroup(args *searchAggregationArgs) (*searchAggregationCaptureGroup, error) {
+	switch args.By {
+	case searchAggregationByRepository, searchAggregationByPath, searchAggregationByDirectory, searchAggregationByFileExtension, searchAggregationByAuthor:
+		return nil, nil
+	case searchAggregationByCaptureGroup:
+	default:
+		return nil, fmt.Errorf("unknown search aggregation dimension %q", args.By)
+	}
+
+	re, err := r.searchPatternRegexp("grouping by capture group")
+	if err != nil {
+		return nil, err
+	}
+	group, err := captureGroupIndex(re, strconv.Itoa(int(args.CaptureGroup)))
+	if err != nil {
+		return nil, err
+	}
+	return &searchAggregationCaptureGroup{re: re, group: group}, nil
+}
+
+// aggregationSearch runs the search exhaustively, sending its results on
+// events as they are found.
+func (r *searchResolver) aggregationSearch(ctx context.Context, events chan<- SearchEvent) (*SearchResultsResolver, error) {
+	q := r.rawQuery()
+	if !r.countIsSet() {
+		q += " count:" + strconv.Itoa(searchAggregationMaxResults)
+	}
+
+	args := r.searchArgs(q)
+	args.ResultChannel = events
+	search, err := NewSearchImplementer(args)
+	if err != nil {
+		return nil, err
+	}
+	return search.Results(ctx)
+}
+
+// searchArgs returns the arguments of a new search for q with the pattern type
+// and version context of r.
+func (r *searchResolver) searchArgs(q string) *SearchArgs {
+	var patternType string
+	switch r.patternType {
+	case query.SearchTypeRegex:
+		patternType = "regexp"
+	case query.SearchTypeLiteral:
+		patternType = "literal"
+	case query.SearchTypeStructural:
+		patternType = "structural"
+	}
+	return &SearchArgs{
+		Version:        "V2",
+		PatternType:    &patternType,
+		Query:          q,
+		VersionContext: r.versionContext,
+	}
+}
+
+// searchAggregationKey identifies an aggregation. Aggregations are not shared
+// between users, since users may have access to different repositories.
+type searchAggregationKey struct {
+	uid      int32
+	internal bool
+
+	query          string
+	patternType    query.SearchType
+	versionContext string
+
+	dimension string
+	group     int
+}
+
+type searchAggregationCaptureGroup struct {
+	re    *regexp.Regexp
+	group int
+}
+
+// searchAggregationRegistry holds the aggregations that are running or
+// finished less than searchAggregationTTL ago.
+type searchAggregationRegistry struct {
+	mu   sync.Mutex
+	jobs map[searchAggregationKey]*searchAggregationJob
+}
+
+var searchAggregations = &searchAggregationRegistry{jobs: map[searchAggregationKey]*searchAggregationJob{}}
+
+// getOrStart returns the job for key. If there is none, it creates one with
+// newJob and starts run in the background.
+func (r *searchAggregationRegistry) getOrStart(key searchAggregationKey, newJob func() *searchAggregationJob, run func(*searchAggregationJob)) *searchAggregationJob {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for k, job := range r.jobs {
+		if job.expired(now) {
+			delete(r.jobs, k)
+		}
+	}
+
+	if job, ok := r.jobs[key]; ok {
+		return job
+	}
+	job := newJob()
+	r.jobs[key] = job
+	goroutine.Go(func() { run(job) })
+	return job
+}
+
+// searchAggregationJob groups the results of a search as they arrive.
+type searchAggregationJob struct {
+	dimension    string
+	captureGroup *searchAggregationCaptureGroup
+
+	mu         sync.Mutex
+	state      string
+	matchCount int32
+	limitHit   bool
+	err        error
+	counts     map[string]int32
+	finishedAt time.Time
+}
+
+func newSearchAggregationJob(dimension string, captureGroup *searchAggregationCaptureGroup) *searchAggregationJob {
+	return &searchAggregationJob{
+		dimension:    dimension,
+		captureGroup: captureGroup,
+		state:        searchAggregationStateProcessing,
+		counts:       map[string]int32{},
+	}
+}
+
+// run runs search and adds the results it sends to the job.
+func (j *searchAggregationJob) run(ctx context.Context, search func(context.Context, chan<- SearchEvent) (*SearchResultsResolver, error)) {
+	events := make(chan SearchEvent)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range events {
+			j.add(event)
+		}
+	}()
+
+	results, err := search(ctx, events)
+	close(events)
+	<-done
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.finishedAt = time.Now()
+	if err != nil {
+		log15.Warn("search aggregation failed", "dimension", j.dimension, "error", err)
+		j.state = searchAggregationStateErrored
+		j.err = err
+		return
+	}
+	if results != nil && results.LimitHit() {
+		j.limitHit = true
+	}
+	j.state = searchAggregationStateCompleted
+}
+
+// add groups the results of event.
+func (j *searchAggregationJob) add(event SearchEvent) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, result := range event.Results {
+		j.matchCount += result.resultCount()
+		aggregateSearchResult(j.dimension, j.captureGroup, result, func(label string, count int32) {
+			j.counts[label] += count
+		})
+	}
+	if event.Stats.LimitHit {
+		j.limitHit = true
+	}
+}
+
+// expired returns true if the job finished more than searchAggregationTTL
+// before now.
+func (j *searchAggregationJob) expired(now time.Time) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return !j.finishedAt.IsZero() && now.Sub(j.finishedAt) > searchAggregationTTL
+}
+
+// snapshot returns the current state of the job.
+func (j *searchAggregationJob) snapshot() *searchAggregation {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	a := &searchAggregation{
+		state:      j.state,
+		matchCount: j.matchCount,
+		limitHit:   j.limitHit,
+		err:        j.err,
+		groups:     make([]*searchAggregationGroup, 0, len(j.counts)),
+	}
+	for label, count := range j.counts {
+		a.groups = append(a.groups, &searchAggregationGroup{label: label, count: count})
+	}
+	sort.Slice(a.groups, func(i, k int) bool {
+		if a.groups[i].count != a.groups[k].count {
+			return a.groups[i].count > a.groups[k].count
+		}
+		return a.groups[i].label < a.groups[k].label
+	})
+	return a
+}
+
+// aggregateSearchResult calls add with the label of each group of dimension
+// that result contributes matches to. Results that the dimension doesn't apply
+// to, such as repository matches when grouping by path, are skipped.
+func aggregateSearchResult(dimension string, captureGroup *searchAggregationCaptureGroup, result SearchResultResolver, add func(label string, count int32)) {
+	switch r := result.(type) {
+	case *RepositoryResolver:
+		if dimension == searchAggregationByRepository {
+			add(r.Name(), r.resultCount())
+		}
+
+	case *FileMatchResolver:
+		repo := r.Repository().Name()
+		switch dimension {
+		case searchAggregationByRepository:
+			add(repo, r.resultCount())
+		case searchAggregationByPath:
+			add(path.Join(repo, r.JPath), r.resultCount())
+		case searchAggregationByDirectory:
+			add(path.Join(repo, path.Dir(r.JPath)), r.resultCount())
+		case searchAggregationByFileExtension:
+			add(path.Ext(r.JPath), r.resultCount())
+		case searchAggregationByCaptureGroup:
+			for _, lm := range r.JLineMatches {
+				for _, m := range captureGroup.re.FindAllStringSubmatch(lm.JPreview, -1) {
+					if value := m[captureGroup.group]; value != "" {
+						add(value, 1)
+					}
+				}
+			}
+		}
+
+	case *commitSearchResultResolver:
+		switch dimension {
+		case searchAggregationByRepository:
+			add(r.commit.Repository().Name(), r.resultCount())
+		case searchAggregationByAuthor:
+			if person := r.commit.author.person; person != nil {
+				add(person.name, r.resultCount())
+			}
+		}
+	}
+}
+
+// searchAggregation is a snapshot of a searchAggregationJob.
+type searchAggregation struct {
+	state      string
+	matchCount int32
+	limitHit   bool
+	err        error
+	groups     []*searchAggregationGroup
+}
+
+type searchAggregationResolver struct {
+	*searchAggregation
+}
+
+func (r *searchAggregationResolver) State() string     { return r.state }
+func (r *searchAggregationResolver) MatchCount() int32 { return r.matchCount }
+func (r *searchAggregationResolver) LimitHit() bool    { return r.limitHit }
+
+func (r *searchAggregationResolver) Error() *string {
+	if r.err == nil {
+		return nil
+	}
+	msg := r.err.Error()
+	return &msg
+}
+
+func (r *searchAggregationResolver) Groups(args *struct{ First *int32 }) []*searchAggregationGroup {
+	if args.First != nil && int(*args.First) >= 0 && int(*args.First) < len(r.groups) {
+		return r.groups[:*args.First]
+	}
+	return r.groups
+}
+
+type searchAggregationGroup struct {
+	label string
+	count int32
+}
+
+func (g *searchAggregationGroup) Label() string { return g.label }
+func (g *searchAggregationGroup) Count() int32  { return g.count }
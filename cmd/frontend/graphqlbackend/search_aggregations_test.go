@@ -0,0 +1,168 @@
+package graphqlbackend
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func TestAggregateSearchResult(t *testing.T) {
+	repo := &RepositoryResolver{repo: &types.Repo{Name: "github.com/a/b"}}
+	fileMatch := &FileMatchResolver{
+		JPath: "deps/go.mod",
+		JLineMatches: []*lineMatch{
+			{JPreview: "require github.com/x/y v1.2.0"},
+			{JPreview: "require github.com/x/z v1.3.0 // github.com/x/y v1.2.0"},
+		},
+		MatchCount: 2,
+		Repo:       repo,
+	}
+	pathMatch := &FileMatchResolver{JPath: "README", Repo: repo}
+	commit := &commitSearchResultResolver{commit: &GitCommitResolver{
+		repoResolver: repo,
+		author:       signatureResolver{person: &personResolver{name: "Alice"}},
+	}}
+	results := []SearchResultResolver{repo, fileMatch, pathMatch, commit}
+
+	captureGroup, err := (&searchResolver{
+		query:       mustProcessQuery(t, `github\.com/x/(\w+)\s(v[\d.]+)`),
+		patternType: query.SearchTypeRegex,
+	}).aggregationCaptureGroup(&searchAggregationArgs{By: searchAggregationByCaptureGroup, CaptureGroup: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		dimension string
+		want      map[string]int32
+	}{
+		{searchAggregationByRepository, map[string]int32{"github.com/a/b": 5}},
+		{searchAggregationByPath, map[string]int32{"github.com/a/b/deps/go.mod": 2, "github.com/a/b/README": 1}},
+		{searchAggregationByDirectory, map[string]int32{"github.com/a/b/deps": 2, "github.com/a/b": 1}},
+		{searchAggregationByFileExtension, map[string]int32{".mod": 2, "": 1}},
+		{searchAggregationByAuthor, map[string]int32{"Alice": 1}},
+		{searchAggregationByCaptureGroup, map[string]int32{"v1.2.0": 2, "v1.3.0": 1}},
+	} {
+		t.Run(tc.dimension, func(t *testing.T) {
+			have := map[string]int32{}
+			for _, result := range results {
+				aggregateSearchResult(tc.dimension, captureGroup, result, func(label string, count int32) {
+					have[label] += count
+				})
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got %v, want %v", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestSearchResolver_aggregationCaptureGroup(t *testing.T) {
+	for _, tc := range []struct {
+		query       string
+		patternType query.SearchType
+		group       int32
+		wantErr     string
+	}{
+		{query: `foo(\d+)`, group: 1},
+		{query: `foo(\d+)`, group: 2, wantErr: "capture group 2 does not exist in the search pattern, which has 1 capture groups"},
+		{query: `foo(\d+)`, group: 1, patternType: query.SearchTypeLiteral, wantErr: "grouping by capture group requires a regexp search"},
+		{query: `foo(\d+) bar(\d+)`, group: 1, wantErr: "grouping by capture group requires a search with exactly one pattern"},
+	} {
+		r := &searchResolver{query: mustProcessQuery(t, tc.query), patternType: tc.patternType}
+		_, err := r.aggregationCaptureGroup(&searchAggregationArgs{By: searchAggregationByCaptureGroup, CaptureGroup: tc.group})
+		if have := errString(err); have != tc.wantErr {
+			t.Errorf("%q group %d: got error %q, want %q", tc.query, tc.group, have, tc.wantErr)
+		}
+	}
+
+	r := &searchResolver{query: mustProcessQuery(t, "foo")}
+	if _, err := r.aggregationCaptureGroup(&searchAggregationArgs{By: "LANGUAGE"}); err == nil {
+		t.Error("expected error for unknown dimension")
+	}
+}
+
+func TestSearchAggregationJob(t *testing.T) {
+	repo := func(name string) *RepositoryResolver {
+		return &RepositoryResolver{repo: &types.Repo{Name: "github.com/a/" + api.RepoName(name)}}
+	}
+
+	t.Run("completed", func(t *testing.T) {
+		job := newSearchAggregationJob(searchAggregationByRepository, nil)
+		job.run(context.Background(), func(ctx context.Context, events chan<- SearchEvent) (*SearchResultsResolver, error) {
+			events <- SearchEvent{Results: []SearchResultResolver{repo("b"), repo("c")}}
+			events <- SearchEvent{Results: []SearchResultResolver{repo("c")}}
+			return &SearchResultsResolver{searchResultsCommon: searchResultsCommon{limitHit: true}}, nil
+		})
+
+		want := &searchAggregation{
+			state:      searchAggregationStateCompleted,
+			matchCount: 3,
+			limitHit:   true,
+			groups: []*searchAggregationGroup{
+				{label: "github.com/a/c", count: 2},
+				{label: "github.com/a/b", count: 1},
+			},
+		}
+		if have := job.snapshot(); !reflect.DeepEqual(have, want) {
+			t.Errorf("got %+v, want %+v", have, want)
+		}
+	})
+
+	t.Run("errored", func(t *testing.T) {
+		job := newSearchAggregationJob(searchAggregationByRepository, nil)
+		job.run(context.Background(), func(ctx context.Context, events chan<- SearchEvent) (*SearchResultsResolver, error) {
+			events <- SearchEvent{Results: []SearchResultResolver{repo("b")}}
+			return nil, errors.New("boom")
+		})
+
+		have := &searchAggregationResolver{job.snapshot()}
+		if have.State() != searchAggregationStateErrored || have.MatchCount() != 1 || *have.Error() != "boom" {
+			t.Errorf("unexpected aggregation %+v", have.searchAggregation)
+		}
+	})
+}
+
+func TestSearchAggregationRegistry(t *testing.T) {
+	registry := &searchAggregationRegistry{jobs: map[searchAggregationKey]*searchAggregationJob{}}
+	started := make(chan struct{}, 2)
+	getOrStart := func(key searchAggregationKey) *searchAggregationJob {
+		return registry.getOrStart(key, func() *searchAggregationJob {
+			return newSearchAggregationJob(key.dimension, nil)
+		}, func(*searchAggregationJob) { started <- struct{}{} })
+	}
+
+	key := searchAggregationKey{uid: 1, query: "foo", dimension: searchAggregationByRepository}
+	job := getOrStart(key)
+	if getOrStart(key) != job {
+		t.Error("expected the running job to be reused")
+	}
+	<-started
+
+	job.finishedAt = job.finishedAt.Add(1) // finished long ago
+	if getOrStart(key) == job {
+		t.Error("expected the expired job to be replaced")
+	}
+	<-started
+}
+
+func mustProcessQuery(t *testing.T, q string) query.QueryInfo {
+	t.Helper()
+	info, err := query.Process(q, query.SearchTypeRegex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
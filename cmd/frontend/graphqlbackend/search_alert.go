@@ -604,3 +604,13 @@ func (searchAlert) Suggestions(context.Context, *searchSuggestionsArgs) ([]*sear
 	return nil, nil
 }
 func (searchAlert) Stats(context.Context) (*searchResultsStats, error) { return nil, nil }
+func (searchAlert) Aggregation(context.Context, *searchAggregationArgs) (*searchAggregationResolver, error) {
+	return nil, nil
+}
+func (a searchAlert) Explain(ctx context.Context) (*searchExplanationResolver, error) {
+	results, err := a.Results(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &searchExplanationResolver{explainer: &searchExplainer{}, results: results}, nil
+}
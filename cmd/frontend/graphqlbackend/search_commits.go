@@ -73,6 +73,10 @@ func (r *commitSearchResultResolver) ToCodemodResult() (*codemodResultResolver,
 	return nil, false
 }
 
+func (r *commitSearchResultResolver) ToCaptureGroupResult() (*captureGroupResultResolver, bool) {
+	return nil, false
+}
+
 func (r *commitSearchResultResolver) searchResultURIs() (string, string) {
 	// Diffs aren't going to be returned with other types of results
 	// and are already ordered in the desired order, so we'll just leave them in place.
@@ -416,8 +420,17 @@ var mockSearchCommitDiffsInRepos func(args *search.TextParametersForCommitParame
 
 // searchCommitDiffsInRepos searches a set of repos for matching commit diffs.
 func searchCommitDiffsInRepos(ctx context.Context, args *search.TextParametersForCommitParameters) ([]SearchResultResolver, *searchResultsCommon, error) {
+	return searchCommitDiffsInReposStream(ctx, args, nil)
+}
+
+// searchCommitDiffsInReposStream is like searchCommitDiffsInRepos, but
+// additionally sends the matches found in each repository to resultChannel, if
+// non-nil, as soon as the repository has been searched.
+func searchCommitDiffsInReposStream(ctx context.Context, args *search.TextParametersForCommitParameters, resultChannel chan<- SearchEvent) ([]SearchResultResolver, *searchResultsCommon, error) {
 	if mockSearchCommitDiffsInRepos != nil {
-		return mockSearchCommitDiffsInRepos(args)
+		results, common, err := mockSearchCommitDiffsInRepos(args)
+		sendSearchEvent(resultChannel, SearchEvent{Results: results, Stats: streamingStats(common)})
+		return results, common, err
 	}
 
 	var err error
@@ -462,13 +475,16 @@ func searchCommitDiffsInRepos(ctx context.Context, args *search.TextParametersFo
 			}
 			mu.Lock()
 			defer mu.Unlock()
-			if fatalErr := handleRepoSearchResult(common, repoRev, repoLimitHit, repoTimedOut, searchErr); fatalErr != nil {
+			repoCommon := &searchResultsCommon{}
+			if fatalErr := handleRepoSearchResult(repoCommon, repoRev, repoLimitHit, repoTimedOut, searchErr); fatalErr != nil {
 				err = errors.Wrapf(searchErr, "failed to search commit diffs %s", repoRev.String())
 				cancel()
 			}
+			common.update(*repoCommon)
 			if len(results) > 0 {
 				unflattened = append(unflattened, results)
 			}
+			sendSearchEvent(resultChannel, SearchEvent{Results: commitSearchResultsToSearchResults(results), Stats: streamingStats(repoCommon)})
 		}(repoRev)
 	}
 	wg.Wait()
@@ -487,8 +503,17 @@ var mockSearchCommitLogInRepos func(args *search.TextParametersForCommitParamete
 
 // searchCommitLogInRepos searches a set of repos for matching commits.
 func searchCommitLogInRepos(ctx context.Context, args *search.TextParametersForCommitParameters) ([]SearchResultResolver, *searchResultsCommon, error) {
+	return searchCommitLogInReposStream(ctx, args, nil)
+}
+
+// searchCommitLogInReposStream is like searchCommitLogInRepos, but additionally
+// sends the matches found in each repository to resultChannel, if non-nil, as
+// soon as the repository has been searched.
+func searchCommitLogInReposStream(ctx context.Context, args *search.TextParametersForCommitParameters, resultChannel chan<- SearchEvent) ([]SearchResultResolver, *searchResultsCommon, error) {
 	if mockSearchCommitLogInRepos != nil {
-		return mockSearchCommitLogInRepos(args)
+		results, common, err := mockSearchCommitLogInRepos(args)
+		sendSearchEvent(resultChannel, SearchEvent{Results: results, Stats: streamingStats(common)})
+		return results, common, err
 	}
 
 	var err error
@@ -527,13 +552,16 @@ func searchCommitLogInRepos(ctx context.Context, args *search.TextParametersForC
 			}
 			mu.Lock()
 			defer mu.Unlock()
-			if fatalErr := handleRepoSearchResult(common, repoRev, repoLimitHit, repoTimedOut, searchErr); fatalErr != nil {
+			repoCommon := &searchResultsCommon{}
+			if fatalErr := handleRepoSearchResult(repoCommon, repoRev, repoLimitHit, repoTimedOut, searchErr); fatalErr != nil {
 				err = errors.Wrapf(searchErr, "failed to search commit log %s", repoRev.String())
 				cancel()
 			}
+			common.update(*repoCommon)
 			if len(results) > 0 {
 				unflattened = append(unflattened, results)
 			}
+			sendSearchEvent(resultChannel, SearchEvent{Results: commitSearchResultsToSearchResults(results), Stats: streamingStats(repoCommon)})
 		}(repoRev)
 	}
 	wg.Wait()
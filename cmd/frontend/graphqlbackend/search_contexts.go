@@ -0,0 +1,484 @@
+package graphqlbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+var validSearchContextName = lazyregexp.New(`^[a-zA-Z0-9_.-]+$`)
+
+func marshalSearchContextID(id int32) graphql.ID {
+	return relay.MarshalID("SearchContext", id)
+}
+
+func unmarshalSearchContextID(id graphql.ID) (searchContextID int32, err error) {
+	err = relay.UnmarshalSpec(id, &searchContextID)
+	return
+}
+
+func searchContextByID(ctx context.Context, id graphql.ID) (*searchContextResolver, error) {
+	searchContextID, err := unmarshalSearchContextID(id)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := db.SearchContexts.GetByID(ctx, searchContextID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Private search contexts are only visible to their owners
+	// and site admins.
+	if err := checkSearchContextReadAccess(ctx, sc); err != nil {
+		return nil, err
+	}
+	return &searchContextResolver{sc: sc}, nil
+}
+
+// checkSearchContextReadAccess returns an error if the current user can't see
+// the search context.
+func checkSearchContextReadAccess(ctx context.Context, sc *types.SearchContext) error {
+	if sc.Public {
+		return nil
+	}
+	return checkSearchContextWriteAccess(ctx, sc)
+}
+
+// checkSearchContextWriteAccess returns an error if the current user can't
+// create, update or delete the search context. Contexts owned by a user can be
+// managed by that user, contexts owned by an organization by its members, and
+// instance-wide contexts by site admins. Site admins can manage all contexts.
+func checkSearchContextWriteAccess(ctx context.Context, sc *types.SearchContext) error {
+	switch {
+	case sc.UserID != 0:
+		return backend.CheckSiteAdminOrSameUser(ctx, sc.UserID)
+	case sc.OrgID != 0:
+		return backend.CheckOrgAccess(ctx, sc.OrgID)
+	default:
+		return backend.CheckCurrentUserIsSiteAdmin(ctx)
+	}
+}
+
+// isAccessDeniedError reports whether err was returned by a permission check
+// because the current user lacks permissions, as opposed to a failure to
+// perform the check.
+func isAccessDeniedError(err error) bool {
+	switch err.(type) {
+	case *backend.InsufficientAuthorizationError:
+		return true
+	}
+	return err == backend.ErrNotAuthenticated || err == backend.ErrNotAnOrgMember || err == backend.ErrMustBeSiteAdmin
+}
+
+func (r *schemaResolver) SearchContexts(ctx context.Context, args *struct {
+	Namespace *graphql.ID
+}) ([]*searchContextResolver, error) {
+	var opt db.SearchContextsListOptions
+	if args.Namespace != nil {
+		var err error
+		if opt.UserID, opt.OrgID, err = unmarshalSearchContextNamespace(*args.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	contexts, err := db.SearchContexts.List(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*searchContextResolver, 0, len(contexts))
+	for _, sc := range contexts {
+		// 🚨 SECURITY: Only list the search contexts the current user can see.
+		if err := checkSearchContextReadAccess(ctx, sc); isAccessDeniedError(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, &searchContextResolver{sc: sc})
+	}
+	return resolvers, nil
+}
+
+func unmarshalSearchContextNamespace(id graphql.ID) (userID, orgID int32, err error) {
+	switch relay.UnmarshalKind(id) {
+	case "User":
+		userID, err = UnmarshalUserID(id)
+	case "Org":
+		orgID, err = UnmarshalOrgID(id)
+	default:
+		err = errors.New("invalid ID for namespace")
+	}
+	return userID, orgID, err
+}
+
+type searchContextInput struct {
+	Name        string
+	Description string
+	Public      bool
+	Namespace   *graphql.ID
+	Query       string
+}
+
+type searchContextRepositoryRevisionsInput struct {
+	Repository graphql.ID
+	Revisions  []string
+}
+
+func (r *schemaResolver) CreateSearchContext(ctx context.Context, args *struct {
+	SearchContext searchContextInput
+	Repositories  []searchContextRepositoryRevisionsInput
+}) (*searchContextResolver, error) {
+	sc := &types.SearchContext{
+		Name:        args.SearchContext.Name,
+		Description: args.SearchContext.Description,
+		Public:      args.SearchContext.Public,
+		Query:       args.SearchContext.Query,
+	}
+	if args.SearchContext.Namespace != nil {
+		var err error
+		if sc.UserID, sc.OrgID, err = unmarshalSearchContextNamespace(*args.SearchContext.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	// 🚨 SECURITY: Check that the current user can create search contexts in
+	// the namespace.
+	if err := checkSearchContextWriteAccess(ctx, sc); err != nil {
+		return nil, err
+	}
+
+	revisions, err := resolveSearchContextRepositoryRevisions(ctx, args.Repositories)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSearchContext(sc, revisions); err != nil {
+		return nil, err
+	}
+
+	sc, err = db.SearchContexts.Create(ctx, sc, revisions)
+	if err != nil {
+		return nil, err
+	}
+	return &searchContextResolver{sc: sc}, nil
+}
+
+func (r *schemaResolver) UpdateSearchContext(ctx context.Context, args *struct {
+	ID            graphql.ID
+	SearchContext struct {
+		Name        string
+		Description string
+		Public      bool
+		Query       string
+	}
+	Repositories []searchContextRepositoryRevisionsInput
+}) (*searchContextResolver, error) {
+	resolver, err := searchContextByID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	sc := resolver.sc
+
+	// 🚨 SECURITY: Check that the current user can update the search context.
+	if err := checkSearchContextWriteAccess(ctx, sc); err != nil {
+		return nil, err
+	}
+
+	sc.Name = args.SearchContext.Name
+	sc.Description = args.SearchContext.Description
+	sc.Public = args.SearchContext.Public
+	sc.Query = args.SearchContext.Query
+
+	revisions, err := resolveSearchContextRepositoryRevisions(ctx, args.Repositories)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSearchContext(sc, revisions); err != nil {
+		return nil, err
+	}
+
+	if err := db.SearchContexts.Update(ctx, sc, revisions); err != nil {
+		return nil, err
+	}
+	return &searchContextResolver{sc: sc}, nil
+}
+
+func (r *schemaResolver) DeleteSearchContext(ctx context.Context, args *struct {
+	ID graphql.ID
+}) (*EmptyResponse, error) {
+	resolver, err := searchContextByID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Check that the current user can delete the search context.
+	if err := checkSearchContextWriteAccess(ctx, resolver.sc); err != nil {
+		return nil, err
+	}
+
+	if err := db.SearchContexts.Delete(ctx, resolver.sc.ID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+// resolveSearchContextRepositoryRevisions returns the repository revisions of
+// the input. An empty list of revisions denotes the default branch.
+func resolveSearchContextRepositoryRevisions(ctx context.Context, inputs []searchContextRepositoryRevisionsInput) ([]*types.SearchContextRepositoryRevision, error) {
+	var revisions []*types.SearchContextRepositoryRevision
+	for _, input := range inputs {
+		// 🚨 SECURITY: repositoryByID only returns repositories the current
+		// user has access to.
+		repo, err := repositoryByID(ctx, input.Repository)
+		if err != nil {
+			return nil, err
+		}
+
+		revs := input.Revisions
+		if len(revs) == 0 {
+			revs = []string{""}
+		}
+		for _, rev := range revs {
+			revisions = append(revisions, &types.SearchContextRepositoryRevision{
+				RepoID:   repo.repo.ID,
+				RepoName: repo.repo.Name,
+				Revision: rev,
+			})
+		}
+	}
+	return revisions, nil
+}
+
+// validateSearchContext returns an error if sc isn't a valid search context
+// with the given repository revisions.
+func validateSearchContext(sc *types.SearchContext, revisions []*types.SearchContextRepositoryRevision) error {
+	if !validSearchContextName.MatchString(sc.Name) {
+		return fmt.Errorf("invalid search context name %q, it may only contain letters, digits, \"_\", \".\" and \"-\"", sc.Name)
+	}
+
+	if sc.Query == "" && len(revisions) == 0 {
+		return errors.New("a search context must have either a query or repositories")
+	}
+	if sc.Query != "" && len(revisions) > 0 {
+		return errors.New("a search context can't have both a query and repositories")
+	}
+	if sc.Query == "" {
+		return nil
+	}
+
+	q, err := query.Process(sc.Query, query.SearchTypeRegex)
+	if err != nil {
+		return err
+	}
+	for field := range q.Fields() {
+		if field == query.FieldDefault {
+			return errors.New("the query of a search context may only contain repo: and -repo: filters, but it contains a search pattern")
+		}
+		if field != query.FieldRepo {
+			return fmt.Errorf("the query of a search context may only contain repo: and -repo: filters, but it contains %q", field+":")
+		}
+	}
+	return nil
+}
+
+// resolveSearchContextRepositories returns the repo: and -repo: filters and the
+// repository revisions of the search context with the given spec, as used in
+// the "context:" search query field. Revisions is nil for search contexts with
+// a query.
+func resolveSearchContextRepositories(ctx context.Context, spec string) (repoFilters, minusRepoFilters []string, revisions map[api.RepoName][]search.RevisionSpecifier, err error) {
+	sc, err := resolveSearchContextSpec(ctx, spec)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if sc.Query != "" {
+		q, err := query.Process(sc.Query, query.SearchTypeRegex)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		repoFilters, minusRepoFilters = q.RegexpPatterns(query.FieldRepo)
+		return repoFilters, minusRepoFilters, nil, nil
+	}
+
+	repoRevisions, err := db.SearchContexts.ListRepositoryRevisions(ctx, sc.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	revisions = make(map[api.RepoName][]search.RevisionSpecifier, len(repoRevisions))
+	for _, rev := range repoRevisions {
+		revisions[rev.RepoName] = append(revisions[rev.RepoName], search.RevisionSpecifier{RevSpec: rev.Revision})
+	}
+	return nil, nil, revisions, nil
+}
+
+// resolveSearchContextSpec returns the search context that spec refers to:
+// "@name/context" for a context owned by the user or organization with the
+// given name, and "context" for an instance-wide context.
+func resolveSearchContextSpec(ctx context.Context, spec string) (*types.SearchContext, error) {
+	notFound := fmt.Errorf("search context %q not found", spec)
+
+	var userID, orgID int32
+	name := spec
+	if strings.HasPrefix(spec, "@") {
+		i := strings.Index(spec, "/")
+		if i == -1 {
+			return nil, fmt.Errorf("invalid search context %q, use context:@namespace/name or context:name", spec)
+		}
+		namespace := spec[1:i]
+		name = spec[i+1:]
+
+		user, err := db.Users.GetByUsername(ctx, namespace)
+		switch {
+		case err == nil:
+			userID = user.ID
+		case errcode.IsNotFound(err):
+			org, err := db.Orgs.GetByName(ctx, namespace)
+			if errcode.IsNotFound(err) {
+				return nil, notFound
+			} else if err != nil {
+				return nil, err
+			}
+			orgID = org.ID
+		default:
+			return nil, err
+		}
+	}
+
+	sc, err := db.SearchContexts.GetByName(ctx, userID, orgID, name)
+	if errcode.IsNotFound(err) {
+		return nil, notFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Don't reveal the existence of private search contexts to
+	// users who can't see them.
+	if err := checkSearchContextReadAccess(ctx, sc); isAccessDeniedError(err) {
+		return nil, notFound
+	} else if err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+type searchContextResolver struct {
+	sc *types.SearchContext
+}
+
+func (r *searchContextResolver) ID() graphql.ID {
+	return marshalSearchContextID(r.sc.ID)
+}
+
+func (r *searchContextResolver) Name() string { return r.sc.Name }
+
+func (r *searchContextResolver) Spec(ctx context.Context) (string, error) {
+	switch {
+	case r.sc.UserID != 0:
+		user, err := db.Users.GetByID(ctx, r.sc.UserID)
+		if err != nil {
+			return "", err
+		}
+		return "@" + user.Username + "/" + r.sc.Name, nil
+	case r.sc.OrgID != 0:
+		org, err := db.Orgs.GetByID(ctx, r.sc.OrgID)
+		if err != nil {
+			return "", err
+		}
+		return "@" + org.Name + "/" + r.sc.Name, nil
+	}
+	return r.sc.Name, nil
+}
+
+func (r *searchContextResolver) Description() string { return r.sc.Description }
+
+func (r *searchContextResolver) Public() bool { return r.sc.Public }
+
+func (r *searchContextResolver) Namespace(ctx context.Context) (*NamespaceResolver, error) {
+	var id graphql.ID
+	switch {
+	case r.sc.UserID != 0:
+		id = MarshalUserID(r.sc.UserID)
+	case r.sc.OrgID != 0:
+		id = marshalOrgID(r.sc.OrgID)
+	default:
+		return nil, nil
+	}
+	n, err := NamespaceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &NamespaceResolver{n}, nil
+}
+
+func (r *searchContextResolver) Query() string { return r.sc.Query }
+
+func (r *searchContextResolver) Repositories(ctx context.Context) ([]*searchContextRepositoryRevisionsResolver, error) {
+	revisions, err := db.SearchContexts.ListRepositoryRevisions(ctx, r.sc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]api.RepoID, 0, len(revisions))
+	for _, rev := range revisions {
+		ids = append(ids, rev.RepoID)
+	}
+	// 🚨 SECURITY: db.Repos.GetByIDs only returns the repositories the
+	// current user has access to.
+	repos, err := db.Repos.GetByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	reposByID := make(map[api.RepoID]*types.Repo, len(repos))
+	for _, repo := range repos {
+		reposByID[repo.ID] = repo
+	}
+
+	var resolvers []*searchContextRepositoryRevisionsResolver
+	for _, rev := range revisions {
+		repo, ok := reposByID[rev.RepoID]
+		if !ok {
+			continue
+		}
+		if n := len(resolvers); n > 0 && resolvers[n-1].repo.repo.ID == rev.RepoID {
+			resolvers[n-1].revisions = append(resolvers[n-1].revisions, rev.Revision)
+			continue
+		}
+		resolvers = append(resolvers, &searchContextRepositoryRevisionsResolver{
+			repo:      &RepositoryResolver{repo: repo},
+			revisions: []string{rev.Revision},
+		})
+	}
+	return resolvers, nil
+}
+
+func (r *searchContextResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
+	if err := checkSearchContextWriteAccess(ctx, r.sc); isAccessDeniedError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *searchContextResolver) CreatedAt() DateTime { return DateTime{Time: r.sc.CreatedAt} }
+
+func (r *searchContextResolver) UpdatedAt() DateTime { return DateTime{Time: r.sc.UpdatedAt} }
+
+type searchContextRepositoryRevisionsResolver struct {
+	repo      *RepositoryResolver
+	revisions []string
+}
+
+func (r *searchContextRepositoryRevisionsResolver) Repository() *RepositoryResolver { return r.repo }
+
+func (r *searchContextRepositoryRevisionsResolver) Revisions() []string { return r.revisions }
@@ -0,0 +1,166 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+)
+
+func TestValidateSearchContext(t *testing.T) {
+	revisions := []*types.SearchContextRepositoryRevision{{RepoID: 1}}
+	for _, tc := range []struct {
+		sc        *types.SearchContext
+		revisions []*types.SearchContextRepositoryRevision
+		wantErr   string
+	}{
+		{sc: &types.SearchContext{Name: "a-b_c.d"}, revisions: revisions},
+		{sc: &types.SearchContext{Name: "a", Query: "repo:a -repo:b"}},
+		{sc: &types.SearchContext{Name: "a b"}, revisions: revisions, wantErr: `invalid search context name "a b", it may only contain letters, digits, "_", "." and "-"`},
+		{sc: &types.SearchContext{Name: "a"}, wantErr: "a search context must have either a query or repositories"},
+		{sc: &types.SearchContext{Name: "a", Query: "repo:a"}, revisions: revisions, wantErr: "a search context can't have both a query and repositories"},
+		{sc: &types.SearchContext{Name: "a", Query: "repo:a foo"}, wantErr: "the query of a search context may only contain repo: and -repo: filters, but it contains a search pattern"},
+		{sc: &types.SearchContext{Name: "a", Query: "repo:a file:b"}, wantErr: `the query of a search context may only contain repo: and -repo: filters, but it contains "file:"`},
+	} {
+		if have := errString(validateSearchContext(tc.sc, tc.revisions)); have != tc.wantErr {
+			t.Errorf("%+v: got error %q, want %q", tc.sc, have, tc.wantErr)
+		}
+	}
+}
+
+func TestResolveSearchContextRepositories(t *testing.T) {
+	defer resetMocks()
+
+	const ownerID, otherID, orgID = 1, 2, 3
+	contexts := []*types.SearchContext{
+		{ID: 1, Name: "private", UserID: ownerID},
+		{ID: 2, Name: "public", OrgID: orgID, Public: true, Query: "repo:^github\\.com/a/ -repo:b$"},
+		{ID: 3, Name: "global", Public: true},
+	}
+	db.Mocks.Users.GetByUsername = func(ctx context.Context, username string) (*types.User, error) {
+		if username == "alice" {
+			return &types.User{ID: ownerID, Username: username}, nil
+		}
+		return nil, &errcode.Mock{IsNotFound: true}
+	}
+	db.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{ID: actor.FromContext(ctx).UID}, nil
+	}
+	db.Mocks.Orgs.GetByName = func(ctx context.Context, name string) (*types.Org, error) {
+		if name == "acme" {
+			return &types.Org{ID: orgID, Name: name}, nil
+		}
+		return nil, &errcode.Mock{IsNotFound: true}
+	}
+	db.Mocks.SearchContexts.GetByName = func(ctx context.Context, userID, orgID int32, name string) (*types.SearchContext, error) {
+		for _, sc := range contexts {
+			if sc.UserID == userID && sc.OrgID == orgID && sc.Name == name {
+				return sc, nil
+			}
+		}
+		return nil, &db.SearchContextNotFoundError{}
+	}
+	db.Mocks.SearchContexts.ListRepositoryRevisions = func(ctx context.Context, id int32) ([]*types.SearchContextRepositoryRevision, error) {
+		return []*types.SearchContextRepositoryRevision{
+			{RepoID: 1, RepoName: "github.com/a/a", Revision: ""},
+			{RepoID: 1, RepoName: "github.com/a/a", Revision: "v1"},
+			{RepoID: 2, RepoName: "github.com/a/b", Revision: "main"},
+		}, nil
+	}
+
+	owner := actor.WithActor(context.Background(), &actor.Actor{UID: ownerID})
+	other := actor.WithActor(context.Background(), &actor.Actor{UID: otherID})
+
+	t.Run("revisions", func(t *testing.T) {
+		repoFilters, minusRepoFilters, revisions, err := resolveSearchContextRepositories(owner, "@alice/private")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if repoFilters != nil || minusRepoFilters != nil {
+			t.Errorf("got filters %q and %q, want none", repoFilters, minusRepoFilters)
+		}
+		want := map[api.RepoName][]search.RevisionSpecifier{
+			"github.com/a/a": {{RevSpec: ""}, {RevSpec: "v1"}},
+			"github.com/a/b": {{RevSpec: "main"}},
+		}
+		if !reflect.DeepEqual(revisions, want) {
+			t.Errorf("got revisions %+v, want %+v", revisions, want)
+		}
+	})
+
+	t.Run("query", func(t *testing.T) {
+		repoFilters, minusRepoFilters, revisions, err := resolveSearchContextRepositories(other, "@acme/public")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"^github\\.com/a/"}; !reflect.DeepEqual(repoFilters, want) {
+			t.Errorf("got repo filters %q, want %q", repoFilters, want)
+		}
+		if want := []string{"b$"}; !reflect.DeepEqual(minusRepoFilters, want) {
+			t.Errorf("got -repo filters %q, want %q", minusRepoFilters, want)
+		}
+		if revisions != nil {
+			t.Errorf("got revisions %+v, want none", revisions)
+		}
+	})
+
+	for _, tc := range []struct {
+		ctx     context.Context
+		spec    string
+		wantErr string
+	}{
+		{ctx: other, spec: "global"},
+		{ctx: other, spec: "@alice/private", wantErr: `search context "@alice/private" not found`},
+		{ctx: owner, spec: "@alice/other", wantErr: `search context "@alice/other" not found`},
+		{ctx: owner, spec: "@nobody/private", wantErr: `search context "@nobody/private" not found`},
+		{ctx: owner, spec: "@alice", wantErr: `invalid search context "@alice", use context:@namespace/name or context:name`},
+	} {
+		_, err := resolveSearchContextSpec(tc.ctx, tc.spec)
+		if have := errString(err); have != tc.wantErr {
+			t.Errorf("%q: got error %q, want %q", tc.spec, have, tc.wantErr)
+		}
+	}
+}
+
+func TestSearchContexts(t *testing.T) {
+	defer resetMocks()
+
+	db.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{ID: actor.FromContext(ctx).UID}, nil
+	}
+	db.Mocks.SearchContexts.List = func(ctx context.Context, opt db.SearchContextsListOptions) ([]*types.SearchContext, error) {
+		return []*types.SearchContext{
+			{ID: 1, Name: "mine", UserID: 1},
+			{ID: 2, Name: "theirs", UserID: 2},
+			{ID: 3, Name: "public", UserID: 2, Public: true},
+			{ID: 4, Name: "global"},
+		}, nil
+	}
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	resolvers, err := (&schemaResolver{}).SearchContexts(ctx, &struct{ Namespace *graphql.ID }{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, r := range resolvers {
+		names = append(names, r.Name())
+	}
+	if want := []string{"mine", "public"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got search contexts %q, want %q", names, want)
+	}
+}
@@ -0,0 +1,295 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// Search backends, as defined by the SearchBackend GraphQL enum.
+const (
+	searchBackendZoekt    = "ZOEKT"
+	searchBackendSearcher = "SEARCHER"
+)
+
+// Explain runs the search and returns an explanation of how it was planned
+// and executed. The search is run separately from the one of the results
+// field, without streaming or pagination.
+func (r *searchResolver) Explain(ctx context.Context) (*searchExplanationResolver, error) {
+	search, err := NewSearchImplementer(r.searchArgs(r.rawQuery()))
+	if err != nil {
+		return nil, err
+	}
+
+	e := &searchExplainer{}
+	start := time.Now()
+	results, err := search.Results(withSearchExplainer(ctx, e))
+	if err != nil {
+		return nil, err
+	}
+	return &searchExplanationResolver{explainer: e, results: results, duration: time.Since(start)}, nil
+}
+
+type searchExplainerKey struct{}
+
+// withSearchExplainer returns a context that records how the searches run
+// with it are planned and executed in e.
+func withSearchExplainer(ctx context.Context, e *searchExplainer) context.Context {
+	return context.WithValue(ctx, searchExplainerKey{}, e)
+}
+
+// searchExplainerFromContext returns the explainer of the search run with
+// ctx, or nil if the search isn't explained.
+func searchExplainerFromContext(ctx context.Context) *searchExplainer {
+	e, _ := ctx.Value(searchExplainerKey{}).(*searchExplainer)
+	return e
+}
+
+// searchExplainer records how a search is planned and executed. Its methods
+// are safe for concurrent use and do nothing when called on a nil explainer,
+// so the search pipeline records into it unconditionally.
+//
+// The leaves of and/or queries are searched separately, so the resolved
+// repositories are the union of those of all leaves, and each leaf adds its
+// own backend calls.
+type searchExplainer struct {
+	mu           sync.Mutex
+	parseTree    string
+	repos        []*search.RepositoryRevisions
+	missingRepos []*search.RepositoryRevisions
+	seenRepos    map[string]struct{}
+	backends     []*searchBackendExplanation
+}
+
+// recordQuery records the query after it was parsed and transformed.
+func (e *searchExplainer) recordQuery(q query.QueryInfo) {
+	if e == nil {
+		return
+	}
+	var tree string
+	switch q := q.(type) {
+	case *query.OrdinaryQuery:
+		tree = q.ParseTree().String()
+	case *query.AndOrQuery:
+		nodes := make([]string, 0, len(q.Query))
+		for _, node := range q.Query {
+			nodes = append(nodes, node.String())
+		}
+		tree = strings.Join(nodes, " ")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.parseTree = tree
+}
+
+// recordRepositories records the repositories and revisions that the query
+// resolved to, and those whose revisions don't exist.
+func (e *searchExplainer) recordRepositories(repos, missingRepos []*search.RepositoryRevisions) {
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.seenRepos == nil {
+		e.seenRepos = make(map[string]struct{})
+	}
+	add := func(dst *[]*search.RepositoryRevisions, repos []*search.RepositoryRevisions, kind string) {
+		for _, repo := range repos {
+			key := kind + repo.String()
+			if _, ok := e.seenRepos[key]; ok {
+				continue
+			}
+			e.seenRepos[key] = struct{}{}
+			*dst = append(*dst, repo)
+		}
+	}
+	add(&e.repos, repos, "found:")
+	add(&e.missingRepos, missingRepos, "missing:")
+}
+
+// startBackend records that the repositories are being searched by the
+// backend with the given pattern. The returned context carries the backend
+// explanation, so that the backend client can record the query it sends.
+func (e *searchExplainer) startBackend(ctx context.Context, backend string, repos []*search.RepositoryRevisions, p *search.TextPatternInfo) (context.Context, *searchBackendExplanation) {
+	if e == nil {
+		return ctx, nil
+	}
+
+	b := &searchBackendExplanation{
+		explainer: e,
+		backend:   backend,
+		repos:     repos,
+		// The pattern info is copied since it is modified by retries.
+		patternInfo: *p,
+		start:       time.Now(),
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.backends = append(e.backends, b)
+	return context.WithValue(ctx, searchBackendExplanationKey{}, b), b
+}
+
+type searchBackendExplanationKey struct{}
+
+// searchBackendExplanationFromContext returns the explanation of the backend
+// call made with ctx, or nil if the search isn't explained.
+func searchBackendExplanationFromContext(ctx context.Context) *searchBackendExplanation {
+	b, _ := ctx.Value(searchBackendExplanationKey{}).(*searchBackendExplanation)
+	return b
+}
+
+// searchBackendExplanation records a call of a search backend over a set of
+// repositories.
+type searchBackendExplanation struct {
+	explainer   *searchExplainer
+	backend     string
+	repos       []*search.RepositoryRevisions
+	patternInfo search.TextPatternInfo
+	zoektQuery  string
+	start       time.Time
+	duration    time.Duration
+}
+
+// recordZoektQuery records the query sent to zoekt. When zoekt is queried
+// more than once, the last query is kept.
+func (b *searchBackendExplanation) recordZoektQuery(q string) {
+	if b == nil {
+		return
+	}
+	b.explainer.mu.Lock()
+	defer b.explainer.mu.Unlock()
+	b.zoektQuery = q
+}
+
+// finish records that a search of the backend call has finished. Searcher is
+// called once per repository revision, so the duration of the call is the
+// time until the last one finished.
+func (b *searchBackendExplanation) finish() {
+	if b == nil {
+		return
+	}
+	b.explainer.mu.Lock()
+	defer b.explainer.mu.Unlock()
+	if d := time.Since(b.start); d > b.duration {
+		b.duration = d
+	}
+}
+
+// searchExplanationResolver is a resolver for the GraphQL type
+// `SearchExplanation`.
+type searchExplanationResolver struct {
+	explainer *searchExplainer
+	results   *SearchResultsResolver
+	duration  time.Duration
+}
+
+func (r *searchExplanationResolver) ParseTree() string {
+	r.explainer.mu.Lock()
+	defer r.explainer.mu.Unlock()
+	return r.explainer.parseTree
+}
+
+func (r *searchExplanationResolver) Repositories() []*searchExplanationRepositoryResolver {
+	r.explainer.mu.Lock()
+	defer r.explainer.mu.Unlock()
+	return searchExplanationRepositoryResolvers(r.explainer.repos)
+}
+
+func (r *searchExplanationResolver) MissingRepositories() []*searchExplanationRepositoryResolver {
+	r.explainer.mu.Lock()
+	defer r.explainer.mu.Unlock()
+	return searchExplanationRepositoryResolvers(r.explainer.missingRepos)
+}
+
+func (r *searchExplanationResolver) Backends() []*searchBackendExplanationResolver {
+	r.explainer.mu.Lock()
+	defer r.explainer.mu.Unlock()
+	backends := make([]*searchBackendExplanationResolver, len(r.explainer.backends))
+	for i, b := range r.explainer.backends {
+		// Copy the explanation, so that it can be resolved without holding
+		// the lock.
+		backends[i] = &searchBackendExplanationResolver{*b}
+	}
+	return backends
+}
+
+func (r *searchExplanationResolver) Cloning() []*RepositoryResolver {
+	return r.results.Cloning()
+}
+
+func (r *searchExplanationResolver) Missing() []*RepositoryResolver {
+	return r.results.Missing()
+}
+
+func (r *searchExplanationResolver) Timedout() []*RepositoryResolver {
+	return r.results.Timedout()
+}
+
+func (r *searchExplanationResolver) Alert() *searchAlert {
+	return r.results.Alert()
+}
+
+func (r *searchExplanationResolver) DurationMilliseconds() int32 {
+	return int32(r.duration.Milliseconds())
+}
+
+func searchExplanationRepositoryResolvers(repos []*search.RepositoryRevisions) []*searchExplanationRepositoryResolver {
+	resolvers := make([]*searchExplanationRepositoryResolver, len(repos))
+	for i, repo := range repos {
+		resolvers[i] = &searchExplanationRepositoryResolver{repo}
+	}
+	return resolvers
+}
+
+// searchExplanationRepositoryResolver is a resolver for the GraphQL type
+// `SearchExplanationRepository`.
+type searchExplanationRepositoryResolver struct {
+	repo *search.RepositoryRevisions
+}
+
+func (r *searchExplanationRepositoryResolver) Repository() *RepositoryResolver {
+	return &RepositoryResolver{repo: r.repo.Repo}
+}
+
+func (r *searchExplanationRepositoryResolver) Revisions() []string {
+	revs := make([]string, len(r.repo.Revs))
+	for i, rev := range r.repo.Revs {
+		revs[i] = rev.String()
+	}
+	return revs
+}
+
+// searchBackendExplanationResolver is a resolver for the GraphQL type
+// `SearchBackendExplanation`.
+type searchBackendExplanationResolver struct {
+	b searchBackendExplanation
+}
+
+func (r *searchBackendExplanationResolver) Backend() string {
+	return r.b.backend
+}
+
+func (r *searchBackendExplanationResolver) Repositories() []*searchExplanationRepositoryResolver {
+	return searchExplanationRepositoryResolvers(r.b.repos)
+}
+
+func (r *searchBackendExplanationResolver) PatternInfo() JSONValue {
+	return JSONValue{r.b.patternInfo}
+}
+
+func (r *searchBackendExplanationResolver) ZoektQuery() *string {
+	if r.b.zoektQuery == "" {
+		return nil
+	}
+	return &r.b.zoektQuery
+}
+
+func (r *searchBackendExplanationResolver) DurationMilliseconds() int32 {
+	return int32(r.b.duration.Milliseconds())
+}
@@ -0,0 +1,141 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/zoekt"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/endpoint"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+func TestSearchExplainer_recordQuery(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		want  string
+	}{
+		{query: "r:foo bar", want: `(and "repo:foo" "bar")`},
+		{query: "repo:foo (a or b)", want: `(and "repo:foo" (or "a" "b"))`},
+	} {
+		q, err := query.ProcessAndOr(tc.query, query.SearchTypeLiteral)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e := &searchExplainer{}
+		e.recordQuery(q)
+		if have := (&searchExplanationResolver{explainer: e}).ParseTree(); have != tc.want {
+			t.Errorf("%q: got parse tree %s, want %s", tc.query, have, tc.want)
+		}
+	}
+
+	// Searches that aren't explained record nothing.
+	var e *searchExplainer
+	e.recordQuery(&query.OrdinaryQuery{})
+	e.recordRepositories(makeRepositoryRevisions("foo"), nil)
+	if _, b := e.startBackend(context.Background(), searchBackendSearcher, nil, &search.TextPatternInfo{}); b != nil {
+		t.Errorf("got backend explanation %+v, want nil", b)
+	}
+}
+
+func TestSearchExplainer_recordRepositories(t *testing.T) {
+	e := &searchExplainer{}
+	// The leaves of and/or queries resolve the same repositories.
+	e.recordRepositories(makeRepositoryRevisions("foo/a", "foo/b@dev"), makeRepositoryRevisions("foo/c@missing"))
+	e.recordRepositories(makeRepositoryRevisions("foo/a", "foo/b@main"), nil)
+
+	r := &searchExplanationResolver{explainer: e}
+	if have, want := explainedRepositories(r.Repositories()), []string{"foo/a@", "foo/b@dev", "foo/b@main"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("got repositories %v, want %v", have, want)
+	}
+	if have, want := explainedRepositories(r.MissingRepositories()), []string{"foo/c@missing"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("got missing repositories %v, want %v", have, want)
+	}
+}
+
+func TestSearchFilesInRepos_explain(t *testing.T) {
+	mockSearchFilesInRepo = func(ctx context.Context, repo *types.Repo, gitserverRepo gitserver.Repo, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration) (matches []*FileMatchResolver, limitHit bool, err error) {
+		if repo.Name == "foo/cloning" {
+			return nil, false, &vcs.RepoNotExistError{Repo: repo.Name, CloneInProgress: true}
+		}
+		return []*FileMatchResolver{{uri: "git://" + string(repo.Name) + "?" + rev + "#main.go"}}, false, nil
+	}
+	defer func() { mockSearchFilesInRepo = nil }()
+
+	q, err := query.ParseAndCheck("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := &search.TextParameters{
+		PatternInfo: &search.TextPatternInfo{
+			FileMatchLimit:         defaultMaxSearchResults,
+			Pattern:                "foo",
+			PathPatternsAreRegExps: true,
+		},
+		Repos: makeRepositoryRevisions("foo/indexed", "foo/unindexed", "foo/cloning"),
+		Query: q,
+		Zoekt: &searchbackend.Zoekt{
+			Client: &fakeSearcher{
+				repos: &zoekt.RepoList{Repos: []*zoekt.RepoListEntry{{
+					Repository: zoekt.Repository{
+						Name:     "foo/indexed",
+						Branches: []zoekt.RepositoryBranch{{Name: "HEAD", Version: "deadbeef"}},
+					},
+				}}},
+				result: &zoekt.SearchResult{},
+			},
+			DisableCache: true,
+		},
+		SearcherURLs: endpoint.Static("test"),
+	}
+
+	e := &searchExplainer{}
+	if _, _, err := searchFilesInRepos(withSearchExplainer(context.Background(), e), args); err != nil {
+		t.Fatal(err)
+	}
+
+	backends := (&searchExplanationResolver{explainer: e}).Backends()
+	if len(backends) != 2 {
+		t.Fatalf("got %d backends, want 2", len(backends))
+	}
+	// The zoekt backend is called concurrently with searcher, so the order
+	// of the backends isn't deterministic.
+	if backends[0].Backend() != searchBackendZoekt {
+		backends[0], backends[1] = backends[1], backends[0]
+	}
+	zoektBackend, searcherBackend := backends[0], backends[1]
+
+	if have, want := explainedRepositories(zoektBackend.Repositories()), []string{"foo/indexed@"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("got zoekt repositories %v, want %v", have, want)
+	}
+	if zoektBackend.ZoektQuery() == nil {
+		t.Error("got no zoekt query")
+	}
+	if have, want := explainedRepositories(searcherBackend.Repositories()), []string{"foo/unindexed@", "foo/cloning@"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("got searcher repositories %v, want %v", have, want)
+	}
+	if searcherBackend.Backend() != searchBackendSearcher || searcherBackend.ZoektQuery() != nil {
+		t.Errorf("unexpected searcher backend %+v", searcherBackend.b)
+	}
+	for _, b := range backends {
+		if have := b.PatternInfo().Value; !reflect.DeepEqual(have, *args.PatternInfo) {
+			t.Errorf("got %s pattern info %+v, want %+v", b.Backend(), have, *args.PatternInfo)
+		}
+	}
+}
+
+func explainedRepositories(repos []*searchExplanationRepositoryResolver) []string {
+	var names []string
+	for _, r := range repos {
+		for _, rev := range r.Revisions() {
+			names = append(names, string(r.repo.Repo.Name)+"@"+rev)
+		}
+	}
+	return names
+}
@@ -0,0 +1,417 @@
+package graphqlbackend
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// SearchExportDir is the directory where the files of search export jobs are
+// written.
+var SearchExportDir = env.Get("SEARCH_EXPORT_DIR", "/tmp/search-exports", "directory to store the files of search export jobs. It must be shared by all frontend instances.")
+
+const (
+	// SearchExportRetention is how long finished search export jobs and their
+	// files are kept.
+	SearchExportRetention = 7 * 24 * time.Hour
+
+	// SearchExportStaleAfter is how long a search export job can be
+	// processing without progress before it is assumed to have been abandoned
+	// and is resumed by another worker. It is longer than the timeout of a
+	// page of paginated search results.
+	SearchExportStaleAfter = 10 * time.Minute
+)
+
+func marshalSearchExportJobID(id int32) graphql.ID {
+	return relay.MarshalID("SearchExportJob", id)
+}
+
+func unmarshalSearchExportJobID(id graphql.ID) (jobID int32, err error) {
+	err = relay.UnmarshalSpec(id, &jobID)
+	return
+}
+
+func searchExportJobByID(ctx context.Context, id graphql.ID) (*searchExportJobResolver, error) {
+	jobID, err := unmarshalSearchExportJobID(id)
+	if err != nil {
+		return nil, err
+	}
+	job, err := db.SearchExportJobs.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only the owner of the job and site admins may see it.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, job.UserID); err != nil {
+		return nil, err
+	}
+	return &searchExportJobResolver{job: job}, nil
+}
+
+func (r *schemaResolver) SearchExportJobs(ctx context.Context) ([]*searchExportJobResolver, error) {
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, errors.New("no currently authenticated user")
+	}
+
+	jobs, err := db.SearchExportJobs.ListByUserID(ctx, currentUser.DatabaseID())
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*searchExportJobResolver, 0, len(jobs))
+	for _, job := range jobs {
+		resolvers = append(resolvers, &searchExportJobResolver{job: job})
+	}
+	return resolvers, nil
+}
+
+func (r *schemaResolver) CreateSearchExportJob(ctx context.Context, args *struct {
+	Query  string
+	Format string
+}) (*searchExportJobResolver, error) {
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, errors.New("no currently authenticated user")
+	}
+
+	format := types.SearchExportFormat(args.Format)
+	if err := validateSearchExportJob(args.Query, format); err != nil {
+		return nil, err
+	}
+
+	job, err := db.SearchExportJobs.Create(ctx, &types.SearchExportJob{
+		UserID: currentUser.DatabaseID(),
+		Query:  args.Query,
+		Format: format,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &searchExportJobResolver{job: job}, nil
+}
+
+func (r *schemaResolver) DeleteSearchExportJob(ctx context.Context, args *struct {
+	ID graphql.ID
+}) (*EmptyResponse, error) {
+	job, err := searchExportJobByID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	// A processing job stops when it fails to record its progress, and
+	// removes the file it was writing.
+	if err := db.SearchExportJobs.Delete(ctx, job.job.ID); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(SearchExportPath(job.job)); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+// validateSearchExportJob returns an error if the results of q can't be
+// exported in the given format.
+func validateSearchExportJob(q string, format types.SearchExportFormat) error {
+	switch format {
+	case types.SearchExportFormatJSONLines, types.SearchExportFormatCSV:
+	default:
+		return fmt.Errorf("invalid search export format %q", format)
+	}
+
+	if !queryHasPatternType(q) {
+		return errors.New("a `patternType:` filter is required in the query of search exports. `patternType` can be \"literal\" or \"regexp\"")
+	}
+	search, err := NewSearchImplementer(&SearchArgs{Version: "V2", Query: q})
+	if err != nil {
+		return err
+	}
+	switch r := search.(type) {
+	case *searchResolver:
+		for _, v := range r.query.Values(query.FieldType) {
+			if v.ToString() != "file" {
+				return errors.New("search exports only support text matches, remove the type: filter from the query")
+			}
+		}
+		return nil
+	case *searchAlert:
+		return errors.New(r.title)
+	default:
+		return fmt.Errorf("invalid search export query %q", q)
+	}
+}
+
+// SearchExportPath returns the path of the file of a search export job.
+func SearchExportPath(job *types.SearchExportJob) string {
+	ext := ".jsonl"
+	if job.Format == types.SearchExportFormatCSV {
+		ext = ".csv"
+	}
+	return filepath.Join(SearchExportDir, strconv.Itoa(int(job.ID))+ext)
+}
+
+// RunSearchExportJob writes all the results of a processing search export job
+// to its file, one page of paginated search results at a time. The progress of
+// the job is recorded after each page, and the job resumes from its last
+// recorded progress if it is run again, e.g. after the process running it
+// died. It returns nil once all results are written, or if the job was
+// deleted while it was running.
+func RunSearchExportJob(ctx context.Context, job *types.SearchExportJob) error {
+	// 🚨 SECURITY: The search is run with the permissions of the owner of the
+	// job, so that it only exports results from repositories they can access.
+	ctx = actor.WithActor(ctx, actor.FromUser(job.UserID))
+
+	if err := os.MkdirAll(SearchExportDir, 0700); err != nil {
+		return err
+	}
+	path := SearchExportPath(job)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.Size() < job.FileSize {
+		// The job was abandoned by a process whose file is missing or
+		// incomplete here, e.g. because SEARCH_EXPORT_DIR isn't shared by all
+		// frontend instances. Start over rather than resume with a corrupt
+		// file.
+		log15.Warn("restarting search export job with missing or incomplete file", "id", job.ID, "size", fi.Size(), "want", job.FileSize)
+		job.Cursor = ""
+		job.ResultCount = 0
+		job.RepoCount = 0
+		job.FileSize = 0
+	}
+
+	// Discard what was written after the last recorded progress.
+	if err := f.Truncate(job.FileSize); err != nil {
+		return err
+	}
+	if _, err := f.Seek(job.FileSize, io.SeekStart); err != nil {
+		return err
+	}
+	w := newSearchExportWriter(f, job.Format, job.FileSize)
+
+	for {
+		cursor, results, err := searchExportPage(ctx, job.Query, job.Cursor)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			fm, ok := result.ToFileMatch()
+			if !ok {
+				continue
+			}
+			for _, lm := range fm.JLineMatches {
+				if err := w.write(searchExportRow{
+					Repository: fm.Repo.Name(),
+					Revision:   string(fm.CommitID),
+					Path:       fm.JPath,
+					LineNumber: lm.JLineNumber + 1,
+					Preview:    lm.JPreview,
+				}); err != nil {
+					return err
+				}
+				job.ResultCount++
+			}
+		}
+		if err := w.flush(); err != nil {
+			return err
+		}
+
+		job.Cursor = marshalSearchCursor(cursor)
+		job.RepoCount = cursor.RepositoryOffset
+		job.FileSize = w.size
+		exists, err := db.SearchExportJobs.UpdateProgress(ctx, job)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			f.Close()
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		}
+		if cursor.Finished {
+			return nil
+		}
+	}
+}
+
+var mockSearchExportPage func(q, after string) (*searchCursor, []SearchResultResolver, error)
+
+// searchExportPage returns the next page of text matches of q after the given
+// pagination cursor, and the cursor of the following page.
+func searchExportPage(ctx context.Context, q, after string) (*searchCursor, []SearchResultResolver, error) {
+	if mockSearchExportPage != nil {
+		return mockSearchExportPage(q, after)
+	}
+
+	first := int32(maxSearchResultsPerPaginatedRequest)
+	args := &SearchArgs{Version: "V2", Query: q, First: &first}
+	if after != "" {
+		args.After = &after
+	}
+	search, err := NewSearchImplementer(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r, ok := search.(*searchResolver); ok && len(r.query.Values(query.FieldType)) == 0 {
+		// Paginated search only supports text matches. The query is parsed
+		// again rather than modifying the parsed query, whose fields may be
+		// derived from its parse tree.
+		if _, ok := r.query.(*query.AndOrQuery); ok {
+			args.Query = "type:file (" + q + ")"
+		} else {
+			args.Query = "type:file " + q
+		}
+		if search, err = NewSearchImplementer(args); err != nil {
+			return nil, nil, err
+		}
+	}
+	results, err := search.Results(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if results.cursor == nil {
+		if results.alert != nil {
+			return nil, nil, errors.New(results.alert.title)
+		}
+		return nil, nil, errors.New("the search returned no pagination cursor")
+	}
+	return results.cursor, results.SearchResults, nil
+}
+
+// searchExportRow is a row of a search export file.
+type searchExportRow struct {
+	Repository string `json:"repository"`
+	Revision   string `json:"revision"`
+	Path       string `json:"path"`
+	LineNumber int32  `json:"lineNumber"`
+	Preview    string `json:"preview"`
+}
+
+// searchExportWriter writes the rows of a search export file in its format and
+// counts the bytes written to the file.
+type searchExportWriter struct {
+	buf  *bufio.Writer
+	csv  *csv.Writer
+	json *json.Encoder
+	size int64
+}
+
+func newSearchExportWriter(w io.Writer, format types.SearchExportFormat, size int64) *searchExportWriter {
+	sw := &searchExportWriter{size: size}
+	sw.buf = bufio.NewWriter(&countingWriter{w: w, n: &sw.size})
+	if format == types.SearchExportFormatCSV {
+		sw.csv = csv.NewWriter(sw.buf)
+		if size == 0 {
+			_ = sw.csv.Write([]string{"repository", "revision", "path", "lineNumber", "preview"})
+		}
+	} else {
+		sw.json = json.NewEncoder(sw.buf)
+	}
+	return sw
+}
+
+func (w *searchExportWriter) write(row searchExportRow) error {
+	if w.csv != nil {
+		return w.csv.Write([]string{row.Repository, row.Revision, row.Path, strconv.Itoa(int(row.LineNumber)), row.Preview})
+	}
+	return w.json.Encode(row)
+}
+
+// flush writes the buffered rows to the underlying writer.
+func (w *searchExportWriter) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return w.buf.Flush()
+}
+
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	*w.n += int64(n)
+	return n, err
+}
+
+type searchExportJobResolver struct {
+	job *types.SearchExportJob
+}
+
+func (r *searchExportJobResolver) ID() graphql.ID { return marshalSearchExportJobID(r.job.ID) }
+
+func (r *searchExportJobResolver) Query() string { return r.job.Query }
+
+func (r *searchExportJobResolver) Format() string { return string(r.job.Format) }
+
+func (r *searchExportJobResolver) State() string { return string(r.job.State) }
+
+func (r *searchExportJobResolver) ResultCount() int32 { return r.job.ResultCount }
+
+func (r *searchExportJobResolver) RepositoriesSearched() int32 { return r.job.RepoCount }
+
+func (r *searchExportJobResolver) ByteSize() float64 { return float64(r.job.FileSize) }
+
+func (r *searchExportJobResolver) FailureMessage() *string {
+	if r.job.FailureMessage == "" {
+		return nil
+	}
+	return &r.job.FailureMessage
+}
+
+func (r *searchExportJobResolver) DownloadURL() *string {
+	if r.job.State != types.SearchExportJobStateCompleted {
+		return nil
+	}
+	url := fmt.Sprintf("/.api/search/exports/%d", r.job.ID)
+	return &url
+}
+
+func (r *searchExportJobResolver) CreatedAt() DateTime { return DateTime{Time: r.job.CreatedAt} }
+
+func (r *searchExportJobResolver) StartedAt() *DateTime { return DateTimeOrNil(r.job.StartedAt) }
+
+func (r *searchExportJobResolver) FinishedAt() *DateTime { return DateTimeOrNil(r.job.FinishedAt) }
+
+func (r *searchExportJobResolver) ExpiresAt() *DateTime {
+	if r.job.FinishedAt == nil {
+		return nil
+	}
+	return &DateTime{Time: r.job.FinishedAt.Add(SearchExportRetention)}
+}
@@ -0,0 +1,154 @@
+package graphqlbackend
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestValidateSearchExportJob(t *testing.T) {
+	for _, tc := range []struct {
+		query   string
+		format  types.SearchExportFormat
+		wantErr string
+	}{
+		{query: "foo patternType:literal", format: types.SearchExportFormatJSONLines},
+		{query: "foo type:file patternType:regexp", format: types.SearchExportFormatCSV},
+		{query: "foo patternType:literal", format: "XML", wantErr: `invalid search export format "XML"`},
+		{query: "foo", format: types.SearchExportFormatCSV, wantErr: "a `patternType:` filter is required in the query of search exports. `patternType` can be \"literal\" or \"regexp\""},
+		{query: "foo type:diff patternType:literal", format: types.SearchExportFormatCSV, wantErr: "search exports only support text matches, remove the type: filter from the query"},
+	} {
+		if have := errString(validateSearchExportJob(tc.query, tc.format)); have != tc.wantErr {
+			t.Errorf("%q: got error %q, want %q", tc.query, have, tc.wantErr)
+		}
+	}
+}
+
+func TestRunSearchExportJob(t *testing.T) {
+	defer resetMocks()
+	defer func() { mockSearchExportPage = nil }()
+
+	dir, err := ioutil.TempDir("", "search-exports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(old string) { SearchExportDir = old }(SearchExportDir)
+	SearchExportDir = dir
+
+	repo := &RepositoryResolver{repo: &types.Repo{Name: "github.com/a/b"}}
+	pages := map[string]*searchCursor{
+		"": {RepositoryOffset: 1, ResultOffset: 1},
+		marshalSearchCursor(&searchCursor{RepositoryOffset: 1, ResultOffset: 1}): {RepositoryOffset: 2, Finished: true},
+	}
+	mockSearchExportPage = func(q, after string) (*searchCursor, []SearchResultResolver, error) {
+		cursor, ok := pages[after]
+		if !ok {
+			t.Fatalf("unexpected cursor %q", after)
+		}
+		return cursor, []SearchResultResolver{
+			&FileMatchResolver{
+				JPath:    "a.go",
+				Repo:     repo,
+				CommitID: "c1",
+				JLineMatches: []*lineMatch{
+					{JPreview: `fmt.Println("a, b")`, JLineNumber: int32(cursor.RepositoryOffset)},
+				},
+			},
+			repo,
+		}, nil
+	}
+
+	var progress []types.SearchExportJob
+	var deleted bool
+	db.Mocks.SearchExportJobs.UpdateProgress = func(ctx context.Context, job *types.SearchExportJob) (bool, error) {
+		progress = append(progress, *job)
+		return !deleted, nil
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		progress = nil
+		job := &types.SearchExportJob{ID: 1, Format: types.SearchExportFormatCSV}
+		if err := RunSearchExportJob(context.Background(), job); err != nil {
+			t.Fatal(err)
+		}
+		want := "repository,revision,path,lineNumber,preview\n" +
+			"github.com/a/b,c1,a.go,2,\"fmt.Println(\"\"a, b\"\")\"\n" +
+			"github.com/a/b,c1,a.go,3,\"fmt.Println(\"\"a, b\"\")\"\n"
+		checkSearchExportFile(t, job, want)
+		if len(progress) != 2 || progress[1].ResultCount != 2 || progress[1].RepoCount != 2 || progress[1].FileSize != int64(len(want)) {
+			t.Errorf("unexpected progress %+v", progress)
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		progress = nil
+		job := &types.SearchExportJob{ID: 2, Format: types.SearchExportFormatJSONLines}
+		first := `{"repository":"github.com/a/b","revision":"c1","path":"a.go","lineNumber":2,"preview":"fmt.Println(\"a, b\")"}` + "\n"
+		// The file contains a partially written page after the recorded
+		// progress, which is discarded.
+		if err := ioutil.WriteFile(SearchExportPath(job), []byte(first+`{"repository":`), 0600); err != nil {
+			t.Fatal(err)
+		}
+		job.Cursor = marshalSearchCursor(&searchCursor{RepositoryOffset: 1, ResultOffset: 1})
+		job.ResultCount = 1
+		job.FileSize = int64(len(first))
+
+		if err := RunSearchExportJob(context.Background(), job); err != nil {
+			t.Fatal(err)
+		}
+		checkSearchExportFile(t, job, first+`{"repository":"github.com/a/b","revision":"c1","path":"a.go","lineNumber":3,"preview":"fmt.Println(\"a, b\")"}`+"\n")
+		if len(progress) != 1 || progress[0].ResultCount != 2 {
+			t.Errorf("unexpected progress %+v", progress)
+		}
+	})
+
+	t.Run("resume without file", func(t *testing.T) {
+		progress = nil
+		// The job was abandoned by a process whose file isn't available
+		// here, so it starts over.
+		job := &types.SearchExportJob{ID: 4, Format: types.SearchExportFormatCSV}
+		job.Cursor = marshalSearchCursor(&searchCursor{RepositoryOffset: 1, ResultOffset: 1})
+		job.ResultCount = 1
+		job.RepoCount = 1
+		job.FileSize = 100
+
+		if err := RunSearchExportJob(context.Background(), job); err != nil {
+			t.Fatal(err)
+		}
+		want := "repository,revision,path,lineNumber,preview\n" +
+			"github.com/a/b,c1,a.go,2,\"fmt.Println(\"\"a, b\"\")\"\n" +
+			"github.com/a/b,c1,a.go,3,\"fmt.Println(\"\"a, b\"\")\"\n"
+		checkSearchExportFile(t, job, want)
+		if len(progress) != 2 || progress[1].ResultCount != 2 || progress[1].FileSize != int64(len(want)) {
+			t.Errorf("unexpected progress %+v", progress)
+		}
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		deleted = true
+		defer func() { deleted = false }()
+		job := &types.SearchExportJob{ID: 3, Format: types.SearchExportFormatJSONLines}
+		if err := RunSearchExportJob(context.Background(), job); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(SearchExportPath(job)); !os.IsNotExist(err) {
+			t.Errorf("got error %v, want the file of the deleted job to be removed", err)
+		}
+	})
+}
+
+func checkSearchExportFile(t *testing.T, job *types.SearchExportJob, want string) {
+	t.Helper()
+	have, err := ioutil.ReadFile(SearchExportPath(job))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(have) != want {
+		t.Errorf("got file\n%s\nwant\n%s", have, want)
+	}
+}
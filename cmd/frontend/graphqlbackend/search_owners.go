@@ -0,0 +1,193 @@
+package graphqlbackend
+
+import (
+	"context"
+	"math"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// partitionOwnerPredicates splits the values of file: filters into path
+// patterns and the owners of file:has.owner(...) values.
+func partitionOwnerPredicates(values []string) (patterns, owners []string, err error) {
+	for _, v := range values {
+		owner, ok, err := query.ParseOwnerPredicate(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			owners = append(owners, owner)
+		} else {
+			patterns = append(patterns, v)
+		}
+	}
+	return patterns, owners, nil
+}
+
+// ownerFilterFetchFactor is the factor by which the file match limit is raised
+// for the search backends when results are filtered by owner. The owner filter
+// is applied to the matches returned by the backends, so a search must fetch
+// more matches than it returns to not stop early with few or no results.
+const ownerFilterFetchFactor = 10
+
+// hasOwnerFilter reports whether p filters files by owner.
+func hasOwnerFilter(p *search.TextPatternInfo) bool {
+	return len(p.IncludeOwners) > 0 || len(p.ExcludeOwners) > 0
+}
+
+// ownerFilterFetchLimit returns the number of matches to fetch from the search
+// backends to return up to limit matches filtered by the owners of p.
+func ownerFilterFetchLimit(p *search.TextPatternInfo, limit int32) int32 {
+	if !hasOwnerFilter(p) {
+		return limit
+	}
+	if limit > math.MaxInt32/ownerFilterFetchFactor {
+		return math.MaxInt32
+	}
+	return limit * ownerFilterFetchFactor
+}
+
+// ownerFilterFetchArgs returns args with the FileMatchLimit raised to
+// ownerFilterFetchLimit. It returns args itself if it doesn't filter by owner.
+func ownerFilterFetchArgs(args *search.TextParameters) *search.TextParameters {
+	if !hasOwnerFilter(args.PatternInfo) {
+		return args
+	}
+	argsCopy := *args
+	patternCopy := *args.PatternInfo
+	patternCopy.FileMatchLimit = ownerFilterFetchLimit(args.PatternInfo, args.PatternInfo.FileMatchLimit)
+	argsCopy.PatternInfo = &patternCopy
+	return &argsCopy
+}
+
+// filterFileMatchesByOwner returns the file matches whose files have all the
+// IncludeOwners and none of the ExcludeOwners of p, according to the
+// CODEOWNERS file of their repository at the commit of the match. The files of
+// repositories without a CODEOWNERS file have no owners.
+//
+// The matches of repositories whose CODEOWNERS file can't be read are omitted,
+// and the errors are returned by repository.
+func filterFileMatchesByOwner(ctx context.Context, p *search.TextPatternInfo, matches []*FileMatchResolver) ([]*FileMatchResolver, map[api.RepoName]error) {
+	if !hasOwnerFilter(p) {
+		return matches, nil
+	}
+
+	var failed map[api.RepoName]error
+	filtered := matches[:0:0]
+	for _, fm := range matches {
+		name := fm.Repo.repo.Name
+		if _, ok := failed[name]; ok {
+			continue
+		}
+		rs, err := backend.CodeOwners(ctx, gitserver.Repo{Name: name}, fm.CommitID)
+		if err != nil {
+			if failed == nil {
+				failed = make(map[api.RepoName]error)
+			}
+			failed[name] = err
+			continue
+		}
+		if isOwnedBy(rs, fm.JPath, p.IncludeOwners, p.ExcludeOwners) {
+			filtered = append(filtered, fm)
+		}
+	}
+	return filtered, failed
+}
+
+// markOwnerFilterFailures marks the repositories whose matches were omitted by
+// filterFileMatchesByOwner as partially searched.
+func markOwnerFilterFailures(common *searchResultsCommon, failed map[api.RepoName]error) {
+	for repo, err := range failed {
+		log15.Warn("filtering search results by owner failed", "error", err, "repo", repo)
+		common.partial[repo] = struct{}{}
+	}
+}
+
+func isOwnedBy(rs *codeowners.Ruleset, path string, includeOwners, excludeOwners []string) bool {
+	if rs == nil {
+		return len(includeOwners) == 0
+	}
+	for _, owner := range includeOwners {
+		if !rs.IsOwnedBy(path, owner) {
+			return false
+		}
+	}
+	for _, owner := range excludeOwners {
+		if rs.IsOwnedBy(path, owner) {
+			return false
+		}
+	}
+	return true
+}
+
+// Ownership returns the ownership of the file according to the CODEOWNERS
+// file of its repository at the commit of the match, or nil if the repository
+// has no CODEOWNERS file.
+func (fm *FileMatchResolver) Ownership(ctx context.Context) (*fileOwnershipResolver, error) {
+	rs, err := backend.CodeOwners(ctx, gitserver.Repo{Name: fm.Repo.repo.Name}, fm.CommitID)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	return &fileOwnershipResolver{ruleset: rs, path: fm.JPath}, nil
+}
+
+// fileOwnershipResolver is a resolver for the GraphQL type `FileOwnership`.
+type fileOwnershipResolver struct {
+	ruleset *codeowners.Ruleset
+	path    string
+}
+
+func (r *fileOwnershipResolver) CodeOwnersPath() string {
+	return r.ruleset.Path
+}
+
+func (r *fileOwnershipResolver) Owners() []string {
+	return nonNilStrings(r.ruleset.Owners(r.path))
+}
+
+func (r *fileOwnershipResolver) Rules() []*codeOwnersRuleResolver {
+	rules := r.ruleset.Match(r.path)
+	resolvers := make([]*codeOwnersRuleResolver, len(rules))
+	for i, rule := range rules {
+		resolvers[i] = &codeOwnersRuleResolver{rule}
+	}
+	return resolvers
+}
+
+// codeOwnersRuleResolver is a resolver for the GraphQL type `CodeOwnersRule`.
+type codeOwnersRuleResolver struct {
+	rule *codeowners.Rule
+}
+
+func (r *codeOwnersRuleResolver) Pattern() string {
+	return r.rule.Pattern
+}
+
+func (r *codeOwnersRuleResolver) Owners() []string {
+	return nonNilStrings(r.rule.Owners)
+}
+
+func (r *codeOwnersRuleResolver) Section() *string {
+	if r.rule.Section == "" {
+		return nil
+	}
+	return &r.rule.Section
+}
+
+func (r *codeOwnersRuleResolver) LineNumber() int32 {
+	return int32(r.rule.LineNumber)
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,261 @@
+package graphqlbackend
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/zoekt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/endpoint"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func mockCodeOwners(t *testing.T) {
+	t.Helper()
+	rs, err := codeowners.Parse(".github/CODEOWNERS", strings.NewReader(`
+*       @org/core
+/web/   @org/frontend @org/core
+*_test.go
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.Mocks.CodeOwners = func(ctx context.Context, repo gitserver.Repo, commitID api.CommitID) (*codeowners.Ruleset, error) {
+		if repo.Name == "github.com/a/owned" && commitID == "c1" {
+			return rs, nil
+		}
+		return nil, nil
+	}
+}
+
+func TestFilterFileMatchesByOwner(t *testing.T) {
+	mockCodeOwners(t)
+	defer func() { backend.Mocks = backend.MockServices{} }()
+
+	owned := &RepositoryResolver{repo: &types.Repo{Name: "github.com/a/owned"}}
+	unowned := &RepositoryResolver{repo: &types.Repo{Name: "github.com/a/unowned"}}
+	matches := []*FileMatchResolver{
+		{Repo: owned, CommitID: "c1", JPath: "main.go"},
+		{Repo: owned, CommitID: "c1", JPath: "main_test.go"},
+		{Repo: owned, CommitID: "c1", JPath: "web/index.ts"},
+		{Repo: unowned, CommitID: "c1", JPath: "main.go"},
+	}
+
+	for _, tc := range []struct {
+		query string
+		want  []string
+	}{
+		{query: "p", want: []string{"github.com/a/owned/main.go", "github.com/a/owned/main_test.go", "github.com/a/owned/web/index.ts", "github.com/a/unowned/main.go"}},
+		{query: "p file:has.owner(@org/core)", want: []string{"github.com/a/owned/main.go", "github.com/a/owned/web/index.ts"}},
+		{query: "p file:has.owner(org/CORE) file:has.owner(@org/frontend)", want: []string{"github.com/a/owned/web/index.ts"}},
+		{query: "p -file:has.owner(@org/frontend)", want: []string{"github.com/a/owned/main.go", "github.com/a/owned/main_test.go", "github.com/a/unowned/main.go"}},
+	} {
+		q, err := query.ParseAndCheck(tc.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := getPatternInfo(q, &getPatternInfoOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		filtered, failed := filterFileMatchesByOwner(context.Background(), p, matches)
+		if len(failed) > 0 {
+			t.Fatal(failed)
+		}
+		var have []string
+		for _, fm := range filtered {
+			have = append(have, fm.Repo.Name()+"/"+fm.JPath)
+		}
+		if !reflect.DeepEqual(have, tc.want) {
+			t.Errorf("%q: got %q, want %q", tc.query, have, tc.want)
+		}
+	}
+}
+
+func TestFilterFileMatchesByOwner_failedRepo(t *testing.T) {
+	mockCodeOwners(t)
+	defer func() { backend.Mocks = backend.MockServices{} }()
+	codeOwners := backend.Mocks.CodeOwners
+	errFailed := errors.New("failed")
+	backend.Mocks.CodeOwners = func(ctx context.Context, repo gitserver.Repo, commitID api.CommitID) (*codeowners.Ruleset, error) {
+		if repo.Name == "github.com/a/failed" {
+			return nil, errFailed
+		}
+		return codeOwners(ctx, repo, commitID)
+	}
+
+	owned := &RepositoryResolver{repo: &types.Repo{Name: "github.com/a/owned"}}
+	failedRepo := &RepositoryResolver{repo: &types.Repo{Name: "github.com/a/failed"}}
+	matches := []*FileMatchResolver{
+		{Repo: failedRepo, CommitID: "c1", JPath: "main.go"},
+		{Repo: owned, CommitID: "c1", JPath: "main.go"},
+		{Repo: failedRepo, CommitID: "c1", JPath: "web/index.ts"},
+	}
+
+	// The matches of the failed repository are omitted without affecting
+	// those of other repositories.
+	p := &search.TextPatternInfo{IncludeOwners: []string{"@org/core"}}
+	filtered, failed := filterFileMatchesByOwner(context.Background(), p, matches)
+	if len(filtered) != 1 || filtered[0] != matches[1] {
+		t.Errorf("got matches %+v, want only %+v", filtered, matches[1])
+	}
+	if want := map[api.RepoName]error{"github.com/a/failed": errFailed}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("got failed repos %v, want %v", failed, want)
+	}
+
+	common := &searchResultsCommon{partial: make(map[api.RepoName]struct{})}
+	markOwnerFilterFailures(common, failed)
+	if _, ok := common.partial["github.com/a/failed"]; !ok || len(common.partial) != 1 {
+		t.Errorf("got partial repos %v, want github.com/a/failed", common.partial)
+	}
+}
+
+func TestOwnerFilterFetchArgs(t *testing.T) {
+	args := &search.TextParameters{PatternInfo: &search.TextPatternInfo{FileMatchLimit: 30}}
+	if got := ownerFilterFetchArgs(args); got != args {
+		t.Errorf("got args %+v, want unchanged args without owner filter", got)
+	}
+
+	args.PatternInfo.ExcludeOwners = []string{"@org/core"}
+	if got := ownerFilterFetchArgs(args).PatternInfo.FileMatchLimit; got != 30*ownerFilterFetchFactor {
+		t.Errorf("got FileMatchLimit %d, want %d", got, 30*ownerFilterFetchFactor)
+	}
+	if args.PatternInfo.FileMatchLimit != 30 {
+		t.Errorf("FileMatchLimit of the original args changed to %d", args.PatternInfo.FileMatchLimit)
+	}
+
+	if got := ownerFilterFetchLimit(args.PatternInfo, math.MaxInt32/2); got != math.MaxInt32 {
+		t.Errorf("got limit %d, want %d", got, math.MaxInt32)
+	}
+}
+
+func TestSearchFilesInRepos_ownerFilter(t *testing.T) {
+	mockCodeOwners(t)
+	defer func() { backend.Mocks = backend.MockServices{} }()
+	codeOwners := backend.Mocks.CodeOwners
+	backend.Mocks.CodeOwners = func(ctx context.Context, repo gitserver.Repo, commitID api.CommitID) (*codeowners.Ruleset, error) {
+		if repo.Name == "github.com/a/failed" {
+			return nil, errors.New("failed")
+		}
+		return codeOwners(ctx, repo, commitID)
+	}
+
+	var (
+		mu          sync.Mutex
+		fetchLimits []int32
+	)
+	mockSearchFilesInRepo = func(ctx context.Context, repo *types.Repo, gitserverRepo gitserver.Repo, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration) (matches []*FileMatchResolver, limitHit bool, err error) {
+		mu.Lock()
+		fetchLimits = append(fetchLimits, info.FileMatchLimit)
+		mu.Unlock()
+
+		// The files owned by @org/core come after those which aren't, so they
+		// are only found when more matches than the limit are fetched.
+		paths := []string{"a_test.go", "b_test.go", "main.go", "web/index.ts"}
+		if len(paths) > int(info.FileMatchLimit) {
+			paths, limitHit = paths[:info.FileMatchLimit], true
+		}
+		for _, path := range paths {
+			matches = append(matches, &FileMatchResolver{
+				Repo:     &RepositoryResolver{repo: repo},
+				CommitID: "c1",
+				JPath:    path,
+				uri:      "git://" + string(repo.Name) + "?c1#" + path,
+			})
+		}
+		return matches, limitHit, nil
+	}
+	defer func() { mockSearchFilesInRepo = nil }()
+
+	q, err := query.ParseAndCheck("foo file:has.owner(@org/core)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The limit is not exceeded by the owned files, so the search isn't
+	// canceled before all repositories are searched.
+	args := &search.TextParameters{
+		PatternInfo: &search.TextPatternInfo{
+			FileMatchLimit: 2,
+			Pattern:        "foo",
+			IncludeOwners:  []string{"@org/core"},
+		},
+		Repos:        makeRepositoryRevisions("github.com/a/owned", "github.com/a/failed"),
+		Query:        q,
+		Zoekt:        &searchbackend.Zoekt{Client: &fakeSearcher{repos: &zoekt.RepoList{}}},
+		SearcherURLs: endpoint.Static("test"),
+	}
+	results, common, err := searchFilesInRepos(context.Background(), args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// More matches than the limit are fetched, so that the owned files are
+	// found.
+	if want := []int32{2 * ownerFilterFetchFactor, 2 * ownerFilterFetchFactor}; !reflect.DeepEqual(fetchLimits, want) {
+		t.Errorf("got fetch limits %v, want %v", fetchLimits, want)
+	}
+	var have []string
+	for _, fm := range results {
+		have = append(have, fm.Repo.Name()+"/"+fm.JPath)
+	}
+	sort.Strings(have)
+	if want := []string{"github.com/a/owned/main.go", "github.com/a/owned/web/index.ts"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("got results %q, want %q", have, want)
+	}
+	// The repository whose CODEOWNERS can't be read doesn't fail the search.
+	if _, ok := common.partial["github.com/a/failed"]; !ok {
+		t.Errorf("got partial repos %v, want github.com/a/failed", common.partial)
+	}
+}
+
+func TestGetPatternInfo_invalidOwner(t *testing.T) {
+	q, err := query.ProcessAndOr("p file:has.owner()", query.SearchTypeLiteral)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getPatternInfo(q, &getPatternInfoOptions{}); err == nil {
+		t.Error("got no error for a has.owner filter without an owner")
+	}
+}
+
+func TestFileMatchResolver_Ownership(t *testing.T) {
+	mockCodeOwners(t)
+	defer func() { backend.Mocks = backend.MockServices{} }()
+
+	ctx := context.Background()
+	owned := &RepositoryResolver{repo: &types.Repo{Name: "github.com/a/owned"}}
+
+	ownership, err := (&FileMatchResolver{Repo: owned, CommitID: "c1", JPath: "web/app_test.go"}).Ownership(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ownership == nil || ownership.CodeOwnersPath() != ".github/CODEOWNERS" {
+		t.Fatalf("unexpected ownership %+v", ownership)
+	}
+	// The last matching rule has no owners.
+	if owners := ownership.Owners(); len(owners) != 0 {
+		t.Errorf("got owners %q, want none", owners)
+	}
+	if rules := ownership.Rules(); len(rules) != 1 || rules[0].Pattern() != "*_test.go" || rules[0].LineNumber() != 4 || rules[0].Section() != nil {
+		t.Errorf("unexpected rules %+v", rules)
+	}
+
+	ownership, err = (&FileMatchResolver{Repo: owned, CommitID: "c2", JPath: "main.go"}).Ownership(ctx)
+	if err != nil || ownership != nil {
+		t.Errorf("got (%+v, %v), want no ownership for a commit without CODEOWNERS", ownership, err)
+	}
+}
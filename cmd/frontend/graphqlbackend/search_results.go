@@ -482,7 +482,7 @@ loop:
 				}
 				addPoint(t)
 			})
-		case *codemodResultResolver:
+		case *codemodResultResolver, *captureGroupResultResolver:
 			continue
 		default:
 			panic("SearchResults.Sparkline unexpected union type state")
@@ -583,7 +583,9 @@ func (r *searchResolver) evaluateLeaf(ctx context.Context) (*SearchResultsResolv
 	start := time.Now()
 	// If the request specifies stable:truthy, use pagination to return a stable ordering.
 	if r.query.BoolValue("stable") {
-		result, err := r.paginatedResults(ctx)
+		result, err := r.collectResults(func() (*SearchResultsResolver, error) {
+			return r.paginatedResults(ctx)
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -606,7 +608,9 @@ func (r *searchResolver) evaluateLeaf(ctx context.Context) (*SearchResultsResolv
 	// If the request is a paginated one, we handle it separately. See
 	// paginatedResults for more details.
 	if r.pagination != nil {
-		return r.paginatedResults(ctx)
+		return r.collectResults(func() (*SearchResultsResolver, error) {
+			return r.paginatedResults(ctx)
+		})
 	}
 
 	rr, err := r.resultsWithTimeoutSuggestion(ctx)
@@ -759,6 +763,88 @@ func intersect(left, right *SearchResultsResolver) *SearchResultsResolver {
 	return intersectMerge(left, right)
 }
 
+// difference returns the results of left without the file matches for files
+// that have file matches in right.
+func difference(left, right *SearchResultsResolver) *SearchResultsResolver {
+	if left == nil || right == nil {
+		return left
+	}
+
+	excluded := make(map[string]struct{})
+	for _, r := range right.SearchResults {
+		if fileMatch, ok := r.ToFileMatch(); ok {
+			excluded[fileMatch.uri] = struct{}{}
+		}
+	}
+
+	var kept []SearchResultResolver
+	for _, leftMatch := range left.SearchResults {
+		if fileMatch, ok := leftMatch.ToFileMatch(); ok {
+			if _, ok := excluded[fileMatch.uri]; ok {
+				continue
+			}
+		}
+		kept = append(kept, leftMatch)
+	}
+	left.SearchResults = kept
+	left.searchResultsCommon.update(right.searchResultsCommon)
+	left.searchResultsCommon.resultCount = int32(len(kept))
+	return left
+}
+
+// partitionNegatedPatterns partitions operands into negated search patterns
+// and all other operands.
+func partitionNegatedPatterns(operands []query.Node) (rest, negated []query.Node) {
+	for _, operand := range operands {
+		if pattern, ok := operand.(query.Pattern); ok && pattern.Negated {
+			negated = append(negated, operand)
+		} else {
+			rest = append(rest, operand)
+		}
+	}
+	return rest, negated
+}
+
+// evaluateAndNot evaluates an and-expression of operands and negated search
+// patterns by performing a set difference on file paths: it removes the files
+// that match any negated pattern from the results for operands. It is used
+// when the negated patterns can't be pushed down to the search backends.
+func (r *searchResolver) evaluateAndNot(ctx context.Context, scopeParameters, operands, negated []query.Node) (*SearchResultsResolver, error) {
+	result, err := r.evaluateAnd(ctx, scopeParameters, operands)
+	if err != nil || result == nil {
+		return result, err
+	}
+
+	// We need all files that match a negated pattern, not just the first
+	// page of them.
+	var hasCount bool
+	excludeParameters := query.MapParameter(scopeParameters, func(field, value string, negated bool) query.Node {
+		if field == "count" {
+			hasCount = true
+			value = strconv.Itoa(maxExcludedFiles)
+		}
+		return query.Parameter{Field: field, Value: value, Negated: negated}
+	})
+	if !hasCount {
+		excludeParameters = append(excludeParameters, query.Parameter{Field: "count", Value: strconv.Itoa(maxExcludedFiles)})
+	}
+
+	for _, node := range negated {
+		pattern := node.(query.Pattern)
+		pattern.Negated = false
+		excluded, err := r.evaluatePatternExpression(ctx, excludeParameters, pattern)
+		if err != nil {
+			return nil, err
+		}
+		result = difference(result, excluded)
+	}
+	return result, nil
+}
+
+// maxExcludedFiles is the maximum number of files matching a negated search
+// pattern that evaluateAndNot excludes from results.
+const maxExcludedFiles = 20000
+
 // evaluateAnd performs set intersection on result sets. It collects results for
 // all expressions that are ANDed together by searching for each subexpression
 // and then intersects those results that are in the same repo/file path. To
@@ -768,6 +854,18 @@ func intersect(left, right *SearchResultsResolver) *SearchResultsResolver {
 // each expression, and if the intersection does not yield N results, and is not
 // exhaustive for every expression, we rerun the search by doubling count again.
 func (r *searchResolver) evaluateAnd(ctx context.Context, scopeParameters []query.Node, operands []query.Node) (*SearchResultsResolver, error) {
+	// Negated search patterns exclude files whose content matches them.
+	// Zoekt and searcher evaluate this natively, so we push negated patterns
+	// down into the searches for the other operands. Structural search
+	// can't, so we evaluate those by set difference instead.
+	operands, negated := partitionNegatedPatterns(operands)
+	if len(negated) > 0 {
+		if r.patternType == query.SearchTypeStructural {
+			return r.evaluateAndNot(ctx, scopeParameters, operands, negated)
+		}
+		scopeParameters = append(append([]query.Node{}, scopeParameters...), negated...)
+	}
+
 	if len(operands) == 0 {
 		return nil, nil
 	}
@@ -945,7 +1043,11 @@ func (r *searchResolver) evaluate(ctx context.Context, q []query.Node) (*SearchR
 		r.query.(*query.AndOrQuery).Query = scopeParameters
 		return r.evaluateLeaf(ctx)
 	}
-	result, err := r.evaluatePatternExpression(ctx, scopeParameters, pattern)
+	// The results of and/or expressions are only known once all operands are
+	// evaluated, so they are not streamed as they are found.
+	result, err := r.collectResults(func() (*SearchResultsResolver, error) {
+		return r.evaluatePatternExpression(ctx, scopeParameters, pattern)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -954,8 +1056,33 @@ func (r *searchResolver) evaluate(ctx context.Context, q []query.Node) (*SearchR
 }
 
 func (r *searchResolver) Results(ctx context.Context) (*SearchResultsResolver, error) {
+	selector, err := r.searchSelector()
+	if err != nil {
+		return &SearchResultsResolver{alert: alertForQuery(r.rawQuery(), err)}, nil
+	}
+	if selector == nil {
+		return r.results(ctx)
+	}
+
+	// Selected results are deduplicated across the whole result set, so
+	// they are only known once the search is complete.
+	return r.collectResults(func() (*SearchResultsResolver, error) {
+		result, err := r.results(ctx)
+		if err != nil || result == nil {
+			return result, err
+		}
+		result.SearchResults, err = selector.selectResults(ctx, result.SearchResults)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+}
+
+func (r *searchResolver) results(ctx context.Context) (*SearchResultsResolver, error) {
 	switch q := r.query.(type) {
 	case *query.OrdinaryQuery:
+		searchExplainerFromContext(ctx).recordQuery(q)
 		return r.evaluateLeaf(ctx)
 	case *query.AndOrQuery:
 		// Get settings to check if `search.uppercase` is active. If so, run transformer.
@@ -966,6 +1093,7 @@ func (r *searchResolver) Results(ctx context.Context) (*SearchResultsResolver, e
 		if v := settings.SearchUppercase; v != nil && *v {
 			q.Query = query.SearchUppercase(q.Query)
 		}
+		searchExplainerFromContext(ctx).recordQuery(q)
 		return r.evaluate(ctx, q.Query)
 	}
 	// Unreachable.
@@ -1232,6 +1360,23 @@ func getPatternInfo(q query.QueryInfo, opts *getPatternInfoOptions) (*search.Tex
 	includePatterns, excludePatterns := q.RegexpPatterns(query.FieldFile)
 	filePatternsReposMustInclude, filePatternsReposMustExclude := q.RegexpPatterns(query.FieldRepoHasFile)
 
+	// Handle file:has.owner(...) and -file:has.owner(...) filters, which are
+	// applied to the files found rather than sent to the backends as path
+	// patterns.
+	includePatterns, includeOwners, err := partitionOwnerPredicates(includePatterns)
+	if err != nil {
+		return nil, err
+	}
+	excludePatterns, excludeOwners, err := partitionOwnerPredicates(excludePatterns)
+	if err != nil {
+		return nil, err
+	}
+	if pattern == "" && len(includePatterns) == 0 && len(excludePatterns) == 0 && (len(includeOwners) > 0 || len(excludeOwners) > 0) {
+		// Owner filters alone match the paths of all the files with (or
+		// without) the owners, so match all paths before filtering them.
+		includePatterns = []string{""}
+	}
+
 	if opts.forceFileSearch {
 		for _, v := range q.Values(query.FieldDefault) {
 			includePatterns = append(includePatterns, v.ToString())
@@ -1253,6 +1398,20 @@ func getPatternInfo(q query.QueryInfo, opts *getPatternInfoOptions) (*search.Tex
 
 	languages, _ := q.StringValues(query.FieldLang)
 
+	// Negated search patterns of and/or queries exclude files whose content
+	// matches them.
+	var excludeContentPatterns []string
+	if andOrQuery, ok := q.(*query.AndOrQuery); ok && !opts.performStructuralSearch {
+		for _, v := range andOrQuery.NegatedPatternValues() {
+			switch {
+			case v.String != nil:
+				excludeContentPatterns = append(excludeContentPatterns, regexp.QuoteMeta(*v.String))
+			case v.Regexp != nil:
+				excludeContentPatterns = append(excludeContentPatterns, v.Regexp.String())
+			}
+		}
+	}
+
 	patternInfo := &search.TextPatternInfo{
 		IsRegExp:                     isRegExp,
 		IsStructuralPat:              isStructuralPat,
@@ -1260,8 +1419,11 @@ func getPatternInfo(q query.QueryInfo, opts *getPatternInfoOptions) (*search.Tex
 		FileMatchLimit:               opts.fileMatchLimit,
 		Pattern:                      pattern,
 		IncludePatterns:              includePatterns,
+		ExcludeContentPatterns:       excludeContentPatterns,
 		FilePatternsReposMustInclude: filePatternsReposMustInclude,
 		FilePatternsReposMustExclude: filePatternsReposMustExclude,
+		IncludeOwners:                includeOwners,
+		ExcludeOwners:                excludeOwners,
 		PathPatternsAreRegExps:       true,
 		Languages:                    languages,
 		PathPatternsAreCaseSensitive: q.IsCaseSensitive(),
@@ -1437,6 +1599,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 	if alertResult != nil {
 		return alertResult, nil
 	}
+	searchExplainerFromContext(ctx).recordRepositories(repos, missingRepoRevs)
 
 	options := &getPatternInfoOptions{}
 	if r.patternType == query.SearchTypeStructural {
@@ -1492,6 +1655,9 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 		// to merge multiple results of different types for the same file
 		fileMatches   = make(map[string]*FileMatchResolver)
 		fileMatchesMu sync.Mutex
+		// Streamed results are owned by the receiver of the result channel,
+		// so file matches of different types are only merged when not streaming.
+		mergeFileMatches = r.resultChannel == nil
 		// Alert is a potential alert shown to the user.
 		alert           *searchAlert
 		seenResultTypes = make(map[string]struct{})
@@ -1549,6 +1715,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					common.update(*repoCommon)
 					commonMu.Unlock()
 				}
+				sendSearchEvent(r.resultChannel, SearchEvent{Results: repoResults, Stats: streamingStats(repoCommon)})
 			})
 		case "symbol":
 			wg := waitGroup(len(resultTypes) == 1)
@@ -1556,7 +1723,19 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 			goroutine.Go(func() {
 				defer wg.Done()
 
-				symbolFileMatches, symbolsCommon, err := searchSymbols(ctx, &args, int(r.maxResults()))
+				// Matches filtered out by owner don't count towards the limit, so
+				// more matches are fetched when filtering by owner.
+				limit := ownerFilterFetchLimit(args.PatternInfo, r.maxResults())
+				symbolFileMatches, symbolsCommon, err := searchSymbols(ctx, ownerFilterFetchArgs(&args), int(limit))
+				if err == nil && symbolsCommon != nil && hasOwnerFilter(args.PatternInfo) {
+					var ownerErrs map[api.RepoName]error
+					symbolFileMatches, ownerErrs = filterFileMatchesByOwner(ctx, args.PatternInfo, symbolFileMatches)
+					markOwnerFilterFailures(symbolsCommon, ownerErrs)
+					if fileMatchLimit := int(args.PatternInfo.FileMatchLimit); len(symbolFileMatches) > fileMatchLimit {
+						symbolFileMatches = symbolFileMatches[:fileMatchLimit]
+						symbolsCommon.limitHit = true
+					}
+				}
 				// Timeouts are reported through searchResultsCommon so don't report an error for them
 				if err != nil && !isContextError(ctx, err) {
 					multiErrMu.Lock()
@@ -1566,7 +1745,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 				for _, symbolFileMatch := range symbolFileMatches {
 					key := symbolFileMatch.uri
 					fileMatchesMu.Lock()
-					if m, ok := fileMatches[key]; ok {
+					if m, ok := fileMatches[key]; ok && mergeFileMatches {
 						m.symbols = symbolFileMatch.symbols
 					} else {
 						fileMatches[key] = symbolFileMatch
@@ -1581,6 +1760,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					common.update(*symbolsCommon)
 					commonMu.Unlock()
 				}
+				sendSearchEvent(r.resultChannel, SearchEvent{Results: fileMatchesToSearchResults(symbolFileMatches), Stats: streamingStats(symbolsCommon)})
 			})
 		case "file", "path":
 			if searchedFileContentsOrPaths {
@@ -1593,7 +1773,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 			goroutine.Go(func() {
 				defer wg.Done()
 
-				fileResults, fileCommon, err := searchFilesInRepos(ctx, &args)
+				fileResults, fileCommon, err := searchFilesInReposStream(ctx, &args, r.resultChannel)
 				// Timeouts are reported through searchResultsCommon so don't report an error for them
 				if err != nil && !isContextError(ctx, err) {
 					multiErrMu.Lock()
@@ -1604,7 +1784,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					// No results for structural search? Automatically search again and force Zoekt to resolve
 					// more potential file matches by setting a higher FileMatchLimit.
 					args.PatternInfo.FileMatchLimit = 1000
-					fileResults, fileCommon, err = searchFilesInRepos(ctx, &args)
+					fileResults, fileCommon, err = searchFilesInReposStream(ctx, &args, r.resultChannel)
 					if err != nil && !isContextError(ctx, err) {
 						multiErrMu.Lock()
 						multiErr = multierror.Append(multiErr, errors.Wrap(err, "text search failed"))
@@ -1620,7 +1800,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					key := r.uri
 					fileMatchesMu.Lock()
 					m, ok := fileMatches[key]
-					if ok {
+					if ok && mergeFileMatches {
 						// merge line match results with an existing symbol result
 						m.JLimitHit = m.JLimitHit || r.JLimitHit
 						m.JLineMatches = r.JLineMatches
@@ -1659,7 +1839,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					Repos:       args.Repos,
 					Query:       args.Query,
 				}
-				diffResults, diffCommon, err := searchCommitDiffsInRepos(ctx, &args)
+				diffResults, diffCommon, err := searchCommitDiffsInReposStream(ctx, &args, r.resultChannel)
 				// Timeouts are reported through searchResultsCommon so don't report an error for them
 				if err != nil && !isContextError(ctx, err) {
 					multiErrMu.Lock()
@@ -1699,7 +1879,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					Repos:       args.Repos,
 					Query:       args.Query,
 				}
-				commitResults, commitCommon, err := searchCommitLogInRepos(ctx, &args)
+				commitResults, commitCommon, err := searchCommitLogInReposStream(ctx, &args, r.resultChannel)
 				// Timeouts are reported through searchResultsCommon so don't report an error for them
 				if err != nil && !isContextError(ctx, err) {
 					multiErrMu.Lock()
@@ -1723,7 +1903,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 			goroutine.Go(func() {
 				defer wg.Done()
 
-				codemodResults, codemodCommon, err := performCodemod(ctx, &args)
+				codemodResults, codemodCommon, err := performCodemodStream(ctx, &args, r.resultChannel)
 				// Timeouts are reported through searchResultsCommon so don't report an error for them
 				if err != nil && !isContextError(ctx, err) {
 					multiErrMu.Lock()
@@ -1813,6 +1993,7 @@ func isContextError(ctx context.Context, err error) bool {
 //   - *fileMatchResolver          // text match
 //   - *commitSearchResultResolver // diff or commit match
 //   - *codemodResultResolver      // code modification
+//   - *captureGroupResultResolver // capture group value selected with "select:group."
 //
 // Note: Any new result types added here also need to be handled properly in search_results.go:301 (sparklines)
 type SearchResultResolver interface {
@@ -1820,6 +2001,7 @@ type SearchResultResolver interface {
 	ToFileMatch() (*FileMatchResolver, bool)
 	ToCommitSearchResult() (*commitSearchResultResolver, bool)
 	ToCodemodResult() (*codemodResultResolver, bool)
+	ToCaptureGroupResult() (*captureGroupResultResolver, bool)
 
 	// SearchResultURIs returns the repo name and file uri respectiveley
 	searchResultURIs() (string, string)
@@ -414,6 +414,20 @@ func TestSearchResolver_getPatternInfo(t *testing.T) {
 			PathPatternsAreRegExps: true,
 			ExcludePattern:         `f|(\.graphql$|\.gql$|\.graphqls$)`,
 		},
+		"p file:f file:has.owner(@team) -file:has.owner(@bot)": {
+			Pattern:                "p",
+			IsRegExp:               true,
+			PathPatternsAreRegExps: true,
+			IncludePatterns:        []string{"f"},
+			IncludeOwners:          []string{"@team"},
+			ExcludeOwners:          []string{"@bot"},
+		},
+		"file:has.owner(@team)": {
+			IsRegExp:               true,
+			PathPatternsAreRegExps: true,
+			IncludePatterns:        []string{""},
+			IncludeOwners:          []string{"@team"},
+		},
 	}
 	for queryStr, want := range tests {
 		t.Run(queryStr, func(t *testing.T) {
@@ -435,6 +449,55 @@ func TestSearchResolver_getPatternInfo(t *testing.T) {
 	}
 }
 
+func TestSearchResolver_getPatternInfo_negatedPatterns(t *testing.T) {
+	q, err := query.ProcessAndOr("repo:r foo and not bar.go and not /b.z/", query.SearchTypeLiteral)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sr := searchResolver{query: q}
+	p, err := sr.getPatternInfo(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "foo"; p.Pattern != want {
+		t.Errorf("got pattern %q, want %q", p.Pattern, want)
+	}
+	if want := []string{`bar\.go`, `/b\.z/`}; !reflect.DeepEqual(p.ExcludeContentPatterns, want) {
+		t.Errorf("got exclude content patterns %q, want %q", p.ExcludeContentPatterns, want)
+	}
+}
+
+func TestDifference(t *testing.T) {
+	fileMatches := func(uris ...string) []SearchResultResolver {
+		var results []SearchResultResolver
+		for _, uri := range uris {
+			results = append(results, &FileMatchResolver{uri: uri})
+		}
+		return results
+	}
+	left := &SearchResultsResolver{SearchResults: fileMatches("a", "b", "c")}
+	right := &SearchResultsResolver{
+		SearchResults:       fileMatches("b", "d"),
+		searchResultsCommon: searchResultsCommon{limitHit: true},
+	}
+
+	got := difference(left, right)
+	var uris []string
+	for _, r := range got.SearchResults {
+		fm, _ := r.ToFileMatch()
+		uris = append(uris, fm.uri)
+	}
+	if want := []string{"a", "c"}; !reflect.DeepEqual(uris, want) {
+		t.Errorf("got %v, want %v", uris, want)
+	}
+	if got.resultCount != 2 {
+		t.Errorf("got result count %d, want 2", got.resultCount)
+	}
+	if !got.limitHit {
+		t.Error("expected limitHit to be set when the excluded results are incomplete")
+	}
+}
+
 func TestSearchResolver_DynamicFilters(t *testing.T) {
 	repo := &types.Repo{Name: "testRepo"}
 
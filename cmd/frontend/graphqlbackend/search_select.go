@@ -0,0 +1,294 @@
+package graphqlbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// maxCaptureGroupExamples is the maximum number of example matches returned
+// for each distinct value of a capture group selected with "select:group.".
+const maxCaptureGroupExamples = 5
+
+// searchSelector projects search results as requested by the "select:" field
+// of a query.
+type searchSelector struct {
+	typ query.SelectType
+
+	// The search pattern and the index of the selected capture group, for
+	// "select:group.<group>".
+	re    *regexp.Regexp
+	group int
+}
+
+// searchSelector returns the selector for the "select:" field of the query, or
+// nil if the query doesn't have one.
+func (r *searchResolver) searchSelector() (*searchSelector, error) {
+	value, _ := r.query.StringValue(query.FieldSelect)
+	if value == "" {
+		return nil, nil
+	}
+	sel, err := query.ParseSelect(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch sel.Type {
+	case query.SelectSymbol:
+		var isSymbolSearch bool
+		for _, v := range r.query.Values(query.FieldType) {
+			if v.ToString() == "symbol" {
+				isSymbolSearch = true
+			}
+		}
+		if !isSymbolSearch {
+			return nil, errors.New("select:symbol requires a symbol search, add type:symbol to the query")
+		}
+
+	case query.SelectGroup:
+		re, err := r.searchPatternRegexp("selecting a capture group")
+		if err != nil {
+			return nil, err
+		}
+		group, err := captureGroupIndex(re, sel.Group)
+		if err != nil {
+			return nil, err
+		}
+		return &searchSelector{typ: sel.Type, re: re, group: group}, nil
+	}
+	return &searchSelector{typ: sel.Type}, nil
+}
+
+// searchPatternRegexp returns the regexp matching the search pattern of a
+// regexp search with a single pattern. The feature that requires it is used in
+// the error returned for other searches.
+func (r *searchResolver) searchPatternRegexp(feature string) (*regexp.Regexp, error) {
+	if r.patternType != query.SearchTypeRegex {
+		return nil, fmt.Errorf("%s requires a regexp search", feature)
+	}
+	patterns := r.query.Values(query.FieldDefault)
+	if len(patterns) != 1 {
+		return nil, fmt.Errorf("%s requires a search with exactly one pattern", feature)
+	}
+
+	pattern := patterns[0].ToString()
+	if !r.query.IsCaseSensitive() {
+		pattern = "(?i:" + pattern + ")"
+	}
+	return regexp.Compile(pattern)
+}
+
+// captureGroupIndex returns the index of the capture group of re with the given
+// number or name.
+func captureGroupIndex(re *regexp.Regexp, group string) (int, error) {
+	if n, err := strconv.Atoi(group); err == nil {
+		if n < 1 || n > re.NumSubexp() {
+			return 0, fmt.Errorf("capture group %d does not exist in the search pattern, which has %d capture groups", n, re.NumSubexp())
+		}
+		return n, nil
+	}
+	for i, name := range re.SubexpNames() {
+		if i > 0 && name == group {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("capture group %q does not exist in the search pattern", group)
+}
+
+// selectResults projects results, removing the results that don't contain the
+// selected entity and deduplicating the rest.
+func (s *searchSelector) selectResults(ctx context.Context, results []SearchResultResolver) ([]SearchResultResolver, error) {
+	switch s.typ {
+	case query.SelectRepo:
+		return selectRepositories(results), nil
+	case query.SelectFile:
+		return selectFiles(results, false), nil
+	case query.SelectSymbol:
+		return selectFiles(results, true), nil
+	case query.SelectGroup:
+		return selectCaptureGroup(ctx, results, s.re, s.group)
+	}
+	return results, nil
+}
+
+// selectRepositories returns the distinct repositories of results.
+func selectRepositories(results []SearchResultResolver) []SearchResultResolver {
+	seen := map[string]bool{}
+	var selected []SearchResultResolver
+	for _, result := range results {
+		var repo *RepositoryResolver
+		switch r := result.(type) {
+		case *RepositoryResolver:
+			repo = r
+		case *FileMatchResolver:
+			repo = r.Repo
+		case *commitSearchResultResolver:
+			repo = r.commit.Repository()
+		case *codemodResultResolver:
+			repo = r.commit.Repository()
+		}
+		if repo == nil || seen[repo.Name()] {
+			continue
+		}
+		seen[repo.Name()] = true
+		selected = append(selected, repo)
+	}
+	return selected
+}
+
+// selectFiles returns the distinct files of the file matches in results,
+// without their line matches. If symbols is true, only files with symbol
+// matches are returned, with their symbols.
+func selectFiles(results []SearchResultResolver, symbols bool) []SearchResultResolver {
+	seen := map[string]bool{}
+	var selected []SearchResultResolver
+	for _, result := range results {
+		fm, ok := result.(*FileMatchResolver)
+		if !ok || seen[fm.uri] || (symbols && len(fm.symbols) == 0) {
+			continue
+		}
+		seen[fm.uri] = true
+
+		file := &FileMatchResolver{
+			JPath:    fm.JPath,
+			uri:      fm.uri,
+			Repo:     fm.Repo,
+			CommitID: fm.CommitID,
+			InputRev: fm.InputRev,
+		}
+		if symbols {
+			file.symbols = fm.symbols
+		}
+		selected = append(selected, file)
+	}
+	return selected
+}
+
+// selectCaptureGroup returns the distinct values of a capture group of re in
+// the line matches of results, ordered by decreasing number of matches.
+func selectCaptureGroup(ctx context.Context, results []SearchResultResolver, re *regexp.Regexp, group int) ([]SearchResultResolver, error) {
+	values := map[string]*captureGroupResultResolver{}
+	for _, result := range results {
+		fm, ok := result.(*FileMatchResolver)
+		if !ok || len(fm.JLineMatches) == 0 {
+			continue
+		}
+		fileURL, err := fm.File().URL(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lm := range fm.JLineMatches {
+			for _, m := range re.FindAllStringSubmatchIndex(lm.JPreview, -1) {
+				start, end := m[2*group], m[2*group+1]
+				if start < 0 || start == end {
+					continue
+				}
+				value := lm.JPreview[start:end]
+				r, ok := values[value]
+				if !ok {
+					r = &captureGroupResultResolver{value: value}
+					values[value] = r
+				}
+				r.count++
+				if len(r.matches) < maxCaptureGroupExamples {
+					r.matches = append(r.matches, &searchResultMatchResolver{
+						url:  fmt.Sprintf("%s#L%d", fileURL, lm.JLineNumber+1),
+						body: "```\n" + lm.JPreview + "\n```",
+						highlights: []*highlightedRange{{
+							character: int32(utf8.RuneCountInString(lm.JPreview[:start])),
+							length:    int32(utf8.RuneCountInString(value)),
+						}},
+					})
+				}
+			}
+		}
+	}
+
+	selected := make([]*captureGroupResultResolver, 0, len(values))
+	for _, r := range values {
+		selected = append(selected, r)
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].count != selected[j].count {
+			return selected[i].count > selected[j].count
+		}
+		return selected[i].value < selected[j].value
+	})
+
+	resolvers := make([]SearchResultResolver, len(selected))
+	for i, r := range selected {
+		resolvers[i] = r
+	}
+	return resolvers, nil
+}
+
+// captureGroupResultResolver is a resolver for the GraphQL type
+// `CaptureGroupResult`.
+type captureGroupResultResolver struct {
+	value   string
+	count   int32
+	matches []*searchResultMatchResolver // examples, at most maxCaptureGroupExamples
+}
+
+func (r *captureGroupResultResolver) ToRepository() (*RepositoryResolver, bool) { return nil, false }
+func (r *captureGroupResultResolver) ToFileMatch() (*FileMatchResolver, bool)   { return nil, false }
+func (r *captureGroupResultResolver) ToCommitSearchResult() (*commitSearchResultResolver, bool) {
+	return nil, false
+}
+
+func (r *captureGroupResultResolver) ToCodemodResult() (*codemodResultResolver, bool) {
+	return nil, false
+}
+
+func (r *captureGroupResultResolver) ToCaptureGroupResult() (*captureGroupResultResolver, bool) {
+	return r, true
+}
+
+func (r *captureGroupResultResolver) searchResultURIs() (string, string) {
+	return "", r.value
+}
+
+func (r *captureGroupResultResolver) resultCount() int32 {
+	return r.count
+}
+
+func (r *captureGroupResultResolver) Icon() string {
+	return "data:image/svg+xml,%3Csvg xmlns='http://www.w3.org/2000/svg' style='width:24px;height:24px' viewBox='0 0 24 24'%3E%3Cpath fill='%23a2b0cd' d='M15,4V6H18V18H15V20H20V4M4,4V20H9V18H6V6H9V4H4Z' /%3E%3C/svg%3E"
+}
+
+func (r *captureGroupResultResolver) Label() *markdownResolver {
+	// Use a code span delimiter that doesn't occur in the value.
+	delim := "`"
+	for strings.Contains(r.value, delim) {
+		delim += "`"
+	}
+	return &markdownResolver{text: delim + " " + r.value + " " + delim}
+}
+
+func (r *captureGroupResultResolver) URL() string {
+	if len(r.matches) == 0 {
+		return ""
+	}
+	return r.matches[0].url
+}
+
+func (r *captureGroupResultResolver) Detail() *markdownResolver {
+	if r.count == 1 {
+		return &markdownResolver{text: "1 match"}
+	}
+	return &markdownResolver{text: fmt.Sprintf("%d matches", r.count)}
+}
+
+func (r *captureGroupResultResolver) Matches() []*searchResultMatchResolver { return r.matches }
+
+func (r *captureGroupResultResolver) Value() string { return r.value }
+
+func (r *captureGroupResultResolver) Count() int32 { return r.count }
@@ -0,0 +1,164 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func TestSearchResolver_searchSelector(t *testing.T) {
+	for _, tc := range []struct {
+		query       string
+		patternType query.SearchType
+		want        *searchSelector
+		wantGroup   int
+		wantErr     string
+	}{
+		{query: "foo"},
+		{query: "foo select:repo", want: &searchSelector{typ: query.SelectRepo}},
+		{query: "foo select:file", want: &searchSelector{typ: query.SelectFile}},
+		{query: "foo type:symbol select:symbol", want: &searchSelector{typ: query.SelectSymbol}},
+		{query: "foo select:symbol", wantErr: "select:symbol requires a symbol search, add type:symbol to the query"},
+		{query: `foo(\d+) select:group.1`, wantGroup: 1},
+		{query: `(?P<pkg>\w+)\.(?P<fn>\w+)\( select:group.fn`, wantGroup: 2},
+		{query: `foo(\d+) select:group.2`, wantErr: "capture group 2 does not exist in the search pattern, which has 1 capture groups"},
+		{query: `foo(\d+) select:group.pkg`, wantErr: `capture group "pkg" does not exist in the search pattern`},
+		{query: `foo(\d+) select:group.1`, patternType: query.SearchTypeLiteral, wantErr: "selecting a capture group requires a regexp search"},
+		{query: `foo(\d+) bar(\d+) select:group.1`, wantErr: "selecting a capture group requires a search with exactly one pattern"},
+	} {
+		r := &searchResolver{query: mustProcessQuery(t, tc.query), patternType: tc.patternType}
+		selector, err := r.searchSelector()
+		if have := errString(err); have != tc.wantErr {
+			t.Errorf("%q: got error %q, want %q", tc.query, have, tc.wantErr)
+			continue
+		}
+		if tc.wantGroup != 0 {
+			if selector == nil || selector.typ != query.SelectGroup || selector.group != tc.wantGroup {
+				t.Errorf("%q: got selector %+v, want capture group %d", tc.query, selector, tc.wantGroup)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(selector, tc.want) {
+			t.Errorf("%q: got selector %+v, want %+v", tc.query, selector, tc.want)
+		}
+	}
+}
+
+func TestSearchSelector_selectResults(t *testing.T) {
+	repoA := &RepositoryResolver{repo: &types.Repo{Name: "github.com/a/a"}}
+	repoB := &RepositoryResolver{repo: &types.Repo{Name: "github.com/a/b"}}
+	mainGo := &FileMatchResolver{
+		JPath: "main.go",
+		JLineMatches: []*lineMatch{
+			{JPreview: `import "github.com/x/log"`, JLineNumber: 2},
+			{JPreview: `	"github.com/x/errors" // not "github.com/x/log"`, JLineNumber: 4},
+		},
+		MatchCount: 3,
+		uri:        "git://github.com/a/a#main.go",
+		Repo:       repoA,
+		CommitID:   "c1",
+	}
+	symbols := &FileMatchResolver{
+		JPath:   "util.go",
+		symbols: []*searchSymbolResult{{}},
+		uri:     "git://github.com/a/b#util.go",
+		Repo:    repoB,
+	}
+	commit := &commitSearchResultResolver{commit: &GitCommitResolver{repoResolver: repoB}}
+	results := []SearchResultResolver{repoA, mainGo, symbols, mainGo, commit}
+
+	t.Run("repo", func(t *testing.T) {
+		selected, err := (&searchSelector{typ: query.SelectRepo}).selectResults(context.Background(), results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []SearchResultResolver{repoA, repoB}; !reflect.DeepEqual(selected, want) {
+			t.Errorf("got %+v, want %+v", selected, want)
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		selected, err := (&searchSelector{typ: query.SelectFile}).selectResults(context.Background(), results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []SearchResultResolver{
+			&FileMatchResolver{JPath: "main.go", uri: mainGo.uri, Repo: repoA, CommitID: "c1"},
+			&FileMatchResolver{JPath: "util.go", uri: symbols.uri, Repo: repoB},
+		}
+		if !reflect.DeepEqual(selected, want) {
+			t.Errorf("got %+v, want %+v", selected, want)
+		}
+	})
+
+	t.Run("symbol", func(t *testing.T) {
+		selected, err := (&searchSelector{typ: query.SelectSymbol}).selectResults(context.Background(), results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []SearchResultResolver{
+			&FileMatchResolver{JPath: "util.go", symbols: symbols.symbols, uri: symbols.uri, Repo: repoB},
+		}
+		if !reflect.DeepEqual(selected, want) {
+			t.Errorf("got %+v, want %+v", selected, want)
+		}
+	})
+
+	t.Run("group", func(t *testing.T) {
+		r := &searchResolver{query: mustProcessQuery(t, `github\.com/x/(\w+) select:group.1`), patternType: query.SearchTypeRegex}
+		selector, err := r.searchSelector()
+		if err != nil {
+			t.Fatal(err)
+		}
+		selected, err := selector.selectResults(context.Background(), []SearchResultResolver{repoA, mainGo})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []SearchResultResolver{
+			&captureGroupResultResolver{
+				value: "log",
+				count: 2,
+				matches: []*searchResultMatchResolver{
+					{
+						url:        "/github.com/a/a@c1/-/blob/main.go#L3",
+						body:       "```\nimport \"github.com/x/log\"\n```",
+						highlights: []*highlightedRange{{character: 21, length: 3}},
+					},
+					{
+						url:        "/github.com/a/a@c1/-/blob/main.go#L5",
+						body:       "```\n\t\"github.com/x/errors\" // not \"github.com/x/log\"\n```",
+						highlights: []*highlightedRange{{character: 44, length: 3}},
+					},
+				},
+			},
+			&captureGroupResultResolver{
+				value: "errors",
+				count: 1,
+				matches: []*searchResultMatchResolver{{
+					url:        "/github.com/a/a@c1/-/blob/main.go#L5",
+					body:       "```\n\t\"github.com/x/errors\" // not \"github.com/x/log\"\n```",
+					highlights: []*highlightedRange{{character: 15, length: 6}},
+				}},
+			},
+		}
+		if !reflect.DeepEqual(selected, want) {
+			t.Errorf("got %+v, want %+v", selected, want)
+		}
+	})
+}
+
+func TestCaptureGroupResultResolver(t *testing.T) {
+	r := &captureGroupResultResolver{value: "a`b", count: 2}
+	if have, want := r.Label().Text(), "`` a`b ``"; have != want {
+		t.Errorf("got label %q, want %q", have, want)
+	}
+	if have, want := r.Detail().Text(), "2 matches"; have != want {
+		t.Errorf("got detail %q, want %q", have, want)
+	}
+	if have := r.URL(); have != "" {
+		t.Errorf("got URL %q for result without matches, want none", have)
+	}
+}
@@ -0,0 +1,87 @@
+package graphqlbackend
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// SearchEvent is a batch of search results sent on the result channel of a
+// streaming search (see SearchArgs.ResultChannel) as soon as they are found.
+//
+// The results of an event are owned by the receiver. A file may be sent more
+// than once, e.g. once with its symbol matches and once with its line matches.
+type SearchEvent struct {
+	Results []SearchResultResolver
+	Stats   streaming.Stats
+}
+
+// sendSearchEvent sends event on c. It is a no-op if c is nil or if the event
+// is empty.
+func sendSearchEvent(c chan<- SearchEvent, event SearchEvent) {
+	if c == nil || (len(event.Results) == 0 && event.Stats.Zero()) {
+		return
+	}
+	c <- event
+}
+
+// collectResults calls f with streaming disabled and sends the results of f as
+// a single event once they are known. It is used for searches whose results can
+// only be determined once the search is complete, such as and/or expressions or
+// paginated searches.
+func (r *searchResolver) collectResults(f func() (*SearchResultsResolver, error)) (*SearchResultsResolver, error) {
+	resultChannel := r.resultChannel
+	if resultChannel == nil {
+		return f()
+	}
+
+	r.resultChannel = nil
+	defer func() { r.resultChannel = resultChannel }()
+
+	result, err := f()
+	if result != nil {
+		sendSearchEvent(resultChannel, SearchEvent{
+			Results: append([]SearchResultResolver(nil), result.SearchResults...),
+			Stats:   streamingStats(&result.searchResultsCommon),
+		})
+	}
+	return result, err
+}
+
+// streamingStats returns the stats sent with a SearchEvent for common.
+func streamingStats(common *searchResultsCommon) streaming.Stats {
+	if common == nil {
+		return streaming.Stats{}
+	}
+	return streaming.Stats{
+		LimitHit: common.limitHit,
+		Searched: repoNames(common.searched),
+		Indexed:  repoNames(common.indexed),
+		Cloning:  repoNames(common.cloning),
+		Missing:  repoNames(common.missing),
+		Timedout: repoNames(common.timedout),
+	}
+}
+
+func repoNames(repos []*types.Repo) []api.RepoName {
+	if len(repos) == 0 {
+		return nil
+	}
+	names := make([]api.RepoName, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	return names
+}
+
+// fileMatchesToSearchResults converts file matches into search results.
+func fileMatchesToSearchResults(matches []*FileMatchResolver) []SearchResultResolver {
+	if len(matches) == 0 {
+		return nil
+	}
+	results := make([]SearchResultResolver, 0, len(matches))
+	for _, match := range matches {
+		results = append(results, match)
+	}
+	return results
+}
@@ -0,0 +1,90 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSearchResultsStream(t *testing.T) {
+	mockDecodedViewerFinalSettings = &schema.Settings{}
+	defer func() { mockDecodedViewerFinalSettings = nil }()
+
+	db.Mocks.Repos.List = func(_ context.Context, op db.ReposListOptions) ([]*types.Repo, error) {
+		return []*types.Repo{{ID: 1, Name: "repo"}}, nil
+	}
+	defer func() { db.Mocks = db.MockStores{} }()
+	db.Mocks.Repos.MockGetByName(t, "repo", 1)
+	db.Mocks.Repos.MockGet(t, 1)
+	db.Mocks.Repos.Count = mockCount
+
+	mockSearchRepositories = func(args *search.TextParameters) ([]SearchResultResolver, *searchResultsCommon, error) {
+		return nil, &searchResultsCommon{}, nil
+	}
+	defer func() { mockSearchRepositories = nil }()
+
+	mockSearchFilesInRepos = func(args *search.TextParameters) ([]*FileMatchResolver, *searchResultsCommon, error) {
+		repo := &types.Repo{ID: 1, Name: "repo"}
+		return []*FileMatchResolver{
+			{
+				uri:          "git://repo?rev#dir/file",
+				JPath:        "dir/file",
+				JLineMatches: []*lineMatch{{JLineNumber: 123}},
+				Repo:         &RepositoryResolver{repo: repo},
+			},
+		}, &searchResultsCommon{repos: []*types.Repo{repo}, searched: []*types.Repo{repo}}, nil
+	}
+	defer func() { mockSearchFilesInRepos = nil }()
+
+	for _, query := range []string{"foo", "foo or bar"} {
+		t.Run(query, func(t *testing.T) {
+			events := make(chan SearchEvent)
+			r, err := (&schemaResolver{}).Search(&SearchArgs{Query: query, Version: "V2", ResultChannel: events})
+			if err != nil {
+				t.Fatal("Search:", err)
+			}
+
+			var (
+				results *SearchResultsResolver
+				done    = make(chan struct{})
+			)
+			go func() {
+				defer close(done)
+				defer close(events)
+				results, err = r.Results(context.Background())
+			}()
+
+			var paths []string
+			var searched []api.RepoName
+			for event := range events {
+				for _, result := range event.Results {
+					if fm, ok := result.ToFileMatch(); ok {
+						paths = append(paths, fm.JPath)
+					}
+				}
+				searched = append(searched, event.Stats.Searched...)
+			}
+			<-done
+			if err != nil {
+				t.Fatal("Results:", err)
+			}
+
+			if diff := cmp.Diff([]string{"dir/file"}, paths); diff != "" {
+				t.Errorf("unexpected streamed paths (-want +got):\n%s", diff)
+			}
+			if len(searched) == 0 {
+				t.Errorf("expected searched repositories to be streamed")
+			}
+			if len(results.SearchResults) != 1 {
+				t.Errorf("expected one result, got %d", len(results.SearchResults))
+			}
+		})
+	}
+}
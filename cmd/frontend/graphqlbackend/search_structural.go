@@ -118,6 +118,7 @@ func zoektSearchHEADOnlyFiles(ctx context.Context, args *search.TextParameters,
 	if len(repos) == 0 {
 		return nil, false, nil, nil
 	}
+	explanation := searchBackendExplanationFromContext(ctx)
 
 	repoSet := &zoektquery.RepoSet{Set: make(map[string]bool, len(repos))}
 	repoMap := make(map[string]*search.RepositoryRevisions, len(repos))
@@ -168,6 +169,7 @@ func zoektSearchHEADOnlyFiles(ctx context.Context, args *search.TextParameters,
 	if err != nil {
 		return nil, false, nil, err
 	}
+	explanation.recordZoektQuery(q.String())
 	resp, err := args.Zoekt.Client.Search(ctx, q, &searchOpts)
 	if err != nil {
 		return nil, false, nil, err
@@ -182,6 +184,10 @@ func zoektSearchHEADOnlyFiles(ctx context.Context, args *search.TextParameters,
 	// manually specified, run a more complete and expensive search.
 	if resp.FileCount < 10 || args.PatternInfo.FileMatchLimit != defaultMaxSearchResults {
 		q, err = buildQuery(args, newRepoSet, filePathPatterns, false)
+		if err != nil {
+			return nil, false, nil, err
+		}
+		explanation.recordZoektQuery(q.String())
 		resp, err = args.Zoekt.Client.Search(ctx, q, &searchOpts)
 		if err != nil {
 			return nil, false, nil, err
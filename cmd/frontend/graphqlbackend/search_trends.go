@@ -0,0 +1,386 @@
+package graphqlbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+const (
+	// maxSearchTrendPoints is the maximum number of points of a search trend
+	// series, e.g. a little over a year of daily points.
+	maxSearchTrendPoints = 400
+
+	// searchTrendMaxResults is the count used for the searches of series whose
+	// query doesn't specify one.
+	searchTrendMaxResults = 100000
+)
+
+func marshalSearchTrendSeriesID(id int32) graphql.ID {
+	return relay.MarshalID("SearchTrendSeries", id)
+}
+
+func unmarshalSearchTrendSeriesID(id graphql.ID) (seriesID int32, err error) {
+	err = relay.UnmarshalSpec(id, &seriesID)
+	return
+}
+
+func searchTrendSeriesByID(ctx context.Context, id graphql.ID) (*searchTrendSeriesResolver, error) {
+	seriesID, err := unmarshalSearchTrendSeriesID(id)
+	if err != nil {
+		return nil, err
+	}
+	series, err := db.SearchTrends.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only the owner of the series and site admins may see it.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, series.UserID); err != nil {
+		return nil, err
+	}
+	return &searchTrendSeriesResolver{s: series}, nil
+}
+
+func (r *schemaResolver) SearchTrendSeries(ctx context.Context) ([]*searchTrendSeriesResolver, error) {
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, errors.New("no currently authenticated user")
+	}
+
+	series, err := db.SearchTrends.ListByUserID(ctx, currentUser.DatabaseID())
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*searchTrendSeriesResolver, 0, len(series))
+	for _, s := range series {
+		resolvers = append(resolvers, &searchTrendSeriesResolver{s: s})
+	}
+	return resolvers, nil
+}
+
+func (r *schemaResolver) CreateSearchTrendSeries(ctx context.Context, args *struct {
+	Query    string
+	Start    DateTime
+	Interval string
+}) (*searchTrendSeriesResolver, error) {
+	currentUser, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, errors.New("no currently authenticated user")
+	}
+
+	interval := types.SearchTrendInterval(args.Interval)
+	if err := validateSearchTrendSeries(args.Query, args.Start.Time, interval, time.Now()); err != nil {
+		return nil, err
+	}
+
+	series, err := db.SearchTrends.Create(ctx, &types.SearchTrendSeries{
+		UserID:   currentUser.DatabaseID(),
+		Query:    args.Query,
+		Start:    args.Start.Time,
+		Interval: interval,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Start backfilling the series right away instead of waiting for the
+	// next run of the background process.
+	goroutine.Go(func() {
+		if _, err := RecordSearchTrendSeries(context.Background(), series, time.Now(), SearchTrendSearchesPerRun); err != nil {
+			log15.Error("recording search trend series", "id", series.ID, "error", err)
+		}
+	})
+
+	return &searchTrendSeriesResolver{s: series}, nil
+}
+
+func (r *schemaResolver) DeleteSearchTrendSeries(ctx context.Context, args *struct {
+	ID graphql.ID
+}) (*EmptyResponse, error) {
+	series, err := searchTrendSeriesByID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.SearchTrends.Delete(ctx, series.s.ID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+// validateSearchTrendSeries returns an error if a series with the given
+// parameters can't be recorded.
+func validateSearchTrendSeries(q string, start time.Time, interval types.SearchTrendInterval, now time.Time) error {
+	switch interval {
+	case types.SearchTrendIntervalDay, types.SearchTrendIntervalWeek, types.SearchTrendIntervalMonth:
+	default:
+		return fmt.Errorf("invalid search trend interval %q", interval)
+	}
+	if !start.Before(now) {
+		return errors.New("the start of a search trend series must be in the past")
+	}
+	if n := len(searchTrendTimes(start, interval, now)); n > maxSearchTrendPoints {
+		return fmt.Errorf("search trend series are limited to %d points, but this one would have %d. Choose a later start or a longer interval.", maxSearchTrendPoints, n)
+	}
+
+	if !queryHasPatternType(q) {
+		return errors.New("a `patternType:` filter is required in the query of search trend series. `patternType` can be \"literal\" or \"regexp\"")
+	}
+	r, err := newSearchTrendResolver(q)
+	if err != nil {
+		return err
+	}
+	for _, v := range r.query.Values(query.FieldRepo) {
+		if strings.Contains(v.ToString(), "@") {
+			return errors.New("the query of a search trend series must not specify revisions, because the revision searched is determined by the time of each point")
+		}
+	}
+	return nil
+}
+
+// newSearchTrendResolver returns the search resolver for the query of a
+// series, or an error if the query is invalid.
+func newSearchTrendResolver(q string) (*searchResolver, error) {
+	search, err := NewSearchImplementer(&SearchArgs{Version: "V2", Query: q})
+	if err != nil {
+		return nil, err
+	}
+	switch r := search.(type) {
+	case *searchResolver:
+		return r, nil
+	case *searchAlert:
+		return nil, errors.New(r.title)
+	default:
+		return nil, fmt.Errorf("invalid search trend query %q", q)
+	}
+}
+
+// searchTrendTimes returns the times of the points of a series up to now.
+func searchTrendTimes(start time.Time, interval types.SearchTrendInterval, now time.Time) []time.Time {
+	var times []time.Time
+	for i := 0; i <= maxSearchTrendPoints; i++ {
+		var t time.Time
+		switch interval {
+		case types.SearchTrendIntervalDay:
+			t = start.AddDate(0, 0, i)
+		case types.SearchTrendIntervalWeek:
+			t = start.AddDate(0, 0, 7*i)
+		case types.SearchTrendIntervalMonth:
+			t = start.AddDate(0, i, 0)
+		default:
+			return nil
+		}
+		if t.After(now) {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// SearchTrendSearchesPerRun is the maximum number of searches that
+// RecordSearchTrendSeries runs per series each time the background process
+// runs.
+const SearchTrendSearchesPerRun = 100
+
+// RecordSearchTrendSeries records the missing points of series up to now. For
+// each repository that the query matches, it searches the last commit before
+// the time of each point. Points whose commit has already been searched for
+// another point reuse its count.
+//
+// It runs at most maxSearches searches, so that long series are backfilled
+// over several calls, and returns the number of searches it ran.
+func RecordSearchTrendSeries(ctx context.Context, series *types.SearchTrendSeries, now time.Time, maxSearches int) (searches int, err error) {
+	// 🚨 SECURITY: Searches are run with the permissions of the owner of the
+	// series, so that it only counts matches in repositories they can access.
+	ctx = actor.WithActor(ctx, actor.FromUser(series.UserID))
+
+	r, err := newSearchTrendResolver(series.Query)
+	if err != nil {
+		return 0, err
+	}
+	repos, _, _, _, err := r.resolveRepositories(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	points, err := db.SearchTrends.ListPoints(ctx, series.ID, 0)
+	if err != nil {
+		return 0, err
+	}
+	recorded := map[api.RepoID]map[time.Time]*types.SearchTrendPoint{}
+	for _, p := range points {
+		if recorded[p.RepoID] == nil {
+			recorded[p.RepoID] = map[time.Time]*types.SearchTrendPoint{}
+		}
+		recorded[p.RepoID][p.Time.UTC()] = p
+	}
+
+	times := searchTrendTimes(series.Start, series.Interval, now)
+	for _, repoRevs := range repos {
+		repo := repoRevs.Repo
+		counts := map[api.CommitID]int32{}
+		for _, p := range recorded[repo.ID] {
+			counts[p.Commit] = p.Count
+		}
+
+		for _, t := range times {
+			if _, ok := recorded[repo.ID][t.UTC()]; ok {
+				continue
+			}
+
+			commit, err := searchTrendCommit(ctx, repo, t)
+			if err != nil {
+				// The repository may be cloning. Try again next time.
+				log15.Warn("resolving commit of search trend point", "series", series.ID, "repo", repo.Name, "time", t, "error", err)
+				break
+			}
+
+			point := &types.SearchTrendPoint{SeriesID: series.ID, RepoID: repo.ID, Time: t, Commit: commit}
+			if count, ok := counts[commit]; ok || commit == "" {
+				point.Count = count
+			} else {
+				if searches >= maxSearches {
+					return searches, nil
+				}
+				searches++
+				point.Count, err = searchTrendCount(ctx, searchTrendQuery(r, series.Query, repo.Name, commit))
+				if err != nil {
+					log15.Warn("searching for search trend point", "series", series.ID, "repo", repo.Name, "commit", commit, "error", err)
+					break
+				}
+				counts[commit] = point.Count
+			}
+
+			if err := db.SearchTrends.UpsertPoint(ctx, point); err != nil {
+				return searches, err
+			}
+		}
+	}
+	return searches, nil
+}
+
+// searchTrendCommit returns the last commit of the default branch of repo
+// before t, or an empty commit ID if there is none.
+func searchTrendCommit(ctx context.Context, repo *types.Repo, t time.Time) (api.CommitID, error) {
+	gitRepo, err := backend.CachedGitRepo(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+	commits, err := git.Commits(ctx, *gitRepo, git.CommitsOptions{Range: "HEAD", Before: t.Format(time.RFC3339), N: 1})
+	if err != nil || len(commits) == 0 {
+		return "", err
+	}
+	return commits[0].ID, nil
+}
+
+// searchTrendQuery returns the query that searches q at the given commit of
+// a repository.
+func searchTrendQuery(r *searchResolver, q string, repo api.RepoName, commit api.CommitID) string {
+	q = fmt.Sprintf("%s repo:^%s$@%s", q, regexp.QuoteMeta(string(repo)), commit)
+	if !r.countIsSet() {
+		q += " count:" + strconv.Itoa(searchTrendMaxResults)
+	}
+	return q
+}
+
+var mockSearchTrendCount func(q string) (int32, error)
+
+// searchTrendCount returns the number of matches of q. It returns an error if
+// the search is not exhaustive.
+func searchTrendCount(ctx context.Context, q string) (int32, error) {
+	if mockSearchTrendCount != nil {
+		return mockSearchTrendCount(q)
+	}
+
+	search, err := NewSearchImplementer(&SearchArgs{Version: "V2", Query: q})
+	if err != nil {
+		return 0, err
+	}
+	results, err := search.Results(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if results.alert != nil {
+		return 0, errors.New(results.alert.title)
+	}
+	if len(results.Cloning()) > 0 || len(results.Missing()) > 0 || len(results.Timedout()) > 0 {
+		return 0, errors.New("the search did not complete")
+	}
+	return results.MatchCount(), nil
+}
+
+type searchTrendSeriesResolver struct {
+	s *types.SearchTrendSeries
+}
+
+func (r *searchTrendSeriesResolver) ID() graphql.ID { return marshalSearchTrendSeriesID(r.s.ID) }
+
+func (r *searchTrendSeriesResolver) Query() string { return r.s.Query }
+
+func (r *searchTrendSeriesResolver) Start() DateTime { return DateTime{Time: r.s.Start} }
+
+func (r *searchTrendSeriesResolver) Interval() string { return string(r.s.Interval) }
+
+func (r *searchTrendSeriesResolver) CreatedAt() DateTime { return DateTime{Time: r.s.CreatedAt} }
+
+func (r *searchTrendSeriesResolver) Points(ctx context.Context, args *struct {
+	Repository *graphql.ID
+}) ([]*searchTrendPointResolver, error) {
+	var repoID api.RepoID
+	if args.Repository != nil {
+		var err error
+		if repoID, err = UnmarshalRepositoryID(*args.Repository); err != nil {
+			return nil, err
+		}
+	}
+
+	points, err := db.SearchTrends.ListPoints(ctx, r.s.ID, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Points are ordered by time, so the points of each time are adjacent.
+	var resolvers []*searchTrendPointResolver
+	for _, p := range points {
+		if n := len(resolvers); n == 0 || !resolvers[n-1].time.Equal(p.Time) {
+			resolvers = append(resolvers, &searchTrendPointResolver{time: p.Time})
+		}
+		last := resolvers[len(resolvers)-1]
+		last.count += p.Count
+		last.repositoryCount++
+	}
+	return resolvers, nil
+}
+
+type searchTrendPointResolver struct {
+	time            time.Time
+	count           int32
+	repositoryCount int32
+}
+
+func (r *searchTrendPointResolver) Time() DateTime         { return DateTime{Time: r.time} }
+func (r *searchTrendPointResolver) Count() int32           { return r.count }
+func (r *searchTrendPointResolver) RepositoryCount() int32 { return r.repositoryCount }
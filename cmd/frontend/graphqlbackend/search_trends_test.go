@@ -0,0 +1,180 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestSearchTrendTimes(t *testing.T) {
+	start := time.Date(2020, 1, 31, 12, 0, 0, 0, time.UTC)
+	now := time.Date(2020, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		interval types.SearchTrendInterval
+		want     int
+		last     time.Time
+	}{
+		{types.SearchTrendIntervalDay, 61, now},
+		{types.SearchTrendIntervalWeek, 9, time.Date(2020, 3, 27, 12, 0, 0, 0, time.UTC)},
+		{types.SearchTrendIntervalMonth, 3, time.Date(2020, 3, 31, 12, 0, 0, 0, time.UTC)},
+	} {
+		times := searchTrendTimes(start, tc.interval, now)
+		if len(times) != tc.want {
+			t.Errorf("%s: got %d times, want %d", tc.interval, len(times), tc.want)
+			continue
+		}
+		if !times[0].Equal(start) || !times[len(times)-1].Equal(tc.last) {
+			t.Errorf("%s: got times %v to %v, want %v to %v", tc.interval, times[0], times[len(times)-1], start, tc.last)
+		}
+	}
+}
+
+func TestValidateSearchTrendSeries(t *testing.T) {
+	conf.Mock(&conf.Unified{})
+	defer conf.Mock(nil)
+
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	yearAgo := now.AddDate(-1, 0, 0)
+
+	for _, tc := range []struct {
+		query    string
+		start    time.Time
+		interval types.SearchTrendInterval
+		wantErr  string
+	}{
+		{query: "deprecatedFunc( patternType:literal", start: yearAgo, interval: types.SearchTrendIntervalWeek},
+		{query: "deprecatedFunc( patternType:literal", start: yearAgo, interval: "HOUR", wantErr: "invalid search trend interval"},
+		{query: "deprecatedFunc( patternType:literal", start: now.Add(time.Hour), interval: types.SearchTrendIntervalDay, wantErr: "must be in the past"},
+		{query: "deprecatedFunc( patternType:literal", start: now.AddDate(-2, 0, 0), interval: types.SearchTrendIntervalDay, wantErr: "limited to 400 points"},
+		{query: "deprecatedFunc(", start: yearAgo, interval: types.SearchTrendIntervalWeek, wantErr: "`patternType:` filter is required"},
+		{query: "repo:foo@v1 deprecatedFunc( patternType:literal", start: yearAgo, interval: types.SearchTrendIntervalWeek, wantErr: "must not specify revisions"},
+	} {
+		err := validateSearchTrendSeries(tc.query, tc.start, tc.interval, now)
+		if tc.wantErr == "" && err != nil {
+			t.Errorf("%q: unexpected error %s", tc.query, err)
+		} else if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+			t.Errorf("%q: got error %v, want %q", tc.query, err, tc.wantErr)
+		}
+	}
+}
+
+func TestRecordSearchTrendSeries(t *testing.T) {
+	conf.Mock(&conf.Unified{})
+	defer conf.Mock(nil)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2020, 4, 15, 0, 0, 0, 0, time.UTC)
+	series := &types.SearchTrendSeries{ID: 1, UserID: 2, Query: "deprecatedFunc( patternType:literal", Start: start, Interval: types.SearchTrendIntervalMonth}
+
+	repo := &types.Repo{ID: 3, Name: "github.com/a/b"}
+	mockResolveRepositories = func(effectiveRepoFieldValues []string) (repoRevs, missingRepoRevs []*search.RepositoryRevisions, excludedRepos *excludedRepos, overLimit bool, err error) {
+		return []*search.RepositoryRevisions{{Repo: repo}}, nil, nil, false, nil
+	}
+	defer func() { mockResolveRepositories = nil }()
+
+	// The repository has its first commit in February, and no commits in
+	// April.
+	git.Mocks.Commits = func(_ gitserver.Repo, opt git.CommitsOptions) ([]*git.Commit, error) {
+		switch opt.Before {
+		case "2020-01-01T00:00:00Z":
+			return nil, nil
+		case "2020-02-01T00:00:00Z":
+			return []*git.Commit{{ID: "c1"}}, nil
+		default:
+			return []*git.Commit{{ID: "c2"}}, nil
+		}
+	}
+	defer git.ResetMocks()
+
+	// The February point was recorded by a previous run.
+	db.Mocks.SearchTrends.ListPoints = func(ctx context.Context, seriesID int32, repoID api.RepoID) ([]*types.SearchTrendPoint, error) {
+		return []*types.SearchTrendPoint{{SeriesID: 1, RepoID: 3, Time: start.AddDate(0, 1, 0), Commit: "c1", Count: 5}}, nil
+	}
+	var upserted []types.SearchTrendPoint
+	db.Mocks.SearchTrends.UpsertPoint = func(ctx context.Context, point *types.SearchTrendPoint) error {
+		upserted = append(upserted, *point)
+		return nil
+	}
+	defer func() { db.Mocks.SearchTrends = db.MockSearchTrends{} }()
+
+	var queries []string
+	mockSearchTrendCount = func(q string) (int32, error) {
+		queries = append(queries, q)
+		return 7, nil
+	}
+	defer func() { mockSearchTrendCount = nil }()
+
+	searches, err := RecordSearchTrendSeries(context.Background(), series, now, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if searches != 1 {
+		t.Errorf("got %d searches, want 1", searches)
+	}
+
+	wantQueries := []string{`deprecatedFunc( patternType:literal repo:^github\.com/a/b$@c2 count:100000`}
+	if !reflect.DeepEqual(queries, wantQueries) {
+		t.Errorf("got queries %q, want %q", queries, wantQueries)
+	}
+
+	wantPoints := []types.SearchTrendPoint{
+		{SeriesID: 1, RepoID: 3, Time: start, Commit: "", Count: 0},
+		{SeriesID: 1, RepoID: 3, Time: start.AddDate(0, 2, 0), Commit: "c2", Count: 7},
+		{SeriesID: 1, RepoID: 3, Time: start.AddDate(0, 3, 0), Commit: "c2", Count: 7},
+	}
+	if !reflect.DeepEqual(upserted, wantPoints) {
+		t.Errorf("got points %+v, want %+v", upserted, wantPoints)
+	}
+
+	t.Run("search limit", func(t *testing.T) {
+		upserted, queries = nil, nil
+		searches, err := RecordSearchTrendSeries(context.Background(), series, now, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if searches != 0 || len(queries) != 0 {
+			t.Errorf("got %d searches, want none", searches)
+		}
+		if len(upserted) != 1 || upserted[0].Commit != "" {
+			t.Errorf("expected only the point without a commit to be recorded, got %+v", upserted)
+		}
+	})
+}
+
+func TestSearchTrendSeriesResolver_Points(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	db.Mocks.SearchTrends.ListPoints = func(ctx context.Context, seriesID int32, repoID api.RepoID) ([]*types.SearchTrendPoint, error) {
+		return []*types.SearchTrendPoint{
+			{RepoID: 1, Time: start, Count: 2},
+			{RepoID: 2, Time: start, Count: 3},
+			{RepoID: 1, Time: start.AddDate(0, 1, 0), Count: 1},
+		}, nil
+	}
+	defer func() { db.Mocks.SearchTrends = db.MockSearchTrends{} }()
+
+	r := &searchTrendSeriesResolver{s: &types.SearchTrendSeries{ID: 1}}
+	points, err := r.Points(context.Background(), &struct{ Repository *graphql.ID }{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*searchTrendPointResolver{
+		{time: start, count: 5, repositoryCount: 2},
+		{time: start.AddDate(0, 1, 0), count: 1, repositoryCount: 1},
+	}
+	if !reflect.DeepEqual(points, want) {
+		t.Errorf("got points %+v, want %+v", points, want)
+	}
+}
@@ -144,6 +144,10 @@ func (r *FileMatchResolver) ToCodemodResult() (*codemodResultResolver, bool) {
 	return nil, false
 }
 
+func (r *FileMatchResolver) ToCaptureGroupResult() (*captureGroupResultResolver, bool) {
+	return nil, false
+}
+
 func (fm *FileMatchResolver) searchResultURIs() (string, string) {
 	return fm.Repo.Name(), fm.JPath
 }
@@ -210,6 +214,9 @@ func textSearch(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.
 		"Languages":       p.Languages,
 		"CombyRule":       []string{p.CombyRule},
 	}
+	if len(p.ExcludeContentPatterns) > 0 {
+		q["ExcludeContentPatterns"] = p.ExcludeContentPatterns
+	}
 	if deadline, ok := ctx.Deadline(); ok {
 		t, err := deadline.MarshalText()
 		if err != nil {
@@ -459,10 +466,24 @@ var mockSearchFilesInRepos func(args *search.TextParameters) ([]*FileMatchResolv
 
 // searchFilesInRepos searches a set of repos for a pattern.
 func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res []*FileMatchResolver, common *searchResultsCommon, err error) {
+	return searchFilesInReposStream(ctx, args, nil)
+}
+
+// searchFilesInReposStream is like searchFilesInRepos, but additionally sends
+// the matches found in each repository to resultChannel, if non-nil, as soon as
+// the repository has been searched. At most FileMatchLimit matches are sent.
+func searchFilesInReposStream(ctx context.Context, args *search.TextParameters, resultChannel chan<- SearchEvent) (res []*FileMatchResolver, common *searchResultsCommon, err error) {
 	if mockSearchFilesInRepos != nil {
-		return mockSearchFilesInRepos(args)
+		res, common, err = mockSearchFilesInRepos(args)
+		sendSearchEvent(resultChannel, SearchEvent{Results: fileMatchesToSearchResults(res), Stats: streamingStats(common)})
+		return res, common, err
 	}
 
+	// Matches filtered out by owner don't count towards the limit, so more
+	// matches are fetched from the backends when filtering by owner.
+	fileMatchLimit := int(args.PatternInfo.FileMatchLimit)
+	args = ownerFilterFetchArgs(args)
+
 	tr, ctx := trace.New(ctx, "searchFilesInRepos", fmt.Sprintf("query: %s, numRepoRevs: %d", args.PatternInfo.Pattern, len(args.Repos)))
 	defer func() {
 		tr.SetError(err)
@@ -556,11 +577,14 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 		searchErr         error
 		unflattened       [][]*FileMatchResolver
 		flattenedSize     int
+		sentSize          int
 		overLimitCanceled bool // canceled because we were over the limit
 	)
 
-	// addMatches assumes the caller holds mu.
-	addMatches := func(matches []*FileMatchResolver) {
+	// addMatches adds the matches found in a batch of repositories, described
+	// by batch, to the results. It assumes the caller holds mu.
+	addMatches := func(matches []*FileMatchResolver, batch *searchResultsCommon) {
+		common.update(*batch)
 		if len(matches) > 0 {
 			common.resultCount += int32(len(matches))
 			sort.Slice(matches, func(i, j int) bool {
@@ -573,13 +597,22 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 			// Stop searching once we have found enough matches. This does
 			// lead to potentially unstable result ordering, but is worth
 			// it for the performance benefit.
-			if flattenedSize > int(args.PatternInfo.FileMatchLimit) {
-				tr.LazyPrintf("cancel due to result size: %d > %d", flattenedSize, args.PatternInfo.FileMatchLimit)
+			if flattenedSize > fileMatchLimit {
+				tr.LazyPrintf("cancel due to result size: %d > %d", flattenedSize, fileMatchLimit)
 				overLimitCanceled = true
 				common.limitHit = true
+				batch.limitHit = true
 				cancel()
 			}
 		}
+
+		if resultChannel != nil {
+			if remaining := fileMatchLimit - sentSize; len(matches) > remaining {
+				matches = matches[:remaining]
+			}
+			sentSize += len(matches)
+			sendSearchEvent(resultChannel, SearchEvent{Results: fileMatchesToSearchResults(matches), Stats: streamingStats(batch)})
+		}
 	}
 
 	// callSearcherOverRepos calls searcher on a set of repos.
@@ -617,6 +650,11 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 			textSearchLimiter.SetLimit(len(eps) * 32)
 		}
 
+		var explanation *searchBackendExplanation
+		if len(searcherRepos) > 0 {
+			_, explanation = searchExplainerFromContext(ctx).startBackend(ctx, searchBackendSearcher, searcherRepos, args.PatternInfo)
+		}
+
 	outer:
 		for _, repoAllRevs := range searcherRepos {
 			if len(repoAllRevs.Revs) == 0 {
@@ -656,27 +694,35 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 					defer done()
 
 					matches, repoLimitHit, err := searchFilesInRepo(ctx, args.SearcherURLs, repoRev.Repo, repoRev.GitserverRepo(), repoRev.RevSpecs()[0], args.PatternInfo, fetchTimeout)
+					explanation.finish()
+					var ownerErrs map[api.RepoName]error
+					if err == nil {
+						matches, ownerErrs = filterFileMatchesByOwner(ctx, args.PatternInfo, matches)
+					}
 					if err != nil {
 						tr.LogFields(otlog.String("repo", string(repoRev.Repo.Name)), otlog.Error(err), otlog.Bool("timeout", errcode.IsTimeout(err)), otlog.Bool("temporary", errcode.IsTemporary(err)))
 						log15.Warn("searchFilesInRepo failed", "error", err, "repo", repoRev.Repo.Name)
 					}
 					mu.Lock()
 					defer mu.Unlock()
+					repoCommon := &searchResultsCommon{partial: make(map[api.RepoName]struct{})}
 					if ctx.Err() == nil {
-						common.searched = append(common.searched, repoRev.Repo)
+						repoCommon.searched = append(repoCommon.searched, repoRev.Repo)
 					}
 					if repoLimitHit {
 						// We did not return all results in this repository.
-						common.partial[repoRev.Repo.Name] = struct{}{}
+						repoCommon.partial[repoRev.Repo.Name] = struct{}{}
 					}
+					markOwnerFilterFailures(repoCommon, ownerErrs)
 					// non-diff search reports timeout through err, so pass false for timedOut
-					if fatalErr := handleRepoSearchResult(common, repoRev, repoLimitHit, false, err); fatalErr != nil {
+					if fatalErr := handleRepoSearchResult(repoCommon, repoRev, repoLimitHit, false, err); fatalErr != nil {
 						if ctx.Err() == context.Canceled {
 							// Our request has been canceled (either because another one of searcherRepos
 							// had a fatal error, or otherwise), so we can just ignore these results. We
 							// handle this here, not in handleRepoSearchResult, because different callers of
 							// handleRepoSearchResult (for different result types) currently all need to
 							// handle cancellations differently.
+							common.update(*repoCommon)
 							return
 						}
 						if searchErr == nil {
@@ -685,7 +731,7 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 							cancel()
 						}
 					}
-					addMatches(matches)
+					addMatches(matches, repoCommon)
 				}(limitCtx, limitDone) // ends the Go routine for a call to searcher for a repo
 			} // ends the for loop iterating over repo's revs
 		} // ends the for loop iterating over repos
@@ -700,33 +746,45 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 		var reposLimitHit map[string]struct{}
 		var limitHit bool
 		var err error
+		zoektCtx := ctx
+		var explanation *searchBackendExplanation
+		if len(zoektRepos) > 0 {
+			zoektCtx, explanation = searchExplainerFromContext(ctx).startBackend(ctx, searchBackendZoekt, zoektRepos, args.PatternInfo)
+		}
 		if !args.PatternInfo.IsStructuralPat {
-			matches, limitHit, reposLimitHit, err = zoektSearchHEAD(ctx, args, zoektRepos, false, time.Since)
+			matches, limitHit, reposLimitHit, err = zoektSearchHEAD(zoektCtx, args, zoektRepos, false, time.Since)
 		} else {
-			matches, limitHit, reposLimitHit, err = zoektSearchHEADOnlyFiles(ctx, args, zoektRepos, false, time.Since)
+			matches, limitHit, reposLimitHit, err = zoektSearchHEADOnlyFiles(zoektCtx, args, zoektRepos, false, time.Since)
+		}
+		explanation.finish()
+		var ownerErrs map[api.RepoName]error
+		if err == nil {
+			matches, ownerErrs = filterFileMatchesByOwner(ctx, args.PatternInfo, matches)
 		}
 		mu.Lock()
 		defer mu.Unlock()
+		zoektCommon := &searchResultsCommon{partial: make(map[api.RepoName]struct{})}
 		if ctx.Err() == nil {
 			for _, repo := range zoektRepos {
-				common.searched = append(common.searched, repo.Repo)
-				common.indexed = append(common.indexed, repo.Repo)
+				zoektCommon.searched = append(zoektCommon.searched, repo.Repo)
+				zoektCommon.indexed = append(zoektCommon.indexed, repo.Repo)
 			}
 			for repo := range reposLimitHit {
 				// Repos that aren't included in the result set due to exceeded limits are partially searched
 				// for dynamic filter purposes. Note, reposLimitHit may include repos that did not have any results
 				// returned in the original result set, because indexed search has `limitHit` for the
 				// entire search rather than per repo as in non-indexed search.
-				common.partial[api.RepoName(repo)] = struct{}{}
+				zoektCommon.partial[api.RepoName(repo)] = struct{}{}
 			}
+			markOwnerFilterFailures(zoektCommon, ownerErrs)
 		}
 		if limitHit {
-			common.limitHit = true
+			zoektCommon.limitHit = true
 		}
 		if err == errNoResultsInTimeout {
 			// Effectively, all repositories have timed out.
 			for _, repo := range zoektRepos {
-				common.timedout = append(common.timedout, repo.Repo)
+				zoektCommon.timedout = append(zoektCommon.timedout, repo.Repo)
 			}
 		}
 		tr.LogFields(otlog.Error(err), otlog.Bool("overLimitCanceled", overLimitCanceled))
@@ -763,6 +821,7 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 			// The Zoekt part of the search is done here as far as
 			// structural search is concerned, so the lock can be
 			// freely released.
+			addMatches(nil, zoektCommon)
 			mu.Unlock()
 			err := callSearcherOverRepos(repos, partition)
 			mu.Lock()
@@ -770,7 +829,7 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 				searchErr = err
 			}
 		} else {
-			addMatches(matches)
+			addMatches(matches, zoektCommon)
 		}
 	}()
 
@@ -788,7 +847,7 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 		return nil, common, searchErr
 	}
 
-	flattened := flattenFileMatches(unflattened, int(args.PatternInfo.FileMatchLimit))
+	flattened := flattenFileMatches(unflattened, fileMatchLimit)
 	return flattened, common, nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -111,6 +112,80 @@ func TestSearchFilesInRepos(t *testing.T) {
 	}
 }
 
+func TestSearchFilesInReposStream(t *testing.T) {
+	mockSearchFilesInRepo = func(ctx context.Context, repo *types.Repo, gitserverRepo gitserver.Repo, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration) (matches []*FileMatchResolver, limitHit bool, err error) {
+		repoName := repo.Name
+		switch repoName {
+		case "foo/one", "foo/two":
+			return []*FileMatchResolver{
+				{
+					uri: "git://" + string(repoName) + "?" + rev + "#" + "main.go",
+				},
+			}, false, nil
+		case "foo/cloning":
+			return nil, false, &vcs.RepoNotExistError{Repo: repoName, CloneInProgress: true}
+		default:
+			return nil, false, errors.New("Unexpected repo")
+		}
+	}
+	defer func() { mockSearchFilesInRepo = nil }()
+
+	zoekt := &searchbackend.Zoekt{Client: &fakeSearcher{repos: &zoekt.RepoList{}}}
+
+	q, err := query.ParseAndCheck("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	searchStream := func(fileMatchLimit int32) (results []SearchResultResolver, stats streaming.Stats) {
+		args := &search.TextParameters{
+			PatternInfo: &search.TextPatternInfo{
+				FileMatchLimit: fileMatchLimit,
+				Pattern:        "foo",
+			},
+			Repos:        makeRepositoryRevisions("foo/one", "foo/two", "foo/cloning"),
+			Query:        q,
+			Zoekt:        zoekt,
+			SearcherURLs: endpoint.Static("test"),
+		}
+
+		events := make(chan SearchEvent)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for event := range events {
+				results = append(results, event.Results...)
+				stats.Update(event.Stats)
+			}
+		}()
+
+		_, _, err := searchFilesInReposStream(context.Background(), args, events)
+		close(events)
+		<-done
+		if err != nil {
+			t.Fatal(err)
+		}
+		return results, stats
+	}
+
+	results, stats := searchStream(defaultMaxSearchResults)
+	if len(results) != 2 {
+		t.Errorf("expected two streamed results, got %d", len(results))
+	}
+	if len(stats.Searched) != 3 {
+		t.Errorf("expected three searched repositories, got %v", stats.Searched)
+	}
+	if !reflect.DeepEqual(stats.Cloning, []api.RepoName{"foo/cloning"}) {
+		t.Errorf("unexpected cloning: %v", stats.Cloning)
+	}
+
+	// No more than FileMatchLimit results are streamed.
+	results, _ = searchStream(1)
+	if len(results) != 1 {
+		t.Errorf("expected one streamed result, got %d", len(results))
+	}
+}
+
 func TestSearchFilesInRepos_multipleRevsPerRepo(t *testing.T) {
 	mockSearchFilesInRepo = func(ctx context.Context, repo *types.Repo, gitserverRepo gitserver.Repo, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration) (matches []*FileMatchResolver, limitHit bool, err error) {
 		repoName := repo.Name
@@ -83,6 +83,7 @@ func zoektSearchHEAD(ctx context.Context, args *search.TextParameters, repos []*
 	if len(repos) == 0 {
 		return nil, false, nil, nil
 	}
+	explanation := searchBackendExplanationFromContext(ctx)
 
 	// Tell zoekt which repos to search
 	repoSet := &zoektquery.RepoSet{Set: make(map[string]bool, len(repos))}
@@ -142,6 +143,8 @@ func zoektSearchHEAD(ctx context.Context, args *search.TextParameters, repos []*
 	finalQuery = zoektquery.NewAnd(newRepoSet, queryExceptRepos)
 	tr.LazyPrintf("after repohasfile filters: nRepos=%d query=%v", len(newRepoSet.Set), finalQuery)
 
+	explanation.recordZoektQuery(finalQuery.String())
+
 	t0 := time.Now()
 	resp, err := args.Zoekt.Client.Search(ctx, finalQuery, &searchOpts)
 	if err != nil {
@@ -386,6 +389,21 @@ func fileRe(pattern string, queryIsCaseSensitive bool) (zoektquery.Q, error) {
 	return parseRe(pattern, true, queryIsCaseSensitive)
 }
 
+// contentRe is like parseRe, but only matches file contents.
+func contentRe(pattern string, queryIsCaseSensitive bool) (zoektquery.Q, error) {
+	q, err := parseRe(pattern, false, queryIsCaseSensitive)
+	if err != nil {
+		return nil, err
+	}
+	switch q := q.(type) {
+	case *zoektquery.Substring:
+		q.Content = true
+	case *zoektquery.Regexp:
+		q.Content = true
+	}
+	return q, nil
+}
+
 func queryToZoektQuery(query *search.TextPatternInfo, isSymbol bool) (zoektquery.Q, error) {
 	var and []zoektquery.Q
 
@@ -435,6 +453,13 @@ func queryToZoektQuery(query *search.TextPatternInfo, isSymbol bool) (zoektquery
 		}
 		and = append(and, &zoektquery.Not{Child: q})
 	}
+	for _, p := range query.ExcludeContentPatterns {
+		q, err := contentRe(p, query.IsCaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, &zoektquery.Not{Child: q})
+	}
 
 	return zoektquery.Simplify(zoektquery.NewAnd(and...)), nil
 }
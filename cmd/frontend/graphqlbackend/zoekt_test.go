@@ -702,6 +702,18 @@ func TestQueryToZoektQuery(t *testing.T) {
 			},
 			Query: `f:test`,
 		},
+		{
+			Name: "exclude content",
+			Pattern: &search.TextPatternInfo{
+				IsRegExp:                     true,
+				IsCaseSensitive:              false,
+				Pattern:                      "foo",
+				ExcludeContentPatterns:       []string{"bar", `ba(z|r)`},
+				PathPatternsAreRegExps:       true,
+				PathPatternsAreCaseSensitive: false,
+			},
+			Query: `foo case:no -c:bar -c:ba(z|r)`,
+		},
 	}
 	for _, tt := range cases {
 		t.Run(tt.Name, func(t *testing.T) {
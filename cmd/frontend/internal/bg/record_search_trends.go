@@ -0,0 +1,29 @@
+package bg
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+)
+
+// RecordSearchTrends periodically records the missing points of all search
+// trend series: the points of series that are still being backfilled and the
+// new points that become due as time passes. Recording a point is idempotent,
+// so concurrent frontend processes at worst repeat each other's searches.
+func RecordSearchTrends(ctx context.Context) {
+	for {
+		series, err := db.SearchTrends.ListAll(ctx)
+		if err != nil {
+			log15.Error("listing search trend series", "error", err)
+		}
+		for _, s := range series {
+			if _, err := graphqlbackend.RecordSearchTrendSeries(ctx, s, time.Now(), graphqlbackend.SearchTrendSearchesPerRun); err != nil {
+				log15.Error("recording search trend series", "id", s.ID, "error", err)
+			}
+		}
+		time.Sleep(10 * time.Minute)
+	}
+}
@@ -0,0 +1,97 @@
+package bg
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// RunSearchExportJobs processes the queued search export jobs one at a time.
+// Jobs abandoned by a frontend process that died are resumed once they are
+// stale.
+func RunSearchExportJobs(ctx context.Context) {
+	for {
+		job, err := db.SearchExportJobs.Dequeue(ctx, graphqlbackend.SearchExportStaleAfter)
+		if err != nil {
+			log15.Error("dequeuing search export job", "error", err)
+		}
+		if job == nil {
+			time.Sleep(10 * time.Second)
+			continue
+		}
+
+		var failureMessage string
+		if err := graphqlbackend.RunSearchExportJob(ctx, job); err != nil {
+			log15.Warn("running search export job", "id", job.ID, "error", err)
+			failureMessage = err.Error()
+		}
+		if err := db.SearchExportJobs.MarkFinished(ctx, job.ID, failureMessage); err != nil {
+			log15.Error("marking search export job as finished", "id", job.ID, "error", err)
+		}
+	}
+}
+
+// DeleteExpiredSearchExportJobs periodically deletes the search export jobs
+// that finished longer than graphqlbackend.SearchExportRetention ago, and
+// their files. Files of jobs that no longer exist, e.g. because they were
+// deleted by another frontend instance, are also removed.
+func DeleteExpiredSearchExportJobs(ctx context.Context) {
+	for {
+		jobs, err := db.SearchExportJobs.ListFinishedBefore(ctx, time.Now().Add(-graphqlbackend.SearchExportRetention))
+		if err != nil {
+			log15.Error("listing expired search export jobs", "error", err)
+		}
+		for _, job := range jobs {
+			if err := os.Remove(graphqlbackend.SearchExportPath(job)); err != nil && !os.IsNotExist(err) {
+				log15.Error("deleting file of expired search export job", "id", job.ID, "error", err)
+				continue
+			}
+			if err := db.SearchExportJobs.Delete(ctx, job.ID); err != nil {
+				log15.Error("deleting expired search export job", "id", job.ID, "error", err)
+			}
+		}
+		if err := deleteOrphanedSearchExportFiles(ctx); err != nil {
+			log15.Error("deleting orphaned search export files", "error", err)
+		}
+		time.Sleep(time.Hour)
+	}
+}
+
+// deleteOrphanedSearchExportFiles removes the files in
+// graphqlbackend.SearchExportDir that don't belong to an existing search export
+// job.
+func deleteOrphanedSearchExportFiles(ctx context.Context) error {
+	infos, err := ioutil.ReadDir(graphqlbackend.SearchExportDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, fi := range infos {
+		name := fi.Name()
+		id, err := strconv.ParseInt(strings.TrimSuffix(name, filepath.Ext(name)), 10, 32)
+		if err != nil || fi.IsDir() {
+			continue
+		}
+		if _, err := db.SearchExportJobs.GetByID(ctx, int32(id)); !errcode.IsNotFound(err) {
+			if err != nil {
+				log15.Error("getting search export job", "id", id, "error", err)
+			}
+			continue
+		}
+		if err := os.Remove(filepath.Join(graphqlbackend.SearchExportDir, name)); err != nil && !os.IsNotExist(err) {
+			log15.Error("deleting orphaned search export file", "name", name, "error", err)
+		}
+	}
+	return nil
+}
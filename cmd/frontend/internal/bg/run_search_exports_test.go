@@ -0,0 +1,58 @@
+package bg
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+type notFoundError struct{ error }
+
+func (notFoundError) NotFound() bool { return true }
+
+func TestDeleteOrphanedSearchExportFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "search-exports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(old string) { graphqlbackend.SearchExportDir = old }(graphqlbackend.SearchExportDir)
+	graphqlbackend.SearchExportDir = dir
+
+	for _, name := range []string{"1.csv", "2.jsonl", "other"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db.Mocks.SearchExportJobs.GetByID = func(ctx context.Context, id int32) (*types.SearchExportJob, error) {
+		if id == 1 {
+			return &types.SearchExportJob{ID: 1}, nil
+		}
+		return nil, notFoundError{errors.New("not found")}
+	}
+	defer func() { db.Mocks.SearchExportJobs = db.MockSearchExportJobs{} }()
+
+	if err := deleteOrphanedSearchExportFiles(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, fi := range infos {
+		names = append(names, fi.Name())
+	}
+	if len(names) != 2 || names[0] != "1.csv" || names[1] != "other" {
+		t.Errorf("got files %v, want [1.csv other]", names)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"os/user"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -241,8 +242,10 @@ func serviceConnections() conftypes.ServiceConnections {
 		}
 
 		serviceConnectionsVal = conftypes.ServiceConnections{
-			GitServers:  gitServers(),
-			PostgresDSN: dbutil.PostgresDSN(username, os.Getenv),
+			GitServers:                 gitServers(),
+			GitServerReplicationFactor: gitServerReplicationFactor(),
+			GitServersPrevious:         strings.Fields(os.Getenv("SRC_GIT_SERVERS_PREVIOUS")),
+			PostgresDSN:                dbutil.PostgresDSN(username, os.Getenv),
 		}
 	})
 	return serviceConnectionsVal
@@ -259,3 +262,15 @@ func gitServers() []string {
 	}
 	return strings.Fields(v)
 }
+
+func gitServerReplicationFactor() int {
+	v := os.Getenv("SRC_GIT_SERVER_REPLICATION_FACTOR")
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		log.Fatalf("invalid SRC_GIT_SERVER_REPLICATION_FACTOR %q: must be a non-negative integer", v)
+	}
+	return n
+}
@@ -194,6 +194,9 @@ func Main(enterpriseSetupHook func() enterprise.Services) error {
 	goroutine.Go(func() { bg.CheckRedisCacheEvictionPolicy() })
 	goroutine.Go(func() { bg.DeleteOldCacheDataInRedis() })
 	goroutine.Go(func() { bg.DeleteOldEventLogsInPostgres(context.Background()) })
+	goroutine.Go(func() { bg.RecordSearchTrends(context.Background()) })
+	goroutine.Go(func() { bg.RunSearchExportJobs(context.Background()) })
+	goroutine.Go(func() { bg.DeleteExpiredSearchExportJobs(context.Background()) })
 	go updatecheck.Start()
 
 	// Parse GraphQL schema and set up resolvers that depend on dbconn.Global
@@ -208,7 +211,7 @@ func Main(enterpriseSetupHook func() enterprise.Services) error {
 	}
 
 	// Create the external HTTP handler.
-	externalHandler, err := newExternalHTTPHandler(schema, enterprise.GithubWebhook, enterprise.BitbucketServerWebhook, enterprise.NewCodeIntelUploadHandler)
+	externalHandler, err := newExternalHTTPHandler(schema, enterprise.GithubWebhook, enterprise.BitbucketServerWebhook, enterprise.GitLabWebhook, enterprise.NewCodeIntelUploadHandler)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,258 @@
+// Package codeowners parses CODEOWNERS files, in the formats of GitHub and
+// GitLab, to determine who owns the files of a repository.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Paths are the paths of the CODEOWNERS file in a repository, in order of
+// precedence. GitHub looks for it in .github/, the root and docs/, and GitLab
+// in the root, docs/ and .gitlab/.
+var Paths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	// Path is the path of the CODEOWNERS file in the repository.
+	Path string
+
+	// Rules are the rules of the file, in the order they appear.
+	Rules []*Rule
+}
+
+// Rule is a line of a CODEOWNERS file that assigns owners to the files
+// matching a pattern.
+type Rule struct {
+	// Pattern is the gitignore-style pattern of the paths of the files that
+	// the rule applies to, such as "*.go" or "/docs/".
+	Pattern string
+
+	// Owners are the owners of the files, such as "@user", "@org/team" or an
+	// email address. A rule without owners unassigns the files. In GitLab
+	// sections, the default owners of the section are used.
+	Owners []string
+
+	// Section is the name of the GitLab section of the rule, or empty if the
+	// rule isn't in a section.
+	Section string
+
+	// LineNumber is the 1-based line number of the rule in the file.
+	LineNumber int
+
+	re *regexp.Regexp
+}
+
+// sectionPattern matches the header of a GitLab section, such as
+// "[Documentation]", "^[Optional]" or "[Frontend][2] @frontend-team", with
+// the default owners of the section.
+var sectionPattern = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(.*)$`)
+
+// Parse parses the CODEOWNERS file at the given path. Lines whose pattern is
+// invalid are ignored, as GitHub and GitLab do.
+func Parse(path string, r io.Reader) (*Ruleset, error) {
+	rs := &Ruleset{Path: path}
+
+	var section string
+	var sectionOwners []string
+	// Sections with the same name, regardless of case, are combined.
+	sectionNames := map[string]string{}
+	sectionDefaultOwners := map[string][]string{}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if m := sectionPattern.FindStringSubmatch(line); m != nil {
+			key := strings.ToLower(m[1])
+			if _, ok := sectionNames[key]; !ok {
+				sectionNames[key] = m[1]
+			}
+			section = sectionNames[key]
+			if owners := parseOwners(m[2]); len(owners) > 0 {
+				sectionDefaultOwners[key] = owners
+			}
+			sectionOwners = sectionDefaultOwners[key]
+			continue
+		}
+
+		pattern, rest := scanPattern(line)
+		re, err := compilePattern(pattern)
+		if err != nil {
+			continue
+		}
+		owners := parseOwners(rest)
+		if len(owners) == 0 {
+			owners = sectionOwners
+		}
+		rs.Rules = append(rs.Rules, &Rule{
+			Pattern:    pattern,
+			Owners:     owners,
+			Section:    section,
+			LineNumber: lineNumber,
+			re:         re,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// scanPattern splits a rule line into its pattern, in which spaces may be
+// escaped with a backslash, and the rest of the line.
+func scanPattern(line string) (pattern, rest string) {
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && i+1 < len(line) && (line[i+1] == ' ' || line[i+1] == '#'):
+			b.WriteByte(line[i+1])
+			i++
+		case c == ' ' || c == '\t':
+			return b.String(), line[i:]
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), ""
+}
+
+// parseOwners parses the owners that follow the pattern of a rule, up to an
+// inline comment.
+func parseOwners(s string) []string {
+	var owners []string
+	for _, f := range strings.Fields(s) {
+		if strings.HasPrefix(f, "#") {
+			break
+		}
+		owners = append(owners, f)
+	}
+	return owners
+}
+
+// compilePattern compiles a gitignore-style pattern to a regular expression
+// matching the paths of the files it applies to. A pattern that matches a
+// directory applies to all the files in it.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.Trim(pattern, "/")
+	// A pattern with a slash at its start or in its middle is relative to the
+	// root of the repository. Otherwise it matches at any depth.
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(p, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '*':
+			if strings.HasPrefix(p[i:], "**/") {
+				b.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(p[i:], "**") {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(p[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := p[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(p) {
+				i++
+				b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.HasSuffix(p, "/*"):
+		// As on GitHub, a pattern ending with "/*" only matches the files
+		// directly in the directory, not those in its subdirectories.
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
+
+// Match returns the rules that determine the owners of the file at path: the
+// last rule matching the path in each section, in the order the sections first
+// appear. Outside GitLab sections, this is the last matching rule of the file.
+func (rs *Ruleset) Match(path string) []*Rule {
+	path = strings.TrimPrefix(path, "/")
+
+	var sections []string
+	last := map[string]*Rule{}
+	for _, rule := range rs.Rules {
+		if !rule.re.MatchString(path) {
+			continue
+		}
+		if _, ok := last[rule.Section]; !ok {
+			sections = append(sections, rule.Section)
+		}
+		last[rule.Section] = rule
+	}
+
+	rules := make([]*Rule, 0, len(sections))
+	for _, section := range sections {
+		rules = append(rules, last[section])
+	}
+	return rules
+}
+
+// Owners returns the owners of the file at path, or nil if it has none.
+func (rs *Ruleset) Owners(path string) []string {
+	var owners []string
+	for _, rule := range rs.Match(path) {
+	next:
+		for _, owner := range rule.Owners {
+			for _, o := range owners {
+				if SameOwner(o, owner) {
+					continue next
+				}
+			}
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
+
+// IsOwnedBy reports whether owner is one of the owners of the file at path.
+func (rs *Ruleset) IsOwnedBy(path, owner string) bool {
+	for _, o := range rs.Owners(path) {
+		if SameOwner(o, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// SameOwner reports whether a and b are the same owner. Owners are compared
+// case-insensitively, and the "@" of user and team handles may be omitted.
+func SameOwner(a, b string) bool {
+	return strings.EqualFold(strings.TrimPrefix(a, "@"), strings.TrimPrefix(b, "@"))
+}
@@ -0,0 +1,119 @@
+package codeowners
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRuleset_Owners_GitHub(t *testing.T) {
+	rs, err := Parse(".github/CODEOWNERS", strings.NewReader(`
+# Default owners.
+*       @global-owner1 @global-owner2
+
+*.js    @js-owner # JavaScript
+*.go    docs@example.com
+
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+**/logs @logs-owner
+/scripts/ @doctocat @octocat
+/apps/github
+path\ with\ spaces/ @spaces
+[invalid @nobody
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		path string
+		want []string
+	}{
+		{path: "README.md", want: []string{"@global-owner1", "@global-owner2"}},
+		{path: "web/index.js", want: []string{"@js-owner"}},
+		{path: "main.go", want: []string{"docs@example.com"}},
+		{path: "build/logs/x.log", want: []string{"@logs-owner"}},
+		{path: "docs/getting-started.md", want: []string{"docs@example.com"}},
+		{path: "docs/build-app/troubleshooting.md", want: []string{"@global-owner1", "@global-owner2"}},
+		{path: "x/apps/main.go", want: []string{"@octocat"}},
+		{path: "apps", want: []string{"@global-owner1", "@global-owner2"}},
+		{path: "deep/down/logs/a.txt", want: []string{"@logs-owner"}},
+		{path: "scripts/build.sh", want: []string{"@doctocat", "@octocat"}},
+		// A rule without owners unassigns the files.
+		{path: "apps/github/main.go", want: nil},
+		{path: "path with spaces/a.txt", want: []string{"@spaces"}},
+		{path: "/README.md", want: []string{"@global-owner1", "@global-owner2"}},
+	} {
+		if have := rs.Owners(tc.path); !reflect.DeepEqual(have, tc.want) {
+			t.Errorf("%s: got owners %q, want %q", tc.path, have, tc.want)
+		}
+	}
+
+	if rules := rs.Match("scripts/build.sh"); len(rules) != 1 || rules[0].Pattern != "/scripts/" || rules[0].LineNumber != 12 {
+		t.Errorf("unexpected matching rules %+v", rules)
+	}
+}
+
+func TestRuleset_Owners_GitLab(t *testing.T) {
+	rs, err := Parse("CODEOWNERS", strings.NewReader(`
+*.rb @ruby-owner
+
+[Documentation] @docs-team
+docs/
+README.md @readme-owner
+
+^[Database][2] @database-team @dba
+model/db/
+config/db/database-setup.md @docs-team
+
+[DOCUMENTATION]
+*.md
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		path string
+		want []string
+	}{
+		{path: "app.rb", want: []string{"@ruby-owner"}},
+		{path: "docs/index.md", want: []string{"@docs-team"}},
+		// Sections with the same name are combined, so the last rule of the
+		// Documentation section matches.
+		{path: "README.md", want: []string{"@docs-team"}},
+		{path: "model/db/user.rb", want: []string{"@ruby-owner", "@database-team", "@dba"}},
+		// The owners of each section are combined.
+		{path: "config/db/database-setup.md", want: []string{"@docs-team"}},
+		{path: "main.go", want: nil},
+	} {
+		if have := rs.Owners(tc.path); !reflect.DeepEqual(have, tc.want) {
+			t.Errorf("%s: got owners %q, want %q", tc.path, have, tc.want)
+		}
+	}
+
+	rules := rs.Match("config/db/database-setup.md")
+	if len(rules) != 2 || rules[0].Section != "Database" || rules[1].Section != "Documentation" {
+		t.Errorf("unexpected matching rules %+v", rules)
+	}
+}
+
+func TestRuleset_IsOwnedBy(t *testing.T) {
+	rs, err := Parse("CODEOWNERS", strings.NewReader("* @Org/Team alice@example.com\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for owner, want := range map[string]bool{
+		"@org/team":         true,
+		"org/team":          true,
+		"ALICE@example.com": true,
+		"@org":              false,
+		"bob@example.com":   false,
+	} {
+		if have := rs.IsOwnedBy("a/b.go", owner); have != want {
+			t.Errorf("%s: got %v, want %v", owner, have, want)
+		}
+	}
+}
@@ -359,10 +359,11 @@ func serveSavedQueriesSetInfo(w http.ResponseWriter, r *http.Request) error {
 		return errors.Wrap(err, "Decode")
 	}
 	err = db.QueryRunnerState.Set(r.Context(), &db.SavedQueryInfo{
-		Query:        info.Query,
-		LastExecuted: info.LastExecuted,
-		LatestResult: info.LatestResult,
-		ExecDuration: info.ExecDuration,
+		Query:             info.Query,
+		LastExecuted:      info.LastExecuted,
+		LatestResult:      info.LatestResult,
+		ExecDuration:      info.ExecDuration,
+		ResultFingerprint: info.ResultFingerprint,
 	})
 	if err != nil {
 		return errors.Wrap(err, "SavedQueries.Set")
@@ -387,6 +388,28 @@ func serveSavedQueriesDeleteInfo(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+func serveSavedQueriesLogWebhookDelivery(w http.ResponseWriter, r *http.Request) error {
+	var delivery *api.SavedQueryWebhookDelivery
+	err := json.NewDecoder(r.Body).Decode(&delivery)
+	if err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	err = db.SavedSearches.LogWebhookDelivery(r.Context(), &types.SavedSearchWebhookDelivery{
+		SavedSearchID: delivery.SavedSearchID,
+		URL:           delivery.URL,
+		Attempt:       int32(delivery.Attempt),
+		StatusCode:    int32(delivery.StatusCode),
+		Error:         delivery.Error,
+		Duration:      delivery.Duration,
+	})
+	if err != nil {
+		return errors.Wrap(err, "SavedSearches.LogWebhookDelivery")
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+	return nil
+}
+
 func serveSettingsGetForSubject(w http.ResponseWriter, r *http.Request) error {
 	var subject api.SettingsSubject
 	if err := json.NewDecoder(r.Body).Decode(&subject); err != nil {
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// ExportHandler is an HTTP handler which serves the file of a completed search
+// export job to its owner or to site admins. The ID of the job is read from the
+// "ID" route variable.
+func ExportHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["ID"], 10, 32)
+	if err != nil {
+		http.Error(w, "invalid search export job ID", http.StatusBadRequest)
+		return
+	}
+
+	job, err := db.SearchExportJobs.GetByID(r.Context(), int32(id))
+	if errcode.IsNotFound(err) {
+		http.Error(w, "search export job not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log15.Error("getting search export job", "id", id, "error", err)
+		http.Error(w, "error getting search export job", http.StatusInternalServerError)
+		return
+	}
+
+	// 🚨 SECURITY: Only the owner of the job and site admins may download its
+	// file. Don't reveal the existence of the jobs of other users.
+	if err := backend.CheckSiteAdminOrSameUser(r.Context(), job.UserID); err != nil {
+		http.Error(w, "search export job not found", http.StatusNotFound)
+		return
+	}
+
+	if job.State != types.SearchExportJobStateCompleted {
+		http.Error(w, "search export job is not completed", http.StatusConflict)
+		return
+	}
+
+	path := graphqlbackend.SearchExportPath(job)
+	f, err := os.Open(path)
+	if err != nil {
+		log15.Error("opening search export file", "id", id, "error", err)
+		http.Error(w, "search export file not found", http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		http.Error(w, "error reading search export file", http.StatusInternalServerError)
+		return
+	}
+
+	contentType := "application/x-ndjson"
+	if job.Format == types.SearchExportFormatCSV {
+		contentType = "text/csv"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "search-export-"+filepath.Base(path)))
+	http.ServeContent(w, r, "", fi.ModTime(), f)
+}
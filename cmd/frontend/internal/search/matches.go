@@ -0,0 +1,136 @@
+package search
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+)
+
+// eventMatch is a match in the payload of a "matches" event. Type is one of
+// "file", "repo", "commit", "codemod" or "group", and determines which of the other fields are set.
+type eventMatch struct {
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+
+	// Set for file matches.
+	Path        string           `json:"path,omitempty"`
+	Revision    string           `json:"revision,omitempty"`
+	Version     string           `json:"version,omitempty"`
+	LineMatches []eventLineMatch `json:"lineMatches,omitempty"`
+	Symbols     []eventSymbol    `json:"symbols,omitempty"`
+
+	// Set for commit and codemod matches. The content of a codemod match is
+	// its diff.
+	Label   string     `json:"label,omitempty"`
+	URL     string     `json:"url,omitempty"`
+	Detail  string     `json:"detail,omitempty"`
+	Content string     `json:"content,omitempty"`
+	Ranges  [][3]int32 `json:"ranges,omitempty"` // line, character, length
+
+	// Set for capture group matches. URL is the location of the first
+	// example match.
+	Value string `json:"value,omitempty"`
+	Count int32  `json:"count,omitempty"`
+}
+
+type eventLineMatch struct {
+	Line             string     `json:"line"`
+	LineNumber       int32      `json:"lineNumber"`
+	OffsetAndLengths [][2]int32 `json:"offsetAndLengths"`
+}
+
+type eventSymbol struct {
+	Name          string `json:"name"`
+	ContainerName string `json:"containerName,omitempty"`
+	Kind          string `json:"kind"`
+}
+
+// toEventMatch converts a search result into a match. It returns false for
+// result types which are not streamed.
+func toEventMatch(result graphqlbackend.SearchResultResolver) (eventMatch, bool) {
+	if fm, ok := result.ToFileMatch(); ok {
+		return fromFileMatch(fm), true
+	}
+	if repo, ok := result.ToRepository(); ok {
+		return eventMatch{Type: "repo", Repository: repo.Name()}, true
+	}
+	if commit, ok := result.ToCommitSearchResult(); ok {
+		match := eventMatch{
+			Type:       "commit",
+			Repository: commit.Commit().Repository().Name(),
+			Version:    string(commit.Commit().OID()),
+			Label:      commit.Label().Text(),
+			URL:        commit.URL(),
+			Detail:     commit.Detail().Text(),
+		}
+		preview := commit.MessagePreview()
+		if diffPreview := commit.DiffPreview(); diffPreview != nil {
+			preview = diffPreview
+		}
+		if preview != nil {
+			match.Content = preview.Value()
+			for _, h := range preview.Highlights() {
+				match.Ranges = append(match.Ranges, [3]int32{h.Line(), h.Character(), h.Length()})
+			}
+		}
+		return match, true
+	}
+	if codemod, ok := result.ToCodemodResult(); ok {
+		match := eventMatch{
+			Type:       "codemod",
+			Repository: codemod.Commit().Repository().Name(),
+			Version:    string(codemod.Commit().OID()),
+			URL:        codemod.URL(),
+			Content:    codemod.RawDiff(),
+		}
+		if label, err := codemod.Label(); err == nil {
+			match.Label = label.Text()
+		}
+		if detail, err := codemod.Detail(); err == nil {
+			match.Detail = detail.Text()
+		}
+		return match, true
+	}
+	if group, ok := result.ToCaptureGroupResult(); ok {
+		return eventMatch{
+			Type:   "group",
+			Label:  group.Label().Text(),
+			URL:    group.URL(),
+			Detail: group.Detail().Text(),
+			Value:  group.Value(),
+			Count:  group.Count(),
+		}, true
+	}
+	return eventMatch{}, false
+}
+
+func fromFileMatch(fm *graphqlbackend.FileMatchResolver) eventMatch {
+	match := eventMatch{
+		Type:       "file",
+		Repository: fm.Repo.Name(),
+		Path:       fm.JPath,
+		Version:    string(fm.CommitID),
+	}
+	if fm.InputRev != nil {
+		match.Revision = *fm.InputRev
+	}
+
+	for _, lm := range fm.JLineMatches {
+		match.LineMatches = append(match.LineMatches, eventLineMatch{
+			Line:             lm.JPreview,
+			LineNumber:       lm.JLineNumber,
+			OffsetAndLengths: lm.JOffsetAndLengths,
+		})
+	}
+
+	for _, symbol := range fm.Symbols() {
+		s := eventSymbol{
+			Name: symbol.Name(),
+			Kind: symbol.Kind(),
+		}
+		if containerName := symbol.ContainerName(); containerName != nil {
+			s.ContainerName = *containerName
+		}
+		match.Symbols = append(match.Symbols, s)
+	}
+
+	return match
+}
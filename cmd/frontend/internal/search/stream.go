@@ -0,0 +1,248 @@
+// Package search implements the HTTP endpoints which stream search results to
+// clients as Server-Sent Events and serve the files of search export jobs.
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// StreamHandler is an HTTP handler which streams back search results as
+// Server-Sent Events as soon as they are found.
+//
+// The search query is read from the "q" URL parameter, the pattern type from
+// the optional "t" parameter and the version context from the optional "v"
+// parameter. The following events are sent:
+//
+//   - "matches": a JSON array of matches (see eventMatch)
+//   - "progress": the statistics of the search so far (see eventProgress)
+//   - "alert": an alert about the search query (see eventAlert)
+//   - "error": a fatal error (see eventError)
+//   - "done": sent last, once the search is complete
+func StreamHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		http.Error(w, "no query found", http.StatusBadRequest)
+		return
+	}
+
+	ew, err := newEventWriter(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	events := make(chan graphqlbackend.SearchEvent)
+	args := &graphqlbackend.SearchArgs{
+		Version:       "V2",
+		Query:         query,
+		ResultChannel: events,
+	}
+	if t := r.URL.Query().Get("t"); t != "" {
+		args.PatternType = &t
+	}
+	if v := r.URL.Query().Get("v"); v != "" {
+		args.VersionContext = &v
+	}
+
+	search, err := graphqlbackend.NewSearchImplementer(args)
+	if err != nil {
+		_ = ew.Event("error", eventError{Message: err.Error()})
+		_ = ew.Event("done", map[string]interface{}{})
+		return
+	}
+
+	streamSearch(r.Context(), ew, search, events)
+}
+
+// searcher is the part of graphqlbackend.SearchImplementer used to stream results.
+type searcher interface {
+	Results(context.Context) (*graphqlbackend.SearchResultsResolver, error)
+}
+
+// streamSearch runs search and writes its results to ew as they arrive on
+// events, the result channel of search.
+func streamSearch(ctx context.Context, ew *eventWriter, search searcher, events chan graphqlbackend.SearchEvent) {
+	start := time.Now()
+
+	var (
+		results *graphqlbackend.SearchResultsResolver
+		err     error
+	)
+	go func() {
+		defer close(events)
+		results, err = search.Results(ctx)
+	}()
+
+	progress := eventProgress{}
+	var stats streaming.Stats
+	var writeErr error
+
+	// We keep receiving after a failed write, because the search only returns
+	// once the result channel has been drained.
+	for event := range events {
+		if writeErr != nil {
+			continue
+		}
+
+		matches := make([]eventMatch, 0, len(event.Results))
+		for _, result := range event.Results {
+			if match, ok := toEventMatch(result); ok {
+				matches = append(matches, match)
+			}
+		}
+		if len(matches) > 0 {
+			progress.MatchCount += len(matches)
+			if writeErr = ew.Event("matches", matches); writeErr != nil {
+				continue
+			}
+		}
+
+		stats.Update(event.Stats)
+		progress.update(stats, time.Since(start))
+		writeErr = ew.Event("progress", progress)
+	}
+
+	if writeErr != nil {
+		log15.Debug("search stream: failed to write event", "error", writeErr)
+		return
+	}
+
+	if err != nil {
+		_ = ew.Event("error", eventError{Message: err.Error()})
+	} else if alert, ok := toEventAlert(results); ok {
+		_ = ew.Event("alert", alert)
+	}
+
+	progress.update(stats, time.Since(start))
+	progress.done(stats)
+	_ = ew.Event("progress", progress)
+	_ = ew.Event("done", map[string]interface{}{})
+}
+
+// eventWriter writes Server-Sent Events to an HTTP response.
+type eventWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+}
+
+func newEventWriter(w http.ResponseWriter) (*eventWriter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, fmt.Errorf("http flushing not supported")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &eventWriter{w: w, flusher: flusher}, nil
+}
+
+// Event writes an event with the JSON encoding of data and flushes it to the
+// client.
+func (e *eventWriter) Event(event string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", event, encoded); err != nil {
+		return err
+	}
+	e.flusher.Flush()
+	return nil
+}
+
+// eventProgress is the payload of a "progress" event. It describes the
+// repositories searched so far.
+//
+// The names of the repositories which could not be searched are only sent in
+// the last progress event (with done set), so that the payload of each event
+// doesn't grow with the number of repositories. Earlier events only contain
+// their counts.
+type eventProgress struct {
+	Done                 bool           `json:"done"`
+	MatchCount           int            `json:"matchCount"`
+	DurationMs           int64          `json:"durationMs"`
+	LimitHit             bool           `json:"limitHit"`
+	RepositoriesSearched int            `json:"repositoriesSearched"`
+	RepositoriesIndexed  int            `json:"repositoriesIndexed"`
+	CloningCount         int            `json:"cloningCount"`
+	MissingCount         int            `json:"missingCount"`
+	TimedoutCount        int            `json:"timedoutCount"`
+	Cloning              []api.RepoName `json:"cloning,omitempty"`
+	Missing              []api.RepoName `json:"missing,omitempty"`
+	Timedout             []api.RepoName `json:"timedout,omitempty"`
+}
+
+func (p *eventProgress) update(stats streaming.Stats, elapsed time.Duration) {
+	p.DurationMs = elapsed.Milliseconds()
+	p.LimitHit = stats.LimitHit
+	p.RepositoriesSearched = len(stats.Searched)
+	p.RepositoriesIndexed = len(stats.Indexed)
+	p.CloningCount = len(stats.Cloning)
+	p.MissingCount = len(stats.Missing)
+	p.TimedoutCount = len(stats.Timedout)
+}
+
+// done marks p as the last progress event of a search with the given stats.
+func (p *eventProgress) done(stats streaming.Stats) {
+	p.Done = true
+	p.Cloning = stats.Cloning
+	p.Missing = stats.Missing
+	p.Timedout = stats.Timedout
+}
+
+// eventError is the payload of an "error" event.
+type eventError struct {
+	Message string `json:"message"`
+}
+
+// eventAlert is the payload of an "alert" event.
+type eventAlert struct {
+	Title           string               `json:"title"`
+	Description     string               `json:"description,omitempty"`
+	ProposedQueries []eventProposedQuery `json:"proposedQueries"`
+}
+
+type eventProposedQuery struct {
+	Description string `json:"description,omitempty"`
+	Query       string `json:"query"`
+}
+
+// toEventAlert returns the payload of an "alert" event for the alert of
+// results, if any.
+func toEventAlert(results *graphqlbackend.SearchResultsResolver) (eventAlert, bool) {
+	if results == nil || results.Alert() == nil {
+		return eventAlert{}, false
+	}
+	a := results.Alert()
+
+	alert := eventAlert{Title: a.Title(), ProposedQueries: []eventProposedQuery{}}
+	if description := a.Description(); description != nil {
+		alert.Description = *description
+	}
+	if proposedQueries := a.ProposedQueries(); proposedQueries != nil {
+		for _, q := range *proposedQueries {
+			proposed := eventProposedQuery{Query: q.Query()}
+			if description := q.Description(); description != nil {
+				proposed.Description = *description
+			}
+			alert.ProposedQueries = append(alert.ProposedQueries, proposed)
+		}
+	}
+	return alert, true
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+type fakeSearcher struct {
+	events  chan<- graphqlbackend.SearchEvent
+	batches []graphqlbackend.SearchEvent
+}
+
+func (s *fakeSearcher) Results(ctx context.Context) (*graphqlbackend.SearchResultsResolver, error) {
+	for _, batch := range s.batches {
+		s.events <- batch
+	}
+	return &graphqlbackend.SearchResultsResolver{}, nil
+}
+
+func TestStreamSearch(t *testing.T) {
+	repo := graphqlbackend.NewRepositoryResolver(&types.Repo{ID: 1, Name: "repo"})
+	events := make(chan graphqlbackend.SearchEvent)
+	search := &fakeSearcher{
+		events: events,
+		batches: []graphqlbackend.SearchEvent{
+			{
+				Results: []graphqlbackend.SearchResultResolver{repo},
+				Stats:   streaming.Stats{Searched: []api.RepoName{"repo"}},
+			},
+			{
+				Results: []graphqlbackend.SearchResultResolver{
+					&graphqlbackend.FileMatchResolver{JPath: "a.go", Repo: repo, CommitID: "deadbeef"},
+				},
+				Stats: streaming.Stats{Cloning: []api.RepoName{"other"}},
+			},
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	ew, err := newEventWriter(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	streamSearch(context.Background(), ew, search, events)
+
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("unexpected content type: %q", got)
+	}
+
+	var names []string
+	var matches []eventMatch
+	var progress []eventProgress
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			names = append(names, strings.TrimPrefix(line, "event: "))
+		case strings.HasPrefix(line, "data: "):
+			data := []byte(strings.TrimPrefix(line, "data: "))
+			var err error
+			switch names[len(names)-1] {
+			case "matches":
+				var batch []eventMatch
+				err = json.Unmarshal(data, &batch)
+				matches = append(matches, batch...)
+			case "progress":
+				var p eventProgress
+				err = json.Unmarshal(data, &p)
+				progress = append(progress, p)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if diff := cmp.Diff([]string{"matches", "progress", "matches", "progress", "progress", "done"}, names); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+
+	expectedMatches := []eventMatch{
+		{Type: "repo", Repository: "repo"},
+		{Type: "file", Repository: "repo", Path: "a.go", Version: "deadbeef"},
+	}
+	if diff := cmp.Diff(expectedMatches, matches); diff != "" {
+		t.Errorf("unexpected matches (-want +got):\n%s", diff)
+	}
+
+	// Only the last progress event lists the repositories which could not be
+	// searched; the others only count them.
+	expectedProgress := []eventProgress{
+		{
+			MatchCount:           1,
+			RepositoriesSearched: 1,
+		},
+		{
+			MatchCount:           2,
+			RepositoriesSearched: 1,
+			CloningCount:         1,
+		},
+		{
+			Done:                 true,
+			MatchCount:           2,
+			RepositoriesSearched: 1,
+			CloningCount:         1,
+			Cloning:              []api.RepoName{"other"},
+		},
+	}
+	for i := range progress {
+		progress[i].DurationMs = 0
+	}
+	if diff := cmp.Diff(expectedProgress, progress); diff != "" {
+		t.Errorf("unexpected progress (-want +got):\n%s", diff)
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // SavedSearch represents a saved search
 type SavedSearch struct {
 	ID              int32 // the globally unique DB ID
@@ -10,4 +12,20 @@ type SavedSearch struct {
 	UserID          *int32  // if non-nil, the owner is this user. UserID/OrgID are mutually exclusive.
 	OrgID           *int32  // if non-nil, the owner is this organization. UserID/OrgID are mutually exclusive.
 	SlackWebhookURL *string // if non-nil && NotifySlack == true, indicates that this Slack webhook URL should be used instead of the owners default Slack webhook.
+	NotifyWebhook   bool    // whether or not to notify the owner(s) of this saved search by posting to WebhookURL
+	WebhookURL      *string // the URL that notifications are posted to if NotifyWebhook == true
+	WebhookSecret   *string // if non-nil and non-empty, the secret used to sign the notifications posted to WebhookURL. It is write-only: reads of saved searches never populate it.
+}
+
+// SavedSearchWebhookDelivery represents an attempt to post a notification to
+// the webhook of a saved search.
+type SavedSearchWebhookDelivery struct {
+	ID            int64
+	SavedSearchID int32
+	URL           string        // the webhook URL that the notification was posted to
+	Attempt       int32         // the 1-based number of the delivery attempt
+	StatusCode    int32         // the HTTP status code of the response, or 0 if no response was received
+	Error         string        // describes why the delivery failed, if it did
+	Duration      time.Duration // the amount of time the delivery attempt took
+	CreatedAt     time.Time
 }
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// SearchContext is a named set of repositories and revisions that searches
+// can be scoped to with the "context:" search query field. It either lists
+// repository revisions or has a query of repo: filters selecting them.
+type SearchContext struct {
+	ID          int32
+	Name        string
+	Description string
+	UserID      int32  // the owner user, or zero
+	OrgID       int32  // the owner organization, or zero; both are zero for instance-wide contexts
+	Public      bool   // whether the context is visible to all users
+	Query       string // repo: and -repo: filters, or empty if the context lists repository revisions
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// SearchContextRepositoryRevision is a revision of a repository in a search
+// context.
+type SearchContextRepositoryRevision struct {
+	RepoID   api.RepoID
+	RepoName api.RepoName
+	Revision string // the revision, or empty for the default branch
+}
@@ -0,0 +1,42 @@
+package types
+
+import "time"
+
+// SearchExportFormat is the file format of a search export.
+type SearchExportFormat string
+
+// SearchExportFormat constants.
+const (
+	SearchExportFormatJSONLines SearchExportFormat = "JSON_LINES"
+	SearchExportFormatCSV       SearchExportFormat = "CSV"
+)
+
+// SearchExportJobState is the state of a search export job.
+type SearchExportJobState string
+
+// SearchExportJobState constants.
+const (
+	SearchExportJobStateQueued     SearchExportJobState = "QUEUED"
+	SearchExportJobStateProcessing SearchExportJobState = "PROCESSING"
+	SearchExportJobStateCompleted  SearchExportJobState = "COMPLETED"
+	SearchExportJobStateErrored    SearchExportJobState = "ERRORED"
+)
+
+// SearchExportJob is a search query whose results are exhaustively written to
+// a file in the background.
+type SearchExportJob struct {
+	ID             int32
+	UserID         int32  // the owner, whose permissions the search is run with
+	Query          string // the search query, including its patternType: filter
+	Format         SearchExportFormat
+	State          SearchExportJobState
+	Cursor         string // the pagination cursor of the next page of results, empty before the first page
+	ResultCount    int32  // the number of results written so far
+	RepoCount      int32  // the number of repositories searched so far
+	FileSize       int64  // the size of the file written so far
+	FailureMessage string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// SearchTrendInterval is the time between two points of a search trend series.
+type SearchTrendInterval string
+
+// SearchTrendInterval constants.
+const (
+	SearchTrendIntervalDay   SearchTrendInterval = "DAY"
+	SearchTrendIntervalWeek  SearchTrendInterval = "WEEK"
+	SearchTrendIntervalMonth SearchTrendInterval = "MONTH"
+)
+
+// SearchTrendSeries is a search query whose match count is recorded at regular
+// intervals of the commit history of the repositories it matches.
+type SearchTrendSeries struct {
+	ID        int32
+	UserID    int32               // the owner, whose permissions the searches are run with
+	Query     string              // the search query, including its patternType: filter
+	Start     time.Time           // the time of the first point
+	Interval  SearchTrendInterval // the time between two points
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SearchTrendPoint is the match count of a search trend series in a repository
+// at a point in time.
+type SearchTrendPoint struct {
+	SeriesID   int32
+	RepoID     api.RepoID
+	Time       time.Time
+	Commit     api.CommitID // the last commit before Time, or empty if the repository had none
+	Count      int32        // the number of matches of the query at Commit
+	RecordedAt time.Time
+}
@@ -33,6 +33,12 @@ const (
 	// repoTTLGC is how often we should reclone a repository once it is
 	// reporting git gc issues.
 	repoTTLGC = time.Hour * 24 * 2
+	// transferredRepoTTL is how long we keep a repository after it was
+	// transferred to its new owner.
+	transferredRepoTTL = 10 * time.Minute
+	// transferredConfigKey is the git config key holding the unix time at which
+	// a repository was transferred to its new owner.
+	transferredConfigKey = "sourcegraph.transferred"
 )
 
 var (
@@ -44,6 +50,10 @@ var (
 		Name: "src_gitserver_repos_recloned",
 		Help: "number of repos removed and recloned due to age",
 	})
+	reposRemovedTransferred = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repos_removed_transferred",
+		Help: "number of repos removed after being transferred to another gitserver",
+	})
 	reposRemovedDiskPressure = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "src_gitserver_repos_removed_disk_pressure",
 		Help: "number of repos removed due to not enough disk space",
@@ -55,7 +65,8 @@ var (
 // 1. Remove corrupt repos.
 // 2. Remove stale lock files.
 // 3. Remove inactive repos on sourcegraph.com
-// 4. Reclone repos after a while. (simulate git gc)
+// 4. Remove repos transferred to another gitserver.
+// 5. Reclone repos after a while. (simulate git gc)
 func (s *Server) cleanupRepos() {
 	bCtx, bCancel := s.serverContext()
 	defer bCancel()
@@ -76,6 +87,27 @@ func (s *Server) cleanupRepos() {
 		return true, nil
 	}
 
+	maybeRemoveTransferred := func(dir GitDir) (done bool, err error) {
+		transferred, err := gitConfigGet(dir, transferredConfigKey)
+		if err != nil || transferred == "" {
+			return false, err
+		}
+		sec, err := strconv.ParseInt(strings.TrimSpace(transferred), 10, 64)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid %s", transferredConfigKey)
+		}
+		if time.Since(time.Unix(sec, 0)) < transferredRepoTTL {
+			return false, nil
+		}
+
+		log15.Info("removing transferred repo", "repo", dir)
+		if err := s.removeRepoDirectory(dir); err != nil {
+			return true, err
+		}
+		reposRemovedTransferred.Inc()
+		return true, nil
+	}
+
 	ensureGitAttributes := func(dir GitDir) (done bool, err error) {
 		return false, setGitAttributes(dir)
 	}
@@ -176,6 +208,9 @@ func (s *Server) cleanupRepos() {
 		// If git is interrupted it can leave lock files lying around. It does
 		// not clean these up, and instead fails commands.
 		{"remove stale locks", removeStaleLocks},
+		// Repos are transferred to their new owner when gitservers are
+		// rebalanced. Remove our copy once the move is complete.
+		{"maybe remove transferred", maybeRemoveTransferred},
 		// We always want to have the same git attributes file at
 		// info/attributes.
 		{"ensure git attributes", ensureGitAttributes},
@@ -10,6 +10,7 @@ import (
 	"path"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"testing/quick"
@@ -164,6 +165,45 @@ func TestCleanupExpired(t *testing.T) {
 	}
 }
 
+func TestCleanupTransferred(t *testing.T) {
+	root := tmpDir(t)
+
+	repoKeep := path.Join(root, "repo-keep", ".git")
+	repoRecent := path.Join(root, "repo-recent", ".git")
+	repoTransferred := path.Join(root, "repo-transferred", ".git")
+	for _, path := range []string{repoKeep, repoRecent, repoTransferred} {
+		cmd := exec.Command("git", "--bare", "init", path)
+		if err := cmd.Run(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for path, delta := range map[string]time.Duration{
+		repoRecent:      transferredRepoTTL / 2,
+		repoTransferred: 2 * transferredRepoTTL,
+	} {
+		ts := time.Now().Add(-delta).Unix()
+		if err := gitConfigSet(GitDir(path), transferredConfigKey, strconv.FormatInt(ts, 10)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Server{ReposDir: root}
+	s.Handler() // Handler as a side-effect sets up Server
+	s.cleanupRepos()
+
+	for path, wantExists := range map[string]bool{
+		repoKeep:        true,
+		repoRecent:      true,
+		repoTransferred: false,
+	} {
+		_, err := os.Stat(path)
+		if exists := err == nil; exists != wantExists {
+			t.Errorf("%s: got exists=%v, want %v", path, exists, wantExists)
+		}
+	}
+}
+
 func TestCleanupOldLocks(t *testing.T) {
 	root := tmpDir(t)
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -129,6 +131,27 @@ func (s *Server) handleRepoDelete(w http.ResponseWriter, r *http.Request) {
 	log15.Info("deleted repository", "repo", req.Repo)
 }
 
+func (s *Server) handleRepoTransferred(w http.ResponseWriter, r *http.Request) {
+	var req protocol.RepoTransferredRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dir := s.dir(protocol.NormalizeRepo(req.Repo))
+	if !repoCloned(dir) {
+		return
+	}
+	// We only mark the repo. The janitor removes it once it is no longer in
+	// use by requests routed to this gitserver before the rebalancing.
+	if err := gitConfigSet(dir, transferredConfigKey, strconv.FormatInt(time.Now().Unix(), 10)); err != nil {
+		log15.Error("failed to mark transferred repository", "repo", req.Repo, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	log15.Info("marked transferred repository for removal", "repo", req.Repo)
+}
+
 func (s *Server) deleteRepo(repo api.RepoName) error {
 	return s.removeRepoDirectory(s.dir(repo))
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
@@ -116,6 +117,12 @@ type Server struct {
 	// DiskSizer tells how much disk is free and how large the disk is.
 	DiskSizer DiskSizer
 
+	// PreviousOwners returns the addresses of the gitservers which held repo
+	// before the set of gitservers was changed, and which no longer hold it.
+	// A repo missing on this gitserver is transferred from its previous
+	// owners rather than cloned from its code host. May be nil.
+	PreviousOwners func(ctx context.Context, repo api.RepoName) []string
+
 	// skipCloneForTests is set by tests to avoid clones.
 	skipCloneForTests bool
 
@@ -233,6 +240,7 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/repo-clone-progress", s.handleRepoCloneProgress)
 	mux.HandleFunc("/delete", s.handleRepoDelete)
 	mux.HandleFunc("/repo-update", s.handleRepoUpdate)
+	mux.HandleFunc("/repo-transferred", s.handleRepoTransferred)
 	mux.HandleFunc("/getGitolitePhabricatorMetadata", s.handleGetGitolitePhabricatorMetadata)
 	mux.HandleFunc("/create-commit-from-patch", s.handleCreateCommitFromPatch)
 	mux.HandleFunc("/ping", func(w http.ResponseWriter, _ *http.Request) {
@@ -418,6 +426,12 @@ func (s *Server) handleRepoUpdate(w http.ResponseWriter, r *http.Request) {
 		resp.Cloned = true
 		var statusErr, updateErr error
 
+		// The repo is assigned to this gitserver (again), so it must not be
+		// removed if it was previously transferred to another gitserver.
+		if err := gitConfigUnset(dir, transferredConfigKey); err != nil {
+			log15.Warn("failed to unmark transferred repo", "repo", req.Repo, "error", err)
+		}
+
 		if debounce(req.Repo, req.Since) {
 			updateErr = s.doRepoUpdate(ctx, req.Repo, req.URL)
 		}
@@ -761,18 +775,31 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 		return progress, nil
 	}
 
+	// If the repo is being rebalanced to this gitserver, we transfer it from
+	// its previous owners instead of cloning it from the code host.
+	var previousOwners []string
+	if s.PreviousOwners != nil {
+		previousOwners = s.PreviousOwners(ctx, repo)
+	}
+
 	// isCloneable causes a network request, so we limit the number that can
 	// run at one time. We use a separate semaphore to cloning since these
 	// checks being blocked by a few slow clones will lead to poor feedback to
 	// users. We can defer since the rest of the function does not block this
 	// goroutine.
-	ctx, cancel, err := s.acquireCloneableLimiter(ctx)
-	if err != nil {
-		return "", err // err will be a context error
-	}
-	defer cancel()
-	if err := s.isCloneable(ctx, url); err != nil {
-		return "", fmt.Errorf("error cloning repo: repo %s not cloneable: %s", repo, redactor.redact(err.Error()))
+	//
+	// We skip the check when transferring from a previous owner to avoid
+	// load on the code host. If the transfer fails, cloning from the code
+	// host reports the same error.
+	if len(previousOwners) == 0 {
+		ctx, cancel, err := s.acquireCloneableLimiter(ctx)
+		if err != nil {
+			return "", err // err will be a context error
+		}
+		defer cancel()
+		if err := s.isCloneable(ctx, url); err != nil {
+			return "", fmt.Errorf("error cloning repo: repo %s not cloneable: %s", repo, redactor.redact(err.Error()))
+		}
 	}
 
 	// Mark this repo as currently being cloned. We have to check again if someone else isn't already
@@ -826,25 +853,28 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 		tmpPath = filepath.Join(tmpPath, ".git")
 		tmp := GitDir(tmpPath)
 
-		var cmd *exec.Cmd
-		if useRefspecOverrides() {
-			cmd, err = refspecOverridesCloneCmd(ctx, url, tmpPath)
-			if err != nil {
-				return err
+		transferredFrom := s.transferRepo(ctx, repo, url, previousOwners, lock, tmpPath)
+		if transferredFrom == "" {
+			var cmd *exec.Cmd
+			if useRefspecOverrides() {
+				cmd, err = refspecOverridesCloneCmd(ctx, url, tmpPath)
+				if err != nil {
+					return err
+				}
+			} else {
+				cmd = exec.CommandContext(ctx, "git", "clone", "--mirror", "--progress", url, tmpPath)
 			}
-		} else {
-			cmd = exec.CommandContext(ctx, "git", "clone", "--mirror", "--progress", url, tmpPath)
-		}
-		// see issue #7322: skip LFS content in repositories with Git LFS configured
-		cmd.Env = append(os.Environ(), "GIT_LFS_SKIP_SMUDGE=1")
-		log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath)
+			// see issue #7322: skip LFS content in repositories with Git LFS configured
+			cmd.Env = append(os.Environ(), "GIT_LFS_SKIP_SMUDGE=1")
+			log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath)
 
-		pr, pw := io.Pipe()
-		defer pw.Close()
-		go readCloneProgress(redactor, lock, pr)
+			pr, pw := io.Pipe()
+			defer pw.Close()
+			go readCloneProgress(redactor, lock, pr, "")
 
-		if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
-			return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+			if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
+				return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+			}
 		}
 
 		removeBadRefs(ctx, tmp)
@@ -877,6 +907,14 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 		log15.Info("repo cloned", "repo", repo)
 		repoClonedCounter.Inc()
 
+		if transferredFrom != "" {
+			repoTransferredCounter.Inc()
+			// The move is complete, so the previous owner can remove its copy.
+			if err := gitserver.DefaultClient.RepoTransferred(ctx, transferredFrom, repo); err != nil {
+				log15.Warn("failed to notify previous owner of transferred repo", "repo", repo, "addr", transferredFrom, "error", err)
+			}
+		}
+
 		return nil
 	}
 
@@ -900,9 +938,45 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 	return "", nil
 }
 
-// readCloneProgress scans the reader and saves the most recent line of output
-// as the lock status.
-func readCloneProgress(redactor *urlRedactor, lock *RepositoryLock, pr io.Reader) {
+// transferRepo clones repo into tmpPath from the first of previousOwners which
+// has it, and points its origin at remoteURL. It returns the address of the
+// gitserver repo was transferred from, or "" if it could not be transferred.
+func (s *Server) transferRepo(ctx context.Context, repo api.RepoName, remoteURL string, previousOwners []string, lock *RepositoryLock, tmpPath string) string {
+	for _, addr := range previousOwners {
+		if ctx.Err() != nil {
+			return ""
+		}
+		log15.Info("transferring repo", "repo", repo, "from", addr, "tmp", tmpPath)
+
+		// We fetch from the smart Git HTTP endpoint of the previous owner.
+		peerURL := "http://" + addr + "/git/" + string(protocol.NormalizeRepo(repo))
+		cmd := exec.CommandContext(ctx, "git", "clone", "--mirror", "--progress", peerURL, tmpPath)
+		pr, pw := io.Pipe()
+		go readCloneProgress(newURLRedactor(remoteURL), lock, pr, "transferring from "+addr+": ")
+		output, err := runWithRemoteOpts(ctx, cmd, pw)
+		pw.Close()
+		if err == nil {
+			cmd = exec.CommandContext(ctx, "git", "remote", "set-url", "origin", remoteURL)
+			cmd.Dir = tmpPath
+			output, err = cmd.CombinedOutput()
+		}
+		if err == nil {
+			return addr
+		}
+
+		log15.Warn("failed to transfer repo from previous owner", "repo", repo, "from", addr, "error", err, "output", string(output))
+		repoTransferFailedCounter.Inc()
+		if err := os.RemoveAll(tmpPath); err != nil {
+			log15.Warn("failed to remove failed transfer", "repo", repo, "tmp", tmpPath, "error", err)
+			return ""
+		}
+	}
+	return ""
+}
+
+// readCloneProgress scans the reader and saves the most recent line of output,
+// prefixed with prefix, as the lock status.
+func readCloneProgress(redactor *urlRedactor, lock *RepositoryLock, pr io.Reader, prefix string) {
 	scan := bufio.NewScanner(pr)
 	scan.Split(scanCRLF)
 	for scan.Scan() {
@@ -917,7 +991,7 @@ func readCloneProgress(redactor *urlRedactor, lock *RepositoryLock, pr io.Reader
 		// fatal: repository 'http://token@github.com/foo/bar/' not found
 		redactedProgress := redactor.redact(progress)
 
-		lock.SetStatus(redactedProgress)
+		lock.SetStatus(prefix + redactedProgress)
 	}
 	if err := scan.Err(); err != nil {
 		log15.Error("error reporting progress", "error", err)
@@ -1044,6 +1118,14 @@ var (
 		Name: "src_gitserver_repo_cloned",
 		Help: "number of successful git clones run",
 	})
+	repoTransferredCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repo_transferred",
+		Help: "number of repos transferred from their previous owner gitserver",
+	})
+	repoTransferFailedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repo_transfer_failed",
+		Help: "number of failed attempts to transfer a repo from its previous owner gitserver",
+	})
 )
 
 func init() {
@@ -1052,6 +1134,8 @@ func init() {
 	prometheus.MustRegister(cloneQueue)
 	prometheus.MustRegister(lsRemoteQueue)
 	prometheus.MustRegister(repoClonedCounter)
+	prometheus.MustRegister(repoTransferredCounter)
+	prometheus.MustRegister(repoTransferFailedCounter)
 }
 
 var headBranchPattern = lazyregexp.New(`HEAD branch: (.+?)\n`)
@@ -105,9 +105,11 @@ func TestRequest(t *testing.T) {
 	s := &Server{ReposDir: "/testroot", skipCloneForTests: true}
 	h := s.Handler()
 
+	origRepoCloned := repoCloned
 	repoCloned = func(dir GitDir) bool {
 		return dir == s.dir("github.com/gorilla/mux") || dir == s.dir("my-mux")
 	}
+	defer func() { repoCloned = origRepoCloned }()
 
 	testRepoExists = func(ctx context.Context, url string) error {
 		if url == "https://github.com/nicksnyder/go-i18n.git" {
@@ -432,6 +434,55 @@ func TestCloneRepo(t *testing.T) {
 	}
 }
 
+func TestCloneRepo_Transfer(t *testing.T) {
+	remote := tmpDir(t)
+	runCmd(t, remote, "git", "init", ".")
+	runCmd(t, remote, "sh", "-c", "echo hello world > hello.txt")
+	runCmd(t, remote, "git", "add", "hello.txt")
+	runCmd(t, remote, "git", "commit", "-m", "hello")
+	wantCommit := runCmd(t, remote, "git", "rev-parse", "HEAD")
+
+	// The previous owner has the repo cloned.
+	repo := api.RepoName("example.com/foo/bar")
+	old := &Server{ReposDir: tmpDir(t)}
+	oldSrv := httptest.NewServer(old.Handler())
+	defer oldSrv.Close()
+	if _, err := old.cloneRepo(context.Background(), repo, remote, &cloneOptions{Block: true}); err != nil {
+		t.Fatal(err)
+	}
+	oldAddr := strings.TrimPrefix(oldSrv.URL, "http://")
+
+	// The new owner transfers the repo from the previous owner. The code host
+	// URL is unreachable, so cloning from the code host would fail.
+	codeHostURL := "https://code-host.invalid/foo/bar"
+	s := &Server{
+		ReposDir: tmpDir(t),
+		PreviousOwners: func(ctx context.Context, name api.RepoName) []string {
+			if name != repo {
+				t.Errorf("got repo %q, want %q", name, repo)
+			}
+			return []string{"127.0.0.1:1", oldAddr}
+		},
+	}
+	s.Handler()
+	if _, err := s.cloneRepo(context.Background(), repo, codeHostURL, &cloneOptions{Block: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Dir(string(s.dir(repo)))
+	if gotCommit := runCmd(t, dst, "git", "rev-parse", "HEAD"); gotCommit != wantCommit {
+		t.Errorf("got commit %q, want %q", gotCommit, wantCommit)
+	}
+	if got, err := repoRemoteURL(context.Background(), s.dir(repo)); err != nil || got != codeHostURL {
+		t.Errorf("got remote URL %q (%v), want %q", got, err, codeHostURL)
+	}
+
+	// The previous owner keeps its copy until the janitor removes it.
+	if transferred, err := gitConfigGet(old.dir(repo), transferredConfigKey); err != nil || transferred == "" {
+		t.Errorf("expected previous owner to mark repo as transferred, got %q (%v)", transferred, err)
+	}
+}
+
 func TestRemoveBadRefs(t *testing.T) {
 	dir := tmpDir(t)
 	gitDir := GitDir(filepath.Join(dir, ".git"))
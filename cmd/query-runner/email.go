@@ -43,6 +43,13 @@ func (n *notifier) emailNotify(ctx context.Context) {
 				ownership = "your organization's"
 			}
 
+			if n.diff != nil {
+				if err := sendEmail(ctx, recipient.spec.userID, "results", resultsDiffEmailTemplates, newResultsDiffEmailData(n, ownership)); err != nil {
+					log15.Error("Failed to send email notification for changed saved search results.", "userID", recipient.spec.userID, "error", err)
+				}
+				continue
+			}
+
 			plural := ""
 			if n.results.Data.Search.Results.ApproximateResultCount != "1" {
 				plural = "s"
@@ -86,6 +93,67 @@ View the new result{{.PluralResults}} on Sourcegraph: {{.URL}}
 `,
 })
 
+// maxEmailDiffEntries is the maximum number of added or removed matches listed
+// in an email notification.
+const maxEmailDiffEntries = 50
+
+type resultsDiffEmailData struct {
+	URL            string
+	Description    string
+	Query          string
+	Ownership      string
+	Added          []string
+	AddedOmitted   int
+	Removed        []string
+	RemovedOmitted int
+}
+
+func newResultsDiffEmailData(n *notifier, ownership string) resultsDiffEmailData {
+	data := resultsDiffEmailData{
+		URL:         searchURL(n.newQuery, utmSourceEmail),
+		Description: n.query.Description,
+		Query:       n.query.Query,
+		Ownership:   ownership,
+	}
+	data.Added, data.AddedOmitted = locations(n.diff.Added, maxEmailDiffEntries)
+	data.Removed, data.RemovedOmitted = locations(n.diff.Removed, maxEmailDiffEntries)
+	return data
+}
+
+var resultsDiffEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `[{{len .Added}}{{with .AddedOmitted}}+{{.}}{{end}} new, {{len .Removed}}{{with .RemovedOmitted}}+{{.}}{{end}} removed] {{.Description}}`,
+	Text: `
+The results of {{.Ownership}} saved search changed:
+
+  "{{.Description}}"
+{{if .Added}}
+New matches:
+{{range .Added}}
+  + {{.}}{{end}}{{with .AddedOmitted}}
+  ...and {{.}} more{{end}}
+{{end}}{{if .Removed}}
+Removed matches:
+{{range .Removed}}
+  - {{.}}{{end}}{{with .RemovedOmitted}}
+  ...and {{.}} more{{end}}
+{{end}}
+View the results on Sourcegraph: {{.URL}}
+`,
+	HTML: `
+<p>The results of {{.Ownership}} saved search changed:</p>
+
+<p style="padding-left: 16px">&quot;{{.Description}}&quot;</p>
+{{if .Added}}
+<p><strong>New matches:</strong></p>
+<ul>{{range .Added}}<li><code>{{.}}</code></li>{{end}}{{with .AddedOmitted}}<li>...and {{.}} more</li>{{end}}</ul>
+{{end}}{{if .Removed}}
+<p><strong>Removed matches:</strong></p>
+<ul>{{range .Removed}}<li><code>{{.}}</code></li>{{end}}{{with .RemovedOmitted}}<li>...and {{.}} more</li>{{end}}</ul>
+{{end}}
+<p><a href="{{.URL}}">View the results on Sourcegraph</a></p>
+`,
+})
+
 func emailNotifySubscribeUnsubscribe(ctx context.Context, recipient *recipient, query api.SavedQuerySpecAndConfig, template txtypes.Templates) error {
 	if !recipient.email {
 		return nil
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// resultEntry is a single matched line of a code search result, or a whole
+// file if the file matched without line matches (e.g. a path match).
+//
+// Entries are identified by their repository, path and a hash of the content
+// of the line, so that a line which merely moved (e.g. because lines were
+// inserted above it) is not considered to have changed.
+type resultEntry struct {
+	Repo string `json:"r"`
+	Path string `json:"p"`
+	Line int32  `json:"l"` // 0-based, or -1 for file entries
+	Hash string `json:"h"` // truncated hash of the line content, or "" for file entries
+}
+
+func (e resultEntry) key() string {
+	return e.Repo + "\x00" + e.Path + "\x00" + e.Hash
+}
+
+// String returns a human-readable location of the entry, such as
+// "github.com/foo/bar/main.go:12".
+func (e resultEntry) String() string {
+	if e.Line < 0 {
+		return e.Repo + "/" + e.Path
+	}
+	return fmt.Sprintf("%s/%s:%d", e.Repo, e.Path, e.Line+1)
+}
+
+// resultDiff is the difference between two consecutive result sets of a saved
+// search.
+type resultDiff struct {
+	Added   []resultEntry
+	Removed []resultEntry
+}
+
+func (d *resultDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// locations returns the human-readable locations of at most max entries, and
+// the number of entries omitted.
+func locations(entries []resultEntry, max int) (locs []string, omitted int) {
+	for i, e := range entries {
+		if i == max {
+			return locs, len(entries) - max
+		}
+		locs = append(locs, e.String())
+	}
+	return locs, 0
+}
+
+// resultEntries returns the entries of the code search results of a query-runner
+// search response. Results other than file matches are ignored.
+func resultEntries(results []interface{}) []resultEntry {
+	var entries []resultEntry
+	for _, result := range results {
+		m, ok := result.(map[string]interface{})
+		if !ok || m["__typename"] != "FileMatch" {
+			continue
+		}
+		resource, _ := m["resource"].(string)
+		repo, path, ok := parseResource(resource)
+		if !ok {
+			continue
+		}
+
+		lineMatches, _ := m["lineMatches"].([]interface{})
+		if len(lineMatches) == 0 {
+			entries = append(entries, resultEntry{Repo: repo, Path: path, Line: -1})
+			continue
+		}
+		for _, lm := range lineMatches {
+			lm, ok := lm.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			preview, _ := lm["preview"].(string)
+			line, _ := lm["lineNumber"].(float64)
+			entries = append(entries, resultEntry{
+				Repo: repo,
+				Path: path,
+				Line: int32(line),
+				Hash: lineHash(preview),
+			})
+		}
+	}
+	sortResultEntries(entries)
+	return entries
+}
+
+// parseResource parses the repository and path of a file match resource such
+// as "git://github.com/foo/bar?rev#dir/file". The revision is ignored.
+func parseResource(resource string) (repo, path string, ok bool) {
+	u, err := url.Parse(resource)
+	if err != nil || u.Fragment == "" {
+		return "", "", false
+	}
+	return u.Host + u.Path, u.Fragment, true
+}
+
+func lineHash(line string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(line)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func sortResultEntries(entries []resultEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Line < b.Line
+	})
+}
+
+// diffResultEntries returns the entries of new which are not in old, and the
+// entries of old which are not in new. Identical lines in the same file are
+// matched up by their number of occurrences.
+func diffResultEntries(old, new []resultEntry) *resultDiff {
+	count := make(map[string]int, len(old))
+	for _, e := range old {
+		count[e.key()]++
+	}
+
+	diff := &resultDiff{}
+	for _, e := range new {
+		if count[e.key()] > 0 {
+			count[e.key()]--
+			continue
+		}
+		diff.Added = append(diff.Added, e)
+	}
+
+	// Whatever is left over in count was removed. Walk old backwards so that
+	// the last occurrences of duplicated lines are the ones reported.
+	for i := len(old) - 1; i >= 0; i-- {
+		e := old[i]
+		if count[e.key()] > 0 {
+			count[e.key()]--
+			diff.Removed = append(diff.Removed, e)
+		}
+	}
+	sortResultEntries(diff.Removed)
+	return diff
+}
+
+// withIncompleteRepos returns the entries of new, except that the entries of
+// the given repositories are replaced by their entries in old. It is used for
+// repositories whose results are incomplete (e.g. because the search timed out
+// or the repository is still cloning), so that their matches are neither
+// reported as removed nor as added once they are searched again.
+func withIncompleteRepos(old, new []resultEntry, incomplete map[string]bool) []resultEntry {
+	if len(incomplete) == 0 {
+		return new
+	}
+	entries := make([]resultEntry, 0, len(new))
+	for _, e := range new {
+		if !incomplete[e.Repo] {
+			entries = append(entries, e)
+		}
+	}
+	for _, e := range old {
+		if incomplete[e.Repo] {
+			entries = append(entries, e)
+		}
+	}
+	sortResultEntries(entries)
+	return entries
+}
+
+// encodeFingerprint encodes entries into the compact fingerprint which is
+// stored for a saved search between runs.
+func encodeFingerprint(entries []resultEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(entries); err != nil {
+		return nil, errors.Wrap(err, "Encode")
+	}
+	if err := zw.Close(); err != nil {
+		return nil, errors.Wrap(err, "Close")
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeFingerprint decodes a fingerprint created by encodeFingerprint.
+func decodeFingerprint(fingerprint []byte) ([]resultEntry, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(fingerprint))
+	if err != nil {
+		return nil, errors.Wrap(err, "gzip.NewReader")
+	}
+	data, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, errors.Wrap(err, "ReadAll")
+	}
+	var entries []resultEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Wrap(err, "Unmarshal")
+	}
+	return entries, nil
+}
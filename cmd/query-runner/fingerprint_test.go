@@ -0,0 +1,116 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func fileMatch(resource string, lines ...interface{}) map[string]interface{} {
+	var lineMatches []interface{}
+	for i := 0; i < len(lines); i += 2 {
+		lineMatches = append(lineMatches, map[string]interface{}{
+			"lineNumber": float64(lines[i].(int)),
+			"preview":    lines[i+1].(string),
+		})
+	}
+	return map[string]interface{}{
+		"__typename":  "FileMatch",
+		"resource":    resource,
+		"lineMatches": lineMatches,
+	}
+}
+
+func TestResultEntries(t *testing.T) {
+	entries := resultEntries([]interface{}{
+		fileMatch("git://github.com/foo/bar?master#b.go", 3, "deprecated()"),
+		fileMatch("git://github.com/foo/bar#a.go"),
+		map[string]interface{}{"__typename": "Repository"},
+	})
+	want := []resultEntry{
+		{Repo: "github.com/foo/bar", Path: "a.go", Line: -1},
+		{Repo: "github.com/foo/bar", Path: "b.go", Line: 3, Hash: lineHash("deprecated()")},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("got %+v, want %+v", entries, want)
+	}
+	if got, want := entries[1].String(), "github.com/foo/bar/b.go:4"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDiffResultEntries(t *testing.T) {
+	old := resultEntries([]interface{}{
+		fileMatch("git://r#a.go", 1, "deprecated()", 5, "deprecated()"),
+		fileMatch("git://r#b.go", 2, "deprecated() // fixed soon"),
+	})
+	new := resultEntries([]interface{}{
+		// Lines were inserted above the first match and the second match was
+		// removed.
+		fileMatch("git://r#a.go", 11, "deprecated()"),
+		fileMatch("git://r#c.go", 7, "  deprecated()"),
+	})
+
+	diff := diffResultEntries(old, new)
+	var added, removed []string
+	for _, e := range diff.Added {
+		added = append(added, e.String())
+	}
+	for _, e := range diff.Removed {
+		removed = append(removed, e.String())
+	}
+	if want := []string{"r/c.go:8"}; !reflect.DeepEqual(added, want) {
+		t.Errorf("got added %q, want %q", added, want)
+	}
+	if want := []string{"r/a.go:6", "r/b.go:3"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("got removed %q, want %q", removed, want)
+	}
+
+	if diff := diffResultEntries(new, new); !diff.empty() {
+		t.Errorf("expected empty diff, got %+v", diff)
+	}
+}
+
+func TestFingerprintRoundTrip(t *testing.T) {
+	entries := resultEntries([]interface{}{
+		fileMatch("git://r#a.go", 1, "deprecated()"),
+		fileMatch("git://r#b.go"),
+	})
+	fingerprint, err := encodeFingerprint(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeFingerprint(fingerprint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, entries) {
+		t.Errorf("got %+v, want %+v", got, entries)
+	}
+}
+
+func TestWithIncompleteRepos(t *testing.T) {
+	old := resultEntries([]interface{}{
+		fileMatch("git://r1#a.go", 1, "deprecated()"),
+		fileMatch("git://r2#b.go", 2, "deprecated()"),
+	})
+	new := resultEntries([]interface{}{
+		// r2 timed out, so its match is missing.
+		fileMatch("git://r1#c.go", 3, "deprecated()"),
+	})
+
+	entries := withIncompleteRepos(old, new, map[string]bool{"r2": true})
+	diff := diffResultEntries(old, entries)
+	var added, removed []string
+	for _, e := range diff.Added {
+		added = append(added, e.String())
+	}
+	for _, e := range diff.Removed {
+		removed = append(removed, e.String())
+	}
+	if want := []string{"r1/c.go:4"}; !reflect.DeepEqual(added, want) {
+		t.Errorf("got added %q, want %q", added, want)
+	}
+	if want := []string{"r1/a.go:2"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("got removed %q, want %q", removed, want)
+	}
+}
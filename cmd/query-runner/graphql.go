@@ -31,6 +31,7 @@ const gqlSearchQuery = `query Search(
 			limitHit
 			cloning { name }
 			timedout { name }
+			missing { name }
 			results {
 				__typename
 				... on FileMatch {
@@ -113,8 +114,10 @@ type gqlSearchResponse struct {
 		Search struct {
 			Results struct {
 				ApproximateResultCount string
+				LimitHit               bool
 				Cloning                []*api.Repo
 				Timedout               []*api.Repo
+				Missing                []*api.Repo
 				Results                []interface{}
 			}
 		}
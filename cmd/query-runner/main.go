@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"regexp"
 	"strings"
 	"syscall"
 	"time"
@@ -138,16 +139,10 @@ func (e *executorT) run(ctx context.Context) error {
 // runQuery runs the given query if an appropriate amount of time has elapsed
 // since it last ran.
 func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery) error {
-	if !query.Notify && !query.NotifySlack {
+	if !query.Notify && !query.NotifySlack && !query.NotifyWebhook {
 		// No need to run this query because there will be nobody to notify.
 		return nil
 	}
-	if !strings.Contains(query.Query, "type:diff") && !strings.Contains(query.Query, "type:commit") {
-		// TODO(slimsag): we temporarily do not support non-commit search
-		// queries, since those do not support the after:"time" operator.
-		return nil
-	}
-
 	info, err := api.InternalClient.SavedQueriesGetInfo(ctx, query.Query)
 	if err != nil {
 		return errors.Wrap(err, "SavedQueriesGetInfo")
@@ -175,6 +170,12 @@ func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, que
 		}
 	}
 
+	if !isCommitQuery(query.Query) {
+		// Code searches do not support the after:"time" operator, so we
+		// compare their result sets instead.
+		return e.runCodeQuery(ctx, spec, query, info)
+	}
+
 	// Construct a new query which finds search results introduced after the
 	// last time we queried.
 	var latestKnownResult time.Time
@@ -215,13 +216,146 @@ func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, que
 	// that we don't block other search queries from running in sequence (which
 	// is done intentionally, to ensure no overloading of searcher/gitserver).
 	go func() {
-		if err := notify(context.Background(), spec, query, newQuery, v); err != nil {
+		if err := notify(context.Background(), spec, query, newQuery, v, nil); err != nil {
+			log15.Error("executor: failed to send notifications", "error", err)
+		}
+	}()
+	return nil
+}
+
+// isCommitQuery reports whether query is a commit or diff search. Only those
+// support the after:"time" operator.
+func isCommitQuery(query string) bool {
+	return strings.Contains(query, "type:diff") || strings.Contains(query, "type:commit")
+}
+
+// codeQueryResultCount is the result limit of code searches run by the
+// query-runner, unless the saved search sets its own with count:. It is larger
+// than the default limit, so that the result sets of most saved searches are
+// complete and can be compared between runs.
+const codeQueryResultCount = 5000
+
+var countFieldRegexp = regexp.MustCompile(`(^|\s)count:`)
+
+// withResultCount returns query with the result limit codeQueryResultCount, if
+// it doesn't set its own.
+func withResultCount(query string) string {
+	if countFieldRegexp.MatchString(query) {
+		return query
+	}
+	return fmt.Sprintf("%s count:%d", query, codeQueryResultCount)
+}
+
+// runCodeQuery runs a saved search over code. The result set of the query is
+// compared with the result set of the previous run, whose fingerprint is
+// stored in the saved query info, and notifications are sent for the matches
+// which appeared or disappeared. No notifications are sent on the first run.
+func (e *executorT) runCodeQuery(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, info *api.SavedQueryInfo) error {
+	v, execDuration, searchErr := performSearch(ctx, withResultCount(query.Query))
+
+	var (
+		fingerprint []byte
+		diff        *resultDiff
+	)
+	if info != nil {
+		// Keep the previous fingerprint if the search fails.
+		fingerprint = info.ResultFingerprint
+	}
+	if searchErr == nil {
+		if v.Data.Search.Results.LimitHit {
+			log15.Warn("executor: not comparing results of saved search because it hit the result limit", "query_description", query.Description)
+		}
+
+		var err error
+		fingerprint, diff, err = nextFingerprint(fingerprint, v, query.Description)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := api.InternalClient.SavedQueriesSetInfo(ctx, &api.SavedQueryInfo{
+		Query:             query.Query,
+		LastExecuted:      time.Now(),
+		LatestResult:      time.Now(),
+		ExecDuration:      execDuration,
+		ResultFingerprint: fingerprint,
+	}); err != nil {
+		return errors.Wrap(err, "SavedQueriesSetInfo")
+	}
+
+	if searchErr != nil {
+		return searchErr
+	}
+	if diff == nil || diff.empty() {
+		return nil
+	}
+
+	go func() {
+		if err := notify(context.Background(), spec, query, query.Query, v, diff); err != nil {
 			log15.Error("executor: failed to send notifications", "error", err)
 		}
 	}()
 	return nil
 }
 
+// nextFingerprint returns the fingerprint of the result set of the search
+// response v, and its diff to the previous result set with the given
+// fingerprint, if any. The diff is nil if there is no previous result set to
+// compare with.
+func nextFingerprint(fingerprint []byte, v *gqlSearchResponse, description string) ([]byte, *resultDiff, error) {
+	if v.Data.Search.Results.LimitHit {
+		// Each run of a search that hits the result limit may return a
+		// different subset of the matches, so whether a match is new or was
+		// removed can't be determined. Keep the previous fingerprint until a
+		// run returns all matches.
+		return fingerprint, nil, nil
+	}
+
+	var diff *resultDiff
+	entries := resultEntries(v.Data.Search.Results.Results)
+	if len(fingerprint) > 0 {
+		old, err := decodeFingerprint(fingerprint)
+		if err != nil {
+			log15.Warn("executor: ignoring invalid result fingerprint", "error", err, "query_description", description)
+		} else {
+			// Keep the previous matches of repositories which were not
+			// completely searched, so that they aren't diffed.
+			entries = withIncompleteRepos(old, entries, incompleteRepos(v))
+			diff = diffResultEntries(old, entries)
+		}
+	}
+
+	newFingerprint, err := encodeFingerprint(entries)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "encodeFingerprint")
+	}
+	return newFingerprint, diff, nil
+}
+
+// incompleteRepos returns the names of the repositories whose results are
+// missing or incomplete in a search response.
+func incompleteRepos(v *gqlSearchResponse) map[string]bool {
+	incomplete := map[string]bool{}
+	results := v.Data.Search.Results
+	for _, repos := range [][]*api.Repo{results.Cloning, results.Timedout, results.Missing} {
+		for _, repo := range repos {
+			incomplete[string(repo.Name)] = true
+		}
+	}
+	for _, result := range results.Results {
+		// A file match whose line matches were truncated.
+		m, ok := result.(map[string]interface{})
+		if !ok || m["__typename"] != "FileMatch" || m["limitHit"] != true {
+			continue
+		}
+		resource, _ := m["resource"].(string)
+		if repo, _, ok := parseResource(resource); ok {
+			incomplete[repo] = true
+		}
+	}
+	return incomplete
+}
+
 func performSearch(ctx context.Context, query string) (v *gqlSearchResponse, execDuration time.Duration, err error) {
 	attempts := 0
 	for {
@@ -275,12 +409,21 @@ func latestResultTime(prevInfo *api.SavedQueryInfo, v *gqlSearchResponse, search
 
 var externalURL *url.URL
 
-// notify handles sending notifications for new search results.
-func notify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, newQuery string, results *gqlSearchResponse) error {
-	if len(results.Data.Search.Results.Results) == 0 {
-		return nil
+// notify handles sending notifications for new search results. If diff is
+// non-nil, the notifications describe the matches added and removed since the
+// previous run instead of the new results.
+func notify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, newQuery string, results *gqlSearchResponse, diff *resultDiff) error {
+	if diff != nil {
+		if diff.empty() {
+			return nil
+		}
+		log15.Info("sending notifications", "added", len(diff.Added), "removed", len(diff.Removed), "description", query.Description)
+	} else {
+		if len(results.Data.Search.Results.Results) == 0 {
+			return nil
+		}
+		log15.Info("sending notifications", "new_results", len(results.Data.Search.Results.Results), "description", query.Description)
 	}
-	log15.Info("sending notifications", "new_results", len(results.Data.Search.Results.Results), "description", query.Description)
 
 	// Determine which users to notify.
 	recipients, err := getNotificationRecipients(ctx, spec, query)
@@ -294,12 +437,14 @@ func notify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSave
 		query:      query,
 		newQuery:   newQuery,
 		results:    results,
+		diff:       diff,
 		recipients: recipients,
 	}
 
-	// Send Slack and email notifications.
+	// Send Slack, email and webhook notifications.
 	n.slackNotify(ctx)
 	n.emailNotify(ctx)
+	n.webhookNotify(ctx)
 	return nil
 }
 
@@ -308,6 +453,7 @@ type notifier struct {
 	query      api.ConfigSavedQuery
 	newQuery   string
 	results    *gqlSearchResponse
+	diff       *resultDiff // the matches added and removed since the previous run, for code searches
 	recipients recipients
 }
 
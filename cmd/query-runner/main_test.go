@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestWithResultCount(t *testing.T) {
+	tests := map[string]string{
+		"deprecated":              "deprecated count:5000",
+		"deprecated count:10":     "deprecated count:10",
+		"count:10 deprecated":     "count:10 deprecated",
+		"file:count.go account:x": "file:count.go account:x count:5000",
+	}
+	for query, want := range tests {
+		if got := withResultCount(query); got != want {
+			t.Errorf("withResultCount(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestNextFingerprint(t *testing.T) {
+	response := func(limitHit bool, results ...interface{}) *gqlSearchResponse {
+		var v gqlSearchResponse
+		v.Data.Search.Results.LimitHit = limitHit
+		v.Data.Search.Results.Results = results
+		return &v
+	}
+	var (
+		a = fileMatch("git://github.com/foo/bar#a.go", 1, "a()")
+		b = fileMatch("git://github.com/foo/bar#b.go", 2, "b()")
+		c = fileMatch("git://github.com/foo/bar#c.go", 3, "c()")
+	)
+
+	first, diff, err := nextFingerprint(nil, response(false, a, b, c), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil {
+		t.Errorf("got diff %+v on the first run, want nil", diff)
+	}
+
+	// Two consecutive runs which hit the result limit and return different
+	// subsets of the matches must not report any additions or removals, and
+	// must keep the fingerprint of the last complete run.
+	fingerprint := first
+	for _, v := range []*gqlSearchResponse{response(true, a, b), response(true, c, a)} {
+		fingerprint, diff, err = nextFingerprint(fingerprint, v, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff != nil {
+			t.Errorf("got diff %+v for a truncated result set, want nil", diff)
+		}
+		if !bytes.Equal(fingerprint, first) {
+			t.Error("fingerprint changed for a truncated result set")
+		}
+	}
+
+	d := fileMatch("git://github.com/foo/bar#d.go", 4, "d()")
+	_, diff, err = nextFingerprint(fingerprint, response(false, b, c, d), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &resultDiff{
+		Added:   resultEntries([]interface{}{d}),
+		Removed: resultEntries([]interface{}{a}),
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("got diff %+v, want %+v", diff, want)
+	}
+}
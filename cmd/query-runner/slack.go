@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/inconshreveable/log15"
 
@@ -10,7 +11,16 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/slack"
 )
 
+// maxSlackDiffEntries is the maximum number of added or removed matches listed
+// in a Slack notification.
+const maxSlackDiffEntries = 10
+
 func (n *notifier) slackNotify(ctx context.Context) {
+	if n.diff != nil {
+		n.slackNotifyDiff(ctx)
+		return
+	}
+
 	plural := ""
 	if n.results.Data.Search.Results.ApproximateResultCount != "1" {
 		plural = "s"
@@ -31,6 +41,44 @@ func (n *notifier) slackNotify(ctx context.Context) {
 	logEvent(0, "SavedSearchSlackNotificationSent", "results")
 }
 
+func (n *notifier) slackNotifyDiff(ctx context.Context) {
+	var b strings.Builder
+	fmt.Fprintf(&b, `*%d* new and *%d* removed match%s for saved search <%s|"%s">`,
+		len(n.diff.Added),
+		len(n.diff.Removed),
+		pluralMatches(len(n.diff.Added)+len(n.diff.Removed)),
+		searchURL(n.newQuery, utmSourceSlack),
+		n.query.Description,
+	)
+	writeEntries := func(prefix string, entries []resultEntry) {
+		locs, omitted := locations(entries, maxSlackDiffEntries)
+		for _, loc := range locs {
+			fmt.Fprintf(&b, "\n%s `%s`", prefix, loc)
+		}
+		if omitted > 0 {
+			fmt.Fprintf(&b, "\n%s _…and %d more_", prefix, omitted)
+		}
+	}
+	writeEntries("+", n.diff.Added)
+	writeEntries("-", n.diff.Removed)
+
+	text := b.String()
+	for _, recipient := range n.recipients {
+		if err := slackNotify(ctx, recipient, text, n.query.SlackWebhookURL); err != nil {
+			log15.Error("Failed to post Slack notification message.", "recipient", recipient, "text", text, "error", err)
+		}
+	}
+	// TODO(Dan): find all users in the recipient list and log events for all of them
+	logEvent(0, "SavedSearchSlackNotificationSent", "results")
+}
+
+func pluralMatches(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "es"
+}
+
 func slackNotifySubscribed(ctx context.Context, recipient *recipient, query api.SavedQuerySpecAndConfig) error {
 	text := fmt.Sprintf(`Slack notifications enabled for the saved search <%s|"%s">. Notifications will be sent here when new results are available.`,
 		searchURL(query.Config.Query, utmSourceSlack),
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+const utmSourceWebhook = "saved-search-webhook"
+
+// webhookSignatureHeader is the header of webhook notifications which holds the
+// hex-encoded HMAC-SHA256 of the request body, keyed with the webhook secret of
+// the saved search.
+const webhookSignatureHeader = "X-Sourcegraph-Signature"
+
+var (
+	// webhookMaxAttempts is the number of times a webhook notification is
+	// posted before giving up.
+	webhookMaxAttempts = 3
+
+	// webhookRetryDelay is the delay before the first retry of a webhook
+	// notification. It doubles with each retry.
+	webhookRetryDelay = 5 * time.Second
+
+	// webhookTimeout is the timeout of a single webhook delivery attempt.
+	webhookTimeout = 30 * time.Second
+
+	// webhookAllowAddress reports whether a webhook notification may be posted
+	// to the given private, loopback, or link-local address (host:port). It is
+	// only overridden by tests.
+	webhookAllowAddress = func(address string) bool { return false }
+)
+
+// webhookClient is the HTTP client used to post webhook notifications. It
+// refuses to connect to private, loopback, and link-local addresses so that
+// webhooks cannot be used to reach internal services. The check is applied to
+// the resolved address of every connection, so it also applies to redirects.
+var webhookClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   webhookTimeout,
+			KeepAlive: 30 * time.Second,
+			Control:   webhookDialControl,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+			return fmt.Errorf("refusing to follow redirect to %s URL", req.URL.Scheme)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	},
+}
+
+// errWebhookAddressBlocked is returned when a webhook notification would be
+// posted to a private, loopback, or link-local address.
+var errWebhookAddressBlocked = errors.New("webhook URL resolves to a private, loopback, or link-local address")
+
+// webhookDialControl rejects connections to private, loopback, and link-local
+// addresses.
+func webhookDialControl(network, address string, c syscall.RawConn) error {
+	if webhookAllowAddress(address) {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || isPrivateAddress(ip) {
+		return errWebhookAddressBlocked
+	}
+	return nil
+}
+
+var privateNetworks = mustParseCIDRs(
+	"0.0.0.0/8",      // "this" network
+	"10.0.0.0/8",     // private
+	"100.64.0.0/10",  // carrier-grade NAT
+	"172.16.0.0/12",  // private
+	"192.168.0.0/16", // private
+	"fc00::/7",       // unique local
+)
+
+// isPrivateAddress reports whether ip is a private, loopback, link-local, or
+// unspecified address.
+func isPrivateAddress(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// webhookPayload is the JSON document posted to the webhook of a saved search.
+type webhookPayload struct {
+	// Event is "results" for new search results or "test" for test notifications.
+	Event       string             `json:"event"`
+	SavedSearch webhookSavedSearch `json:"savedSearch"`
+
+	// ResultCount is the (approximate) number of new results.
+	ResultCount string `json:"resultCount"`
+
+	// Added and Removed identify the results which appeared and disappeared
+	// since the last run of the saved search.
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+
+	// URL links to the search results.
+	URL string `json:"url"`
+}
+
+type webhookSavedSearch struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Query       string `json:"query"`
+}
+
+func (n *notifier) webhookNotify(ctx context.Context) {
+	if !n.query.NotifyWebhook {
+		return
+	}
+
+	added := make([]string, 0, len(n.results.Data.Search.Results.Results))
+	removed := []string{}
+	if n.diff != nil {
+		for _, e := range n.diff.Added {
+			added = append(added, e.String())
+		}
+		for _, e := range n.diff.Removed {
+			removed = append(removed, e.String())
+		}
+	} else {
+		for _, result := range n.results.Data.Search.Results.Results {
+			if id, ok := resultIdentifier(result); ok {
+				added = append(added, id)
+			}
+		}
+	}
+
+	payload := &webhookPayload{
+		Event:       "results",
+		SavedSearch: newWebhookSavedSearch(n.spec, n.query),
+		ResultCount: n.results.Data.Search.Results.ApproximateResultCount,
+		Added:       added,
+		Removed:     removed,
+		URL:         searchURL(n.newQuery, utmSourceWebhook),
+	}
+
+	// Post webhook notifications asynchronously, as retries of failed deliveries
+	// would otherwise delay the notifications of other saved searches.
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		if err := webhookNotify(ctx, n.spec, n.query, payload); err != nil {
+			log15.Error("Failed to post webhook notification.", "savedSearch", n.spec.Key, "error", err)
+			return
+		}
+		// TODO(Dan): find all users in the recipient list and log events for all of them
+		logEvent(0, "SavedSearchWebhookNotificationSent", "results")
+	}()
+}
+
+func webhookNotifyTest(ctx context.Context, query api.SavedQuerySpecAndConfig) error {
+	if !query.Config.NotifyWebhook {
+		return nil
+	}
+	payload := &webhookPayload{
+		Event:       "test",
+		SavedSearch: newWebhookSavedSearch(query.Spec, query.Config),
+		ResultCount: "0",
+		Added:       []string{},
+		Removed:     []string{},
+		URL:         searchURL(query.Config.Query, utmSourceWebhook),
+	}
+	return webhookNotify(ctx, query.Spec, query.Config, payload)
+}
+
+func newWebhookSavedSearch(spec api.SavedQueryIDSpec, query api.ConfigSavedQuery) webhookSavedSearch {
+	return webhookSavedSearch{
+		ID:          spec.Key,
+		Description: query.Description,
+		Query:       query.Query,
+	}
+}
+
+// webhookNotify posts payload to the webhook of the saved search, retrying
+// failed deliveries. Each delivery attempt is logged so that it is visible to
+// the owner of the saved search.
+func webhookNotify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, payload *webhookPayload) error {
+	if query.WebhookURL == nil || *query.WebhookURL == "" {
+		return fmt.Errorf("unable to send webhook notification because saved search %s has no webhook URL configured", spec.Key)
+	}
+	webhookURL := *query.WebhookURL
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "Marshal")
+	}
+	var secret string
+	if query.WebhookSecret != nil {
+		secret = *query.WebhookSecret
+	}
+
+	savedSearchID, err := strconv.Atoi(spec.Key)
+	if err != nil {
+		return errors.Wrapf(err, "invalid saved search key %q", spec.Key)
+	}
+
+	delay := webhookRetryDelay
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		statusCode, err := postWebhook(ctx, webhookURL, secret, body)
+
+		delivery := &api.SavedQueryWebhookDelivery{
+			SavedSearchID: int32(savedSearchID),
+			URL:           webhookURL,
+			Attempt:       attempt,
+			StatusCode:    statusCode,
+			Duration:      time.Since(start),
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if logErr := api.InternalClient.SavedQueriesLogWebhookDelivery(ctx, delivery); logErr != nil {
+			log15.Warn("Failed to log webhook delivery.", "savedSearch", spec.Key, "error", logErr)
+		}
+
+		if err == nil {
+			return nil
+		}
+		if attempt >= webhookMaxAttempts || !retryableWebhookStatus(statusCode) || errors.Is(err, errWebhookAddressBlocked) {
+			return errors.Wrapf(err, "delivery attempt %d", attempt)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// postWebhook posts body to webhookURL, signed with secret if it is non-empty.
+// It returns the status code of the response, or 0 if no response was
+// received.
+func postWebhook(ctx context.Context, webhookURL, secret string, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest("POST", webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Sourcegraph-Saved-Search-Webhook")
+	if secret != "" {
+		req.Header.Set(webhookSignatureHeader, "sha256="+webhookSignature(secret, body))
+	}
+
+	resp, err := ctxhttp.Do(ctx, webhookClient, req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// webhookSignature returns the hex-encoded HMAC-SHA256 of body keyed with
+// secret.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryableWebhookStatus reports whether a delivery which failed with the
+// given status code should be retried. A status code of 0 means that no
+// response was received.
+func retryableWebhookStatus(statusCode int) bool {
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// resultIdentifier returns a stable identifier for a search result of the
+// search query run by the query-runner.
+func resultIdentifier(result interface{}) (string, bool) {
+	m, ok := result.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	switch m["__typename"] {
+	case "FileMatch":
+		resource, ok := m["resource"].(string)
+		return resource, ok
+	case "CommitSearchResult":
+		commit, ok := m["commit"].(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		oid, _ := commit["oid"].(string)
+		repo, _ := commit["repository"].(map[string]interface{})
+		name, _ := repo["name"].(string)
+		if oid == "" || name == "" {
+			return "", false
+		}
+		return name + "@" + oid, true
+	}
+	return "", false
+}
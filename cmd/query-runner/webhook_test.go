@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestWebhookNotify(t *testing.T) {
+	retryDelay := webhookRetryDelay
+	webhookRetryDelay = 0
+	defer func() { webhookRetryDelay = retryDelay }()
+
+	// The test servers listen on a loopback address.
+	allowAddress := webhookAllowAddress
+	webhookAllowAddress = func(address string) bool { return true }
+	defer func() { webhookAllowAddress = allowAddress }()
+
+	var deliveries []*api.SavedQueryWebhookDelivery
+	api.MockSavedQueriesLogWebhookDelivery = func(delivery *api.SavedQueryWebhookDelivery) error {
+		deliveries = append(deliveries, delivery)
+		return nil
+	}
+	defer func() { api.MockSavedQueriesLogWebhookDelivery = nil }()
+
+	payload := &webhookPayload{
+		Event:       "results",
+		SavedSearch: webhookSavedSearch{ID: "7", Description: "d", Query: "q"},
+		ResultCount: "1",
+		Added:       []string{"repo@abc"},
+		Removed:     []string{},
+	}
+	secret := "s3cr3t"
+
+	t.Run("retries failed deliveries", func(t *testing.T) {
+		deliveries = nil
+		requests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := r.Header.Get(webhookSignatureHeader), "sha256="+webhookSignature(secret, body); got != want {
+				t.Errorf("got signature %q, want %q", got, want)
+			}
+			var got webhookPayload
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(&got, payload) {
+				t.Errorf("got payload %+v, want %+v", got, payload)
+			}
+			if requests == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer ts.Close()
+
+		query := api.ConfigSavedQuery{NotifyWebhook: true, WebhookURL: &ts.URL, WebhookSecret: &secret}
+		if err := webhookNotify(context.Background(), api.SavedQueryIDSpec{Key: "7"}, query, payload); err != nil {
+			t.Fatal(err)
+		}
+		if requests != 2 {
+			t.Errorf("got %d requests, want 2", requests)
+		}
+		if len(deliveries) != 2 {
+			t.Fatalf("got %d logged deliveries, want 2", len(deliveries))
+		}
+		if d := deliveries[0]; d.SavedSearchID != 7 || d.Attempt != 1 || d.StatusCode != http.StatusServiceUnavailable || d.Error == "" {
+			t.Errorf("unexpected first delivery %+v", d)
+		}
+		if d := deliveries[1]; d.Attempt != 2 || d.StatusCode != http.StatusOK || d.Error != "" {
+			t.Errorf("unexpected second delivery %+v", d)
+		}
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		deliveries = nil
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(webhookSignatureHeader) != "" {
+				t.Errorf("expected unsigned request without a webhook secret")
+			}
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer ts.Close()
+
+		query := api.ConfigSavedQuery{NotifyWebhook: true, WebhookURL: &ts.URL}
+		if err := webhookNotify(context.Background(), api.SavedQueryIDSpec{Key: "7"}, query, payload); err == nil {
+			t.Fatal("expected error")
+		}
+		if len(deliveries) != 1 {
+			t.Errorf("got %d logged deliveries, want 1", len(deliveries))
+		}
+	})
+
+	t.Run("does not post to private addresses", func(t *testing.T) {
+		webhookAllowAddress = allowAddress
+		defer func() { webhookAllowAddress = func(address string) bool { return true } }()
+
+		deliveries = nil
+		requests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+		}))
+		defer ts.Close()
+
+		query := api.ConfigSavedQuery{NotifyWebhook: true, WebhookURL: &ts.URL}
+		if err := webhookNotify(context.Background(), api.SavedQueryIDSpec{Key: "7"}, query, payload); !errors.Is(err, errWebhookAddressBlocked) {
+			t.Fatalf("got error %v, want %v", err, errWebhookAddressBlocked)
+		}
+		if requests != 0 {
+			t.Errorf("got %d requests, want 0", requests)
+		}
+		if len(deliveries) != 1 {
+			t.Errorf("got %d logged deliveries, want 1", len(deliveries))
+		}
+	})
+
+	t.Run("does not follow redirects to private addresses", func(t *testing.T) {
+		deliveries = nil
+		requests := 0
+		internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+		}))
+		defer internal.Close()
+
+		redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, internal.URL, http.StatusTemporaryRedirect)
+		}))
+		defer redirect.Close()
+
+		// Only the redirecting server is allowed; the check applies to the
+		// redirect target as well.
+		webhookAllowAddress = func(address string) bool { return address == redirect.Listener.Addr().String() }
+		defer func() { webhookAllowAddress = func(address string) bool { return true } }()
+
+		query := api.ConfigSavedQuery{NotifyWebhook: true, WebhookURL: &redirect.URL}
+		if err := webhookNotify(context.Background(), api.SavedQueryIDSpec{Key: "7"}, query, payload); !errors.Is(err, errWebhookAddressBlocked) {
+			t.Fatalf("got error %v, want %v", err, errWebhookAddressBlocked)
+		}
+		if requests != 0 {
+			t.Errorf("got %d requests, want 0", requests)
+		}
+	})
+}
+
+func TestIsPrivateAddress(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1":       true,
+		"::1":             true,
+		"10.1.2.3":        true,
+		"172.20.0.1":      true,
+		"192.168.1.1":     true,
+		"169.254.169.254": true,
+		"fe80::1":         true,
+		"fd00::1":         true,
+		"0.0.0.0":         true,
+		"8.8.8.8":         false,
+		"2001:4860::8888": false,
+	}
+	for address, want := range tests {
+		if got := isPrivateAddress(net.ParseIP(address)); got != want {
+			t.Errorf("%s: got %v, want %v", address, got, want)
+		}
+	}
+}
+
+func TestResultIdentifier(t *testing.T) {
+	tests := []struct {
+		result interface{}
+		want   string
+	}{
+		{
+			result: map[string]interface{}{"__typename": "FileMatch", "resource": "git://repo?rev#file"},
+			want:   "git://repo?rev#file",
+		},
+		{
+			result: map[string]interface{}{
+				"__typename": "CommitSearchResult",
+				"commit": map[string]interface{}{
+					"oid":        "abc",
+					"repository": map[string]interface{}{"name": "repo"},
+				},
+			},
+			want: "repo@abc",
+		},
+	}
+	for _, test := range tests {
+		got, ok := resultIdentifier(test.result)
+		if !ok || got != test.want {
+			t.Errorf("got %q (%v), want %q", got, ok, test.want)
+		}
+	}
+
+	if _, ok := resultIdentifier(map[string]interface{}{"__typename": "Repository"}); ok {
+		t.Error("expected no identifier for repository results")
+	}
+}
@@ -44,6 +44,13 @@ type RewriteSpecification struct {
 	// A template pattern that expresses how matches should be rewritten.
 	RewriteTemplate string
 
+	// A rule that places constraints on matching or rewriting (e.g., `where :[x] != "foo"`).
+	Rule string
+
+	// A representative file extension (e.g., ".go") which selects the language
+	// parser used to match. When empty, it is inferred from the file extension.
+	Matcher string
+
 	// A file extension suffix filtering which files to process (e.g., ".go")
 	FileExtension string
 
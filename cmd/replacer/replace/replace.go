@@ -71,6 +71,14 @@ func (t *ExternalTool) command(ctx context.Context, spec *protocol.RewriteSpecif
 			args = append(args, "-exclude-dir", spec.DirectoryExclude)
 		}
 
+		if spec.Rule != "" {
+			args = append(args, "-rule", spec.Rule)
+		}
+
+		if spec.Matcher != "" {
+			args = append(args, "-matcher", spec.Matcher)
+		}
+
 		log15.Info(fmt.Sprintf("running command: comby %q", strings.Join(args[:], " ")))
 		return exec.CommandContext(ctx, t.BinaryPath, args...), nil
 
@@ -48,6 +48,12 @@ func main() {
 		}, `
 {"uri":"main.go","diff":"--- main.go\n+++ main.go\n@@ -2,6 +2,6 @@\n \n import \"fmt\"\n \n-func main() {\n+derp main() {\n \tfmt.Println(\"Hello foo\")\n }"}
 `},
+		{protocol.RewriteSpecification{
+			MatchTemplate:   "fmt.Println(:[x])",
+			RewriteTemplate: "fmt.Print(:[x])",
+			Rule:            `where :[x] != "\"Hello foo\""`,
+			Matcher:         ".go",
+		}, ""},
 	}
 
 	store, cleanup, err := testutil.NewStore(files)
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -106,6 +107,148 @@ func (s GitLabSource) GetRepo(ctx context.Context, pathWithNamespace string) (*R
 	return s.makeRepo(proj), nil
 }
 
+var _ ChangesetSource = GitLabSource{}
+
+// CreateChangeset creates the given *Changeset as a merge request on GitLab.
+func (s GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	var exists bool
+
+	project := c.Repo.Metadata.(*gitlab.Project)
+	source := git.AbbreviateRef(c.HeadRef)
+	target := git.AbbreviateRef(c.BaseRef)
+
+	mr, err := s.client.CreateMergeRequest(ctx, project, gitlab.CreateMergeRequestOpts{
+		SourceBranch: source,
+		TargetBranch: target,
+		Title:        c.Title,
+		Description:  c.Body,
+	})
+	if err != nil {
+		if err != gitlab.ErrMergeRequestAlreadyExists {
+			return exists, errors.Wrap(err, "creating merge request")
+		}
+
+		mr, err = s.client.GetOpenMergeRequestByRefs(ctx, project, source, target)
+		if err != nil {
+			return exists, errors.Wrap(err, "retrieving existing merge request")
+		}
+		log15.Info("Existing MR extracted", "IID", mr.IID)
+		exists = true
+	}
+
+	if err := s.loadMergeRequestData(ctx, project, mr); err != nil {
+		return false, errors.Wrap(err, "loading extra metadata")
+	}
+	if err = c.SetMetadata(mr); err != nil {
+		return false, errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return exists, nil
+}
+
+// CloseChangeset closes the merge request on GitLab and updates the Metadata
+// of the *campaigns.Changeset to the newly closed merge request.
+func (s GitLabSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	project := c.Repo.Metadata.(*gitlab.Project)
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, gitlab.UpdateMergeRequestOpts{
+		StateEvent: gitlab.UpdateMergeRequestStateEventClose,
+	})
+	if err != nil {
+		return errors.Wrap(err, "closing merge request")
+	}
+
+	if err := s.loadMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrap(err, "loading extra metadata")
+	}
+	c.Changeset.Metadata = updated
+
+	return nil
+}
+
+// LoadChangesets loads the latest state of the given Changesets from GitLab.
+func (s GitLabSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
+	var notFound []*Changeset
+
+	for i := range cs {
+		project := cs[i].Repo.Metadata.(*gitlab.Project)
+		iid, err := strconv.Atoi(cs[i].ExternalID)
+		if err != nil {
+			return err
+		}
+
+		mr, err := s.client.GetMergeRequest(ctx, project, iid)
+		if err != nil {
+			if gitlab.IsNotFound(err) {
+				notFound = append(notFound, cs[i])
+				if cs[i].Changeset.Metadata == nil {
+					cs[i].Changeset.Metadata = &gitlab.MergeRequest{IID: iid, ProjectID: project.ID}
+				}
+				continue
+			}
+
+			return err
+		}
+
+		if err := s.loadMergeRequestData(ctx, project, mr); err != nil {
+			return errors.Wrap(err, "loading merge request data")
+		}
+		if err := cs[i].SetMetadata(mr); err != nil {
+			return errors.Wrap(err, "setting changeset metadata")
+		}
+	}
+
+	if len(notFound) > 0 {
+		return ChangesetsNotFoundError{Changesets: notFound}
+	}
+
+	return nil
+}
+
+// loadMergeRequestData loads the notes and pipelines of the merge request,
+// from which its events and check state are derived.
+func (s GitLabSource) loadMergeRequestData(ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest) error {
+	if err := s.client.LoadMergeRequestNotes(ctx, project, mr); err != nil {
+		return errors.Wrap(err, "loading mr notes")
+	}
+
+	if err := s.client.LoadMergeRequestPipelines(ctx, project, mr); err != nil {
+		return errors.Wrap(err, "loading mr pipelines")
+	}
+
+	return nil
+}
+
+// UpdateChangeset updates the title, body and base branch of the merge
+// request on GitLab.
+func (s GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	project := c.Repo.Metadata.(*gitlab.Project)
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, gitlab.UpdateMergeRequestOpts{
+		Title:        c.Title,
+		Description:  c.Body,
+		TargetBranch: git.AbbreviateRef(c.BaseRef),
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating merge request")
+	}
+
+	if err := s.loadMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrap(err, "loading extra metadata")
+	}
+	c.Changeset.Metadata = updated
+
+	return nil
+}
+
 // ExternalServices returns a singleton slice containing the external service.
 func (s GitLabSource) ExternalServices() ExternalServices {
 	return ExternalServices{s.svc}
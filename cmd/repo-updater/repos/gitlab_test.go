@@ -11,7 +11,9 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
@@ -213,3 +215,142 @@ func TestGitLabSource_makeRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestGitLabSource_ChangesetSource(t *testing.T) {
+	ctx := context.Background()
+	project := &gitlab.Project{ProjectCommon: gitlab.ProjectCommon{ID: 42}}
+
+	svc := &ExternalService{
+		Kind: extsvc.KindGitLab,
+		Config: marshalJSON(t, &schema.GitLabConnection{
+			Url:   "https://gitlab.com",
+			Token: "token",
+		}),
+	}
+	src, err := NewGitLabSource(svc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gitlab.MockLoadMergeRequestNotes = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest) error {
+		mr.Notes = []*gitlab.Note{{ID: 1, Body: "approved this merge request", System: true}}
+		return nil
+	}
+	gitlab.MockLoadMergeRequestPipelines = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest) error {
+		mr.Pipelines = []*gitlab.Pipeline{{ID: 2, Status: gitlab.PipelineStatusSuccess}}
+		return nil
+	}
+	defer func() {
+		gitlab.MockCreateMergeRequest = nil
+		gitlab.MockGetMergeRequest = nil
+		gitlab.MockGetOpenMergeRequestByRefs = nil
+		gitlab.MockUpdateMergeRequest = nil
+		gitlab.MockLoadMergeRequestNotes = nil
+		gitlab.MockLoadMergeRequestPipelines = nil
+	}()
+
+	newChangeset := func() *Changeset {
+		return &Changeset{
+			Title:     "title",
+			Body:      "body",
+			HeadRef:   "refs/heads/campaign",
+			BaseRef:   "refs/heads/master",
+			Repo:      &Repo{Metadata: project},
+			Changeset: &campaigns.Changeset{},
+		}
+	}
+
+	t.Run("CreateChangeset", func(t *testing.T) {
+		for _, alreadyExists := range []bool{false, true} {
+			gitlab.MockCreateMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, opts gitlab.CreateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+				want := gitlab.CreateMergeRequestOpts{SourceBranch: "campaign", TargetBranch: "master", Title: "title", Description: "body"}
+				if opts != want {
+					t.Errorf("got opts %+v, want %+v", opts, want)
+				}
+				if alreadyExists {
+					return nil, gitlab.ErrMergeRequestAlreadyExists
+				}
+				return &gitlab.MergeRequest{IID: 3, SourceBranch: opts.SourceBranch}, nil
+			}
+			gitlab.MockGetOpenMergeRequestByRefs = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, source, target string) (*gitlab.MergeRequest, error) {
+				return &gitlab.MergeRequest{IID: 4, SourceBranch: source}, nil
+			}
+
+			cs := newChangeset()
+			exists, err := src.CreateChangeset(ctx, cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exists != alreadyExists {
+				t.Errorf("got exists %v, want %v", exists, alreadyExists)
+			}
+
+			wantID := "3"
+			if alreadyExists {
+				wantID = "4"
+			}
+			if cs.ExternalID != wantID || cs.ExternalServiceType != extsvc.TypeGitLab || cs.ExternalBranch != "campaign" {
+				t.Errorf("unexpected changeset %+v", cs.Changeset)
+			}
+			mr := cs.Changeset.Metadata.(*gitlab.MergeRequest)
+			if len(mr.Notes) != 1 || len(mr.Pipelines) != 1 {
+				t.Errorf("merge request data not loaded: %+v", mr)
+			}
+		}
+	})
+
+	t.Run("LoadChangesets", func(t *testing.T) {
+		gitlab.MockGetMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, iid int) (*gitlab.MergeRequest, error) {
+			if iid == 404 {
+				return nil, errors.Wrap(gitlab.ErrNotFound, "not found")
+			}
+			return &gitlab.MergeRequest{IID: iid, State: gitlab.MergeRequestStateMerged}, nil
+		}
+
+		found, missing := newChangeset(), newChangeset()
+		found.ExternalID = "3"
+		missing.ExternalID = "404"
+
+		err := src.LoadChangesets(ctx, found, missing)
+		notFound, ok := err.(ChangesetsNotFoundError)
+		if !ok || len(notFound.Changesets) != 1 || notFound.Changesets[0] != missing {
+			t.Fatalf("got error %v, want ChangesetsNotFoundError for the missing changeset", err)
+		}
+		if mr := found.Changeset.Metadata.(*gitlab.MergeRequest); mr.State != gitlab.MergeRequestStateMerged || len(mr.Notes) != 1 {
+			t.Errorf("unexpected merge request %+v", mr)
+		}
+		if mr := missing.Changeset.Metadata.(*gitlab.MergeRequest); mr.IID != 404 {
+			t.Errorf("unexpected merge request %+v", mr)
+		}
+	})
+
+	t.Run("CloseChangeset and UpdateChangeset", func(t *testing.T) {
+		var updates []gitlab.UpdateMergeRequestOpts
+		gitlab.MockUpdateMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+			updates = append(updates, opts)
+			updated := *mr
+			updated.Title = "updated"
+			return &updated, nil
+		}
+
+		cs := newChangeset()
+		cs.Changeset.Metadata = &gitlab.MergeRequest{IID: 3}
+		if err := src.UpdateChangeset(ctx, cs); err != nil {
+			t.Fatal(err)
+		}
+		if err := src.CloseChangeset(ctx, cs); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []gitlab.UpdateMergeRequestOpts{
+			{Title: "title", Description: "body", TargetBranch: "master"},
+			{StateEvent: gitlab.UpdateMergeRequestStateEventClose},
+		}
+		if diff := cmp.Diff(updates, want); diff != "" {
+			t.Errorf("unexpected updates: %s", diff)
+		}
+		if mr := cs.Changeset.Metadata.(*gitlab.MergeRequest); mr.Title != "updated" || len(mr.Pipelines) != 1 {
+			t.Errorf("unexpected merge request %+v", mr)
+		}
+	})
+}
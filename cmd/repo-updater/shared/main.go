@@ -263,6 +263,11 @@ func watchSyncer(ctx context.Context, syncer *repos.Syncer, sched scheduler, gps
 
 // syncCloned will periodically list the cloned repositories on gitserver and
 // update the scheduler with the list.
+//
+// If repositories are replicated across gitservers, only repositories cloned on
+// all of their replicas are listed. Repositories missing on a gitserver (e.g.
+// after it came back with an empty disk) are therefore prioritized by the
+// scheduler, whose update requests re-replicate them.
 func syncCloned(ctx context.Context, sched scheduler, gitserverClient *gitserver.Client) {
 	for {
 		select {
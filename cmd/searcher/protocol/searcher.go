@@ -81,6 +81,11 @@ type PatternInfo struct {
 	// glob or Go regexp that represents multiple such patterns ANDed together.
 	IncludePatterns []string
 
+	// ExcludeContentPatterns is a list of regular expressions that may not
+	// match the returned files' content. If any of them matches a file, the
+	// file is not returned, even if Pattern matches its path.
+	ExcludeContentPatterns []string
+
 	// IncludeExcludePatternAreRegExps indicates that ExcludePattern, IncludePattern,
 	// and IncludePatterns are regular expressions (not globs).
 	PathPatternsAreRegExps bool
@@ -151,6 +156,9 @@ func (p *PatternInfo) String() string {
 	for _, inc := range p.IncludePatterns {
 		args = append(args, fmt.Sprintf("%s:%q", path, inc))
 	}
+	for _, exc := range p.ExcludeContentPatterns {
+		args = append(args, fmt.Sprintf("-content:%q", exc))
+	}
 
 	return fmt.Sprintf("PatternInfo{%s}", strings.Join(args, ","))
 }
@@ -61,6 +61,11 @@ type readerGrep struct {
 	// re. It is the output of the longestLiteral function. It is only set if
 	// the regex has an empty LiteralPrefix.
 	literalSubstring []byte
+
+	// excludeContent are the regexps that may not match the content of a
+	// file for it to be returned. Like re, they are lowercased if we ignore
+	// case.
+	excludeContent []*regexp.Regexp
 }
 
 // compile returns a readerGrep for matching p.
@@ -121,11 +126,30 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 		return nil, err
 	}
 
+	var excludeContent []*regexp.Regexp
+	for _, pattern := range p.ExcludeContentPatterns {
+		expr := "(?m:" + pattern + ")"
+		if !p.IsCaseSensitive {
+			re, err := syntax.Parse(expr, syntax.Perl)
+			if err != nil {
+				return nil, err
+			}
+			lowerRegexpASCII(re)
+			expr = re.String()
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+		excludeContent = append(excludeContent, re)
+	}
+
 	return &readerGrep{
 		re:               re,
 		ignoreCase:       !p.IsCaseSensitive,
 		matchPath:        matchPath,
 		literalSubstring: literalSubstring,
+		excludeContent:   excludeContent,
 	}, nil
 }
 
@@ -137,6 +161,7 @@ func (rg *readerGrep) Copy() *readerGrep {
 		ignoreCase:       rg.ignoreCase,
 		matchPath:        rg.matchPath,
 		literalSubstring: rg.literalSubstring,
+		excludeContent:   rg.excludeContent,
 	}
 }
 
@@ -152,6 +177,32 @@ func (rg *readerGrep) matchString(s string) bool {
 	return rg.re.MatchString(s)
 }
 
+// excluded returns whether any of rg's exclude content regexps matches the
+// content of f.
+// NOTE: This is not safe to use concurrently.
+func (rg *readerGrep) excluded(zf *store.ZipFile, f *store.SrcFile) bool {
+	if len(rg.excludeContent) == 0 {
+		return false
+	}
+
+	fileMatchBuf := zf.DataFor(f)
+	if rg.ignoreCase {
+		if rg.transformBuf == nil {
+			rg.transformBuf = make([]byte, zf.MaxLen)
+		}
+		fileBuf := fileMatchBuf
+		fileMatchBuf = rg.transformBuf[:len(fileBuf)]
+		bytesToLowerASCII(fileMatchBuf, fileBuf)
+	}
+
+	for _, re := range rg.excludeContent {
+		if re.Match(fileMatchBuf) {
+			return true
+		}
+	}
+	return false
+}
+
 // Find returns a LineMatch for each line that matches rg in reader.
 // LimitHit is true if some matches may not have been included in the result.
 // NOTE: This is not safe to use concurrently.
@@ -341,7 +392,7 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, fileMat
 		// Fast path for only matching file paths (or with a nil pattern, which matches all files,
 		// so is effectively matching only on file paths).
 		for _, f := range files {
-			if rg.matchPath.MatchPath(f.Name) && rg.matchString(f.Name) {
+			if rg.matchPath.MatchPath(f.Name) && rg.matchString(f.Name) && !rg.excluded(zf, &f) {
 				if len(matches) < fileMatchLimit {
 					matches = append(matches, protocol.FileMatch{Path: f.Name})
 				} else {
@@ -411,6 +462,9 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, fileMat
 						fm.Path = f.Name
 					}
 				}
+				if match && rg.excluded(zf, f) {
+					match = false
+				}
 				if match {
 					matchesmu.Lock()
 					if len(matches) < fileMatchLimit {
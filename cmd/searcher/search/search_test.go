@@ -180,6 +180,16 @@ main.go:7:}
 `},
 
 		{protocol.PatternInfo{Pattern: "^$", IsRegExp: true}, ``},
+
+		{protocol.PatternInfo{Pattern: "world", ExcludeContentPatterns: []string{"println"}}, `
+README.md:1:# Hello World
+README.md:3:Hello world example in go
+`},
+		{protocol.PatternInfo{Pattern: "world", ExcludeContentPatterns: []string{"println"}, IsCaseSensitive: true}, `
+README.md:3:Hello world example in go
+main.go:6:	fmt.Println("Hello world")
+`},
+		{protocol.PatternInfo{Pattern: "world", ExcludeContentPatterns: []string{"^import", "example"}}, ``},
 	}
 
 	store, cleanup, err := newStore(files)
@@ -354,6 +364,9 @@ func doSearch(u string, p *protocol.Request) ([]protocol.FileMatch, error) {
 		"IncludePatterns": p.IncludePatterns,
 		"ExcludePattern":  []string{p.ExcludePattern},
 	}
+	if len(p.ExcludeContentPatterns) > 0 {
+		form["ExcludeContentPatterns"] = p.ExcludeContentPatterns
+	}
 	if p.IsRegExp {
 		form.Set("IsRegExp", "true")
 	}
@@ -0,0 +1,256 @@
+package symbols
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/inconshreveable/log15"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+// maxChangedPaths is the maximum number of changed paths between two commits
+// for which a database is updated incrementally. The changed paths are passed
+// to git as arguments, and beyond that it is cheaper to re-parse the whole
+// repository.
+const maxChangedPaths = 500
+
+// maxRecentDBsPerRepo is the maximum number of recently used databases of a
+// repository that are candidates for incremental updates.
+const maxRecentDBsPerRepo = 5
+
+// maxRecentDBRepos is the maximum number of repositories whose recently used
+// databases are remembered.
+const maxRecentDBRepos = 10000
+
+// Changes are the paths that changed between two commits.
+type Changes struct {
+	Added    []string
+	Modified []string
+	Deleted  []string
+}
+
+// ParseGitDiffNameStatus parses the output of `git diff -z --name-status --no-renames`.
+func ParseGitDiffNameStatus(output []byte) (Changes, error) {
+	var changes Changes
+
+	fields := bytes.Split(bytes.TrimRight(output, "\x00"), []byte{0})
+	if len(fields) == 1 && len(fields[0]) == 0 {
+		return changes, nil
+	}
+	if len(fields)%2 != 0 {
+		return Changes{}, errors.Errorf("unexpected git diff output: %q", output)
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		status, path := string(fields[i]), string(fields[i+1])
+		switch status {
+		case "A":
+			changes.Added = append(changes.Added, path)
+		case "D":
+			changes.Deleted = append(changes.Deleted, path)
+		case "M", "T":
+			changes.Modified = append(changes.Modified, path)
+		default:
+			return Changes{}, errors.Errorf("unrecognized git diff status %q for path %q", status, path)
+		}
+	}
+
+	return changes, nil
+}
+
+// cachedDB is a database in the disk cache for a commit of a repository.
+type cachedDB struct {
+	commitID api.CommitID
+	path     string
+}
+
+// recentDBsOf returns the recently used databases of the given repository, most
+// recent first.
+func (s *Service) recentDBsOf(repo api.RepoName) []cachedDB {
+	s.recentDBsMu.Lock()
+	defer s.recentDBsMu.Unlock()
+	if s.recentDBs == nil {
+		return nil
+	}
+	dbs, ok := s.recentDBs.Get(repo)
+	if !ok {
+		return nil
+	}
+	return dbs.([]cachedDB)
+}
+
+// addRecentDB records the most recently used database of the given repository.
+func (s *Service) addRecentDB(repo api.RepoName, commitID api.CommitID, path string) {
+	s.recentDBsMu.Lock()
+	defer s.recentDBsMu.Unlock()
+	if s.recentDBs == nil {
+		s.recentDBs = lru.New(maxRecentDBRepos)
+	}
+
+	dbs := []cachedDB{{commitID: commitID, path: path}}
+	if v, ok := s.recentDBs.Get(repo); ok {
+		for _, db := range v.([]cachedDB) {
+			if db.commitID != commitID && len(dbs) < maxRecentDBsPerRepo {
+				dbs = append(dbs, db)
+			}
+		}
+	}
+	s.recentDBs.Add(repo, dbs)
+}
+
+// nearestDB returns the recently used database of the given repository whose
+// commit is the fewest commits away from commitID.
+func (s *Service) nearestDB(ctx context.Context, repo api.RepoName, commitID api.CommitID) (nearest cachedDB, ok bool) {
+	nearestDistance := -1
+	for _, db := range s.recentDBsOf(repo) {
+		if db.commitID == commitID {
+			continue
+		}
+		if _, err := os.Stat(db.path); err != nil {
+			// The database has been evicted from the cache.
+			continue
+		}
+
+		distance, err := s.CommitDistance(ctx, repo, db.commitID, commitID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return cachedDB{}, false
+			}
+			log15.Warn("Unable to determine commit distance.", "repo", repo, "commit", commitID, "baseCommit", db.commitID, "error", err)
+			continue
+		}
+		if nearestDistance == -1 || distance < nearestDistance {
+			nearest, nearestDistance = db, distance
+		}
+	}
+	return nearest, nearestDistance != -1
+}
+
+// writeSymbolsToNewDB writes the symbols of repo@commit to the blank database
+// file `dbFile`. If a database for another commit of the same repository is in
+// the cache, the one of the nearest commit is copied and only the paths that
+// changed between the two commits are re-parsed. Otherwise all symbols are
+// written from scratch.
+func (s *Service) writeSymbolsToNewDB(ctx context.Context, dbFile string, repoName api.RepoName, commitID api.CommitID) error {
+	if s.GitDiff != nil && s.FetchTarPaths != nil && s.CommitDistance != nil {
+		if base, ok := s.nearestDB(ctx, repoName, commitID); ok {
+			err := s.updateSymbolsFromDB(ctx, dbFile, repoName, base, commitID)
+			if err == nil {
+				incrementalUpdates.Inc()
+				return nil
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			incrementalUpdatesFailed.Inc()
+			log15.Warn("Unable to incrementally update symbols, parsing all files.", "repo", repoName, "commit", commitID, "baseCommit", base.commitID, "error", err)
+
+			// Start over from a blank database.
+			if err := os.Truncate(dbFile, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.writeAllSymbolsToNewDB(ctx, dbFile, repoName, commitID)
+}
+
+// updateSymbolsFromDB copies the database `base` to `dbFile` and updates the
+// copy to reflect the symbols of repo@commit by re-parsing the paths that
+// changed since the commit of `base`.
+func (s *Service) updateSymbolsFromDB(ctx context.Context, dbFile string, repoName api.RepoName, base cachedDB, commitID api.CommitID) error {
+	changes, err := s.GitDiff(ctx, repoName, base.commitID, commitID)
+	if err != nil {
+		return errors.Wrap(err, "GitDiff")
+	}
+	if n := len(changes.Added) + len(changes.Modified) + len(changes.Deleted); n > maxChangedPaths {
+		return errors.Errorf("too many changed paths (%d > %d)", n, maxChangedPaths)
+	}
+
+	if err := copyFile(dbFile, base.path); err != nil {
+		return errors.Wrap(err, "copying database")
+	}
+
+	db, err := sqlx.Open("sqlite3_with_pcre", dbFile)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, paths := range [][]string{changes.Added, changes.Modified, changes.Deleted} {
+		for _, path := range paths {
+			if _, err := tx.Exec(`DELETE FROM symbols WHERE path = ?`, path); err != nil {
+				return err
+			}
+		}
+	}
+
+	paths := append(append([]string{}, changes.Added...), changes.Modified...)
+	if len(paths) > 0 {
+		insertStatement, err := prepareInsertSymbol(tx)
+		if err != nil {
+			return err
+		}
+
+		err = s.parseUncached(ctx, repoName, commitID, paths, func(symbol protocol.Symbol) error {
+			symbolInDBValue := symbolToSymbolInDB(symbol)
+			_, err := insertStatement.Exec(&symbolInDBValue)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// copyFile overwrites the contents of dst with the contents of src.
+func copyFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if err1 := out.Close(); err == nil {
+		err = err1
+	}
+	return err
+}
+
+var (
+	incrementalUpdates = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "symbols_store_incremental_updates",
+		Help: "The total number of databases created by updating the database of another commit.",
+	})
+	incrementalUpdatesFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "symbols_store_incremental_updates_failed",
+		Help: "The total number of incremental database updates that failed and fell back to parsing all files.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(incrementalUpdates)
+	prometheus.MustRegister(incrementalUpdatesFailed)
+}
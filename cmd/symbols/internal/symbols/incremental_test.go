@@ -0,0 +1,241 @@
+package symbols
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/pkg/ctags"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	symbolsclient "github.com/sourcegraph/sourcegraph/internal/symbols"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+func TestParseGitDiffNameStatus(t *testing.T) {
+	tests := map[string]struct {
+		output  string
+		want    Changes
+		wantErr bool
+	}{
+		"empty": {
+			output: "",
+			want:   Changes{},
+		},
+		"all kinds": {
+			output: "A\x00a.go\x00M\x00b/c.go\x00D\x00d.go\x00T\x00e\x00",
+			want: Changes{
+				Added:    []string{"a.go"},
+				Modified: []string{"b/c.go", "e"},
+				Deleted:  []string{"d.go"},
+			},
+		},
+		"unknown status": {
+			output:  "R100\x00a.go\x00b.go\x00",
+			wantErr: true,
+		},
+	}
+	for label, test := range tests {
+		t.Run(label, func(t *testing.T) {
+			changes, err := ParseGitDiffNameStatus([]byte(test.output))
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(changes, test.want) {
+				t.Errorf("got %+v, want %+v", changes, test.want)
+			}
+		})
+	}
+}
+
+func TestServiceIncremental(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { os.RemoveAll(tmpDir) }()
+
+	commits := map[api.CommitID]map[string]string{
+		"a": {"a.js": "x", "b.js": "y", "c.js": "z"},
+		"b": {"a.js": "x", "b.js": "w", "d.js": "v"},
+	}
+	var fetchedPaths [][]string
+	service := Service{
+		FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
+			fetchedPaths = append(fetchedPaths, nil)
+			return createTar(commits[commit])
+		},
+		FetchTarPaths: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			fetchedPaths = append(fetchedPaths, paths)
+			files := map[string]string{}
+			for _, path := range paths {
+				files[path] = commits[commit][path]
+			}
+			return createTar(files)
+		},
+		GitDiff: func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (Changes, error) {
+			if commitA != "a" || commitB != "b" {
+				t.Fatalf("unexpected diff %s..%s", commitA, commitB)
+			}
+			return Changes{Added: []string{"d.js"}, Modified: []string{"b.js"}, Deleted: []string{"c.js"}}, nil
+		},
+		CommitDistance: func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (int, error) {
+			return 1, nil
+		},
+		NewParser: func() (ctags.Parser, error) {
+			return contentParser{}, nil
+		},
+		Path: tmpDir,
+	}
+
+	if err := service.Start(); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(service.Handler())
+	defer server.Close()
+	client := symbolsclient.Client{URL: server.URL}
+
+	searchCommit := func(commitID api.CommitID) []protocol.Symbol {
+		result, err := client.Search(context.Background(), search.SymbolsParameters{Repo: "r", CommitID: commitID, First: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(result.Symbols, func(i, j int) bool { return result.Symbols[i].Path < result.Symbols[j].Path })
+		return result.Symbols
+	}
+
+	if got, want := searchCommit("a"), []protocol.Symbol{{Name: "x", Path: "a.js"}, {Name: "y", Path: "b.js"}, {Name: "z", Path: "c.js"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got, want := searchCommit("b"), []protocol.Symbol{{Name: "x", Path: "a.js"}, {Name: "w", Path: "b.js"}, {Name: "v", Path: "d.js"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if want := [][]string{nil, {"d.js", "b.js"}}; !reflect.DeepEqual(fetchedPaths, want) {
+		t.Errorf("got fetched paths %q, want %q", fetchedPaths, want)
+	}
+}
+
+func TestServiceIncremental_nearestDB(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { os.RemoveAll(tmpDir) }()
+
+	// Commit c is nearer to a than to b, although b was used more recently.
+	commits := map[api.CommitID]map[string]string{
+		"a": {"a.js": "x"},
+		"b": {"a.js": "y", "b.js": "z"},
+		"c": {"a.js": "x", "c.js": "w"},
+	}
+	distances := map[[2]api.CommitID]int{
+		{"a", "b"}: 1,
+		{"a", "c"}: 1,
+		{"b", "c"}: 2,
+	}
+	var diffs [][2]api.CommitID
+	service := Service{
+		FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
+			return createTar(commits[commit])
+		},
+		FetchTarPaths: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			files := map[string]string{}
+			for _, path := range paths {
+				files[path] = commits[commit][path]
+			}
+			return createTar(files)
+		},
+		GitDiff: func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (Changes, error) {
+			diffs = append(diffs, [2]api.CommitID{commitA, commitB})
+			var changes Changes
+			for path := range commits[commitB] {
+				if _, ok := commits[commitA][path]; !ok {
+					changes.Added = append(changes.Added, path)
+				} else if commits[commitA][path] != commits[commitB][path] {
+					changes.Modified = append(changes.Modified, path)
+				}
+			}
+			for path := range commits[commitA] {
+				if _, ok := commits[commitB][path]; !ok {
+					changes.Deleted = append(changes.Deleted, path)
+				}
+			}
+			return changes, nil
+		},
+		CommitDistance: func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (int, error) {
+			if commitA > commitB {
+				commitA, commitB = commitB, commitA
+			}
+			return distances[[2]api.CommitID{commitA, commitB}], nil
+		},
+		NewParser: func() (ctags.Parser, error) {
+			return contentParser{}, nil
+		},
+		Path: tmpDir,
+	}
+
+	if err := service.Start(); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(service.Handler())
+	defer server.Close()
+	client := symbolsclient.Client{URL: server.URL}
+
+	for _, commitID := range []api.CommitID{"a", "b", "c"} {
+		if _, err := client.Search(context.Background(), search.SymbolsParameters{Repo: "r", CommitID: commitID, First: 10}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	result, err := client.Search(context.Background(), search.SymbolsParameters{Repo: "r", CommitID: "c", First: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(result.Symbols, func(i, j int) bool { return result.Symbols[i].Path < result.Symbols[j].Path })
+	if want := []protocol.Symbol{{Name: "x", Path: "a.js"}, {Name: "w", Path: "c.js"}}; !reflect.DeepEqual(result.Symbols, want) {
+		t.Errorf("got %+v, want %+v", result.Symbols, want)
+	}
+
+	if want := [][2]api.CommitID{{"a", "b"}, {"a", "c"}}; !reflect.DeepEqual(diffs, want) {
+		t.Errorf("got diffs %v, want %v", diffs, want)
+	}
+}
+
+func TestAddRecentDB(t *testing.T) {
+	var s Service
+	for i := 0; i < maxRecentDBsPerRepo+2; i++ {
+		s.addRecentDB("r", api.CommitID(strconv.Itoa(i)), "")
+	}
+	s.addRecentDB("r", "3", "")
+
+	var commitIDs []api.CommitID
+	for _, db := range s.recentDBsOf("r") {
+		commitIDs = append(commitIDs, db.commitID)
+	}
+	if want := []api.CommitID{"3", "6", "5", "4", "2"}; !reflect.DeepEqual(commitIDs, want) {
+		t.Errorf("got commits %v, want %v", commitIDs, want)
+	}
+}
+
+// contentParser returns a single symbol per file whose name is the file's
+// contents.
+type contentParser struct{}
+
+func (contentParser) Parse(name string, content []byte) ([]ctags.Entry, error) {
+	return []ctags.Entry{{Name: string(content), Path: name}}, nil
+}
+
+func (contentParser) Close() {}
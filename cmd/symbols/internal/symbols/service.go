@@ -6,8 +6,10 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/golang/groupcache/lru"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/pkg/ctags"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -22,6 +24,19 @@ type Service struct {
 	// determine if the error is a bad request (eg invalid repo).
 	FetchTar func(context.Context, gitserver.Repo, api.CommitID) (io.ReadCloser, error)
 
+	// FetchTarPaths is like FetchTar, but the archive only contains the given paths.
+	FetchTarPaths func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error)
+
+	// GitDiff returns the paths that changed between two commits of a repository. When set
+	// along with FetchTarPaths and CommitDistance, the database of a new commit is created by
+	// copying the database of the nearest recently used commit of the same repository and
+	// re-parsing only the changed paths.
+	GitDiff func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (Changes, error)
+
+	// CommitDistance returns the number of commits of a repository that are reachable from
+	// one of two commits but not from the other.
+	CommitDistance func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (int, error)
+
 	// MaxConcurrentFetchTar is the maximum number of concurrent calls allowed
 	// to FetchTar. It defaults to 15.
 	MaxConcurrentFetchTar int
@@ -49,6 +64,15 @@ type Service struct {
 
 	// pool of ctags parser child processes
 	parsers chan ctags.Parser
+
+	// recentDBsMu protects recentDBs.
+	recentDBsMu sync.Mutex
+
+	// recentDBs maps a repository to its most recently used databases in the
+	// cache ([]cachedDB, most recent first) for the most recently used
+	// repositories. It is used to find a database to update incrementally when
+	// a new commit is requested.
+	recentDBs *lru.Cache
 }
 
 // Start must be called before any requests are handled.
@@ -22,6 +22,7 @@ import (
 
 func init() {
 	sqliteutil.SetLocalLibpath()
+	sqliteutil.MustRegisterSqlite3WithPcre()
 }
 
 func TestIsLiteralEquality(t *testing.T) {
@@ -57,8 +58,6 @@ func TestIsLiteralEquality(t *testing.T) {
 }
 
 func TestService(t *testing.T) {
-	sqliteutil.MustRegisterSqlite3WithPcre()
-
 	tmpDir, err := ioutil.TempDir("", "")
 	if err != nil {
 		t.Fatal(err)
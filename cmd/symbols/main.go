@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log"
 	"net"
@@ -12,9 +13,11 @@ import (
 	"os/signal"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
 
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/pkg/ctags"
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/symbols"
@@ -49,6 +52,33 @@ func main() {
 		FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
 			return gitserver.DefaultClient.Archive(ctx, repo, gitserver.ArchiveOptions{Treeish: string(commit), Format: "tar"})
 		},
+		FetchTarPaths: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			// The paths are passed to git as pathspecs, which must be literal so
+			// that paths containing wildcard characters only match themselves.
+			pathspecs := make([]string, len(paths))
+			for i, path := range paths {
+				pathspecs[i] = ":(literal)" + path
+			}
+			return gitserver.DefaultClient.Archive(ctx, repo, gitserver.ArchiveOptions{Treeish: string(commit), Format: "tar", Paths: pathspecs})
+		},
+		GitDiff: func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (symbols.Changes, error) {
+			cmd := gitserver.DefaultClient.Command("git", "diff", "-z", "--name-status", "--no-renames", string(commitA), string(commitB))
+			cmd.Repo = gitserver.Repo{Name: repo}
+			out, err := cmd.Output(ctx)
+			if err != nil {
+				return symbols.Changes{}, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
+			}
+			return symbols.ParseGitDiffNameStatus(out)
+		},
+		CommitDistance: func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (int, error) {
+			cmd := gitserver.DefaultClient.Command("git", "rev-list", "--count", string(commitA)+"..."+string(commitB))
+			cmd.Repo = gitserver.Repo{Name: repo}
+			out, err := cmd.Output(ctx)
+			if err != nil {
+				return 0, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
+			}
+			return strconv.Atoi(strings.TrimSpace(string(out)))
+		},
 		NewParser: ctags.New,
 		Path:      cacheDir,
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
@@ -41,6 +42,7 @@ import (
 	codeintelresolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
 	codeintelgqlresolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers/graphql"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	codeintelsymbols "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/symbols"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/globalstatedb"
@@ -163,6 +165,7 @@ func initCampaigns(ctx context.Context, enterpriseServices *enterprise.Services)
 		msResolutionClock,
 		"sourcegraph-"+globalState.SiteID,
 	)
+	enterpriseServices.GitLabWebhook = campaigns.NewGitLabWebhook(campaignsStore, repositories, msResolutionClock)
 }
 
 var bundleManagerURL = env.Get("PRECISE_CODE_INTEL_BUNDLE_MANAGER_URL", "", "HTTP address for internal LSIF bundle manager server.")
@@ -191,6 +194,8 @@ func initCodeIntel(enterpriseServices *enterprise.Services) {
 	enterpriseServices.NewCodeIntelUploadHandler = func(internal bool) http.Handler {
 		return codeintelhttpapi.NewUploadHandler(store, bundleManagerClient, internal)
 	}
+
+	backend.PreciseSymbols = codeintelsymbols.NewSearcher(store, bundleManagerClient).Search
 }
 
 type usersStore struct{}
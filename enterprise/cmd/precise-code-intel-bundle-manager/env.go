@@ -16,6 +16,13 @@ var (
 	rawMaxUploadAge        = env.Get("PRECISE_CODE_INTEL_MAX_UPLOAD_AGE", "24h", "The maximum time an upload can sit on disk.")
 	rawMaxUploadPartAge    = env.Get("PRECISE_CODE_INTEL_MAX_UPLOAD_PART_AGE", "2h", "The maximum time an upload part file can sit on disk.")
 	rawMaxDatabasePartAge  = env.Get("PRECISE_CODE_INTEL_MAX_DATABASE_PART_AGE", "2h", "The maximum time a database part file can sit on disk.")
+	rawStorageBackend      = env.Get("PRECISE_CODE_INTEL_STORAGE_BACKEND", "local", "Where uploads and converted bundles are stored (local or s3).")
+	rawS3Endpoint          = env.Get("PRECISE_CODE_INTEL_S3_ENDPOINT", "", "The URL of the S3-compatible object store. Defaults to the AWS endpoint of the configured region.")
+	rawS3Bucket            = env.Get("PRECISE_CODE_INTEL_S3_BUCKET", "", "The bucket containing uploads and converted bundles.")
+	rawS3Region            = env.Get("PRECISE_CODE_INTEL_S3_REGION", "us-east-1", "The region of the bucket.")
+	rawS3AccessKeyID       = env.Get("PRECISE_CODE_INTEL_S3_ACCESS_KEY_ID", "", "The access key used to authenticate with the object store.")
+	rawS3SecretAccessKey   = env.Get("PRECISE_CODE_INTEL_S3_SECRET_ACCESS_KEY", "", "The secret key used to authenticate with the object store.")
+	rawS3ForcePathStyle    = env.Get("PRECISE_CODE_INTEL_S3_FORCE_PATH_STYLE", "false", "Address the bucket as a path segment of the endpoint (required by most self-hosted object stores).")
 )
 
 // mustGet returns the non-empty version of the given raw value fatally logs on failure.
@@ -37,6 +44,16 @@ func mustParseInt(rawValue, name string) int {
 	return int(i)
 }
 
+// mustParseBool returns the boolean version of the given raw value fatally logs on failure.
+func mustParseBool(rawValue, name string) bool {
+	b, err := strconv.ParseBool(rawValue)
+	if err != nil {
+		log.Fatalf("invalid bool %q for %s: %s", rawValue, name, err)
+	}
+
+	return b
+}
+
 // mustParsePercent returns the integer percent (in range [0, 100]) version of the given raw
 // value fatally logs on failure.
 func mustParsePercent(rawValue, name string) int {
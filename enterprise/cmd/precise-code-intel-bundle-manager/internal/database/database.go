@@ -27,6 +27,9 @@ type Database interface {
 	// References returns the set of locations referencing the symbol at the given position.
 	References(ctx context.Context, path string, line, character int) ([]client.Location, error)
 
+	// Implementations returns the set of locations implementing the symbol at the given position.
+	Implementations(ctx context.Context, path string, line, character int) ([]client.Location, error)
+
 	// Hover returns the hover text of the symbol at the given position.
 	Hover(ctx context.Context, path string, line, character int) (string, client.Range, bool, error)
 
@@ -34,14 +37,23 @@ type Database interface {
 	// also returns the size of the complete result set to aid in pagination (along with skip and take).
 	Diagnostics(ctx context.Context, prefix string, skip, take int) ([]client.Diagnostic, int, error)
 
+	// Symbols returns the document symbols defined in the given path, ordered by position.
+	Symbols(ctx context.Context, path string) ([]client.Symbol, error)
+
+	// SearchSymbols returns the symbols whose name contains the given query (case-insensitively). This
+	// method also returns the size of the complete result set to aid in pagination (along with skip and
+	// take).
+	SearchSymbols(ctx context.Context, query string, skip, take int) ([]client.Symbol, int, error)
+
 	// MonikersByPosition returns all monikers attached ranges containing the given position. If multiple
 	// ranges contain the position, then this method will return multiple sets of monikers. Each slice
 	// of monikers are attached to a single range. The order of the output slice is "outside-in", so that
 	// the range attached to earlier monikers enclose the range attached to later monikers.
 	MonikersByPosition(ctx context.Context, path string, line, character int) ([][]client.MonikerData, error)
 
-	// MonikerResults returns the locations that define or reference the given moniker. This method
-	// also returns the size of the complete result set to aid in pagination (along with skip and take).
+	// MonikerResults returns the locations that define, reference, or implement the given moniker. This
+	// method also returns the size of the complete result set to aid in pagination (along with skip and
+	// take).
 	MonikerResults(ctx context.Context, tableName, scheme, identifier string, skip, take int) ([]client.Location, int, error)
 
 	// PackageInformation looks up package information data by identifier.
@@ -168,6 +180,35 @@ func (db *databaseImpl) References(ctx context.Context, path string, line, chara
 	return allLocations, nil
 }
 
+// Implementations returns the set of locations implementing the symbol at the given position.
+func (db *databaseImpl) Implementations(ctx context.Context, path string, line, character int) ([]client.Location, error) {
+	_, ranges, exists, err := db.getRangeByPosition(ctx, path, line, character)
+	if err != nil || !exists {
+		return nil, pkgerrors.Wrap(err, "db.getRangeByPosition")
+	}
+
+	var allLocations []client.Location
+	for _, r := range ranges {
+		if r.ImplementationResultID == "" {
+			continue
+		}
+
+		implementationResults, err := db.getResultByID(ctx, r.ImplementationResultID)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "db.getResultByID")
+		}
+
+		locations, err := db.convertRangesToLocations(ctx, implementationResults)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "db.convertRangesToLocations")
+		}
+
+		allLocations = append(allLocations, locations...)
+	}
+
+	return allLocations, nil
+}
+
 // Hover returns the hover text of the symbol at the given position.
 func (db *databaseImpl) Hover(ctx context.Context, path string, line, character int) (string, client.Range, bool, error) {
 	documentData, ranges, exists, err := db.getRangeByPosition(ctx, path, line, character)
@@ -242,6 +283,43 @@ func (db *databaseImpl) Diagnostics(ctx context.Context, prefix string, skip, ta
 	return diagnostics, totalCount, nil
 }
 
+// Symbols returns the document symbols defined in the given path, ordered by position.
+func (db *databaseImpl) Symbols(ctx context.Context, path string) ([]client.Symbol, error) {
+	symbols, err := db.reader.ReadSymbols(ctx, path)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "reader.ReadSymbols")
+	}
+
+	return convertSymbols(symbols), nil
+}
+
+// SearchSymbols returns the symbols whose name contains the given query (case-insensitively). This
+// method also returns the size of the complete result set to aid in pagination (along with skip and
+// take).
+func (db *databaseImpl) SearchSymbols(ctx context.Context, query string, skip, take int) ([]client.Symbol, int, error) {
+	symbols, totalCount, err := db.reader.SearchSymbols(ctx, query, skip, take)
+	if err != nil {
+		return nil, 0, pkgerrors.Wrap(err, "reader.SearchSymbols")
+	}
+
+	return convertSymbols(symbols), totalCount, nil
+}
+
+func convertSymbols(symbols []types.SymbolData) []client.Symbol {
+	converted := make([]client.Symbol, 0, len(symbols))
+	for _, symbol := range symbols {
+		converted = append(converted, client.Symbol{
+			Path:          symbol.URI,
+			Name:          symbol.Name,
+			Kind:          symbol.Kind,
+			ContainerName: symbol.ContainerName,
+			Range:         newRange(symbol.StartLine, symbol.StartCharacter, symbol.EndLine, symbol.EndCharacter),
+		})
+	}
+
+	return converted
+}
+
 // MonikersByPosition returns all monikers attached ranges containing the given position. If multiple
 // ranges contain the position, then this method will return multiple sets of monikers. Each slice
 // of monikers are attached to a single range. The order of the output slice is "outside-in", so that
@@ -280,8 +358,9 @@ func (db *databaseImpl) MonikersByPosition(ctx context.Context, path string, lin
 	return monikerData, nil
 }
 
-// MonikerResults returns the locations that define or reference the given moniker. This method
-// also returns the size of the complete result set to aid in pagination (along with skip and take).
+// MonikerResults returns the locations that define, reference, or implement the given moniker. This
+// method also returns the size of the complete result set to aid in pagination (along with skip and
+// take).
 func (db *databaseImpl) MonikerResults(ctx context.Context, tableName, scheme, identifier string, skip, take int) (_ []client.Location, _ int, err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "getResultChunkByResultID")
 	span.SetTag("filename", db.filename)
@@ -306,6 +385,10 @@ func (db *databaseImpl) MonikerResults(ctx context.Context, tableName, scheme, i
 		if rows, totalCount, err = db.reader.ReadReferences(ctx, scheme, identifier, skip, take); err != nil {
 			err = pkgerrors.Wrap(err, "reader.ReadReferences")
 		}
+	} else if tableName == "implementations" {
+		if rows, totalCount, err = db.reader.ReadImplementations(ctx, scheme, identifier, skip, take); err != nil {
+			err = pkgerrors.Wrap(err, "reader.ReadImplementations")
+		}
 	}
 
 	if err != nil {
@@ -394,7 +477,7 @@ func (db *databaseImpl) getRangeByPosition(ctx context.Context, path string, lin
 	return documentData, findRanges(documentData.Ranges, line, character), true, nil
 }
 
-// getResultByID fetches and unmarshals a definition or reference result by identifier.
+// getResultByID fetches and unmarshals a definition, reference, or implementation result by identifier.
 // This method caches result chunk data by a unique key prefixed by the database filename.
 func (db *databaseImpl) getResultByID(ctx context.Context, id types.ID) ([]DocumentPathRangeID, error) {
 	resultChunkData, exists, err := db.getResultChunkByResultID(ctx, id)
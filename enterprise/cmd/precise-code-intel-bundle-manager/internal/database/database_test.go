@@ -10,7 +10,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/cache"
+	persistencemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/mocks"
 	sqlitereader "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/sqliteutil"
 )
@@ -95,6 +97,118 @@ func TestDatabaseReferences(t *testing.T) {
 	}
 }
 
+func TestDatabaseImplementations(t *testing.T) {
+	// The test bundles predate implementation results, so we construct one by hand:
+	// the interface method in `a.go` is implemented by methods in `b.go` and `c.go`.
+
+	reader := persistencemocks.NewMockReader()
+	reader.ReadMetaFunc.SetDefaultReturn(types.MetaData{NumResultChunks: 1}, nil)
+	reader.ReadDocumentFunc.SetDefaultHook(func(ctx context.Context, path string) (types.DocumentData, bool, error) {
+		documents := map[string]types.DocumentData{
+			"a.go": {Ranges: map[types.ID]types.RangeData{
+				"r01": {StartLine: 3, StartCharacter: 1, EndLine: 3, EndCharacter: 5, ImplementationResultID: "x01"},
+			}},
+			"b.go": {Ranges: map[types.ID]types.RangeData{
+				"r02": {StartLine: 10, StartCharacter: 12, EndLine: 10, EndCharacter: 16},
+			}},
+			"c.go": {Ranges: map[types.ID]types.RangeData{
+				"r03": {StartLine: 20, StartCharacter: 14, EndLine: 20, EndCharacter: 18},
+			}},
+		}
+
+		document, ok := documents[path]
+		return document, ok, nil
+	})
+	reader.ReadResultChunkFunc.SetDefaultReturn(types.ResultChunkData{
+		DocumentPaths: map[types.ID]string{"d02": "b.go", "d03": "c.go"},
+		DocumentIDRangeIDs: map[types.ID][]types.DocumentIDRangeID{
+			"x01": {{DocumentID: "d03", RangeID: "r03"}, {DocumentID: "d02", RangeID: "r02"}},
+		},
+	}, true, nil)
+
+	db, err := OpenDatabase(context.Background(), "test.db", reader)
+	if err != nil {
+		t.Fatalf("unexpected error opening database: %s", err)
+	}
+
+	if actual, err := db.Implementations(context.Background(), "a.go", 3, 2); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	} else {
+		expected := []client.Location{
+			{
+				Path:  "b.go",
+				Range: newRange(10, 12, 10, 16),
+			},
+			{
+				Path:  "c.go",
+				Range: newRange(20, 14, 20, 18),
+			},
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("unexpected implementation locations (-want +got):\n%s", diff)
+		}
+	}
+
+	// Ranges without implementation results have no implementations
+	if actual, err := db.Implementations(context.Background(), "b.go", 10, 13); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	} else if len(actual) != 0 {
+		t.Errorf("unexpected implementation locations: %v", actual)
+	}
+}
+
+func TestDatabaseSymbols(t *testing.T) {
+	reader := persistencemocks.NewMockReader()
+	reader.ReadMetaFunc.SetDefaultReturn(types.MetaData{NumResultChunks: 1}, nil)
+	reader.ReadSymbolsFunc.SetDefaultReturn([]types.SymbolData{
+		{URI: "a.go", Name: "Server", Kind: 23, StartLine: 1, StartCharacter: 0, EndLine: 4, EndCharacter: 1},
+		{URI: "a.go", Name: "Serve", Kind: 6, ContainerName: "Server", StartLine: 2, StartCharacter: 1, EndLine: 3, EndCharacter: 2},
+	}, nil)
+	reader.SearchSymbolsFunc.SetDefaultReturn([]types.SymbolData{
+		{URI: "b.go", Name: "NewServer", Kind: 12, StartLine: 5, StartCharacter: 0, EndLine: 7, EndCharacter: 1},
+	}, 3, nil)
+
+	db, err := OpenDatabase(context.Background(), "test.db", reader)
+	if err != nil {
+		t.Fatalf("unexpected error opening database: %s", err)
+	}
+
+	if actual, err := db.Symbols(context.Background(), "a.go"); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	} else {
+		expected := []client.Symbol{
+			{Path: "a.go", Name: "Server", Kind: 23, Range: newRange(1, 0, 4, 1)},
+			{Path: "a.go", Name: "Serve", Kind: 6, ContainerName: "Server", Range: newRange(2, 1, 3, 2)},
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+		}
+	}
+
+	if actual, totalCount, err := db.SearchSymbols(context.Background(), "server", 1, 1); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	} else {
+		if totalCount != 3 {
+			t.Errorf("unexpected count. want=%d have=%d", 3, totalCount)
+		}
+
+		expected := []client.Symbol{
+			{Path: "b.go", Name: "NewServer", Kind: 12, Range: newRange(5, 0, 7, 1)},
+		}
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+		}
+	}
+
+	if calls := reader.SearchSymbolsFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of SearchSymbols calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != "server" || calls[0].Arg2 != 1 || calls[0].Arg3 != 1 {
+		t.Errorf("unexpected SearchSymbols arguments: %v", calls[0].Args())
+	}
+}
+
 func TestDatabaseHover(t *testing.T) {
 	// `\tcontents, err := findContents(pkgs, p, f, obj)`
 	//                     ^^^^^^^^^^^^
@@ -28,6 +28,9 @@ type MockDatabase struct {
 	// HoverFunc is an instance of a mock function object controlling the
 	// behavior of the method Hover.
 	HoverFunc *DatabaseHoverFunc
+	// ImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method Implementations.
+	ImplementationsFunc *DatabaseImplementationsFunc
 	// MonikerResultsFunc is an instance of a mock function object
 	// controlling the behavior of the method MonikerResults.
 	MonikerResultsFunc *DatabaseMonikerResultsFunc
@@ -40,6 +43,12 @@ type MockDatabase struct {
 	// ReferencesFunc is an instance of a mock function object controlling
 	// the behavior of the method References.
 	ReferencesFunc *DatabaseReferencesFunc
+	// SearchSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchSymbols.
+	SearchSymbolsFunc *DatabaseSearchSymbolsFunc
+	// SymbolsFunc is an instance of a mock function object controlling the
+	// behavior of the method Symbols.
+	SymbolsFunc *DatabaseSymbolsFunc
 }
 
 // NewMockDatabase creates a new mock of the Database interface. All methods
@@ -71,6 +80,11 @@ func NewMockDatabase() *MockDatabase {
 				return "", client.Range{}, false, nil
 			},
 		},
+		ImplementationsFunc: &DatabaseImplementationsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]client.Location, error) {
+				return nil, nil
+			},
+		},
 		MonikerResultsFunc: &DatabaseMonikerResultsFunc{
 			defaultHook: func(context.Context, string, string, string, int, int) ([]client.Location, int, error) {
 				return nil, 0, nil
@@ -91,6 +105,16 @@ func NewMockDatabase() *MockDatabase {
 				return nil, nil
 			},
 		},
+		SearchSymbolsFunc: &DatabaseSearchSymbolsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]client.Symbol, int, error) {
+				return nil, 0, nil
+			},
+		},
+		SymbolsFunc: &DatabaseSymbolsFunc{
+			defaultHook: func(context.Context, string) ([]client.Symbol, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -113,6 +137,9 @@ func NewMockDatabaseFrom(i Database) *MockDatabase {
 		HoverFunc: &DatabaseHoverFunc{
 			defaultHook: i.Hover,
 		},
+		ImplementationsFunc: &DatabaseImplementationsFunc{
+			defaultHook: i.Implementations,
+		},
 		MonikerResultsFunc: &DatabaseMonikerResultsFunc{
 			defaultHook: i.MonikerResults,
 		},
@@ -125,6 +152,12 @@ func NewMockDatabaseFrom(i Database) *MockDatabase {
 		ReferencesFunc: &DatabaseReferencesFunc{
 			defaultHook: i.References,
 		},
+		SearchSymbolsFunc: &DatabaseSearchSymbolsFunc{
+			defaultHook: i.SearchSymbols,
+		},
+		SymbolsFunc: &DatabaseSymbolsFunc{
+			defaultHook: i.Symbols,
+		},
 	}
 }
 
@@ -688,6 +721,121 @@ func (c DatabaseHoverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
 }
 
+// DatabaseImplementationsFunc describes the behavior when the
+// Implementations method of the parent MockDatabase instance is invoked.
+type DatabaseImplementationsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]client.Location, error)
+	hooks       []func(context.Context, string, int, int) ([]client.Location, error)
+	history     []DatabaseImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// Implementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDatabase) Implementations(v0 context.Context, v1 string, v2 int, v3 int) ([]client.Location, error) {
+	r0, r1 := m.ImplementationsFunc.nextHook()(v0, v1, v2, v3)
+	m.ImplementationsFunc.appendCall(DatabaseImplementationsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Implementations
+// method of the parent MockDatabase instance is invoked and the hook queue
+// is empty.
+func (f *DatabaseImplementationsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Implementations method of the parent MockDatabase instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DatabaseImplementationsFunc) PushHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DatabaseImplementationsFunc) SetDefaultReturn(r0 []client.Location, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DatabaseImplementationsFunc) PushReturn(r0 []client.Location, r1 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+func (f *DatabaseImplementationsFunc) nextHook() func(context.Context, string, int, int) ([]client.Location, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DatabaseImplementationsFunc) appendCall(r0 DatabaseImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DatabaseImplementationsFuncCall objects
+// describing the invocations of this function.
+func (f *DatabaseImplementationsFunc) History() []DatabaseImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]DatabaseImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DatabaseImplementationsFuncCall is an object that describes an invocation
+// of method Implementations on an instance of MockDatabase.
+type DatabaseImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DatabaseImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DatabaseImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DatabaseMonikerResultsFunc describes the behavior when the MonikerResults
 // method of the parent MockDatabase instance is invoked.
 type DatabaseMonikerResultsFunc struct {
@@ -1155,3 +1303,229 @@ func (c DatabaseReferencesFuncCall) Args() []interface{} {
 func (c DatabaseReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// DatabaseSearchSymbolsFunc describes the behavior when the SearchSymbols
+// method of the parent MockDatabase instance is invoked.
+type DatabaseSearchSymbolsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]client.Symbol, int, error)
+	hooks       []func(context.Context, string, int, int) ([]client.Symbol, int, error)
+	history     []DatabaseSearchSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchSymbols delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDatabase) SearchSymbols(v0 context.Context, v1 string, v2 int, v3 int) ([]client.Symbol, int, error) {
+	r0, r1, r2 := m.SearchSymbolsFunc.nextHook()(v0, v1, v2, v3)
+	m.SearchSymbolsFunc.appendCall(DatabaseSearchSymbolsFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the SearchSymbols method
+// of the parent MockDatabase instance is invoked and the hook queue is
+// empty.
+func (f *DatabaseSearchSymbolsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]client.Symbol, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchSymbols method of the parent MockDatabase instance inovkes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DatabaseSearchSymbolsFunc) PushHook(hook func(context.Context, string, int, int) ([]client.Symbol, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DatabaseSearchSymbolsFunc) SetDefaultReturn(r0 []client.Symbol, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]client.Symbol, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DatabaseSearchSymbolsFunc) PushReturn(r0 []client.Symbol, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]client.Symbol, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DatabaseSearchSymbolsFunc) nextHook() func(context.Context, string, int, int) ([]client.Symbol, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DatabaseSearchSymbolsFunc) appendCall(r0 DatabaseSearchSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DatabaseSearchSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *DatabaseSearchSymbolsFunc) History() []DatabaseSearchSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]DatabaseSearchSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DatabaseSearchSymbolsFuncCall is an object that describes an invocation
+// of method SearchSymbols on an instance of MockDatabase.
+type DatabaseSearchSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.Symbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DatabaseSearchSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DatabaseSearchSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// DatabaseSymbolsFunc describes the behavior when the Symbols method of the
+// parent MockDatabase instance is invoked.
+type DatabaseSymbolsFunc struct {
+	defaultHook func(context.Context, string) ([]client.Symbol, error)
+	hooks       []func(context.Context, string) ([]client.Symbol, error)
+	history     []DatabaseSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// Symbols delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockDatabase) Symbols(v0 context.Context, v1 string) ([]client.Symbol, error) {
+	r0, r1 := m.SymbolsFunc.nextHook()(v0, v1)
+	m.SymbolsFunc.appendCall(DatabaseSymbolsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Symbols method of
+// the parent MockDatabase instance is invoked and the hook queue is empty.
+func (f *DatabaseSymbolsFunc) SetDefaultHook(hook func(context.Context, string) ([]client.Symbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Symbols method of the parent MockDatabase instance inovkes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DatabaseSymbolsFunc) PushHook(hook func(context.Context, string) ([]client.Symbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DatabaseSymbolsFunc) SetDefaultReturn(r0 []client.Symbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]client.Symbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DatabaseSymbolsFunc) PushReturn(r0 []client.Symbol, r1 error) {
+	f.PushHook(func(context.Context, string) ([]client.Symbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *DatabaseSymbolsFunc) nextHook() func(context.Context, string) ([]client.Symbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DatabaseSymbolsFunc) appendCall(r0 DatabaseSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DatabaseSymbolsFuncCall objects describing
+// the invocations of this function.
+func (f *DatabaseSymbolsFunc) History() []DatabaseSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]DatabaseSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DatabaseSymbolsFuncCall is an object that describes an invocation of
+// method Symbols on an instance of MockDatabase.
+type DatabaseSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.Symbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DatabaseSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DatabaseSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -16,8 +16,11 @@ type ObservedDatabase struct {
 	existsOperation             *observation.Operation
 	definitionsOperation        *observation.Operation
 	referencesOperation         *observation.Operation
+	implementationsOperation    *observation.Operation
 	hoverOperation              *observation.Operation
 	diagnosticsOperation        *observation.Operation
+	symbolsOperation            *observation.Operation
+	searchSymbolsOperation      *observation.Operation
 	monikersByPositionOperation *observation.Operation
 	monikerResultsOperation     *observation.Operation
 	packageInformationOperation *observation.Operation
@@ -59,6 +62,11 @@ func NewObserved(database Database, filename string, observationContext *observa
 			MetricLabels: []string{"references"},
 			Metrics:      metrics,
 		}),
+		implementationsOperation: observationContext.Operation(observation.Op{
+			Name:         "Database.Implementations",
+			MetricLabels: []string{"implementations"},
+			Metrics:      metrics,
+		}),
 		hoverOperation: observationContext.Operation(observation.Op{
 			Name:         "Database.Hover",
 			MetricLabels: []string{"hover"},
@@ -69,6 +77,16 @@ func NewObserved(database Database, filename string, observationContext *observa
 			MetricLabels: []string{"diagnostics"},
 			Metrics:      metrics,
 		}),
+		symbolsOperation: observationContext.Operation(observation.Op{
+			Name:         "Database.Symbols",
+			MetricLabels: []string{"symbols"},
+			Metrics:      metrics,
+		}),
+		searchSymbolsOperation: observationContext.Operation(observation.Op{
+			Name:         "Database.SearchSymbols",
+			MetricLabels: []string{"search_symbols"},
+			Metrics:      metrics,
+		}),
 		monikersByPositionOperation: observationContext.Operation(observation.Op{
 			Name:         "Database.MonikersByPosition",
 			MetricLabels: []string{"monikers_by_position"},
@@ -130,6 +148,20 @@ func (db *ObservedDatabase) References(ctx context.Context, path string, line, c
 	return db.database.References(ctx, path, line, character)
 }
 
+// Implementations calls into the inner Database and registers the observed results.
+func (db *ObservedDatabase) Implementations(ctx context.Context, path string, line, character int) (implementations []client.Location, err error) {
+	ctx, endObservation := db.implementationsOperation.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.String("filename", db.filename),
+			log.String("path", path),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer func() { endObservation(float64(len(implementations)), observation.Args{}) }()
+	return db.database.Implementations(ctx, path, line, character)
+}
+
 // Hover calls into the inner Database and registers the observed results.
 func (db *ObservedDatabase) Hover(ctx context.Context, path string, line, character int) (_ string, _ client.Range, _ bool, err error) {
 	ctx, endObservation := db.hoverOperation.With(ctx, &err, observation.Args{
@@ -156,6 +188,32 @@ func (db *ObservedDatabase) Diagnostics(ctx context.Context, prefix string, skip
 	return db.database.Diagnostics(ctx, prefix, skip, take)
 }
 
+// Symbols calls into the inner Database and registers the observed results.
+func (db *ObservedDatabase) Symbols(ctx context.Context, path string) (symbols []client.Symbol, err error) {
+	ctx, endObservation := db.symbolsOperation.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.String("filename", db.filename),
+			log.String("path", path),
+		},
+	})
+	defer func() { endObservation(float64(len(symbols)), observation.Args{}) }()
+	return db.database.Symbols(ctx, path)
+}
+
+// SearchSymbols calls into the inner Database and registers the observed results.
+func (db *ObservedDatabase) SearchSymbols(ctx context.Context, query string, skip, take int) (symbols []client.Symbol, _ int, err error) {
+	ctx, endObservation := db.searchSymbolsOperation.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.String("filename", db.filename),
+			log.String("query", query),
+			log.Int("skip", skip),
+			log.Int("take", take),
+		},
+	})
+	defer func() { endObservation(float64(len(symbols)), observation.Args{}) }()
+	return db.database.SearchSymbols(ctx, query, skip, take)
+}
+
 // MonikersByPosition calls into the inner Database and registers the observed results.
 func (db *ObservedDatabase) MonikersByPosition(ctx context.Context, path string, line, character int) (monikers [][]client.MonikerData, err error) {
 	ctx, endObservation := db.monikersByPositionOperation.With(ctx, &err, observation.Args{
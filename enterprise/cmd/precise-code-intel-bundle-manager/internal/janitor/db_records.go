@@ -5,13 +5,13 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
-	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/paths"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 )
 
 // removeProcessedUploadsWithoutBundleFile removes all processed upload records
-// that do not have a corresponding bundle file on disk.
+// that do not have a corresponding bundle file in storage.
 func (j *Janitor) removeProcessedUploadsWithoutBundleFile() error {
 	ctx := context.Background()
 
@@ -21,12 +21,18 @@ func (j *Janitor) removeProcessedUploadsWithoutBundleFile() error {
 		return errors.Wrap(err, "store.GetDumpIDs")
 	}
 
+	objects, err := j.storage.List(ctx, storage.DBsPrefix)
+	if err != nil {
+		return errors.Wrap(err, "storage.List")
+	}
+
+	keys := map[string]struct{}{}
+	for _, object := range objects {
+		keys[object.Key] = struct{}{}
+	}
+
 	for _, id := range ids {
-		exists, err := paths.PathExists(paths.DBDir(j.bundleDir, int64(id)))
-		if err != nil {
-			return errors.Wrap(err, "paths.PathExists")
-		}
-		if exists {
+		if _, exists := keys[storage.DBKey(int64(id))]; exists {
 			continue
 		}
 
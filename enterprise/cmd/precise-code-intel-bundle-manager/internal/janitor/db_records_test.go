@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 )
@@ -29,6 +30,7 @@ func TestRemoveProcessedUploadsWithoutBundleFile(t *testing.T) {
 	j := &Janitor{
 		store:     mockStore,
 		bundleDir: bundleDir,
+		storage:   storage.NewLocalStore(bundleDir),
 		metrics:   NewJanitorMetrics(metrics.TestRegisterer),
 	}
 
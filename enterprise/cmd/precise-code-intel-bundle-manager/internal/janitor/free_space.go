@@ -4,7 +4,9 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"sort"
 	"syscall"
+	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
@@ -12,7 +14,8 @@ import (
 )
 
 // freeSpace determines the space available on the device containing the bundle directory,
-// then calls cleanOldBundles to free enough space to get back below the disk usage threshold.
+// then calls evictBundles to free enough space to get back below the disk usage threshold.
+// If bundles are not stored on the local disk, evictCachedDatabases is called instead.
 func (j *Janitor) freeSpace() error {
 	var fs syscall.Statfs_t
 	if err := syscall.Statfs(j.bundleDir, &fs); err != nil {
@@ -26,6 +29,10 @@ func (j *Janitor) freeSpace() error {
 		return nil
 	}
 
+	if !j.storesBundlesLocally() {
+		return j.evictCachedDatabases(desiredFreeBytes - freeBytes)
+	}
+
 	return j.evictBundles(desiredFreeBytes - freeBytes)
 }
 
@@ -76,7 +83,7 @@ func (j *Janitor) evictBundle() (uint64, bool, error) {
 		return 0, false, err
 	}
 
-	if !j.remove(path) {
+	if !j.removeDatabase(id) {
 		return 0, true, nil
 	}
 
@@ -85,6 +92,58 @@ func (j *Janitor) evictBundle() (uint64, bool, error) {
 	return size, true, nil
 }
 
+// evictCachedDatabases removes the local copies of databases fetched from storage, least
+// recently fetched first, until at least bytesToFree have been removed or there are no more
+// cached databases. Evicted databases are fetched again from storage on their next use.
+func (j *Janitor) evictCachedDatabases(bytesToFree uint64) error {
+	ids, err := cachedDatabaseIDs(j.bundleDir)
+	if err != nil {
+		return err
+	}
+
+	modTimes := map[int]time.Time{}
+	for _, id := range ids {
+		fileInfo, err := os.Stat(paths.SQLiteDBFilename(j.bundleDir, int64(id)))
+		if err != nil {
+			continue
+		}
+
+		modTimes[id] = fileInfo.ModTime()
+	}
+
+	sort.Slice(ids, func(i, k int) bool {
+		return modTimes[ids[i]].Before(modTimes[ids[k]])
+	})
+
+	for _, id := range ids {
+		path := paths.DBDir(j.bundleDir, int64(id))
+
+		size, err := sizeOf(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return err
+		}
+
+		if !j.remove(path) {
+			continue
+		}
+
+		log15.Debug("Removed cached bundle file", "id", id, "path", path)
+		j.metrics.CachedBundleFilesRemoved.Inc()
+
+		if size >= bytesToFree {
+			break
+		}
+
+		bytesToFree -= size
+	}
+
+	return nil
+}
+
 // sizeOf recursively find the size of the given path. Returns any stat errors
 // that occur when trying to find the size of a file or files within the given
 // directory.
@@ -3,10 +3,13 @@ package janitor
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 )
@@ -43,6 +46,7 @@ func TestEvictBundlesStopsAfterFreeingDesiredSpace(t *testing.T) {
 	j := &Janitor{
 		store:     mockStore,
 		bundleDir: bundleDir,
+		storage:   storage.NewLocalStore(bundleDir),
 		metrics:   NewJanitorMetrics(metrics.TestRegisterer),
 	}
 
@@ -99,6 +103,7 @@ func TestEvictBundlesStopsWithNoPrunableDatabases(t *testing.T) {
 	j := &Janitor{
 		store:     mockStore,
 		bundleDir: bundleDir,
+		storage:   storage.NewLocalStore(bundleDir),
 		metrics:   NewJanitorMetrics(metrics.TestRegisterer),
 	}
 
@@ -133,6 +138,7 @@ func TestEvictBundlesNoBundleFile(t *testing.T) {
 	j := &Janitor{
 		store:     mockStore,
 		bundleDir: bundleDir,
+		storage:   storage.NewLocalStore(bundleDir),
 		metrics:   NewJanitorMetrics(metrics.TestRegisterer),
 	}
 
@@ -140,3 +146,46 @@ func TestEvictBundlesNoBundleFile(t *testing.T) {
 		t.Fatalf("unexpected error evicting bundles: %s", err)
 	}
 }
+
+func TestEvictCachedDatabases(t *testing.T) {
+	bundleDir := testRoot(t)
+
+	for id := 1; id <= 5; id++ {
+		path := filepath.Join(bundleDir, "dbs", fmt.Sprintf("%d", id), "sqlite.db")
+		if err := makeFileWithSize(path, 20); err != nil {
+			t.Fatalf("unexpected error creating file %s: %s", path, err)
+		}
+
+		mtime := time.Now().Local().Add(-time.Duration(10-id) * time.Minute)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("unexpected error changing file times %s: %s", path, err)
+		}
+	}
+
+	mockStore := storemocks.NewMockStore()
+
+	j := &Janitor{
+		store:     mockStore,
+		bundleDir: bundleDir,
+		storage:   remoteStore{storage.NewLocalStore(testRoot(t))},
+		metrics:   NewJanitorMetrics(metrics.TestRegisterer),
+	}
+
+	if err := j.evictCachedDatabases(50); err != nil {
+		t.Fatalf("unexpected error evicting cached databases: %s", err)
+	}
+
+	names, err := getFilenames(filepath.Join(bundleDir, "dbs"))
+	if err != nil {
+		t.Fatalf("unexpected error listing directory: %s", err)
+	}
+
+	expected := []string{"4/sqlite.db", "5/sqlite.db"}
+	if diff := cmp.Diff(expected, names); diff != "" {
+		t.Errorf("unexpected directory contents (-want +got):\n%s", diff)
+	}
+
+	if calls := len(mockStore.DeleteOldestDumpFunc.History()); calls != 0 {
+		t.Errorf("unexpected number of DeleteOldestDump calls. want=%d have=%d", 0, calls)
+	}
+}
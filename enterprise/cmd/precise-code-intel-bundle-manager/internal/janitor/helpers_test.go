@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 )
 
 func testRoot(t *testing.T) string {
@@ -64,3 +66,8 @@ func getFilenames(root string) ([]string, error) {
 
 	return paths, nil
 }
+
+// remoteStore wraps a local store so that the janitor treats it as remote storage.
+type remoteStore struct {
+	*storage.LocalStore
+}
@@ -1,18 +1,22 @@
 package janitor
 
 import (
+	"context"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/paths"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 )
 
 type Janitor struct {
 	store              store.Store
 	bundleDir          string
+	storage            storage.Store
 	desiredPercentFree int
 	janitorInterval    time.Duration
 	maxUploadAge       time.Duration
@@ -26,6 +30,7 @@ type Janitor struct {
 func New(
 	store store.Store,
 	bundleDir string,
+	storage storage.Store,
 	desiredPercentFree int,
 	janitorInterval time.Duration,
 	maxUploadAge time.Duration,
@@ -36,6 +41,7 @@ func New(
 	return &Janitor{
 		store:              store,
 		bundleDir:          bundleDir,
+		storage:            storage,
 		desiredPercentFree: desiredPercentFree,
 		janitorInterval:    janitorInterval,
 		maxUploadAge:       maxUploadAge,
@@ -114,3 +120,30 @@ func (j *Janitor) remove(path string) bool {
 
 	return true
 }
+
+// removeObject deletes the object with the given key from storage. Returns a boolean indicating
+// success. If unsuccessful, the key and error will be logged and the error counter will be
+// incremented.
+func (j *Janitor) removeObject(key string) bool {
+	if err := j.storage.Delete(context.Background(), key); err != nil {
+		j.metrics.Errors.Inc()
+		log15.Error("Failed to remove object", "key", key, "err", err)
+		return false
+	}
+
+	return true
+}
+
+// removeDatabase deletes the database with the given identifier from storage as well as any
+// copy of the database cached on local disk. Returns a boolean indicating success.
+func (j *Janitor) removeDatabase(id int) bool {
+	return j.removeObject(storage.DBKey(int64(id))) && j.remove(paths.DBDir(j.bundleDir, int64(id)))
+}
+
+// storesBundlesLocally returns true if the bundle directory is the primary storage location
+// of uploads and databases. Otherwise, the bundle directory holds only cached copies of
+// databases that can be fetched again from storage.
+func (j *Janitor) storesBundlesLocally() bool {
+	_, ok := j.storage.(*storage.LocalStore)
+	return ok
+}
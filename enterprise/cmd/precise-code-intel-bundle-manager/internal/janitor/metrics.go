@@ -7,6 +7,7 @@ type JanitorMetrics struct {
 	PartFilesRemoved          prometheus.Counter
 	OrphanedFilesRemoved      prometheus.Counter
 	EvictedBundleFilesRemoved prometheus.Counter
+	CachedBundleFilesRemoved  prometheus.Counter
 	UploadRecordsRemoved      prometheus.Counter
 	Errors                    prometheus.Counter
 }
@@ -36,6 +37,12 @@ func NewJanitorMetrics(r prometheus.Registerer) JanitorMetrics {
 	})
 	r.MustRegister(evictedBundleFilesRemoved)
 
+	cachedBundleFilesRemoved := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_bundle_manager_janitor_cached_bundle_files_removed_total",
+		Help: "Total number of locally cached bundle files removed (to free disk space)",
+	})
+	r.MustRegister(cachedBundleFilesRemoved)
+
 	uploadRecordsRemoved := prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "src_bundle_manager_janitor_upload_records_removed_total",
 		Help: "Total number of processed upload records removed (with no corresponding bundle file)",
@@ -53,6 +60,7 @@ func NewJanitorMetrics(r prometheus.Registerer) JanitorMetrics {
 		PartFilesRemoved:          partFilesRemoved,
 		OrphanedFilesRemoved:      orphanedFilesRemoved,
 		EvictedBundleFilesRemoved: evictedBundleFilesRemoved,
+		CachedBundleFilesRemoved:  cachedBundleFilesRemoved,
 		UploadRecordsRemoved:      uploadRecordsRemoved,
 		Errors:                    errors,
 	}
@@ -1,12 +1,11 @@
 package janitor
 
 import (
-	"io/ioutil"
-	"path/filepath"
+	"context"
 	"time"
 
 	"github.com/inconshreveable/log15"
-	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/paths"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 )
 
 // removeOldUploadFiles removes all upload files that are older than the configured
@@ -15,8 +14,8 @@ import (
 // upload is properly in an errored state, but we keep this cleanup routine here as
 // well for good measure.
 func (j *Janitor) removeOldUploadFiles() error {
-	return j.removeOldFiles(paths.UploadsDir(j.bundleDir), j.maxUploadAge, func(path string, age time.Duration) {
-		log15.Debug("Removed old upload file", "path", path, "age", age)
+	return j.removeOldFiles(storage.UploadsPrefix, j.maxUploadAge, func(key string, age time.Duration) {
+		log15.Debug("Removed old upload file", "key", key, "age", age)
 		j.metrics.UploadFilesRemoved.Inc()
 	})
 }
@@ -24,8 +23,8 @@ func (j *Janitor) removeOldUploadFiles() error {
 // removeOldUploadPartFiles removes all upload part files that are older than the configured max
 // upload part age. These files are left on disk if an upload does not complete within a CI run.
 func (j *Janitor) removeOldUploadPartFiles() error {
-	return j.removeOldFiles(paths.UploadPartsDir(j.bundleDir), j.maxUploadPartAge, func(path string, age time.Duration) {
-		log15.Debug("Removed old upload part file", "path", path, "age", age)
+	return j.removeOldFiles(storage.UploadPartsPrefix, j.maxUploadPartAge, func(key string, age time.Duration) {
+		log15.Debug("Removed old upload part file", "key", key, "age", age)
 		j.metrics.PartFilesRemoved.Inc()
 	})
 }
@@ -34,28 +33,28 @@ func (j *Janitor) removeOldUploadPartFiles() error {
 // max database part age. These files are left on disk if a worker does not successfully complete
 // all requests of a SendDB command.
 func (j *Janitor) removeOldDatabasePartFiles() error {
-	return j.removeOldFiles(paths.DBPartsDir(j.bundleDir), j.maxDatabasePartAge, func(path string, age time.Duration) {
-		log15.Debug("Removed old database part file", "path", path, "age", age)
+	return j.removeOldFiles(storage.DBPartsPrefix, j.maxDatabasePartAge, func(key string, age time.Duration) {
+		log15.Debug("Removed old database part file", "key", key, "age", age)
 		j.metrics.PartFilesRemoved.Inc()
 	})
 }
 
-// removeOldFiles removes all part files within the given directrory that are older than the given
-// age. The onRemove function is called when a file or directory is successfully unlinked.
-func (j *Janitor) removeOldFiles(root string, maxAge time.Duration, onRemove func(path string, age time.Duration)) error {
-	fileInfos, err := ioutil.ReadDir(root)
+// removeOldFiles removes all objects with the given key prefix that are older than the given
+// age. The onRemove function is called when an object is successfully removed.
+func (j *Janitor) removeOldFiles(prefix string, maxAge time.Duration, onRemove func(key string, age time.Duration)) error {
+	objects, err := j.storage.List(context.Background(), prefix)
 	if err != nil {
 		return err
 	}
 
-	for _, fileInfo := range fileInfos {
-		age := time.Since(fileInfo.ModTime())
+	for _, object := range objects {
+		age := time.Since(object.LastModified)
 		if age <= maxAge {
 			continue
 		}
 
-		if path := filepath.Join(root, fileInfo.Name()); j.remove(path) {
-			onRemove(path, age)
+		if j.removeObject(object.Key) {
+			onRemove(object.Key, age)
 		}
 	}
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 )
 
@@ -27,6 +28,7 @@ func TestRemoveOldUploadFiles(t *testing.T) {
 
 	j := &Janitor{
 		bundleDir:    bundleDir,
+		storage:      storage.NewLocalStore(bundleDir),
 		maxUploadAge: time.Minute,
 		metrics:      NewJanitorMetrics(metrics.TestRegisterer),
 	}
@@ -64,6 +66,7 @@ func TestRemoveOldUploadPartFiles(t *testing.T) {
 
 	j := &Janitor{
 		bundleDir:        bundleDir,
+		storage:          storage.NewLocalStore(bundleDir),
 		maxUploadPartAge: time.Minute,
 		metrics:          NewJanitorMetrics(metrics.TestRegisterer),
 	}
@@ -101,6 +104,7 @@ func TestRemoveOldDatabasePartFiles(t *testing.T) {
 
 	j := &Janitor{
 		bundleDir:          bundleDir,
+		storage:            storage.NewLocalStore(bundleDir),
 		maxDatabasePartAge: time.Minute,
 		metrics:            NewJanitorMetrics(metrics.TestRegisterer),
 	}
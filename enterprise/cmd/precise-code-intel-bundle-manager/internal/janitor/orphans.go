@@ -3,7 +3,7 @@ package janitor
 import (
 	"context"
 	"io/ioutil"
-	"path/filepath"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +11,7 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/paths"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 )
 
 // GetStateBatchSize is the maximum number of bundle ids to request the state of from the
@@ -22,40 +23,44 @@ const GetStateBatchSize = 100
 // have committed by the time this clenaup function runs.
 const MinimumUploadAge = time.Minute
 
-// removeOrphanedUploadFiles removes any upload file on disk that is associated with an
+// removeOrphanedUploadFiles removes any upload file in storage that is associated with an
 // errored (or missing) entry in the database.
 func (j *Janitor) removeOrphanedUploadFiles() error {
-	pathsByID, err := j.uploadPathsByID()
+	keysByID, err := j.uploadKeysByID()
 	if err != nil {
 		return err
 	}
 
-	return j.removeOrphans(pathsByID, func(id int, path string) {
-		log15.Debug("Removed orphaned upload file", "id", id, "path", path)
+	return j.removeOrphans(keysByID, j.removeObject, func(id int, key string) {
+		log15.Debug("Removed orphaned upload file", "id", id, "key", key)
 		j.metrics.OrphanedFilesRemoved.Inc()
 	})
 }
 
-// removeOrphanedUploadFiles removes any bundle file on disk that is associated with an
-// errored (or missing) entry in the database.
+// removeOrphanedBundleFiles removes any bundle file in storage or cached on disk that is
+// associated with an errored (or missing) entry in the database.
 func (j *Janitor) removeOrphanedBundleFiles() error {
-	pathsByID, err := j.databasePathsByID()
+	keysByID, err := j.databaseKeysByID()
 	if err != nil {
 		return err
 	}
 
-	return j.removeOrphans(pathsByID, func(id int, path string) {
-		log15.Debug("Removed orphaned bundle file", "id", id, "path", path)
+	removeDatabase := func(key string) bool {
+		return j.removeDatabase(databaseIDFromKey(key))
+	}
+
+	return j.removeOrphans(keysByID, removeDatabase, func(id int, key string) {
+		log15.Debug("Removed orphaned bundle file", "id", id, "key", key)
 		j.metrics.OrphanedFilesRemoved.Inc()
 	})
 }
 
-// removeOrphans removes files from the given mapping if the upload identifier matches an
-// errored (or missing) entry in the database. The onRemove function is called when a file
-// or directory is successfully unlinked.
-func (j *Janitor) removeOrphans(pathsByID map[int]string, onRemove func(id int, path string)) error {
+// removeOrphans removes objects from the given mapping if the upload identifier matches an
+// errored (or missing) entry in the database. The onRemove function is called when an object
+// is successfully removed.
+func (j *Janitor) removeOrphans(keysByID map[int]string, remove func(key string) bool, onRemove func(id int, key string)) error {
 	var ids []int
-	for id := range pathsByID {
+	for id := range keysByID {
 		ids = append(ids, id)
 	}
 
@@ -71,10 +76,10 @@ func (j *Janitor) removeOrphans(pathsByID map[int]string, onRemove func(id int,
 		}
 	}
 
-	for id, path := range pathsByID {
+	for id, key := range keysByID {
 		if state, exists := states[id]; !exists || state == "errored" {
-			if j.remove(path) {
-				onRemove(id, path)
+			if remove(key) {
+				onRemove(id, key)
 			}
 		}
 	}
@@ -82,40 +87,82 @@ func (j *Janitor) removeOrphans(pathsByID map[int]string, onRemove func(id int,
 	return nil
 }
 
-// uploadPathsByID returns map of bundle ids to their upload file on disk.
-func (j *Janitor) uploadPathsByID() (map[int]string, error) {
-	fileInfos, err := ioutil.ReadDir(paths.UploadsDir(j.bundleDir))
+// uploadKeysByID returns map of bundle ids to the key of their upload file in storage.
+func (j *Janitor) uploadKeysByID() (map[int]string, error) {
+	objects, err := j.storage.List(context.Background(), storage.UploadsPrefix)
 	if err != nil {
 		return nil, err
 	}
 
-	pathsByID := map[int]string{}
-	for _, fileInfo := range fileInfos {
-		if age := time.Since(fileInfo.ModTime()); age <= MinimumUploadAge {
+	keysByID := map[int]string{}
+	for _, object := range objects {
+		if age := time.Since(object.LastModified); age <= MinimumUploadAge {
 			continue
 		}
 
-		if id, err := strconv.Atoi(strings.Split(fileInfo.Name(), ".")[0]); err == nil {
-			pathsByID[id] = filepath.Join(paths.UploadsDir(j.bundleDir), fileInfo.Name())
+		if id, err := strconv.Atoi(strings.Split(path.Base(object.Key), ".")[0]); err == nil {
+			keysByID[id] = object.Key
+		}
+	}
+
+	return keysByID, nil
+}
+
+// databaseKeysByID returns map of bundle ids to the key of their database in storage. This
+// also includes the databases that are cached on local disk but no longer exist in storage.
+func (j *Janitor) databaseKeysByID() (map[int]string, error) {
+	objects, err := j.storage.List(context.Background(), storage.DBsPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	keysByID := map[int]string{}
+	for _, object := range objects {
+		if id := databaseIDFromKey(object.Key); id != 0 {
+			keysByID[id] = storage.DBKey(int64(id))
 		}
 	}
 
-	return pathsByID, nil
+	ids, err := cachedDatabaseIDs(j.bundleDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		keysByID[id] = storage.DBKey(int64(id))
+	}
+
+	return keysByID, nil
 }
 
-// databasePathsByID returns map of bundle ids to their path on disk.
-func (j *Janitor) databasePathsByID() (map[int]string, error) {
-	fileInfos, err := ioutil.ReadDir(paths.DBsDir(j.bundleDir))
+// databaseIDFromKey returns the bundle id of the database object with the given key. If the
+// key does not belong to a database, this function returns zero.
+func databaseIDFromKey(key string) int {
+	parts := strings.Split(strings.TrimPrefix(key, storage.DBsPrefix), "/")
+	if !strings.HasPrefix(key, storage.DBsPrefix) || len(parts) < 2 {
+		return 0
+	}
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
+
+// cachedDatabaseIDs returns the bundle ids of the databases on local disk.
+func cachedDatabaseIDs(bundleDir string) ([]int, error) {
+	fileInfos, err := ioutil.ReadDir(paths.DBsDir(bundleDir))
 	if err != nil {
 		return nil, err
 	}
 
-	pathsByID := map[int]string{}
+	var ids []int
 	for _, fileInfo := range fileInfos {
 		if id, err := strconv.Atoi(fileInfo.Name()); err == nil {
-			pathsByID[id] = filepath.Join(paths.DBsDir(j.bundleDir), fileInfo.Name())
+			ids = append(ids, id)
 		}
 	}
 
-	return pathsByID, nil
+	return ids, nil
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 )
@@ -47,6 +48,7 @@ func TestRemoveOrphanedUploadFile(t *testing.T) {
 	j := &Janitor{
 		store:     mockStore,
 		bundleDir: bundleDir,
+		storage:   storage.NewLocalStore(bundleDir),
 		metrics:   NewJanitorMetrics(metrics.TestRegisterer),
 	}
 
@@ -103,6 +105,7 @@ func TestRemoveOrphanedBundleFile(t *testing.T) {
 	j := &Janitor{
 		store:     mockStore,
 		bundleDir: bundleDir,
+		storage:   storage.NewLocalStore(bundleDir),
 		metrics:   NewJanitorMetrics(metrics.TestRegisterer),
 	}
 
@@ -159,6 +162,7 @@ func TestRemoveOrphanedBundleFilesMaxRequestBatchSize(t *testing.T) {
 	j := &Janitor{
 		store:     mockStore,
 		bundleDir: bundleDir,
+		storage:   storage.NewLocalStore(bundleDir),
 		metrics:   NewJanitorMetrics(metrics.TestRegisterer),
 	}
 
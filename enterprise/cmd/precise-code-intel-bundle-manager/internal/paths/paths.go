@@ -11,6 +11,7 @@ const uploadPartsDir = "upload-parts"
 const dbsDir = "dbs"
 const dbPartsDir = "db-parts"
 const migrationMarkersDir = "migration-markers"
+const tempDir = "tmp"
 
 // PrepDirectories creates the root directories within the given bundle dir.
 func PrepDirectories(bundleDir string) error {
@@ -20,6 +21,7 @@ func PrepDirectories(bundleDir string) error {
 		dbsDir,
 		dbPartsDir,
 		migrationMarkersDir,
+		tempDir,
 	}
 
 	for _, dir := range rootDirs {
@@ -76,6 +78,12 @@ func DBPartFilename(bundleDir string, id, index int64) string {
 	return filepath.Join(bundleDir, dbPartsDir, fmt.Sprintf("%d.%d.gz", id, index))
 }
 
+// TempDir returns the path of the directory containing files that are being written or
+// extracted and are not yet visible to readers.
+func TempDir(bundleDir string) string {
+	return filepath.Join(bundleDir, tempDir)
+}
+
 // MigrationMarkerFilename returns the path to the file that marks a migration has been performed.
 func MigrationMarkerFilename(bundleDir string, version int) string {
 	return filepath.Join(bundleDir, migrationMarkersDir, fmt.Sprintf("v%d", version))
@@ -0,0 +1,37 @@
+package readers
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/paths"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
+	sqlitereader "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite"
+)
+
+// NewFetcher returns a function that downloads a SQLite database from the given store into the
+// bundle directory when it does not exist locally. Databases fetched this way remain on local disk
+// until they are evicted by the janitor, so recently used databases are served without a round-trip
+// to the store.
+func NewFetcher(bundleDir string, store storage.Store) sqlitereader.FetchFunc {
+	return func(ctx context.Context, filename string) (bool, error) {
+		id, err := strconv.ParseInt(filepath.Base(filepath.Dir(filename)), 10, 64)
+		if err != nil || filename != paths.SQLiteDBFilename(bundleDir, id) {
+			return false, nil
+		}
+
+		log15.Debug("Fetching database from storage", "id", id)
+
+		if err := storage.Download(ctx, store, storage.DBKey(id), filename, paths.TempDir(bundleDir)); err != nil {
+			if err == storage.ErrNotFound {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return true, nil
+	}
+}
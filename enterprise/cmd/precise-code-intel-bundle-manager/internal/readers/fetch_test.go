@@ -0,0 +1,59 @@
+package readers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/paths"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
+)
+
+func TestFetcher(t *testing.T) {
+	bundleDir := testBundleDir(t)
+	storeDir := testBundleDir(t)
+
+	store := storage.NewLocalStore(storeDir)
+	if err := store.Upload(context.Background(), storage.DBKey(42), strings.NewReader("database content")); err != nil {
+		t.Fatalf("unexpected error uploading database: %s", err)
+	}
+
+	fetch := NewFetcher(bundleDir, store)
+
+	filename := paths.SQLiteDBFilename(bundleDir, 42)
+	if fetched, err := fetch(context.Background(), filename); err != nil {
+		t.Fatalf("unexpected error fetching database: %s", err)
+	} else if !fetched {
+		t.Fatalf("expected database to be fetched")
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unexpected error reading database: %s", err)
+	}
+	if string(content) != "database content" {
+		t.Errorf("unexpected content. want=%q have=%q", "database content", content)
+	}
+
+	if fetched, err := fetch(context.Background(), paths.SQLiteDBFilename(bundleDir, 43)); err != nil {
+		t.Fatalf("unexpected error fetching database: %s", err)
+	} else if fetched {
+		t.Errorf("expected unknown database not to be fetched")
+	}
+}
+
+func testBundleDir(t *testing.T) string {
+	bundleDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error creating temp dir: %s", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(bundleDir) })
+
+	if err := paths.PrepDirectories(bundleDir); err != nil {
+		t.Fatalf("unexpected error preparing directories: %s", err)
+	}
+
+	return bundleDir
+}
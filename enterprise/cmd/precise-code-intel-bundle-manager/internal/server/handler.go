@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	"github.com/gorilla/mux"
 	"github.com/hashicorp/go-multierror"
@@ -14,9 +16,9 @@ import (
 	"github.com/mxk/go-flowrate/flowrate"
 	"github.com/opentracing/opentracing-go/ext"
 	pkgerrors "github.com/pkg/errors"
-	"github.com/sourcegraph/codeintelutils"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/database"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/paths"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence"
 	sqlitereader "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite"
 	"github.com/sourcegraph/sourcegraph/internal/tar"
@@ -25,6 +27,7 @@ import (
 
 const DefaultMonikerResultPageSize = 100
 const DefaultDiagnosticResultPageSize = 100
+const DefaultSymbolResultPageSize = 100
 
 func (s *Server) handler() http.Handler {
 	mux := mux.NewRouter()
@@ -38,8 +41,11 @@ func (s *Server) handler() http.Handler {
 	mux.Path("/dbs/{id:[0-9]+}/exists").Methods("GET").HandlerFunc(s.handleExists)
 	mux.Path("/dbs/{id:[0-9]+}/definitions").Methods("GET").HandlerFunc(s.handleDefinitions)
 	mux.Path("/dbs/{id:[0-9]+}/references").Methods("GET").HandlerFunc(s.handleReferences)
+	mux.Path("/dbs/{id:[0-9]+}/implementations").Methods("GET").HandlerFunc(s.handleImplementations)
 	mux.Path("/dbs/{id:[0-9]+}/hover").Methods("GET").HandlerFunc(s.handleHover)
 	mux.Path("/dbs/{id:[0-9]+}/diagnostics").Methods("GET").HandlerFunc(s.handleDiagnostics)
+	mux.Path("/dbs/{id:[0-9]+}/symbols").Methods("GET").HandlerFunc(s.handleSymbols)
+	mux.Path("/dbs/{id:[0-9]+}/searchSymbols").Methods("GET").HandlerFunc(s.handleSearchSymbols)
 	mux.Path("/dbs/{id:[0-9]+}/monikersByPosition").Methods("GET").HandlerFunc(s.handleMonikersByPosition)
 	mux.Path("/dbs/{id:[0-9]+}/monikerResults").Methods("GET").HandlerFunc(s.handleMonikerResults)
 	mux.Path("/dbs/{id:[0-9]+}/packageInformation").Methods("GET").HandlerFunc(s.handlePackageInformation)
@@ -51,52 +57,47 @@ func (s *Server) handler() http.Handler {
 
 // GET /uploads/{id:[0-9]+}
 func (s *Server) handleGetUpload(w http.ResponseWriter, r *http.Request) {
-	file, err := os.Open(paths.UploadFilename(s.bundleDir, idFromRequest(r)))
-	if err != nil {
-		http.Error(w, "Upload not found.", http.StatusNotFound)
-		return
-	}
-	defer file.Close()
-
 	// If there was a transient error while the worker was trying to access the upload
 	// file, it retries but indicates the number of bytes that it has received. We can
 	// fast-forward the file to this position and only give the worker the data that it
 	// still needs. This technique saves us from having to pre-chunk the file as we must
 	// do in the reverse direction.
-	if _, err := file.Seek(int64(getQueryInt(r, "seek")), io.SeekStart); err != nil {
-		log15.Error("Failed to seek upload file", "err", err)
+	rc, err := s.storage.Get(r.Context(), storage.UploadKey(idFromRequest(r)), int64(getQueryInt(r, "seek")))
+	if err != nil {
+		if err == storage.ErrNotFound {
+			http.Error(w, "Upload not found.", http.StatusNotFound)
+			return
+		}
+
+		log15.Error("Failed to read upload file", "err", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	defer rc.Close()
 
-	if _, err := io.Copy(limitTransferRate(w), file); err != nil {
+	if _, err := io.Copy(limitTransferRate(w), rc); err != nil {
 		log15.Error("Failed to write payload to client", "err", err)
 	}
 }
 
 // POST /uploads/{id:[0-9]+}
 func (s *Server) handlePostUpload(w http.ResponseWriter, r *http.Request) {
-	_ = s.doUpload(w, r, paths.UploadFilename)
+	_ = s.doUpload(w, r, storage.UploadKey(idFromRequest(r)))
 }
 
 // POST /uploads/{id:[0-9]+}/{index:[0-9]+}
 func (s *Server) handlePostUploadPart(w http.ResponseWriter, r *http.Request) {
-	makeFilename := func(bundleDir string, id int64) string {
-		return paths.UploadPartFilename(bundleDir, id, indexFromRequest(r))
-	}
-
-	_ = s.doUpload(w, r, makeFilename)
+	_ = s.doUpload(w, r, storage.UploadPartKey(idFromRequest(r), indexFromRequest(r)))
 }
 
 // POST /uploads/{id:[0-9]+}/stitch
 func (s *Server) handlePostUploadStitch(w http.ResponseWriter, r *http.Request) {
 	id := idFromRequest(r)
-	filename := paths.UploadFilename(s.bundleDir, id)
-	makePartFilename := func(index int) string {
-		return paths.UploadPartFilename(s.bundleDir, id, int64(index))
+	makePartKey := func(index int) string {
+		return storage.UploadPartKey(id, int64(index))
 	}
 
-	if err := codeintelutils.StitchFiles(filename, makePartFilename, true); err != nil {
+	if err := storage.Stitch(r.Context(), s.storage, storage.UploadKey(id), makePartKey, true); err != nil {
 		log15.Error("Failed to stitch multipart upload", "err", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -110,30 +111,25 @@ func (s *Server) handleDeleteUpload(w http.ResponseWriter, r *http.Request) {
 
 // POST /dbs/{id:[0-9]+}/{index:[0-9]+}
 func (s *Server) handlePostDatabasePart(w http.ResponseWriter, r *http.Request) {
-	makeFilename := func(bundleDir string, id int64) string {
-		return paths.DBPartFilename(bundleDir, id, indexFromRequest(r))
-	}
-
-	_ = s.doUpload(w, r, makeFilename)
+	_ = s.doUpload(w, r, storage.DBPartKey(idFromRequest(r), indexFromRequest(r)))
 }
 
 // POST /dbs/{id:[0-9]+}/stitch
 func (s *Server) handlePostDatabaseStitch(w http.ResponseWriter, r *http.Request) {
 	id := idFromRequest(r)
-	dirname := paths.DBDir(s.bundleDir, id)
-	makePartFilename := func(index int) string {
-		return paths.DBPartFilename(s.bundleDir, id, int64(index))
+	makePartKey := func(index int) string {
+		return storage.DBPartKey(id, int64(index))
 	}
 
-	stitchedReader, err := codeintelutils.StitchFilesReader(makePartFilename, false)
+	stitchedReader, err := storage.StitchReader(r.Context(), s.storage, makePartKey, false)
 	if err != nil {
 		log15.Error("Failed to stitch multipart database", "err", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	if err := tar.Extract(dirname, stitchedReader); err != nil {
-		log15.Error("Failed to extract database archive", "err", err)
+	if err := s.storeDatabaseArchive(r.Context(), id, stitchedReader); err != nil {
+		log15.Error("Failed to store database archive", "err", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -175,6 +171,17 @@ func (s *Server) handleReferences(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GET /dbs/{id:[0-9]+}/implementations
+func (s *Server) handleImplementations(w http.ResponseWriter, r *http.Request) {
+	s.dbQuery(w, r, func(ctx context.Context, db database.Database) (interface{}, error) {
+		implementations, err := db.Implementations(ctx, getQuery(r, "path"), getQueryInt(r, "line"), getQueryInt(r, "character"))
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "db.Implementations")
+		}
+		return implementations, nil
+	})
+}
+
 // GET /dbs/{id:[0-9]+}/hover
 func (s *Server) handleHover(w http.ResponseWriter, r *http.Request) {
 	s.dbQuery(w, r, func(ctx context.Context, db database.Database) (interface{}, error) {
@@ -212,6 +219,39 @@ func (s *Server) handleDiagnostics(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GET /dbs/{id:[0-9]+}/symbols
+func (s *Server) handleSymbols(w http.ResponseWriter, r *http.Request) {
+	s.dbQuery(w, r, func(ctx context.Context, db database.Database) (interface{}, error) {
+		symbols, err := db.Symbols(ctx, getQuery(r, "path"))
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "db.Symbols")
+		}
+		return symbols, nil
+	})
+}
+
+// GET /dbs/{id:[0-9]+}/searchSymbols
+func (s *Server) handleSearchSymbols(w http.ResponseWriter, r *http.Request) {
+	s.dbQuery(w, r, func(ctx context.Context, db database.Database) (interface{}, error) {
+		skip := getQueryInt(r, "skip")
+		if skip < 0 {
+			return nil, errors.New("illegal skip supplied")
+		}
+
+		take := getQueryIntDefault(r, "take", DefaultSymbolResultPageSize)
+		if take <= 0 {
+			return nil, errors.New("illegal take supplied")
+		}
+
+		symbols, count, err := db.SearchSymbols(ctx, getQuery(r, "query"), skip, take)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "db.SearchSymbols")
+		}
+
+		return map[string]interface{}{"symbols": symbols, "count": count}, nil
+	})
+}
+
 // GET /dbs/{id:[0-9]+}/monikersByPosition
 func (s *Server) handleMonikersByPosition(w http.ResponseWriter, r *http.Request) {
 	s.dbQuery(w, r, func(ctx context.Context, db database.Database) (interface{}, error) {
@@ -232,6 +272,8 @@ func (s *Server) handleMonikerResults(w http.ResponseWriter, r *http.Request) {
 			tableName = "definitions"
 		case "reference":
 			tableName = "references"
+		case "implementation":
+			tableName = "implementations"
 		default:
 			return nil, errors.New("illegal tableName supplied")
 		}
@@ -281,10 +323,9 @@ func (s *Server) handlePackageInformation(w http.ResponseWriter, r *http.Request
 	})
 }
 
-// doUpload writes the HTTP request body to the path determined by the given
-// makeFilename function.
-func (s *Server) doUpload(w http.ResponseWriter, r *http.Request, makeFilename func(bundleDir string, id int64) string) bool {
-	if err := writeToFile(makeFilename(s.bundleDir, idFromRequest(r)), r.Body); err != nil {
+// doUpload writes the HTTP request body to the object with the given key.
+func (s *Server) doUpload(w http.ResponseWriter, r *http.Request, key string) bool {
+	if err := s.storage.Upload(r.Context(), key, r.Body); err != nil {
 		log15.Error("Failed to write payload", "err", err)
 		http.Error(w, fmt.Sprintf("failed to write payload: %s", err.Error()), http.StatusInternalServerError)
 		return false
@@ -293,26 +334,34 @@ func (s *Server) doUpload(w http.ResponseWriter, r *http.Request, makeFilename f
 	return true
 }
 
-func writeToFile(filename string, r io.Reader) (err error) {
-	targetFile, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE, 0666)
+// storeDatabaseArchive extracts the SQLite database from the given archive into a temporary
+// directory and writes it to the object store under the key of the given bundle.
+func (s *Server) storeDatabaseArchive(ctx context.Context, id int64, r io.Reader) (err error) {
+	tempDir, err := ioutil.TempDir(paths.TempDir(s.bundleDir), "db-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := tar.Extract(tempDir, r); err != nil {
+		return pkgerrors.Wrap(err, "tar.Extract")
+	}
+
+	file, err := os.Open(filepath.Join(tempDir, filepath.Base(paths.SQLiteDBFilename(s.bundleDir, id))))
 	if err != nil {
 		return err
 	}
 	defer func() {
-		if closeErr := targetFile.Close(); closeErr != nil {
+		if closeErr := file.Close(); closeErr != nil {
 			err = multierror.Append(err, closeErr)
 		}
 	}()
 
-	if _, err := io.Copy(targetFile, r); err != nil {
-		return err
-	}
-
-	return nil
+	return s.storage.Upload(ctx, storage.DBKey(id), file)
 }
 
 func (s *Server) deleteUpload(w http.ResponseWriter, r *http.Request) {
-	if err := os.Remove(paths.UploadFilename(s.bundleDir, idFromRequest(r))); err != nil {
+	if err := s.storage.Delete(r.Context(), storage.UploadKey(idFromRequest(r))); err != nil {
 		log15.Warn("Failed to delete upload file", "err", err)
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/cache"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -19,6 +20,7 @@ const Port = 3187
 
 type Server struct {
 	bundleDir          string
+	storage            storage.Store
 	readerCache        cache.ReaderCache
 	observationContext *observation.Context
 	server             *http.Server
@@ -27,6 +29,7 @@ type Server struct {
 
 func New(
 	bundleDir string,
+	storage storage.Store,
 	readerCache cache.ReaderCache,
 	observationContext *observation.Context,
 ) *Server {
@@ -37,6 +40,7 @@ func New(
 
 	s := &Server{
 		bundleDir:          bundleDir,
+		storage:            storage,
 		readerCache:        readerCache,
 		observationContext: observationContext,
 	}
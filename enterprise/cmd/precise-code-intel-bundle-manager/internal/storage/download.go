@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Download writes the content of the object with the given key to the given filename. The content
+// is written to a temporary file within tempDir, which is moved into place once complete so that
+// concurrent readers never observe a partially written file. The temporary directory must be on
+// the same device as the target file. If the object does not exist, ErrNotFound is returned.
+func Download(ctx context.Context, store Store, key, filename, tempDir string) (err error) {
+	rc, err := store.Get(ctx, key, 0)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err := os.MkdirAll(filepath.Dir(filename), os.ModePerm); err != nil {
+		return err
+	}
+
+	tempFile, err := ioutil.TempFile(tempDir, "download-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tempFile.Name())
+		}
+	}()
+
+	if _, err := io.Copy(tempFile, rc); err != nil {
+		if closeErr := tempFile.Close(); closeErr != nil {
+			err = multierror.Append(err, closeErr)
+		}
+		return err
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), filename)
+}
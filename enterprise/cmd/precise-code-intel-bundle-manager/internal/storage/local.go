@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// TempPrefix is the prefix of keys reserved for partially written objects in a local store.
+const TempPrefix = "tmp/"
+
+// LocalStore is a Store backed by a directory on the local filesystem. The key of an object is
+// its path relative to the root directory.
+type LocalStore struct {
+	root string
+}
+
+var _ Store = &LocalStore{}
+
+// NewLocalStore creates a new store rooted at the given directory.
+func NewLocalStore(root string) *LocalStore {
+	return &LocalStore{root: root}
+}
+
+// Get returns a reader for the content of the object with the given key.
+func (s *LocalStore) Get(ctx context.Context, key string, skip int64) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if _, err := file.Seek(skip, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Upload writes the content of the given reader to the object with the given key. The content
+// is written to a temporary file which is moved into place once complete so that concurrent
+// readers never observe a partially written object.
+func (s *LocalStore) Upload(ctx context.Context, key string, r io.Reader) (err error) {
+	filename := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(filename), os.ModePerm); err != nil {
+		return err
+	}
+
+	tempDir := s.path(TempPrefix)
+	if err := os.MkdirAll(tempDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	tempFile, err := ioutil.TempFile(tempDir, "upload-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tempFile.Name())
+		}
+	}()
+
+	if _, err := io.Copy(tempFile, r); err != nil {
+		if closeErr := tempFile.Close(); closeErr != nil {
+			err = multierror.Append(err, closeErr)
+		}
+		return err
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), filename)
+}
+
+// Delete removes the object with the given key. Any directories emptied by the removal are
+// also removed, with the exception of the top-level directories of the store.
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	filename := s.path(key)
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	root := filepath.Clean(s.root)
+	for dir := filepath.Dir(filename); dir != root && filepath.Dir(dir) != root; dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+// List returns all objects whose key begins with the given prefix.
+func (s *LocalStore) List(ctx context.Context, prefix string) ([]Object, error) {
+	dir := path.Dir(prefix)
+	if strings.HasSuffix(prefix, "/") {
+		dir = strings.TrimSuffix(prefix, "/")
+	}
+
+	var objects []Object
+	if err := filepath.Walk(s.path(dir), func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, filename)
+		if err != nil {
+			return err
+		}
+
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			objects = append(objects, Object{Key: key, LastModified: info.ModTime()})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// path returns the path on disk of the object with the given key.
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
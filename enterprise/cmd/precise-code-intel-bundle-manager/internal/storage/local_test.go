@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLocalStore(t *testing.T) {
+	root := testRoot(t)
+	testStore(t, NewLocalStore(root))
+
+	// Ensure emptied directories are removed along with objects
+	if _, err := os.Stat(filepath.Join(root, "dbs", "42")); !os.IsNotExist(err) {
+		t.Errorf("expected db directory to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(root, "dbs")); err != nil {
+		t.Errorf("expected top-level directory to be retained: %s", err)
+	}
+}
+
+func TestLocalStoreGetUnknownKey(t *testing.T) {
+	if _, err := NewLocalStore(testRoot(t)).Get(context.Background(), UploadKey(42), 0); err != ErrNotFound {
+		t.Errorf("unexpected error. want=%q have=%q", ErrNotFound, err)
+	}
+}
+
+// testStore exercises the basic operations of the given store.
+func testStore(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	for key, content := range map[string]string{
+		UploadKey(42):        "upload content",
+		UploadPartKey(42, 0): "part content",
+		DBKey(42):            "database content",
+		DBKey(43):            "other database content",
+	} {
+		if err := store.Upload(ctx, key, strings.NewReader(content)); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	rc, err := store.Get(ctx, UploadKey(42), 7)
+	if err != nil {
+		t.Fatalf("unexpected error getting object: %s", err)
+	}
+	content, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+	if string(content) != "content" {
+		t.Errorf("unexpected content. want=%q have=%q", "content", content)
+	}
+
+	objects, err := store.List(ctx, DBsPrefix)
+	if err != nil {
+		t.Fatalf("unexpected error listing objects: %s", err)
+	}
+	if diff := cmp.Diff([]string{DBKey(42), DBKey(43)}, keys(objects)); diff != "" {
+		t.Errorf("unexpected keys (-want +got):\n%s", diff)
+	}
+
+	if err := store.Delete(ctx, DBKey(42)); err != nil {
+		t.Fatalf("unexpected error deleting object: %s", err)
+	}
+	if err := store.Delete(ctx, DBKey(44)); err != nil {
+		t.Fatalf("unexpected error deleting missing object: %s", err)
+	}
+	if _, err := store.Get(ctx, DBKey(42), 0); err != ErrNotFound {
+		t.Errorf("unexpected error. want=%q have=%q", ErrNotFound, err)
+	}
+
+	objects, err = store.List(ctx, DBKey(43))
+	if err != nil {
+		t.Fatalf("unexpected error listing objects: %s", err)
+	}
+	if diff := cmp.Diff([]string{DBKey(43)}, keys(objects)); diff != "" {
+		t.Errorf("unexpected keys (-want +got):\n%s", diff)
+	}
+}
+
+func testRoot(t *testing.T) string {
+	root, err := ioutil.TempDir("", "precise-code-intel-bundle-manager-")
+	if err != nil {
+		t.Fatalf("unexpected error creating test directory: %s", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(root) })
+
+	for _, dir := range []string{"uploads", "upload-parts", "dbs", "db-parts"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), os.ModePerm); err != nil {
+			t.Fatalf("unexpected error creating test directory: %s", err)
+		}
+	}
+
+	return root
+}
+
+func keys(objects []Object) []string {
+	var keys []string
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(testRoot(t))
+	if err := store.Upload(ctx, DBKey(42), strings.NewReader("database content")); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+
+	cacheDir := testRoot(t)
+	filename := filepath.Join(cacheDir, "dbs", "42", "sqlite.db")
+	if err := Download(ctx, store, DBKey(42), filename, cacheDir); err != nil {
+		t.Fatalf("unexpected error downloading object: %s", err)
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unexpected error reading file: %s", err)
+	}
+	if string(content) != "database content" {
+		t.Errorf("unexpected content. want=%q have=%q", "database content", content)
+	}
+
+	if err := Download(ctx, store, DBKey(43), filepath.Join(cacheDir, "dbs", "43", "sqlite.db"), cacheDir); err != ErrNotFound {
+		t.Errorf("unexpected error. want=%q have=%q", ErrNotFound, err)
+	}
+}
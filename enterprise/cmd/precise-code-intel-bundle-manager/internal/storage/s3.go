@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/aws/defaults"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/s3manager"
+)
+
+// S3Config configures the connection to an S3-compatible object store.
+type S3Config struct {
+	// Endpoint is the URL of the object store. If empty, the AWS endpoint for the
+	// configured region is used.
+	Endpoint string
+
+	// Bucket is the name of the bucket holding all objects.
+	Bucket string
+
+	// Region is the region of the bucket.
+	Region string
+
+	// AccessKeyID and SecretAccessKey are the static credentials used to sign requests.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// ForcePathStyle addresses the bucket as a path segment of the endpoint rather than as
+	// a subdomain. This is generally required by self-hosted S3-compatible stores.
+	ForcePathStyle bool
+}
+
+// S3Store is a Store backed by a bucket in an S3-compatible object store.
+type S3Store struct {
+	bucket   string
+	client   *s3.Client
+	uploader *s3manager.Uploader
+}
+
+var _ Store = &S3Store{}
+
+// NewS3Store creates a new store backed by the bucket described by the given config.
+func NewS3Store(config S3Config) *S3Store {
+	awsConfig := defaults.Config()
+	awsConfig.Region = config.Region
+	awsConfig.Credentials = aws.StaticCredentialsProvider{
+		Value: aws.Credentials{
+			AccessKeyID:     config.AccessKeyID,
+			SecretAccessKey: config.SecretAccessKey,
+			Source:          "precise-code-intel-bundle-manager",
+		},
+	}
+	if config.Endpoint != "" {
+		awsConfig.EndpointResolver = aws.ResolveWithEndpointURL(config.Endpoint)
+	}
+
+	client := s3.New(awsConfig)
+	client.ForcePathStyle = config.ForcePathStyle
+
+	return &S3Store{
+		bucket:   config.Bucket,
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+	}
+}
+
+// Get returns a reader for the content of the object with the given key.
+func (s *S3Store) Get(ctx context.Context, key string, skip int64) (io.ReadCloser, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+	if skip > 0 {
+		input.Range = aws.String(fmt.Sprintf("bytes=%d-", skip))
+	}
+
+	resp, err := s.client.GetObjectRequest(input).Send(ctx)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// Upload writes the content of the given reader to the object with the given key. Large
+// objects are sent to the object store in multiple parts.
+func (s *S3Store) Upload(ctx context.Context, key string, r io.Reader) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   r,
+	})
+
+	return err
+}
+
+// Delete removes the object with the given key.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObjectRequest(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}).Send(ctx)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// List returns all objects whose key begins with the given prefix.
+func (s *S3Store) List(ctx context.Context, prefix string) ([]Object, error) {
+	paginator := s3.NewListObjectsV2Paginator(s.client.ListObjectsV2Request(&s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	}))
+
+	var objects []Object
+	for paginator.Next(ctx) {
+		for _, content := range paginator.CurrentPage().Contents {
+			object := Object{Key: aws.StringValue(content.Key)}
+			if content.LastModified != nil {
+				object.LastModified = *content.LastModified
+			}
+
+			objects = append(objects, object)
+		}
+	}
+
+	if err := paginator.Err(); err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// isNotFound returns true if the given error indicates that the requested object does not exist.
+func isNotFound(err error) bool {
+	if requestErr, ok := err.(awserr.RequestFailure); ok && requestErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == s3.ErrCodeNoSuchKey || strings.EqualFold(awsErr.Code(), "NotFound")
+	}
+
+	return false
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestS3Store(t *testing.T) {
+	server := httptest.NewServer(newFakeS3Server("test-bucket"))
+	defer server.Close()
+
+	testStore(t, NewS3Store(S3Config{
+		Endpoint:        server.URL,
+		Bucket:          "test-bucket",
+		Region:          "us-east-1",
+		AccessKeyID:     "access-key",
+		SecretAccessKey: "secret-key",
+		ForcePathStyle:  true,
+	}))
+}
+
+// fakeS3Server is a minimal in-memory stand-in for an S3-compatible object store. It
+// supports path-style object reads (with ranges), writes, deletes, and bucket listings.
+type fakeS3Server struct {
+	bucket  string
+	m       sync.Mutex
+	objects map[string]fakeS3Object
+}
+
+type fakeS3Object struct {
+	content      []byte
+	lastModified time.Time
+}
+
+func newFakeS3Server(bucket string) *fakeS3Server {
+	return &fakeS3Server{bucket: bucket, objects: map[string]fakeS3Object{}}
+}
+
+func (s *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if parts[0] != s.bucket {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	if len(parts) == 1 || parts[1] == "" {
+		s.list(w, r)
+		return
+	}
+	key := parts[1]
+
+	switch r.Method {
+	case "GET":
+		object, ok := s.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+
+		content := object.content
+		if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+			start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			content = content[start:]
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		_, _ = w.Write(content)
+
+	case "PUT":
+		content, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeS3Error(w, http.StatusInternalServerError, "InternalError")
+			return
+		}
+		s.objects[key] = fakeS3Object{content: content, lastModified: time.Now().UTC()}
+
+	case "DELETE":
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (s *fakeS3Server) list(w http.ResponseWriter, r *http.Request) {
+	type content struct {
+		Key          string
+		LastModified string
+	}
+	type listBucketResult struct {
+		XMLName     xml.Name `xml:"ListBucketResult"`
+		IsTruncated bool
+		Contents    []content
+	}
+
+	prefix := r.URL.Query().Get("prefix")
+
+	result := listBucketResult{}
+	for key, object := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			result.Contents = append(result.Contents, content{
+				Key:          key,
+				LastModified: object.lastModified.Format(time.RFC3339),
+			})
+		}
+	}
+	sort.Slice(result.Contents, func(i, j int) bool { return result.Contents[i].Key < result.Contents[j].Key })
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	type errorResponse struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(errorResponse{Code: code, Message: code})
+}
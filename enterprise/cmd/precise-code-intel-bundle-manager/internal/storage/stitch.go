@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+
+	"github.com/sourcegraph/codeintelutils"
+)
+
+// PartKeyFunc constructs the key of a part object from its part index.
+type PartKeyFunc func(index int) string
+
+// Stitch combines multiple compressed part objects into a single object with the given key. The
+// content of each part is decompressed and written to the new object sequentially. If compress is
+// true, the stitched object is compressed. On success, the part objects are removed.
+func Stitch(ctx context.Context, store Store, key string, makePartKey PartKeyFunc, compress bool) error {
+	r, err := StitchReader(ctx, store, makePartKey, compress)
+	if err != nil {
+		return err
+	}
+
+	return store.Upload(ctx, key, r)
+}
+
+// StitchReader combines multiple compressed part objects into a single reader. The content of
+// each part is decompressed and written to the returned reader sequentially. If compress is true,
+// the returned reader is compressed. On success, the part objects are removed.
+func StitchReader(ctx context.Context, store Store, makePartKey PartKeyFunc, compress bool) (io.Reader, error) {
+	pr, pw := io.Pipe()
+
+	go func() {
+		defer pw.Close()
+
+		index := 0
+		for {
+			ok, err := writePart(ctx, store, pw, makePartKey(index))
+			if err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+			if !ok {
+				break
+			}
+
+			index++
+		}
+
+		for i := index - 1; i >= 0; i-- {
+			_ = store.Delete(ctx, makePartKey(i))
+		}
+	}()
+
+	if compress {
+		return codeintelutils.Gzip(pr), nil
+	}
+	return pr, nil
+}
+
+// writePart writes the decompressed content of the part object with the given key to the given
+// writer. Returns a boolean flag indicating whether or not the part object exists.
+func writePart(ctx context.Context, store Store, w io.Writer, key string) (bool, error) {
+	rc, err := store.Get(ctx, key, 0)
+	if err != nil {
+		if err == ErrNotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+	defer rc.Close()
+
+	reader, err := gzip.NewReader(rc)
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(w, reader)
+	return true, err
+}
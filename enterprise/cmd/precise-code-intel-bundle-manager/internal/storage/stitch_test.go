@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"testing"
+)
+
+func TestStitch(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(testRoot(t))
+
+	for index, content := range []string{"foo", "bar", "baz"} {
+		var buf bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buf)
+		_, _ = gzipWriter.Write([]byte(content))
+		gzipWriter.Close()
+
+		if err := store.Upload(ctx, UploadPartKey(42, int64(index)), &buf); err != nil {
+			t.Fatalf("unexpected error uploading part: %s", err)
+		}
+	}
+
+	makePartKey := func(index int) string { return UploadPartKey(42, int64(index)) }
+	if err := Stitch(ctx, store, UploadKey(42), makePartKey, true); err != nil {
+		t.Fatalf("unexpected error stitching parts: %s", err)
+	}
+
+	rc, err := store.Get(ctx, UploadKey(42), 0)
+	if err != nil {
+		t.Fatalf("unexpected error getting stitched object: %s", err)
+	}
+	defer rc.Close()
+
+	gzipReader, err := gzip.NewReader(rc)
+	if err != nil {
+		t.Fatalf("unexpected error decompressing stitched object: %s", err)
+	}
+	content, err := ioutil.ReadAll(gzipReader)
+	if err != nil {
+		t.Fatalf("unexpected error reading stitched object: %s", err)
+	}
+	if string(content) != "foobarbaz" {
+		t.Errorf("unexpected content. want=%q have=%q", "foobarbaz", content)
+	}
+
+	objects, err := store.List(ctx, UploadPartsPrefix)
+	if err != nil {
+		t.Fatalf("unexpected error listing objects: %s", err)
+	}
+	if len(objects) != 0 {
+		t.Errorf("expected part objects to be removed. have=%v", keys(objects))
+	}
+}
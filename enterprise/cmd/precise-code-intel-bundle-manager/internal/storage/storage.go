@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrNotFound occurs when the requested object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Store is a blob store that holds the uploads and converted databases managed by the bundle
+// manager. Objects are addressed by slash-separated keys. Storing objects outside of the local
+// disk of the bundle manager allows multiple replicas to serve the same set of bundles.
+type Store interface {
+	// Get returns a reader for the content of the object with the given key. The first skip
+	// bytes of the object are omitted. If the object does not exist, ErrNotFound is returned.
+	Get(ctx context.Context, key string, skip int64) (io.ReadCloser, error)
+
+	// Upload writes the content of the given reader to the object with the given key. Any
+	// existing object with the same key is replaced.
+	Upload(ctx context.Context, key string, r io.Reader) error
+
+	// Delete removes the object with the given key. Deleting an object that does not exist
+	// is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// List returns all objects whose key begins with the given prefix.
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Object describes an object within a store.
+type Object struct {
+	Key          string
+	LastModified time.Time
+}
+
+const UploadsPrefix = "uploads/"
+const UploadPartsPrefix = "upload-parts/"
+const DBsPrefix = "dbs/"
+const DBPartsPrefix = "db-parts/"
+
+// UploadKey returns the key of the upload with the given identifier.
+func UploadKey(id int64) string {
+	return fmt.Sprintf("%s%d.gz", UploadsPrefix, id)
+}
+
+// UploadPartKey returns the key of the upload with the given identifier and part index.
+func UploadPartKey(id, index int64) string {
+	return fmt.Sprintf("%s%d.%d.gz", UploadPartsPrefix, id, index)
+}
+
+// DBKey returns the key of the SQLite db for the given bundle identifier.
+func DBKey(id int64) string {
+	return fmt.Sprintf("%s%d/sqlite.db", DBsPrefix, id)
+}
+
+// DBPartKey returns the key of the db with the given identifier and part index.
+func DBPartKey(id, index int64) string {
+	return fmt.Sprintf("%s%d.%d.gz", DBPartsPrefix, id, index)
+}
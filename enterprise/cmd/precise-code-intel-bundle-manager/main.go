@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/paths"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/readers"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/server"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/storage"
 	sqlitereader "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -42,7 +43,16 @@ func main() {
 		maxDatabasePartAge  = mustParseInterval(rawMaxDatabasePartAge, "PRECISE_CODE_INTEL_MAX_DATABASE_PART_AGE")
 	)
 
-	readerCache, err := sqlitereader.NewReaderCache(readerDataCacheSize)
+	storage, local := mustInitializeStorage(bundleDir)
+
+	// Databases are read from local disk. If the bundle directory is not the primary storage
+	// location, fetch databases from storage on first use and keep them on disk as a cache.
+	var fetch sqlitereader.FetchFunc
+	if !local {
+		fetch = readers.NewFetcher(bundleDir, storage)
+	}
+
+	readerCache, err := sqlitereader.NewReaderCache(readerDataCacheSize, fetch)
 	if err != nil {
 		log.Fatalf("failed to initialize reader cache: %s", err)
 	}
@@ -51,12 +61,14 @@ func main() {
 		log.Fatalf("failed to prepare directories: %s", err)
 	}
 
-	if err := paths.Migrate(bundleDir); err != nil {
-		log.Fatalf("failed to migrate paths: %s", err)
-	}
+	if local {
+		if err := paths.Migrate(bundleDir); err != nil {
+			log.Fatalf("failed to migrate paths: %s", err)
+		}
 
-	if err := readers.Migrate(bundleDir, readerCache); err != nil {
-		log.Fatalf("failed to migrate readers: %s", err)
+		if err := readers.Migrate(bundleDir, readerCache); err != nil {
+			log.Fatalf("failed to migrate readers: %s", err)
+		}
 	}
 
 	observationContext := &observation.Context{
@@ -68,9 +80,9 @@ func main() {
 	store := store.NewObserved(mustInitializeStore(), observationContext)
 	metrics.MustRegisterDiskMonitor(bundleDir)
 
-	server := server.New(bundleDir, readerCache, observationContext)
+	server := server.New(bundleDir, storage, readerCache, observationContext)
 	janitorMetrics := janitor.NewJanitorMetrics(prometheus.DefaultRegisterer)
-	janitor := janitor.New(store, bundleDir, desiredPercentFree, janitorInterval, maxUploadAge, maxUploadPartAge, maxDatabasePartAge, janitorMetrics)
+	janitor := janitor.New(store, bundleDir, storage, desiredPercentFree, janitorInterval, maxUploadAge, maxUploadPartAge, maxDatabasePartAge, janitorMetrics)
 
 	go server.Start()
 	go janitor.Run()
@@ -91,6 +103,28 @@ func main() {
 	janitor.Stop()
 }
 
+// mustInitializeStorage returns the store holding uploads and converted bundles as well as a
+// flag indicating whether that store is the local bundle directory.
+func mustInitializeStorage(bundleDir string) (storage.Store, bool) {
+	switch rawStorageBackend {
+	case "local":
+		return storage.NewLocalStore(bundleDir), true
+
+	case "s3":
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:        rawS3Endpoint,
+			Bucket:          mustGet(rawS3Bucket, "PRECISE_CODE_INTEL_S3_BUCKET"),
+			Region:          rawS3Region,
+			AccessKeyID:     rawS3AccessKeyID,
+			SecretAccessKey: rawS3SecretAccessKey,
+			ForcePathStyle:  mustParseBool(rawS3ForcePathStyle, "PRECISE_CODE_INTEL_S3_FORCE_PATH_STYLE"),
+		}), false
+	}
+
+	log.Fatalf("invalid value %q for PRECISE_CODE_INTEL_STORAGE_BACKEND: must be local or s3", rawStorageBackend)
+	return nil, false
+}
+
 func mustInitializeStore() store.Store {
 	postgresDSN := conf.Get().ServiceConnections.PostgresDSN
 	conf.Watch(func() {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer/internal/inference"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
@@ -82,9 +83,9 @@ func (u *Updater) queueRepository(ctx context.Context, repoUsageStatistics store
 		return errors.Wrap(err, "gitserver.Head")
 	}
 
-	exists, err := u.gitserverClient.FileExists(ctx, u.store, repoUsageStatistics.RepositoryID, commit, "go.mod")
-	if err != nil || !exists {
-		return errors.Wrap(err, "gitserver.FileExists")
+	jobs, err := inference.IndexJobs(ctx, u.store, u.gitserverClient, repoUsageStatistics.RepositoryID, commit)
+	if err != nil || len(jobs) == 0 {
+		return errors.Wrap(err, "inference.IndexJobs")
 	}
 
 	// TODO(efritz) - also check repo size
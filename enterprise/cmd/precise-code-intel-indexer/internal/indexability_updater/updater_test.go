@@ -34,8 +34,11 @@ func TestUpdate(t *testing.T) {
 	}, nil)
 
 	mockGitserverClient := gitservermocks.NewMockClient()
-	mockGitserverClient.FileExistsFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int, commit, file string) (bool, error) {
-		return repositoryID%2 == 0, nil
+	mockGitserverClient.ListFilesFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int, commit string) ([]string, error) {
+		if repositoryID%2 == 0 {
+			return []string{"go.mod", "main.go"}, nil
+		}
+		return []string{"README.md"}, nil
 	})
 	mockGitserverClient.HeadFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int) (string, error) {
 		return fmt.Sprintf("c%d", repositoryID), nil
@@ -52,19 +55,26 @@ func TestUpdate(t *testing.T) {
 	}
 
 	if len(mockGitserverClient.FileExistsFunc.History()) != 4 {
-		t.Errorf("unexpected number of calls to FileExists. want=%d have=%d", 2, len(mockGitserverClient.FileExistsFunc.History()))
+		t.Errorf("unexpected number of calls to FileExists. want=%d have=%d", 4, len(mockGitserverClient.FileExistsFunc.History()))
 	} else {
-		var repositoryIDs []int
 		for _, call := range mockGitserverClient.FileExistsFunc.History() {
+			if call.Arg4 != "sourcegraph.yaml" {
+				t.Errorf("unexpected file argument. want=%q have=%q", "sourcegraph.yaml", call.Arg4)
+			}
+		}
+	}
+
+	if len(mockGitserverClient.ListFilesFunc.History()) != 4 {
+		t.Errorf("unexpected number of calls to ListFiles. want=%d have=%d", 4, len(mockGitserverClient.ListFilesFunc.History()))
+	} else {
+		var repositoryIDs []int
+		for _, call := range mockGitserverClient.ListFilesFunc.History() {
 			repositoryIDs = append(repositoryIDs, call.Arg2)
 			expectedCommit := fmt.Sprintf("c%d", call.Arg2)
 
 			if call.Arg3 != expectedCommit {
 				t.Errorf("unexpected commit argument. want=%q have=%q", expectedCommit, call.Arg3)
 			}
-			if call.Arg4 != "go.mod" {
-				t.Errorf("unexpected file argument. want=%q have=%q", "go.mod", call.Arg4)
-			}
 		}
 		sort.Ints(repositoryIDs)
 
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/codeintelutils"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer/internal/inference"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 )
@@ -23,6 +26,14 @@ type processor struct {
 }
 
 func (p *processor) Process(ctx context.Context, index store.Index) error {
+	jobs, err := inference.IndexJobs(ctx, p.store, p.gitserverClient, index.RepositoryID, index.Commit)
+	if err != nil {
+		return errors.Wrap(err, "failed to determine index jobs")
+	}
+	if len(jobs) == 0 {
+		return errors.New("repository is not indexable")
+	}
+
 	repoDir, err := fetchRepository(ctx, p.store, p.gitserverClient, index.RepositoryID, index.Commit)
 	if err != nil {
 		return err
@@ -31,48 +42,78 @@ func (p *processor) Process(ctx context.Context, index store.Index) error {
 		_ = os.RemoveAll(repoDir)
 	}()
 
-	if err := p.index(ctx, repoDir, index); err != nil {
-		return errors.Wrap(err, "failed to index repository")
+	moduleVersion, err := p.moduleVersion(ctx, index)
+	if err != nil {
+		return err
 	}
 
-	if err := p.upload(ctx, repoDir, index); err != nil {
-		return errors.Wrap(err, "failed to upload index")
+	// Run every indexer before uploading anything so that we do not produce
+	// a partial set of uploads for the commit when one of the indexers fails.
+	for _, job := range jobs {
+		if err := p.index(ctx, repoDir, job, moduleVersion); err != nil {
+			return errors.Wrapf(err, "failed to index repository (root=%q, indexer=%q)", job.Root, job.Indexer)
+		}
+	}
+
+	for _, job := range jobs {
+		if err := p.upload(ctx, repoDir, index, job); err != nil {
+			return errors.Wrapf(err, "failed to upload index (root=%q, indexer=%q)", job.Root, job.Indexer)
+		}
 	}
 
 	return nil
 }
 
-func (p *processor) index(ctx context.Context, repoDir string, index store.Index) error {
+// moduleVersion returns the version of the repository at the indexed commit.
+func (p *processor) moduleVersion(ctx context.Context, index store.Index) (string, error) {
 	tag, exact, err := p.gitserverClient.Tags(ctx, p.store, index.RepositoryID, index.Commit)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if !exact {
 		tag = fmt.Sprintf("%s-%s", tag, index.Commit[:12])
 	}
 
-	args := []string{
-		"--repositoryRoot=.",
-		fmt.Sprintf("--moduleVersion=%s", tag),
+	return tag, nil
+}
+
+func (p *processor) index(ctx context.Context, repoDir string, job inference.IndexJob, moduleVersion string) error {
+	args := job.ExpandArguments(moduleVersion)
+
+	if job.Image == "" {
+		return command(filepath.Join(repoDir, filepath.FromSlash(job.Root)), job.Command, args...)
 	}
 
-	return command(repoDir, "lsif-go", args...)
+	dockerArgs := append([]string{
+		"run", "--rm",
+		"-v", fmt.Sprintf("%s:/data", repoDir),
+		"-w", path.Join("/data", job.Root),
+		job.Image,
+		job.Command,
+	}, args...)
+
+	return command(repoDir, "docker", dockerArgs...)
 }
 
-func (p *processor) upload(ctx context.Context, repoDir string, index store.Index) error {
+func (p *processor) upload(ctx context.Context, repoDir string, index store.Index, job inference.IndexJob) error {
 	repoName, err := p.store.RepoName(ctx, index.RepositoryID)
 	if err != nil {
 		return errors.Wrap(err, "store.RepoName")
 	}
 
+	file, err := outfilePath(repoDir, job)
+	if err != nil {
+		return err
+	}
+
 	opts := codeintelutils.UploadIndexOpts{
 		Endpoint:            fmt.Sprintf("http://%s", p.frontendURL),
 		Path:                "/.internal/lsif/upload",
 		Repo:                repoName,
 		Commit:              index.Commit,
-		Root:                "",
-		Indexer:             "lsif-go",
-		File:                filepath.Join(repoDir, "dump.lsif"),
+		Root:                job.Root,
+		Indexer:             job.Indexer,
+		File:                file,
 		MaxPayloadSizeBytes: 100 * 1000 * 1000, // 100Mb
 	}
 
@@ -82,3 +123,23 @@ func (p *processor) upload(ctx context.Context, repoDir string, index store.Inde
 
 	return nil
 }
+
+// outfilePath returns the path of the LSIF dump written by the given index job. The repository
+// controls the files in repoDir, so the path must not resolve to a file outside of it, such as
+// through a symlink.
+func outfilePath(repoDir string, job inference.IndexJob) (string, error) {
+	resolvedRepoDir, err := filepath.EvalSymlinks(repoDir)
+	if err != nil {
+		return "", err
+	}
+	file, err := filepath.EvalSymlinks(filepath.Join(repoDir, filepath.FromSlash(job.Root), filepath.FromSlash(job.Outfile)))
+	if err != nil {
+		return "", errors.Wrap(err, "resolving outfile")
+	}
+
+	if rel, err := filepath.Rel(resolvedRepoDir, file); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("outfile %q is outside of the repository", job.Outfile)
+	}
+
+	return file, nil
+}
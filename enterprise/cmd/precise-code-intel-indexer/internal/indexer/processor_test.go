@@ -1,3 +1,45 @@
 package indexer
 
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer/internal/inference"
+)
+
 // TODO(efritz) - write index processor tests
+
+func TestOutfilePath(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	repoDir := filepath.Join(tmpDir, "repo")
+	if err := os.MkdirAll(filepath.Join(repoDir, "web"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{filepath.Join(repoDir, "web", "dump.lsif"), filepath.Join(tmpDir, "secret")} {
+		if err := ioutil.WriteFile(name, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(tmpDir, "secret"), filepath.Join(repoDir, "web", "link.lsif")); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := outfilePath(repoDir, inference.IndexJob{Root: "web", Outfile: "dump.lsif"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if filepath.Base(file) != "dump.lsif" {
+		t.Errorf("unexpected outfile path. want=%s have=%s", "dump.lsif", file)
+	}
+
+	if _, err := outfilePath(repoDir, inference.IndexJob{Root: "web", Outfile: "link.lsif"}); err == nil {
+		t.Errorf("expected error for outfile linking outside of the repository")
+	}
+}
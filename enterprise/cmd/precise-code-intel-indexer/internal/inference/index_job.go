@@ -0,0 +1,95 @@
+package inference
+
+import (
+	"path"
+	"strings"
+)
+
+// DefaultOutfile is the path, relative to the index job root, of the LSIF dump written by
+// an indexer when an index job does not specify an outfile.
+const DefaultOutfile = "dump.lsif"
+
+// The following placeholders may occur in the arguments of an index job. They are replaced
+// by the values of the repository being indexed by ExpandArguments.
+const (
+	// RepositoryRootPlaceholder is the relative path from the index job root to the root
+	// of the repository.
+	RepositoryRootPlaceholder = "{{repositoryRoot}}"
+
+	// ModuleVersionPlaceholder is the version of the repository at the indexed commit.
+	ModuleVersionPlaceholder = "{{moduleVersion}}"
+)
+
+// IndexJob describes how to produce a single LSIF upload for a project within a repository.
+type IndexJob struct {
+	// Root is the directory, relative to the repository root, in which the indexer is run.
+	// It is also the root of the resulting upload. The empty string denotes the repository
+	// root.
+	Root string `yaml:"root"`
+
+	// Indexer is the name of the indexer reported with the upload.
+	Indexer string `yaml:"indexer"`
+
+	// Image is the docker image in which the command is run. When empty, the command is
+	// run directly on the host, which is only permitted for the built-in recipes.
+	Image string `yaml:"image"`
+
+	// Command is the indexer binary.
+	Command string `yaml:"command"`
+
+	// Arguments are the arguments to the indexer binary.
+	Arguments []string `yaml:"arguments"`
+
+	// Outfile is the path of the resulting LSIF dump relative to Root.
+	Outfile string `yaml:"outfile"`
+}
+
+// ExpandArguments returns the arguments of the index job with placeholders replaced.
+func (j IndexJob) ExpandArguments(moduleVersion string) []string {
+	replacer := strings.NewReplacer(
+		RepositoryRootPlaceholder, relativeRepositoryRoot(j.Root),
+		ModuleVersionPlaceholder, moduleVersion,
+	)
+
+	args := make([]string, 0, len(j.Arguments))
+	for _, arg := range j.Arguments {
+		args = append(args, replacer.Replace(arg))
+	}
+
+	return args
+}
+
+// relativeRepositoryRoot returns the relative path from the given root to the repository root.
+func relativeRepositoryRoot(root string) string {
+	if root == "" {
+		return "."
+	}
+
+	return strings.TrimSuffix(strings.Repeat("../", len(strings.Split(root, "/"))), "/")
+}
+
+// normalizeRoot cleans the given root and returns false if it does not denote a
+// directory within the repository.
+func normalizeRoot(root string) (string, bool) {
+	root = path.Clean(strings.TrimSpace(root))
+	if root == "." {
+		return "", true
+	}
+
+	if path.IsAbs(root) || root == ".." || strings.HasPrefix(root, "../") {
+		return "", false
+	}
+
+	return root, true
+}
+
+// normalizeOutfile cleans the given outfile and returns false if it does not
+// denote a file within the index job root.
+func normalizeOutfile(outfile string) (string, bool) {
+	outfile = path.Clean(strings.TrimSpace(outfile))
+	if outfile == "." || path.IsAbs(outfile) || outfile == ".." || strings.HasPrefix(outfile, "../") {
+		return "", false
+	}
+
+	return outfile, true
+}
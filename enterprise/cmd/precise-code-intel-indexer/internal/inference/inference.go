@@ -0,0 +1,37 @@
+// Package inference determines how to index a repository. Index jobs are read from a
+// configuration file in the repository when present and otherwise inferred from the build
+// files found in the repository by a registry of language recipes.
+package inference
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+// IndexJobs returns the index jobs for the given repository and commit. An empty result
+// indicates that the repository is not indexable.
+func IndexJobs(ctx context.Context, store store.Store, gitserverClient gitserver.Client, repositoryID int, commit string) ([]IndexJob, error) {
+	exists, err := gitserverClient.FileExists(ctx, store, repositoryID, commit, ConfigFilename)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.FileExists")
+	}
+
+	if exists {
+		contents, err := gitserverClient.RawContents(ctx, store, repositoryID, commit, ConfigFilename)
+		if err != nil {
+			return nil, errors.Wrap(err, "gitserver.RawContents")
+		}
+
+		return ParseConfig(contents)
+	}
+
+	paths, err := gitserverClient.ListFiles(ctx, store, repositoryID, commit)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.ListFiles")
+	}
+
+	return InferIndexJobs(Recipes, paths), nil
+}
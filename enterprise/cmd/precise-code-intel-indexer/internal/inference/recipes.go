@@ -0,0 +1,164 @@
+package inference
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// Recipe describes how to index projects of a particular language or build system. A project
+// is detected by the presence of a build file, and the directory containing the build file
+// becomes the root of the index job.
+type Recipe struct {
+	// Name identifies the recipe.
+	Name string
+
+	// BuildFiles are the names of files whose presence marks a project root.
+	BuildFiles []string
+
+	// ExcludeIfPresent are the names of files whose presence next to a build file causes
+	// the project to be ignored by this recipe (because a more specific recipe applies).
+	ExcludeIfPresent []string
+
+	// Job is the template of the index jobs created for each project root.
+	Job IndexJob
+}
+
+// Recipes is the registry of recipes used to infer index jobs for repositories that do not
+// contain a configuration file.
+var Recipes = []Recipe{
+	{
+		Name:       "go",
+		BuildFiles: []string{"go.mod"},
+		Job: IndexJob{
+			Indexer:   "lsif-go",
+			Command:   "lsif-go",
+			Arguments: []string{"--repositoryRoot=" + RepositoryRootPlaceholder, "--moduleVersion=" + ModuleVersionPlaceholder},
+		},
+	},
+	{
+		Name:       "typescript",
+		BuildFiles: []string{"tsconfig.json"},
+		Job: IndexJob{
+			Indexer:   "lsif-tsc",
+			Image:     "sourcegraph/lsif-node",
+			Command:   "lsif-tsc",
+			Arguments: []string{"-p", "."},
+		},
+	},
+	{
+		Name:             "javascript",
+		BuildFiles:       []string{"package.json"},
+		ExcludeIfPresent: []string{"tsconfig.json"},
+		Job: IndexJob{
+			Indexer:   "lsif-tsc",
+			Image:     "sourcegraph/lsif-node",
+			Command:   "lsif-tsc",
+			Arguments: []string{"**/*.js", "--AllowJs", "--checkJs"},
+		},
+	},
+	{
+		Name:       "java",
+		BuildFiles: []string{"pom.xml", "build.gradle"},
+		Job: IndexJob{
+			Indexer:   "lsif-java",
+			Image:     "sourcegraph/lsif-java",
+			Command:   "lsif-java",
+			Arguments: []string{"index"},
+		},
+	},
+	{
+		Name:       "rust",
+		BuildFiles: []string{"Cargo.toml"},
+		Job: IndexJob{
+			Indexer:   "lsif-rust",
+			Image:     "sourcegraph/lsif-rust",
+			Command:   "lsif-rust",
+			Arguments: []string{"index"},
+		},
+	},
+}
+
+// excludedDirectories are directory names whose contents never form an indexable project.
+var excludedDirectories = map[string]struct{}{
+	"node_modules": {},
+	"vendor":       {},
+	"testdata":     {},
+	"third_party":  {},
+	".git":         {},
+}
+
+// InferIndexJobs returns the index jobs for every project detected by the given recipes in a
+// repository containing the given paths. Jobs are ordered by recipe and then by root.
+func InferIndexJobs(recipes []Recipe, paths []string) []IndexJob {
+	filesByDir := map[string]map[string]struct{}{}
+	for _, p := range paths {
+		dir, name := path.Split(p)
+		dir = strings.TrimSuffix(dir, "/")
+		if isExcluded(dir) {
+			continue
+		}
+
+		if _, ok := filesByDir[dir]; !ok {
+			filesByDir[dir] = map[string]struct{}{}
+		}
+		filesByDir[dir][name] = struct{}{}
+	}
+
+	var jobs []IndexJob
+	for _, recipe := range recipes {
+		var roots []string
+		for dir, files := range filesByDir {
+			if containsAny(files, recipe.BuildFiles) && !containsAny(files, recipe.ExcludeIfPresent) {
+				roots = append(roots, dir)
+			}
+		}
+		sort.Strings(roots)
+
+		for _, root := range roots {
+			job := recipe.Job
+			job.Root = root
+			job.Arguments = append([]string(nil), recipe.Job.Arguments...)
+			jobs = append(jobs, withDefaults(job))
+		}
+	}
+
+	return jobs
+}
+
+// isExcluded returns true if the given directory is nested in an excluded directory.
+func isExcluded(dir string) bool {
+	if dir == "" {
+		return false
+	}
+
+	for _, segment := range strings.Split(dir, "/") {
+		if _, ok := excludedDirectories[segment]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAny(files map[string]struct{}, names []string) bool {
+	for _, name := range names {
+		if _, ok := files[name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withDefaults fills in the optional fields of an index job.
+func withDefaults(job IndexJob) IndexJob {
+	if job.Outfile == "" {
+		job.Outfile = DefaultOutfile
+	}
+	if job.Indexer == "" {
+		job.Indexer = path.Base(job.Command)
+	}
+
+	return job
+}
@@ -0,0 +1,62 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInferIndexJobs(t *testing.T) {
+	paths := []string{
+		"go.mod",
+		"main.go",
+		"tools/go.mod",
+		"tools/vendor/github.com/foo/bar/go.mod",
+		"web/package.json",
+		"web/tsconfig.json",
+		"web/node_modules/left-pad/package.json",
+		"scripts/package.json",
+		"server/pom.xml",
+		"server/build.gradle",
+		"crates/core/Cargo.toml",
+		"README.md",
+	}
+
+	expected := []IndexJob{
+		{Root: "", Indexer: "lsif-go", Command: "lsif-go", Arguments: []string{"--repositoryRoot={{repositoryRoot}}", "--moduleVersion={{moduleVersion}}"}, Outfile: "dump.lsif"},
+		{Root: "tools", Indexer: "lsif-go", Command: "lsif-go", Arguments: []string{"--repositoryRoot={{repositoryRoot}}", "--moduleVersion={{moduleVersion}}"}, Outfile: "dump.lsif"},
+		{Root: "web", Indexer: "lsif-tsc", Image: "sourcegraph/lsif-node", Command: "lsif-tsc", Arguments: []string{"-p", "."}, Outfile: "dump.lsif"},
+		{Root: "scripts", Indexer: "lsif-tsc", Image: "sourcegraph/lsif-node", Command: "lsif-tsc", Arguments: []string{"**/*.js", "--AllowJs", "--checkJs"}, Outfile: "dump.lsif"},
+		{Root: "server", Indexer: "lsif-java", Image: "sourcegraph/lsif-java", Command: "lsif-java", Arguments: []string{"index"}, Outfile: "dump.lsif"},
+		{Root: "crates/core", Indexer: "lsif-rust", Image: "sourcegraph/lsif-rust", Command: "lsif-rust", Arguments: []string{"index"}, Outfile: "dump.lsif"},
+	}
+
+	if diff := cmp.Diff(expected, InferIndexJobs(Recipes, paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestInferIndexJobsNotIndexable(t *testing.T) {
+	if jobs := InferIndexJobs(Recipes, []string{"README.md", "vendor/go.mod"}); len(jobs) != 0 {
+		t.Errorf("unexpected index jobs: %v", jobs)
+	}
+}
+
+func TestExpandArguments(t *testing.T) {
+	testCases := []struct {
+		root     string
+		expected []string
+	}{
+		{root: "", expected: []string{"--repositoryRoot=.", "--moduleVersion=v1.2.3"}},
+		{root: "tools", expected: []string{"--repositoryRoot=..", "--moduleVersion=v1.2.3"}},
+		{root: "a/b/c", expected: []string{"--repositoryRoot=../../..", "--moduleVersion=v1.2.3"}},
+	}
+
+	for _, testCase := range testCases {
+		job := IndexJob{Root: testCase.root, Arguments: []string{"--repositoryRoot={{repositoryRoot}}", "--moduleVersion={{moduleVersion}}"}}
+
+		if diff := cmp.Diff(testCase.expected, job.ExpandArguments("v1.2.3")); diff != "" {
+			t.Errorf("unexpected arguments for root %q (-want +got):\n%s", testCase.root, diff)
+		}
+	}
+}
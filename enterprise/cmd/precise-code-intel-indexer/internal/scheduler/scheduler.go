@@ -7,6 +7,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer/internal/inference"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
@@ -111,6 +112,14 @@ func (s *Scheduler) queueIndex(ctx context.Context, indexableRepository store.In
 		return nil
 	}
 
+	jobs, err := inference.IndexJobs(ctx, s.store, s.gitserverClient, indexableRepository.RepositoryID, commit)
+	if err != nil {
+		return errors.Wrap(err, "inference.IndexJobs")
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
 		return errors.Wrap(err, "store.Transact")
@@ -41,6 +41,7 @@ func TestUpdate(t *testing.T) {
 	mockGitserverClient.HeadFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int) (string, error) {
 		return fmt.Sprintf("c%d", repositoryID), nil
 	})
+	mockGitserverClient.ListFilesFunc.SetDefaultReturn([]string{"go.mod"}, nil)
 
 	scheduler := &Scheduler{
 		store:           mockStore,
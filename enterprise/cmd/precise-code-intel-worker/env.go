@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/env"
@@ -11,6 +12,8 @@ var (
 	rawBundleManagerURL   = env.Get("PRECISE_CODE_INTEL_BUNDLE_MANAGER_URL", "", "HTTP address for internal LSIF bundle manager server.")
 	rawWorkerPollInterval = env.Get("PRECISE_CODE_INTEL_WORKER_POLL_INTERVAL", "1s", "Interval between queries to the upload queue.")
 	rawResetInterval      = env.Get("PRECISE_CODE_INTEL_RESET_INTERVAL", "1m", "How often to reset stalled uploads.")
+	rawRetentionInterval  = env.Get("PRECISE_CODE_INTEL_RETENTION_INTERVAL", "1h", "How often to remove uploads that are no longer retained by a retention policy.")
+	rawSpillThreshold     = env.Get("PRECISE_CODE_INTEL_CORRELATION_SPILL_THRESHOLD_BYTES", "1073741824", "The uncompressed size of an upload after which correlation data is moved to disk (0 to disable).")
 )
 
 // mustGet returns the non-empty version of the given raw value fatally logs on failure.
@@ -22,6 +25,16 @@ func mustGet(rawValue, name string) string {
 	return rawValue
 }
 
+// mustParseInt returns the integer version of the given raw value fatally logs on failure.
+func mustParseInt(rawValue, name string) int {
+	i, err := strconv.ParseInt(rawValue, 10, 64)
+	if err != nil {
+		log.Fatalf("invalid int %q for %s: %s", rawValue, name, err)
+	}
+
+	return int(i)
+}
+
 // mustParseInterval returns the interval version of the given raw value fatally logs on failure.
 func mustParseInterval(rawValue, name string) time.Duration {
 	d, err := time.ParseDuration(rawValue)
@@ -9,8 +9,8 @@ import (
 
 // canonicalize deduplicates data in the raw correlation state and collapses range,
 // result set, and moniker data that form chains via next edges.
-func canonicalize(state *State) {
-	fns := []func(state *State){
+func canonicalize(state *State) error {
+	fns := []func(state *State) error{
 		canonicalizeDocuments,
 		canonicalizeReferenceResults,
 		canonicalizeResultSets,
@@ -18,17 +18,21 @@ func canonicalize(state *State) {
 	}
 
 	for _, fn := range fns {
-		fn(state)
+		if err := fn(state); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
 // canonicalizeDocuments determines if multiple documents are defined with the same URI. This can
 // happen in some indexers (such as lsif-tsc) that index dependent projects into the same index
 // as the target project. For each set of documents that share a path, we choose one document to
-// be the canonical representative and merge the contains, definition, and reference data into the
-// unique canonical document. This function guarantees that duplicate document IDs are removed from
-// the correlation state.
-func canonicalizeDocuments(state *State) {
+// be the canonical representative and merge the contains, definition, reference, and implementation
+// data into the unique canonical document. This function guarantees that duplicate document IDs are
+// removed from the correlation state.
+func canonicalizeDocuments(state *State) error {
 	documentIDs := map[string][]string{}
 	for documentID, doc := range state.DocumentData {
 		documentIDs[doc.URI] = append(documentIDs[doc.URI], documentID)
@@ -37,6 +41,9 @@ func canonicalizeDocuments(state *State) {
 		sort.Strings(v)
 	}
 
+	// Maintain a map from a non-canonical document to its canonical identifier
+	canonicalIDs := map[string]string{}
+
 	for documentID, doc := range state.DocumentData {
 		// Choose canonical document alphabetically
 		if canonicalID := documentIDs[doc.URI][0]; documentID != canonicalID {
@@ -45,32 +52,59 @@ func canonicalizeDocuments(state *State) {
 				state.DocumentData[canonicalID].Contains.Add(id)
 			}
 
-			// Move definition/reference data into the canonical document
-			canonicalizeDocumentsInDefinitionReferences(state, state.DefinitionData, documentID, canonicalID)
-			canonicalizeDocumentsInDefinitionReferences(state, state.ReferenceData, documentID, canonicalID)
-
 			// Remove non-canonical document
+			canonicalIDs[documentID] = canonicalID
 			delete(state.DocumentData, documentID)
 		}
 	}
+
+	if len(canonicalIDs) == 0 {
+		return nil
+	}
+
+	// Move definition/reference/implementation data into the canonical documents
+	for _, data := range []map[string]datastructures.DefaultIDSetMap{state.DefinitionData, state.ReferenceData, state.ImplementationData} {
+		if err := canonicalizeDocumentsInDefinitionReferences(state, data, canonicalIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // canonicalizeDocumentsInDefinitionReferences moves definition or reference result data from the
-// given document to the given canonical document and removes all references to the non-canonical
-// document.
-func canonicalizeDocumentsInDefinitionReferences(state *State, definitionReferenceData map[string]datastructures.DefaultIDSetMap, documentID, canonicalID string) {
-	for _, documentRanges := range definitionReferenceData {
-		rangeIDs, ok := documentRanges[documentID]
-		if !ok {
-			continue
+// non-canonical documents in the given map to their canonical document and removes all references
+// to the non-canonical documents.
+func canonicalizeDocumentsInDefinitionReferences(state *State, definitionReferenceData map[string]datastructures.DefaultIDSetMap, canonicalIDs map[string]string) error {
+	for id := range definitionReferenceData {
+		documentRanges, err := state.resultData(definitionReferenceData, id)
+		if err != nil {
+			return err
 		}
 
-		// Move definition/reference data into the canonical document
-		documentRanges.GetOrCreate(canonicalID).AddAll(rangeIDs)
+		changed := false
+		for documentID, canonicalID := range canonicalIDs {
+			rangeIDs, ok := documentRanges[documentID]
+			if !ok {
+				continue
+			}
+
+			// Move definition/reference data into the canonical document
+			documentRanges.GetOrCreate(canonicalID).AddAll(rangeIDs)
+
+			// Remove references to non-canonical document
+			delete(documentRanges, documentID)
+			changed = true
+		}
 
-		// Remove references to non-canonical document
-		delete(documentRanges, documentID)
+		if changed {
+			if err := state.setResultData(definitionReferenceData, id, documentRanges); err != nil {
+				return err
+			}
+		}
 	}
+
+	return nil
 }
 
 // canonicalizeReferenceResults determines which reference results are linked together. For each
@@ -78,7 +112,7 @@ func canonicalizeDocumentsInDefinitionReferences(state *State, definitionReferen
 // and merge the data into the unique canonical result set. All non-canonical results are removed from
 // the correlation state and references to non-canonical results are updated to refer to the canonical
 // choice.
-func canonicalizeReferenceResults(state *State) {
+func canonicalizeReferenceResults(state *State) error {
 	// Maintain a map from a reference result to its canonical identifier
 	canonicalIDs := map[string]string{}
 
@@ -91,25 +125,46 @@ func canonicalizeReferenceResults(state *State) {
 		// Find all reachable items in this set
 		linkedIDs := state.LinkedReferenceResults.ExtractSet(referenceResultID)
 		canonicalID, _ := linkedIDs.Choose()
-		canonicalReferenceResult := state.ReferenceData[canonicalID]
+		canonicalReferenceResult, err := state.resultData(state.ReferenceData, canonicalID)
+		if err != nil {
+			return err
+		}
 
 		for linkedID := range linkedIDs {
 			// Mark canonical choice
 			canonicalIDs[linkedID] = canonicalID
 
 			if linkedID != canonicalID {
-				for documentID, rangeIDs := range state.ReferenceData[linkedID] {
+				linkedReferenceResult, err := state.resultData(state.ReferenceData, linkedID)
+				if err != nil {
+					return err
+				}
+
+				for documentID, rangeIDs := range linkedReferenceResult {
 					// Move range data into the canonical document
 					canonicalReferenceResult.GetOrCreate(documentID).AddAll(rangeIDs)
 				}
 			}
 		}
+
+		if err := state.setResultData(state.ReferenceData, canonicalID, canonicalReferenceResult); err != nil {
+			return err
+		}
 	}
 
-	for id, item := range state.RangeData {
-		if canonicalID, ok := canonicalIDs[item.ReferenceResultID]; ok {
-			// Update reference result identifier to canonical choice
-			state.RangeData[id] = item.SetReferenceResultID(canonicalID)
+	if len(canonicalIDs) == 0 {
+		return nil
+	}
+
+	for id := range state.RangeData {
+		if _, err := state.updateRangeData(id, func(item lsif.Range) lsif.Range {
+			if canonicalID, ok := canonicalIDs[item.ReferenceResultID]; ok {
+				// Update reference result identifier to canonical choice
+				return item.SetReferenceResultID(canonicalID)
+			}
+			return item
+		}); err != nil {
+			return err
 		}
 	}
 
@@ -129,15 +184,17 @@ func canonicalizeReferenceResults(state *State) {
 	for referenceResultID := range canonicalIDs {
 		if _, ok := inverseMap[referenceResultID]; !ok {
 			// Remove non-canonical reference result
-			delete(state.ReferenceData, referenceResultID)
+			state.deleteResultData(state.ReferenceData, referenceResultID)
 		}
 	}
+
+	return nil
 }
 
 // canonicalizeResultSets runs canonicalizeResultSet on each result set in the correlation state.
 // This will collapse result sets down recursively so that if a result set's next element also has
 // a next element, then both sets merge down into the original result set.
-func canonicalizeResultSets(state *State) {
+func canonicalizeResultSets(state *State) error {
 	for resultSetID, resultSetData := range state.ResultSetData {
 		canonicalizeResultSetData(state, resultSetID, resultSetData)
 	}
@@ -145,30 +202,38 @@ func canonicalizeResultSets(state *State) {
 	for resultSetID, resultSetData := range state.ResultSetData {
 		state.ResultSetData[resultSetID] = resultSetData.SetMonikerIDs(gatherMonikers(state, resultSetData.MonikerIDs))
 	}
+
+	return nil
 }
 
-// canonicalizeResultSets "merges down" the definition, reference, and hover result identifiers
-// from the element's "next" result set if such an element exists and the identifier is not already.
-// defined. This also merges down the moniker ids by unioning the sets.
+// canonicalizeResultSets "merges down" the definition, reference, implementation, and hover result
+// identifiers from the element's "next" result set if such an element exists and the identifier is
+// not already. defined. This also merges down the moniker ids by unioning the sets.
 //
 // This method is assumed to be invoked only after canonicalizeResultSets, otherwise the next element
 // of a range may not have all of the necessary data to perform this canonicalization step.
-func canonicalizeRanges(state *State) {
-	for rangeID, rangeData := range state.RangeData {
-		if _, nextItem, ok := next(state, rangeID); ok {
-			// Merge range and next element
-			rangeData = mergeNextRangeData(rangeData, nextItem)
-			// Delete next data to prevent us from re-performing this step
-			delete(state.NextData, rangeID)
-		}
+func canonicalizeRanges(state *State) error {
+	for rangeID := range state.RangeData {
+		if _, err := state.updateRangeData(rangeID, func(rangeData lsif.Range) lsif.Range {
+			if _, nextItem, ok := next(state, rangeID); ok {
+				// Merge range and next element
+				rangeData = mergeNextRangeData(rangeData, nextItem)
+				// Delete next data to prevent us from re-performing this step
+				delete(state.NextData, rangeID)
+			}
 
-		state.RangeData[rangeID] = rangeData.SetMonikerIDs(gatherMonikers(state, rangeData.MonikerIDs))
+			return rangeData.SetMonikerIDs(gatherMonikers(state, rangeData.MonikerIDs))
+		}); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
-// canonicalizeResultSets "merges down" the definition, reference, and hover result identifiers
-// from the element's "next" result set if such an element exists and the identifier is not
-// already defined. This also merges down the moniker ids by unioning the sets.
+// canonicalizeResultSets "merges down" the definition, reference, implementation, and hover result
+// identifiers from the element's "next" result set if such an element exists and the identifier is
+// not already defined. This also merges down the moniker ids by unioning the sets.
 func canonicalizeResultSetData(state *State, id string, item lsif.ResultSet) lsif.ResultSet {
 	if nextID, nextItem, ok := next(state, id); ok {
 		// Recursively canonicalize the next element
@@ -183,9 +248,9 @@ func canonicalizeResultSetData(state *State, id string, item lsif.ResultSet) lsi
 	return item
 }
 
-// mergeNextResultSetData merges the definition, reference, and hover result identifiers from
-// nextItem into item when not already defined. The moniker identifiers of nextItem are unioned
-// into the moniker identifiers of item.
+// mergeNextResultSetData merges the definition, reference, implementation, and hover result
+// identifiers from nextItem into item when not already defined. The moniker identifiers of nextItem
+// are unioned into the moniker identifiers of item.
 func mergeNextResultSetData(item, nextItem lsif.ResultSet) lsif.ResultSet {
 	if item.DefinitionResultID == "" {
 		item = item.SetDefinitionResultID(nextItem.DefinitionResultID)
@@ -193,6 +258,9 @@ func mergeNextResultSetData(item, nextItem lsif.ResultSet) lsif.ResultSet {
 	if item.ReferenceResultID == "" {
 		item = item.SetReferenceResultID(nextItem.ReferenceResultID)
 	}
+	if item.ImplementationResultID == "" {
+		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
+	}
 	if item.HoverResultID == "" {
 		item = item.SetHoverResultID(nextItem.HoverResultID)
 	}
@@ -201,9 +269,9 @@ func mergeNextResultSetData(item, nextItem lsif.ResultSet) lsif.ResultSet {
 	return item
 }
 
-// mergeNextRangeData merges the definition, reference, and hover result identifiers from nextItem
-// into item when not already defined. The moniker identifiers of nextItem are unioned into the
-// moniker identifiers of item.
+// mergeNextRangeData merges the definition, reference, implementation, and hover result identifiers
+// from nextItem into item when not already defined. The moniker identifiers of nextItem are unioned
+// into the moniker identifiers of item.
 func mergeNextRangeData(item lsif.Range, nextItem lsif.ResultSet) lsif.Range {
 	if item.DefinitionResultID == "" {
 		item = item.SetDefinitionResultID(nextItem.DefinitionResultID)
@@ -211,6 +279,9 @@ func mergeNextRangeData(item lsif.Range, nextItem lsif.ResultSet) lsif.Range {
 	if item.ReferenceResultID == "" {
 		item = item.SetReferenceResultID(nextItem.ReferenceResultID)
 	}
+	if item.ImplementationResultID == "" {
+		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
+	}
 	if item.HoverResultID == "" {
 		item = item.SetHoverResultID(nextItem.HoverResultID)
 	}
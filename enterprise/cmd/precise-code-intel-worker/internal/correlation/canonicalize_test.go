@@ -25,7 +25,9 @@ func TestCanonicalizeDocuments(t *testing.T) {
 			"x04": {"d03": datastructures.IDSet{"r09": {}}, "d04": datastructures.IDSet{"r10": {}}},
 		},
 	}
-	canonicalizeDocuments(state)
+	if err := canonicalizeDocuments(state); err != nil {
+		t.Fatalf("unexpected error canonicalizing state: %s", err)
+	}
 
 	expectedState := &State{
 		DocumentData: map[string]lsif.Document{
@@ -69,7 +71,9 @@ func TestCanonicalizeReferenceResults(t *testing.T) {
 		},
 		LinkedReferenceResults: linkedReferenceResults,
 	}
-	canonicalizeReferenceResults(state)
+	if err := canonicalizeReferenceResults(state); err != nil {
+		t.Fatalf("unexpected error canonicalizing state: %s", err)
+	}
 
 	expectedState := &State{
 		RangeData: map[string]lsif.Range{
@@ -138,7 +142,9 @@ func TestCanonicalizeResultSets(t *testing.T) {
 		},
 		LinkedMonikers: linkedMonikers,
 	}
-	canonicalizeResultSets(state)
+	if err := canonicalizeResultSets(state); err != nil {
+		t.Fatalf("unexpected error canonicalizing state: %s", err)
+	}
 
 	expectedState := &State{
 		ResultSetData: map[string]lsif.ResultSet{
@@ -227,7 +233,9 @@ func TestCanonicalizeRanges(t *testing.T) {
 		},
 		LinkedMonikers: linkedMonikers,
 	}
-	canonicalizeRanges(state)
+	if err := canonicalizeRanges(state); err != nil {
+		t.Fatalf("unexpected error canonicalizing state: %s", err)
+	}
 
 	expectedState := &State{
 		RangeData: map[string]lsif.Range{
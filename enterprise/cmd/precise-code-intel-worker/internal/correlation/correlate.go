@@ -7,7 +7,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/correlation/datastructures"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/correlation/lsif"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/correlation/lsif/jsonlines"
@@ -15,16 +17,28 @@ import (
 )
 
 // Correlate reads LSIF data from the given reader and returns a correlation state object with
-// the same data canonicalized and pruned for storage.
-func Correlate(r io.Reader, dumpID int, root string, getChildren existence.GetChildrenFunc) (*GroupedBundleData, error) {
+// the same data canonicalized and pruned for storage. Once more than the configured threshold of
+// input has been read, ranges and results are moved to a temporary file to bound the memory used
+// by large indexes. The returned data does not depend on whether this data was spilled. The caller
+// must close the returned value.
+func Correlate(r io.Reader, dumpID int, root string, getChildren existence.GetChildrenFunc, spillOptions SpillOptions) (_ *GroupedBundleData, err error) {
 	// Read raw upload stream and return a correlation state
-	state, err := correlateFromReader(r, root)
+	state, err := correlateFromReader(r, root, spillOptions)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		if err != nil {
+			if closeErr := state.Close(); closeErr != nil {
+				err = multierror.Append(err, closeErr)
+			}
+		}
+	}()
 
 	// Remove duplicate elements, collapse linked elements
-	canonicalize(state)
+	if err := canonicalize(state); err != nil {
+		return nil, err
+	}
 
 	// Remove elements we don't need to store
 	if err := prune(state, root, getChildren); err != nil {
@@ -40,10 +54,13 @@ func Correlate(r io.Reader, dumpID int, root string, getChildren existence.GetCh
 }
 
 // correlateFromReader reads the given upload stream and returns a correlation state object.
-// The data in the correlation state is neither canonicalized nor pruned.
-func correlateFromReader(r io.Reader, root string) (*State, error) {
+// The data in the correlation state is neither canonicalized nor pruned. The caller must
+// close the returned state.
+func correlateFromReader(r io.Reader, root string, spillOptions SpillOptions) (_ *State, err error) {
+	cr := &countingReader{r: r}
+
 	ctx, cancel := context.WithCancel(context.Background())
-	ch := jsonlines.Read(ctx, r)
+	ch := jsonlines.Read(ctx, cr)
 	defer func() {
 		// stop producer from reading more input on correlation error
 		cancel()
@@ -54,6 +71,13 @@ func correlateFromReader(r io.Reader, root string) (*State, error) {
 	}()
 
 	wrappedState := newWrappedState(root)
+	defer func() {
+		if err != nil {
+			if closeErr := wrappedState.Close(); closeErr != nil {
+				err = multierror.Append(err, closeErr)
+			}
+		}
+	}()
 
 	i := 0
 	for pair := range ch {
@@ -66,6 +90,14 @@ func correlateFromReader(r io.Reader, root string) (*State, error) {
 		if err := correlateElement(wrappedState, pair.Element); err != nil {
 			return nil, fmt.Errorf("dump malformed on element %d: %s", i, err)
 		}
+
+		if spillOptions.Threshold > 0 && wrappedState.Spill == nil && cr.Count() > spillOptions.Threshold {
+			log15.Info("Spilling correlation payloads to disk", "bytesRead", cr.Count(), "threshold", spillOptions.Threshold)
+
+			if err := wrappedState.spillPayloads(spillOptions.Dir); err != nil {
+				return nil, errors.Wrap(err, "spillPayloads")
+			}
+		}
 	}
 
 	if wrappedState.LSIFVersion == "" {
@@ -102,16 +134,18 @@ func correlateElement(state *wrappedState, element lsif.Element) error {
 }
 
 var vertexHandlers = map[string]func(state *wrappedState, element lsif.Element) error{
-	"metaData":           correlateMetaData,
-	"document":           correlateDocument,
-	"range":              correlateRange,
-	"resultSet":          correlateResultSet,
-	"definitionResult":   correlateDefinitionResult,
-	"referenceResult":    correlateReferenceResult,
-	"hoverResult":        correlateHoverResult,
-	"moniker":            correlateMoniker,
-	"packageInformation": correlatePackageInformation,
-	"diagnosticResult":   correlateDiagnosticResult,
+	"metaData":             correlateMetaData,
+	"document":             correlateDocument,
+	"range":                correlateRange,
+	"resultSet":            correlateResultSet,
+	"definitionResult":     correlateDefinitionResult,
+	"referenceResult":      correlateReferenceResult,
+	"implementationResult": correlateImplementationResult,
+	"hoverResult":          correlateHoverResult,
+	"moniker":              correlateMoniker,
+	"packageInformation":   correlatePackageInformation,
+	"diagnosticResult":     correlateDiagnosticResult,
+	"documentSymbolResult": correlateDocumentSymbolResult,
 }
 
 // correlateElement maps a single vertex element into the correlation state.
@@ -131,16 +165,18 @@ func correlateVertex(state *wrappedState, element lsif.Element) error {
 }
 
 var edgeHandlers = map[string]func(state *wrappedState, id string, edge lsif.Edge) error{
-	"contains":                correlateContainsEdge,
-	"next":                    correlateNextEdge,
-	"item":                    correlateItemEdge,
-	"textDocument/definition": correlateTextDocumentDefinitionEdge,
-	"textDocument/references": correlateTextDocumentReferencesEdge,
-	"textDocument/hover":      correlateTextDocumentHoverEdge,
-	"moniker":                 correlateMonikerEdge,
-	"nextMoniker":             correlateNextMonikerEdge,
-	"packageInformation":      correlatePackageInformationEdge,
-	"textDocument/diagnostic": correlateDiagnosticEdge,
+	"contains":                    correlateContainsEdge,
+	"next":                        correlateNextEdge,
+	"item":                        correlateItemEdge,
+	"textDocument/definition":     correlateTextDocumentDefinitionEdge,
+	"textDocument/references":     correlateTextDocumentReferencesEdge,
+	"textDocument/implementation": correlateTextDocumentImplementationEdge,
+	"textDocument/hover":          correlateTextDocumentHoverEdge,
+	"moniker":                     correlateMonikerEdge,
+	"nextMoniker":                 correlateNextMonikerEdge,
+	"packageInformation":          correlatePackageInformationEdge,
+	"textDocument/diagnostic":     correlateDiagnosticEdge,
+	"textDocument/documentSymbol": correlateDocumentSymbolEdge,
 }
 
 // correlateElement maps a single edge element into the correlation state.
@@ -213,8 +249,7 @@ func correlateRange(state *wrappedState, element lsif.Element) error {
 		return ErrUnexpectedPayload
 	}
 
-	state.RangeData[element.ID] = payload
-	return nil
+	return state.setRangeData(element.ID, payload)
 }
 
 func correlateResultSet(state *wrappedState, element lsif.Element) error {
@@ -225,13 +260,15 @@ func correlateResultSet(state *wrappedState, element lsif.Element) error {
 }
 
 func correlateDefinitionResult(state *wrappedState, element lsif.Element) error {
-	state.DefinitionData[element.ID] = map[string]datastructures.IDSet{}
-	return nil
+	return state.setResultData(state.DefinitionData, element.ID, map[string]datastructures.IDSet{})
 }
 
 func correlateReferenceResult(state *wrappedState, element lsif.Element) error {
-	state.ReferenceData[element.ID] = map[string]datastructures.IDSet{}
-	return nil
+	return state.setResultData(state.ReferenceData, element.ID, map[string]datastructures.IDSet{})
+}
+
+func correlateImplementationResult(state *wrappedState, element lsif.Element) error {
+	return state.setResultData(state.ImplementationData, element.ID, map[string]datastructures.IDSet{})
 }
 
 func correlateHoverResult(state *wrappedState, element lsif.Element) error {
@@ -240,8 +277,7 @@ func correlateHoverResult(state *wrappedState, element lsif.Element) error {
 		return ErrUnexpectedPayload
 	}
 
-	state.HoverData[element.ID] = payload
-	return nil
+	return state.addHoverText(element.ID, payload)
 }
 
 func correlateMoniker(state *wrappedState, element lsif.Element) error {
@@ -270,8 +306,16 @@ func correlateDiagnosticResult(state *wrappedState, element lsif.Element) error
 		return ErrUnexpectedPayload
 	}
 
-	state.Diagnostics[element.ID] = payload
-	return nil
+	return state.addDiagnosticResult(element.ID, payload)
+}
+
+func correlateDocumentSymbolResult(state *wrappedState, element lsif.Element) error {
+	payload, ok := element.Payload.(lsif.DocumentSymbolResult)
+	if !ok {
+		return ErrUnexpectedPayload
+	}
+
+	return state.addDocumentSymbolResult(element.ID, payload)
 }
 
 func correlateContainsEdge(state *wrappedState, id string, edge lsif.Edge) error {
@@ -306,20 +350,19 @@ func correlateNextEdge(state *wrappedState, id string, edge lsif.Edge) error {
 }
 
 func correlateItemEdge(state *wrappedState, id string, edge lsif.Edge) error {
-	if documentMap, ok := state.DefinitionData[edge.OutV]; ok {
+	if _, ok := state.DefinitionData[edge.OutV]; ok {
 		for _, inV := range edge.InVs {
 			if _, ok := state.RangeData[inV]; !ok {
 				return malformedDump(id, edge.InV, "range")
 			}
-
-			// Link definition data to defining range
-			documentMap.GetOrCreate(edge.Document).Add(inV)
 		}
 
-		return nil
+		// Link definition data to defining range
+		return state.addResultRanges(state.DefinitionData, edge.OutV, edge.Document, edge.InVs)
 	}
 
-	if documentMap, ok := state.ReferenceData[edge.OutV]; ok {
+	if _, ok := state.ReferenceData[edge.OutV]; ok {
+		var rangeIDs []string
 		for _, inV := range edge.InVs {
 			if _, ok := state.ReferenceData[inV]; ok {
 				// Link reference data identifiers together
@@ -329,12 +372,23 @@ func correlateItemEdge(state *wrappedState, id string, edge lsif.Edge) error {
 					return malformedDump(id, edge.InV, "range")
 				}
 
-				// Link reference data to a reference range
-				documentMap.GetOrCreate(edge.Document).Add(inV)
+				rangeIDs = append(rangeIDs, inV)
 			}
 		}
 
-		return nil
+		// Link reference data to a reference range
+		return state.addResultRanges(state.ReferenceData, edge.OutV, edge.Document, rangeIDs)
+	}
+
+	if _, ok := state.ImplementationData[edge.OutV]; ok {
+		for _, inV := range edge.InVs {
+			if _, ok := state.RangeData[inV]; !ok {
+				return malformedDump(id, edge.InV, "range")
+			}
+		}
+
+		// Link implementation data to an implementing range
+		return state.addResultRanges(state.ImplementationData, edge.OutV, edge.Document, edge.InVs)
 	}
 
 	if !state.unsupportedVertexes.Contains(edge.OutV) {
@@ -350,14 +404,14 @@ func correlateTextDocumentDefinitionEdge(state *wrappedState, id string, edge ls
 		return malformedDump(id, edge.InV, "definitionResult")
 	}
 
-	if source, ok := state.RangeData[edge.OutV]; ok {
-		state.RangeData[edge.OutV] = source.SetDefinitionResultID(edge.InV)
-	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+	if ok, err := state.updateRangeData(edge.OutV, func(r lsif.Range) lsif.Range { return r.SetDefinitionResultID(edge.InV) }); err != nil || ok {
+		return err
+	}
+	if source, ok := state.ResultSetData[edge.OutV]; ok {
 		state.ResultSetData[edge.OutV] = source.SetDefinitionResultID(edge.InV)
-	} else {
-		return malformedDump(id, edge.OutV, "range", "resultSet")
+		return nil
 	}
-	return nil
+	return malformedDump(id, edge.OutV, "range", "resultSet")
 }
 
 func correlateTextDocumentReferencesEdge(state *wrappedState, id string, edge lsif.Edge) error {
@@ -365,14 +419,29 @@ func correlateTextDocumentReferencesEdge(state *wrappedState, id string, edge ls
 		return malformedDump(id, edge.InV, "referenceResult")
 	}
 
-	if source, ok := state.RangeData[edge.OutV]; ok {
-		state.RangeData[edge.OutV] = source.SetReferenceResultID(edge.InV)
-	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+	if ok, err := state.updateRangeData(edge.OutV, func(r lsif.Range) lsif.Range { return r.SetReferenceResultID(edge.InV) }); err != nil || ok {
+		return err
+	}
+	if source, ok := state.ResultSetData[edge.OutV]; ok {
 		state.ResultSetData[edge.OutV] = source.SetReferenceResultID(edge.InV)
-	} else {
-		return malformedDump(id, edge.OutV, "range", "resultSet")
+		return nil
 	}
-	return nil
+	return malformedDump(id, edge.OutV, "range", "resultSet")
+}
+
+func correlateTextDocumentImplementationEdge(state *wrappedState, id string, edge lsif.Edge) error {
+	if _, ok := state.ImplementationData[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "implementationResult")
+	}
+
+	if ok, err := state.updateRangeData(edge.OutV, func(r lsif.Range) lsif.Range { return r.SetImplementationResultID(edge.InV) }); err != nil || ok {
+		return err
+	}
+	if source, ok := state.ResultSetData[edge.OutV]; ok {
+		state.ResultSetData[edge.OutV] = source.SetImplementationResultID(edge.InV)
+		return nil
+	}
+	return malformedDump(id, edge.OutV, "range", "resultSet")
 }
 
 func correlateTextDocumentHoverEdge(state *wrappedState, id string, edge lsif.Edge) error {
@@ -380,14 +449,14 @@ func correlateTextDocumentHoverEdge(state *wrappedState, id string, edge lsif.Ed
 		return malformedDump(id, edge.InV, "hoverResult")
 	}
 
-	if source, ok := state.RangeData[edge.OutV]; ok {
-		state.RangeData[edge.OutV] = source.SetHoverResultID(edge.InV)
-	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+	if ok, err := state.updateRangeData(edge.OutV, func(r lsif.Range) lsif.Range { return r.SetHoverResultID(edge.InV) }); err != nil || ok {
+		return err
+	}
+	if source, ok := state.ResultSetData[edge.OutV]; ok {
 		state.ResultSetData[edge.OutV] = source.SetHoverResultID(edge.InV)
-	} else {
-		return malformedDump(id, edge.OutV, "range", "resultSet")
+		return nil
 	}
-	return nil
+	return malformedDump(id, edge.OutV, "range", "resultSet")
 }
 
 func correlateMonikerEdge(state *wrappedState, id string, edge lsif.Edge) error {
@@ -398,14 +467,14 @@ func correlateMonikerEdge(state *wrappedState, id string, edge lsif.Edge) error
 	ids := datastructures.IDSet{}
 	ids.Add(edge.InV)
 
-	if source, ok := state.RangeData[edge.OutV]; ok {
-		state.RangeData[edge.OutV] = source.SetMonikerIDs(ids)
-	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+	if ok, err := state.updateRangeData(edge.OutV, func(r lsif.Range) lsif.Range { return r.SetMonikerIDs(ids) }); err != nil || ok {
+		return err
+	}
+	if source, ok := state.ResultSetData[edge.OutV]; ok {
 		state.ResultSetData[edge.OutV] = source.SetMonikerIDs(ids)
-	} else {
-		return malformedDump(id, edge.OutV, "range", "resultSet")
+		return nil
 	}
-	return nil
+	return malformedDump(id, edge.OutV, "range", "resultSet")
 }
 
 func correlateNextMonikerEdge(state *wrappedState, id string, edge lsif.Edge) error {
@@ -456,3 +525,17 @@ func correlateDiagnosticEdge(state *wrappedState, id string, edge lsif.Edge) err
 	document.Diagnostics.Add(edge.InV)
 	return nil
 }
+
+func correlateDocumentSymbolEdge(state *wrappedState, id string, edge lsif.Edge) error {
+	document, ok := state.DocumentData[edge.OutV]
+	if !ok {
+		return malformedDump(id, edge.OutV, "document")
+	}
+
+	if _, ok := state.DocumentSymbolResults[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "documentSymbolResult")
+	}
+
+	document.DocumentSymbols.Add(edge.InV)
+	return nil
+}
@@ -3,6 +3,7 @@ package correlation
 import (
 	"bytes"
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -16,7 +17,7 @@ func TestCorrelate(t *testing.T) {
 		t.Fatalf("unexpected error reading test file: %s", err)
 	}
 
-	state, err := correlateFromReader(bytes.NewReader(input), "root")
+	state, err := correlateFromReader(bytes.NewReader(input), "root", SpillOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error correlating input: %s", err)
 	}
@@ -26,14 +27,16 @@ func TestCorrelate(t *testing.T) {
 		ProjectRoot: "file:///test/root",
 		DocumentData: map[string]lsif.Document{
 			"02": {
-				URI:         "foo.go",
-				Contains:    datastructures.IDSet{"04": {}, "05": {}, "06": {}},
-				Diagnostics: datastructures.IDSet{"49": {}},
+				URI:             "foo.go",
+				Contains:        datastructures.IDSet{"04": {}, "05": {}, "06": {}},
+				Diagnostics:     datastructures.IDSet{"49": {}},
+				DocumentSymbols: datastructures.IDSet{"54": {}},
 			},
 			"03": {
-				URI:         "bar.go",
-				Contains:    datastructures.IDSet{"07": {}, "08": {}, "09": {}},
-				Diagnostics: datastructures.IDSet{},
+				URI:             "bar.go",
+				Contains:        datastructures.IDSet{"07": {}, "08": {}, "09": {}},
+				Diagnostics:     datastructures.IDSet{},
+				DocumentSymbols: datastructures.IDSet{"56": {}},
 			},
 		},
 		RangeData: map[string]lsif.Range{
@@ -84,6 +87,15 @@ func TestCorrelate(t *testing.T) {
 				EndLine:        8,
 				EndCharacter:   9,
 				MonikerIDs:     datastructures.IDSet{"19": {}},
+				Tag: &lsif.RangeTag{
+					Type:           "definition",
+					Text:           "ident B",
+					Kind:           12,
+					StartLine:      6,
+					StartCharacter: 0,
+					EndLine:        9,
+					EndCharacter:   1,
+				},
 			},
 		},
 		ResultSetData: map[string]lsif.ResultSet{
@@ -93,8 +105,9 @@ func TestCorrelate(t *testing.T) {
 				MonikerIDs:         datastructures.IDSet{"20": {}},
 			},
 			"11": {
-				HoverResultID: "16",
-				MonikerIDs:    datastructures.IDSet{"21": {}},
+				ImplementationResultID: "51",
+				HoverResultID:          "16",
+				MonikerIDs:             datastructures.IDSet{"21": {}},
 			},
 		},
 		DefinitionData: map[string]datastructures.DefaultIDSetMap{
@@ -105,6 +118,9 @@ func TestCorrelate(t *testing.T) {
 			"14": {"02": {"04": {}, "05": {}}},
 			"15": {},
 		},
+		ImplementationData: map[string]datastructures.DefaultIDSetMap{
+			"51": {"03": {"08": {}}},
+		},
 		HoverData: map[string]string{
 			"16": "```go\ntext A\n```",
 			"17": "```go\ntext B\n```",
@@ -135,6 +151,35 @@ func TestCorrelate(t *testing.T) {
 				},
 			},
 		},
+		DocumentSymbolResults: map[string]lsif.DocumentSymbolResult{
+			"54": {
+				Result: []lsif.DocumentSymbol{
+					{
+						Name:           "foo",
+						Kind:           12,
+						StartLine:      1,
+						StartCharacter: 0,
+						EndLine:        5,
+						EndCharacter:   1,
+						Children: []lsif.DocumentSymbol{
+							{
+								Name:           "bar",
+								Kind:           13,
+								StartLine:      2,
+								StartCharacter: 1,
+								EndLine:        2,
+								EndCharacter:   10,
+							},
+						},
+					},
+				},
+			},
+			"56": {
+				Result: []lsif.DocumentSymbol{
+					{RangeID: "09"},
+				},
+			},
+		},
 		NextData: map[string]string{
 			"09": "10",
 			"10": "11",
@@ -156,7 +201,7 @@ func TestCorrelateMetaDataRoot(t *testing.T) {
 		t.Fatalf("unexpected error reading test file: %s", err)
 	}
 
-	state, err := correlateFromReader(bytes.NewReader(input), "root/")
+	state, err := correlateFromReader(bytes.NewReader(input), "root/", SpillOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error correlating input: %s", err)
 	}
@@ -166,19 +211,22 @@ func TestCorrelateMetaDataRoot(t *testing.T) {
 		ProjectRoot: "file:///test/root/",
 		DocumentData: map[string]lsif.Document{
 			"02": {
-				URI:         "foo.go",
-				Contains:    datastructures.IDSet{},
-				Diagnostics: datastructures.IDSet{},
+				URI:             "foo.go",
+				Contains:        datastructures.IDSet{},
+				Diagnostics:     datastructures.IDSet{},
+				DocumentSymbols: datastructures.IDSet{},
 			},
 		},
 		RangeData:              map[string]lsif.Range{},
 		ResultSetData:          map[string]lsif.ResultSet{},
 		DefinitionData:         map[string]datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[string]datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[string]datastructures.DefaultIDSetMap{},
 		HoverData:              map[string]string{},
 		MonikerData:            map[string]lsif.Moniker{},
 		PackageInformationData: map[string]lsif.PackageInformation{},
 		Diagnostics:            map[string]lsif.DiagnosticResult{},
+		DocumentSymbolResults:  map[string]lsif.DocumentSymbolResult{},
 		NextData:               map[string]string{},
 		ImportedMonikers:       datastructures.IDSet{},
 		ExportedMonikers:       datastructures.IDSet{},
@@ -197,7 +245,7 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		t.Fatalf("unexpected error reading test file: %s", err)
 	}
 
-	state, err := correlateFromReader(bytes.NewReader(input), "")
+	state, err := correlateFromReader(bytes.NewReader(input), "", SpillOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error correlating input: %s", err)
 	}
@@ -207,19 +255,22 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		ProjectRoot: "file:///__w/sourcegraph/sourcegraph/shared/",
 		DocumentData: map[string]lsif.Document{
 			"02": {
-				URI:         "../node_modules/@types/history/index.d.ts",
-				Contains:    datastructures.IDSet{},
-				Diagnostics: datastructures.IDSet{},
+				URI:             "../node_modules/@types/history/index.d.ts",
+				Contains:        datastructures.IDSet{},
+				Diagnostics:     datastructures.IDSet{},
+				DocumentSymbols: datastructures.IDSet{},
 			},
 		},
 		RangeData:              map[string]lsif.Range{},
 		ResultSetData:          map[string]lsif.ResultSet{},
 		DefinitionData:         map[string]datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[string]datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[string]datastructures.DefaultIDSetMap{},
 		HoverData:              map[string]string{},
 		MonikerData:            map[string]lsif.Moniker{},
 		PackageInformationData: map[string]lsif.PackageInformation{},
 		Diagnostics:            map[string]lsif.DiagnosticResult{},
+		DocumentSymbolResults:  map[string]lsif.DocumentSymbolResult{},
 		NextData:               map[string]string{},
 		ImportedMonikers:       datastructures.IDSet{},
 		ExportedMonikers:       datastructures.IDSet{},
@@ -231,3 +282,49 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		t.Errorf("unexpected state (-want +got):\n%s", diff)
 	}
 }
+
+func TestCorrelateSpill(t *testing.T) {
+	input, err := ioutil.ReadFile("../../testdata/dump1.lsif")
+	if err != nil {
+		t.Fatalf("unexpected error reading test file: %s", err)
+	}
+
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error creating temp directory: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	getChildren := func(dirnames []string) (map[string][]string, error) {
+		return map[string][]string{"root": {"root/foo.go", "root/bar.go"}}, nil
+	}
+
+	groupedBundleData, err := Correlate(bytes.NewReader(input), 42, "root/", getChildren, SpillOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error correlating input: %s", err)
+	}
+	expectedBundleData := readGroupedBundleData(t, groupedBundleData)
+	normalizeGroupedBundleData(expectedBundleData)
+
+	if len(expectedBundleData.Documents) != 2 {
+		t.Fatalf("unexpected number of documents. want=%d have=%d", 2, len(expectedBundleData.Documents))
+	}
+
+	// Spill all payloads after the metadata vertex is read
+	groupedBundleData, err = Correlate(bytes.NewReader(input), 42, "root/", getChildren, SpillOptions{Threshold: 1, Dir: tempDir})
+	if err != nil {
+		t.Fatalf("unexpected error correlating input: %s", err)
+	}
+	spilledBundleData := readGroupedBundleData(t, groupedBundleData)
+	normalizeGroupedBundleData(spilledBundleData)
+
+	if diff := cmp.Diff(expectedBundleData, spilledBundleData); diff != "" {
+		t.Errorf("unexpected bundle data (-want +got):\n%s", diff)
+	}
+
+	if names, err := ioutil.ReadDir(tempDir); err != nil {
+		t.Fatalf("unexpected error reading temp directory: %s", err)
+	} else if len(names) != 0 {
+		t.Errorf("unexpected files in temp directory. want=%d have=%d", 0, len(names))
+	}
+}
@@ -1,9 +1,12 @@
 package correlation
 
 import (
+	"context"
 	"math"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/correlation/datastructures"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/correlation/lsif"
@@ -13,23 +16,41 @@ import (
 
 // GroupedBundleData is a view of a correlation State that sorts data by it containing document
 // and shared data into shareded result chunks. The fields of this type are what is written to
-// persistent storage and what is read in the query path.
+// persistent storage and what is read in the query path. Documents and result chunks are produced
+// one at a time from the underlying state as the channels are read, so that the serialized form of
+// a large index is never held in memory at once.
 type GroupedBundleData struct {
 	Meta              types.MetaData
-	Documents         map[string]types.DocumentData
-	ResultChunks      map[int]types.ResultChunkData
+	Documents         chan types.KeyedDocumentData
+	ResultChunks      chan types.IndexedResultChunkData
 	Definitions       []types.MonikerLocations
 	References        []types.MonikerLocations
+	Implementations   []types.MonikerLocations
 	Packages          []types.Package
 	PackageReferences []types.PackageReference
+	Symbols           []types.SymbolData
+	close             func() error
+}
+
+// Close stops the production of documents and result chunks and releases the underlying
+// correlation state. This method returns any error that occurred while producing documents or
+// result chunks, in which case the data read from the channels is incomplete. Close must be
+// called once the grouped bundle data is no longer used.
+func (d *GroupedBundleData) Close() error {
+	if d.close == nil {
+		return nil
+	}
+
+	return d.close()
 }
 
 const MaxNumResultChunks = 1000
 const ResultsPerResultChunk = 500
 
 // groupBundleData converts a raw (but canonicalized) correlation State into a GroupedBundleData.
+// On success, the returned value takes ownership of the given state.
 func groupBundleData(state *State, dumpID int) (*GroupedBundleData, error) {
-	numResults := len(state.DefinitionData) + len(state.ReferenceData)
+	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData)
 	numResultChunks := int(math.Min(
 		MaxNumResultChunks,
 		math.Max(
@@ -39,41 +60,97 @@ func groupBundleData(state *State, dumpID int) (*GroupedBundleData, error) {
 	))
 
 	meta := types.MetaData{NumResultChunks: numResultChunks}
-	documents := serializeBundleDocuments(state)
-	resultChunks := serializeResultChunks(state, numResultChunks)
-	definitionRows := gatherMonikersLocations(state, state.DefinitionData, getDefinitionResultID)
-	referenceRows := gatherMonikersLocations(state, state.ReferenceData, getReferenceResultID)
+	definitionRows, referenceRows, implementationRows, err := gatherMonikersLocations(state)
+	if err != nil {
+		return nil, err
+	}
 	packages := gatherPackages(state, dumpID)
+	symbols, err := gatherSymbols(state)
+	if err != nil {
+		return nil, err
+	}
 	packageReferences, err := gatherPackageReferences(state, dumpID)
 	if err != nil {
 		return nil, err
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	documents, documentErrs := serializeBundleDocuments(ctx, state)
+	resultChunks, resultChunkErrs := serializeResultChunks(ctx, state, numResultChunks)
+
+	closer := func() (err error) {
+		// stop producers on early close
+		cancel()
+
+		for range documents {
+			// drain whatever is in the channel to unblock the producer
+		}
+		for range resultChunks {
+			// drain whatever is in the channel to unblock the producer
+		}
+
+		for _, errs := range []<-chan error{documentErrs, resultChunkErrs} {
+			if producerErr := <-errs; producerErr != nil && producerErr != context.Canceled {
+				err = multierror.Append(err, producerErr)
+			}
+		}
+
+		if closeErr := state.Close(); closeErr != nil {
+			err = multierror.Append(err, closeErr)
+		}
+
+		return err
+	}
+
 	return &GroupedBundleData{
 		Meta:              meta,
 		Documents:         documents,
 		ResultChunks:      resultChunks,
 		Definitions:       definitionRows,
 		References:        referenceRows,
+		Implementations:   implementationRows,
 		Packages:          packages,
 		PackageReferences: packageReferences,
+		Symbols:           symbols,
+		close:             closer,
 	}, nil
 }
 
-func serializeBundleDocuments(state *State) map[string]types.DocumentData {
-	out := map[string]types.DocumentData{}
-	for _, doc := range state.DocumentData {
-		if strings.HasPrefix(doc.URI, "..") {
-			continue
-		}
+// serializeBundleDocuments serializes the documents of the given state one at a time onto the
+// returned channel. Any error that stops the production of documents is sent to the error channel
+// once the document channel is closed.
+func serializeBundleDocuments(ctx context.Context, state *State) (chan types.KeyedDocumentData, <-chan error) {
+	ch := make(chan types.KeyedDocumentData)
+	errs := make(chan error, 1)
 
-		out[doc.URI] = serializeDocument(state, doc)
-	}
+	go func() {
+		defer close(errs)
+		defer close(ch)
+
+		for _, doc := range state.DocumentData {
+			if strings.HasPrefix(doc.URI, "..") {
+				continue
+			}
+
+			document, err := serializeDocument(state, doc)
+			if err != nil {
+				errs <- err
+				return
+			}
 
-	return out
+			select {
+			case ch <- types.KeyedDocumentData{Path: doc.URI, Document: document}:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return ch, errs
 }
 
-func serializeDocument(state *State, doc lsif.Document) types.DocumentData {
+func serializeDocument(state *State, doc lsif.Document) (types.DocumentData, error) {
 	document := types.DocumentData{
 		Ranges:             map[types.ID]types.RangeData{},
 		HoverResults:       map[types.ID]string{},
@@ -84,7 +161,10 @@ func serializeDocument(state *State, doc lsif.Document) types.DocumentData {
 
 	for rangeID := range doc.Contains {
 		k := rangeID
-		v := state.RangeData[rangeID]
+		v, err := state.rangeData(rangeID)
+		if err != nil {
+			return types.DocumentData{}, errors.Wrap(err, "rangeData")
+		}
 
 		var monikerIDs []types.ID
 		for m := range v.MonikerIDs {
@@ -92,18 +172,22 @@ func serializeDocument(state *State, doc lsif.Document) types.DocumentData {
 		}
 
 		document.Ranges[types.ID(k)] = types.RangeData{
-			StartLine:          v.StartLine,
-			StartCharacter:     v.StartCharacter,
-			EndLine:            v.EndLine,
-			EndCharacter:       v.EndCharacter,
-			DefinitionResultID: types.ID(v.DefinitionResultID),
-			ReferenceResultID:  types.ID(v.ReferenceResultID),
-			HoverResultID:      types.ID(v.HoverResultID),
-			MonikerIDs:         monikerIDs,
+			StartLine:              v.StartLine,
+			StartCharacter:         v.StartCharacter,
+			EndLine:                v.EndLine,
+			EndCharacter:           v.EndCharacter,
+			DefinitionResultID:     types.ID(v.DefinitionResultID),
+			ReferenceResultID:      types.ID(v.ReferenceResultID),
+			ImplementationResultID: types.ID(v.ImplementationResultID),
+			HoverResultID:          types.ID(v.HoverResultID),
+			MonikerIDs:             monikerIDs,
 		}
 
 		if v.HoverResultID != "" {
-			hoverData := state.HoverData[v.HoverResultID]
+			hoverData, err := state.hoverText(v.HoverResultID)
+			if err != nil {
+				return types.DocumentData{}, errors.Wrap(err, "hoverText")
+			}
 			document.HoverResults[types.ID(v.HoverResultID)] = hoverData
 		}
 
@@ -127,7 +211,12 @@ func serializeDocument(state *State, doc lsif.Document) types.DocumentData {
 	}
 
 	for diagnosticID := range doc.Diagnostics {
-		for _, diagnostic := range state.Diagnostics[diagnosticID].Result {
+		diagnosticResult, err := state.diagnosticResult(diagnosticID)
+		if err != nil {
+			return types.DocumentData{}, errors.Wrap(err, "diagnosticResult")
+		}
+
+		for _, diagnostic := range diagnosticResult.Result {
 			document.Diagnostics = append(document.Diagnostics, types.DiagnosticData{
 				Severity:       diagnostic.Severity,
 				Code:           diagnostic.Code,
@@ -141,36 +230,74 @@ func serializeDocument(state *State, doc lsif.Document) types.DocumentData {
 		}
 	}
 
-	return document
+	return document, nil
 }
 
-func serializeResultChunks(state *State, numResultChunks int) map[int]types.ResultChunkData {
-	var resultChunks []types.ResultChunkData
-	for i := 0; i < numResultChunks; i++ {
-		resultChunks = append(resultChunks, types.ResultChunkData{
-			DocumentPaths:      map[types.ID]string{},
-			DocumentIDRangeIDs: map[types.ID][]types.DocumentIDRangeID{},
-		})
-	}
+// serializeResultChunks serializes the definition, reference, and implementation results of the
+// given state into the given number of result chunks, which are sent one at a time onto the returned
+// channel. Any error that stops the production of result chunks is sent to the error channel once
+// the result chunk channel is closed.
+func serializeResultChunks(ctx context.Context, state *State, numResultChunks int) (chan types.IndexedResultChunkData, <-chan error) {
+	ch := make(chan types.IndexedResultChunkData)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(ch)
+
+		// Determine the results of each chunk up-front so that each chunk can be built
+		// without holding the contents of any other chunk in memory.
+		resultIDs := make([][]resultID, numResultChunks)
+		for _, data := range []map[string]datastructures.DefaultIDSetMap{state.DefinitionData, state.ReferenceData, state.ImplementationData} {
+			for id := range data {
+				index := types.HashKey(types.ID(id), numResultChunks)
+				resultIDs[index] = append(resultIDs[index], resultID{data: data, id: id})
+			}
+		}
 
-	addToChunk(state, resultChunks, state.DefinitionData)
-	addToChunk(state, resultChunks, state.ReferenceData)
+		for index, ids := range resultIDs {
+			resultChunk, err := serializeResultChunk(state, ids)
+			if err != nil {
+				errs <- err
+				return
+			}
 
-	out := map[int]types.ResultChunkData{}
-	for id, resultChunk := range resultChunks {
-		if len(resultChunk.DocumentPaths) == 0 && len(resultChunk.DocumentIDRangeIDs) == 0 {
-			continue
+			if len(resultChunk.DocumentPaths) == 0 && len(resultChunk.DocumentIDRangeIDs) == 0 {
+				continue
+			}
+
+			select {
+			case ch <- types.IndexedResultChunkData{Index: index, ResultChunk: resultChunk}:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
 		}
+	}()
 
-		out[id] = resultChunk
-	}
+	return ch, errs
+}
 
-	return out
+// resultID identifies a result within one of the definition, reference, or implementation result
+// maps of a correlation state.
+type resultID struct {
+	data map[string]datastructures.DefaultIDSetMap
+	id   string
 }
 
-func addToChunk(state *State, resultChunks []types.ResultChunkData, data map[string]datastructures.DefaultIDSetMap) {
-	for id, documentRanges := range data {
-		resultChunk := resultChunks[types.HashKey(types.ID(id), len(resultChunks))]
+func serializeResultChunk(state *State, ids []resultID) (types.ResultChunkData, error) {
+	resultChunk := types.ResultChunkData{
+		DocumentPaths:      map[types.ID]string{},
+		DocumentIDRangeIDs: map[types.ID][]types.DocumentIDRangeID{},
+	}
+
+	for _, resultID := range ids {
+		id := resultID.id
+
+		documentRanges, err := state.resultData(resultID.data, id)
+		if err != nil {
+			return types.ResultChunkData{}, errors.Wrap(err, "resultData")
+		}
 
 		if len(documentRanges) == 0 {
 			// We may have pruned all document/ranges from a definition or reference result,
@@ -191,53 +318,93 @@ func addToChunk(state *State, resultChunks []types.ResultChunkData, data map[str
 			}
 		}
 	}
+
+	return resultChunk, nil
 }
 
-var (
-	getDefinitionResultID = func(r lsif.Range) string { return r.DefinitionResultID }
-	getReferenceResultID  = func(r lsif.Range) string { return r.ReferenceResultID }
-)
+// gatherMonikersLocations returns the locations of the definition, reference, and implementation
+// results attached to each moniker in the given state. The ranges of the state are read only once.
+func gatherMonikersLocations(state *State) (definitionRows, referenceRows, implementationRows []types.MonikerLocations, err error) {
+	definitionMonikers := datastructures.DefaultIDSetMap{}
+	referenceMonikers := datastructures.DefaultIDSetMap{}
+	implementationMonikers := datastructures.DefaultIDSetMap{}
 
-func gatherMonikersLocations(state *State, data map[string]datastructures.DefaultIDSetMap, getResultID func(r lsif.Range) string) []types.MonikerLocations {
-	monikers := datastructures.DefaultIDSetMap{}
-	for _, r := range state.RangeData {
-		resultID := getResultID(r)
-		if resultID != "" && len(r.MonikerIDs) > 0 {
-			s := monikers.GetOrCreate(resultID)
-			for id := range r.MonikerIDs {
-				s.Add(id)
-			}
+	for id := range state.RangeData {
+		r, err := state.rangeData(id)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "rangeData")
+		}
+
+		if len(r.MonikerIDs) == 0 {
+			continue
 		}
+
+		addMonikers(definitionMonikers, r.DefinitionResultID, r.MonikerIDs)
+		addMonikers(referenceMonikers, r.ReferenceResultID, r.MonikerIDs)
+		addMonikers(implementationMonikers, r.ImplementationResultID, r.MonikerIDs)
+	}
+
+	if definitionRows, err = gatherMonikerLocations(state, state.DefinitionData, definitionMonikers); err != nil {
+		return nil, nil, nil, err
+	}
+	if referenceRows, err = gatherMonikerLocations(state, state.ReferenceData, referenceMonikers); err != nil {
+		return nil, nil, nil, err
+	}
+	if implementationRows, err = gatherMonikerLocations(state, state.ImplementationData, implementationMonikers); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return definitionRows, referenceRows, implementationRows, nil
+}
+
+// addMonikers associates the given monikers with the given result, if any.
+func addMonikers(monikers datastructures.DefaultIDSetMap, resultID string, monikerIDs datastructures.IDSet) {
+	if resultID == "" {
+		return
+	}
+
+	s := monikers.GetOrCreate(resultID)
+	for id := range monikerIDs {
+		s.Add(id)
 	}
+}
 
+func gatherMonikerLocations(state *State, data map[string]datastructures.DefaultIDSetMap, monikers datastructures.DefaultIDSetMap) ([]types.MonikerLocations, error) {
 	uniques := map[string]types.MonikerLocations{}
-	for id, documentRanges := range data {
-		monikerIDs, ok := monikers[id]
-		if !ok {
+	for id, monikerIDs := range monikers {
+		if _, ok := data[id]; !ok {
 			continue
 		}
 
-		for monikerID := range monikerIDs {
-			var locations []types.Location
-			for documentID, rangeIDs := range documentRanges {
-				document := state.DocumentData[documentID]
-				if strings.HasPrefix(document.URI, "..") {
-					continue
-				}
+		documentRanges, err := state.resultData(data, id)
+		if err != nil {
+			return nil, errors.Wrap(err, "resultData")
+		}
 
-				for id := range rangeIDs {
-					r := state.RangeData[id]
+		var locations []types.Location
+		for documentID, rangeIDs := range documentRanges {
+			document := state.DocumentData[documentID]
+			if strings.HasPrefix(document.URI, "..") {
+				continue
+			}
 
-					locations = append(locations, types.Location{
-						URI:            document.URI,
-						StartLine:      r.StartLine,
-						StartCharacter: r.StartCharacter,
-						EndLine:        r.EndLine,
-						EndCharacter:   r.EndCharacter,
-					})
+			for id := range rangeIDs {
+				r, err := state.rangeData(id)
+				if err != nil {
+					return nil, errors.Wrap(err, "rangeData")
 				}
+
+				locations = append(locations, types.Location{
+					URI:            document.URI,
+					StartLine:      r.StartLine,
+					StartCharacter: r.StartCharacter,
+					EndLine:        r.EndLine,
+					EndCharacter:   r.EndCharacter,
+				})
 			}
+		}
 
+		for monikerID := range monikerIDs {
 			moniker := state.MonikerData[monikerID]
 			key := makeKey(moniker.Scheme, moniker.Identifier)
 			uniques[key] = types.MonikerLocations{
@@ -255,7 +422,7 @@ func gatherMonikersLocations(state *State, data map[string]datastructures.Defaul
 		}
 	}
 
-	return monikerLocations
+	return monikerLocations, nil
 }
 
 // TODO(efritz) - document
@@ -287,7 +454,7 @@ func gatherPackageReferences(state *State, dumpID int) ([]types.PackageReference
 		Scheme      string
 		Name        string
 		Version     string
-		Identifiers []string
+		Identifiers map[string]struct{}
 	}
 
 	uniques := map[string]ExpandedPackageReference{}
@@ -296,33 +463,128 @@ func gatherPackageReferences(state *State, dumpID int) ([]types.PackageReference
 		packageInfo := state.PackageInformationData[source.PackageInformationID]
 
 		key := makeKey(source.Scheme, packageInfo.Name, packageInfo.Version)
-		uniques[key] = ExpandedPackageReference{
-			Scheme:      source.Scheme,
-			Name:        packageInfo.Name,
-			Version:     packageInfo.Version,
-			Identifiers: append(uniques[key].Identifiers, source.Identifier),
+		if _, ok := uniques[key]; !ok {
+			uniques[key] = ExpandedPackageReference{
+				Scheme:      source.Scheme,
+				Name:        packageInfo.Name,
+				Version:     packageInfo.Version,
+				Identifiers: map[string]struct{}{},
+			}
 		}
+
+		uniques[key].Identifiers[source.Identifier] = struct{}{}
 	}
 
 	packageReferences := make([]types.PackageReference, 0, len(uniques))
 	for _, v := range uniques {
-		filter, err := bloomfilter.CreateFilter(v.Identifiers)
+		identifiers := make([]string, 0, len(v.Identifiers))
+		for identifier := range v.Identifiers {
+			identifiers = append(identifiers, identifier)
+		}
+		sort.Strings(identifiers)
+
+		filter, err := bloomfilter.CreateFilter(identifiers)
 		if err != nil {
 			return nil, errors.Wrap(err, "bloomfilter.CreateFilter")
 		}
 
 		packageReferences = append(packageReferences, types.PackageReference{
-			DumpID:  dumpID,
-			Scheme:  v.Scheme,
-			Name:    v.Name,
-			Version: v.Version,
-			Filter:  filter,
+			DumpID:          dumpID,
+			Scheme:          v.Scheme,
+			Name:            v.Name,
+			Version:         v.Version,
+			Filter:          filter,
+			IdentifierCount: len(identifiers),
 		})
 	}
 
 	return packageReferences, nil
 }
 
+// gatherSymbols flattens the document symbol trees of every document in the dump into
+// a list of symbols ordered by path and position. Range-based document symbols take
+// their name, kind, and full range from the tag of the range they reference, and are
+// skipped if the range is not tagged.
+func gatherSymbols(state *State) ([]types.SymbolData, error) {
+	var symbols []types.SymbolData
+	for _, doc := range state.DocumentData {
+		if strings.HasPrefix(doc.URI, "..") {
+			continue
+		}
+
+		for resultID := range doc.DocumentSymbols {
+			documentSymbolResult, err := state.documentSymbolResult(resultID)
+			if err != nil {
+				return nil, errors.Wrap(err, "documentSymbolResult")
+			}
+
+			if symbols, err = appendSymbols(state, symbols, doc.URI, "", documentSymbolResult.Result); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(symbols, func(i, j int) bool {
+		if symbols[i].URI != symbols[j].URI {
+			return symbols[i].URI < symbols[j].URI
+		}
+		if symbols[i].StartLine != symbols[j].StartLine {
+			return symbols[i].StartLine < symbols[j].StartLine
+		}
+		if symbols[i].StartCharacter != symbols[j].StartCharacter {
+			return symbols[i].StartCharacter < symbols[j].StartCharacter
+		}
+		return symbols[i].Name < symbols[j].Name
+	})
+
+	return symbols, nil
+}
+
+func appendSymbols(state *State, symbols []types.SymbolData, uri, containerName string, documentSymbols []lsif.DocumentSymbol) ([]types.SymbolData, error) {
+	for _, documentSymbol := range documentSymbols {
+		symbol := types.SymbolData{
+			URI:            uri,
+			Name:           documentSymbol.Name,
+			Kind:           documentSymbol.Kind,
+			ContainerName:  containerName,
+			StartLine:      documentSymbol.StartLine,
+			StartCharacter: documentSymbol.StartCharacter,
+			EndLine:        documentSymbol.EndLine,
+			EndCharacter:   documentSymbol.EndCharacter,
+		}
+
+		if documentSymbol.RangeID != "" {
+			if _, ok := state.RangeData[documentSymbol.RangeID]; !ok {
+				continue
+			}
+
+			r, err := state.rangeData(documentSymbol.RangeID)
+			if err != nil {
+				return nil, errors.Wrap(err, "rangeData")
+			}
+			if r.Tag == nil {
+				continue
+			}
+
+			symbol.Name = r.Tag.Text
+			symbol.Kind = r.Tag.Kind
+			symbol.StartLine = r.Tag.StartLine
+			symbol.StartCharacter = r.Tag.StartCharacter
+			symbol.EndLine = r.Tag.EndLine
+			symbol.EndCharacter = r.Tag.EndCharacter
+		}
+
+		symbols = append(symbols, symbol)
+
+		var err error
+		if symbols, err = appendSymbols(state, symbols, uri, symbol.Name, documentSymbol.Children); err != nil {
+			return nil, err
+		}
+	}
+
+	return symbols, nil
+}
+
 func makeKey(parts ...string) string {
 	return strings.Join(parts, ":")
 }
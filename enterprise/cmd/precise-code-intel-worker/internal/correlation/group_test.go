@@ -1,6 +1,8 @@
 package correlation
 
 import (
+	"io/ioutil"
+	"os"
 	"sort"
 	"strings"
 	"testing"
@@ -13,32 +15,43 @@ import (
 )
 
 func TestConvert(t *testing.T) {
+	testConvert(t, false)
+}
+
+func TestConvertSpilled(t *testing.T) {
+	testConvert(t, true)
+}
+
+func testConvert(t *testing.T, spill bool) {
 	state := &State{
 		DocumentData: map[string]lsif.Document{
 			"d01": {
-				URI:         "foo.go",
-				Contains:    datastructures.IDSet{"r01": {}, "r02": {}, "r03": {}},
-				Diagnostics: datastructures.IDSet{"d01": {}, "d02": {}},
+				URI:             "foo.go",
+				Contains:        datastructures.IDSet{"r01": {}, "r02": {}, "r03": {}},
+				Diagnostics:     datastructures.IDSet{"d01": {}, "d02": {}},
+				DocumentSymbols: datastructures.IDSet{"s01": {}},
 			},
 			"d02": {
-				URI:         "bar.go",
-				Contains:    datastructures.IDSet{"r04": {}, "r05": {}, "r06": {}},
-				Diagnostics: datastructures.IDSet{"d03": {}},
+				URI:             "bar.go",
+				Contains:        datastructures.IDSet{"r04": {}, "r05": {}, "r06": {}},
+				Diagnostics:     datastructures.IDSet{"d03": {}},
+				DocumentSymbols: datastructures.IDSet{},
 			},
 			"d03": {
-				URI:         "baz.go",
-				Contains:    datastructures.IDSet{"r07": {}, "r08": {}, "r09": {}},
-				Diagnostics: datastructures.IDSet{}, // TODO
+				URI:             "baz.go",
+				Contains:        datastructures.IDSet{"r07": {}, "r08": {}, "r09": {}},
+				Diagnostics:     datastructures.IDSet{}, // TODO
+				DocumentSymbols: datastructures.IDSet{"s02": {}},
 			},
 		},
 		RangeData: map[string]lsif.Range{
 			"r01": {StartLine: 1, StartCharacter: 2, EndLine: 3, EndCharacter: 4, DefinitionResultID: "x01", MonikerIDs: datastructures.IDSet{"m01": {}, "m02": {}}},
-			"r02": {StartLine: 2, StartCharacter: 3, EndLine: 4, EndCharacter: 5, ReferenceResultID: "x06", MonikerIDs: datastructures.IDSet{"m03": {}, "m04": {}}},
+			"r02": {StartLine: 2, StartCharacter: 3, EndLine: 4, EndCharacter: 5, ReferenceResultID: "x06", ImplementationResultID: "x10", MonikerIDs: datastructures.IDSet{"m03": {}, "m04": {}}},
 			"r03": {StartLine: 3, StartCharacter: 4, EndLine: 5, EndCharacter: 6, DefinitionResultID: "x02"},
 			"r04": {StartLine: 4, StartCharacter: 5, EndLine: 6, EndCharacter: 7, ReferenceResultID: "x07"},
 			"r05": {StartLine: 5, StartCharacter: 6, EndLine: 7, EndCharacter: 8, DefinitionResultID: "x03"},
 			"r06": {StartLine: 6, StartCharacter: 7, EndLine: 8, EndCharacter: 9, HoverResultID: "x08"},
-			"r07": {StartLine: 7, StartCharacter: 8, EndLine: 9, EndCharacter: 0, DefinitionResultID: "x04"},
+			"r07": {StartLine: 7, StartCharacter: 8, EndLine: 9, EndCharacter: 0, DefinitionResultID: "x04", Tag: &lsif.RangeTag{Type: "definition", Text: "Baz", Kind: 5, StartLine: 7, StartCharacter: 0, EndLine: 12, EndCharacter: 1}},
 			"r08": {StartLine: 8, StartCharacter: 9, EndLine: 0, EndCharacter: 1, HoverResultID: "x09"},
 			"r09": {StartLine: 9, StartCharacter: 0, EndLine: 1, EndCharacter: 2, DefinitionResultID: "x05"},
 		},
@@ -53,6 +66,9 @@ func TestConvert(t *testing.T) {
 			"x06": {"d01": {"r03": {}}, "d03": {"r07": {}, "r09": {}}},
 			"x07": {"d01": {"r02": {}}, "d03": {"r07": {}, "r09": {}}},
 		},
+		ImplementationData: map[string]datastructures.DefaultIDSetMap{
+			"x10": {"d02": {"r05": {}}},
+		},
 		HoverData: map[string]string{
 			"x08": "foo",
 			"x09": "bar",
@@ -121,14 +137,47 @@ func TestConvert(t *testing.T) {
 				},
 			},
 		},
+		DocumentSymbolResults: map[string]lsif.DocumentSymbolResult{
+			"s01": {
+				Result: []lsif.DocumentSymbol{
+					{
+						Name: "Foo", Kind: 23, StartLine: 1, StartCharacter: 0, EndLine: 6, EndCharacter: 1,
+						Children: []lsif.DocumentSymbol{
+							{Name: "bar", Kind: 8, StartLine: 2, StartCharacter: 1, EndLine: 2, EndCharacter: 10},
+						},
+					},
+				},
+			},
+			"s02": {
+				Result: []lsif.DocumentSymbol{
+					{
+						RangeID:  "r07",
+						Children: []lsif.DocumentSymbol{{RangeID: "r08"}},
+					},
+				},
+			},
+		},
 		ImportedMonikers: datastructures.IDSet{"m01": {}},
 		ExportedMonikers: datastructures.IDSet{"m03": {}},
 	}
 
-	actualBundleData, err := groupBundleData(state, 42)
+	if spill {
+		tempDir, err := ioutil.TempDir("", "")
+		if err != nil {
+			t.Fatalf("unexpected error creating temp directory: %s", err)
+		}
+		defer os.RemoveAll(tempDir)
+
+		if err := state.spillPayloads(tempDir); err != nil {
+			t.Fatalf("unexpected error spilling payloads: %s", err)
+		}
+	}
+
+	groupedBundleData, err := groupBundleData(state, 42)
 	if err != nil {
 		t.Fatalf("unexpected error converting correlation state to types: %s", err)
 	}
+	actualBundleData := readGroupedBundleData(t, groupedBundleData)
 	// Ensure arrays have deterministic order so we can compare with a canned expected object structure
 	normalizeGroupedBundleData(actualBundleData)
 
@@ -137,7 +186,7 @@ func TestConvert(t *testing.T) {
 		t.Fatalf("unexpected error creating bloom filter: %s", err)
 	}
 
-	expectedBundleData := &GroupedBundleData{
+	expectedBundleData := &groupedBundleDataMaps{
 		Meta: types.MetaData{
 			NumResultChunks: 1,
 		},
@@ -145,7 +194,7 @@ func TestConvert(t *testing.T) {
 			"foo.go": {
 				Ranges: map[types.ID]types.RangeData{
 					"r01": {StartLine: 1, StartCharacter: 2, EndLine: 3, EndCharacter: 4, DefinitionResultID: "x01", MonikerIDs: []types.ID{"m01", "m02"}},
-					"r02": {StartLine: 2, StartCharacter: 3, EndLine: 4, EndCharacter: 5, ReferenceResultID: "x06", MonikerIDs: []types.ID{"m03", "m04"}},
+					"r02": {StartLine: 2, StartCharacter: 3, EndLine: 4, EndCharacter: 5, ReferenceResultID: "x06", ImplementationResultID: "x10", MonikerIDs: []types.ID{"m03", "m04"}},
 					"r03": {StartLine: 3, StartCharacter: 4, EndLine: 5, EndCharacter: 6, DefinitionResultID: "x02"},
 				},
 				HoverResults: map[types.ID]string{},
@@ -269,6 +318,9 @@ func TestConvert(t *testing.T) {
 						{DocumentID: "d03", RangeID: "r07"},
 						{DocumentID: "d03", RangeID: "r09"},
 					},
+					"x10": {
+						{DocumentID: "d02", RangeID: "r05"},
+					},
 				},
 			},
 		},
@@ -312,11 +364,32 @@ func TestConvert(t *testing.T) {
 				},
 			},
 		},
+		Implementations: []types.MonikerLocations{
+			{
+				Scheme:     "scheme C",
+				Identifier: "ident C",
+				Locations: []types.Location{
+					{URI: "bar.go", StartLine: 5, StartCharacter: 6, EndLine: 7, EndCharacter: 8},
+				},
+			},
+			{
+				Scheme:     "scheme D",
+				Identifier: "ident D",
+				Locations: []types.Location{
+					{URI: "bar.go", StartLine: 5, StartCharacter: 6, EndLine: 7, EndCharacter: 8},
+				},
+			},
+		},
 		Packages: []types.Package{
 			{DumpID: 42, Scheme: "scheme C", Name: "pkg B", Version: "1.2.3"},
 		},
 		PackageReferences: []types.PackageReference{
-			{DumpID: 42, Scheme: "scheme A", Name: "pkg A", Version: "0.1.0", Filter: expectedFilter},
+			{DumpID: 42, Scheme: "scheme A", Name: "pkg A", Version: "0.1.0", Filter: expectedFilter, IdentifierCount: 1},
+		},
+		Symbols: []types.SymbolData{
+			{URI: "baz.go", Name: "Baz", Kind: 5, StartLine: 7, StartCharacter: 0, EndLine: 12, EndCharacter: 1},
+			{URI: "foo.go", Name: "Foo", Kind: 23, StartLine: 1, StartCharacter: 0, EndLine: 6, EndCharacter: 1},
+			{URI: "foo.go", Name: "bar", Kind: 8, ContainerName: "Foo", StartLine: 2, StartCharacter: 1, EndLine: 2, EndCharacter: 10},
 		},
 	}
 
@@ -328,7 +401,49 @@ func TestConvert(t *testing.T) {
 //
 //
 
-func normalizeGroupedBundleData(groupedBundleData *GroupedBundleData) {
+// groupedBundleDataMaps is a GroupedBundleData with its documents and result chunks read into maps.
+type groupedBundleDataMaps struct {
+	Meta              types.MetaData
+	Documents         map[string]types.DocumentData
+	ResultChunks      map[int]types.ResultChunkData
+	Definitions       []types.MonikerLocations
+	References        []types.MonikerLocations
+	Implementations   []types.MonikerLocations
+	Packages          []types.Package
+	PackageReferences []types.PackageReference
+	Symbols           []types.SymbolData
+}
+
+// readGroupedBundleData drains the channels of the given grouped bundle data and closes it.
+func readGroupedBundleData(t *testing.T, groupedBundleData *GroupedBundleData) *groupedBundleDataMaps {
+	documents := map[string]types.DocumentData{}
+	for v := range groupedBundleData.Documents {
+		documents[v.Path] = v.Document
+	}
+
+	resultChunks := map[int]types.ResultChunkData{}
+	for v := range groupedBundleData.ResultChunks {
+		resultChunks[v.Index] = v.ResultChunk
+	}
+
+	if err := groupedBundleData.Close(); err != nil {
+		t.Fatalf("unexpected error closing grouped bundle data: %s", err)
+	}
+
+	return &groupedBundleDataMaps{
+		Meta:              groupedBundleData.Meta,
+		Documents:         documents,
+		ResultChunks:      resultChunks,
+		Definitions:       groupedBundleData.Definitions,
+		References:        groupedBundleData.References,
+		Implementations:   groupedBundleData.Implementations,
+		Packages:          groupedBundleData.Packages,
+		PackageReferences: groupedBundleData.PackageReferences,
+		Symbols:           groupedBundleData.Symbols,
+	}
+}
+
+func normalizeGroupedBundleData(groupedBundleData *groupedBundleDataMaps) {
 	for _, document := range groupedBundleData.Documents {
 		sortDiagnostics(document.Diagnostics)
 
@@ -345,6 +460,7 @@ func normalizeGroupedBundleData(groupedBundleData *GroupedBundleData) {
 
 	sortMonikerLocations(groupedBundleData.Definitions)
 	sortMonikerLocations(groupedBundleData.References)
+	sortMonikerLocations(groupedBundleData.Implementations)
 }
 
 func sortMonikerIDs(s []types.ID) {
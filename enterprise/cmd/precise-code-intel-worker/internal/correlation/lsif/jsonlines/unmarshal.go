@@ -66,13 +66,14 @@ func unmarshalEdge(line []byte) (interface{}, error) {
 }
 
 var vertexUnmarshalers = map[string]func(line []byte) (interface{}, error){
-	"metaData":           unmarshalMetaData,
-	"document":           unmarshalDocument,
-	"range":              unmarshalRange,
-	"hoverResult":        unmarshalHover,
-	"moniker":            unmarshalMoniker,
-	"packageInformation": unmarshalPackageInformation,
-	"diagnosticResult":   unmarshalDiagnosticResult,
+	"metaData":             unmarshalMetaData,
+	"document":             unmarshalDocument,
+	"range":                unmarshalRange,
+	"hoverResult":          unmarshalHover,
+	"moniker":              unmarshalMoniker,
+	"packageInformation":   unmarshalPackageInformation,
+	"diagnosticResult":     unmarshalDiagnosticResult,
+	"documentSymbolResult": unmarshalDocumentSymbolResult,
 }
 
 func unmarshalMetaData(line []byte) (interface{}, error) {
@@ -99,9 +100,10 @@ func unmarshalDocument(line []byte) (interface{}, error) {
 	}
 
 	return lsif.Document{
-		URI:         payload.URI,
-		Contains:    datastructures.IDSet{},
-		Diagnostics: datastructures.IDSet{},
+		URI:             payload.URI,
+		Contains:        datastructures.IDSet{},
+		Diagnostics:     datastructures.IDSet{},
+		DocumentSymbols: datastructures.IDSet{},
 	}, nil
 }
 
@@ -110,20 +112,45 @@ func unmarshalRange(line []byte) (interface{}, error) {
 		Line      int `json:"line"`
 		Character int `json:"character"`
 	}
+	type _range struct {
+		Start _position `json:"start"`
+		End   _position `json:"end"`
+	}
+	type _tag struct {
+		Type      string `json:"type"`
+		Text      string `json:"text"`
+		Kind      int    `json:"kind"`
+		FullRange _range `json:"fullRange"`
+	}
 	var payload struct {
 		Start _position `json:"start"`
 		End   _position `json:"end"`
+		Tag   *_tag     `json:"tag"`
 	}
 	if err := unmarshaller.Unmarshal(line, &payload); err != nil {
 		return nil, err
 	}
 
+	var tag *lsif.RangeTag
+	if payload.Tag != nil {
+		tag = &lsif.RangeTag{
+			Type:           payload.Tag.Type,
+			Text:           payload.Tag.Text,
+			Kind:           payload.Tag.Kind,
+			StartLine:      payload.Tag.FullRange.Start.Line,
+			StartCharacter: payload.Tag.FullRange.Start.Character,
+			EndLine:        payload.Tag.FullRange.End.Line,
+			EndCharacter:   payload.Tag.FullRange.End.Character,
+		}
+	}
+
 	return lsif.Range{
 		StartLine:      payload.Start.Line,
 		StartCharacter: payload.Start.Character,
 		EndLine:        payload.End.Line,
 		EndCharacter:   payload.End.Character,
 		MonikerIDs:     datastructures.IDSet{},
+		Tag:            tag,
 	}, nil
 }
 
@@ -254,6 +281,54 @@ func unmarshalDiagnosticResult(line []byte) (interface{}, error) {
 	return lsif.DiagnosticResult{Result: diagnostics}, nil
 }
 
+// documentSymbol is the union of the inline DocumentSymbol and the RangeBasedDocumentSymbol
+// elements of a documentSymbolResult vertex.
+type documentSymbol struct {
+	ID    StringOrInt `json:"id"`
+	Name  string      `json:"name"`
+	Kind  int         `json:"kind"`
+	Range struct {
+		Start struct {
+			Line      int `json:"line"`
+			Character int `json:"character"`
+		} `json:"start"`
+		End struct {
+			Line      int `json:"line"`
+			Character int `json:"character"`
+		} `json:"end"`
+	} `json:"range"`
+	Children []documentSymbol `json:"children"`
+}
+
+func unmarshalDocumentSymbolResult(line []byte) (interface{}, error) {
+	var payload struct {
+		Results []documentSymbol `json:"result"`
+	}
+	if err := unmarshaller.Unmarshal(line, &payload); err != nil {
+		return nil, err
+	}
+
+	return lsif.DocumentSymbolResult{Result: convertDocumentSymbols(payload.Results)}, nil
+}
+
+func convertDocumentSymbols(symbols []documentSymbol) []lsif.DocumentSymbol {
+	var converted []lsif.DocumentSymbol
+	for _, symbol := range symbols {
+		converted = append(converted, lsif.DocumentSymbol{
+			RangeID:        string(symbol.ID),
+			Name:           symbol.Name,
+			Kind:           symbol.Kind,
+			StartLine:      symbol.Range.Start.Line,
+			StartCharacter: symbol.Range.Start.Character,
+			EndLine:        symbol.Range.End.Line,
+			EndCharacter:   symbol.Range.End.Character,
+			Children:       convertDocumentSymbols(symbol.Children),
+		})
+	}
+
+	return converted
+}
+
 type StringOrInt string
 
 func (id *StringOrInt) UnmarshalJSON(raw []byte) error {
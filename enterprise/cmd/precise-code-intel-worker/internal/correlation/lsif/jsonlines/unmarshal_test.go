@@ -97,9 +97,10 @@ func TestUnmarshalDocument(t *testing.T) {
 	}
 
 	expectedDocument := lsif.Document{
-		URI:         "file:///test/root/foo.go",
-		Contains:    datastructures.IDSet{},
-		Diagnostics: datastructures.IDSet{},
+		URI:             "file:///test/root/foo.go",
+		Contains:        datastructures.IDSet{},
+		Diagnostics:     datastructures.IDSet{},
+		DocumentSymbols: datastructures.IDSet{},
 	}
 	if diff := cmp.Diff(expectedDocument, document); diff != "" {
 		t.Errorf("unexpected document (-want +got):\n%s", diff)
@@ -127,6 +128,33 @@ func TestUnmarshalRange(t *testing.T) {
 	}
 }
 
+func TestUnmarshalRangeTag(t *testing.T) {
+	r, err := unmarshalRange([]byte(`{"id": "04", "type": "vertex", "label": "range", "start": {"line": 1, "character": 2}, "end": {"line": 1, "character": 5}, "tag": {"type": "definition", "text": "foo", "kind": 12, "fullRange": {"start": {"line": 1, "character": 0}, "end": {"line": 3, "character": 1}}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling range data: %s", err)
+	}
+
+	expectedRange := lsif.Range{
+		StartLine:      1,
+		StartCharacter: 2,
+		EndLine:        1,
+		EndCharacter:   5,
+		MonikerIDs:     datastructures.IDSet{},
+		Tag: &lsif.RangeTag{
+			Type:           "definition",
+			Text:           "foo",
+			Kind:           12,
+			StartLine:      1,
+			StartCharacter: 0,
+			EndLine:        3,
+			EndCharacter:   1,
+		},
+	}
+	if diff := cmp.Diff(expectedRange, r); diff != "" {
+		t.Errorf("unexpected range (-want +got):\n%s", diff)
+	}
+}
+
 func TestUnmarshalHover(t *testing.T) {
 	testCases := []struct {
 		contents      string
@@ -221,3 +249,42 @@ func TestUnmarshalDiagnosticResult(t *testing.T) {
 		t.Errorf("unexpected diagnostic result (-want +got):\n%s", diff)
 	}
 }
+
+func TestUnmarshalDocumentSymbolResult(t *testing.T) {
+	documentSymbolResult, err := unmarshalDocumentSymbolResult([]byte(`{"id": 19, "type": "vertex", "label": "documentSymbolResult", "result": [{"name": "Server", "kind": 23, "range": {"start": {"line": 1, "character": 0}, "end": {"line": 4, "character": 1}}, "children": [{"name": "Serve", "kind": 6, "range": {"start": {"line": 2, "character": 1}, "end": {"line": 3, "character": 2}}}]}, {"id": 5, "children": [{"id": 6}]}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling document symbol result data: %s", err)
+	}
+
+	expectedDocumentSymbolResult := lsif.DocumentSymbolResult{
+		Result: []lsif.DocumentSymbol{
+			{
+				Name:           "Server",
+				Kind:           23,
+				StartLine:      1,
+				StartCharacter: 0,
+				EndLine:        4,
+				EndCharacter:   1,
+				Children: []lsif.DocumentSymbol{
+					{
+						Name:           "Serve",
+						Kind:           6,
+						StartLine:      2,
+						StartCharacter: 1,
+						EndLine:        3,
+						EndCharacter:   2,
+					},
+				},
+			},
+			{
+				RangeID: "5",
+				Children: []lsif.DocumentSymbol{
+					{RangeID: "6"},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDocumentSymbolResult, documentSymbolResult); diff != "" {
+		t.Errorf("unexpected document symbol result (-want +got):\n%s", diff)
+	}
+}
@@ -22,114 +22,168 @@ type MetaData struct {
 }
 
 type Document struct {
-	URI         string
-	Contains    datastructures.IDSet
-	Diagnostics datastructures.IDSet
+	URI             string
+	Contains        datastructures.IDSet
+	Diagnostics     datastructures.IDSet
+	DocumentSymbols datastructures.IDSet
 }
 
 type Range struct {
-	StartLine          int
-	StartCharacter     int
-	EndLine            int
-	EndCharacter       int
-	DefinitionResultID string
-	ReferenceResultID  string
-	HoverResultID      string
-	MonikerIDs         datastructures.IDSet
+	StartLine              int
+	StartCharacter         int
+	EndLine                int
+	EndCharacter           int
+	DefinitionResultID     string
+	ReferenceResultID      string
+	ImplementationResultID string
+	HoverResultID          string
+	MonikerIDs             datastructures.IDSet
+	Tag                    *RangeTag
 }
 
 func (d Range) SetDefinitionResultID(id string) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: id,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         d.MonikerIDs,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     id,
+		ReferenceResultID:      d.ReferenceResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
+		Tag:                    d.Tag,
 	}
 }
 
 func (d Range) SetReferenceResultID(id string) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  id,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         d.MonikerIDs,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      id,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
+		Tag:                    d.Tag,
+	}
+}
+
+func (d Range) SetImplementationResultID(id string) Range {
+	return Range{
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		ImplementationResultID: id,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
+		Tag:                    d.Tag,
 	}
 }
 
 func (d Range) SetHoverResultID(id string) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      id,
-		MonikerIDs:         d.MonikerIDs,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          id,
+		MonikerIDs:             d.MonikerIDs,
+		Tag:                    d.Tag,
 	}
 }
 
 func (d Range) SetMonikerIDs(ids datastructures.IDSet) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         ids,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             ids,
+		Tag:                    d.Tag,
 	}
 }
 
+// RangeTag is the optional tag of a range vertex that describes the symbol declared, defined, or
+// referenced by the range. Document symbol results may refer to ranges tagged as declarations
+// or definitions instead of repeating the name and kind of each symbol.
+type RangeTag struct {
+	Type           string // declaration, definition, reference, or unknown
+	Text           string
+	Kind           int
+	StartLine      int // start of the full range
+	StartCharacter int
+	EndLine        int // end of the full range
+	EndCharacter   int
+}
+
 type ResultSet struct {
-	DefinitionResultID string
-	ReferenceResultID  string
-	HoverResultID      string
-	MonikerIDs         datastructures.IDSet
+	DefinitionResultID     string
+	ReferenceResultID      string
+	ImplementationResultID string
+	HoverResultID          string
+	MonikerIDs             datastructures.IDSet
 }
 
 func (d ResultSet) SetDefinitionResultID(id string) ResultSet {
 	return ResultSet{
-		DefinitionResultID: id,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         d.MonikerIDs,
+		DefinitionResultID:     id,
+		ReferenceResultID:      d.ReferenceResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
 	}
 }
 
 func (d ResultSet) SetReferenceResultID(id string) ResultSet {
 	return ResultSet{
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  id,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         d.MonikerIDs,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      id,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
+	}
+}
+
+func (d ResultSet) SetImplementationResultID(id string) ResultSet {
+	return ResultSet{
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		ImplementationResultID: id,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
 	}
 }
 
 func (d ResultSet) SetHoverResultID(id string) ResultSet {
 	return ResultSet{
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      id,
-		MonikerIDs:         d.MonikerIDs,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          id,
+		MonikerIDs:             d.MonikerIDs,
 	}
 }
 
 func (d ResultSet) SetMonikerIDs(ids datastructures.IDSet) ResultSet {
 	return ResultSet{
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         ids,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             ids,
 	}
 }
 
@@ -168,3 +222,20 @@ type Diagnostic struct {
 	EndLine        int
 	EndCharacter   int
 }
+
+type DocumentSymbolResult struct {
+	Result []DocumentSymbol
+}
+
+// DocumentSymbol is a node of a document symbol result. Range-based document symbols refer to a
+// tagged range by RangeID; otherwise the name, kind, and range of the symbol are given inline.
+type DocumentSymbol struct {
+	RangeID        string
+	Name           string
+	Kind           int
+	StartLine      int
+	StartCharacter int
+	EndLine        int
+	EndCharacter   int
+	Children       []DocumentSymbol
+}
@@ -27,18 +27,37 @@ func prune(state *State, root string, getChildren existence.GetChildrenFunc) err
 		}
 	}
 
-	pruneFromDefinitionReferences(state, state.DefinitionData)
-	pruneFromDefinitionReferences(state, state.ReferenceData)
+	for _, data := range []map[string]datastructures.DefaultIDSetMap{state.DefinitionData, state.ReferenceData, state.ImplementationData} {
+		if err := pruneFromDefinitionReferences(state, data); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func pruneFromDefinitionReferences(state *State, definitionReferenceData map[string]datastructures.DefaultIDSetMap) {
-	for _, documentRanges := range definitionReferenceData {
+func pruneFromDefinitionReferences(state *State, definitionReferenceData map[string]datastructures.DefaultIDSetMap) error {
+	for id := range definitionReferenceData {
+		documentRanges, err := state.resultData(definitionReferenceData, id)
+		if err != nil {
+			return err
+		}
+
+		changed := false
 		for documentID := range documentRanges {
 			if _, ok := state.DocumentData[documentID]; !ok {
 				// Document was pruned, remove reference
 				delete(documentRanges, documentID)
+				changed = true
+			}
+		}
+
+		if changed {
+			if err := state.setResultData(definitionReferenceData, id, documentRanges); err != nil {
+				return err
 			}
 		}
 	}
+
+	return nil
 }
@@ -39,6 +39,9 @@ func TestPrune(t *testing.T) {
 			"x03": {"d02": {}},
 			"x04": {"d02": {}, "d05": {}},
 		},
+		ImplementationData: map[string]datastructures.DefaultIDSetMap{
+			"x05": {"d03": {}, "d04": {}},
+		},
 	}
 
 	if err := prune(state, "root", getChildren); err != nil {
@@ -59,6 +62,9 @@ func TestPrune(t *testing.T) {
 			"x03": {"d02": {}},
 			"x04": {"d02": {}},
 		},
+		ImplementationData: map[string]datastructures.DefaultIDSetMap{
+			"x05": {"d03": {}},
+		},
 	}
 	if diff := cmp.Diff(expectedState, state); diff != "" {
 		t.Errorf("unexpected state (-want +got):\n%s", diff)
@@ -0,0 +1,221 @@
+package correlation
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// SpillOptions controls when correlation moves the bulk of the data of an index to disk.
+type SpillOptions struct {
+	// Threshold is the number of uncompressed bytes of the index that can be read before the
+	// ranges, the definition, reference, and implementation results, and the payloads of hover,
+	// diagnostic, and document symbol results are written to a temporary file instead of being
+	// held in memory. A zero value disables spilling.
+	Threshold int64
+
+	// Dir is the directory in which the temporary file is created. The default directory
+	// for temporary files is used if empty.
+	Dir string
+}
+
+// spillBufferSize is the number of bytes of payloads buffered in memory before they are
+// written to the spill file.
+const spillBufferSize = 1 << 20
+
+// PayloadSpill is an append-only temporary file holding the JSON-encoded payloads of vertices.
+// Only the location of each payload within the file is kept in memory. A payload replaced by a
+// later write remains in the file until it is closed. A PayloadSpill is safe for concurrent use.
+type PayloadSpill struct {
+	m       sync.Mutex
+	file    *os.File
+	size    int64  // the number of bytes written to file
+	buf     []byte // payloads not yet written to file, which start at offset size
+	offsets map[string]spillOffset
+	items   map[string][]spillOffset
+}
+
+type spillOffset struct {
+	offset int64
+	length int
+}
+
+// newPayloadSpill creates an empty payload spill file in the given directory.
+func newPayloadSpill(dir string) (*PayloadSpill, error) {
+	file, err := ioutil.TempFile(dir, "correlation-spill-")
+	if err != nil {
+		return nil, err
+	}
+
+	return &PayloadSpill{
+		file:    file,
+		offsets: map[string]spillOffset{},
+		items:   map[string][]spillOffset{},
+	}, nil
+}
+
+// add writes the given payload to the end of the spill file, replacing any previous payload
+// with the same identifier. Element identifiers are unique within an index, so payloads of
+// different element types can share the same file.
+func (s *PayloadSpill) add(id string, payload interface{}) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	offset, err := s.write(payload)
+	if err != nil {
+		return err
+	}
+
+	s.offsets[id] = offset
+	return nil
+}
+
+// get decodes the payload with the given identifier into v. The value of v is left unchanged
+// if no such payload exists.
+func (s *PayloadSpill) get(id string, v interface{}) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	offset, ok := s.offsets[id]
+	if !ok {
+		return nil
+	}
+
+	data, err := s.read(offset)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// appendItem adds the given payload to the list of items with the given identifier.
+func (s *PayloadSpill) appendItem(id string, payload interface{}) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	offset, err := s.write(payload)
+	if err != nil {
+		return err
+	}
+
+	s.items[id] = append(s.items[id], offset)
+	return nil
+}
+
+// setItems replaces the list of items with the given identifier by the given payload.
+func (s *PayloadSpill) setItems(id string, payload interface{}) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	offset, err := s.write(payload)
+	if err != nil {
+		return err
+	}
+
+	s.items[id] = []spillOffset{offset}
+	return nil
+}
+
+// getItems returns the raw payloads of the list of items with the given identifier in the
+// order in which they were added.
+func (s *PayloadSpill) getItems(id string) ([]json.RawMessage, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	offsets := s.items[id]
+	payloads := make([]json.RawMessage, 0, len(offsets))
+	for _, offset := range offsets {
+		data, err := s.read(offset)
+		if err != nil {
+			return nil, err
+		}
+
+		payloads = append(payloads, data)
+	}
+
+	return payloads, nil
+}
+
+// remove forgets the payload and the list of items with the given identifier.
+func (s *PayloadSpill) remove(id string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	delete(s.offsets, id)
+	delete(s.items, id)
+}
+
+// write encodes the given payload and appends it to the spill file. The caller must hold the lock.
+func (s *PayloadSpill) write(payload interface{}) (spillOffset, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return spillOffset{}, err
+	}
+
+	offset := spillOffset{offset: s.size + int64(len(s.buf)), length: len(data)}
+	s.buf = append(s.buf, data...)
+
+	if len(s.buf) >= spillBufferSize {
+		if _, err := s.file.Write(s.buf); err != nil {
+			return spillOffset{}, err
+		}
+
+		s.size += int64(len(s.buf))
+		s.buf = s.buf[:0]
+	}
+
+	return offset, nil
+}
+
+// read returns the data at the given offset. The caller must hold the lock.
+func (s *PayloadSpill) read(offset spillOffset) ([]byte, error) {
+	data := make([]byte, offset.length)
+
+	if offset.offset >= s.size {
+		// Payload has not yet been written to disk
+		copy(data, s.buf[offset.offset-s.size:])
+		return data, nil
+	}
+
+	if _, err := s.file.ReadAt(data, offset.offset); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Close closes and removes the spill file.
+func (s *PayloadSpill) Close() (err error) {
+	if closeErr := s.file.Close(); closeErr != nil {
+		err = multierror.Append(err, closeErr)
+	}
+	if removeErr := os.Remove(s.file.Name()); removeErr != nil {
+		err = multierror.Append(err, removeErr)
+	}
+
+	return err
+}
+
+// countingReader tracks the number of bytes read from the underlying reader. The count
+// may be read concurrently with calls to Read.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	atomic.AddInt64(&r.n, int64(n))
+	return n, err
+}
+
+// Count returns the number of bytes read so far.
+func (r *countingReader) Count() int64 {
+	return atomic.LoadInt64(&r.n)
+}
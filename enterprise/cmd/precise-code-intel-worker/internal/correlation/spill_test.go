@@ -0,0 +1,170 @@
+package correlation
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/correlation/datastructures"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/correlation/lsif"
+)
+
+func TestSpillPayloads(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error creating temp directory: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	diagnosticResult := lsif.DiagnosticResult{
+		Result: []lsif.Diagnostic{
+			{Severity: 1, Code: "2322", Message: "Type '10' is not assignable to type 'string'.", Source: "eslint", StartLine: 1, StartCharacter: 5, EndLine: 1, EndCharacter: 6},
+		},
+	}
+	documentSymbolResult := lsif.DocumentSymbolResult{
+		Result: []lsif.DocumentSymbol{
+			{RangeID: "r01", Children: []lsif.DocumentSymbol{{Name: "foo", Kind: 12, StartLine: 2, EndLine: 3}}},
+		},
+	}
+
+	state := &State{
+		HoverData:             map[string]string{"h01": "hover text"},
+		Diagnostics:           map[string]lsif.DiagnosticResult{"d01": diagnosticResult},
+		DocumentSymbolResults: map[string]lsif.DocumentSymbolResult{},
+		DocumentData:          map[string]lsif.Document{"x01": {URI: "foo.go", Contains: datastructures.IDSet{}}},
+	}
+
+	if err := state.spillPayloads(tempDir); err != nil {
+		t.Fatalf("unexpected error spilling payloads: %s", err)
+	}
+
+	// Added after the spill
+	if err := state.addHoverText("h02", "more hover text"); err != nil {
+		t.Fatalf("unexpected error adding hover text: %s", err)
+	}
+	if err := state.addDocumentSymbolResult("s01", documentSymbolResult); err != nil {
+		t.Fatalf("unexpected error adding document symbol result: %s", err)
+	}
+
+	expectedHoverData := map[string]string{"h01": "", "h02": ""}
+	if diff := cmp.Diff(expectedHoverData, state.HoverData); diff != "" {
+		t.Errorf("unexpected hover data (-want +got):\n%s", diff)
+	}
+
+	for id, expectedText := range map[string]string{"h01": "hover text", "h02": "more hover text", "h03": ""} {
+		text, err := state.hoverText(id)
+		if err != nil {
+			t.Fatalf("unexpected error reading hover text: %s", err)
+		}
+		if text != expectedText {
+			t.Errorf("unexpected hover text for %s. want=%q have=%q", id, expectedText, text)
+		}
+	}
+
+	if result, err := state.diagnosticResult("d01"); err != nil {
+		t.Fatalf("unexpected error reading diagnostic result: %s", err)
+	} else if diff := cmp.Diff(diagnosticResult, result); diff != "" {
+		t.Errorf("unexpected diagnostic result (-want +got):\n%s", diff)
+	}
+
+	if result, err := state.documentSymbolResult("s01"); err != nil {
+		t.Fatalf("unexpected error reading document symbol result: %s", err)
+	} else if diff := cmp.Diff(documentSymbolResult, result); diff != "" {
+		t.Errorf("unexpected document symbol result (-want +got):\n%s", diff)
+	}
+
+	if err := state.Close(); err != nil {
+		t.Fatalf("unexpected error closing state: %s", err)
+	}
+
+	if names, err := ioutil.ReadDir(tempDir); err != nil {
+		t.Fatalf("unexpected error reading temp directory: %s", err)
+	} else if len(names) != 0 {
+		t.Errorf("unexpected files in temp directory. want=%d have=%d", 0, len(names))
+	}
+}
+
+func TestSpillRangesAndResults(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error creating temp directory: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	state := &State{
+		RangeData: map[string]lsif.Range{
+			"r01": {StartLine: 1, StartCharacter: 2, EndLine: 3, EndCharacter: 4},
+		},
+		DefinitionData: map[string]datastructures.DefaultIDSetMap{
+			"x01": {"d01": datastructures.IDSet{"r01": {}}},
+			"x02": {},
+		},
+		ReferenceData:         map[string]datastructures.DefaultIDSetMap{},
+		ImplementationData:    map[string]datastructures.DefaultIDSetMap{},
+		HoverData:             map[string]string{},
+		Diagnostics:           map[string]lsif.DiagnosticResult{},
+		DocumentSymbolResults: map[string]lsif.DocumentSymbolResult{},
+	}
+
+	if err := state.spillPayloads(tempDir); err != nil {
+		t.Fatalf("unexpected error spilling payloads: %s", err)
+	}
+	defer state.Close()
+
+	if diff := cmp.Diff(map[string]lsif.Range{"r01": {}}, state.RangeData); diff != "" {
+		t.Errorf("unexpected range data (-want +got):\n%s", diff)
+	}
+
+	if ok, err := state.updateRangeData("r01", func(r lsif.Range) lsif.Range { return r.SetDefinitionResultID("x01") }); err != nil || !ok {
+		t.Fatalf("unexpected result updating range. ok=%v err=%v", ok, err)
+	}
+	if ok, err := state.updateRangeData("r02", func(r lsif.Range) lsif.Range { return r }); err != nil || ok {
+		t.Fatalf("unexpected result updating missing range. ok=%v err=%v", ok, err)
+	}
+
+	// Write enough data to flush the buffer to disk
+	for i := 0; i < spillBufferSize/len("hover text"); i++ {
+		if err := state.addHoverText("h01", "hover text"); err != nil {
+			t.Fatalf("unexpected error adding hover text: %s", err)
+		}
+	}
+	if state.Spill.size == 0 {
+		t.Fatalf("expected spill buffer to be flushed")
+	}
+
+	if err := state.addResultRanges(state.DefinitionData, "x01", "d02", []string{"r02", "r03"}); err != nil {
+		t.Fatalf("unexpected error adding result ranges: %s", err)
+	}
+
+	if r, err := state.rangeData("r01"); err != nil {
+		t.Fatalf("unexpected error reading range: %s", err)
+	} else if diff := cmp.Diff(lsif.Range{StartLine: 1, StartCharacter: 2, EndLine: 3, EndCharacter: 4, DefinitionResultID: "x01"}, r); diff != "" {
+		t.Errorf("unexpected range (-want +got):\n%s", diff)
+	}
+
+	expectedDocumentRanges := datastructures.DefaultIDSetMap{
+		"d01": datastructures.IDSet{"r01": {}},
+		"d02": datastructures.IDSet{"r02": {}, "r03": {}},
+	}
+	if documentRanges, err := state.resultData(state.DefinitionData, "x01"); err != nil {
+		t.Fatalf("unexpected error reading result: %s", err)
+	} else if diff := cmp.Diff(expectedDocumentRanges, documentRanges); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	if err := state.setResultData(state.DefinitionData, "x01", datastructures.DefaultIDSetMap{"d03": datastructures.IDSet{"r04": {}}}); err != nil {
+		t.Fatalf("unexpected error writing result: %s", err)
+	}
+	state.deleteResultData(state.DefinitionData, "x02")
+
+	if documentRanges, err := state.resultData(state.DefinitionData, "x01"); err != nil {
+		t.Fatalf("unexpected error reading result: %s", err)
+	} else if diff := cmp.Diff(datastructures.DefaultIDSetMap{"d03": datastructures.IDSet{"r04": {}}}, documentRanges); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(map[string]datastructures.DefaultIDSetMap{"x01": nil}, state.DefinitionData); diff != "" {
+		t.Errorf("unexpected definition data (-want +got):\n%s", diff)
+	}
+}
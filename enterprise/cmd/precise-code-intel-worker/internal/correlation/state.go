@@ -1,11 +1,18 @@
 package correlation
 
 import (
+	"encoding/json"
+
+	"github.com/hashicorp/go-multierror"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/correlation/datastructures"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/correlation/lsif"
 )
 
-// State is an in-memory representation of an uploaded LSIF index.
+// State is an in-memory representation of an uploaded LSIF index. When the index is large, the
+// ranges, the definition, reference, and implementation results, and the payloads of hover,
+// diagnostic, and document symbol results are moved to a temporary file and the corresponding
+// maps retain only their keys. Once spilled, this data must be read and written via the methods
+// of the state (e.g. rangeData and setRangeData).
 type State struct {
 	LSIFVersion            string
 	ProjectRoot            string
@@ -14,15 +21,18 @@ type State struct {
 	ResultSetData          map[string]lsif.ResultSet
 	DefinitionData         map[string]datastructures.DefaultIDSetMap
 	ReferenceData          map[string]datastructures.DefaultIDSetMap
+	ImplementationData     map[string]datastructures.DefaultIDSetMap
 	HoverData              map[string]string
 	MonikerData            map[string]lsif.Moniker
 	PackageInformationData map[string]lsif.PackageInformation
 	Diagnostics            map[string]lsif.DiagnosticResult
+	DocumentSymbolResults  map[string]lsif.DocumentSymbolResult
 	NextData               map[string]string            // maps vertices related via next edges
 	ImportedMonikers       datastructures.IDSet         // moniker ids that have kind "import"
 	ExportedMonikers       datastructures.IDSet         // moniker ids that have kind "export"
 	LinkedMonikers         datastructures.DisjointIDSet // tracks which moniker ids are related via next edges
 	LinkedReferenceResults datastructures.DisjointIDSet // tracks which reference result ids are related via next edges
+	Spill                  *PayloadSpill                // holds result payloads once spilled to disk
 }
 
 // newState create a new State with zero-valued map fields.
@@ -33,10 +43,12 @@ func newState() *State {
 		ResultSetData:          map[string]lsif.ResultSet{},
 		DefinitionData:         map[string]datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[string]datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[string]datastructures.DefaultIDSetMap{},
 		HoverData:              map[string]string{},
 		MonikerData:            map[string]lsif.Moniker{},
 		PackageInformationData: map[string]lsif.PackageInformation{},
 		Diagnostics:            map[string]lsif.DiagnosticResult{},
+		DocumentSymbolResults:  map[string]lsif.DocumentSymbolResult{},
 		NextData:               map[string]string{},
 		ImportedMonikers:       datastructures.IDSet{},
 		ExportedMonikers:       datastructures.IDSet{},
@@ -44,3 +56,253 @@ func newState() *State {
 		LinkedReferenceResults: datastructures.DisjointIDSet{},
 	}
 }
+
+// spillPayloads moves all ranges, results, and hover, diagnostic, and document symbol payloads into
+// a new temporary file in the given directory. Data added to the state afterwards is written directly
+// to the same file.
+func (s *State) spillPayloads(dir string) (err error) {
+	spill, err := newPayloadSpill(dir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if closeErr := spill.Close(); closeErr != nil {
+				err = multierror.Append(err, closeErr)
+			}
+		}
+	}()
+
+	for id, r := range s.RangeData {
+		if err := spill.add(id, r); err != nil {
+			return err
+		}
+		s.RangeData[id] = lsif.Range{}
+	}
+	for _, data := range []map[string]datastructures.DefaultIDSetMap{s.DefinitionData, s.ReferenceData, s.ImplementationData} {
+		for id, documentRanges := range data {
+			if len(documentRanges) > 0 {
+				if err := spill.setItems(id, serializeResultItem(documentRanges)); err != nil {
+					return err
+				}
+			}
+			data[id] = nil
+		}
+	}
+	for id, text := range s.HoverData {
+		if err := spill.add(id, text); err != nil {
+			return err
+		}
+		s.HoverData[id] = ""
+	}
+	for id, result := range s.Diagnostics {
+		if err := spill.add(id, result); err != nil {
+			return err
+		}
+		s.Diagnostics[id] = lsif.DiagnosticResult{}
+	}
+	for id, result := range s.DocumentSymbolResults {
+		if err := spill.add(id, result); err != nil {
+			return err
+		}
+		s.DocumentSymbolResults[id] = lsif.DocumentSymbolResult{}
+	}
+
+	s.Spill = spill
+	return nil
+}
+
+// rangeData returns the range with the given identifier.
+func (s *State) rangeData(id string) (lsif.Range, error) {
+	if s.Spill == nil {
+		return s.RangeData[id], nil
+	}
+
+	var r lsif.Range
+	err := s.Spill.get(id, &r)
+	return r, err
+}
+
+// setRangeData adds or replaces the range with the given identifier.
+func (s *State) setRangeData(id string, r lsif.Range) error {
+	if s.Spill == nil {
+		s.RangeData[id] = r
+		return nil
+	}
+
+	s.RangeData[id] = lsif.Range{}
+	return s.Spill.add(id, r)
+}
+
+// updateRangeData replaces the range with the given identifier by the result of the given function.
+// This method returns false if there is no such range.
+func (s *State) updateRangeData(id string, f func(r lsif.Range) lsif.Range) (bool, error) {
+	if _, ok := s.RangeData[id]; !ok {
+		return false, nil
+	}
+
+	r, err := s.rangeData(id)
+	if err != nil {
+		return false, err
+	}
+
+	return true, s.setRangeData(id, f(r))
+}
+
+// resultData returns the ranges, keyed by document, of the result with the given identifier in the
+// given definition, reference, or implementation result map. Modifications of the returned value are
+// only guaranteed to be reflected in the state once passed to setResultData.
+func (s *State) resultData(data map[string]datastructures.DefaultIDSetMap, id string) (datastructures.DefaultIDSetMap, error) {
+	if s.Spill == nil {
+		return data[id], nil
+	}
+
+	payloads, err := s.Spill.getItems(id)
+	if err != nil {
+		return nil, err
+	}
+
+	documentRanges := datastructures.DefaultIDSetMap{}
+	for _, payload := range payloads {
+		var item map[string][]string
+		if err := json.Unmarshal(payload, &item); err != nil {
+			return nil, err
+		}
+
+		for documentID, rangeIDs := range item {
+			set := documentRanges.GetOrCreate(documentID)
+			for _, rangeID := range rangeIDs {
+				set.Add(rangeID)
+			}
+		}
+	}
+
+	return documentRanges, nil
+}
+
+// setResultData replaces the ranges of the result with the given identifier in the given definition,
+// reference, or implementation result map.
+func (s *State) setResultData(data map[string]datastructures.DefaultIDSetMap, id string, documentRanges datastructures.DefaultIDSetMap) error {
+	if s.Spill == nil {
+		data[id] = documentRanges
+		return nil
+	}
+
+	data[id] = nil
+	return s.Spill.setItems(id, serializeResultItem(documentRanges))
+}
+
+// addResultRanges adds the given ranges of the given document to the result with the given identifier
+// in the given definition, reference, or implementation result map. The result must already exist.
+func (s *State) addResultRanges(data map[string]datastructures.DefaultIDSetMap, id, documentID string, rangeIDs []string) error {
+	if len(rangeIDs) == 0 {
+		return nil
+	}
+
+	if s.Spill == nil {
+		set := data[id].GetOrCreate(documentID)
+		for _, rangeID := range rangeIDs {
+			set.Add(rangeID)
+		}
+		return nil
+	}
+
+	return s.Spill.appendItem(id, map[string][]string{documentID: rangeIDs})
+}
+
+// deleteResultData removes the result with the given identifier from the given definition, reference,
+// or implementation result map.
+func (s *State) deleteResultData(data map[string]datastructures.DefaultIDSetMap, id string) {
+	delete(data, id)
+
+	if s.Spill != nil {
+		s.Spill.remove(id)
+	}
+}
+
+// serializeResultItem converts the given ranges of a result into the form written to the spill file.
+func serializeResultItem(documentRanges datastructures.DefaultIDSetMap) map[string][]string {
+	item := make(map[string][]string, len(documentRanges))
+	for documentID, rangeIDs := range documentRanges {
+		item[documentID] = rangeIDs.Keys()
+	}
+
+	return item
+}
+
+// addHoverText adds the given hover result to the state.
+func (s *State) addHoverText(id, text string) error {
+	if s.Spill == nil {
+		s.HoverData[id] = text
+		return nil
+	}
+
+	s.HoverData[id] = ""
+	return s.Spill.add(id, text)
+}
+
+// addDiagnosticResult adds the given diagnostic result to the state.
+func (s *State) addDiagnosticResult(id string, result lsif.DiagnosticResult) error {
+	if s.Spill == nil {
+		s.Diagnostics[id] = result
+		return nil
+	}
+
+	s.Diagnostics[id] = lsif.DiagnosticResult{}
+	return s.Spill.add(id, result)
+}
+
+// addDocumentSymbolResult adds the given document symbol result to the state.
+func (s *State) addDocumentSymbolResult(id string, result lsif.DocumentSymbolResult) error {
+	if s.Spill == nil {
+		s.DocumentSymbolResults[id] = result
+		return nil
+	}
+
+	s.DocumentSymbolResults[id] = lsif.DocumentSymbolResult{}
+	return s.Spill.add(id, result)
+}
+
+// hoverText returns the text of the hover result with the given identifier.
+func (s *State) hoverText(id string) (string, error) {
+	if s.Spill == nil {
+		return s.HoverData[id], nil
+	}
+
+	var text string
+	err := s.Spill.get(id, &text)
+	return text, err
+}
+
+// diagnosticResult returns the diagnostic result with the given identifier.
+func (s *State) diagnosticResult(id string) (lsif.DiagnosticResult, error) {
+	if s.Spill == nil {
+		return s.Diagnostics[id], nil
+	}
+
+	var result lsif.DiagnosticResult
+	err := s.Spill.get(id, &result)
+	return result, err
+}
+
+// documentSymbolResult returns the document symbol result with the given identifier.
+func (s *State) documentSymbolResult(id string) (lsif.DocumentSymbolResult, error) {
+	if s.Spill == nil {
+		return s.DocumentSymbolResults[id], nil
+	}
+
+	var result lsif.DocumentSymbolResult
+	err := s.Spill.get(id, &result)
+	return result, err
+}
+
+// Close removes any temporary file holding spilled payloads.
+func (s *State) Close() error {
+	if s.Spill == nil {
+		return nil
+	}
+
+	err := s.Spill.Close()
+	s.Spill = nil
+	return err
+}
@@ -0,0 +1,148 @@
+package retentionenforcer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/retention"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// PoliciesFn returns the current retention policies from the site configuration.
+type PoliciesFn func() *schema.CodeIntelRetentionPolicies
+
+type Enforcer struct {
+	store           store.Store
+	gitserverClient gitserver.Client
+	policies        PoliciesFn
+	interval        time.Duration
+	metrics         EnforcerMetrics
+	done            chan struct{}
+	once            sync.Once
+}
+
+func NewEnforcer(
+	store store.Store,
+	gitserverClient gitserver.Client,
+	policies PoliciesFn,
+	interval time.Duration,
+	metrics EnforcerMetrics,
+) *Enforcer {
+	return &Enforcer{
+		store:           store,
+		gitserverClient: gitserverClient,
+		policies:        policies,
+		interval:        interval,
+		metrics:         metrics,
+		done:            make(chan struct{}),
+	}
+}
+
+// Start periodically removes the uploads that are no longer retained by the retention
+// policy of their repository.
+func (e *Enforcer) Start() {
+	for {
+		if err := e.enforce(context.Background()); err != nil {
+			e.metrics.Errors.Inc()
+			log15.Error("Failed to enforce retention policies", "err", err)
+		}
+
+		select {
+		case <-time.After(e.interval):
+		case <-e.done:
+			return
+		}
+	}
+}
+
+func (e *Enforcer) Stop() {
+	e.once.Do(func() {
+		close(e.done)
+	})
+}
+
+func (e *Enforcer) enforce(ctx context.Context) error {
+	policies := e.policies()
+	if policies == nil {
+		return nil
+	}
+
+	repositoryIDs, err := e.store.GetDumpRepositoryIDs(ctx)
+	if err != nil {
+		return errors.Wrap(err, "store.GetDumpRepositoryIDs")
+	}
+
+	for _, repositoryID := range repositoryIDs {
+		if err := e.enforceRepository(ctx, policies, repositoryID); err != nil {
+			if isRepoNotExist(err) {
+				continue
+			}
+
+			// Do not let one failing repository block enforcement for the remaining repositories
+			e.metrics.Errors.Inc()
+			log15.Error("Failed to enforce retention policy", "repository_id", repositoryID, "err", err)
+		}
+	}
+
+	return nil
+}
+
+func (e *Enforcer) enforceRepository(ctx context.Context, policies *schema.CodeIntelRetentionPolicies, repositoryID int) error {
+	repositoryName, err := e.store.RepoName(ctx, repositoryID)
+	if err != nil {
+		return errors.Wrap(err, "store.RepoName")
+	}
+
+	policy, ok, err := retention.PolicyForRepository(policies, repositoryName)
+	if err != nil || !ok {
+		return err
+	}
+
+	expirations, err := retention.ExpiredUploads(ctx, e.store, e.gitserverClient, repositoryID, policy, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "retention.ExpiredUploads")
+	}
+
+	for _, expiration := range expirations {
+		deleted, err := e.store.DeleteUploadByID(ctx, expiration.Dump.ID, e.getTipCommit)
+		if err != nil {
+			return errors.Wrap(err, "store.DeleteUploadByID")
+		}
+
+		if deleted {
+			log15.Debug("Removed expired upload", "id", expiration.Dump.ID, "repository_id", repositoryID, "reason", expiration.Reason)
+			e.metrics.UploadsRemoved.Inc()
+		}
+	}
+
+	return nil
+}
+
+// getTipCommit returns the head of the default branch for the given repository. This
+// is used to recalculate the set of visible dumps for a repository on dump deletion.
+func (e *Enforcer) getTipCommit(ctx context.Context, repositoryID int) (string, error) {
+	tipCommit, err := e.gitserverClient.Head(ctx, e.store, repositoryID)
+	if err != nil && !isRepoNotExist(err) {
+		return "", errors.Wrap(err, "gitserver.Head")
+	}
+
+	return tipCommit, nil
+}
+
+func isRepoNotExist(err error) bool {
+	for err != nil {
+		if vcs.IsRepoNotExist(err) {
+			return true
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return false
+}
@@ -0,0 +1,146 @@
+package retentionenforcer
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	gitservermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		log15.Root().SetHandler(log15.DiscardHandler())
+	}
+	os.Exit(m.Run())
+}
+
+func TestEnforce(t *testing.T) {
+	now := time.Now()
+
+	mockStore := storemocks.NewMockStore()
+	mockStore.GetDumpRepositoryIDsFunc.SetDefaultReturn([]int{50, 51}, nil)
+	mockStore.RepoNameFunc.SetDefaultHook(func(ctx context.Context, repositoryID int) (string, error) {
+		if repositoryID == 50 {
+			return "github.com/sourcegraph/sourcegraph", nil
+		}
+		return "github.com/sourcegraph/other", nil
+	})
+	mockStore.GetDumpsByRepositoryIDFunc.SetDefaultHook(func(ctx context.Context, repositoryID int) ([]store.Dump, error) {
+		return []store.Dump{
+			{ID: repositoryID*10 + 1, Commit: "c1", UploadedAt: now.Add(-time.Hour), RepositoryID: repositoryID},
+			{ID: repositoryID*10 + 2, Commit: "c2", UploadedAt: now.Add(-time.Hour * 2), RepositoryID: repositoryID},
+			{ID: repositoryID*10 + 3, Commit: "c3", UploadedAt: now.Add(-time.Hour * 3), RepositoryID: repositoryID},
+		}, nil
+	})
+	mockStore.DeleteUploadByIDFunc.SetDefaultReturn(true, nil)
+
+	mockGitserverClient := gitservermocks.NewMockClient()
+	mockGitserverClient.RefsContainingFunc.SetDefaultReturn([]gitserver.Ref{{Name: "main", Type: gitserver.RefTypeBranch, Commit: "c1"}}, true, nil)
+
+	policies := &schema.CodeIntelRetentionPolicies{
+		Repositories: []*schema.CodeIntelRepositoryRetentionPolicy{
+			{Pattern: "^github.com/sourcegraph/sourcegraph$", Policy: schema.CodeIntelRetentionPolicy{KeepLatestPerBranch: 1}},
+		},
+	}
+
+	enforcer := &Enforcer{
+		store:           mockStore,
+		gitserverClient: mockGitserverClient,
+		policies:        func() *schema.CodeIntelRetentionPolicies { return policies },
+		metrics:         NewEnforcerMetrics(metrics.TestRegisterer),
+	}
+
+	if err := enforcer.enforce(context.Background()); err != nil {
+		t.Fatalf("unexpected error enforcing retention policies: %s", err)
+	}
+
+	var ids []int
+	for _, call := range mockStore.DeleteUploadByIDFunc.History() {
+		ids = append(ids, call.Arg1)
+	}
+	sort.Ints(ids)
+
+	if diff := cmp.Diff([]int{502, 503}, ids); diff != "" {
+		t.Errorf("unexpected deleted uploads (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnforceRepositoryError(t *testing.T) {
+	now := time.Now()
+
+	mockStore := storemocks.NewMockStore()
+	mockStore.GetDumpRepositoryIDsFunc.SetDefaultReturn([]int{50, 51}, nil)
+	mockStore.RepoNameFunc.SetDefaultReturn("github.com/sourcegraph/sourcegraph", nil)
+	mockStore.GetDumpsByRepositoryIDFunc.SetDefaultHook(func(ctx context.Context, repositoryID int) ([]store.Dump, error) {
+		if repositoryID == 50 {
+			return nil, fmt.Errorf("oops")
+		}
+
+		return []store.Dump{
+			{ID: repositoryID*10 + 1, Commit: "c1", UploadedAt: now.Add(-time.Hour), RepositoryID: repositoryID},
+			{ID: repositoryID*10 + 2, Commit: "c2", UploadedAt: now.Add(-time.Hour * 2), RepositoryID: repositoryID},
+		}, nil
+	})
+	mockStore.DeleteUploadByIDFunc.SetDefaultReturn(true, nil)
+
+	mockGitserverClient := gitservermocks.NewMockClient()
+	mockGitserverClient.RefsContainingFunc.SetDefaultReturn([]gitserver.Ref{{Name: "main", Type: gitserver.RefTypeBranch, Commit: "c1"}}, true, nil)
+
+	policies := &schema.CodeIntelRetentionPolicies{
+		Repositories: []*schema.CodeIntelRepositoryRetentionPolicy{
+			{Pattern: "^github.com/sourcegraph/sourcegraph$", Policy: schema.CodeIntelRetentionPolicy{KeepLatestPerBranch: 1}},
+		},
+	}
+
+	enforcer := &Enforcer{
+		store:           mockStore,
+		gitserverClient: mockGitserverClient,
+		policies:        func() *schema.CodeIntelRetentionPolicies { return policies },
+		metrics:         NewEnforcerMetrics(metrics.TestRegisterer),
+	}
+
+	if err := enforcer.enforce(context.Background()); err != nil {
+		t.Fatalf("unexpected error enforcing retention policies: %s", err)
+	}
+
+	var ids []int
+	for _, call := range mockStore.DeleteUploadByIDFunc.History() {
+		ids = append(ids, call.Arg1)
+	}
+
+	if diff := cmp.Diff([]int{512}, ids); diff != "" {
+		t.Errorf("unexpected deleted uploads (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnforceNoPolicies(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+
+	enforcer := &Enforcer{
+		store:           mockStore,
+		gitserverClient: gitservermocks.NewMockClient(),
+		policies:        func() *schema.CodeIntelRetentionPolicies { return nil },
+		metrics:         NewEnforcerMetrics(metrics.TestRegisterer),
+	}
+
+	if err := enforcer.enforce(context.Background()); err != nil {
+		t.Fatalf("unexpected error enforcing retention policies: %s", err)
+	}
+
+	if len(mockStore.GetDumpRepositoryIDsFunc.History()) != 0 {
+		t.Errorf("unexpected call count. want=%d have=%d", 0, len(mockStore.GetDumpRepositoryIDsFunc.History()))
+	}
+}
@@ -0,0 +1,29 @@
+package retentionenforcer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type EnforcerMetrics struct {
+	UploadsRemoved prometheus.Counter
+	Errors         prometheus.Counter
+}
+
+func NewEnforcerMetrics(r prometheus.Registerer) EnforcerMetrics {
+	uploadsRemoved := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_retention_enforcer_uploads_removed_total",
+		Help: "Total number of uploads removed by the retention enforcer",
+	})
+	r.MustRegister(uploadsRemoved)
+
+	errors := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_retention_enforcer_errors_total",
+		Help: "Total number of errors when running the retention enforcer",
+	})
+	r.MustRegister(errors)
+
+	return EnforcerMetrics{
+		UploadsRemoved: uploadsRemoved,
+		Errors:         errors,
+	}
+}
@@ -27,6 +27,7 @@ type Processor interface {
 type processor struct {
 	bundleManagerClient bundles.BundleManagerClient
 	gitserverClient     gitserver.Client
+	spillThreshold      int64
 }
 
 // process converts a raw upload into a dump within the given transaction context.
@@ -60,6 +61,7 @@ func (p *processor) Process(ctx context.Context, store store.Store, upload store
 		ctx,
 		r,
 		tempDir,
+		p.spillThreshold,
 		upload.ID,
 		upload.Root,
 		func(dirnames []string) (map[string][]string, error) {
@@ -166,12 +168,23 @@ func (p *processor) updateCommitsAndVisibility(ctx context.Context, store store.
 	return nil
 }
 
-// convert correlates the raw input data and commits the correlated data to disk.
-func convert(ctx context.Context, r io.Reader, tempDir string, dumpID int, root string, getChildren existence.GetChildrenFunc) ([]types.Package, []types.PackageReference, error) {
-	groupedBundleData, err := correlation.Correlate(r, dumpID, root, getChildren)
+// convert correlates the raw input data and commits the correlated data to disk. Once more than
+// spillThreshold bytes of input have been read, correlation payloads are spilled into tempDir.
+func convert(ctx context.Context, r io.Reader, tempDir string, spillThreshold int64, dumpID int, root string, getChildren existence.GetChildrenFunc) (_ []types.Package, _ []types.PackageReference, err error) {
+	spillOptions := correlation.SpillOptions{
+		Threshold: spillThreshold,
+		Dir:       tempDir,
+	}
+
+	groupedBundleData, err := correlation.Correlate(r, dumpID, root, getChildren, spillOptions)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "correlation.Correlate")
 	}
+	defer func() {
+		if closeErr := groupedBundleData.Close(); closeErr != nil {
+			err = multierror.Append(err, errors.Wrap(closeErr, "groupedBundleData.Close"))
+		}
+	}()
 
 	if err := write(ctx, tempDir, groupedBundleData); err != nil {
 		return nil, nil, err
@@ -205,6 +218,12 @@ func write(ctx context.Context, dirname string, groupedBundleData *correlation.G
 	if err := writer.WriteReferences(ctx, groupedBundleData.References); err != nil {
 		return errors.Wrap(err, "writer.WriteReferences")
 	}
+	if err := writer.WriteImplementations(ctx, groupedBundleData.Implementations); err != nil {
+		return errors.Wrap(err, "writer.WriteImplementations")
+	}
+	if err := writer.WriteSymbols(ctx, groupedBundleData.Symbols); err != nil {
+		return errors.Wrap(err, "writer.WriteSymbols")
+	}
 
 	return err
 }
@@ -92,10 +92,11 @@ func TestProcess(t *testing.T) {
 	}
 	expectedPackageReferences := []types.PackageReference{
 		{DumpID: 42,
-			Scheme:  "scheme A",
-			Name:    "pkg A",
-			Version: "v0.1.0",
-			Filter:  filter,
+			Scheme:          "scheme A",
+			Name:            "pkg A",
+			Version:         "v0.1.0",
+			Filter:          filter,
+			IdentifierCount: 1,
 		},
 	}
 	if len(mockStore.UpdatePackageReferencesFunc.History()) != 1 {
@@ -26,11 +26,13 @@ func NewWorker(
 	bundleManagerClient bundles.BundleManagerClient,
 	gitserverClient gitserver.Client,
 	pollInterval time.Duration,
+	spillThreshold int64,
 	metrics WorkerMetrics,
 ) *Worker {
 	processor := &processor{
 		bundleManagerClient: bundleManagerClient,
 		gitserverClient:     gitserverClient,
+		spillThreshold:      spillThreshold,
 	}
 
 	return &Worker{
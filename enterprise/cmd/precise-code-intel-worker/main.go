@@ -10,6 +10,7 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/resetter"
+	retentionenforcer "github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/retention_enforcer"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/server"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/worker"
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
@@ -22,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/sqliteutil"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/tracer"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func main() {
@@ -35,6 +37,8 @@ func main() {
 		bundleManagerURL   = mustGet(rawBundleManagerURL, "PRECISE_CODE_INTEL_BUNDLE_MANAGER_URL")
 		workerPollInterval = mustParseInterval(rawWorkerPollInterval, "PRECISE_CODE_INTEL_WORKER_POLL_INTERVAL")
 		resetInterval      = mustParseInterval(rawResetInterval, "PRECISE_CODE_INTEL_RESET_INTERVAL")
+		retentionInterval  = mustParseInterval(rawRetentionInterval, "PRECISE_CODE_INTEL_RETENTION_INTERVAL")
+		spillThreshold     = mustParseInt(rawSpillThreshold, "PRECISE_CODE_INTEL_CORRELATION_SPILL_THRESHOLD_BYTES")
 	)
 
 	observationContext := &observation.Context{
@@ -47,6 +51,7 @@ func main() {
 	MustRegisterQueueMonitor(observationContext.Registerer, store)
 	workerMetrics := worker.NewWorkerMetrics(prometheus.DefaultRegisterer)
 	resetterMetrics := resetter.NewResetterMetrics(prometheus.DefaultRegisterer)
+	enforcerMetrics := retentionenforcer.NewEnforcerMetrics(prometheus.DefaultRegisterer)
 	server := server.New()
 
 	uploadResetter := resetter.UploadResetter{
@@ -60,12 +65,22 @@ func main() {
 		bundles.New(bundleManagerURL),
 		gitserver.DefaultClient,
 		workerPollInterval,
+		int64(spillThreshold),
 		workerMetrics,
 	)
 
+	retentionEnforcer := retentionenforcer.NewEnforcer(
+		store,
+		gitserver.DefaultClient,
+		func() *schema.CodeIntelRetentionPolicies { return conf.Get().CodeIntelRetentionPolicies },
+		retentionInterval,
+		enforcerMetrics,
+	)
+
 	go server.Start()
 	go uploadResetter.Run()
 	go worker.Start()
+	go retentionEnforcer.Start()
 	go debugserver.Start()
 
 	// Attempt to clean up after first shutdown signal
@@ -81,6 +96,7 @@ func main() {
 
 	server.Stop()
 	worker.Stop()
+	retentionEnforcer.Stop()
 }
 
 func mustInitializeStore() store.Store {
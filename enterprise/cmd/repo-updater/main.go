@@ -63,6 +63,7 @@ func enterpriseInit(
 
 	sourcer := repos.NewSourcer(cf)
 	go campaigns.RunWorkers(ctx, campaignsStore, clock, gitserver.DefaultClient, sourcer, 5*time.Second)
+	go campaigns.RunActionWorkers(ctx, campaignsStore, clock, gitserver.DefaultClient, 5*time.Second)
 
 	// Set up expired patch set deletion
 	go func() {
@@ -0,0 +1,510 @@
+package campaigns
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/tar"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+var (
+	maxActionWorkers     = env.Get("CAMPAIGNS_MAX_ACTION_WORKERS", "4", "maximum number of campaign action jobs to run in parallel")
+	maxActionJobAttempts = env.Get("CAMPAIGNS_ACTION_JOB_MAX_ATTEMPTS", "3", "number of times a failing campaign action job is run before it's marked as errored")
+	actionJobTimeout     = env.Get("CAMPAIGNS_ACTION_JOB_TIMEOUT", "30m", "maximum duration of a single run of a campaign action job")
+	actionWorkspacesDir  = env.Get("CAMPAIGNS_ACTION_WORKSPACES_DIR", "", "directory in which the workspaces of campaign action jobs are created (defaults to the system's temporary directory). It must be accessible to the Docker daemon.")
+	actionDockerBinary   = env.Get("CAMPAIGNS_ACTION_DOCKER_BINARY", "docker", "the Docker CLI used to run the docker steps of campaign actions")
+	actionStepMemory     = env.Get("CAMPAIGNS_ACTION_STEP_MEMORY", "2g", "maximum amount of memory available to the container of a campaign action step")
+	actionStepCPUs       = env.Get("CAMPAIGNS_ACTION_STEP_CPUS", "1", "maximum number of CPUs available to the container of a campaign action step")
+	actionStepPidsLimit  = env.Get("CAMPAIGNS_ACTION_STEP_PIDS_LIMIT", "1024", "maximum number of processes in the container of a campaign action step")
+)
+
+const (
+	defaultActionWorkers    = 4
+	defaultActionAttempts   = 3
+	defaultActionJobTimeout = 30 * time.Minute
+)
+
+// maxActionJobLogSize is the maximum number of bytes of step output that is
+// stored for an ActionJob. Only the tail of longer logs is kept.
+const maxActionJobLogSize = 64 * 1024
+
+// ArchiveClient produces archives of repositories.
+type ArchiveClient interface {
+	Archive(ctx context.Context, repo gitserver.Repo, opt gitserver.ArchiveOptions) (io.ReadCloser, error)
+}
+
+// RunActionWorkers should be executed in a background goroutine and is
+// responsible for finding queued ActionJobs and executing them, as long as
+// server-side execution of campaign actions is enabled in the site
+// configuration.
+// ctx should be canceled to terminate the function.
+func RunActionWorkers(ctx context.Context, s *Store, clock func() time.Time, archiver ArchiveClient, backoffDuration time.Duration) {
+	workerCount, err := strconv.Atoi(maxActionWorkers)
+	if err != nil {
+		log15.Error("Parsing max action worker count failed. Falling back to default.", "default", defaultActionWorkers, "err", err)
+		workerCount = defaultActionWorkers
+	}
+
+	maxAttempts, err := strconv.Atoi(maxActionJobAttempts)
+	if err != nil {
+		log15.Error("Parsing max action job attempts failed. Falling back to default.", "default", defaultActionAttempts, "err", err)
+		maxAttempts = defaultActionAttempts
+	}
+
+	timeout, err := time.ParseDuration(actionJobTimeout)
+	if err != nil {
+		log15.Error("Parsing action job timeout failed. Falling back to default.", "default", defaultActionJobTimeout, "err", err)
+		timeout = defaultActionJobTimeout
+	}
+
+	enabled := func() bool {
+		return conf.Get().CampaignsActionExecutionEnabled
+	}
+
+	opts := ExecActionJobOpts{
+		Clock:       clock,
+		Store:       s,
+		Archiver:    archiver,
+		MaxAttempts: int32(maxAttempts),
+		Timeout:     timeout,
+	}
+
+	worker := func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			if !enabled() {
+				time.Sleep(backoffDuration)
+				continue
+			}
+
+			job, err := s.DequeueActionJob(ctx)
+			if err != nil {
+				if err != ErrNoResults {
+					log15.Error("Dequeueing action job", "err", err)
+				}
+				// Back off on error or when no jobs available
+				time.Sleep(backoffDuration)
+				continue
+			}
+
+			if err := ExecActionJob(ctx, job, opts); err != nil {
+				log15.Error("ExecActionJob", "jobID", job.ID, "err", err)
+			}
+		}
+	}
+	for i := 0; i < workerCount; i++ {
+		go worker()
+	}
+
+	// Jobs of workers that died while executing them would otherwise never be
+	// finished, so we put them back into the queue once they've been running
+	// for much longer than they're allowed to.
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(timeout):
+			}
+
+			executionIDs, err := s.ResetStalledActionJobs(ctx, clock().Add(-2*timeout), int32(maxAttempts))
+			if err != nil {
+				log15.Error("ResetStalledActionJobs", "err", err)
+				continue
+			}
+
+			// Jobs that stalled in their last attempt have been marked as
+			// errored, which may have finished their execution.
+			for _, id := range executionIDs {
+				if err := finishActionExecution(ctx, s, id); err != nil {
+					log15.Error("finishActionExecution", "executionID", id, "err", err)
+				}
+			}
+		}
+	}()
+}
+
+type ExecActionJobOpts struct {
+	Clock    func() time.Time
+	Store    *Store
+	Archiver ArchiveClient
+
+	// MaxAttempts is the number of times the job is run before it's marked
+	// as errored.
+	MaxAttempts int32
+	// Timeout is the maximum duration of a single run of the job.
+	Timeout time.Duration
+}
+
+// ExecActionJob runs the steps of the Action of the job's ActionExecution in
+// the job's repository and saves the resulting diff and log in the job.
+//
+// If running the steps fails and the job has been attempted fewer than
+// opts.MaxAttempts times, the job is put back into the queue. Once the last
+// job of the execution has finished, the execution's PatchSet is created from
+// the diffs of all its jobs.
+func ExecActionJob(ctx context.Context, job *campaigns.ActionJob, opts ExecActionJobOpts) (err error) {
+	tr, ctx := trace.New(ctx, "campaigns.ExecActionJob", fmt.Sprintf("job_id: %d", job.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	runCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var log bytes.Buffer
+	runErr := runActionJob(runCtx, job, opts, &log)
+
+	job.Log = truncateActionJobLog(log.String())
+	job.Error = ""
+	job.FinishedAt = opts.Clock()
+	if runErr != nil {
+		job.Error = runErr.Error()
+		if job.Attempts < opts.MaxAttempts {
+			// Put the job back into the queue.
+			job.StartedAt = time.Time{}
+			job.FinishedAt = time.Time{}
+		}
+	}
+
+	if err := opts.Store.UpdateActionJob(ctx, job); err != nil {
+		return multierror.Append(runErr, err).ErrorOrNil()
+	}
+
+	if job.Completed() {
+		if err := finishActionExecution(ctx, opts.Store, job.ExecutionID); err != nil {
+			return multierror.Append(runErr, err).ErrorOrNil()
+		}
+	}
+
+	return runErr
+}
+
+// runActionJob resolves the base revision of the job's repository, checks it
+// out into a temporary workspace, runs the steps of the Action in it and sets
+// job.Diff to the resulting changes. The output of the steps is written to
+// log.
+func runActionJob(ctx context.Context, job *campaigns.ActionJob, opts ExecActionJobOpts, log io.Writer) error {
+	execution, err := opts.Store.GetActionExecution(ctx, GetActionExecutionOpts{ID: job.ExecutionID})
+	if err != nil {
+		return errors.Wrap(err, "getting action execution")
+	}
+
+	action, err := campaigns.ParseAction(execution.Definition)
+	if err != nil {
+		return err
+	}
+
+	reposStore := repos.NewDBStore(opts.Store.DB(), sql.TxOptions{})
+	rs, err := reposStore.ListRepos(ctx, repos.StoreListReposArgs{IDs: []api.RepoID{job.RepoID}})
+	if err != nil {
+		return err
+	}
+	if len(rs) != 1 {
+		return errors.Errorf("repo not found: %d", job.RepoID)
+	}
+	repo := gitserver.Repo{Name: api.RepoName(rs[0].Name)}
+
+	// The base revision is resolved once, so that retries run against the
+	// same commit.
+	if job.Rev == "" {
+		ref, _, _, err := git.ExecSafe(ctx, repo, []string{"symbolic-ref", "HEAD"})
+		if err != nil {
+			return errors.Wrap(err, "resolving default branch")
+		}
+		job.BaseRef = strings.TrimSpace(string(ref))
+
+		job.Rev, err = git.ResolveRevision(ctx, repo, nil, job.BaseRef, nil)
+		if err != nil {
+			return errors.Wrap(err, "resolving base revision")
+		}
+	}
+
+	archive, err := opts.Archiver.Archive(ctx, repo, gitserver.ArchiveOptions{
+		Treeish: string(job.Rev),
+		Format:  "tar",
+	})
+	if err != nil {
+		return errors.Wrap(err, "fetching repository archive")
+	}
+	defer archive.Close()
+
+	ws, err := newActionWorkspace(ctx, archive)
+	if err != nil {
+		return errors.Wrap(err, "creating workspace")
+	}
+	defer func() {
+		if err := os.RemoveAll(ws.root); err != nil {
+			log15.Warn("Removing action job workspace failed", "dir", ws.root, "err", err)
+		}
+	}()
+
+	if err := runActionSteps(ctx, ws.dir, action.Steps, log); err != nil {
+		return err
+	}
+
+	job.Diff, err = actionWorkspaceDiff(ctx, ws)
+	return err
+}
+
+// finishActionExecution creates the PatchSet of the ActionExecution with the
+// given ID from the diffs of its ActionJobs, if all of them have finished.
+func finishActionExecution(ctx context.Context, s *Store, id int64) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Done(&err)
+
+	// Locking the execution serializes the jobs of the execution that finish
+	// at the same time, so that exactly one of them sees all jobs finished.
+	execution, err := tx.GetActionExecution(ctx, GetActionExecutionOpts{ID: id, ForUpdate: true})
+	if err != nil {
+		return err
+	}
+	if !execution.FinishedAt.IsZero() {
+		return nil
+	}
+
+	status, err := tx.GetActionExecutionStatus(ctx, GetActionExecutionStatusOpts{ID: id})
+	if err != nil {
+		return err
+	}
+	if status.Pending > 0 {
+		return nil
+	}
+
+	jobs, _, err := tx.ListActionJobs(ctx, ListActionJobsOpts{ExecutionID: id, Limit: -1})
+	if err != nil {
+		return err
+	}
+
+	var patches []*campaigns.Patch
+	for _, j := range jobs {
+		if j.Error != "" || j.Diff == "" {
+			continue
+		}
+		p := &campaigns.Patch{
+			RepoID:  j.RepoID,
+			Rev:     j.Rev,
+			BaseRef: j.BaseRef,
+			Diff:    j.Diff,
+		}
+		if err := p.ComputeDiffStat(); err != nil {
+			return errors.Wrapf(err, "computing diff stat of action job %d", j.ID)
+		}
+		patches = append(patches, p)
+	}
+
+	if len(patches) > 0 {
+		patchSet := &campaigns.PatchSet{UserID: execution.UserID}
+		if err := tx.CreatePatchSet(ctx, patchSet); err != nil {
+			return err
+		}
+		for _, p := range patches {
+			p.PatchSetID = patchSet.ID
+			if err := tx.CreatePatch(ctx, p); err != nil {
+				return err
+			}
+		}
+		execution.PatchSetID = patchSet.ID
+	}
+
+	execution.FinishedAt = tx.now()
+	return tx.UpdateActionExecution(ctx, execution)
+}
+
+// actionWorkspace is the temporary workspace of an ActionJob.
+type actionWorkspace struct {
+	// root is the temporary directory containing all other directories.
+	root string
+
+	// dir contains the files of the repository, and is mounted into the
+	// containers of the steps.
+	dir string
+
+	// gitDir is the Git directory of dir, and home is the home directory of
+	// the Git commands run on the host. They are outside of dir, so that
+	// neither the repository nor the steps can change the configuration or
+	// hooks of those commands.
+	gitDir string
+	home   string
+}
+
+// newActionWorkspace extracts the given tar archive into a new temporary
+// workspace and commits its contents to a new Git repository, so that the
+// changes made by the steps can be computed with `git diff`.
+func newActionWorkspace(ctx context.Context, archive io.Reader) (ws *actionWorkspace, err error) {
+	root, err := ioutil.TempDir(actionWorkspacesDir, "campaign-action-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(root)
+		}
+	}()
+
+	ws = &actionWorkspace{
+		root:   root,
+		dir:    filepath.Join(root, "work"),
+		gitDir: filepath.Join(root, "git"),
+		home:   filepath.Join(root, "home"),
+	}
+	for _, dir := range []string{ws.dir, ws.gitDir, ws.home} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tar.Extract(ws.dir, archive); err != nil {
+		return nil, errors.Wrap(err, "extracting archive")
+	}
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "--all"},
+		{"commit", "--quiet", "--allow-empty", "--no-verify", "--message", "base"},
+	} {
+		if _, err := runWorkspaceGit(ctx, ws, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	return ws, nil
+}
+
+// actionWorkspaceDiff returns the changes made to the workspace as a unified
+// diff without a/ and b/ prefixes, which is the format expected by
+// ExecChangesetJob.
+func actionWorkspaceDiff(ctx context.Context, ws *actionWorkspace) (string, error) {
+	if _, err := runWorkspaceGit(ctx, ws, "add", "--all"); err != nil {
+		return "", err
+	}
+	return runWorkspaceGit(ctx, ws, "diff", "--cached", "--no-prefix", "--binary")
+}
+
+// runWorkspaceGit runs git with the given arguments on the workspace. The
+// files of the workspace are controlled by the repository and the steps, so
+// git is run without any configuration or hooks other than those of the
+// private Git directory of the workspace.
+func runWorkspaceGit(ctx context.Context, ws *actionWorkspace, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{
+		"--git-dir=" + ws.gitDir,
+		"--work-tree=" + ws.dir,
+		"-c", "core.fsmonitor=",
+		"-c", "core.hooksPath=/dev/null",
+	}, args...)...)
+	cmd.Dir = ws.dir
+	cmd.Env = append(actionSandboxEnv(ws.home),
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_CONFIG_GLOBAL=/dev/null",
+		"GIT_AUTHOR_NAME=Sourcegraph",
+		"GIT_AUTHOR_EMAIL=campaigns@sourcegraph.com",
+		"GIT_COMMITTER_NAME=Sourcegraph",
+		"GIT_COMMITTER_EMAIL=campaigns@sourcegraph.com",
+	)
+
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return "", errors.Errorf("git %s: %s: %s", strings.Join(args, " "), err, bytes.TrimSpace(ee.Stderr))
+		}
+		return "", errors.Wrapf(err, "git %s", strings.Join(args, " "))
+	}
+	return string(out), nil
+}
+
+// runActionSteps runs the given steps one after another in the workspace in
+// dir, writing their output to log. It stops at the first failing step.
+//
+// Every step runs in a new container in which the workspace is mounted at
+// /work. The container has no network access, limited resources, and runs as
+// the user of this process, so that the files it creates in the workspace can
+// be removed afterwards. Command steps, which src-cli runs directly on the
+// machine, are not supported.
+func runActionSteps(ctx context.Context, dir string, steps []campaigns.ActionStep, log io.Writer) error {
+	for i, step := range steps {
+		var cmd *exec.Cmd
+		switch step.Type {
+		case campaigns.ActionStepTypeDocker:
+			if err := campaigns.ValidateActionImage(step.Image); err != nil {
+				return errors.Wrapf(err, "step %d", i+1)
+			}
+
+			args := append([]string{
+				"run", "--rm", "--init",
+				"--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+				"--network", "none",
+				"--memory", actionStepMemory,
+				"--cpus", actionStepCPUs,
+				"--pids-limit", actionStepPidsLimit,
+				"--security-opt", "no-new-privileges",
+				"--workdir", "/work",
+				"--mount", "type=bind,source=" + dir + ",target=/work",
+				step.Image,
+			}, step.Args...)
+			cmd = exec.CommandContext(ctx, actionDockerBinary, args...)
+		case campaigns.ActionStepTypeCommand:
+			return errors.Errorf("step %d: %s", i+1, ErrActionCommandStepsUnsupported)
+		default:
+			return errors.Errorf("step %d: unknown step type %q", i+1, step.Type)
+		}
+
+		fmt.Fprintf(log, "$ %s\n", strings.Join(cmd.Args, " "))
+		cmd.Stdout = log
+		cmd.Stderr = log
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			return errors.Wrapf(err, "step %d", i+1)
+		}
+	}
+	return nil
+}
+
+func actionSandboxEnv(home string) []string {
+	return []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + home,
+	}
+}
+
+func truncateActionJobLog(log string) string {
+	if len(log) <= maxActionJobLogSize {
+		return log
+	}
+	return "[log truncated]\n" + log[len(log)-maxActionJobLogSize:]
+}
@@ -0,0 +1,329 @@
+package campaigns
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestActionWorkspace(t *testing.T) {
+	ctx := context.Background()
+
+	archive := newTestTarArchive(t, map[string]string{
+		"README.md":    "# Hello\n",
+		"old.txt":      "old\n",
+		"cmd/main.go":  "package main\n",
+		"docs/keep.md": "keep\n",
+	})
+
+	ws, err := newActionWorkspace(ctx, archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(ws.root)
+	dir := ws.dir
+
+	t.Run("no changes", func(t *testing.T) {
+		diff, err := actionWorkspaceDiff(ctx, ws)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff != "" {
+			t.Fatalf("unexpected diff:\n%s", diff)
+		}
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		f, err := os.OpenFile(filepath.Join(dir, "README.md"), os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.WriteString("World\n"); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Remove(filepath.Join(dir, "old.txt")); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "new.txt"), []byte("new\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		diff, err := actionWorkspaceDiff(ctx, ws)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{
+			"diff --git README.md README.md",
+			"+World",
+			"diff --git new.txt new.txt",
+			"+new",
+			"diff --git old.txt old.txt",
+			"deleted file mode",
+		}
+		for _, w := range want {
+			if !strings.Contains(diff, w) {
+				t.Errorf("diff does not contain %q:\n%s", w, diff)
+			}
+		}
+		if strings.Contains(diff, "main.go") || strings.Contains(diff, "keep.md") {
+			t.Errorf("diff contains unchanged files:\n%s", diff)
+		}
+
+		p := &cmpgn.Patch{Diff: diff}
+		if err := p.ComputeDiffStat(); err != nil {
+			t.Fatalf("diff is not a valid patch: %s", err)
+		}
+	})
+}
+
+func TestActionWorkspace_untrustedGitConfig(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDir, err := ioutil.TempDir("", "campaigns-pwned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	pwned := filepath.Join(tmpDir, "PWNED")
+	maliciousConfig := fmt.Sprintf("[core]\n\tfsmonitor = \"touch %s; false\"\n\thooksPath = %s\n", pwned, tmpDir)
+	hook := fmt.Sprintf("#!/bin/sh\ntouch %s\n", pwned)
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, "pre-commit"), []byte(hook), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	assertNotPwned := func(t *testing.T) {
+		t.Helper()
+		if _, err := os.Stat(pwned); !os.IsNotExist(err) {
+			t.Fatal("git ran a command of the repository's configuration on the host")
+		}
+	}
+
+	t.Run("repository with .gitconfig", func(t *testing.T) {
+		ws, err := newActionWorkspace(ctx, newTestTarArchive(t, map[string]string{
+			"a.txt":      "a\n",
+			".gitconfig": maliciousConfig,
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(ws.root)
+		assertNotPwned(t)
+
+		if err := ioutil.WriteFile(filepath.Join(ws.dir, "a.txt"), []byte("b\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := actionWorkspaceDiff(ctx, ws); err != nil {
+			t.Fatal(err)
+		}
+		assertNotPwned(t)
+	})
+
+	t.Run("step rewriting .git/config", func(t *testing.T) {
+		ws, err := newActionWorkspace(ctx, newTestTarArchive(t, map[string]string{"a.txt": "a\n"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(ws.root)
+
+		// The fake docker CLI writes to the mounted workspace what a malicious
+		// step could write to /work.
+		binDir, err := ioutil.TempDir("", "campaigns-docker")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(binDir)
+		configFile := filepath.Join(binDir, "config")
+		if err := ioutil.WriteFile(configFile, []byte(maliciousConfig), 0644); err != nil {
+			t.Fatal(err)
+		}
+		script := fmt.Sprintf(`#!/bin/sh
+for arg; do
+	case "$arg" in type=bind,source=*) dir=${arg#type=bind,source=}; dir=${dir%%,target=/work};; esac
+done
+mkdir -p "$dir/.git/hooks"
+cp %[1]s "$dir/.git/config"
+cp %[1]s "$dir/.gitconfig"
+cp %[2]s "$dir/.git/hooks/pre-commit"
+`, configFile, filepath.Join(tmpDir, "pre-commit"))
+		fakeDocker := filepath.Join(binDir, "docker")
+		if err := ioutil.WriteFile(fakeDocker, []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+		defer func(binary string) { actionDockerBinary = binary }(actionDockerBinary)
+		actionDockerBinary = fakeDocker
+
+		var log bytes.Buffer
+		steps := []cmpgn.ActionStep{{Type: cmpgn.ActionStepTypeDocker, Image: "alpine:3"}}
+		if err := runActionSteps(ctx, ws.dir, steps, &log); err != nil {
+			t.Fatalf("unexpected error: %s\n%s", err, log.String())
+		}
+		if _, err := os.Stat(filepath.Join(ws.dir, ".git", "config")); err != nil {
+			t.Fatalf("step did not write .git/config: %s", err)
+		}
+
+		diff, err := actionWorkspaceDiff(ctx, ws)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertNotPwned(t)
+		if !strings.Contains(diff, "diff --git .gitconfig .gitconfig") {
+			t.Errorf("diff does not contain the new .gitconfig:\n%s", diff)
+		}
+	})
+}
+
+func TestRunActionSteps(t *testing.T) {
+	ctx := context.Background()
+	ws, err := newActionWorkspace(ctx, newTestTarArchive(t, map[string]string{"a.txt": "a\n"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(ws.root)
+	dir := ws.dir
+
+	// The fake docker CLI prints its arguments and fails if the image is
+	// "failing".
+	binDir, err := ioutil.TempDir("", "campaigns-docker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(binDir)
+
+	fakeDocker := filepath.Join(binDir, "docker")
+	script := "#!/bin/sh\necho \"docker $*\"\ncase \"$*\" in *' failing'*) echo oops >&2; exit 3;; esac\n"
+	if err := ioutil.WriteFile(fakeDocker, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(binary string) { actionDockerBinary = binary }(actionDockerBinary)
+	actionDockerBinary = fakeDocker
+
+	t.Run("docker step", func(t *testing.T) {
+		var log bytes.Buffer
+		steps := []cmpgn.ActionStep{
+			{Type: cmpgn.ActionStepTypeDocker, Image: "alpine:3", Args: []string{"sh", "-c", "echo hi"}},
+		}
+		if err := runActionSteps(ctx, dir, steps, &log); err != nil {
+			t.Fatalf("unexpected error: %s\n%s", err, log.String())
+		}
+
+		want := []string{
+			fmt.Sprintf("--user %d:%d", os.Getuid(), os.Getgid()),
+			"--network none",
+			"--memory " + actionStepMemory,
+			"--cpus " + actionStepCPUs,
+			"--pids-limit " + actionStepPidsLimit,
+			"--security-opt no-new-privileges",
+			"--mount type=bind,source=" + dir + ",target=/work alpine:3 sh -c echo hi\n",
+		}
+		for _, w := range want {
+			if !strings.Contains(log.String(), w) {
+				t.Errorf("docker arguments do not contain %q:\n%s", w, log.String())
+			}
+		}
+	})
+
+	t.Run("failing step", func(t *testing.T) {
+		var log bytes.Buffer
+		steps := []cmpgn.ActionStep{
+			{Type: cmpgn.ActionStepTypeDocker, Image: "failing"},
+			{Type: cmpgn.ActionStepTypeDocker, Image: "never"},
+		}
+
+		err := runActionSteps(ctx, dir, steps, &log)
+		if err == nil || !strings.HasPrefix(err.Error(), "step 1:") {
+			t.Fatalf("have error %v, want error of step 1", err)
+		}
+		if !strings.Contains(log.String(), "oops") {
+			t.Errorf("stderr missing from log:\n%s", log.String())
+		}
+		if strings.Contains(log.String(), "never") {
+			t.Error("step after failing step was run")
+		}
+	})
+
+	for _, tc := range []struct {
+		name    string
+		step    cmpgn.ActionStep
+		wantErr string
+	}{
+		{
+			name:    "command step",
+			step:    cmpgn.ActionStep{Type: cmpgn.ActionStepTypeCommand, Args: []string{"touch", "host.txt"}},
+			wantErr: ErrActionCommandStepsUnsupported.Error(),
+		},
+		{
+			name:    "image with flag",
+			step:    cmpgn.ActionStep{Type: cmpgn.ActionStepTypeDocker, Image: "--privileged"},
+			wantErr: `invalid image "--privileged"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var log bytes.Buffer
+			err := runActionSteps(ctx, dir, []cmpgn.ActionStep{tc.step}, &log)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("have error %v, want %q", err, tc.wantErr)
+			}
+			if log.Len() != 0 {
+				t.Errorf("step was run:\n%s", log.String())
+			}
+			if _, err := os.Stat(filepath.Join(dir, "host.txt")); !os.IsNotExist(err) {
+				t.Error("command step was run on the host")
+			}
+		})
+	}
+}
+
+func TestTruncateActionJobLog(t *testing.T) {
+	short := "short log"
+	if diff := cmp.Diff(truncateActionJobLog(short), short); diff != "" {
+		t.Error(diff)
+	}
+
+	long := strings.Repeat("a", maxActionJobLogSize) + "tail"
+	have := truncateActionJobLog(long)
+	if !strings.HasPrefix(have, "[log truncated]\n") || !strings.HasSuffix(have, "tail") {
+		t.Errorf("unexpected truncated log prefix/suffix")
+	}
+	if len(have) != len("[log truncated]\n")+maxActionJobLogSize {
+		t.Errorf("have length %d", len(have))
+	}
+}
+
+func newTestTarArchive(t *testing.T, files map[string]string) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, contents := range files {
+		hdr := &tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(contents)),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(contents)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
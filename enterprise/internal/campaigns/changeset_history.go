@@ -81,18 +81,24 @@ func computeHistory(ch *cmpgn.Changeset, ce ChangesetEvents) (changesetHistory,
 		}
 
 		switch e.Kind {
-		case cmpgn.ChangesetEventKindGitHubClosed, cmpgn.ChangesetEventKindBitbucketServerDeclined:
+		case cmpgn.ChangesetEventKindGitHubClosed,
+			cmpgn.ChangesetEventKindBitbucketServerDeclined,
+			cmpgn.ChangesetEventKindGitLabClosed:
 			// Merged is a final state. We can ignore everything after.
 			if currentState != cmpgn.ChangesetStateMerged {
 				currentState = cmpgn.ChangesetStateClosed
 				pushStates(et)
 			}
 
-		case cmpgn.ChangesetEventKindGitHubMerged, cmpgn.ChangesetEventKindBitbucketServerMerged:
+		case cmpgn.ChangesetEventKindGitHubMerged,
+			cmpgn.ChangesetEventKindBitbucketServerMerged,
+			cmpgn.ChangesetEventKindGitLabMerged:
 			currentState = cmpgn.ChangesetStateMerged
 			pushStates(et)
 
-		case cmpgn.ChangesetEventKindGitHubReopened, cmpgn.ChangesetEventKindBitbucketServerReopened:
+		case cmpgn.ChangesetEventKindGitHubReopened,
+			cmpgn.ChangesetEventKindBitbucketServerReopened,
+			cmpgn.ChangesetEventKindGitLabReopened:
 			// Merged is a final state. We can ignore everything after.
 			if currentState != cmpgn.ChangesetStateMerged {
 				currentState = cmpgn.ChangesetStateOpen
@@ -101,7 +107,8 @@ func computeHistory(ch *cmpgn.Changeset, ce ChangesetEvents) (changesetHistory,
 
 		case campaigns.ChangesetEventKindGitHubReviewed,
 			campaigns.ChangesetEventKindBitbucketServerApproved,
-			campaigns.ChangesetEventKindBitbucketServerReviewed:
+			campaigns.ChangesetEventKindBitbucketServerReviewed,
+			campaigns.ChangesetEventKindGitLabApproved:
 
 			s, err := e.ReviewState()
 			if err != nil {
@@ -152,7 +159,8 @@ func computeHistory(ch *cmpgn.Changeset, ce ChangesetEvents) (changesetHistory,
 			continue
 
 		case campaigns.ChangesetEventKindBitbucketServerUnapproved,
-			campaigns.ChangesetEventKindBitbucketServerDismissed:
+			campaigns.ChangesetEventKindBitbucketServerDismissed,
+			campaigns.ChangesetEventKindGitLabUnapproved:
 			author, err := e.ReviewAuthor()
 			if err != nil {
 				return nil, err
@@ -161,12 +169,13 @@ func computeHistory(ch *cmpgn.Changeset, ce ChangesetEvents) (changesetHistory,
 				continue
 			}
 
-			if e.Type() == campaigns.ChangesetEventKindBitbucketServerUnapproved {
-				// A BitbucketServer Unapproved can only follow a previous Approved by
+			if e.Type() == campaigns.ChangesetEventKindBitbucketServerUnapproved ||
+				e.Type() == campaigns.ChangesetEventKindGitLabUnapproved {
+				// An Unapproved event can only follow a previous Approved by
 				// the same author.
 				lastReview, ok := lastReviewByAuthor[author]
 				if !ok || lastReview != campaigns.ChangesetReviewStateApproved {
-					log15.Warn("Bitbucket Server Unapproval not following an Approval", "event", e)
+					log15.Warn("Unapproval not following an Approval", "event", e)
 					continue
 				}
 			}
@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
 
 func TestCalcCounts(t *testing.T) {
@@ -274,6 +275,27 @@ func TestCalcCounts(t *testing.T) {
 				{Time: daysAgo(0), Total: 1, Merged: 1},
 			},
 		},
+		{
+			codehosts: extsvc.TypeGitLab,
+			name:      "single changeset open closed reopened merged",
+			changesets: []*campaigns.Changeset{
+				glChangeset(1, daysAgo(4)),
+			},
+			start: daysAgo(5),
+			events: []*campaigns.ChangesetEvent{
+				event(t, daysAgo(3), campaigns.ChangesetEventKindGitLabClosed, 1),
+				event(t, daysAgo(2), campaigns.ChangesetEventKindGitLabReopened, 1),
+				event(t, daysAgo(1), campaigns.ChangesetEventKindGitLabMerged, 1),
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(5), Total: 0, Open: 0},
+				{Time: daysAgo(4), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(3), Total: 1, Open: 0, Closed: 1},
+				{Time: daysAgo(2), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(1), Total: 1, Merged: 1},
+				{Time: daysAgo(0), Total: 1, Merged: 1},
+			},
+		},
 		{
 			codehosts: extsvc.TypeGitHub,
 			name:      "multiple changesets open closed reopened merged different times",
@@ -1006,6 +1028,25 @@ func TestCalcCounts(t *testing.T) {
 				{Time: daysAgo(0), Total: 1, Open: 1, OpenPending: 1, OpenApproved: 0},
 			},
 		},
+		{
+			codehosts: extsvc.TypeGitLab,
+			name:      "single changeset open, approved, unapproved",
+			changesets: []*campaigns.Changeset{
+				glChangeset(1, daysAgo(3)),
+			},
+			start: daysAgo(4),
+			events: []*campaigns.ChangesetEvent{
+				glApproval(1, daysAgo(2), "user1", true),
+				glApproval(1, daysAgo(1), "user1", false),
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(4), Total: 0, Open: 0},
+				{Time: daysAgo(3), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(2), Total: 1, Open: 1, OpenPending: 0, OpenApproved: 1},
+				{Time: daysAgo(1), Total: 1, Open: 1, OpenPending: 1, OpenApproved: 0},
+				{Time: daysAgo(0), Total: 1, Open: 1, OpenPending: 1, OpenApproved: 0},
+			},
+		},
 		{
 			codehosts: "bitbucketserver",
 			name:      "single changeset open, changes requested, approved, unapproved",
@@ -1235,6 +1276,10 @@ func bbsChangeset(id int64, t time.Time) *campaigns.Changeset {
 	}
 }
 
+func glChangeset(id int64, t time.Time) *campaigns.Changeset {
+	return &campaigns.Changeset{ID: id, Metadata: &gitlab.MergeRequest{CreatedAt: t}}
+}
+
 func setExternalDeletedAt(c *campaigns.Changeset, t time.Time) *campaigns.Changeset {
 	c.SetDeleted()
 	c.ExternalDeletedAt = t
@@ -1262,6 +1307,13 @@ func event(t *testing.T, ti time.Time, kind campaigns.ChangesetEventKind, id int
 
 		ch.Metadata = &bitbucketserver.Activity{CreatedDate: timeToUnixMilli(ti)}
 
+	case campaigns.ChangesetEventKindGitLabMerged:
+		ch.Metadata = &gitlab.MergeRequestMergedEvent{Note: gitlab.Note{CreatedAt: ti}}
+	case campaigns.ChangesetEventKindGitLabClosed:
+		ch.Metadata = &gitlab.MergeRequestClosedEvent{Note: gitlab.Note{CreatedAt: ti}}
+	case campaigns.ChangesetEventKindGitLabReopened:
+		ch.Metadata = &gitlab.MergeRequestReopenedEvent{Note: gitlab.Note{CreatedAt: ti}}
+
 	default:
 		t.Fatalf("unknown changeset event kind: %s", kind)
 	}
@@ -1319,6 +1371,25 @@ func bbsActivity(id int64, t time.Time, username string, kind campaigns.Changese
 	}
 }
 
+func glApproval(id int64, t time.Time, username string, approved bool) *campaigns.ChangesetEvent {
+	note := gitlab.Note{
+		CreatedAt: t,
+		Author:    gitlab.User{Username: username},
+	}
+	if approved {
+		return &campaigns.ChangesetEvent{
+			ChangesetID: id,
+			Kind:        campaigns.ChangesetEventKindGitLabApproved,
+			Metadata:    &gitlab.ReviewApprovedEvent{Note: note},
+		}
+	}
+	return &campaigns.ChangesetEvent{
+		ChangesetID: id,
+		Kind:        campaigns.ChangesetEventKindGitLabUnapproved,
+		Metadata:    &gitlab.ReviewUnapprovedEvent{Note: note},
+	}
+}
+
 func bbsParticipantEvent(id int64, t time.Time, username string, kind campaigns.ChangesetEventKind) *campaigns.ChangesetEvent {
 	return &campaigns.ChangesetEvent{
 		ChangesetID: id,
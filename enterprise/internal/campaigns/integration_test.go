@@ -31,6 +31,7 @@ func TestIntegration(t *testing.T) {
 		t.Run("PatchSets_DeleteExpired", storeTest(db, testStorePatchSetsDeleteExpired))
 		t.Run("Patches", storeTest(db, testStorePatches))
 		t.Run("ChangesetJobs", storeTest(db, testStoreChangesetJobs))
+		t.Run("ActionExecutions", storeTest(db, testStoreActionExecutions))
 	})
 
 	t.Run("GitHubWebhook", testGitHubWebhook(db, userID))
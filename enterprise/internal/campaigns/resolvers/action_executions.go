@@ -0,0 +1,205 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+const actionExecutionIDKind = "ActionExecution"
+
+func marshalActionExecutionID(id int64) graphql.ID {
+	return relay.MarshalID(actionExecutionIDKind, id)
+}
+
+func unmarshalActionExecutionID(id graphql.ID) (executionID int64, err error) {
+	err = relay.UnmarshalSpec(id, &executionID)
+	return
+}
+
+var _ graphqlbackend.ActionExecutionResolver = &actionExecutionResolver{}
+
+type actionExecutionResolver struct {
+	store     *ee.Store
+	execution *campaigns.ActionExecution
+}
+
+func (r *actionExecutionResolver) ID() graphql.ID {
+	return marshalActionExecutionID(r.execution.ID)
+}
+
+func (r *actionExecutionResolver) Definition() graphqlbackend.JSONCString {
+	return graphqlbackend.JSONCString(r.execution.Definition)
+}
+
+func (r *actionExecutionResolver) Status(ctx context.Context) (graphqlbackend.BackgroundProcessStatus, error) {
+	return r.store.GetActionExecutionStatus(ctx, ee.GetActionExecutionStatusOpts{ID: r.execution.ID})
+}
+
+func (r *actionExecutionResolver) Jobs(ctx context.Context, args *graphqlutil.ConnectionArgs) graphqlbackend.ActionJobConnectionResolver {
+	return &actionJobsConnectionResolver{
+		store: r.store,
+		opts: ee.ListActionJobsOpts{
+			ExecutionID: r.execution.ID,
+			Limit:       int(args.GetFirst()),
+		},
+	}
+}
+
+func (r *actionExecutionResolver) PatchSet(ctx context.Context) (graphqlbackend.PatchSetResolver, error) {
+	if r.execution.PatchSetID == 0 {
+		return nil, nil
+	}
+
+	patchSet, err := r.store.GetPatchSet(ctx, ee.GetPatchSetOpts{ID: r.execution.PatchSetID})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &patchSetResolver{store: r.store, patchSet: patchSet}, nil
+}
+
+func (r *actionExecutionResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.execution.CreatedAt}
+}
+
+func (r *actionExecutionResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.execution.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.execution.FinishedAt}
+}
+
+type actionJobsConnectionResolver struct {
+	store *ee.Store
+	opts  ee.ListActionJobsOpts
+
+	// cache results because they are used by multiple fields
+	once      sync.Once
+	jobs      []*campaigns.ActionJob
+	reposByID map[api.RepoID]*types.Repo
+	next      int64
+	err       error
+}
+
+func (r *actionJobsConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.ActionJobResolver, error) {
+	jobs, reposByID, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ActionJobResolver, 0, len(jobs))
+	for _, j := range jobs {
+		repo, ok := reposByID[j.RepoID]
+		if !ok {
+			// If it's not in reposByID the repository was either deleted or
+			// filtered out by the authz-filter.
+			continue
+		}
+		resolvers = append(resolvers, &actionJobResolver{job: j, preloadedRepo: repo})
+	}
+	return resolvers, nil
+}
+
+func (r *actionJobsConnectionResolver) compute(ctx context.Context) ([]*campaigns.ActionJob, map[api.RepoID]*types.Repo, int64, error) {
+	r.once.Do(func() {
+		r.jobs, r.next, r.err = r.store.ListActionJobs(ctx, r.opts)
+		if r.err != nil {
+			return
+		}
+
+		repoIDs := make([]api.RepoID, len(r.jobs))
+		for i, j := range r.jobs {
+			repoIDs[i] = j.RepoID
+		}
+
+		// 🚨 SECURITY: db.Repos.GetByIDs uses the authzFilter under the hood and
+		// filters out repositories that the user doesn't have access to.
+		rs, err := db.Repos.GetByIDs(ctx, repoIDs...)
+		if err != nil {
+			r.err = err
+			return
+		}
+
+		r.reposByID = make(map[api.RepoID]*types.Repo, len(rs))
+		for _, repo := range rs {
+			r.reposByID[repo.ID] = repo
+		}
+	})
+	return r.jobs, r.reposByID, r.next, r.err
+}
+
+func (r *actionJobsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	status, err := r.store.GetActionExecutionStatus(ctx, ee.GetActionExecutionStatusOpts{ID: r.opts.ExecutionID})
+	if err != nil {
+		return 0, err
+	}
+	return status.Total, nil
+}
+
+func (r *actionJobsConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, _, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(next != 0), nil
+}
+
+var _ graphqlbackend.ActionJobResolver = &actionJobResolver{}
+
+type actionJobResolver struct {
+	job           *campaigns.ActionJob
+	preloadedRepo *types.Repo
+}
+
+func (r *actionJobResolver) Repository(ctx context.Context) (*graphqlbackend.RepositoryResolver, error) {
+	return graphqlbackend.NewRepositoryResolver(r.preloadedRepo), nil
+}
+
+func (r *actionJobResolver) State() campaigns.ActionJobState {
+	return r.job.State()
+}
+
+func (r *actionJobResolver) Attempts() int32 {
+	return r.job.Attempts
+}
+
+func (r *actionJobResolver) Log() *string {
+	if r.job.Log == "" {
+		return nil
+	}
+	return &r.job.Log
+}
+
+func (r *actionJobResolver) Error() *string {
+	if r.job.Error == "" {
+		return nil
+	}
+	return &r.job.Error
+}
+
+func (r *actionJobResolver) StartedAt() *graphqlbackend.DateTime {
+	if r.job.StartedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.job.StartedAt}
+}
+
+func (r *actionJobResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.job.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.job.FinishedAt}
+}
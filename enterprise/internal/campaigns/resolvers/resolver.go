@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -607,6 +608,153 @@ func (r *Resolver) CreatePatchSetFromPatches(ctx context.Context, args graphqlba
 	return &patchSetResolver{store: r.store, patchSet: patchSet}, nil
 }
 
+func (r *Resolver) ActionExecutionByID(ctx context.Context, id graphql.ID) (graphqlbackend.ActionExecutionResolver, error) {
+	// 🚨 SECURITY: Only site admins may access action executions for now.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	executionID, err := unmarshalActionExecutionID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if executionID == 0 {
+		return nil, nil
+	}
+
+	execution, err := r.store.GetActionExecution(ctx, ee.GetActionExecutionOpts{ID: executionID})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &actionExecutionResolver{store: r.store, execution: execution}, nil
+}
+
+func (r *Resolver) CreateActionExecution(ctx context.Context, args *graphqlbackend.CreateActionExecutionArgs) (_ graphqlbackend.ActionExecutionResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateActionExecution", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins may execute actions for now.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := backend.CurrentUser(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+	if user == nil {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	// Check this before running the (possibly expensive) scope query.
+	if !conf.Get().CampaignsActionExecutionEnabled {
+		return nil, ee.ErrActionExecutionDisabled
+	}
+
+	action, err := campaigns.ParseAction(string(args.Definition))
+	if err != nil {
+		return nil, err
+	}
+
+	repoIDs, err := searchActionScope(ctx, action.ScopeQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving action scope")
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	execution, err := svc.CreateActionExecution(ctx, string(args.Definition), repoIDs, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &actionExecutionResolver{store: r.store, execution: execution}, nil
+}
+
+// searchActionScope returns the IDs of the repositories matched by the given
+// scope query of an action.
+func searchActionScope(ctx context.Context, query string) ([]api.RepoID, error) {
+	// Like src-cli, we want every matching repository, not only the first
+	// page of results.
+	if !strings.Contains(query, "count:") {
+		query += " count:999999"
+	}
+
+	search, err := graphqlbackend.NewSearchImplementer(&graphqlbackend.SearchArgs{Version: "V2", Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := search.Results(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if alert := results.Alert(); alert != nil && len(results.Results()) == 0 {
+		return nil, errors.New(alert.Title())
+	}
+
+	var ids []api.RepoID
+	seen := map[api.RepoID]struct{}{}
+	for _, res := range results.Results() {
+		var repo *graphqlbackend.RepositoryResolver
+		if r, ok := res.ToRepository(); ok {
+			repo = r
+		} else if fm, ok := res.ToFileMatch(); ok {
+			repo = fm.Repository()
+		} else if cr, ok := res.ToCommitSearchResult(); ok {
+			repo = cr.Commit().Repository()
+		} else {
+			continue
+		}
+
+		id := repo.Type().ID
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (r *Resolver) RetryActionExecution(ctx context.Context, args *graphqlbackend.RetryActionExecutionArgs) (_ graphqlbackend.ActionExecutionResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RetryActionExecution", fmt.Sprintf("ActionExecution: %q", args.ActionExecution))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins may execute actions for now.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	executionID, err := unmarshalActionExecutionID(args.ActionExecution)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling action execution id")
+	}
+
+	if executionID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	execution, err := svc.RetryActionExecution(ctx, executionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrying action execution")
+	}
+
+	return &actionExecutionResolver{store: r.store, execution: execution}, nil
+}
+
 func (r *Resolver) CloseCampaign(ctx context.Context, args *graphqlbackend.CloseCampaignArgs) (_ graphqlbackend.CampaignResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.CloseCampaign", fmt.Sprintf("Campaign: %q", args.Campaign))
 	defer func() {
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -96,6 +97,132 @@ func (s *Service) CreatePatchSetFromPatches(ctx context.Context, patches []*camp
 	return patchSet, nil
 }
 
+// ErrActionExecutionDisabled is returned by CreateActionExecution when
+// server-side execution of campaign actions is disabled in the site
+// configuration.
+var ErrActionExecutionDisabled = errors.New("server-side execution of campaign actions is disabled. Set campaigns.actionExecution.enabled in the site configuration to enable it")
+
+// ErrActionCommandStepsUnsupported is returned by CreateActionExecution when
+// the action contains a command step. Command steps would run directly on the
+// Sourcegraph instance, so only docker steps can be executed server-side.
+var ErrActionCommandStepsUnsupported = errors.New("command steps are not supported by server-side execution of campaign actions. Use a docker step instead")
+
+// CreateActionExecution creates an ActionExecution of the given action
+// definition with one ActionJob per repository, which are executed in the
+// background by RunActionWorkers. Repositories that the user can't access or
+// whose code host isn't supported by campaigns are skipped.
+func (s *Service) CreateActionExecution(ctx context.Context, definition string, repoIDs []api.RepoID, userID int32) (execution *campaigns.ActionExecution, err error) {
+	tr, ctx := trace.New(ctx, "service.CreateActionExecution", fmt.Sprintf("user: %d", userID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if userID == 0 {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	if !conf.Get().CampaignsActionExecutionEnabled {
+		return nil, ErrActionExecutionDisabled
+	}
+
+	action, err := campaigns.ParseAction(definition)
+	if err != nil {
+		return nil, err
+	}
+	for i, step := range action.Steps {
+		if step.Type == campaigns.ActionStepTypeCommand {
+			return nil, errors.Errorf("step %d: %s", i+1, ErrActionCommandStepsUnsupported)
+		}
+	}
+
+	// 🚨 SECURITY: We use db.Repos.GetByIDs to check for which the user has access.
+	rs, err := db.Repos.GetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	var supported []*types.Repo
+	for _, r := range rs {
+		if campaigns.IsRepoSupported(&r.ExternalRepo) {
+			supported = append(supported, r)
+		}
+	}
+	if len(supported) == 0 {
+		return nil, errors.New("the action's scope query matches no repositories that are supported by campaigns")
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Done(&err)
+
+	execution = &campaigns.ActionExecution{UserID: userID, Definition: definition}
+	if err := tx.CreateActionExecution(ctx, execution); err != nil {
+		return nil, err
+	}
+
+	for _, r := range supported {
+		job := &campaigns.ActionJob{ExecutionID: execution.ID, RepoID: r.ID}
+		if err := tx.CreateActionJob(ctx, job); err != nil {
+			return nil, err
+		}
+	}
+
+	return execution, nil
+}
+
+// RetryActionExecution puts the failed ActionJobs of the finished
+// ActionExecution with the given ID back into the queue. Once they have
+// finished, a new PatchSet is created for the execution.
+func (s *Service) RetryActionExecution(ctx context.Context, id int64) (execution *campaigns.ActionExecution, err error) {
+	tr, ctx := trace.New(ctx, "service.RetryActionExecution", fmt.Sprintf("action execution: %d", id))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Done(&err)
+
+	execution, err = tx.GetActionExecution(ctx, GetActionExecutionOpts{ID: id, ForUpdate: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting action execution")
+	}
+
+	if err := backend.CheckSiteAdminOrSameUser(ctx, execution.UserID); err != nil {
+		return nil, err
+	}
+
+	if execution.FinishedAt.IsZero() {
+		return nil, errors.New("action execution is still running")
+	}
+
+	status, err := tx.GetActionExecutionStatus(ctx, GetActionExecutionStatusOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	if status.Failed == 0 {
+		return execution, nil
+	}
+
+	if err := tx.ResetActionJobs(ctx, ResetActionJobsOpts{ExecutionID: id, OnlyFailed: true}); err != nil {
+		return nil, errors.Wrap(err, "resetting failed action jobs")
+	}
+
+	execution.PatchSetID = 0
+	execution.FinishedAt = time.Time{}
+	if err := tx.UpdateActionExecution(ctx, execution); err != nil {
+		return nil, err
+	}
+
+	return execution, nil
+}
+
 // CreateCampaign creates the Campaign. When a PatchSetID is set on the
 // Campaign it validates that the PatchSet contains Patches.
 func (s *Service) CreateCampaign(ctx context.Context, c *campaigns.Campaign) error {
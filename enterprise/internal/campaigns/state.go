@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
 
 // SetDerivedState will update the external state fields on the Changeset based
@@ -51,6 +52,9 @@ func ComputeCheckState(c *cmpgn.Changeset, events ChangesetEvents) cmpgn.Changes
 
 	case *bitbucketserver.PullRequest:
 		return computeBitbucketBuildStatus(c.UpdatedAt, m, events)
+
+	case *gitlab.MergeRequest:
+		return computeGitLabPipelineState(c.UpdatedAt, m, events)
 	}
 
 	return cmpgn.ChangesetCheckStateUnknown
@@ -143,6 +147,55 @@ func parseBitbucketBuildState(s string) cmpgn.ChangesetCheckState {
 	}
 }
 
+// computeGitLabPipelineState returns the check state of the most recent
+// pipeline of the merge request. A pipeline already aggregates the state of all
+// its jobs, so older pipelines are superseded by newer ones.
+func computeGitLabPipelineState(lastSynced time.Time, mr *gitlab.MergeRequest, events []*cmpgn.ChangesetEvent) cmpgn.ChangesetCheckState {
+	var latest *gitlab.Pipeline
+	consider := func(p *gitlab.Pipeline) {
+		if latest == nil || latest.CreatedAt.Before(p.CreatedAt) ||
+			(latest.ID == p.ID && latest.UpdatedAt.Before(p.UpdatedAt)) {
+			latest = p
+		}
+	}
+
+	// States from last sync
+	for _, p := range mr.Pipelines {
+		consider(p)
+	}
+
+	// Add any events we've received since our last sync
+	for _, e := range events {
+		if p, ok := e.Metadata.(*gitlab.Pipeline); ok && p.UpdatedAt.After(lastSynced) {
+			consider(p)
+		}
+	}
+
+	if latest == nil {
+		return cmpgn.ChangesetCheckStateUnknown
+	}
+	return parseGitLabPipelineStatus(latest.Status)
+}
+
+func parseGitLabPipelineStatus(status gitlab.PipelineStatus) cmpgn.ChangesetCheckState {
+	switch status {
+	case gitlab.PipelineStatusCreated,
+		gitlab.PipelineStatusWaitingForResource,
+		gitlab.PipelineStatusPreparing,
+		gitlab.PipelineStatusPending,
+		gitlab.PipelineStatusRunning,
+		gitlab.PipelineStatusScheduled,
+		gitlab.PipelineStatusManual:
+		return cmpgn.ChangesetCheckStatePending
+	case gitlab.PipelineStatusSuccess:
+		return cmpgn.ChangesetCheckStatePassed
+	case gitlab.PipelineStatusFailed, gitlab.PipelineStatusCanceled:
+		return cmpgn.ChangesetCheckStateFailed
+	default:
+		return cmpgn.ChangesetCheckStateUnknown
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*cmpgn.ChangesetEvent) cmpgn.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -315,6 +368,15 @@ func computeSingleChangesetState(c *cmpgn.Changeset) (s cmpgn.ChangesetState, er
 		} else {
 			s = cmpgn.ChangesetState(m.State)
 		}
+	case *gitlab.MergeRequest:
+		switch m.State {
+		case gitlab.MergeRequestStateOpened:
+			s = cmpgn.ChangesetStateOpen
+		case gitlab.MergeRequestStateClosed, gitlab.MergeRequestStateLocked:
+			s = cmpgn.ChangesetStateClosed
+		default:
+			s = cmpgn.ChangesetState(strings.ToUpper(string(m.State)))
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -351,6 +413,23 @@ func computeSingleChangesetReviewState(c *cmpgn.Changeset) (s cmpgn.ChangesetRev
 				states[cmpgn.ChangesetReviewStateApproved] = true
 			}
 		}
+
+	case *gitlab.MergeRequest:
+		// GitLab doesn't return the approvals with the merge request, so we
+		// replay the approval system notes to find out who currently
+		// approves it.
+		approvedBy := map[int32]bool{}
+		for _, n := range m.Notes {
+			switch n.ToEvent().(type) {
+			case *gitlab.ReviewApprovedEvent:
+				approvedBy[n.Author.ID] = true
+			case *gitlab.ReviewUnapprovedEvent:
+				delete(approvedBy, n.Author.ID)
+			}
+		}
+		if len(approvedBy) > 0 {
+			states[cmpgn.ChangesetReviewStateApproved] = true
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
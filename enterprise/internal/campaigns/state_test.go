@@ -1,6 +1,7 @@
 package campaigns
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
 
 func TestComputeGithubCheckState(t *testing.T) {
@@ -261,6 +263,89 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeGitLabPipelineState(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	lastSynced := now.Add(-1 * time.Minute)
+
+	pipeline := func(id int, minutesSinceSync int, status gitlab.PipelineStatus) *gitlab.Pipeline {
+		return &gitlab.Pipeline{
+			ID:        id,
+			Status:    status,
+			CreatedAt: lastSynced.Add(time.Duration(id) * time.Second),
+			UpdatedAt: lastSynced.Add(time.Duration(minutesSinceSync) * time.Minute),
+		}
+	}
+	pipelineEvent := func(id int, minutesSinceSync int, status gitlab.PipelineStatus) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{
+			Kind:     cmpgn.ChangesetEventKindGitLabPipeline,
+			Metadata: pipeline(id, minutesSinceSync, status),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		pipelines []*gitlab.Pipeline
+		events    []*cmpgn.ChangesetEvent
+		want      cmpgn.ChangesetCheckState
+	}{
+		{
+			name: "no pipelines",
+			want: cmpgn.ChangesetCheckStateUnknown,
+		},
+		{
+			name:      "synced success",
+			pipelines: []*gitlab.Pipeline{pipeline(1, 0, gitlab.PipelineStatusSuccess)},
+			want:      cmpgn.ChangesetCheckStatePassed,
+		},
+		{
+			name:      "synced running",
+			pipelines: []*gitlab.Pipeline{pipeline(1, 0, gitlab.PipelineStatusRunning)},
+			want:      cmpgn.ChangesetCheckStatePending,
+		},
+		{
+			name:      "synced canceled",
+			pipelines: []*gitlab.Pipeline{pipeline(1, 0, gitlab.PipelineStatusCanceled)},
+			want:      cmpgn.ChangesetCheckStateFailed,
+		},
+		{
+			name: "newer pipeline supersedes older failure",
+			pipelines: []*gitlab.Pipeline{
+				pipeline(1, 0, gitlab.PipelineStatusFailed),
+				pipeline(2, 0, gitlab.PipelineStatusSuccess),
+			},
+			want: cmpgn.ChangesetCheckStatePassed,
+		},
+		{
+			name:      "event updates synced pipeline",
+			pipelines: []*gitlab.Pipeline{pipeline(1, 0, gitlab.PipelineStatusRunning)},
+			events:    []*cmpgn.ChangesetEvent{pipelineEvent(1, 1, gitlab.PipelineStatusFailed)},
+			want:      cmpgn.ChangesetCheckStateFailed,
+		},
+		{
+			name:      "event for new pipeline",
+			pipelines: []*gitlab.Pipeline{pipeline(1, 0, gitlab.PipelineStatusFailed)},
+			events:    []*cmpgn.ChangesetEvent{pipelineEvent(2, 1, gitlab.PipelineStatusPending)},
+			want:      cmpgn.ChangesetCheckStatePending,
+		},
+		{
+			name:      "events before last sync are ignored",
+			pipelines: []*gitlab.Pipeline{pipeline(1, 0, gitlab.PipelineStatusSuccess)},
+			events:    []*cmpgn.ChangesetEvent{pipelineEvent(1, -1, gitlab.PipelineStatusRunning)},
+			want:      cmpgn.ChangesetCheckStatePassed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := &gitlab.MergeRequest{Pipelines: tc.pipelines}
+			have := computeGitLabPipelineState(lastSynced, mr, tc.events)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestComputeReviewState(t *testing.T) {
 	now := time.Now().UTC().Truncate(time.Microsecond)
 	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
@@ -317,6 +402,26 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: cmpgn.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gitlab - no events",
+			changeset: gitlabChangeset(daysAgo(10), gitlab.MergeRequestStateOpened, "alice"),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "gitlab - approval revoked",
+			changeset: gitlabChangeset(daysAgo(10), gitlab.MergeRequestStateOpened, "alice", "-alice"),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetReviewStatePending,
+		},
+		{
+			name:      "gitlab - changeset older than events",
+			changeset: gitlabChangeset(daysAgo(10), gitlab.MergeRequestStateOpened),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(0), reviewState: campaigns.ChangesetReviewStateApproved},
+			},
+			want: cmpgn.ChangesetReviewStateApproved,
+		},
 	}
 
 	for i, tc := range tests {
@@ -405,6 +510,32 @@ func TestComputeChangesetState(t *testing.T) {
 			},
 			want: cmpgn.ChangesetStateDeleted,
 		},
+		{
+			name:      "gitlab - opened",
+			changeset: gitlabChangeset(daysAgo(10), gitlab.MergeRequestStateOpened),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetStateOpen,
+		},
+		{
+			name:      "gitlab - locked",
+			changeset: gitlabChangeset(daysAgo(10), gitlab.MergeRequestStateLocked),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetStateClosed,
+		},
+		{
+			name:      "gitlab - merged",
+			changeset: gitlabChangeset(daysAgo(10), gitlab.MergeRequestStateMerged),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetStateMerged,
+		},
+		{
+			name:      "gitlab - changeset older than events",
+			changeset: gitlabChangeset(daysAgo(10), gitlab.MergeRequestStateOpened),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(0), state: campaigns.ChangesetStateClosed},
+			},
+			want: cmpgn.ChangesetStateClosed,
+		},
 	}
 
 	for i, tc := range tests {
@@ -451,6 +582,36 @@ func githubChangeset(updatedAt time.Time, state string) *campaigns.Changeset {
 	}
 }
 
+// gitlabChangeset returns a GitLab changeset with an approval system note for
+// each of the given usernames, or an unapproval one if the username is
+// prefixed with "-".
+func gitlabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, approvals ...string) *campaigns.Changeset {
+	mr := &gitlab.MergeRequest{State: state}
+	userIDs := map[string]int32{}
+	for i, username := range approvals {
+		body := "approved this merge request"
+		if strings.HasPrefix(username, "-") {
+			username = username[1:]
+			body = "unapproved this merge request"
+		}
+		if _, ok := userIDs[username]; !ok {
+			userIDs[username] = int32(len(userIDs) + 1)
+		}
+		mr.Notes = append(mr.Notes, &gitlab.Note{
+			ID:     i,
+			Body:   body,
+			Author: gitlab.User{ID: userIDs[username], Username: username},
+			System: true,
+		})
+	}
+
+	return &campaigns.Changeset{
+		ExternalServiceType: extsvc.TypeGitLab,
+		UpdatedAt:           updatedAt,
+		Metadata:            mr,
+	}
+}
+
 func setDeletedAt(c *campaigns.Changeset, deletedAt time.Time) *campaigns.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
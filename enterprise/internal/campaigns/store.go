@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
 
 // Store exposes methods to read and write campaigns domain models
@@ -2785,6 +2786,582 @@ func (s *Store) GetRepoIDsForFailedChangesetJobs(ctx context.Context, campaign i
 	return ids, nil
 }
 
+// CreateActionExecution creates the given ActionExecution.
+func (s *Store) CreateActionExecution(ctx context.Context, e *campaigns.ActionExecution) error {
+	q, err := s.createActionExecutionQuery(e)
+	if err != nil {
+		return err
+	}
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanActionExecution(e, sc)
+		return e.ID, 1, err
+	})
+}
+
+var createActionExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CreateActionExecution
+INSERT INTO action_executions (
+  user_id,
+  definition,
+  patch_set_id,
+  created_at,
+  updated_at,
+  finished_at
+)
+VALUES (%s, %s, %s, %s, %s, %s)
+RETURNING
+  id,
+  user_id,
+  definition,
+  patch_set_id,
+  created_at,
+  updated_at,
+  finished_at
+`
+
+func (s *Store) createActionExecutionQuery(e *campaigns.ActionExecution) (*sqlf.Query, error) {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = s.now()
+	}
+
+	if e.UpdatedAt.IsZero() {
+		e.UpdatedAt = e.CreatedAt
+	}
+
+	return sqlf.Sprintf(
+		createActionExecutionQueryFmtstr,
+		e.UserID,
+		e.Definition,
+		nullInt64Column(e.PatchSetID),
+		e.CreatedAt,
+		e.UpdatedAt,
+		nullTimeColumn(e.FinishedAt),
+	), nil
+}
+
+// UpdateActionExecution updates the given ActionExecution.
+func (s *Store) UpdateActionExecution(ctx context.Context, e *campaigns.ActionExecution) error {
+	q, err := s.updateActionExecutionQuery(e)
+	if err != nil {
+		return err
+	}
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanActionExecution(e, sc)
+		return e.ID, 1, err
+	})
+}
+
+var updateActionExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:UpdateActionExecution
+UPDATE action_executions
+SET (
+  user_id,
+  definition,
+  patch_set_id,
+  updated_at,
+  finished_at
+) = (%s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING
+  id,
+  user_id,
+  definition,
+  patch_set_id,
+  created_at,
+  updated_at,
+  finished_at
+`
+
+func (s *Store) updateActionExecutionQuery(e *campaigns.ActionExecution) (*sqlf.Query, error) {
+	e.UpdatedAt = s.now()
+
+	return sqlf.Sprintf(
+		updateActionExecutionQueryFmtstr,
+		e.UserID,
+		e.Definition,
+		nullInt64Column(e.PatchSetID),
+		e.UpdatedAt,
+		nullTimeColumn(e.FinishedAt),
+		e.ID,
+	), nil
+}
+
+// GetActionExecutionOpts captures the query options needed for getting an
+// ActionExecution.
+type GetActionExecutionOpts struct {
+	ID int64
+
+	// ForUpdate locks the row of the ActionExecution until the end of the
+	// transaction.
+	ForUpdate bool
+}
+
+// GetActionExecution gets an ActionExecution matching the given options.
+func (s *Store) GetActionExecution(ctx context.Context, opts GetActionExecutionOpts) (*campaigns.ActionExecution, error) {
+	q := getActionExecutionQuery(&opts)
+
+	var e campaigns.ActionExecution
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, scanActionExecution(&e, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if e.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &e, nil
+}
+
+var getActionExecutionsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:GetActionExecution
+SELECT
+  id,
+  user_id,
+  definition,
+  patch_set_id,
+  created_at,
+  updated_at,
+  finished_at
+FROM action_executions
+WHERE %s
+LIMIT 1
+`
+
+func getActionExecutionQuery(opts *GetActionExecutionOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("id = %s", opts.ID))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	queryTemplate := getActionExecutionsQueryFmtstr
+	if opts.ForUpdate {
+		queryTemplate += "FOR UPDATE\n"
+	}
+
+	return sqlf.Sprintf(queryTemplate, sqlf.Join(preds, "\n AND "))
+}
+
+// GetActionExecutionStatusOpts captures the query options needed for getting
+// the BackgroundProcessStatus for an ActionExecution.
+type GetActionExecutionStatusOpts struct {
+	ID int64
+
+	// ExcludeErrorsInRepos filters out error messages from ActionJobs in
+	// repositories with the given IDs. This is used to filter out error
+	// messages from repositories the user doesn't have access to.
+	ExcludeErrorsInRepos []api.RepoID
+}
+
+// GetActionExecutionStatus gets the campaigns.BackgroundProcessStatus for an
+// ActionExecution, computed from the states of its ActionJobs.
+func (s *Store) GetActionExecutionStatus(ctx context.Context, opts GetActionExecutionStatusOpts) (*campaigns.BackgroundProcessStatus, error) {
+	q := getActionExecutionStatusQuery(&opts)
+	return s.queryBackgroundProcessStatus(ctx, q)
+}
+
+func getActionExecutionStatusQuery(opts *GetActionExecutionStatusOpts) *sqlf.Query {
+	errorsPreds := []*sqlf.Query{
+		sqlf.Sprintf("finished_at IS NOT NULL AND error != ''"),
+	}
+
+	if len(opts.ExcludeErrorsInRepos) > 0 {
+		ids := make([]*sqlf.Query, 0, len(opts.ExcludeErrorsInRepos))
+		for _, repoID := range opts.ExcludeErrorsInRepos {
+			ids = append(ids, sqlf.Sprintf("%s", repoID))
+		}
+		errorsPreds = append(errorsPreds, sqlf.Sprintf("repo_id NOT IN (%s)", sqlf.Join(ids, ",")))
+	}
+
+	return sqlf.Sprintf(
+		getActionExecutionStatusQueryFmtstr,
+		sqlf.Join(errorsPreds, " AND "),
+		opts.ID,
+	)
+}
+
+var getActionExecutionStatusQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:GetActionExecutionStatus
+SELECT
+  -- canceled is here so that this can be used with scanBackgroundProcessStatus
+  false AS canceled,
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE finished_at IS NULL) AS pending,
+  COUNT(*) FILTER (WHERE finished_at IS NOT NULL) AS completed,
+  COUNT(*) FILTER (WHERE finished_at IS NOT NULL AND error != '') AS failed,
+  array_agg(error) FILTER (WHERE %s) AS errors
+FROM action_jobs
+WHERE execution_id = %s
+LIMIT 1
+`
+
+// CreateActionJob creates the given ActionJob.
+func (s *Store) CreateActionJob(ctx context.Context, j *campaigns.ActionJob) error {
+	q, err := s.createActionJobQuery(j)
+	if err != nil {
+		return err
+	}
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanActionJob(j, sc)
+		return j.ID, 1, err
+	})
+}
+
+var createActionJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CreateActionJob
+INSERT INTO action_jobs (
+  execution_id,
+  repo_id,
+  rev,
+  base_ref,
+  diff,
+  log,
+  error,
+  attempts,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING
+  id,
+  execution_id,
+  repo_id,
+  rev,
+  base_ref,
+  diff,
+  log,
+  error,
+  attempts,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+`
+
+func (s *Store) createActionJobQuery(j *campaigns.ActionJob) (*sqlf.Query, error) {
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = s.now()
+	}
+
+	if j.UpdatedAt.IsZero() {
+		j.UpdatedAt = j.CreatedAt
+	}
+
+	return sqlf.Sprintf(
+		createActionJobQueryFmtstr,
+		j.ExecutionID,
+		j.RepoID,
+		j.Rev,
+		j.BaseRef,
+		j.Diff,
+		j.Log,
+		j.Error,
+		j.Attempts,
+		nullTimeColumn(j.StartedAt),
+		nullTimeColumn(j.FinishedAt),
+		j.CreatedAt,
+		j.UpdatedAt,
+	), nil
+}
+
+// UpdateActionJob updates the given ActionJob.
+func (s *Store) UpdateActionJob(ctx context.Context, j *campaigns.ActionJob) error {
+	q, err := s.updateActionJobQuery(j)
+	if err != nil {
+		return err
+	}
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanActionJob(j, sc)
+		return j.ID, 1, err
+	})
+}
+
+var updateActionJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:UpdateActionJob
+UPDATE action_jobs
+SET (
+  execution_id,
+  repo_id,
+  rev,
+  base_ref,
+  diff,
+  log,
+  error,
+  attempts,
+  started_at,
+  finished_at,
+  updated_at
+) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING
+  id,
+  execution_id,
+  repo_id,
+  rev,
+  base_ref,
+  diff,
+  log,
+  error,
+  attempts,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+`
+
+func (s *Store) updateActionJobQuery(j *campaigns.ActionJob) (*sqlf.Query, error) {
+	j.UpdatedAt = s.now()
+
+	return sqlf.Sprintf(
+		updateActionJobQueryFmtstr,
+		j.ExecutionID,
+		j.RepoID,
+		j.Rev,
+		j.BaseRef,
+		j.Diff,
+		j.Log,
+		j.Error,
+		j.Attempts,
+		nullTimeColumn(j.StartedAt),
+		nullTimeColumn(j.FinishedAt),
+		j.UpdatedAt,
+		j.ID,
+	), nil
+}
+
+// ListActionJobsOpts captures the query options needed for listing
+// ActionJobs.
+type ListActionJobsOpts struct {
+	ExecutionID int64
+	Cursor      int64
+	Limit       int
+}
+
+// ListActionJobs lists ActionJobs with the given filters.
+func (s *Store) ListActionJobs(ctx context.Context, opts ListActionJobsOpts) (js []*campaigns.ActionJob, next int64, err error) {
+	q := listActionJobsQuery(&opts)
+
+	js = make([]*campaigns.ActionJob, 0, opts.Limit)
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var j campaigns.ActionJob
+		if err = scanActionJob(&j, sc); err != nil {
+			return 0, 0, err
+		}
+		js = append(js, &j)
+		return j.ID, 1, err
+	})
+
+	if opts.Limit != 0 && len(js) == opts.Limit {
+		next = js[len(js)-1].ID
+		js = js[:len(js)-1]
+	}
+
+	return js, next, err
+}
+
+var listActionJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListActionJobs
+SELECT
+  id,
+  execution_id,
+  repo_id,
+  rev,
+  base_ref,
+  diff,
+  log,
+  error,
+  attempts,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+FROM action_jobs
+WHERE %s
+ORDER BY id ASC
+`
+
+func listActionJobsQuery(opts *ListActionJobsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("id >= %s", opts.Cursor),
+	}
+
+	if opts.ExecutionID != 0 {
+		preds = append(preds, sqlf.Sprintf("execution_id = %s", opts.ExecutionID))
+	}
+
+	return sqlf.Sprintf(listActionJobsQueryFmtstr+limitClause, sqlf.Join(preds, "\n AND "))
+}
+
+// DequeueActionJob marks the least recently updated ActionJob that hasn't
+// been started yet as started, increments its attempts and returns it. If no
+// such job exists, it returns ErrNoResults.
+//
+// Unlike ProcessPendingChangesetJobs, this doesn't hold a transaction open
+// while the job is processed, since running the steps of an action can take
+// a long time. Jobs of workers that died while processing them are put back
+// into the queue by ResetStalledActionJobs.
+func (s *Store) DequeueActionJob(ctx context.Context) (*campaigns.ActionJob, error) {
+	now := s.now()
+	q := sqlf.Sprintf(dequeueActionJobQueryFmtstr, now, now)
+
+	var j campaigns.ActionJob
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, scanActionJob(&j, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if j.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &j, nil
+}
+
+var dequeueActionJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:DequeueActionJob
+UPDATE action_jobs
+SET
+  started_at = %s,
+  updated_at = %s,
+  attempts = attempts + 1
+WHERE id = (
+  SELECT id FROM action_jobs
+  WHERE started_at IS NULL
+  ORDER BY updated_at ASC, id ASC
+  FOR UPDATE SKIP LOCKED
+  LIMIT 1
+)
+RETURNING
+  id,
+  execution_id,
+  repo_id,
+  rev,
+  base_ref,
+  diff,
+  log,
+  error,
+  attempts,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+`
+
+// ErrActionJobStalled is the error of ActionJobs that stalled in each of
+// their attempts.
+var ErrActionJobStalled = errors.New("action job did not finish in any of its attempts")
+
+// ResetStalledActionJobs puts ActionJobs that were started before the given
+// time but never finished back into the queue. Jobs that have already been
+// attempted maxAttempts times are marked as errored instead, and the IDs of
+// their executions are returned.
+func (s *Store) ResetStalledActionJobs(ctx context.Context, startedBefore time.Time, maxAttempts int32) (executionIDs []int64, err error) {
+	now := s.now()
+	q := sqlf.Sprintf(
+		resetStalledActionJobsQueryFmtstr,
+		maxAttempts,
+		maxAttempts, now,
+		maxAttempts, ErrActionJobStalled.Error(),
+		now,
+		startedBefore,
+	)
+
+	seen := map[int64]bool{}
+	err = s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var executionID int64
+		var errored bool
+		if err := sc.Scan(&executionID, &errored); err != nil {
+			return 0, 0, err
+		}
+		if errored && !seen[executionID] {
+			seen[executionID] = true
+			executionIDs = append(executionIDs, executionID)
+		}
+		return executionID, 1, nil
+	})
+	return executionIDs, err
+}
+
+var resetStalledActionJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ResetStalledActionJobs
+UPDATE action_jobs
+SET
+  started_at = CASE WHEN attempts < %s THEN NULL ELSE started_at END,
+  finished_at = CASE WHEN attempts < %s THEN NULL ELSE %s END,
+  error = CASE WHEN attempts < %s THEN error ELSE %s END,
+  updated_at = %s
+WHERE started_at < %s AND finished_at IS NULL
+RETURNING execution_id, finished_at IS NOT NULL
+`
+
+// ResetActionJobsOpts captures the query options needed for resetting
+// ActionJobs.
+type ResetActionJobsOpts struct {
+	// The ExecutionID of the ActionJobs to be reset.
+	ExecutionID int64
+
+	// If OnlyFailed is set, only ActionJobs that finished with an error are
+	// reset.
+	OnlyFailed bool
+}
+
+// ResetActionJobs puts the ActionJobs matching the given options back into the
+// queue, resetting their Error, Attempts, StartedAt and FinishedAt fields.
+func (s *Store) ResetActionJobs(ctx context.Context, opts ResetActionJobsOpts) error {
+	if opts.ExecutionID == 0 {
+		return errors.New("ExecutionID cannot be zero")
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("execution_id = %s", opts.ExecutionID),
+	}
+
+	if opts.OnlyFailed {
+		preds = append(preds, sqlf.Sprintf("finished_at IS NOT NULL AND error != ''"))
+	}
+
+	q := sqlf.Sprintf(resetActionJobsQueryFmtstr, s.now(), sqlf.Join(preds, "\n AND "))
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		return 0, 1, nil
+	})
+}
+
+var resetActionJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ResetActionJobs
+UPDATE action_jobs
+SET
+  error = '',
+  attempts = 0,
+  started_at = NULL,
+  finished_at = NULL,
+  updated_at = %s
+WHERE %s
+`
+
 func (s *Store) exec(ctx context.Context, q *sqlf.Query, sc scanFunc) error {
 	_, _, err := s.query(ctx, q, sc)
 	return err
@@ -2867,6 +3444,8 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 		t.Metadata = new(github.PullRequest)
 	case extsvc.TypeBitbucketServer:
 		t.Metadata = new(bitbucketserver.PullRequest)
+	case extsvc.TypeGitLab:
+		t.Metadata = new(gitlab.MergeRequest)
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -2958,6 +3537,36 @@ func scanChangesetJob(c *campaigns.ChangesetJob, s scanner) error {
 	)
 }
 
+func scanActionExecution(e *campaigns.ActionExecution, s scanner) error {
+	return s.Scan(
+		&e.ID,
+		&e.UserID,
+		&e.Definition,
+		&dbutil.NullInt64{N: &e.PatchSetID},
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&dbutil.NullTime{Time: &e.FinishedAt},
+	)
+}
+
+func scanActionJob(j *campaigns.ActionJob, s scanner) error {
+	return s.Scan(
+		&j.ID,
+		&j.ExecutionID,
+		&j.RepoID,
+		&j.Rev,
+		&j.BaseRef,
+		&j.Diff,
+		&j.Log,
+		&j.Error,
+		&j.Attempts,
+		&dbutil.NullTime{Time: &j.StartedAt},
+		&dbutil.NullTime{Time: &j.FinishedAt},
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	)
+}
+
 func scanBackgroundProcessStatus(b *campaigns.BackgroundProcessStatus, s scanner) error {
 	return s.Scan(
 		&b.Canceled,
@@ -2371,6 +2371,211 @@ func testStorePatchSetsDeleteExpired(t *testing.T, ctx context.Context, s *Store
 	}
 }
 
+func testStoreActionExecutions(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	execution := &cmpgn.ActionExecution{
+		UserID:     1,
+		Definition: `{"scopeQuery": "repo:github", "steps": [{"type": "command", "args": ["true"]}]}`,
+	}
+
+	t.Run("CreateActionExecution", func(t *testing.T) {
+		want := execution.Clone()
+		if err := s.CreateActionExecution(ctx, execution); err != nil {
+			t.Fatal(err)
+		}
+
+		if execution.ID == 0 {
+			t.Fatal("ID should not be zero")
+		}
+
+		want.ID = execution.ID
+		want.CreatedAt = clock.now()
+		want.UpdatedAt = clock.now()
+
+		if diff := cmp.Diff(execution, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	jobs := make([]*cmpgn.ActionJob, 0, 3)
+
+	t.Run("CreateActionJob", func(t *testing.T) {
+		for i := 0; i < cap(jobs); i++ {
+			j := &cmpgn.ActionJob{ExecutionID: execution.ID, RepoID: api.RepoID(i + 1)}
+
+			want := j.Clone()
+			if err := s.CreateActionJob(ctx, j); err != nil {
+				t.Fatal(err)
+			}
+
+			want.ID = j.ID
+			want.CreatedAt = clock.now()
+			want.UpdatedAt = clock.now()
+
+			if diff := cmp.Diff(j, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			jobs = append(jobs, j)
+		}
+	})
+
+	t.Run("ListActionJobs", func(t *testing.T) {
+		have, next, err := s.ListActionJobs(ctx, ListActionJobsOpts{ExecutionID: execution.ID, Limit: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if next != jobs[2].ID {
+			t.Fatalf("have next %d, want %d", next, jobs[2].ID)
+		}
+
+		if diff := cmp.Diff(have, jobs[:2]); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("DequeueActionJob", func(t *testing.T) {
+		clock.add(1 * time.Second)
+
+		for i := range jobs {
+			have, err := s.DequeueActionJob(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID != jobs[i].ID {
+				t.Fatalf("dequeued job %d, want %d", have.ID, jobs[i].ID)
+			}
+
+			if have.Attempts != 1 || have.StartedAt != clock.now() {
+				t.Fatalf("job not marked as started: %+v", have)
+			}
+
+			jobs[i] = have
+		}
+
+		if _, err := s.DequeueActionJob(ctx); err != ErrNoResults {
+			t.Fatalf("have error %v, want %v", err, ErrNoResults)
+		}
+	})
+
+	t.Run("GetActionExecutionStatus", func(t *testing.T) {
+		jobs[0].Diff = "diff"
+		jobs[0].FinishedAt = clock.now()
+		jobs[1].Error = "step 1: exit status 1"
+		jobs[1].FinishedAt = clock.now()
+		for _, j := range jobs[:2] {
+			if err := s.UpdateActionJob(ctx, j); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := s.GetActionExecutionStatus(ctx, GetActionExecutionStatusOpts{ID: execution.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &cmpgn.BackgroundProcessStatus{
+			Total:         3,
+			Completed:     2,
+			Pending:       1,
+			Failed:        1,
+			ProcessState:  cmpgn.BackgroundProcessStateProcessing,
+			ProcessErrors: []string{"step 1: exit status 1"},
+		}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("ResetStalledActionJobs", func(t *testing.T) {
+		clock.add(1 * time.Second)
+
+		executionIDs, err := s.ResetStalledActionJobs(ctx, clock.now(), 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(executionIDs) != 0 {
+			t.Fatalf("unexpected errored executions: %v", executionIDs)
+		}
+
+		have, err := s.DequeueActionJob(ctx)
+		if err != nil {
+			t.Fatalf("stalled job was not reset: %s", err)
+		}
+		if have.ID != jobs[2].ID || have.Attempts != 2 {
+			t.Fatalf("unexpected job dequeued: %+v", have)
+		}
+	})
+
+	t.Run("ResetActionJobs", func(t *testing.T) {
+		err := s.ResetActionJobs(ctx, ResetActionJobsOpts{ExecutionID: execution.ID, OnlyFailed: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.DequeueActionJob(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.ID != jobs[1].ID || have.Error != "" || have.Attempts != 1 {
+			t.Fatalf("failed job was not reset: %+v", have)
+		}
+	})
+
+	t.Run("ResetStalledActionJobs max attempts", func(t *testing.T) {
+		clock.add(1 * time.Second)
+
+		// jobs[2] is in its second attempt, jobs[1] in its first.
+		executionIDs, err := s.ResetStalledActionJobs(ctx, clock.now(), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{execution.ID}, executionIDs); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, _, err := s.ListActionJobs(ctx, ListActionJobsOpts{ExecutionID: execution.ID, Limit: -1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, j := range have {
+			switch j.ID {
+			case jobs[1].ID:
+				if j.State() != cmpgn.ActionJobStateQueued {
+					t.Errorf("job in first attempt was not reset: %+v", j)
+				}
+			case jobs[2].ID:
+				if j.State() != cmpgn.ActionJobStateErrored || j.Error != ErrActionJobStalled.Error() {
+					t.Errorf("job in last attempt was not marked as errored: %+v", j)
+				}
+			}
+		}
+	})
+
+	t.Run("UpdateActionExecution", func(t *testing.T) {
+		clock.add(1 * time.Second)
+		execution.PatchSetID = 42
+		execution.FinishedAt = clock.now()
+
+		want := execution.Clone()
+		want.UpdatedAt = clock.now()
+
+		if err := s.UpdateActionExecution(ctx, execution); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetActionExecution(ctx, GetActionExecutionOpts{ID: execution.ID, ForUpdate: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
 func testStoreChangesetJobs(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
 	changesetJobs := make([]*cmpgn.ChangesetJob, 0, 3)
 
@@ -2,6 +2,7 @@ package campaigns
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -21,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -30,7 +32,7 @@ type Webhook struct {
 	Now   func() time.Time
 
 	// ServiceType corresponds to api.ExternalRepoSpec.ServiceType
-	// Example values: extsvc.TypeBitbucketServer, extsvc.TypeGitHub
+	// Example values: extsvc.TypeBitbucketServer, extsvc.TypeGitHub, extsvc.TypeGitLab
 	ServiceType string
 }
 
@@ -78,6 +80,8 @@ func extractExternalServiceID(extSvc *repos.ExternalService) (string, error) {
 		serviceID = c.Url
 	case *schema.BitbucketServerConnection:
 		serviceID = c.Url
+	case *schema.GitLabConnection:
+		serviceID = c.Url
 	}
 	if serviceID == "" {
 		return "", errors.New("could not determine service id")
@@ -903,6 +907,122 @@ func (h *BitbucketServerWebhook) convertEvent(theirs interface{}) (prs []PR, our
 	return
 }
 
+// GitLabWebhook receives GitLab project webhook events that are relevant to
+// campaigns, normalizes those events into ChangesetEvents and upserts them to
+// the database.
+type GitLabWebhook struct {
+	*Webhook
+}
+
+func NewGitLabWebhook(store *Store, repos repos.Store, now func() time.Time) *GitLabWebhook {
+	return &GitLabWebhook{&Webhook{store, repos, now, extsvc.TypeGitLab}}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *GitLabWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, extSvc, hErr := h.parseEvent(r)
+	if hErr != nil {
+		respond(w, hErr.code, hErr)
+		return
+	}
+
+	externalServiceID, err := extractExternalServiceID(extSvc)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	pr, ev := h.convertEvent(e)
+	if pr == (PR{}) || ev == nil {
+		log15.Debug("Dropping GitLab webhook event", "type", fmt.Sprintf("%T", e))
+		return
+	}
+
+	if err := h.upsertChangesetEvent(r.Context(), externalServiceID, pr, ev); err != nil {
+		respond(w, http.StatusInternalServerError, err)
+	}
+}
+
+func (h *GitLabWebhook) parseEvent(r *http.Request) (interface{}, *repos.ExternalService, *httpError) {
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	token := gitlab.WebhookToken(r)
+	if token == "" {
+		return nil, nil, &httpError{http.StatusUnauthorized, errors.New("missing webhook token")}
+	}
+
+	rawID := r.FormValue(extsvc.IDParam)
+	var externalServiceID int64
+	if rawID != "" {
+		externalServiceID, err = strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "invalid external service id")}
+		}
+	}
+
+	args := repos.StoreListExternalServicesArgs{Kinds: []string{extsvc.KindGitLab}}
+	if externalServiceID != 0 {
+		args.IDs = append(args.IDs, externalServiceID)
+	}
+	es, err := h.Repos.ListExternalServices(r.Context(), args)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	var extSvc *repos.ExternalService
+	for _, e := range es {
+		c, _ := e.Configuration()
+		con, ok := c.(*schema.GitLabConnection)
+		if !ok {
+			continue
+		}
+
+		for _, hook := range con.Webhooks {
+			if subtle.ConstantTimeCompare([]byte(hook.Secret), []byte(token)) == 1 {
+				extSvc = e
+				break
+			}
+		}
+		if extSvc != nil {
+			break
+		}
+	}
+
+	if extSvc == nil {
+		return nil, nil, &httpError{http.StatusUnauthorized, errors.New("no GitLab connection matches the webhook token")}
+	}
+
+	e, err := gitlab.ParseWebhookEvent(gitlab.WebhookEventType(r), payload)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "parsing webhook")}
+	}
+	return e, extSvc, nil
+}
+
+func (h *GitLabWebhook) convertEvent(theirs interface{}) (pr PR, ours keyer) {
+	log15.Debug("GitLab webhook received", "type", fmt.Sprintf("%T", theirs))
+
+	switch e := theirs.(type) {
+	case *gitlab.MergeRequestEvent:
+		ev, ok := e.ToEvent().(keyer)
+		if !ok {
+			return PR{}, nil
+		}
+		return PR{ID: int64(e.ObjectAttributes.IID), RepoExternalID: strconv.Itoa(e.Project.ID)}, ev
+
+	case *gitlab.PipelineEvent:
+		if e.MergeRequest == nil {
+			return PR{}, nil
+		}
+		return PR{ID: int64(e.MergeRequest.IID), RepoExternalID: strconv.Itoa(e.Project.ID)}, e.Pipeline()
+	}
+
+	return PR{}, nil
+}
+
 type httpError struct {
 	code int
 	err  error
@@ -427,3 +427,98 @@ func marshalJSON(t testing.TB, v interface{}) string {
 
 	return string(bs)
 }
+
+func TestGitLabWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(repos.FakeStore)
+	extSvc := &repos.ExternalService{
+		Kind:        extsvc.KindGitLab,
+		DisplayName: "GitLab",
+		Config: marshalJSON(t, &schema.GitLabConnection{
+			Url:      "https://gitlab.example.com",
+			Token:    "token",
+			Webhooks: []*schema.GitLabWebhook{{Secret: "secret"}},
+		}),
+	}
+	if err := store.UpsertExternalServices(ctx, extSvc); err != nil {
+		t.Fatal(err)
+	}
+
+	hook := NewGitLabWebhook(nil, store, time.Now)
+
+	newRequest := func(eventType, token, payload string) *http.Request {
+		req, err := http.NewRequest("POST", "", strings.NewReader(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Gitlab-Event", eventType)
+		if token != "" {
+			req.Header.Set("X-Gitlab-Token", token)
+		}
+		return req
+	}
+
+	t.Run("authentication", func(t *testing.T) {
+		for _, token := range []string{"", "wrong"} {
+			_, _, hErr := hook.parseEvent(newRequest("Merge Request Hook", token, `{}`))
+			if hErr == nil || hErr.code != http.StatusUnauthorized {
+				t.Errorf("token %q: got error %v, want status %d", token, hErr, http.StatusUnauthorized)
+			}
+		}
+	})
+
+	t.Run("merge request approved", func(t *testing.T) {
+		e, svc, hErr := hook.parseEvent(newRequest("Merge Request Hook", "secret", `{
+			"user": {"id": 7, "username": "alice"},
+			"project": {"id": 42},
+			"object_attributes": {"iid": 3, "action": "approved", "updated_at": "2020-05-01T10:00:00Z"}
+		}`))
+		if hErr != nil {
+			t.Fatal(hErr)
+		}
+		if svc.ID != extSvc.ID {
+			t.Fatalf("got external service %d, want %d", svc.ID, extSvc.ID)
+		}
+
+		pr, ev := hook.convertEvent(e)
+		if want := (PR{ID: 3, RepoExternalID: "42"}); pr != want {
+			t.Errorf("got PR %+v, want %+v", pr, want)
+		}
+		if kind := campaigns.ChangesetEventKindFor(ev); kind != campaigns.ChangesetEventKindGitLabApproved {
+			t.Errorf("got event kind %q, want %q", kind, campaigns.ChangesetEventKindGitLabApproved)
+		}
+	})
+
+	t.Run("merge request updated", func(t *testing.T) {
+		e, _, hErr := hook.parseEvent(newRequest("Merge Request Hook", "secret", `{
+			"project": {"id": 42},
+			"object_attributes": {"iid": 3, "action": "update"}
+		}`))
+		if hErr != nil {
+			t.Fatal(hErr)
+		}
+		if _, ev := hook.convertEvent(e); ev != nil {
+			t.Errorf("got event %+v, want none", ev)
+		}
+	})
+
+	t.Run("pipeline", func(t *testing.T) {
+		e, _, hErr := hook.parseEvent(newRequest("Pipeline Hook", "secret", `{
+			"project": {"id": 42},
+			"object_attributes": {"id": 9, "status": "running", "created_at": "2020-05-01 10:00:00 UTC"},
+			"merge_request": {"iid": 3}
+		}`))
+		if hErr != nil {
+			t.Fatal(hErr)
+		}
+
+		pr, ev := hook.convertEvent(e)
+		if want := (PR{ID: 3, RepoExternalID: "42"}); pr != want {
+			t.Errorf("got PR %+v, want %+v", pr, want)
+		}
+		if kind := campaigns.ChangesetEventKindFor(ev); kind != campaigns.ChangesetEventKindGitLabPipeline {
+			t.Errorf("got event kind %q, want %q", kind, campaigns.ChangesetEventKindGitLabPipeline)
+		}
+	})
+}
@@ -15,7 +15,7 @@ type CodeIntelAPI interface {
 	// queries for the given path. If exactPath is true, then only dumps that definitely contain the
 	// exact document path are returned. Otherwise, dumps containing any document for which the given
 	// path is a prefix are returned. These dump IDs should be subsequently passed to invocations of
-	// Definitions, References, and Hover.
+	// Definitions, References, Implementations, and Hover.
 	FindClosestDumps(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) ([]store.Dump, error)
 
 	// Definitions returns the list of source locations that define the symbol at the given position.
@@ -26,6 +26,10 @@ type CodeIntelAPI interface {
 	// This may include references from other dumps and repositories.
 	References(ctx context.Context, repositoryID int, commit string, limit int, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error)
 
+	// Implementations returns the list of source locations that implement the symbol at the given
+	// position. This may include implementations from other dumps and repositories.
+	Implementations(ctx context.Context, repositoryID int, commit string, limit int, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error)
+
 	// Hover returns the hover text and range for the symbol at the given position.
 	Hover(ctx context.Context, file string, line, character, uploadID int) (string, bundles.Range, bool, error)
 
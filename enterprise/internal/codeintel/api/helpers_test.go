@@ -210,6 +210,21 @@ func setMockBundleClientReferences(t *testing.T, mockBundleClient *bundlemocks.M
 	})
 }
 
+func setMockBundleClientImplementations(t *testing.T, mockBundleClient *bundlemocks.MockBundleClient, expectedPath string, expectedLine, expectedCharacter int, locations []bundles.Location) {
+	mockBundleClient.ImplementationsFunc.SetDefaultHook(func(ctx context.Context, path string, line, character int) ([]bundles.Location, error) {
+		if path != expectedPath {
+			t.Errorf("unexpected path for Implementations. want=%s have=%s", expectedPath, path)
+		}
+		if line != expectedLine {
+			t.Errorf("unexpected line for Implementations. want=%d have=%d", expectedLine, line)
+		}
+		if character != expectedCharacter {
+			t.Errorf("unexpected character for Implementations. want=%d have=%d", expectedCharacter, character)
+		}
+		return locations, nil
+	})
+}
+
 func setMockBundleClientHover(t *testing.T, mockBundleClient *bundlemocks.MockBundleClient, expectedPath string, expectedLine, expectedCharacter int, text string, r bundles.Range, exists bool) {
 	mockBundleClient.HoverFunc.SetDefaultHook(func(ctx context.Context, path string, line, character int) (string, bundles.Range, bool, error) {
 		if path != expectedPath {
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+)
+
+// Implementations returns the list of source locations that implement the symbol at the given
+// position. This may include implementations from other dumps and repositories.
+func (api *codeIntelAPI) Implementations(ctx context.Context, repositoryID int, commit string, limit int, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error) {
+	if limit <= 0 {
+		return nil, Cursor{}, false, ErrIllegalLimit
+	}
+
+	rpr := &ReferencePageResolver{
+		store:               api.store,
+		bundleManagerClient: api.bundleManagerClient,
+		modelType:           "implementation",
+		repositoryID:        repositoryID,
+		commit:              commit,
+		remoteDumpLimit:     RemoteDumpLimit,
+		limit:               limit,
+	}
+
+	return rpr.resolvePage(ctx, cursor)
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	bundlemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+)
+
+func TestImplementationsIllegalLimit(t *testing.T) {
+	api := New(storemocks.NewMockStore(), bundlemocks.NewMockBundleManagerClient(), nil)
+
+	if _, _, _, err := api.Implementations(context.Background(), 100, testCommit, 0, Cursor{}); err != ErrIllegalLimit {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrIllegalLimit, err)
+	}
+}
+
+func TestHandleSameDumpCursorImplementations(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient := bundlemocks.NewMockBundleClient()
+
+	setMockStoreGetDumpByID(t, mockStore, map[int]store.Dump{42: testDump1})
+	setMockBundleManagerClientBundleClient(t, mockBundleManagerClient, map[int]bundles.BundleClient{42: mockBundleClient})
+	setMockBundleClientImplementations(t, mockBundleClient, "main.go", 23, 34, []bundles.Location{
+		{DumpID: 42, Path: "foo.go", Range: testRange1},
+		{DumpID: 42, Path: "bar.go", Range: testRange2},
+	})
+
+	rpr := &ReferencePageResolver{
+		store:               mockStore,
+		bundleManagerClient: mockBundleManagerClient,
+		modelType:           "implementation",
+		repositoryID:        100,
+		commit:              testCommit,
+		limit:               5,
+	}
+
+	implementations, newCursor, hasNewCursor, err := rpr.dispatchCursorHandler(context.Background(), Cursor{
+		Phase:       "same-dump",
+		DumpID:      42,
+		Path:        "main.go",
+		Line:        23,
+		Character:   34,
+		Monikers:    []bundles.MonikerData{{Kind: "import", Scheme: "gomod", Identifier: "pad"}},
+		SkipResults: 0,
+	})
+	if err != nil {
+		t.Fatalf("expected error getting implementations: %s", err)
+	}
+
+	expectedImplementations := []ResolvedLocation{
+		{Dump: testDump1, Path: "sub1/foo.go", Range: testRange1},
+		{Dump: testDump1, Path: "sub1/bar.go", Range: testRange2},
+	}
+	if diff := cmp.Diff(expectedImplementations, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+
+	expectedNewCursor := Cursor{
+		Phase:       "same-dump-monikers",
+		DumpID:      42,
+		Path:        "main.go",
+		Line:        23,
+		Character:   34,
+		Monikers:    []bundles.MonikerData{{Kind: "import", Scheme: "gomod", Identifier: "pad"}},
+		SkipResults: 0,
+	}
+	if !hasNewCursor {
+		t.Errorf("expected new cursor")
+	} else if diff := cmp.Diff(expectedNewCursor, newCursor); diff != "" {
+		t.Errorf("unexpected new cursor (-want +got):\n%s", diff)
+	}
+
+	if len(mockBundleClient.ReferencesFunc.History()) != 0 {
+		t.Errorf("unexpected call to References")
+	}
+}
+
+func TestHandleSameDumpMonikersCursorImplementations(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient := bundlemocks.NewMockBundleClient()
+
+	setMockStoreGetDumpByID(t, mockStore, map[int]store.Dump{42: testDump1})
+	setMockBundleManagerClientBundleClient(t, mockBundleManagerClient, map[int]bundles.BundleClient{42: mockBundleClient})
+	setMockBundleClientImplementations(t, mockBundleClient, "main.go", 23, 34, []bundles.Location{
+		{DumpID: 42, Path: "foo.go", Range: testRange1},
+	})
+	setMockBundleClientMonikerResults(t, mockBundleClient, "implementation", "gomod", "pad", 0, 5, []bundles.Location{
+		{DumpID: 42, Path: "foo.go", Range: testRange1},
+		{DumpID: 42, Path: "bar.go", Range: testRange2},
+	}, 2)
+
+	rpr := &ReferencePageResolver{
+		store:               mockStore,
+		bundleManagerClient: mockBundleManagerClient,
+		modelType:           "implementation",
+		repositoryID:        100,
+		commit:              testCommit,
+		limit:               5,
+	}
+
+	implementations, newCursor, hasNewCursor, err := rpr.dispatchCursorHandler(context.Background(), Cursor{
+		Phase:       "same-dump-monikers",
+		DumpID:      42,
+		Path:        "main.go",
+		Line:        23,
+		Character:   34,
+		Monikers:    []bundles.MonikerData{{Kind: "import", Scheme: "gomod", Identifier: "pad"}},
+		SkipResults: 0,
+	})
+	if err != nil {
+		t.Fatalf("expected error getting implementations: %s", err)
+	}
+
+	expectedImplementations := []ResolvedLocation{
+		{Dump: testDump1, Path: "sub1/bar.go", Range: testRange2},
+	}
+	if diff := cmp.Diff(expectedImplementations, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+
+	expectedNewCursor := Cursor{
+		Phase:       "definition-monikers",
+		DumpID:      42,
+		Path:        "main.go",
+		Monikers:    []bundles.MonikerData{{Kind: "import", Scheme: "gomod", Identifier: "pad"}},
+		SkipResults: 0,
+	}
+	if !hasNewCursor {
+		t.Errorf("expected new cursor")
+	} else if diff := cmp.Diff(expectedNewCursor, newCursor); diff != "" {
+		t.Errorf("unexpected new cursor (-want +got):\n%s", diff)
+	}
+}
@@ -15,6 +15,7 @@ type ObservedCodeIntelAPI struct {
 	findClosestDumpsOperation *observation.Operation
 	definitionsOperation      *observation.Operation
 	referencesOperation       *observation.Operation
+	implementationsOperation  *observation.Operation
 	hoverOperation            *observation.Operation
 	diagnosticsOperation      *observation.Operation
 }
@@ -47,6 +48,11 @@ func NewObserved(codeIntelAPI CodeIntelAPI, observationContext *observation.Cont
 			MetricLabels: []string{"references"},
 			Metrics:      metrics,
 		}),
+		implementationsOperation: observationContext.Operation(observation.Op{
+			Name:         "CodeIntelAPI.Implementations",
+			MetricLabels: []string{"implementations"},
+			Metrics:      metrics,
+		}),
 		hoverOperation: observationContext.Operation(observation.Op{
 			Name:         "CodeIntelAPI.Hover",
 			MetricLabels: []string{"hover"},
@@ -81,6 +87,13 @@ func (api *ObservedCodeIntelAPI) References(ctx context.Context, repositoryID in
 	return api.codeIntelAPI.References(ctx, repositoryID, commit, limit, cursor)
 }
 
+// Implementations calls into the inner CodeIntelAPI and registers the observed results.
+func (api *ObservedCodeIntelAPI) Implementations(ctx context.Context, repositoryID int, commit string, limit int, cursor Cursor) (implementations []ResolvedLocation, _ Cursor, _ bool, err error) {
+	ctx, endObservation := api.implementationsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(implementations)), observation.Args{}) }()
+	return api.codeIntelAPI.Implementations(ctx, repositoryID, commit, limit, cursor)
+}
+
 // Hover calls into the inner CodeIntelAPI and registers the observed results.
 func (api *ObservedCodeIntelAPI) Hover(ctx context.Context, file string, line, character, uploadID int) (_ string, _ bundles.Range, _ bool, err error) {
 	ctx, endObservation := api.hoverOperation.With(ctx, &err, observation.Args{})
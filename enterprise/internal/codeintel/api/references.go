@@ -30,6 +30,7 @@ func (api *codeIntelAPI) References(ctx context.Context, repositoryID int, commi
 	rpr := &ReferencePageResolver{
 		store:               api.store,
 		bundleManagerClient: api.bundleManagerClient,
+		modelType:           "reference",
 		repositoryID:        repositoryID,
 		commit:              commit,
 		remoteDumpLimit:     RemoteDumpLimit,
@@ -39,9 +40,13 @@ func (api *codeIntelAPI) References(ctx context.Context, repositoryID int, commi
 	return rpr.resolvePage(ctx, cursor)
 }
 
+// ReferencePageResolver pages through the locations related to the symbol described by a cursor.
+// The modelType field is either "reference" or "implementation" and determines which results are
+// read from the graph-encoded portion of each bundle and from the moniker tables of each bundle.
 type ReferencePageResolver struct {
 	store               store.Store
 	bundleManagerClient bundles.BundleManagerClient
+	modelType           string
 	repositoryID        int
 	commit              string
 	remoteDumpLimit     int
@@ -99,13 +104,13 @@ func (s *ReferencePageResolver) handleSameDumpCursor(ctx context.Context, cursor
 	}
 	bundleClient := s.bundleManagerClient.BundleClient(dump.ID)
 
-	locations, err := bundleClient.References(ctx, cursor.Path, cursor.Line, cursor.Character)
+	locations, err := s.sameDumpLocations(ctx, bundleClient, cursor)
 	if err != nil {
 		if err == client.ErrNotFound {
 			log15.Warn("Bundle does not exist")
 			return nil, Cursor{}, false, nil
 		}
-		return nil, Cursor{}, false, pkgerrors.Wrap(err, "bundleClient.References")
+		return nil, Cursor{}, false, err
 	}
 
 	resolvedLocations := resolveLocationsWithDump(dump, sliceLocations(locations, cursor.SkipResults, cursor.SkipResults+s.limit))
@@ -135,6 +140,24 @@ func (s *ReferencePageResolver) handleSameDumpCursor(ctx context.Context, cursor
 	return resolvedLocations, newCursor, true, nil
 }
 
+// sameDumpLocations returns the graph-encoded reference or implementation locations (depending on
+// the resolver's model type) of the symbol at the cursor's position within the cursor's dump.
+func (s *ReferencePageResolver) sameDumpLocations(ctx context.Context, bundleClient bundles.BundleClient, cursor Cursor) ([]bundles.Location, error) {
+	if s.modelType == "implementation" {
+		locations, err := bundleClient.Implementations(ctx, cursor.Path, cursor.Line, cursor.Character)
+		if err != nil && err != client.ErrNotFound {
+			return nil, pkgerrors.Wrap(err, "bundleClient.Implementations")
+		}
+		return locations, err
+	}
+
+	locations, err := bundleClient.References(ctx, cursor.Path, cursor.Line, cursor.Character)
+	if err != nil && err != client.ErrNotFound {
+		return nil, pkgerrors.Wrap(err, "bundleClient.References")
+	}
+	return locations, err
+}
+
 func (s *ReferencePageResolver) handleSameDumpMonikersCursor(ctx context.Context, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error) {
 	dump, exists, err := s.store.GetDumpByID(ctx, cursor.DumpID)
 	if err != nil {
@@ -148,13 +171,13 @@ func (s *ReferencePageResolver) handleSameDumpMonikersCursor(ctx context.Context
 	// Get the references that we've seen from the graph-encoded portion of the bundle. We
 	// need to know what we've returned previously so that we can filter out duplicate locations
 	// that are also encoded as monikers.
-	previousLocations, err := bundleClient.References(ctx, cursor.Path, cursor.Line, cursor.Character)
+	previousLocations, err := s.sameDumpLocations(ctx, bundleClient, cursor)
 	if err != nil {
 		if err == client.ErrNotFound {
 			log15.Warn("Bundle does not exist")
 			return nil, Cursor{}, false, nil
 		}
-		return nil, Cursor{}, false, pkgerrors.Wrap(err, "bundleClient.References")
+		return nil, Cursor{}, false, err
 	}
 
 	hashes := map[string]struct{}{}
@@ -170,7 +193,7 @@ func (s *ReferencePageResolver) handleSameDumpMonikersCursor(ctx context.Context
 	// the governing definition, and those may not be fully linked in the LSIF data. This
 	// method returns a cursor if there are reference rows remaining for a subsequent page.
 	for _, moniker := range cursor.Monikers {
-		results, count, err := bundleClient.MonikerResults(ctx, "reference", moniker.Scheme, moniker.Identifier, cursor.SkipResults, s.limit)
+		results, count, err := bundleClient.MonikerResults(ctx, s.modelType, moniker.Scheme, moniker.Identifier, cursor.SkipResults, s.limit)
 		if err != nil {
 			if err == client.ErrNotFound {
 				log15.Warn("Bundle does not exist")
@@ -255,7 +278,7 @@ func (s *ReferencePageResolver) handleDefinitionMonikersCursor(ctx context.Conte
 			continue
 		}
 
-		locations, count, err := lookupMoniker(s.store, s.bundleManagerClient, cursor.DumpID, cursor.Path, "reference", moniker, cursor.SkipResults, s.limit)
+		locations, count, err := lookupMoniker(s.store, s.bundleManagerClient, cursor.DumpID, cursor.Path, s.modelType, moniker, cursor.SkipResults, s.limit)
 		if err != nil {
 			return nil, Cursor{}, false, err
 		}
@@ -384,7 +407,7 @@ func (s *ReferencePageResolver) resolveLocationsViaReferencePager(ctx context.Co
 		}
 		bundleClient := s.bundleManagerClient.BundleClient(batchDumpID)
 
-		results, count, err := bundleClient.MonikerResults(ctx, "reference", scheme, identifier, cursor.SkipResultsInDump, limit)
+		results, count, err := bundleClient.MonikerResults(ctx, s.modelType, scheme, identifier, cursor.SkipResultsInDump, limit)
 		if err != nil {
 			if err == client.ErrNotFound {
 				log15.Warn("Bundle does not exist")
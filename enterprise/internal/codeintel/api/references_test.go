@@ -36,6 +36,7 @@ func TestHandleSameDumpCursor(t *testing.T) {
 	rpr := &ReferencePageResolver{
 		store:               mockStore,
 		bundleManagerClient: mockBundleManagerClient,
+		modelType:           "reference",
 		repositoryID:        100,
 		commit:              testCommit,
 		limit:               5,
@@ -139,6 +140,7 @@ func TestHandleSameDumpMonikersCursor(t *testing.T) {
 	rpr := &ReferencePageResolver{
 		store:               mockStore,
 		bundleManagerClient: mockBundleManagerClient,
+		modelType:           "reference",
 		repositoryID:        100,
 		commit:              testCommit,
 		limit:               5,
@@ -247,6 +249,7 @@ func TestHandleDefinitionMonikersCursor(t *testing.T) {
 	rpr := &ReferencePageResolver{
 		store:               mockStore,
 		bundleManagerClient: mockBundleManagerClient,
+		modelType:           "reference",
 		repositoryID:        100,
 		commit:              testCommit,
 		limit:               5,
@@ -373,6 +376,7 @@ func TestHandleSameRepoCursor(t *testing.T) {
 		rpr := &ReferencePageResolver{
 			store:               mockStore,
 			bundleManagerClient: mockBundleManagerClient,
+			modelType:           "reference",
 			repositoryID:        100,
 			commit:              testCommit,
 			remoteDumpLimit:     5,
@@ -438,6 +442,7 @@ func TestHandleSameRepoCursor(t *testing.T) {
 		rpr := &ReferencePageResolver{
 			store:               mockStore,
 			bundleManagerClient: mockBundleManagerClient,
+			modelType:           "reference",
 			repositoryID:        100,
 			commit:              testCommit,
 			remoteDumpLimit:     5,
@@ -504,6 +509,7 @@ func TestHandleSameRepoCursorMultipleDumpBatches(t *testing.T) {
 	rpr := &ReferencePageResolver{
 		store:               mockStore,
 		bundleManagerClient: mockBundleManagerClient,
+		modelType:           "reference",
 		repositoryID:        100,
 		commit:              testCommit,
 		remoteDumpLimit:     2,
@@ -589,6 +595,7 @@ func TestHandleRemoteRepoCursor(t *testing.T) {
 		rpr := &ReferencePageResolver{
 			store:               mockStore,
 			bundleManagerClient: mockBundleManagerClient,
+			modelType:           "reference",
 			repositoryID:        100,
 			commit:              testCommit,
 			remoteDumpLimit:     5,
@@ -654,6 +661,7 @@ func TestHandleRemoteRepoCursor(t *testing.T) {
 		rpr := &ReferencePageResolver{
 			store:               mockStore,
 			bundleManagerClient: mockBundleManagerClient,
+			modelType:           "reference",
 			repositoryID:        100,
 			commit:              testCommit,
 			remoteDumpLimit:     5,
@@ -709,6 +717,7 @@ func TestHandleRemoteRepoCursorMultipleDumpBatches(t *testing.T) {
 	rpr := &ReferencePageResolver{
 		store:               mockStore,
 		bundleManagerClient: mockBundleManagerClient,
+		modelType:           "reference",
 		repositoryID:        100,
 		commit:              testCommit,
 		remoteDumpLimit:     2,
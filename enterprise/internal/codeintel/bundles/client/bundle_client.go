@@ -19,12 +19,21 @@ type BundleClient interface {
 	// Definitions retrieves a list of reference locations for the symbol under the given location.
 	References(ctx context.Context, path string, line, character int) ([]Location, error)
 
+	// Implementations retrieves a list of implementation locations for the symbol under the given location.
+	Implementations(ctx context.Context, path string, line, character int) ([]Location, error)
+
 	// Hover retrieves the hover text for the symbol under the given location.
 	Hover(ctx context.Context, path string, line, character int) (string, Range, bool, error)
 
 	// Diagnostics retrieves the diagnostics and total count of diagnostics for the documents that have the given path prefix.
 	Diagnostics(ctx context.Context, prefix string, skip, take int) ([]Diagnostic, int, error)
 
+	// Symbols retrieves the document symbols defined in the given path.
+	Symbols(ctx context.Context, path string) ([]Symbol, error)
+
+	// SearchSymbols retrieves the symbols whose name contains the given query and the total count of such symbols.
+	SearchSymbols(ctx context.Context, query string, skip, take int) ([]Symbol, int, error)
+
 	// MonikersByPosition retrieves a list of monikers attached to the symbol under the given location. There may
 	// be multiple ranges enclosing this point. The returned monikers are partitioned such that inner ranges occur
 	// first in the result, and outer ranges occur later.
@@ -81,6 +90,19 @@ func (c *bundleClientImpl) References(ctx context.Context, path string, line, ch
 	return locations, err
 }
 
+// Implementations retrieves a list of implementation locations for the symbol under the given location.
+func (c *bundleClientImpl) Implementations(ctx context.Context, path string, line, character int) (locations []Location, err error) {
+	args := map[string]interface{}{
+		"path":      path,
+		"line":      line,
+		"character": character,
+	}
+
+	err = c.request(ctx, "implementations", args, &locations)
+	c.addBundleIDToLocations(locations)
+	return locations, err
+}
+
 // Hover retrieves the hover text for the symbol under the given location.
 func (c *bundleClientImpl) Hover(ctx context.Context, path string, line, character int) (string, Range, bool, error) {
 	args := map[string]interface{}{
@@ -134,6 +156,37 @@ func (c *bundleClientImpl) Diagnostics(ctx context.Context, prefix string, skip,
 	return diagnostics, count, err
 }
 
+// Symbols retrieves the document symbols defined in the given path.
+func (c *bundleClientImpl) Symbols(ctx context.Context, path string) (symbols []Symbol, err error) {
+	err = c.request(ctx, "symbols", map[string]interface{}{"path": path}, &symbols)
+	c.addBundleIDToSymbols(symbols)
+	return symbols, err
+}
+
+// SearchSymbols retrieves the symbols whose name contains the given query and the total count of such symbols.
+func (c *bundleClientImpl) SearchSymbols(ctx context.Context, query string, skip, take int) (symbols []Symbol, count int, err error) {
+	args := map[string]interface{}{
+		"query": query,
+	}
+	if skip != 0 {
+		args["skip"] = skip
+	}
+	if take != 0 {
+		args["take"] = take
+	}
+
+	target := struct {
+		Symbols []Symbol `json:"symbols"`
+		Count   int      `json:"count"`
+	}{}
+
+	err = c.request(ctx, "searchSymbols", args, &target)
+	symbols = target.Symbols
+	count = target.Count
+	c.addBundleIDToSymbols(symbols)
+	return symbols, count, err
+}
+
 // MonikersByPosition retrieves a list of monikers attached to the symbol under the given location. There may
 // be multiple ranges enclosing this point. The returned monikers are partitioned such that inner ranges occur
 // first in the result, and outer ranges occur later.
@@ -200,3 +253,9 @@ func (c *bundleClientImpl) addBundleIDToDiagnostics(diagnostics []Diagnostic) {
 		diagnostics[i].DumpID = c.bundleID
 	}
 }
+
+func (c *bundleClientImpl) addBundleIDToSymbols(symbols []Symbol) {
+	for i := range symbols {
+		symbols[i].DumpID = c.bundleID
+	}
+}
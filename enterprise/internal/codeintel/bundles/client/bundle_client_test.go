@@ -113,6 +113,35 @@ func TestReferences(t *testing.T) {
 	}
 }
 
+func TestImplementations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequest(t, r, "GET", "/dbs/42/implementations", map[string]string{
+			"path":      "main.go",
+			"line":      "10",
+			"character": "20",
+		})
+
+		_, _ = w.Write([]byte(`[
+			{"path": "foo.go", "range": {"start": {"line": 1, "character": 2}, "end": {"line": 3, "character": 4}}},
+			{"path": "bar.go", "range": {"start": {"line": 5, "character": 6}, "end": {"line": 7, "character": 8}}}
+		]`))
+	}))
+	defer ts.Close()
+
+	expected := []Location{
+		{DumpID: 42, Path: "foo.go", Range: Range{Start: Position{1, 2}, End: Position{3, 4}}},
+		{DumpID: 42, Path: "bar.go", Range: Range{Start: Position{5, 6}, End: Position{7, 8}}},
+	}
+
+	client := &bundleClientImpl{base: &bundleManagerClientImpl{bundleManagerURL: ts.URL}, bundleID: 42}
+	implementations, err := client.Implementations(context.Background(), "main.go", 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying implementations: %s", err)
+	} else if diff := cmp.Diff(expected, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+}
+
 func TestHover(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assertRequest(t, r, "GET", "/dbs/42/hover", map[string]string{
@@ -244,6 +273,71 @@ func TestDiagnostics(t *testing.T) {
 	}
 }
 
+func TestSymbols(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequest(t, r, "GET", "/dbs/42/symbols", map[string]string{
+			"path": "main.go",
+		})
+
+		_, _ = w.Write([]byte(`[
+			{"path": "main.go", "name": "Server", "kind": 23, "containerName": "", "range": {"start": {"line": 1, "character": 0}, "end": {"line": 4, "character": 1}}},
+			{"path": "main.go", "name": "Serve", "kind": 6, "containerName": "Server", "range": {"start": {"line": 2, "character": 1}, "end": {"line": 3, "character": 2}}}
+		]`))
+	}))
+	defer ts.Close()
+
+	client := &bundleClientImpl{base: &bundleManagerClientImpl{bundleManagerURL: ts.URL}, bundleID: 42}
+	symbols, err := client.Symbols(context.Background(), "main.go")
+	if err != nil {
+		t.Fatalf("unexpected error querying symbols: %s", err)
+	}
+
+	expectedSymbols := []Symbol{
+		{DumpID: 42, Path: "main.go", Name: "Server", Kind: 23, Range: Range{Start: Position{1, 0}, End: Position{4, 1}}},
+		{DumpID: 42, Path: "main.go", Name: "Serve", Kind: 6, ContainerName: "Server", Range: Range{Start: Position{2, 1}, End: Position{3, 2}}},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchSymbols(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequest(t, r, "GET", "/dbs/42/searchSymbols", map[string]string{
+			"query": "serve",
+			"skip":  "1",
+			"take":  "2",
+		})
+
+		_, _ = w.Write([]byte(`{
+			"count": 4,
+			"symbols": [
+				{"path": "main.go", "name": "Serve", "kind": 6, "containerName": "Server", "range": {"start": {"line": 2, "character": 1}, "end": {"line": 3, "character": 2}}},
+				{"path": "server.go", "name": "Server", "kind": 23, "containerName": "", "range": {"start": {"line": 1, "character": 0}, "end": {"line": 4, "character": 1}}}
+			]
+		}`))
+	}))
+	defer ts.Close()
+
+	client := &bundleClientImpl{base: &bundleManagerClientImpl{bundleManagerURL: ts.URL}, bundleID: 42}
+	symbols, totalCount, err := client.SearchSymbols(context.Background(), "serve", 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error querying symbols: %s", err)
+	}
+
+	expectedSymbols := []Symbol{
+		{DumpID: 42, Path: "main.go", Name: "Serve", Kind: 6, ContainerName: "Server", Range: Range{Start: Position{2, 1}, End: Position{3, 2}}},
+		{DumpID: 42, Path: "server.go", Name: "Server", Kind: 23, Range: Range{Start: Position{1, 0}, End: Position{4, 1}}},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	if totalCount != 4 {
+		t.Errorf("unexpected total count. want=%d have=%d", 4, totalCount)
+	}
+}
+
 func TestMonikersByPosition(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assertRequest(t, r, "GET", "/dbs/42/monikersByPosition", map[string]string{
@@ -28,6 +28,9 @@ type MockBundleClient struct {
 	// IDFunc is an instance of a mock function object controlling the
 	// behavior of the method ID.
 	IDFunc *BundleClientIDFunc
+	// ImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method Implementations.
+	ImplementationsFunc *BundleClientImplementationsFunc
 	// MonikerResultsFunc is an instance of a mock function object
 	// controlling the behavior of the method MonikerResults.
 	MonikerResultsFunc *BundleClientMonikerResultsFunc
@@ -40,6 +43,12 @@ type MockBundleClient struct {
 	// ReferencesFunc is an instance of a mock function object controlling
 	// the behavior of the method References.
 	ReferencesFunc *BundleClientReferencesFunc
+	// SearchSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchSymbols.
+	SearchSymbolsFunc *BundleClientSearchSymbolsFunc
+	// SymbolsFunc is an instance of a mock function object controlling the
+	// behavior of the method Symbols.
+	SymbolsFunc *BundleClientSymbolsFunc
 }
 
 // NewMockBundleClient creates a new mock of the BundleClient interface. All
@@ -71,6 +80,11 @@ func NewMockBundleClient() *MockBundleClient {
 				return 0
 			},
 		},
+		ImplementationsFunc: &BundleClientImplementationsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]client.Location, error) {
+				return nil, nil
+			},
+		},
 		MonikerResultsFunc: &BundleClientMonikerResultsFunc{
 			defaultHook: func(context.Context, string, string, string, int, int) ([]client.Location, int, error) {
 				return nil, 0, nil
@@ -91,6 +105,16 @@ func NewMockBundleClient() *MockBundleClient {
 				return nil, nil
 			},
 		},
+		SearchSymbolsFunc: &BundleClientSearchSymbolsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]client.Symbol, int, error) {
+				return nil, 0, nil
+			},
+		},
+		SymbolsFunc: &BundleClientSymbolsFunc{
+			defaultHook: func(context.Context, string) ([]client.Symbol, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -114,6 +138,9 @@ func NewMockBundleClientFrom(i client.BundleClient) *MockBundleClient {
 		IDFunc: &BundleClientIDFunc{
 			defaultHook: i.ID,
 		},
+		ImplementationsFunc: &BundleClientImplementationsFunc{
+			defaultHook: i.Implementations,
+		},
 		MonikerResultsFunc: &BundleClientMonikerResultsFunc{
 			defaultHook: i.MonikerResults,
 		},
@@ -126,6 +153,12 @@ func NewMockBundleClientFrom(i client.BundleClient) *MockBundleClient {
 		ReferencesFunc: &BundleClientReferencesFunc{
 			defaultHook: i.References,
 		},
+		SearchSymbolsFunc: &BundleClientSearchSymbolsFunc{
+			defaultHook: i.SearchSymbols,
+		},
+		SymbolsFunc: &BundleClientSymbolsFunc{
+			defaultHook: i.Symbols,
+		},
 	}
 }
 
@@ -689,6 +722,122 @@ func (c BundleClientIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// BundleClientImplementationsFunc describes the behavior when the
+// Implementations method of the parent MockBundleClient instance is
+// invoked.
+type BundleClientImplementationsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]client.Location, error)
+	hooks       []func(context.Context, string, int, int) ([]client.Location, error)
+	history     []BundleClientImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// Implementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockBundleClient) Implementations(v0 context.Context, v1 string, v2 int, v3 int) ([]client.Location, error) {
+	r0, r1 := m.ImplementationsFunc.nextHook()(v0, v1, v2, v3)
+	m.ImplementationsFunc.appendCall(BundleClientImplementationsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Implementations
+// method of the parent MockBundleClient instance is invoked and the hook
+// queue is empty.
+func (f *BundleClientImplementationsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Implementations method of the parent MockBundleClient instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *BundleClientImplementationsFunc) PushHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *BundleClientImplementationsFunc) SetDefaultReturn(r0 []client.Location, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *BundleClientImplementationsFunc) PushReturn(r0 []client.Location, r1 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+func (f *BundleClientImplementationsFunc) nextHook() func(context.Context, string, int, int) ([]client.Location, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BundleClientImplementationsFunc) appendCall(r0 BundleClientImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of BundleClientImplementationsFuncCall objects
+// describing the invocations of this function.
+func (f *BundleClientImplementationsFunc) History() []BundleClientImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]BundleClientImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BundleClientImplementationsFuncCall is an object that describes an
+// invocation of method Implementations on an instance of MockBundleClient.
+type BundleClientImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BundleClientImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BundleClientImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // BundleClientMonikerResultsFunc describes the behavior when the
 // MonikerResults method of the parent MockBundleClient instance is invoked.
 type BundleClientMonikerResultsFunc struct {
@@ -1158,3 +1307,230 @@ func (c BundleClientReferencesFuncCall) Args() []interface{} {
 func (c BundleClientReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// BundleClientSearchSymbolsFunc describes the behavior when the
+// SearchSymbols method of the parent MockBundleClient instance is invoked.
+type BundleClientSearchSymbolsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]client.Symbol, int, error)
+	hooks       []func(context.Context, string, int, int) ([]client.Symbol, int, error)
+	history     []BundleClientSearchSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchSymbols delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockBundleClient) SearchSymbols(v0 context.Context, v1 string, v2 int, v3 int) ([]client.Symbol, int, error) {
+	r0, r1, r2 := m.SearchSymbolsFunc.nextHook()(v0, v1, v2, v3)
+	m.SearchSymbolsFunc.appendCall(BundleClientSearchSymbolsFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the SearchSymbols method
+// of the parent MockBundleClient instance is invoked and the hook queue is
+// empty.
+func (f *BundleClientSearchSymbolsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]client.Symbol, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchSymbols method of the parent MockBundleClient instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *BundleClientSearchSymbolsFunc) PushHook(hook func(context.Context, string, int, int) ([]client.Symbol, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *BundleClientSearchSymbolsFunc) SetDefaultReturn(r0 []client.Symbol, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]client.Symbol, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *BundleClientSearchSymbolsFunc) PushReturn(r0 []client.Symbol, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]client.Symbol, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *BundleClientSearchSymbolsFunc) nextHook() func(context.Context, string, int, int) ([]client.Symbol, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BundleClientSearchSymbolsFunc) appendCall(r0 BundleClientSearchSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of BundleClientSearchSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *BundleClientSearchSymbolsFunc) History() []BundleClientSearchSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]BundleClientSearchSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BundleClientSearchSymbolsFuncCall is an object that describes an
+// invocation of method SearchSymbols on an instance of MockBundleClient.
+type BundleClientSearchSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.Symbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BundleClientSearchSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BundleClientSearchSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// BundleClientSymbolsFunc describes the behavior when the Symbols method of
+// the parent MockBundleClient instance is invoked.
+type BundleClientSymbolsFunc struct {
+	defaultHook func(context.Context, string) ([]client.Symbol, error)
+	hooks       []func(context.Context, string) ([]client.Symbol, error)
+	history     []BundleClientSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// Symbols delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockBundleClient) Symbols(v0 context.Context, v1 string) ([]client.Symbol, error) {
+	r0, r1 := m.SymbolsFunc.nextHook()(v0, v1)
+	m.SymbolsFunc.appendCall(BundleClientSymbolsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Symbols method of
+// the parent MockBundleClient instance is invoked and the hook queue is
+// empty.
+func (f *BundleClientSymbolsFunc) SetDefaultHook(hook func(context.Context, string) ([]client.Symbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Symbols method of the parent MockBundleClient instance inovkes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *BundleClientSymbolsFunc) PushHook(hook func(context.Context, string) ([]client.Symbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *BundleClientSymbolsFunc) SetDefaultReturn(r0 []client.Symbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]client.Symbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *BundleClientSymbolsFunc) PushReturn(r0 []client.Symbol, r1 error) {
+	f.PushHook(func(context.Context, string) ([]client.Symbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *BundleClientSymbolsFunc) nextHook() func(context.Context, string) ([]client.Symbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BundleClientSymbolsFunc) appendCall(r0 BundleClientSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of BundleClientSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *BundleClientSymbolsFunc) History() []BundleClientSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]BundleClientSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BundleClientSymbolsFuncCall is an object that describes an invocation of
+// method Symbols on an instance of MockBundleClient.
+type BundleClientSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.Symbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BundleClientSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BundleClientSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
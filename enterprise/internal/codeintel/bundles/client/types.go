@@ -47,3 +47,14 @@ type Diagnostic struct {
 	EndLine        int    `json:"endLine"`
 	EndCharacter   int    `json:"endCharacter"`
 }
+
+// Symbol describes a document symbol (e.g. a function, type, or field) defined within
+// a particular dump. The kind of the symbol is the integer value of an LSP SymbolKind.
+type Symbol struct {
+	DumpID        int
+	Path          string `json:"path"`
+	Name          string `json:"name"`
+	Kind          int    `json:"kind"`
+	ContainerName string `json:"containerName"`
+	Range         Range  `json:"range"`
+}
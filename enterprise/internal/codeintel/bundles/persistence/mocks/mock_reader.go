@@ -26,6 +26,9 @@ type MockReader struct {
 	// ReadDocumentFunc is an instance of a mock function object controlling
 	// the behavior of the method ReadDocument.
 	ReadDocumentFunc *ReaderReadDocumentFunc
+	// ReadImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method ReadImplementations.
+	ReadImplementationsFunc *ReaderReadImplementationsFunc
 	// ReadMetaFunc is an instance of a mock function object controlling the
 	// behavior of the method ReadMeta.
 	ReadMetaFunc *ReaderReadMetaFunc
@@ -35,6 +38,12 @@ type MockReader struct {
 	// ReadResultChunkFunc is an instance of a mock function object
 	// controlling the behavior of the method ReadResultChunk.
 	ReadResultChunkFunc *ReaderReadResultChunkFunc
+	// ReadSymbolsFunc is an instance of a mock function object controlling
+	// the behavior of the method ReadSymbols.
+	ReadSymbolsFunc *ReaderReadSymbolsFunc
+	// SearchSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchSymbols.
+	SearchSymbolsFunc *ReaderSearchSymbolsFunc
 }
 
 // NewMockReader creates a new mock of the Reader interface. All methods
@@ -61,6 +70,11 @@ func NewMockReader() *MockReader {
 				return types.DocumentData{}, false, nil
 			},
 		},
+		ReadImplementationsFunc: &ReaderReadImplementationsFunc{
+			defaultHook: func(context.Context, string, string, int, int) ([]types.Location, int, error) {
+				return nil, 0, nil
+			},
+		},
 		ReadMetaFunc: &ReaderReadMetaFunc{
 			defaultHook: func(context.Context) (types.MetaData, error) {
 				return types.MetaData{}, nil
@@ -76,6 +90,16 @@ func NewMockReader() *MockReader {
 				return types.ResultChunkData{}, false, nil
 			},
 		},
+		ReadSymbolsFunc: &ReaderReadSymbolsFunc{
+			defaultHook: func(context.Context, string) ([]types.SymbolData, error) {
+				return nil, nil
+			},
+		},
+		SearchSymbolsFunc: &ReaderSearchSymbolsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]types.SymbolData, int, error) {
+				return nil, 0, nil
+			},
+		},
 	}
 }
 
@@ -95,6 +119,9 @@ func NewMockReaderFrom(i persistence.Reader) *MockReader {
 		ReadDocumentFunc: &ReaderReadDocumentFunc{
 			defaultHook: i.ReadDocument,
 		},
+		ReadImplementationsFunc: &ReaderReadImplementationsFunc{
+			defaultHook: i.ReadImplementations,
+		},
 		ReadMetaFunc: &ReaderReadMetaFunc{
 			defaultHook: i.ReadMeta,
 		},
@@ -104,6 +131,12 @@ func NewMockReaderFrom(i persistence.Reader) *MockReader {
 		ReadResultChunkFunc: &ReaderReadResultChunkFunc{
 			defaultHook: i.ReadResultChunk,
 		},
+		ReadSymbolsFunc: &ReaderReadSymbolsFunc{
+			defaultHook: i.ReadSymbols,
+		},
+		SearchSymbolsFunc: &ReaderSearchSymbolsFunc{
+			defaultHook: i.SearchSymbols,
+		},
 	}
 }
 
@@ -547,6 +580,127 @@ func (c ReaderReadDocumentFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// ReaderReadImplementationsFunc describes the behavior when the
+// ReadImplementations method of the parent MockReader instance is invoked.
+type ReaderReadImplementationsFunc struct {
+	defaultHook func(context.Context, string, string, int, int) ([]types.Location, int, error)
+	hooks       []func(context.Context, string, string, int, int) ([]types.Location, int, error)
+	history     []ReaderReadImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// ReadImplementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReader) ReadImplementations(v0 context.Context, v1 string, v2 string, v3 int, v4 int) ([]types.Location, int, error) {
+	r0, r1, r2 := m.ReadImplementationsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.ReadImplementationsFunc.appendCall(ReaderReadImplementationsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the ReadImplementations
+// method of the parent MockReader instance is invoked and the hook queue is
+// empty.
+func (f *ReaderReadImplementationsFunc) SetDefaultHook(hook func(context.Context, string, string, int, int) ([]types.Location, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReadImplementations method of the parent MockReader instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReaderReadImplementationsFunc) PushHook(hook func(context.Context, string, string, int, int) ([]types.Location, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ReaderReadImplementationsFunc) SetDefaultReturn(r0 []types.Location, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int, int) ([]types.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ReaderReadImplementationsFunc) PushReturn(r0 []types.Location, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, string, int, int) ([]types.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ReaderReadImplementationsFunc) nextHook() func(context.Context, string, string, int, int) ([]types.Location, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReaderReadImplementationsFunc) appendCall(r0 ReaderReadImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReaderReadImplementationsFuncCall objects
+// describing the invocations of this function.
+func (f *ReaderReadImplementationsFunc) History() []ReaderReadImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReaderReadImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReaderReadImplementationsFuncCall is an object that describes an
+// invocation of method ReadImplementations on an instance of MockReader.
+type ReaderReadImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReaderReadImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReaderReadImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // ReaderReadMetaFunc describes the behavior when the ReadMeta method of the
 // parent MockReader instance is invoked.
 type ReaderReadMetaFunc struct {
@@ -884,3 +1038,228 @@ func (c ReaderReadResultChunkFuncCall) Args() []interface{} {
 func (c ReaderReadResultChunkFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
+
+// ReaderReadSymbolsFunc describes the behavior when the ReadSymbols method
+// of the parent MockReader instance is invoked.
+type ReaderReadSymbolsFunc struct {
+	defaultHook func(context.Context, string) ([]types.SymbolData, error)
+	hooks       []func(context.Context, string) ([]types.SymbolData, error)
+	history     []ReaderReadSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// ReadSymbols delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReader) ReadSymbols(v0 context.Context, v1 string) ([]types.SymbolData, error) {
+	r0, r1 := m.ReadSymbolsFunc.nextHook()(v0, v1)
+	m.ReadSymbolsFunc.appendCall(ReaderReadSymbolsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ReadSymbols method
+// of the parent MockReader instance is invoked and the hook queue is empty.
+func (f *ReaderReadSymbolsFunc) SetDefaultHook(hook func(context.Context, string) ([]types.SymbolData, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReadSymbols method of the parent MockReader instance inovkes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReaderReadSymbolsFunc) PushHook(hook func(context.Context, string) ([]types.SymbolData, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ReaderReadSymbolsFunc) SetDefaultReturn(r0 []types.SymbolData, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]types.SymbolData, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ReaderReadSymbolsFunc) PushReturn(r0 []types.SymbolData, r1 error) {
+	f.PushHook(func(context.Context, string) ([]types.SymbolData, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReaderReadSymbolsFunc) nextHook() func(context.Context, string) ([]types.SymbolData, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReaderReadSymbolsFunc) appendCall(r0 ReaderReadSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReaderReadSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *ReaderReadSymbolsFunc) History() []ReaderReadSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReaderReadSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReaderReadSymbolsFuncCall is an object that describes an invocation of
+// method ReadSymbols on an instance of MockReader.
+type ReaderReadSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.SymbolData
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReaderReadSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReaderReadSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReaderSearchSymbolsFunc describes the behavior when the SearchSymbols
+// method of the parent MockReader instance is invoked.
+type ReaderSearchSymbolsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]types.SymbolData, int, error)
+	hooks       []func(context.Context, string, int, int) ([]types.SymbolData, int, error)
+	history     []ReaderSearchSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchSymbols delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReader) SearchSymbols(v0 context.Context, v1 string, v2 int, v3 int) ([]types.SymbolData, int, error) {
+	r0, r1, r2 := m.SearchSymbolsFunc.nextHook()(v0, v1, v2, v3)
+	m.SearchSymbolsFunc.appendCall(ReaderSearchSymbolsFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the SearchSymbols method
+// of the parent MockReader instance is invoked and the hook queue is empty.
+func (f *ReaderSearchSymbolsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]types.SymbolData, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchSymbols method of the parent MockReader instance inovkes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReaderSearchSymbolsFunc) PushHook(hook func(context.Context, string, int, int) ([]types.SymbolData, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ReaderSearchSymbolsFunc) SetDefaultReturn(r0 []types.SymbolData, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]types.SymbolData, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ReaderSearchSymbolsFunc) PushReturn(r0 []types.SymbolData, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]types.SymbolData, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ReaderSearchSymbolsFunc) nextHook() func(context.Context, string, int, int) ([]types.SymbolData, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReaderSearchSymbolsFunc) appendCall(r0 ReaderSearchSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReaderSearchSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *ReaderSearchSymbolsFunc) History() []ReaderSearchSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReaderSearchSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReaderSearchSymbolsFuncCall is an object that describes an invocation of
+// method SearchSymbols on an instance of MockReader.
+type ReaderSearchSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.SymbolData
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReaderSearchSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReaderSearchSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
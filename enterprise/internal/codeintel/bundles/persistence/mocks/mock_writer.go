@@ -23,6 +23,9 @@ type MockWriter struct {
 	// WriteDocumentsFunc is an instance of a mock function object
 	// controlling the behavior of the method WriteDocuments.
 	WriteDocumentsFunc *WriterWriteDocumentsFunc
+	// WriteImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method WriteImplementations.
+	WriteImplementationsFunc *WriterWriteImplementationsFunc
 	// WriteMetaFunc is an instance of a mock function object controlling
 	// the behavior of the method WriteMeta.
 	WriteMetaFunc *WriterWriteMetaFunc
@@ -32,6 +35,9 @@ type MockWriter struct {
 	// WriteResultChunksFunc is an instance of a mock function object
 	// controlling the behavior of the method WriteResultChunks.
 	WriteResultChunksFunc *WriterWriteResultChunksFunc
+	// WriteSymbolsFunc is an instance of a mock function object controlling
+	// the behavior of the method WriteSymbols.
+	WriteSymbolsFunc *WriterWriteSymbolsFunc
 }
 
 // NewMockWriter creates a new mock of the Writer interface. All methods
@@ -49,7 +55,12 @@ func NewMockWriter() *MockWriter {
 			},
 		},
 		WriteDocumentsFunc: &WriterWriteDocumentsFunc{
-			defaultHook: func(context.Context, map[string]types.DocumentData) error {
+			defaultHook: func(context.Context, chan types.KeyedDocumentData) error {
+				return nil
+			},
+		},
+		WriteImplementationsFunc: &WriterWriteImplementationsFunc{
+			defaultHook: func(context.Context, []types.MonikerLocations) error {
 				return nil
 			},
 		},
@@ -64,7 +75,12 @@ func NewMockWriter() *MockWriter {
 			},
 		},
 		WriteResultChunksFunc: &WriterWriteResultChunksFunc{
-			defaultHook: func(context.Context, map[int]types.ResultChunkData) error {
+			defaultHook: func(context.Context, chan types.IndexedResultChunkData) error {
+				return nil
+			},
+		},
+		WriteSymbolsFunc: &WriterWriteSymbolsFunc{
+			defaultHook: func(context.Context, []types.SymbolData) error {
 				return nil
 			},
 		},
@@ -84,6 +100,9 @@ func NewMockWriterFrom(i persistence.Writer) *MockWriter {
 		WriteDocumentsFunc: &WriterWriteDocumentsFunc{
 			defaultHook: i.WriteDocuments,
 		},
+		WriteImplementationsFunc: &WriterWriteImplementationsFunc{
+			defaultHook: i.WriteImplementations,
+		},
 		WriteMetaFunc: &WriterWriteMetaFunc{
 			defaultHook: i.WriteMeta,
 		},
@@ -93,6 +112,9 @@ func NewMockWriterFrom(i persistence.Writer) *MockWriter {
 		WriteResultChunksFunc: &WriterWriteResultChunksFunc{
 			defaultHook: i.WriteResultChunks,
 		},
+		WriteSymbolsFunc: &WriterWriteSymbolsFunc{
+			defaultHook: i.WriteSymbols,
+		},
 	}
 }
 
@@ -307,15 +329,15 @@ func (c WriterWriteDefinitionsFuncCall) Results() []interface{} {
 // WriterWriteDocumentsFunc describes the behavior when the WriteDocuments
 // method of the parent MockWriter instance is invoked.
 type WriterWriteDocumentsFunc struct {
-	defaultHook func(context.Context, map[string]types.DocumentData) error
-	hooks       []func(context.Context, map[string]types.DocumentData) error
+	defaultHook func(context.Context, chan types.KeyedDocumentData) error
+	hooks       []func(context.Context, chan types.KeyedDocumentData) error
 	history     []WriterWriteDocumentsFuncCall
 	mutex       sync.Mutex
 }
 
 // WriteDocuments delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockWriter) WriteDocuments(v0 context.Context, v1 map[string]types.DocumentData) error {
+func (m *MockWriter) WriteDocuments(v0 context.Context, v1 chan types.KeyedDocumentData) error {
 	r0 := m.WriteDocumentsFunc.nextHook()(v0, v1)
 	m.WriteDocumentsFunc.appendCall(WriterWriteDocumentsFuncCall{v0, v1, r0})
 	return r0
@@ -324,7 +346,7 @@ func (m *MockWriter) WriteDocuments(v0 context.Context, v1 map[string]types.Docu
 // SetDefaultHook sets function that is called when the WriteDocuments
 // method of the parent MockWriter instance is invoked and the hook queue is
 // empty.
-func (f *WriterWriteDocumentsFunc) SetDefaultHook(hook func(context.Context, map[string]types.DocumentData) error) {
+func (f *WriterWriteDocumentsFunc) SetDefaultHook(hook func(context.Context, chan types.KeyedDocumentData) error) {
 	f.defaultHook = hook
 }
 
@@ -332,7 +354,7 @@ func (f *WriterWriteDocumentsFunc) SetDefaultHook(hook func(context.Context, map
 // WriteDocuments method of the parent MockWriter instance inovkes the hook
 // at the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *WriterWriteDocumentsFunc) PushHook(hook func(context.Context, map[string]types.DocumentData) error) {
+func (f *WriterWriteDocumentsFunc) PushHook(hook func(context.Context, chan types.KeyedDocumentData) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -341,7 +363,7 @@ func (f *WriterWriteDocumentsFunc) PushHook(hook func(context.Context, map[strin
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
 func (f *WriterWriteDocumentsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, map[string]types.DocumentData) error {
+	f.SetDefaultHook(func(context.Context, chan types.KeyedDocumentData) error {
 		return r0
 	})
 }
@@ -349,12 +371,12 @@ func (f *WriterWriteDocumentsFunc) SetDefaultReturn(r0 error) {
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
 func (f *WriterWriteDocumentsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, map[string]types.DocumentData) error {
+	f.PushHook(func(context.Context, chan types.KeyedDocumentData) error {
 		return r0
 	})
 }
 
-func (f *WriterWriteDocumentsFunc) nextHook() func(context.Context, map[string]types.DocumentData) error {
+func (f *WriterWriteDocumentsFunc) nextHook() func(context.Context, chan types.KeyedDocumentData) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -392,7 +414,7 @@ type WriterWriteDocumentsFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 map[string]types.DocumentData
+	Arg1 chan types.KeyedDocumentData
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -410,6 +432,112 @@ func (c WriterWriteDocumentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// WriterWriteImplementationsFunc describes the behavior when the
+// WriteImplementations method of the parent MockWriter instance is invoked.
+type WriterWriteImplementationsFunc struct {
+	defaultHook func(context.Context, []types.MonikerLocations) error
+	hooks       []func(context.Context, []types.MonikerLocations) error
+	history     []WriterWriteImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// WriteImplementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockWriter) WriteImplementations(v0 context.Context, v1 []types.MonikerLocations) error {
+	r0 := m.WriteImplementationsFunc.nextHook()(v0, v1)
+	m.WriteImplementationsFunc.appendCall(WriterWriteImplementationsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WriteImplementations
+// method of the parent MockWriter instance is invoked and the hook queue is
+// empty.
+func (f *WriterWriteImplementationsFunc) SetDefaultHook(hook func(context.Context, []types.MonikerLocations) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WriteImplementations method of the parent MockWriter instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WriterWriteImplementationsFunc) PushHook(hook func(context.Context, []types.MonikerLocations) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *WriterWriteImplementationsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []types.MonikerLocations) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *WriterWriteImplementationsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []types.MonikerLocations) error {
+		return r0
+	})
+}
+
+func (f *WriterWriteImplementationsFunc) nextHook() func(context.Context, []types.MonikerLocations) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WriterWriteImplementationsFunc) appendCall(r0 WriterWriteImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WriterWriteImplementationsFuncCall objects
+// describing the invocations of this function.
+func (f *WriterWriteImplementationsFunc) History() []WriterWriteImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]WriterWriteImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WriterWriteImplementationsFuncCall is an object that describes an
+// invocation of method WriteImplementations on an instance of MockWriter.
+type WriterWriteImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []types.MonikerLocations
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WriterWriteImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WriterWriteImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // WriterWriteMetaFunc describes the behavior when the WriteMeta method of
 // the parent MockWriter instance is invoked.
 type WriterWriteMetaFunc struct {
@@ -624,15 +752,15 @@ func (c WriterWriteReferencesFuncCall) Results() []interface{} {
 // WriterWriteResultChunksFunc describes the behavior when the
 // WriteResultChunks method of the parent MockWriter instance is invoked.
 type WriterWriteResultChunksFunc struct {
-	defaultHook func(context.Context, map[int]types.ResultChunkData) error
-	hooks       []func(context.Context, map[int]types.ResultChunkData) error
+	defaultHook func(context.Context, chan types.IndexedResultChunkData) error
+	hooks       []func(context.Context, chan types.IndexedResultChunkData) error
 	history     []WriterWriteResultChunksFuncCall
 	mutex       sync.Mutex
 }
 
 // WriteResultChunks delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockWriter) WriteResultChunks(v0 context.Context, v1 map[int]types.ResultChunkData) error {
+func (m *MockWriter) WriteResultChunks(v0 context.Context, v1 chan types.IndexedResultChunkData) error {
 	r0 := m.WriteResultChunksFunc.nextHook()(v0, v1)
 	m.WriteResultChunksFunc.appendCall(WriterWriteResultChunksFuncCall{v0, v1, r0})
 	return r0
@@ -641,7 +769,7 @@ func (m *MockWriter) WriteResultChunks(v0 context.Context, v1 map[int]types.Resu
 // SetDefaultHook sets function that is called when the WriteResultChunks
 // method of the parent MockWriter instance is invoked and the hook queue is
 // empty.
-func (f *WriterWriteResultChunksFunc) SetDefaultHook(hook func(context.Context, map[int]types.ResultChunkData) error) {
+func (f *WriterWriteResultChunksFunc) SetDefaultHook(hook func(context.Context, chan types.IndexedResultChunkData) error) {
 	f.defaultHook = hook
 }
 
@@ -649,7 +777,7 @@ func (f *WriterWriteResultChunksFunc) SetDefaultHook(hook func(context.Context,
 // WriteResultChunks method of the parent MockWriter instance inovkes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *WriterWriteResultChunksFunc) PushHook(hook func(context.Context, map[int]types.ResultChunkData) error) {
+func (f *WriterWriteResultChunksFunc) PushHook(hook func(context.Context, chan types.IndexedResultChunkData) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -658,7 +786,7 @@ func (f *WriterWriteResultChunksFunc) PushHook(hook func(context.Context, map[in
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
 func (f *WriterWriteResultChunksFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, map[int]types.ResultChunkData) error {
+	f.SetDefaultHook(func(context.Context, chan types.IndexedResultChunkData) error {
 		return r0
 	})
 }
@@ -666,12 +794,12 @@ func (f *WriterWriteResultChunksFunc) SetDefaultReturn(r0 error) {
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
 func (f *WriterWriteResultChunksFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, map[int]types.ResultChunkData) error {
+	f.PushHook(func(context.Context, chan types.IndexedResultChunkData) error {
 		return r0
 	})
 }
 
-func (f *WriterWriteResultChunksFunc) nextHook() func(context.Context, map[int]types.ResultChunkData) error {
+func (f *WriterWriteResultChunksFunc) nextHook() func(context.Context, chan types.IndexedResultChunkData) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -709,7 +837,7 @@ type WriterWriteResultChunksFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 map[int]types.ResultChunkData
+	Arg1 chan types.IndexedResultChunkData
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -726,3 +854,108 @@ func (c WriterWriteResultChunksFuncCall) Args() []interface{} {
 func (c WriterWriteResultChunksFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// WriterWriteSymbolsFunc describes the behavior when the WriteSymbols
+// method of the parent MockWriter instance is invoked.
+type WriterWriteSymbolsFunc struct {
+	defaultHook func(context.Context, []types.SymbolData) error
+	hooks       []func(context.Context, []types.SymbolData) error
+	history     []WriterWriteSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// WriteSymbols delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockWriter) WriteSymbols(v0 context.Context, v1 []types.SymbolData) error {
+	r0 := m.WriteSymbolsFunc.nextHook()(v0, v1)
+	m.WriteSymbolsFunc.appendCall(WriterWriteSymbolsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WriteSymbols method
+// of the parent MockWriter instance is invoked and the hook queue is empty.
+func (f *WriterWriteSymbolsFunc) SetDefaultHook(hook func(context.Context, []types.SymbolData) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WriteSymbols method of the parent MockWriter instance inovkes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *WriterWriteSymbolsFunc) PushHook(hook func(context.Context, []types.SymbolData) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *WriterWriteSymbolsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []types.SymbolData) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *WriterWriteSymbolsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []types.SymbolData) error {
+		return r0
+	})
+}
+
+func (f *WriterWriteSymbolsFunc) nextHook() func(context.Context, []types.SymbolData) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WriterWriteSymbolsFunc) appendCall(r0 WriterWriteSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WriterWriteSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *WriterWriteSymbolsFunc) History() []WriterWriteSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]WriterWriteSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WriterWriteSymbolsFuncCall is an object that describes an invocation of
+// method WriteSymbols on an instance of MockWriter.
+type WriterWriteSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []types.SymbolData
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WriterWriteSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WriterWriteSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
@@ -10,13 +10,16 @@ import (
 
 // An ObservedReader wraps another Reader with error logging, Prometheus metrics, and tracing.
 type ObservedReader struct {
-	reader                   Reader
-	readMetaOperation        *observation.Operation
-	pathsWithPrefixOperation *observation.Operation
-	readDocumentOperation    *observation.Operation
-	readResultChunkOperation *observation.Operation
-	readDefinitionsOperation *observation.Operation
-	readReferencesOperation  *observation.Operation
+	reader                       Reader
+	readMetaOperation            *observation.Operation
+	pathsWithPrefixOperation     *observation.Operation
+	readDocumentOperation        *observation.Operation
+	readResultChunkOperation     *observation.Operation
+	readDefinitionsOperation     *observation.Operation
+	readReferencesOperation      *observation.Operation
+	readImplementationsOperation *observation.Operation
+	readSymbolsOperation         *observation.Operation
+	searchSymbolsOperation       *observation.Operation
 }
 
 var _ Reader = &ObservedReader{}
@@ -69,6 +72,21 @@ func NewObserved(reader Reader, observationContext *observation.Context) Reader
 			MetricLabels: []string{"read_references"},
 			Metrics:      metrics,
 		}),
+		readImplementationsOperation: observationContext.Operation(observation.Op{
+			Name:         "Reader.ReadImplementations",
+			MetricLabels: []string{"read_implementations"},
+			Metrics:      metrics,
+		}),
+		readSymbolsOperation: observationContext.Operation(observation.Op{
+			Name:         "Reader.ReadSymbols",
+			MetricLabels: []string{"read_symbols"},
+			Metrics:      metrics,
+		}),
+		searchSymbolsOperation: observationContext.Operation(observation.Op{
+			Name:         "Reader.SearchSymbols",
+			MetricLabels: []string{"search_symbols"},
+			Metrics:      metrics,
+		}),
 	}
 }
 
@@ -114,6 +132,27 @@ func (r *ObservedReader) ReadReferences(ctx context.Context, scheme, identifier
 	return r.reader.ReadReferences(ctx, scheme, identifier, skip, take)
 }
 
+// ReadImplementations calls into the inner Reader and registers the observed results.
+func (r *ObservedReader) ReadImplementations(ctx context.Context, scheme, identifier string, skip, take int) (locations []types.Location, _ int, err error) {
+	ctx, endObservation := r.readImplementationsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(locations)), observation.Args{}) }()
+	return r.reader.ReadImplementations(ctx, scheme, identifier, skip, take)
+}
+
+// ReadSymbols calls into the inner Reader and registers the observed results.
+func (r *ObservedReader) ReadSymbols(ctx context.Context, path string) (symbols []types.SymbolData, err error) {
+	ctx, endObservation := r.readSymbolsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(symbols)), observation.Args{}) }()
+	return r.reader.ReadSymbols(ctx, path)
+}
+
+// SearchSymbols calls into the inner Reader and registers the observed results.
+func (r *ObservedReader) SearchSymbols(ctx context.Context, query string, skip, take int) (symbols []types.SymbolData, _ int, err error) {
+	ctx, endObservation := r.searchSymbolsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(symbols)), observation.Args{}) }()
+	return r.reader.SearchSymbols(ctx, query, skip, take)
+}
+
 func (r *ObservedReader) Close() error {
 	return r.reader.Close()
 }
@@ -14,48 +14,6 @@ import (
 // NumWriterRoutines is the number of goroutines launched to write database records.
 var NumWriterRoutines = runtime.NumCPU() * 2
 
-// KeyedDocument pairs a document with its path.
-type KeyedDocument struct {
-	Path     string
-	Document types.DocumentData
-}
-
-// IndexedResultChunk pairs a result chunk with its index.
-type IndexedResultChunk struct {
-	Index       int
-	ResultChunk types.ResultChunkData
-}
-
-// WriteDocuments serializes the given documents and writes them in batch to the given execable.
-func WriteDocuments(ctx context.Context, s sqliteutil.Execable, tableName string, serializer serialization.Serializer, documents map[string]types.DocumentData) error {
-	ch := make(chan KeyedDocument, len(documents))
-
-	go func() {
-		defer close(ch)
-
-		for k, v := range documents {
-			ch <- KeyedDocument{Path: k, Document: v}
-		}
-	}()
-
-	return WriteDocumentsChan(ctx, s, tableName, serializer, ch)
-}
-
-// WriteResultChunks serializes the given result chunks and writes them in batch to the given execable.
-func WriteResultChunks(ctx context.Context, s sqliteutil.Execable, tableName string, serializer serialization.Serializer, resultChunks map[int]types.ResultChunkData) error {
-	ch := make(chan IndexedResultChunk, len(resultChunks))
-
-	go func() {
-		defer close(ch)
-
-		for i, v := range resultChunks {
-			ch <- IndexedResultChunk{Index: i, ResultChunk: v}
-		}
-	}()
-
-	return WriteResultChunksChan(ctx, s, tableName, serializer, ch)
-}
-
 // WriteMonikerLocations serializes the given moniker locations and writes them in batch to the given execable.
 func WriteMonikerLocations(ctx context.Context, s sqliteutil.Execable, tableName string, serializer serialization.Serializer, monikerLocations []types.MonikerLocations) error {
 	ch := make(chan types.MonikerLocations, len(monikerLocations))
@@ -71,8 +29,25 @@ func WriteMonikerLocations(ctx context.Context, s sqliteutil.Execable, tableName
 	return WriteMonikerLocationsChan(ctx, s, tableName, serializer, ch)
 }
 
+// WriteSymbols writes the given symbols in batch to the given execable.
+func WriteSymbols(ctx context.Context, s sqliteutil.Execable, tableName string, symbols []types.SymbolData) error {
+	inserter := sqliteutil.NewBatchInserter(s, tableName, "path", "name", "kind", "container_name", "start_line", "start_character", "end_line", "end_character")
+
+	for _, v := range symbols {
+		if err := inserter.Insert(ctx, v.URI, v.Name, v.Kind, v.ContainerName, v.StartLine, v.StartCharacter, v.EndLine, v.EndCharacter); err != nil {
+			return errors.Wrap(err, "inserter.Insert")
+		}
+	}
+
+	if err := inserter.Flush(ctx); err != nil {
+		return errors.Wrap(err, "inserter.Flush")
+	}
+
+	return nil
+}
+
 // WriteDocumentsChan serializes and writes the document data read from the given channel.
-func WriteDocumentsChan(ctx context.Context, s sqliteutil.Execable, tableName string, serializer serialization.Serializer, ch <-chan KeyedDocument) error {
+func WriteDocumentsChan(ctx context.Context, s sqliteutil.Execable, tableName string, serializer serialization.Serializer, ch <-chan types.KeyedDocumentData) error {
 	return util.InvokeN(NumWriterRoutines, func() error {
 		inserter := sqliteutil.NewBatchInserter(s, tableName, "path", "data")
 
@@ -96,7 +71,7 @@ func WriteDocumentsChan(ctx context.Context, s sqliteutil.Execable, tableName st
 }
 
 // WriteResultChunksChan serializes and writes the result chunk data read from the given channel.
-func WriteResultChunksChan(ctx context.Context, s sqliteutil.Execable, tableName string, serializer serialization.Serializer, ch <-chan IndexedResultChunk) error {
+func WriteResultChunksChan(ctx context.Context, s sqliteutil.Execable, tableName string, serializer serialization.Serializer, ch <-chan types.IndexedResultChunkData) error {
 	return util.InvokeN(NumWriterRoutines, func() error {
 		inserter := sqliteutil.NewBatchInserter(s, tableName, "id", "data")
 
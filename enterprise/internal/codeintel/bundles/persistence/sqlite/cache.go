@@ -13,9 +13,14 @@ import (
 // ErrUnknownDatabase occurs when a request for an unknown database is made.
 var ErrUnknownDatabase = errors.New("unknown database")
 
+// FetchFunc writes the database with the given filename to disk if it does not exist locally.
+// This function returns false if the database is unknown.
+type FetchFunc func(ctx context.Context, filename string) (bool, error)
+
 // NewReaderCache creates a new reader cache. All readers share the same data cache with the
-// given maximum capacity.
-func NewReaderCache(dataCacheSize int) (cache.ReaderCache, error) {
+// given maximum capacity. If a fetch function is supplied, it is invoked to populate the local
+// disk when a reader is requested for a database that does not exist locally.
+func NewReaderCache(dataCacheSize int, fetch FetchFunc) (cache.ReaderCache, error) {
 	readerDataCache, err := cache.NewDataCache(dataCacheSize)
 	if err != nil {
 		return nil, err
@@ -26,7 +31,15 @@ func NewReaderCache(dataCacheSize int) (cache.ReaderCache, error) {
 		if exists, err := util.PathExists(filename); err != nil {
 			return nil, err
 		} else if !exists {
-			return nil, ErrUnknownDatabase
+			if fetch == nil {
+				return nil, ErrUnknownDatabase
+			}
+
+			if fetched, err := fetch(context.Background(), filename); err != nil {
+				return nil, err
+			} else if !fetched {
+				return nil, ErrUnknownDatabase
+			}
 		}
 
 		reader, err := NewReader(context.Background(), filename, readerDataCache)
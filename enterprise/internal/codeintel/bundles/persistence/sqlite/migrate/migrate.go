@@ -12,6 +12,8 @@ import (
 	v3 "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite/migrate/v3"
 	v4 "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite/migrate/v4"
 	v5 "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite/migrate/v5"
+	v6 "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite/migrate/v6"
+	v7 "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite/migrate/v7"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite/store"
 )
 
@@ -34,6 +36,8 @@ var migrations = []struct {
 	{v3.Migrate, false},
 	{v4.Migrate, true},
 	{v5.Migrate, true},
+	{v6.Migrate, false},
+	{v7.Migrate, false},
 }
 
 var UnknownSchemaVersion = 0
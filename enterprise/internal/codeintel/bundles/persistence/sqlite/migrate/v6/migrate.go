@@ -0,0 +1,15 @@
+package v6
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/serialization"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite/store"
+)
+
+// Migrate v6: Create an empty implementations table. Bundles written before this version have no
+// implementation results, so implementation queries against them return no locations.
+func Migrate(ctx context.Context, s *store.Store, serializer serialization.Serializer) error {
+	return s.Exec(ctx, sqlf.Sprintf(`CREATE TABLE "implementations" ("scheme" text NOT NULL, "identifier" text NOT NULL, "data" blob NOT NULL, PRIMARY KEY (scheme, identifier))`))
+}
@@ -0,0 +1,26 @@
+package v7
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/serialization"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite/store"
+)
+
+// Migrate v7: Create an empty symbols table. Bundles written before this version have no
+// document symbols, so symbol queries against them return no symbols.
+func Migrate(ctx context.Context, s *store.Store, serializer serialization.Serializer) error {
+	queries := []*sqlf.Query{
+		sqlf.Sprintf(`CREATE TABLE "symbols" ("path" text NOT NULL, "name" text NOT NULL, "kind" integer NOT NULL, "container_name" text NOT NULL, "start_line" integer NOT NULL, "start_character" integer NOT NULL, "end_line" integer NOT NULL, "end_character" integer NOT NULL)`),
+		sqlf.Sprintf(`CREATE INDEX "symbols_path" ON "symbols" ("path")`),
+	}
+
+	for _, query := range queries {
+		if err := s.Exec(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"strings"
@@ -133,6 +134,10 @@ func (r *sqliteReader) ReadReferences(ctx context.Context, scheme, identifier st
 	return r.readDefinitionReferences(ctx, "references", scheme, identifier, skip, take)
 }
 
+func (r *sqliteReader) ReadImplementations(ctx context.Context, scheme, identifier string, skip, take int) ([]types.Location, int, error) {
+	return r.readDefinitionReferences(ctx, "implementations", scheme, identifier, skip, take)
+}
+
 func (r *sqliteReader) readDefinitionReferences(ctx context.Context, tableName, scheme, identifier string, skip, take int) ([]types.Location, int, error) {
 	locations, err := r.readMonikerLocations(ctx, tableName, scheme, identifier)
 	if err != nil {
@@ -182,10 +187,82 @@ func (r *sqliteReader) readMonikerLocations(ctx context.Context, tableName, sche
 	return locations, nil
 }
 
+func (r *sqliteReader) ReadSymbols(ctx context.Context, path string) ([]types.SymbolData, error) {
+	return scanSymbols(r.store.Query(ctx, sqlf.Sprintf(
+		`SELECT `+symbolColumns+` FROM symbols WHERE path = %s ORDER BY start_line, start_character`,
+		path,
+	)))
+}
+
+func (r *sqliteReader) SearchSymbols(ctx context.Context, query string, skip, take int) ([]types.SymbolData, int, error) {
+	// Symbol names are matched case-insensitively by substring
+	cond := sqlf.Sprintf(`name LIKE %s ESCAPE '\'`, "%"+escapeLikePattern(query)+"%")
+
+	count, _, err := store.ScanFirstInt(r.store.Query(ctx, sqlf.Sprintf(`SELECT COUNT(*) FROM symbols WHERE %s`, cond)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit := take
+	if skip == 0 && take == 0 {
+		// Pagination is disabled, return full result set
+		limit = -1
+	}
+
+	symbols, err := scanSymbols(r.store.Query(ctx, sqlf.Sprintf(
+		`SELECT `+symbolColumns+` FROM symbols WHERE %s ORDER BY name, path, start_line, start_character LIMIT %s OFFSET %s`,
+		cond,
+		limit,
+		skip,
+	)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return symbols, count, nil
+}
+
 func (r *sqliteReader) Close() error {
 	return r.closer()
 }
 
+// symbolColumns are the columns of the symbols table in the order expected by scanSymbols.
+const symbolColumns = `path, name, kind, container_name, start_line, start_character, end_line, end_character`
+
+// scanSymbols scans a slice of symbols from the return value of `*store.Query`.
+func scanSymbols(rows *sql.Rows, queryErr error) (_ []types.SymbolData, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = store.CloseRows(rows, err) }()
+
+	var symbols []types.SymbolData
+	for rows.Next() {
+		var symbol types.SymbolData
+		if err := rows.Scan(
+			&symbol.URI,
+			&symbol.Name,
+			&symbol.Kind,
+			&symbol.ContainerName,
+			&symbol.StartLine,
+			&symbol.StartCharacter,
+			&symbol.EndLine,
+			&symbol.EndCharacter,
+		); err != nil {
+			return nil, err
+		}
+
+		symbols = append(symbols, symbol)
+	}
+
+	return symbols, nil
+}
+
+// escapeLikePattern escapes the wildcard characters of a LIKE pattern.
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (r *sqliteReader) getFromCache(key string) interface{} {
 	val, _ := r.cache.Get(key)
 	return val
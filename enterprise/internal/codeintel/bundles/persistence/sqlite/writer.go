@@ -66,12 +66,12 @@ func (w *sqliteWriter) WriteMeta(ctx context.Context, metaData types.MetaData) e
 	return nil
 }
 
-func (w *sqliteWriter) WriteDocuments(ctx context.Context, documents map[string]types.DocumentData) error {
-	return batch.WriteDocuments(ctx, w.store, "documents", w.serializer, documents)
+func (w *sqliteWriter) WriteDocuments(ctx context.Context, documents chan types.KeyedDocumentData) error {
+	return batch.WriteDocumentsChan(ctx, w.store, "documents", w.serializer, documents)
 }
 
-func (w *sqliteWriter) WriteResultChunks(ctx context.Context, resultChunks map[int]types.ResultChunkData) error {
-	return batch.WriteResultChunks(ctx, w.store, "result_chunks", w.serializer, resultChunks)
+func (w *sqliteWriter) WriteResultChunks(ctx context.Context, resultChunks chan types.IndexedResultChunkData) error {
+	return batch.WriteResultChunksChan(ctx, w.store, "result_chunks", w.serializer, resultChunks)
 }
 
 func (w *sqliteWriter) WriteDefinitions(ctx context.Context, monikerLocations []types.MonikerLocations) error {
@@ -82,6 +82,14 @@ func (w *sqliteWriter) WriteReferences(ctx context.Context, monikerLocations []t
 	return batch.WriteMonikerLocations(ctx, w.store, "references", w.serializer, monikerLocations)
 }
 
+func (w *sqliteWriter) WriteImplementations(ctx context.Context, monikerLocations []types.MonikerLocations) error {
+	return batch.WriteMonikerLocations(ctx, w.store, "implementations", w.serializer, monikerLocations)
+}
+
+func (w *sqliteWriter) WriteSymbols(ctx context.Context, symbols []types.SymbolData) error {
+	return batch.WriteSymbols(ctx, w.store, "symbols", symbols)
+}
+
 func (w *sqliteWriter) Close(err error) error {
 	err = w.store.Done(err)
 
@@ -100,6 +108,9 @@ func createTables(ctx context.Context, store *store.Store) error {
 		sqlf.Sprintf(`CREATE TABLE "result_chunks" ("id" integer PRIMARY KEY NOT NULL, "data" blob NOT NULL)`),
 		sqlf.Sprintf(`CREATE TABLE "definitions" ("scheme" text NOT NULL, "identifier" text NOT NULL, "data" blob NOT NULL, PRIMARY KEY (scheme, identifier))`),
 		sqlf.Sprintf(`CREATE TABLE "references" ("scheme" text NOT NULL, "identifier" text NOT NULL, "data" blob NOT NULL, PRIMARY KEY (scheme, identifier))`),
+		sqlf.Sprintf(`CREATE TABLE "implementations" ("scheme" text NOT NULL, "identifier" text NOT NULL, "data" blob NOT NULL, PRIMARY KEY (scheme, identifier))`),
+		sqlf.Sprintf(`CREATE TABLE "symbols" ("path" text NOT NULL, "name" text NOT NULL, "kind" integer NOT NULL, "container_name" text NOT NULL, "start_line" integer NOT NULL, "start_character" integer NOT NULL, "end_line" integer NOT NULL, "end_character" integer NOT NULL)`),
+		sqlf.Sprintf(`CREATE INDEX "symbols_path" ON "symbols" ("path")`),
 	}
 
 	for _, query := range queries {
@@ -49,7 +49,11 @@ func TestWrite(t *testing.T) {
 			"p02": {Name: "pkg B", Version: "1.2.3"},
 		},
 	}
-	if err := writer.WriteDocuments(ctx, map[string]types.DocumentData{"foo.go": expectedDocumentData}); err != nil {
+	documents := make(chan types.KeyedDocumentData, 1)
+	documents <- types.KeyedDocumentData{Path: "foo.go", Document: expectedDocumentData}
+	close(documents)
+
+	if err := writer.WriteDocuments(ctx, documents); err != nil {
 		t.Fatalf("unexpected error while writing documents: %s", err)
 	}
 
@@ -77,7 +81,11 @@ func TestWrite(t *testing.T) {
 			},
 		},
 	}
-	if err := writer.WriteResultChunks(ctx, map[int]types.ResultChunkData{7: expectedResultChunkData}); err != nil {
+	resultChunks := make(chan types.IndexedResultChunkData, 1)
+	resultChunks <- types.IndexedResultChunkData{Index: 7, ResultChunk: expectedResultChunkData}
+	close(resultChunks)
+
+	if err := writer.WriteResultChunks(ctx, resultChunks); err != nil {
 		t.Fatalf("unexpected error while writing result chunks: %s", err)
 	}
 
@@ -115,6 +123,17 @@ func TestWrite(t *testing.T) {
 		t.Fatalf("unexpected error while writing references: %s", err)
 	}
 
+	symbols := []types.SymbolData{
+		{URI: "foo.go", Name: "NewServer", Kind: 12, StartLine: 10, StartCharacter: 0, EndLine: 20, EndCharacter: 1},
+		{URI: "foo.go", Name: "Server", Kind: 23, StartLine: 1, StartCharacter: 5, EndLine: 8, EndCharacter: 1},
+		{URI: "foo.go", Name: "Serve", Kind: 6, ContainerName: "Server", StartLine: 22, StartCharacter: 0, EndLine: 30, EndCharacter: 1},
+		{URI: "bar.go", Name: "server_test", Kind: 13, StartLine: 3, StartCharacter: 4, EndLine: 3, EndCharacter: 15},
+		{URI: "bar.go", Name: "handler", Kind: 12, StartLine: 5, StartCharacter: 0, EndLine: 9, EndCharacter: 1},
+	}
+	if err := writer.WriteSymbols(ctx, symbols); err != nil {
+		t.Fatalf("unexpected error while writing symbols: %s", err)
+	}
+
 	if err := writer.Close(nil); err != nil {
 		t.Fatalf("unexpected error closing writer: %s", err)
 	}
@@ -169,4 +188,40 @@ func TestWrite(t *testing.T) {
 	if diff := cmp.Diff(expectedReferences, references); diff != "" {
 		t.Errorf("unexpected references (-want +got):\n%s", diff)
 	}
+
+	expectedDocumentSymbols := []types.SymbolData{symbols[1], symbols[0], symbols[2]}
+
+	documentSymbols, err := reader.ReadSymbols(ctx, "foo.go")
+	if err != nil {
+		t.Fatalf("unexpected error reading from database: %s", err)
+	}
+	if diff := cmp.Diff(expectedDocumentSymbols, documentSymbols); diff != "" {
+		t.Errorf("unexpected document symbols (-want +got):\n%s", diff)
+	}
+
+	searchTestCases := []struct {
+		query           string
+		skip            int
+		take            int
+		expectedSymbols []types.SymbolData
+		expectedCount   int
+	}{
+		{query: "serve", expectedSymbols: []types.SymbolData{symbols[0], symbols[2], symbols[1], symbols[3]}, expectedCount: 4},
+		{query: "serve", skip: 1, take: 2, expectedSymbols: []types.SymbolData{symbols[2], symbols[1]}, expectedCount: 4},
+		{query: "r_t", expectedSymbols: []types.SymbolData{symbols[3]}, expectedCount: 1},
+		{query: "%", expectedSymbols: nil, expectedCount: 0},
+	}
+
+	for _, testCase := range searchTestCases {
+		searchSymbols, count, err := reader.SearchSymbols(ctx, testCase.query, testCase.skip, testCase.take)
+		if err != nil {
+			t.Fatalf("unexpected error reading from database: %s", err)
+		}
+		if count != testCase.expectedCount {
+			t.Errorf("unexpected symbol count for query %q. want=%d have=%d", testCase.query, testCase.expectedCount, count)
+		}
+		if diff := cmp.Diff(testCase.expectedSymbols, searchSymbols); diff != "" {
+			t.Errorf("unexpected symbols for query %q (-want +got):\n%s", testCase.query, diff)
+		}
+	}
 }
@@ -8,9 +8,11 @@ import (
 
 type Writer interface {
 	WriteMeta(ctx context.Context, meta types.MetaData) error
-	WriteDocuments(ctx context.Context, documents map[string]types.DocumentData) error
-	WriteResultChunks(ctx context.Context, resultChunks map[int]types.ResultChunkData) error
+	WriteDocuments(ctx context.Context, documents chan types.KeyedDocumentData) error
+	WriteResultChunks(ctx context.Context, resultChunks chan types.IndexedResultChunkData) error
 	WriteDefinitions(ctx context.Context, monikerLocations []types.MonikerLocations) error
 	WriteReferences(ctx context.Context, monikerLocations []types.MonikerLocations) error
+	WriteImplementations(ctx context.Context, monikerLocations []types.MonikerLocations) error
+	WriteSymbols(ctx context.Context, symbols []types.SymbolData) error
 	Close(err error) error
 }
@@ -18,19 +18,26 @@ type DocumentData struct {
 	Diagnostics        []DiagnosticData
 }
 
+// KeyedDocumentData pairs a document with its path.
+type KeyedDocumentData struct {
+	Path     string
+	Document DocumentData
+}
+
 // RangeData represents a range vertex within an index. It contains the same relevant
 // edge data, which can be subsequently queried in the containing document. The data
 // that was reachable via a result set has been collapsed into this object during
 // conversion.
 type RangeData struct {
-	StartLine          int  // 0-indexed, inclusive
-	StartCharacter     int  // 0-indexed, inclusive
-	EndLine            int  // 0-indexed, inclusive
-	EndCharacter       int  // 0-indexed, inclusive
-	DefinitionResultID ID   // possibly empty
-	ReferenceResultID  ID   // possibly empty
-	HoverResultID      ID   // possibly empty
-	MonikerIDs         []ID // possibly empty
+	StartLine              int  // 0-indexed, inclusive
+	StartCharacter         int  // 0-indexed, inclusive
+	EndLine                int  // 0-indexed, inclusive
+	EndCharacter           int  // 0-indexed, inclusive
+	DefinitionResultID     ID   // possibly empty
+	ReferenceResultID      ID   // possibly empty
+	ImplementationResultID ID   // possibly empty
+	HoverResultID          ID   // possibly empty
+	MonikerIDs             []ID // possibly empty
 }
 
 // MonikerData represent a unique name (eventually) attached to a range.
@@ -63,22 +70,43 @@ type DiagnosticData struct {
 	EndCharacter   int // 0-indexed, inclusive
 }
 
+// SymbolData represents a symbol declared within a document of an index. Symbols are
+// extracted from the document symbol results of the index and flattened so that each
+// symbol refers to the name of the symbol that encloses it.
+type SymbolData struct {
+	URI            string
+	Name           string
+	Kind           int    // an LSP SymbolKind
+	ContainerName  string // possibly empty
+	StartLine      int    // 0-indexed, inclusive
+	StartCharacter int    // 0-indexed, inclusive
+	EndLine        int    // 0-indexed, inclusive
+	EndCharacter   int    // 0-indexed, inclusive
+}
+
 // ResultChunkData represents a row of the resultChunk table. Each row is a subset
-// of definition and reference result data in the index. Results are inserted into
-// chunks based on the hash of their identifier, thus every chunk has a roughly
-// proportional amount of data.
+// of definition, reference, and implementation result data in the index. Results
+// are inserted into chunks based on the hash of their identifier, thus every chunk
+// has a roughly proportional amount of data.
 type ResultChunkData struct {
 	// DocumentPaths is a mapping from document identifiers to their paths. This
 	// must be used to convert a document identifier in DocumentIDRangeIDs into
 	// a key that can be used to fetch document data.
 	DocumentPaths map[ID]string
 
-	// DocumentIDRangeIDs is a mapping from a definition or result reference
-	// identifier to the set of ranges that compose that result set. Each range
-	// is paired with the identifier of the document in which it can found.
+	// DocumentIDRangeIDs is a mapping from a definition, reference, or
+	// implementation result identifier to the set of ranges that compose that
+	// result set. Each range is paired with the identifier of the document in
+	// which it can found.
 	DocumentIDRangeIDs map[ID][]DocumentIDRangeID
 }
 
+// IndexedResultChunkData pairs a result chunk with its index.
+type IndexedResultChunkData struct {
+	Index       int
+	ResultChunk ResultChunkData
+}
+
 // DocumentIDRangeID is a pair of document and range identifiers.
 type DocumentIDRangeID struct {
 	// The identifier of the document to which the range belongs. This id is only
@@ -122,4 +150,7 @@ type PackageReference struct {
 	Name    string
 	Version string
 	Filter  []byte // a bloom filter of identifiers imported by this dependent
+
+	// IdentifierCount is the number of distinct identifiers imported by this dependent.
+	IdentifierCount int
 }
@@ -28,10 +28,20 @@ type Client interface {
 	// FileExists determines whether a file exists in a particular commit of a repository.
 	FileExists(ctx context.Context, store store.Store, repositoryID int, commit, file string) (bool, error)
 
+	// RawContents returns the contents of a file in a particular commit of a repository.
+	RawContents(ctx context.Context, store store.Store, repositoryID int, commit, file string) ([]byte, error)
+
+	// ListFiles returns the paths of all files in a particular commit of a repository.
+	ListFiles(ctx context.Context, store store.Store, repositoryID int, commit string) ([]string, error)
+
 	// Tags returns the git tags associated with the given commit along with a boolean indicating whether
 	// or not the tag was attached directly to the commit. If no tags exist at or before this commit, the
 	// tag is an empty string.
 	Tags(ctx context.Context, store store.Store, repositoryID int, commit string) (string, bool, error)
+
+	// RefsContaining returns the branches and tags from which the given commit is reachable along with
+	// a boolean indicating whether or not the commit is known to the repository.
+	RefsContaining(ctx context.Context, store store.Store, repositoryID int, commit string) ([]Ref, bool, error)
 }
 
 type defaultClient struct{}
@@ -58,6 +68,18 @@ func (c *defaultClient) FileExists(ctx context.Context, store store.Store, repos
 	return FileExists(ctx, store, repositoryID, commit, file)
 }
 
+func (c *defaultClient) RawContents(ctx context.Context, store store.Store, repositoryID int, commit, file string) ([]byte, error) {
+	return RawContents(ctx, store, repositoryID, commit, file)
+}
+
+func (c *defaultClient) ListFiles(ctx context.Context, store store.Store, repositoryID int, commit string) ([]string, error) {
+	return ListFiles(ctx, store, repositoryID, commit)
+}
+
 func (c *defaultClient) Tags(ctx context.Context, store store.Store, repositoryID int, commit string) (string, bool, error) {
 	return Tags(ctx, store, repositoryID, commit)
 }
+
+func (c *defaultClient) RefsContaining(ctx context.Context, store store.Store, repositoryID int, commit string) ([]Ref, bool, error) {
+	return RefsContaining(ctx, store, repositoryID, commit)
+}
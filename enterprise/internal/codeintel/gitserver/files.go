@@ -3,6 +3,7 @@ package gitserver
 import (
 	"context"
 	"os"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
@@ -31,3 +32,41 @@ func FileExists(ctx context.Context, store store.Store, repositoryID int, commit
 
 	return true, nil
 }
+
+// RawContents returns the contents of a file in a particular commit of a repository.
+func RawContents(ctx context.Context, store store.Store, repositoryID int, commit, file string) ([]byte, error) {
+	repo, err := repositoryIDToRepo(ctx, store, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := git.ReadFile(ctx, repo, api.CommitID(commit), file, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "git.ReadFile")
+	}
+
+	return out, nil
+}
+
+// ListFiles returns the paths of all files in a particular commit of a repository.
+func ListFiles(ctx context.Context, store store.Store, repositoryID int, commit string) ([]string, error) {
+	out, err := execGitCommandRaw(ctx, store, repositoryID, "ls-tree", "-r", "-z", "--name-only", "--full-tree", commit)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseListFiles(string(out)), nil
+}
+
+// parseListFiles converts the NUL-separated output of git ls-tree -z into a list of paths.
+// Paths are not quoted in this format and may contain any character but NUL.
+func parseListFiles(out string) []string {
+	var paths []string
+	for _, path := range strings.Split(out, "\x00") {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
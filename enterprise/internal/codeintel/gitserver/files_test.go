@@ -0,0 +1,16 @@
+package gitserver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseListFiles(t *testing.T) {
+	out := "go.mod\x00cmd/main.go\x00web/package.json\x00docs/with\nnewline.md\x00 leading space.go\x00"
+
+	expected := []string{"go.mod", "cmd/main.go", "web/package.json", "docs/with\nnewline.md", " leading space.go"}
+	if diff := cmp.Diff(expected, parseListFiles(out)); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,83 @@
+package gitserver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+// RefType is the type of a git reference.
+type RefType int
+
+const (
+	RefTypeBranch RefType = iota
+	RefTypeTag
+)
+
+// Ref is a branch or tag of a repository.
+type Ref struct {
+	Name string
+	Type RefType
+
+	// Commit is the commit the ref points to. For annotated tags, this is the
+	// tagged commit and not the tag object.
+	Commit string
+}
+
+// RefsContaining returns the branches and tags from which the given commit is reachable along with
+// a boolean indicating whether or not the commit is known to the repository. Reachability cannot be
+// determined for an unknown commit (e.g. one that has not yet been fetched, or one that was garbage
+// collected after a force push), in which case no refs and a false-valued flag are returned.
+func RefsContaining(ctx context.Context, store store.Store, repositoryID int, commit string) ([]Ref, bool, error) {
+	out, err := execGitCommand(
+		ctx,
+		store,
+		repositoryID,
+		"for-each-ref",
+		"--contains", commit,
+		"--format=%(refname)%00%(objectname)%00%(*objectname)",
+		"refs/heads/",
+		"refs/tags/",
+	)
+	if err != nil {
+		if isUnknownCommit(out) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return parseRefs(strings.Split(out, "\n")), true, nil
+}
+
+// parseRefs converts the output of git for-each-ref into a slice of refs.
+func parseRefs(lines []string) []Ref {
+	var refs []Ref
+	for _, line := range lines {
+		parts := strings.Split(strings.TrimSpace(line), "\x00")
+		if len(parts) != 3 {
+			continue
+		}
+
+		commit := parts[1]
+		if parts[2] != "" {
+			// Annotated tags point to a tag object, which points to the commit
+			commit = parts[2]
+		}
+
+		if name := strings.TrimPrefix(parts[0], "refs/heads/"); name != parts[0] {
+			refs = append(refs, Ref{Name: name, Type: RefTypeBranch, Commit: commit})
+		} else if name := strings.TrimPrefix(parts[0], "refs/tags/"); name != parts[0] {
+			refs = append(refs, Ref{Name: name, Type: RefTypeTag, Commit: commit})
+		}
+	}
+
+	return refs
+}
+
+// isUnknownCommit returns true if the given output of a failed git command indicates
+// that the command received a commit that does not exist in the repository.
+func isUnknownCommit(out string) bool {
+	return strings.Contains(out, "no such commit") || strings.Contains(out, "malformed object name")
+}
@@ -0,0 +1,42 @@
+package gitserver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRefs(t *testing.T) {
+	lines := []string{
+		"refs/heads/main\x00d7f17555a6719446a92e693948dfffae5abdf2b4\x00",
+		"refs/heads/feature/foo\x00d7f17555a6719446a92e693948dfffae5abdf2b4\x00",
+		"refs/tags/v1.0.0\x0062f7d32ec7fb8e2cbbf1ed3a3b7e0bb5d4cc3b0c\x0074d26d79ba1d2c5e4a8a1e2ed4cea7d4d4c1a7e9",
+		"refs/tags/v1.0.1\x0009b1bc0be8f5ff4bb8bd1cd3c1e6e8b1e11e4c1c\x00",
+		"refs/remotes/origin/main\x00d7f17555a6719446a92e693948dfffae5abdf2b4\x00",
+		"",
+	}
+
+	expected := []Ref{
+		{Name: "main", Type: RefTypeBranch, Commit: "d7f17555a6719446a92e693948dfffae5abdf2b4"},
+		{Name: "feature/foo", Type: RefTypeBranch, Commit: "d7f17555a6719446a92e693948dfffae5abdf2b4"},
+		{Name: "v1.0.0", Type: RefTypeTag, Commit: "74d26d79ba1d2c5e4a8a1e2ed4cea7d4d4c1a7e9"},
+		{Name: "v1.0.1", Type: RefTypeTag, Commit: "09b1bc0be8f5ff4bb8bd1cd3c1e6e8b1e11e4c1c"},
+	}
+	if diff := cmp.Diff(expected, parseRefs(lines)); diff != "" {
+		t.Errorf("unexpected refs (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsUnknownCommit(t *testing.T) {
+	testCases := map[string]bool{
+		"error: no such commit 1111111111111111111111111111111111111111": true,
+		"error: malformed object name deadbeef":                          true,
+		"fatal: not a git repository":                                    false,
+	}
+
+	for out, expected := range testCases {
+		if unknown := isUnknownCommit(out); unknown != expected {
+			t.Errorf("unexpected result for %q. want=%v have=%v", out, expected, unknown)
+		}
+	}
+}
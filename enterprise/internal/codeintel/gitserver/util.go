@@ -12,17 +12,42 @@ import (
 
 // execGitCommand executes a git command for the given repository by identifier.
 func execGitCommand(ctx context.Context, store store.Store, repositoryID int, args ...string) (string, error) {
-	repo, err := repositoryIDToRepo(ctx, store, repositoryID)
+	cmd, err := gitCommand(ctx, store, repositoryID, args...)
 	if err != nil {
 		return "", err
 	}
 
-	cmd := gitserver.DefaultClient.Command("git", args...)
-	cmd.Repo = repo
 	out, err := cmd.CombinedOutput(ctx)
 	return string(bytes.TrimSpace(out)), errors.Wrap(err, "gitserver.Command")
 }
 
+// execGitCommandRaw is like execGitCommand but returns the standard output of the command
+// unmodified. The standard error of the command is only returned as part of an error.
+func execGitCommandRaw(ctx context.Context, store store.Store, repositoryID int, args ...string) ([]byte, error) {
+	cmd, err := gitCommand(ctx, store, repositoryID, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, stderr, err := cmd.DividedOutput(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "gitserver.Command (stderr: %q)", bytes.TrimSpace(stderr))
+	}
+	return stdout, nil
+}
+
+// gitCommand creates a git command for the given repository by identifier.
+func gitCommand(ctx context.Context, store store.Store, repositoryID int, args ...string) (*gitserver.Cmd, error) {
+	repo, err := repositoryIDToRepo(ctx, store, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", args...)
+	cmd.Repo = repo
+	return cmd, nil
+}
+
 // repositoryIDToRepo creates a gitserver.Repo from a repository identifier.
 func repositoryIDToRepo(ctx context.Context, store store.Store, repositoryID int) (gitserver.Repo, error) {
 	repoName, err := store.RepoName(ctx, repositoryID)
@@ -34,6 +34,7 @@ func (r *DiagnosticResolver) Location(ctx context.Context) (gql.LocationResolver
 			Path:           r.diagnostic.Path,
 			AdjustedCommit: r.diagnostic.AdjustedCommit,
 			AdjustedRange:  r.diagnostic.AdjustedRange,
+			Confidence:     r.diagnostic.Confidence,
 		},
 	)
 }
@@ -3,19 +3,23 @@ package graphql
 import (
 	"github.com/sourcegraph/go-lsp"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
 )
 
 type HoverResolver struct {
-	text     string
-	lspRange lsp.Range
+	text       string
+	lspRange   lsp.Range
+	confidence resolvers.AdjustmentConfidence
 }
 
-func NewHoverResolver(text string, lspRange lsp.Range) gql.HoverResolver {
+func NewHoverResolver(text string, lspRange lsp.Range, confidence resolvers.AdjustmentConfidence) gql.HoverResolver {
 	return &HoverResolver{
-		text:     text,
-		lspRange: lspRange,
+		text:       text,
+		lspRange:   lspRange,
+		confidence: confidence,
 	}
 }
 
 func (r *HoverResolver) Markdown() gql.MarkdownResolver { return gql.NewMarkdownResolver(r.text) }
 func (r *HoverResolver) Range() gql.RangeResolver       { return gql.NewRangeResolver(r.lspRange) }
+func (r *HoverResolver) AdjustmentConfidence() string   { return r.confidence.String() }
@@ -250,5 +250,5 @@ func resolveLocation(ctx context.Context, locationResolver *CachedLocationResolv
 	}
 
 	lspRange := convertRange(location.AdjustedRange)
-	return gql.NewLocationResolver(treeResolver, &lspRange), nil
+	return gql.NewAdjustedLocationResolver(treeResolver, &lspRange, location.Confidence.String()), nil
 }
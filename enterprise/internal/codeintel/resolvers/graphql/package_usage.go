@@ -0,0 +1,64 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+type PackageResolver struct {
+	scheme  string
+	name    string
+	version string
+}
+
+func (r *PackageResolver) Scheme() string  { return r.scheme }
+func (r *PackageResolver) Name() string    { return r.name }
+func (r *PackageResolver) Version() string { return r.version }
+
+type PackageUsageResolver struct {
+	resolver           resolvers.Resolver
+	usage              store.PackageUsage
+	repositoryResolver *gql.RepositoryResolver
+	upload             store.Upload
+	locationResolver   *CachedLocationResolver
+}
+
+func NewPackageUsageResolver(
+	resolver resolvers.Resolver,
+	usage store.PackageUsage,
+	repositoryResolver *gql.RepositoryResolver,
+	upload store.Upload,
+	locationResolver *CachedLocationResolver,
+) gql.LSIFPackageUsageResolver {
+	return &PackageUsageResolver{
+		resolver:           resolver,
+		usage:              usage,
+		repositoryResolver: repositoryResolver,
+		upload:             upload,
+		locationResolver:   locationResolver,
+	}
+}
+
+func (r *PackageUsageResolver) Package() gql.LSIFPackageResolver {
+	return &PackageResolver{scheme: r.usage.Scheme, name: r.usage.Name, version: r.usage.Version}
+}
+
+func (r *PackageUsageResolver) Repository() *gql.RepositoryResolver { return r.repositoryResolver }
+
+func (r *PackageUsageResolver) Upload() gql.LSIFUploadResolver {
+	return NewUploadResolver(r.upload, r.locationResolver)
+}
+
+func (r *PackageUsageResolver) IdentifierCount() *int32 { return toInt32(r.usage.IdentifierCount) }
+
+func (r *PackageUsageResolver) Provider(ctx context.Context) (gql.LSIFUploadResolver, error) {
+	upload, exists, err := r.resolver.GetPackageProvider(ctx, r.usage.Scheme, r.usage.Name, r.usage.Version)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return NewUploadResolver(upload, r.locationResolver), nil
+}
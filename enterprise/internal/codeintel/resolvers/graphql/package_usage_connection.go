@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+type PackageUsageConnectionResolver struct {
+	resolver         resolvers.Resolver
+	usagesResolver   *resolvers.PackageUsagesResolver
+	locationResolver *CachedLocationResolver
+}
+
+func NewPackageUsageConnectionResolver(resolver resolvers.Resolver, usagesResolver *resolvers.PackageUsagesResolver, locationResolver *CachedLocationResolver) gql.LSIFPackageUsageConnectionResolver {
+	return &PackageUsageConnectionResolver{
+		resolver:         resolver,
+		usagesResolver:   usagesResolver,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *PackageUsageConnectionResolver) Nodes(ctx context.Context) ([]gql.LSIFPackageUsageResolver, error) {
+	if err := r.usagesResolver.Resolve(ctx); err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]gql.LSIFPackageUsageResolver, 0, len(r.usagesResolver.Usages))
+	for _, usage := range r.usagesResolver.Usages {
+		// 🚨 SECURITY: Omit uploads of repositories the current user cannot access
+		repositoryResolver, err := r.locationResolver.Repository(ctx, api.RepoID(usage.RepositoryID))
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		upload, exists, err := r.resolver.GetUploadByID(ctx, usage.DumpID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		resolvers = append(resolvers, NewPackageUsageResolver(r.resolver, usage, repositoryResolver, upload, r.locationResolver))
+	}
+
+	return resolvers, nil
+}
+
+func (r *PackageUsageConnectionResolver) TotalCount(ctx context.Context) (*int32, error) {
+	// 🚨 SECURITY: The total count includes uploads of repositories the current user
+	// may not be able to access, so it is only returned to site admins
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		if err == backend.ErrMustBeSiteAdmin || err == backend.ErrNotAuthenticated {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := r.usagesResolver.Resolve(ctx); err != nil {
+		return nil, err
+	}
+	return toInt32(&r.usagesResolver.TotalCount), nil
+}
+
+func (r *PackageUsageConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	if err := r.usagesResolver.Resolve(ctx); err != nil {
+		return nil, err
+	}
+	return encodeIntCursor(toInt32(r.usagesResolver.NextOffset)), nil
+}
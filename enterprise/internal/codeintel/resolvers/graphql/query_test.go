@@ -8,6 +8,7 @@ import (
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
 	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers/mocks"
 )
 
@@ -110,15 +111,56 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 	}
 }
 
+func TestImplementations(t *testing.T) {
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
+
+	offset := int32(25)
+	cursor := base64.StdEncoding.EncodeToString([]byte("test-cursor"))
+
+	args := &gql.LSIFPagedQueryPositionArgs{
+		LSIFQueryPositionArgs: gql.LSIFQueryPositionArgs{
+			Line:      10,
+			Character: 15,
+		},
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &offset},
+		After:          &cursor,
+	}
+
+	if _, err := resolver.Implementations(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockResolver.ImplementationsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.ImplementationsFunc.History()))
+	}
+	if val := mockResolver.ImplementationsFunc.History()[0].Arg1; val != 10 {
+		t.Fatalf("unexpected line. want=%d have=%d", 10, val)
+	}
+	if val := mockResolver.ImplementationsFunc.History()[0].Arg2; val != 15 {
+		t.Fatalf("unexpected character. want=%d have=%d", 15, val)
+	}
+	if val := mockResolver.ImplementationsFunc.History()[0].Arg3; val != 25 {
+		t.Fatalf("unexpected character. want=%d have=%d", 25, val)
+	}
+	if val := mockResolver.ImplementationsFunc.History()[0].Arg4; val != "test-cursor" {
+		t.Fatalf("unexpected character. want=%s have=%s", "test-cursor", val)
+	}
+}
+
 func TestHover(t *testing.T) {
 	mockResolver := resolvermocks.NewMockQueryResolver()
-	mockResolver.HoverFunc.SetDefaultReturn("text", bundles.Range{}, true, nil)
+	mockResolver.HoverFunc.SetDefaultReturn("text", bundles.Range{}, resolvers.ConfidenceAdjusted, true, nil)
 	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
 
 	args := &gql.LSIFQueryPositionArgs{Line: 10, Character: 15}
-	if _, err := resolver.Hover(context.Background(), args); err != nil {
+	hover, err := resolver.Hover(context.Background(), args)
+	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
+	if val := hover.AdjustmentConfidence(); val != "ADJUSTED" {
+		t.Errorf("unexpected adjustment confidence. want=%s have=%s", "ADJUSTED", val)
+	}
 
 	if len(mockResolver.HoverFunc.History()) != 1 {
 		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.HoverFunc.History()))
@@ -13,6 +13,7 @@ import (
 
 const DefaultUploadPageSize = 50
 const DefaultIndexPageSize = 50
+const DefaultPackageUsagePageSize = 50
 
 // Resolver is the main interface to code intel-related operations exposted to the GraphQL API. This
 // resolver concerns itself with GraphQL/API-specific behaviors (auth, validation, marshaling, etc.).
@@ -77,6 +78,48 @@ func (r *Resolver) DeleteLSIFUpload(ctx context.Context, id graphql.ID) (*gql.Em
 	return &gql.EmptyResponse{}, nil
 }
 
+func (r *Resolver) LSIFUploadRetentionPreview(ctx context.Context, id graphql.ID) ([]gql.LSIFUploadExpirationResolver, error) {
+	// 🚨 SECURITY: Only site admins may inspect the retention policy for now
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := resolveRepositoryID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	expirations, err := r.resolver.UploadRetentionPreview(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	expirationResolvers := make([]gql.LSIFUploadExpirationResolver, 0, len(expirations))
+	for _, expiration := range expirations {
+		expirationResolvers = append(expirationResolvers, NewUploadExpirationResolver(expiration, r.locationResolver))
+	}
+
+	return expirationResolvers, nil
+}
+
+func (r *Resolver) LSIFPackageDependents(ctx context.Context, args *gql.LSIFRepositoryPackageDependentsQueryArgs) (gql.LSIFPackageUsageConnectionResolver, error) {
+	opts, err := makeGetPackageDependentsOptions(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPackageUsageConnectionResolver(r.resolver, r.resolver.PackageDependentsConnectionResolver(opts), r.locationResolver), nil
+}
+
+func (r *Resolver) LSIFPackageDependencies(ctx context.Context, args *gql.LSIFRepositoryPackageDependenciesQueryArgs) (gql.LSIFPackageUsageConnectionResolver, error) {
+	opts, err := makeGetPackageDependenciesOptions(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPackageUsageConnectionResolver(r.resolver, r.resolver.PackageDependenciesConnectionResolver(opts), r.locationResolver), nil
+}
+
 func (r *Resolver) LSIFIndexByID(ctx context.Context, id graphql.ID) (gql.LSIFIndexResolver, error) {
 	indexID, err := unmarshalLSIFIndexGQLID(id)
 	if err != nil {
@@ -190,3 +233,48 @@ func resolveRepositoryID(ctx context.Context, id graphql.ID) (int, error) {
 
 	return int(repositoryResolver.Type().ID), nil
 }
+
+// makeGetPackageDependentsOptions translates the given GraphQL arguments into options defined by the
+// store.GetPackageDependents operation.
+func makeGetPackageDependentsOptions(ctx context.Context, args *gql.LSIFRepositoryPackageDependentsQueryArgs) (store.GetPackageDependentsOptions, error) {
+	repositoryID, err := resolveRepositoryID(ctx, args.RepositoryID)
+	if err != nil {
+		return store.GetPackageDependentsOptions{}, err
+	}
+
+	offset, err := decodeIntCursor(args.After)
+	if err != nil {
+		return store.GetPackageDependentsOptions{}, err
+	}
+
+	return store.GetPackageDependentsOptions{
+		RepositoryID: repositoryID,
+		Name:         args.Name,
+		Version:      args.Version,
+		VisibleAtTip: derefBool(args.IsLatestForRepo, false),
+		Limit:        derefInt32(args.First, DefaultPackageUsagePageSize),
+		Offset:       offset,
+	}, nil
+}
+
+// makeGetPackageDependenciesOptions translates the given GraphQL arguments into options defined by the
+// store.GetPackageDependencies operation.
+func makeGetPackageDependenciesOptions(ctx context.Context, args *gql.LSIFRepositoryPackageDependenciesQueryArgs) (store.GetPackageDependenciesOptions, error) {
+	repositoryID, err := resolveRepositoryID(ctx, args.RepositoryID)
+	if err != nil {
+		return store.GetPackageDependenciesOptions{}, err
+	}
+
+	offset, err := decodeIntCursor(args.After)
+	if err != nil {
+		return store.GetPackageDependenciesOptions{}, err
+	}
+
+	return store.GetPackageDependenciesOptions{
+		RepositoryID: repositoryID,
+		Commit:       derefString(args.Commit, ""),
+		VisibleAtTip: derefBool(args.IsLatestForRepo, false),
+		Limit:        derefInt32(args.First, DefaultPackageUsagePageSize),
+		Offset:       offset,
+	}, nil
+}
@@ -12,9 +12,12 @@ import (
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
 	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 )
 
 func TestDeleteLSIFUpload(t *testing.T) {
@@ -81,6 +84,56 @@ func TestDeleteLSIFIndexUnauthenticated(t *testing.T) {
 	}
 }
 
+func TestLSIFUploadRetentionPreview(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Users.GetByCurrentAuthUser = nil
+		db.Mocks.Repos.Get = nil
+	})
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.UploadRetentionPreviewFunc.SetDefaultReturn([]resolvers.UploadExpiration{
+		{Upload: store.Upload{ID: 42}, Reason: "BRANCH_LIMIT"},
+	}, nil)
+
+	id := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repo:50")))
+	expirations, err := NewResolver(mockResolver).LSIFUploadRetentionPreview(context.Background(), id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockResolver.UploadRetentionPreviewFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.UploadRetentionPreviewFunc.History()))
+	}
+	if val := mockResolver.UploadRetentionPreviewFunc.History()[0].Arg1; val != 50 {
+		t.Fatalf("unexpected repository id. want=%d have=%d", 50, val)
+	}
+
+	if len(expirations) != 1 {
+		t.Fatalf("unexpected number of expirations. want=%d have=%d", 1, len(expirations))
+	}
+	if val := expirations[0].Reason(); val != "BRANCH_LIMIT" {
+		t.Errorf("unexpected reason. want=%s have=%s", "BRANCH_LIMIT", val)
+	}
+	if val := expirations[0].Upload().ID(); val != marshalLSIFUploadGQLID(42) {
+		t.Errorf("unexpected upload id. want=%s have=%s", marshalLSIFUploadGQLID(42), val)
+	}
+}
+
+func TestLSIFUploadRetentionPreviewUnauthenticated(t *testing.T) {
+	id := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repo:50")))
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(mockResolver).LSIFUploadRetentionPreview(context.Background(), id); err != backend.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", backend.ErrNotAuthenticated, err)
+	}
+}
+
 func TestMakeGetUploadsOptions(t *testing.T) {
 	t.Cleanup(func() {
 		db.Mocks.Repos.Get = nil
@@ -199,3 +252,128 @@ func TestMakeGetIndexesOptionsDefaults(t *testing.T) {
 		t.Errorf("unexpected opts (-want +got):\n%s", diff)
 	}
 }
+
+func TestMakeGetPackageDependentsOptions(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Repos.Get = nil
+	})
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+
+	opts, err := makeGetPackageDependentsOptions(context.Background(), &gql.LSIFRepositoryPackageDependentsQueryArgs{
+		LSIFPackageDependentsQueryArgs: &gql.LSIFPackageDependentsQueryArgs{
+			ConnectionArgs:  graphqlutil.ConnectionArgs{First: intPtr(5)},
+			Name:            "leftpad",
+			Version:         "0.1.0",
+			IsLatestForRepo: boolPtr(true),
+			After:           encodeIntCursor(intPtr(25)).EndCursor(),
+		},
+		RepositoryID: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repo:50"))),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error making options: %s", err)
+	}
+
+	expected := store.GetPackageDependentsOptions{
+		RepositoryID: 50,
+		Name:         "leftpad",
+		Version:      "0.1.0",
+		VisibleAtTip: true,
+		Limit:        5,
+		Offset:       25,
+	}
+	if diff := cmp.Diff(expected, opts); diff != "" {
+		t.Errorf("unexpected opts (-want +got):\n%s", diff)
+	}
+}
+
+func TestMakeGetPackageDependenciesOptionsDefaults(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Repos.Get = nil
+	})
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+
+	opts, err := makeGetPackageDependenciesOptions(context.Background(), &gql.LSIFRepositoryPackageDependenciesQueryArgs{
+		LSIFPackageDependenciesQueryArgs: &gql.LSIFPackageDependenciesQueryArgs{},
+		RepositoryID:                     graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repo:50"))),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error making options: %s", err)
+	}
+
+	expected := store.GetPackageDependenciesOptions{
+		RepositoryID: 50,
+		Limit:        DefaultPackageUsagePageSize,
+	}
+	if diff := cmp.Diff(expected, opts); diff != "" {
+		t.Errorf("unexpected opts (-want +got):\n%s", diff)
+	}
+}
+
+func TestPackageUsageConnectionNodes(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Repos.Get = nil
+		db.Mocks.Users.GetByCurrentAuthUser = nil
+	})
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		if id == 51 {
+			return nil, &errcode.Mock{Message: "repo not found", IsNotFound: true}
+		}
+		return &types.Repo{ID: id}, nil
+	}
+
+	mockStore := storemocks.NewMockStore()
+	two := 2
+	mockStore.GetPackageDependentsFunc.SetDefaultReturn([]store.PackageUsage{
+		{DumpID: 1, RepositoryID: 50, Scheme: "gomod", Name: "leftpad", Version: "0.1.0", IdentifierCount: &two},
+		{DumpID: 2, RepositoryID: 51, Scheme: "gomod", Name: "leftpad", Version: "0.1.0"},
+		{DumpID: 3, RepositoryID: 52, Scheme: "gomod", Name: "leftpad", Version: "0.1.0"},
+	}, 3, nil)
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.GetUploadByIDFunc.SetDefaultHook(func(ctx context.Context, id int) (store.Upload, bool, error) {
+		return store.Upload{ID: id}, id != 3, nil
+	})
+
+	usagesResolver := resolvers.NewPackageDependentsResolver(mockStore, store.GetPackageDependentsOptions{})
+	connectionResolver := NewPackageUsageConnectionResolver(mockResolver, usagesResolver, NewCachedLocationResolver())
+
+	nodes, err := connectionResolver.Nodes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(nodes) != 1 {
+		t.Fatalf("unexpected number of nodes. want=%d have=%d", 1, len(nodes))
+	}
+	if val := nodes[0].Upload().ID(); val != marshalLSIFUploadGQLID(1) {
+		t.Errorf("unexpected upload id. want=%s have=%s", marshalLSIFUploadGQLID(1), val)
+	}
+	if val := nodes[0].IdentifierCount(); val == nil || *val != 2 {
+		t.Errorf("unexpected identifier count. want=%d have=%v", 2, val)
+	}
+	if val := nodes[0].Package().Name(); val != "leftpad" {
+		t.Errorf("unexpected package name. want=%s have=%s", "leftpad", val)
+	}
+
+	// The total count includes the omitted uploads, so it is only returned to site admins.
+	for _, siteAdmin := range []bool{false, true} {
+		db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+			return &types.User{SiteAdmin: siteAdmin}, nil
+		}
+
+		totalCount, err := connectionResolver.TotalCount(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !siteAdmin {
+			if totalCount != nil {
+				t.Errorf("unexpected total count for non-site-admin. want=nil have=%d", *totalCount)
+			}
+		} else if totalCount == nil || *totalCount != 3 {
+			t.Errorf("unexpected total count. want=%d have=%v", 3, totalCount)
+		}
+	}
+}
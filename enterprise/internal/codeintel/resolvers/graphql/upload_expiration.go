@@ -0,0 +1,24 @@
+package graphql
+
+import (
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+)
+
+type UploadExpirationResolver struct {
+	expiration       resolvers.UploadExpiration
+	locationResolver *CachedLocationResolver
+}
+
+func NewUploadExpirationResolver(expiration resolvers.UploadExpiration, locationResolver *CachedLocationResolver) gql.LSIFUploadExpirationResolver {
+	return &UploadExpirationResolver{
+		expiration:       expiration,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *UploadExpirationResolver) Upload() gql.LSIFUploadResolver {
+	return NewUploadResolver(r.expiration.Upload, r.locationResolver)
+}
+
+func (r *UploadExpirationResolver) Reason() string { return r.expiration.Reason }
@@ -35,13 +35,13 @@ func NewMockPositionAdjuster() *MockPositionAdjuster {
 			},
 		},
 		AdjustPositionFunc: &PositionAdjusterAdjustPositionFunc{
-			defaultHook: func(context.Context, string, string, client.Position, bool) (string, client.Position, bool, error) {
-				return "", client.Position{}, false, nil
+			defaultHook: func(context.Context, string, string, client.Position, bool) (string, client.Position, AdjustmentConfidence, bool, error) {
+				return "", client.Position{}, 0, false, nil
 			},
 		},
 		AdjustRangeFunc: &PositionAdjusterAdjustRangeFunc{
-			defaultHook: func(context.Context, string, string, client.Range, bool) (string, client.Range, bool, error) {
-				return "", client.Range{}, false, nil
+			defaultHook: func(context.Context, string, string, client.Range, bool) (string, client.Range, AdjustmentConfidence, bool, error) {
+				return "", client.Range{}, 0, false, nil
 			},
 		},
 	}
@@ -186,24 +186,24 @@ func (c PositionAdjusterAdjustPathFuncCall) Results() []interface{} {
 // AdjustPosition method of the parent MockPositionAdjuster instance is
 // invoked.
 type PositionAdjusterAdjustPositionFunc struct {
-	defaultHook func(context.Context, string, string, client.Position, bool) (string, client.Position, bool, error)
-	hooks       []func(context.Context, string, string, client.Position, bool) (string, client.Position, bool, error)
+	defaultHook func(context.Context, string, string, client.Position, bool) (string, client.Position, AdjustmentConfidence, bool, error)
+	hooks       []func(context.Context, string, string, client.Position, bool) (string, client.Position, AdjustmentConfidence, bool, error)
 	history     []PositionAdjusterAdjustPositionFuncCall
 	mutex       sync.Mutex
 }
 
 // AdjustPosition delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockPositionAdjuster) AdjustPosition(v0 context.Context, v1 string, v2 string, v3 client.Position, v4 bool) (string, client.Position, bool, error) {
-	r0, r1, r2, r3 := m.AdjustPositionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.AdjustPositionFunc.appendCall(PositionAdjusterAdjustPositionFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3})
-	return r0, r1, r2, r3
+func (m *MockPositionAdjuster) AdjustPosition(v0 context.Context, v1 string, v2 string, v3 client.Position, v4 bool) (string, client.Position, AdjustmentConfidence, bool, error) {
+	r0, r1, r2, r3, r4 := m.AdjustPositionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.AdjustPositionFunc.appendCall(PositionAdjusterAdjustPositionFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3, r4})
+	return r0, r1, r2, r3, r4
 }
 
 // SetDefaultHook sets function that is called when the AdjustPosition
 // method of the parent MockPositionAdjuster instance is invoked and the
 // hook queue is empty.
-func (f *PositionAdjusterAdjustPositionFunc) SetDefaultHook(hook func(context.Context, string, string, client.Position, bool) (string, client.Position, bool, error)) {
+func (f *PositionAdjusterAdjustPositionFunc) SetDefaultHook(hook func(context.Context, string, string, client.Position, bool) (string, client.Position, AdjustmentConfidence, bool, error)) {
 	f.defaultHook = hook
 }
 
@@ -211,7 +211,7 @@ func (f *PositionAdjusterAdjustPositionFunc) SetDefaultHook(hook func(context.Co
 // AdjustPosition method of the parent MockPositionAdjuster instance inovkes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *PositionAdjusterAdjustPositionFunc) PushHook(hook func(context.Context, string, string, client.Position, bool) (string, client.Position, bool, error)) {
+func (f *PositionAdjusterAdjustPositionFunc) PushHook(hook func(context.Context, string, string, client.Position, bool) (string, client.Position, AdjustmentConfidence, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -219,21 +219,21 @@ func (f *PositionAdjusterAdjustPositionFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *PositionAdjusterAdjustPositionFunc) SetDefaultReturn(r0 string, r1 client.Position, r2 bool, r3 error) {
-	f.SetDefaultHook(func(context.Context, string, string, client.Position, bool) (string, client.Position, bool, error) {
-		return r0, r1, r2, r3
+func (f *PositionAdjusterAdjustPositionFunc) SetDefaultReturn(r0 string, r1 client.Position, r2 AdjustmentConfidence, r3 bool, r4 error) {
+	f.SetDefaultHook(func(context.Context, string, string, client.Position, bool) (string, client.Position, AdjustmentConfidence, bool, error) {
+		return r0, r1, r2, r3, r4
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *PositionAdjusterAdjustPositionFunc) PushReturn(r0 string, r1 client.Position, r2 bool, r3 error) {
-	f.PushHook(func(context.Context, string, string, client.Position, bool) (string, client.Position, bool, error) {
-		return r0, r1, r2, r3
+func (f *PositionAdjusterAdjustPositionFunc) PushReturn(r0 string, r1 client.Position, r2 AdjustmentConfidence, r3 bool, r4 error) {
+	f.PushHook(func(context.Context, string, string, client.Position, bool) (string, client.Position, AdjustmentConfidence, bool, error) {
+		return r0, r1, r2, r3, r4
 	})
 }
 
-func (f *PositionAdjusterAdjustPositionFunc) nextHook() func(context.Context, string, string, client.Position, bool) (string, client.Position, bool, error) {
+func (f *PositionAdjusterAdjustPositionFunc) nextHook() func(context.Context, string, string, client.Position, bool) (string, client.Position, AdjustmentConfidence, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -290,10 +290,13 @@ type PositionAdjusterAdjustPositionFuncCall struct {
 	Result1 client.Position
 	// Result2 is the value of the 3rd result returned from this method
 	// invocation.
-	Result2 bool
+	Result2 AdjustmentConfidence
 	// Result3 is the value of the 4th result returned from this method
 	// invocation.
-	Result3 error
+	Result3 bool
+	// Result4 is the value of the 5th result returned from this method
+	// invocation.
+	Result4 error
 }
 
 // Args returns an interface slice containing the arguments of this
@@ -305,31 +308,31 @@ func (c PositionAdjusterAdjustPositionFuncCall) Args() []interface{} {
 // Results returns an interface slice containing the results of this
 // invocation.
 func (c PositionAdjusterAdjustPositionFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3, c.Result4}
 }
 
 // PositionAdjusterAdjustRangeFunc describes the behavior when the
 // AdjustRange method of the parent MockPositionAdjuster instance is
 // invoked.
 type PositionAdjusterAdjustRangeFunc struct {
-	defaultHook func(context.Context, string, string, client.Range, bool) (string, client.Range, bool, error)
-	hooks       []func(context.Context, string, string, client.Range, bool) (string, client.Range, bool, error)
+	defaultHook func(context.Context, string, string, client.Range, bool) (string, client.Range, AdjustmentConfidence, bool, error)
+	hooks       []func(context.Context, string, string, client.Range, bool) (string, client.Range, AdjustmentConfidence, bool, error)
 	history     []PositionAdjusterAdjustRangeFuncCall
 	mutex       sync.Mutex
 }
 
 // AdjustRange delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockPositionAdjuster) AdjustRange(v0 context.Context, v1 string, v2 string, v3 client.Range, v4 bool) (string, client.Range, bool, error) {
-	r0, r1, r2, r3 := m.AdjustRangeFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.AdjustRangeFunc.appendCall(PositionAdjusterAdjustRangeFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3})
-	return r0, r1, r2, r3
+func (m *MockPositionAdjuster) AdjustRange(v0 context.Context, v1 string, v2 string, v3 client.Range, v4 bool) (string, client.Range, AdjustmentConfidence, bool, error) {
+	r0, r1, r2, r3, r4 := m.AdjustRangeFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.AdjustRangeFunc.appendCall(PositionAdjusterAdjustRangeFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3, r4})
+	return r0, r1, r2, r3, r4
 }
 
 // SetDefaultHook sets function that is called when the AdjustRange method
 // of the parent MockPositionAdjuster instance is invoked and the hook queue
 // is empty.
-func (f *PositionAdjusterAdjustRangeFunc) SetDefaultHook(hook func(context.Context, string, string, client.Range, bool) (string, client.Range, bool, error)) {
+func (f *PositionAdjusterAdjustRangeFunc) SetDefaultHook(hook func(context.Context, string, string, client.Range, bool) (string, client.Range, AdjustmentConfidence, bool, error)) {
 	f.defaultHook = hook
 }
 
@@ -337,7 +340,7 @@ func (f *PositionAdjusterAdjustRangeFunc) SetDefaultHook(hook func(context.Conte
 // AdjustRange method of the parent MockPositionAdjuster instance inovkes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *PositionAdjusterAdjustRangeFunc) PushHook(hook func(context.Context, string, string, client.Range, bool) (string, client.Range, bool, error)) {
+func (f *PositionAdjusterAdjustRangeFunc) PushHook(hook func(context.Context, string, string, client.Range, bool) (string, client.Range, AdjustmentConfidence, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -345,21 +348,21 @@ func (f *PositionAdjusterAdjustRangeFunc) PushHook(hook func(context.Context, st
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *PositionAdjusterAdjustRangeFunc) SetDefaultReturn(r0 string, r1 client.Range, r2 bool, r3 error) {
-	f.SetDefaultHook(func(context.Context, string, string, client.Range, bool) (string, client.Range, bool, error) {
-		return r0, r1, r2, r3
+func (f *PositionAdjusterAdjustRangeFunc) SetDefaultReturn(r0 string, r1 client.Range, r2 AdjustmentConfidence, r3 bool, r4 error) {
+	f.SetDefaultHook(func(context.Context, string, string, client.Range, bool) (string, client.Range, AdjustmentConfidence, bool, error) {
+		return r0, r1, r2, r3, r4
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *PositionAdjusterAdjustRangeFunc) PushReturn(r0 string, r1 client.Range, r2 bool, r3 error) {
-	f.PushHook(func(context.Context, string, string, client.Range, bool) (string, client.Range, bool, error) {
-		return r0, r1, r2, r3
+func (f *PositionAdjusterAdjustRangeFunc) PushReturn(r0 string, r1 client.Range, r2 AdjustmentConfidence, r3 bool, r4 error) {
+	f.PushHook(func(context.Context, string, string, client.Range, bool) (string, client.Range, AdjustmentConfidence, bool, error) {
+		return r0, r1, r2, r3, r4
 	})
 }
 
-func (f *PositionAdjusterAdjustRangeFunc) nextHook() func(context.Context, string, string, client.Range, bool) (string, client.Range, bool, error) {
+func (f *PositionAdjusterAdjustRangeFunc) nextHook() func(context.Context, string, string, client.Range, bool) (string, client.Range, AdjustmentConfidence, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -415,10 +418,13 @@ type PositionAdjusterAdjustRangeFuncCall struct {
 	Result1 client.Range
 	// Result2 is the value of the 3rd result returned from this method
 	// invocation.
-	Result2 bool
+	Result2 AdjustmentConfidence
 	// Result3 is the value of the 4th result returned from this method
 	// invocation.
-	Result3 error
+	Result3 bool
+	// Result4 is the value of the 5th result returned from this method
+	// invocation.
+	Result4 error
 }
 
 // Args returns an interface slice containing the arguments of this
@@ -430,5 +436,5 @@ func (c PositionAdjusterAdjustRangeFuncCall) Args() []interface{} {
 // Results returns an interface slice containing the results of this
 // invocation.
 func (c PositionAdjusterAdjustRangeFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3, c.Result4}
 }
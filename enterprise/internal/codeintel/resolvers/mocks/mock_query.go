@@ -23,6 +23,9 @@ type MockQueryResolver struct {
 	// HoverFunc is an instance of a mock function object controlling the
 	// behavior of the method Hover.
 	HoverFunc *QueryResolverHoverFunc
+	// ImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method Implementations.
+	ImplementationsFunc *QueryResolverImplementationsFunc
 	// ReferencesFunc is an instance of a mock function object controlling
 	// the behavior of the method References.
 	ReferencesFunc *QueryResolverReferencesFunc
@@ -43,8 +46,13 @@ func NewMockQueryResolver() *MockQueryResolver {
 			},
 		},
 		HoverFunc: &QueryResolverHoverFunc{
-			defaultHook: func(context.Context, int, int) (string, client.Range, bool, error) {
-				return "", client.Range{}, false, nil
+			defaultHook: func(context.Context, int, int) (string, client.Range, resolvers.AdjustmentConfidence, bool, error) {
+				return "", client.Range{}, 0, false, nil
+			},
+		},
+		ImplementationsFunc: &QueryResolverImplementationsFunc{
+			defaultHook: func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+				return nil, "", nil
 			},
 		},
 		ReferencesFunc: &QueryResolverReferencesFunc{
@@ -69,6 +77,9 @@ func NewMockQueryResolverFrom(i resolvers.QueryResolver) *MockQueryResolver {
 		HoverFunc: &QueryResolverHoverFunc{
 			defaultHook: i.Hover,
 		},
+		ImplementationsFunc: &QueryResolverImplementationsFunc{
+			defaultHook: i.Implementations,
+		},
 		ReferencesFunc: &QueryResolverReferencesFunc{
 			defaultHook: i.References,
 		},
@@ -302,23 +313,23 @@ func (c QueryResolverDiagnosticsFuncCall) Results() []interface{} {
 // QueryResolverHoverFunc describes the behavior when the Hover method of
 // the parent MockQueryResolver instance is invoked.
 type QueryResolverHoverFunc struct {
-	defaultHook func(context.Context, int, int) (string, client.Range, bool, error)
-	hooks       []func(context.Context, int, int) (string, client.Range, bool, error)
+	defaultHook func(context.Context, int, int) (string, client.Range, resolvers.AdjustmentConfidence, bool, error)
+	hooks       []func(context.Context, int, int) (string, client.Range, resolvers.AdjustmentConfidence, bool, error)
 	history     []QueryResolverHoverFuncCall
 	mutex       sync.Mutex
 }
 
 // Hover delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockQueryResolver) Hover(v0 context.Context, v1 int, v2 int) (string, client.Range, bool, error) {
-	r0, r1, r2, r3 := m.HoverFunc.nextHook()(v0, v1, v2)
-	m.HoverFunc.appendCall(QueryResolverHoverFuncCall{v0, v1, v2, r0, r1, r2, r3})
-	return r0, r1, r2, r3
+func (m *MockQueryResolver) Hover(v0 context.Context, v1 int, v2 int) (string, client.Range, resolvers.AdjustmentConfidence, bool, error) {
+	r0, r1, r2, r3, r4 := m.HoverFunc.nextHook()(v0, v1, v2)
+	m.HoverFunc.appendCall(QueryResolverHoverFuncCall{v0, v1, v2, r0, r1, r2, r3, r4})
+	return r0, r1, r2, r3, r4
 }
 
 // SetDefaultHook sets function that is called when the Hover method of the
 // parent MockQueryResolver instance is invoked and the hook queue is empty.
-func (f *QueryResolverHoverFunc) SetDefaultHook(hook func(context.Context, int, int) (string, client.Range, bool, error)) {
+func (f *QueryResolverHoverFunc) SetDefaultHook(hook func(context.Context, int, int) (string, client.Range, resolvers.AdjustmentConfidence, bool, error)) {
 	f.defaultHook = hook
 }
 
@@ -326,7 +337,7 @@ func (f *QueryResolverHoverFunc) SetDefaultHook(hook func(context.Context, int,
 // Hover method of the parent MockQueryResolver instance inovkes the hook at
 // the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *QueryResolverHoverFunc) PushHook(hook func(context.Context, int, int) (string, client.Range, bool, error)) {
+func (f *QueryResolverHoverFunc) PushHook(hook func(context.Context, int, int) (string, client.Range, resolvers.AdjustmentConfidence, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -334,21 +345,21 @@ func (f *QueryResolverHoverFunc) PushHook(hook func(context.Context, int, int) (
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *QueryResolverHoverFunc) SetDefaultReturn(r0 string, r1 client.Range, r2 bool, r3 error) {
-	f.SetDefaultHook(func(context.Context, int, int) (string, client.Range, bool, error) {
-		return r0, r1, r2, r3
+func (f *QueryResolverHoverFunc) SetDefaultReturn(r0 string, r1 client.Range, r2 resolvers.AdjustmentConfidence, r3 bool, r4 error) {
+	f.SetDefaultHook(func(context.Context, int, int) (string, client.Range, resolvers.AdjustmentConfidence, bool, error) {
+		return r0, r1, r2, r3, r4
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *QueryResolverHoverFunc) PushReturn(r0 string, r1 client.Range, r2 bool, r3 error) {
-	f.PushHook(func(context.Context, int, int) (string, client.Range, bool, error) {
-		return r0, r1, r2, r3
+func (f *QueryResolverHoverFunc) PushReturn(r0 string, r1 client.Range, r2 resolvers.AdjustmentConfidence, r3 bool, r4 error) {
+	f.PushHook(func(context.Context, int, int) (string, client.Range, resolvers.AdjustmentConfidence, bool, error) {
+		return r0, r1, r2, r3, r4
 	})
 }
 
-func (f *QueryResolverHoverFunc) nextHook() func(context.Context, int, int) (string, client.Range, bool, error) {
+func (f *QueryResolverHoverFunc) nextHook() func(context.Context, int, int) (string, client.Range, resolvers.AdjustmentConfidence, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -398,10 +409,13 @@ type QueryResolverHoverFuncCall struct {
 	Result1 client.Range
 	// Result2 is the value of the 3rd result returned from this method
 	// invocation.
-	Result2 bool
+	Result2 resolvers.AdjustmentConfidence
 	// Result3 is the value of the 4th result returned from this method
 	// invocation.
-	Result3 error
+	Result3 bool
+	// Result4 is the value of the 5th result returned from this method
+	// invocation.
+	Result4 error
 }
 
 // Args returns an interface slice containing the arguments of this
@@ -413,7 +427,129 @@ func (c QueryResolverHoverFuncCall) Args() []interface{} {
 // Results returns an interface slice containing the results of this
 // invocation.
 func (c QueryResolverHoverFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3, c.Result4}
+}
+
+// QueryResolverImplementationsFunc describes the behavior when the
+// Implementations method of the parent MockQueryResolver instance is
+// invoked.
+type QueryResolverImplementationsFunc struct {
+	defaultHook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)
+	hooks       []func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)
+	history     []QueryResolverImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// Implementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockQueryResolver) Implementations(v0 context.Context, v1 int, v2 int, v3 int, v4 string) ([]resolvers.AdjustedLocation, string, error) {
+	r0, r1, r2 := m.ImplementationsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.ImplementationsFunc.appendCall(QueryResolverImplementationsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the Implementations
+// method of the parent MockQueryResolver instance is invoked and the hook
+// queue is empty.
+func (f *QueryResolverImplementationsFunc) SetDefaultHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Implementations method of the parent MockQueryResolver instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *QueryResolverImplementationsFunc) PushHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *QueryResolverImplementationsFunc) SetDefaultReturn(r0 []resolvers.AdjustedLocation, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *QueryResolverImplementationsFunc) PushReturn(r0 []resolvers.AdjustedLocation, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *QueryResolverImplementationsFunc) nextHook() func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *QueryResolverImplementationsFunc) appendCall(r0 QueryResolverImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of QueryResolverImplementationsFuncCall
+// objects describing the invocations of this function.
+func (f *QueryResolverImplementationsFunc) History() []QueryResolverImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]QueryResolverImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// QueryResolverImplementationsFuncCall is an object that describes an
+// invocation of method Implementations on an instance of MockQueryResolver.
+type QueryResolverImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []resolvers.AdjustedLocation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c QueryResolverImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c QueryResolverImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
 // QueryResolverReferencesFunc describes the behavior when the References
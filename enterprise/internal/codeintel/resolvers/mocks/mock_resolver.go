@@ -24,18 +24,32 @@ type MockResolver struct {
 	// GetIndexByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexByID.
 	GetIndexByIDFunc *ResolverGetIndexByIDFunc
+	// GetPackageProviderFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPackageProvider.
+	GetPackageProviderFunc *ResolverGetPackageProviderFunc
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *ResolverGetUploadByIDFunc
 	// IndexConnectionResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method IndexConnectionResolver.
 	IndexConnectionResolverFunc *ResolverIndexConnectionResolverFunc
+	// PackageDependenciesConnectionResolverFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// PackageDependenciesConnectionResolver.
+	PackageDependenciesConnectionResolverFunc *ResolverPackageDependenciesConnectionResolverFunc
+	// PackageDependentsConnectionResolverFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// PackageDependentsConnectionResolver.
+	PackageDependentsConnectionResolverFunc *ResolverPackageDependentsConnectionResolverFunc
 	// QueryResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method QueryResolver.
 	QueryResolverFunc *ResolverQueryResolverFunc
 	// UploadConnectionResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method UploadConnectionResolver.
 	UploadConnectionResolverFunc *ResolverUploadConnectionResolverFunc
+	// UploadRetentionPreviewFunc is an instance of a mock function object
+	// controlling the behavior of the method UploadRetentionPreview.
+	UploadRetentionPreviewFunc *ResolverUploadRetentionPreviewFunc
 }
 
 // NewMockResolver creates a new mock of the Resolver interface. All methods
@@ -57,6 +71,11 @@ func NewMockResolver() *MockResolver {
 				return store.Index{}, false, nil
 			},
 		},
+		GetPackageProviderFunc: &ResolverGetPackageProviderFunc{
+			defaultHook: func(context.Context, string, string, string) (store.Upload, bool, error) {
+				return store.Upload{}, false, nil
+			},
+		},
 		GetUploadByIDFunc: &ResolverGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (store.Upload, bool, error) {
 				return store.Upload{}, false, nil
@@ -67,6 +86,16 @@ func NewMockResolver() *MockResolver {
 				return nil
 			},
 		},
+		PackageDependenciesConnectionResolverFunc: &ResolverPackageDependenciesConnectionResolverFunc{
+			defaultHook: func(store.GetPackageDependenciesOptions) *resolvers.PackageUsagesResolver {
+				return nil
+			},
+		},
+		PackageDependentsConnectionResolverFunc: &ResolverPackageDependentsConnectionResolverFunc{
+			defaultHook: func(store.GetPackageDependentsOptions) *resolvers.PackageUsagesResolver {
+				return nil
+			},
+		},
 		QueryResolverFunc: &ResolverQueryResolverFunc{
 			defaultHook: func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
 				return nil, nil
@@ -77,6 +106,11 @@ func NewMockResolver() *MockResolver {
 				return nil
 			},
 		},
+		UploadRetentionPreviewFunc: &ResolverUploadRetentionPreviewFunc{
+			defaultHook: func(context.Context, int) ([]resolvers.UploadExpiration, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -93,18 +127,30 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: i.GetIndexByID,
 		},
+		GetPackageProviderFunc: &ResolverGetPackageProviderFunc{
+			defaultHook: i.GetPackageProvider,
+		},
 		GetUploadByIDFunc: &ResolverGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
 		IndexConnectionResolverFunc: &ResolverIndexConnectionResolverFunc{
 			defaultHook: i.IndexConnectionResolver,
 		},
+		PackageDependenciesConnectionResolverFunc: &ResolverPackageDependenciesConnectionResolverFunc{
+			defaultHook: i.PackageDependenciesConnectionResolver,
+		},
+		PackageDependentsConnectionResolverFunc: &ResolverPackageDependentsConnectionResolverFunc{
+			defaultHook: i.PackageDependentsConnectionResolver,
+		},
 		QueryResolverFunc: &ResolverQueryResolverFunc{
 			defaultHook: i.QueryResolver,
 		},
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: i.UploadConnectionResolver,
 		},
+		UploadRetentionPreviewFunc: &ResolverUploadRetentionPreviewFunc{
+			defaultHook: i.UploadRetentionPreview,
+		},
 	}
 }
 
@@ -432,6 +478,124 @@ func (c ResolverGetIndexByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// ResolverGetPackageProviderFunc describes the behavior when the
+// GetPackageProvider method of the parent MockResolver instance is invoked.
+type ResolverGetPackageProviderFunc struct {
+	defaultHook func(context.Context, string, string, string) (store.Upload, bool, error)
+	hooks       []func(context.Context, string, string, string) (store.Upload, bool, error)
+	history     []ResolverGetPackageProviderFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPackageProvider delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) GetPackageProvider(v0 context.Context, v1 string, v2 string, v3 string) (store.Upload, bool, error) {
+	r0, r1, r2 := m.GetPackageProviderFunc.nextHook()(v0, v1, v2, v3)
+	m.GetPackageProviderFunc.appendCall(ResolverGetPackageProviderFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetPackageProvider
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverGetPackageProviderFunc) SetDefaultHook(hook func(context.Context, string, string, string) (store.Upload, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPackageProvider method of the parent MockResolver instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverGetPackageProviderFunc) PushHook(hook func(context.Context, string, string, string) (store.Upload, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverGetPackageProviderFunc) SetDefaultReturn(r0 store.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, string, string) (store.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverGetPackageProviderFunc) PushReturn(r0 store.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, string, string, string) (store.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverGetPackageProviderFunc) nextHook() func(context.Context, string, string, string) (store.Upload, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverGetPackageProviderFunc) appendCall(r0 ResolverGetPackageProviderFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverGetPackageProviderFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverGetPackageProviderFunc) History() []ResolverGetPackageProviderFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverGetPackageProviderFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverGetPackageProviderFuncCall is an object that describes an
+// invocation of method GetPackageProvider on an instance of MockResolver.
+type ResolverGetPackageProviderFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 store.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverGetPackageProviderFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverGetPackageProviderFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // ResolverGetUploadByIDFunc describes the behavior when the GetUploadByID
 // method of the parent MockResolver instance is invoked.
 type ResolverGetUploadByIDFunc struct {
@@ -650,6 +814,222 @@ func (c ResolverIndexConnectionResolverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ResolverPackageDependenciesConnectionResolverFunc describes the behavior
+// when the PackageDependenciesConnectionResolver method of the parent
+// MockResolver instance is invoked.
+type ResolverPackageDependenciesConnectionResolverFunc struct {
+	defaultHook func(store.GetPackageDependenciesOptions) *resolvers.PackageUsagesResolver
+	hooks       []func(store.GetPackageDependenciesOptions) *resolvers.PackageUsagesResolver
+	history     []ResolverPackageDependenciesConnectionResolverFuncCall
+	mutex       sync.Mutex
+}
+
+// PackageDependenciesConnectionResolver delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockResolver) PackageDependenciesConnectionResolver(v0 store.GetPackageDependenciesOptions) *resolvers.PackageUsagesResolver {
+	r0 := m.PackageDependenciesConnectionResolverFunc.nextHook()(v0)
+	m.PackageDependenciesConnectionResolverFunc.appendCall(ResolverPackageDependenciesConnectionResolverFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// PackageDependenciesConnectionResolver method of the parent MockResolver
+// instance is invoked and the hook queue is empty.
+func (f *ResolverPackageDependenciesConnectionResolverFunc) SetDefaultHook(hook func(store.GetPackageDependenciesOptions) *resolvers.PackageUsagesResolver) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PackageDependenciesConnectionResolver method of the parent MockResolver
+// instance inovkes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ResolverPackageDependenciesConnectionResolverFunc) PushHook(hook func(store.GetPackageDependenciesOptions) *resolvers.PackageUsagesResolver) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverPackageDependenciesConnectionResolverFunc) SetDefaultReturn(r0 *resolvers.PackageUsagesResolver) {
+	f.SetDefaultHook(func(store.GetPackageDependenciesOptions) *resolvers.PackageUsagesResolver {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverPackageDependenciesConnectionResolverFunc) PushReturn(r0 *resolvers.PackageUsagesResolver) {
+	f.PushHook(func(store.GetPackageDependenciesOptions) *resolvers.PackageUsagesResolver {
+		return r0
+	})
+}
+
+func (f *ResolverPackageDependenciesConnectionResolverFunc) nextHook() func(store.GetPackageDependenciesOptions) *resolvers.PackageUsagesResolver {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverPackageDependenciesConnectionResolverFunc) appendCall(r0 ResolverPackageDependenciesConnectionResolverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ResolverPackageDependenciesConnectionResolverFuncCall objects describing
+// the invocations of this function.
+func (f *ResolverPackageDependenciesConnectionResolverFunc) History() []ResolverPackageDependenciesConnectionResolverFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverPackageDependenciesConnectionResolverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverPackageDependenciesConnectionResolverFuncCall is an object that
+// describes an invocation of method PackageDependenciesConnectionResolver
+// on an instance of MockResolver.
+type ResolverPackageDependenciesConnectionResolverFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 store.GetPackageDependenciesOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *resolvers.PackageUsagesResolver
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverPackageDependenciesConnectionResolverFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverPackageDependenciesConnectionResolverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ResolverPackageDependentsConnectionResolverFunc describes the behavior
+// when the PackageDependentsConnectionResolver method of the parent
+// MockResolver instance is invoked.
+type ResolverPackageDependentsConnectionResolverFunc struct {
+	defaultHook func(store.GetPackageDependentsOptions) *resolvers.PackageUsagesResolver
+	hooks       []func(store.GetPackageDependentsOptions) *resolvers.PackageUsagesResolver
+	history     []ResolverPackageDependentsConnectionResolverFuncCall
+	mutex       sync.Mutex
+}
+
+// PackageDependentsConnectionResolver delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockResolver) PackageDependentsConnectionResolver(v0 store.GetPackageDependentsOptions) *resolvers.PackageUsagesResolver {
+	r0 := m.PackageDependentsConnectionResolverFunc.nextHook()(v0)
+	m.PackageDependentsConnectionResolverFunc.appendCall(ResolverPackageDependentsConnectionResolverFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// PackageDependentsConnectionResolver method of the parent MockResolver
+// instance is invoked and the hook queue is empty.
+func (f *ResolverPackageDependentsConnectionResolverFunc) SetDefaultHook(hook func(store.GetPackageDependentsOptions) *resolvers.PackageUsagesResolver) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PackageDependentsConnectionResolver method of the parent MockResolver
+// instance inovkes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ResolverPackageDependentsConnectionResolverFunc) PushHook(hook func(store.GetPackageDependentsOptions) *resolvers.PackageUsagesResolver) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverPackageDependentsConnectionResolverFunc) SetDefaultReturn(r0 *resolvers.PackageUsagesResolver) {
+	f.SetDefaultHook(func(store.GetPackageDependentsOptions) *resolvers.PackageUsagesResolver {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverPackageDependentsConnectionResolverFunc) PushReturn(r0 *resolvers.PackageUsagesResolver) {
+	f.PushHook(func(store.GetPackageDependentsOptions) *resolvers.PackageUsagesResolver {
+		return r0
+	})
+}
+
+func (f *ResolverPackageDependentsConnectionResolverFunc) nextHook() func(store.GetPackageDependentsOptions) *resolvers.PackageUsagesResolver {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverPackageDependentsConnectionResolverFunc) appendCall(r0 ResolverPackageDependentsConnectionResolverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ResolverPackageDependentsConnectionResolverFuncCall objects describing
+// the invocations of this function.
+func (f *ResolverPackageDependentsConnectionResolverFunc) History() []ResolverPackageDependentsConnectionResolverFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverPackageDependentsConnectionResolverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverPackageDependentsConnectionResolverFuncCall is an object that
+// describes an invocation of method PackageDependentsConnectionResolver on
+// an instance of MockResolver.
+type ResolverPackageDependentsConnectionResolverFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 store.GetPackageDependentsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *resolvers.PackageUsagesResolver
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverPackageDependentsConnectionResolverFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverPackageDependentsConnectionResolverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ResolverQueryResolverFunc describes the behavior when the QueryResolver
 // method of the parent MockResolver instance is invoked.
 type ResolverQueryResolverFunc struct {
@@ -864,3 +1244,114 @@ func (c ResolverUploadConnectionResolverFuncCall) Args() []interface{} {
 func (c ResolverUploadConnectionResolverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// ResolverUploadRetentionPreviewFunc describes the behavior when the
+// UploadRetentionPreview method of the parent MockResolver instance is
+// invoked.
+type ResolverUploadRetentionPreviewFunc struct {
+	defaultHook func(context.Context, int) ([]resolvers.UploadExpiration, error)
+	hooks       []func(context.Context, int) ([]resolvers.UploadExpiration, error)
+	history     []ResolverUploadRetentionPreviewFuncCall
+	mutex       sync.Mutex
+}
+
+// UploadRetentionPreview delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) UploadRetentionPreview(v0 context.Context, v1 int) ([]resolvers.UploadExpiration, error) {
+	r0, r1 := m.UploadRetentionPreviewFunc.nextHook()(v0, v1)
+	m.UploadRetentionPreviewFunc.appendCall(ResolverUploadRetentionPreviewFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UploadRetentionPreview method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverUploadRetentionPreviewFunc) SetDefaultHook(hook func(context.Context, int) ([]resolvers.UploadExpiration, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UploadRetentionPreview method of the parent MockResolver instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverUploadRetentionPreviewFunc) PushHook(hook func(context.Context, int) ([]resolvers.UploadExpiration, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverUploadRetentionPreviewFunc) SetDefaultReturn(r0 []resolvers.UploadExpiration, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]resolvers.UploadExpiration, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverUploadRetentionPreviewFunc) PushReturn(r0 []resolvers.UploadExpiration, r1 error) {
+	f.PushHook(func(context.Context, int) ([]resolvers.UploadExpiration, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverUploadRetentionPreviewFunc) nextHook() func(context.Context, int) ([]resolvers.UploadExpiration, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverUploadRetentionPreviewFunc) appendCall(r0 ResolverUploadRetentionPreviewFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverUploadRetentionPreviewFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverUploadRetentionPreviewFunc) History() []ResolverUploadRetentionPreviewFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverUploadRetentionPreviewFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverUploadRetentionPreviewFuncCall is an object that describes an
+// invocation of method UploadRetentionPreview on an instance of
+// MockResolver.
+type ResolverUploadRetentionPreviewFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []resolvers.UploadExpiration
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverUploadRetentionPreviewFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverUploadRetentionPreviewFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -0,0 +1,63 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+// PackageUsagesResolver wraps store.GetPackageDependents or store.GetPackageDependencies
+// so that the underlying function can be invoked lazily and its results memoized.
+type PackageUsagesResolver struct {
+	getUsages func(ctx context.Context) ([]store.PackageUsage, int, error)
+	offset    int
+	once      sync.Once
+	//
+	Usages     []store.PackageUsage
+	TotalCount int
+	NextOffset *int
+	err        error
+}
+
+// NewPackageDependentsResolver creates a new PackageUsagesResolver which will invoke
+// store.GetPackageDependents with the given options.
+func NewPackageDependentsResolver(s store.Store, opts store.GetPackageDependentsOptions) *PackageUsagesResolver {
+	return &PackageUsagesResolver{
+		getUsages: func(ctx context.Context) ([]store.PackageUsage, int, error) {
+			return s.GetPackageDependents(ctx, opts)
+		},
+		offset: opts.Offset,
+	}
+}
+
+// NewPackageDependenciesResolver creates a new PackageUsagesResolver which will invoke
+// store.GetPackageDependencies with the given options.
+func NewPackageDependenciesResolver(s store.Store, opts store.GetPackageDependenciesOptions) *PackageUsagesResolver {
+	return &PackageUsagesResolver{
+		getUsages: func(ctx context.Context) ([]store.PackageUsage, int, error) {
+			return s.GetPackageDependencies(ctx, opts)
+		},
+		offset: opts.Offset,
+	}
+}
+
+// Resolve ensures that the underlying store method has been invoked. This function returns
+// the error from the invocation, if any. If the error is nil, then the resolver's Usages,
+// TotalCount, and NextOffset fields will be populated.
+func (r *PackageUsagesResolver) Resolve(ctx context.Context) error {
+	r.once.Do(func() { r.err = r.resolve(ctx) })
+	return r.err
+}
+
+func (r *PackageUsagesResolver) resolve(ctx context.Context) error {
+	usages, totalCount, err := r.getUsages(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.Usages = usages
+	r.NextOffset = nextOffset(r.offset, len(usages), totalCount)
+	r.TotalCount = totalCount
+	return nil
+}
@@ -1,10 +1,12 @@
 package resolvers
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"io/ioutil"
 	"strings"
+	"sync"
 
 	"github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
@@ -13,6 +15,46 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
+// MaxChangedFraction is the fraction of lines of a file that may be removed or rewritten between
+// two commits before adjustments of positions within that file are considered unreliable.
+const MaxChangedFraction = 0.5
+
+// AdjustmentConfidence describes how closely an adjusted position is expected to match the
+// original position.
+type AdjustmentConfidence int
+
+const (
+	// ConfidenceExact indicates that the position was valid as-is in the target commit.
+	ConfidenceExact AdjustmentConfidence = iota
+
+	// ConfidenceAdjusted indicates that the position was moved to account for lines added or
+	// removed earlier in the file.
+	ConfidenceAdjusted
+
+	// ConfidenceFileChangedTooMuch indicates that the file was edited so heavily between the
+	// two commits that the adjusted position may not refer to the same code.
+	ConfidenceFileChangedTooMuch
+)
+
+func (c AdjustmentConfidence) String() string {
+	switch c {
+	case ConfidenceExact:
+		return "EXACT"
+	case ConfidenceAdjusted:
+		return "ADJUSTED"
+	default:
+		return "FILE_CHANGED_TOO_MUCH"
+	}
+}
+
+// lowestConfidence returns the least confident of the given confidence values.
+func lowestConfidence(a, b AdjustmentConfidence) AdjustmentConfidence {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // PositionAdjuster translates a position within a git tree at a source commit into the
 // equivalent position in a target commit commit. The position adjuster instance carries
 // along with it the source commit.
@@ -22,28 +64,60 @@ type PositionAdjuster interface {
 	AdjustPath(ctx context.Context, commit, path string, reverse bool) (string, bool, error)
 
 	// AdjustPosition translates the given position from the source commit into the given
-	// target commit. The adjusted path and position are returned, along with a boolean flag
-	// indicating that the translation was successful. If revese is true, then the source and
-	// target commits are swapped.
-	AdjustPosition(ctx context.Context, commit, path string, px bundles.Position, reverse bool) (string, bundles.Position, bool, error)
+	// target commit. The adjusted path and position are returned, along with the confidence
+	// of the adjustment and a boolean flag indicating that the translation was successful.
+	// If revese is true, then the source and target commits are swapped.
+	AdjustPosition(ctx context.Context, commit, path string, px bundles.Position, reverse bool) (string, bundles.Position, AdjustmentConfidence, bool, error)
 
 	// AdjustRange translates the given range from the source commit into the given target
-	// commit. The adjusted path and range are returned, along with a boolean flag indicating
-	// that the translation was successful. If revese is true, then the source and target commits
-	// are swapped.
-	AdjustRange(ctx context.Context, commit, path string, rx bundles.Range, reverse bool) (string, bundles.Range, bool, error)
+	// commit. The adjusted path and range are returned, along with the confidence of the
+	// adjustment and a boolean flag indicating that the translation was successful. If revese
+	// is true, then the source and target commits are swapped.
+	AdjustRange(ctx context.Context, commit, path string, rx bundles.Range, reverse bool) (string, bundles.Range, AdjustmentConfidence, bool, error)
 }
 
 type positionAdjuster struct {
 	repo   *types.Repo
 	commit string
+
+	m     sync.Mutex
+	diffs map[fileDiffKey]*fileDiffEntry
+}
+
+type fileDiffKey struct {
+	commit string
+	path   string
+}
+
+// fileDiffEntry holds the changes of a single file between the source commit of a position
+// adjuster and another commit. The diff is read at most once per adjuster.
+type fileDiffEntry struct {
+	once sync.Once
+	diff *fileDiff
+	err  error
+}
+
+type fileDiff struct {
+	// hunks is the position-ordered slice of changes from the source commit to the target commit.
+	hunks []*diff.Hunk
+
+	// reverseHunks is the position-ordered slice of changes from the target commit to the source
+	// commit. These are derived from hunks rather than requested separately.
+	reverseHunks []*diff.Hunk
+
+	// changedTooMuch is true if more than MaxChangedFraction of the lines of the file in either
+	// commit were removed or rewritten in the other.
+	changedTooMuch bool
 }
 
 // NewPositionAdjuster creates a new PositionAdjuster with the given repository and source commit.
+// The diffs read to adjust positions are cached for the lifetime of the adjuster, so an adjuster
+// should be shared by all queries of a single request.
 func NewPositionAdjuster(repo *types.Repo, commit string) PositionAdjuster {
 	return &positionAdjuster{
 		repo:   repo,
 		commit: commit,
+		diffs:  map[fileDiffKey]*fileDiffEntry{},
 	}
 }
 
@@ -54,69 +128,204 @@ func (p *positionAdjuster) AdjustPath(ctx context.Context, commit, path string,
 }
 
 // AdjustPosition translates the given position from the source commit into the given
-// target commit. The adjusted path and position are returned, along with a boolean flag
-// indicating that the translation was successful. If revese is true, then the source and
-// target commits are swapped.
-func (p *positionAdjuster) AdjustPosition(ctx context.Context, commit, path string, px bundles.Position, reverse bool) (string, bundles.Position, bool, error) {
-	hunks, err := p.readHunks(ctx, p.repo, p.commit, commit, path, reverse)
+// target commit. The adjusted path and position are returned, along with the confidence
+// of the adjustment and a boolean flag indicating that the translation was successful.
+// If revese is true, then the source and target commits are swapped.
+func (p *positionAdjuster) AdjustPosition(ctx context.Context, commit, path string, px bundles.Position, reverse bool) (string, bundles.Position, AdjustmentConfidence, bool, error) {
+	hunks, changedTooMuch, err := p.readHunks(ctx, commit, path, reverse)
 	if err != nil {
-		return "", bundles.Position{}, false, err
+		return "", bundles.Position{}, 0, false, err
 	}
 
 	adjusted, ok := adjustPosition(hunks, px)
-	return path, adjusted, ok, nil
+	return path, adjusted, positionConfidence(px, adjusted, changedTooMuch), ok, nil
 }
 
 // AdjustRange translates the given range from the source commit into the given target
-// commit. The adjusted path and range are returned, along with a boolean flag indicating
-// that the translation was successful. If revese is true, then the source and target commits
-// are swapped.
-func (p *positionAdjuster) AdjustRange(ctx context.Context, commit, path string, rx bundles.Range, reverse bool) (string, bundles.Range, bool, error) {
-	hunks, err := p.readHunks(ctx, p.repo, p.commit, commit, path, reverse)
+// commit. The adjusted path and range are returned, along with the confidence of the
+// adjustment and a boolean flag indicating that the translation was successful. If revese
+// is true, then the source and target commits are swapped.
+func (p *positionAdjuster) AdjustRange(ctx context.Context, commit, path string, rx bundles.Range, reverse bool) (string, bundles.Range, AdjustmentConfidence, bool, error) {
+	hunks, changedTooMuch, err := p.readHunks(ctx, commit, path, reverse)
 	if err != nil {
-		return "", bundles.Range{}, false, err
+		return "", bundles.Range{}, 0, false, err
 	}
 
 	adjusted, ok := adjustRange(hunks, rx)
-	return path, adjusted, ok, nil
+	confidence := lowestConfidence(
+		positionConfidence(rx.Start, adjusted.Start, changedTooMuch),
+		positionConfidence(rx.End, adjusted.End, changedTooMuch),
+	)
+
+	return path, adjusted, confidence, ok, nil
+}
+
+// positionConfidence returns the confidence of the adjustment of the given position.
+func positionConfidence(original, adjusted bundles.Position, changedTooMuch bool) AdjustmentConfidence {
+	if changedTooMuch {
+		return ConfidenceFileChangedTooMuch
+	}
+	if original != adjusted {
+		return ConfidenceAdjusted
+	}
+	return ConfidenceExact
 }
 
 // readHunks returns a position-ordered slice of changes (additions or deletions) of the
-// given path between the given source and target commits. If revese is true, then the
-// source and target commits are swapped.
-func (p *positionAdjuster) readHunks(ctx context.Context, repo *types.Repo, sourceCommit, targetCommit, path string, reverse bool) ([]*diff.Hunk, error) {
-	if sourceCommit == targetCommit {
-		return nil, nil
+// given path between the source commit and the given target commit, along with a flag
+// indicating whether the file changed too much for the changes to be reliable. If revese
+// is true, then the source and target commits are swapped.
+//
+// A single diff between the two commits accounts for every commit in between, so the cost
+// of an adjustment does not depend on the distance between the commits. The diff is read
+// once per path and commit and serves adjustments in both directions.
+func (p *positionAdjuster) readHunks(ctx context.Context, commit, path string, reverse bool) ([]*diff.Hunk, bool, error) {
+	if p.commit == commit {
+		return nil, false, nil
+	}
+
+	p.m.Lock()
+	entry, ok := p.diffs[fileDiffKey{commit, path}]
+	if !ok {
+		entry = &fileDiffEntry{}
+		p.diffs[fileDiffKey{commit, path}] = entry
 	}
+	p.m.Unlock()
+
+	entry.once.Do(func() { entry.diff, entry.err = p.readFileDiff(ctx, commit, path) })
+	if entry.err != nil {
+		return nil, false, entry.err
+	}
+
 	if reverse {
-		sourceCommit, targetCommit = targetCommit, sourceCommit
+		return entry.diff.reverseHunks, entry.diff.changedTooMuch, nil
 	}
+	return entry.diff.hunks, entry.diff.changedTooMuch, nil
+}
 
-	cachedRepo, err := backend.CachedGitRepo(ctx, repo)
+// readFileDiff reads the changes of the given path between the source commit and the given
+// target commit.
+func (p *positionAdjuster) readFileDiff(ctx context.Context, commit, path string) (*fileDiff, error) {
+	output, err := p.execGit(ctx, []string{"diff", p.commit, commit, "--", path})
 	if err != nil {
 		return nil, err
 	}
+	if len(output) == 0 {
+		return &fileDiff{}, nil
+	}
 
-	// TODO(efritz) - cache diff results
-	reader, err := git.ExecReader(ctx, *cachedRepo, []string{"diff", sourceCommit, targetCommit, "--", path})
+	d, err := diff.NewFileDiffReader(bytes.NewReader(output)).Read()
 	if err != nil {
 		return nil, err
 	}
-	defer reader.Close()
 
-	output, err := ioutil.ReadAll(reader)
+	if d.OrigName == "/dev/null" || d.NewName == "/dev/null" {
+		// The file does not exist in one of the commits, so there is no content to read and
+		// every line of the file was added or removed.
+		return &fileDiff{
+			hunks:          d.Hunks,
+			reverseHunks:   invertHunks(d.Hunks),
+			changedTooMuch: true,
+		}, nil
+	}
+
+	// Determine how much of the file was rewritten relative to its size in each commit
+	contents, err := p.execGit(ctx, []string{"show", p.commit + ":" + path})
 	if err != nil {
 		return nil, err
 	}
-	if len(output) == 0 {
-		return nil, nil
+	sourceLines := countLines(contents)
+	added, removed := countChangedLines(d.Hunks)
+	targetLines := sourceLines - removed + added
+
+	return &fileDiff{
+		hunks:          d.Hunks,
+		reverseHunks:   invertHunks(d.Hunks),
+		changedTooMuch: exceedsChangedFraction(removed, sourceLines) || exceedsChangedFraction(added, targetLines),
+	}, nil
+}
+
+// execGit returns the output of the given git command in the adjuster's repository.
+func (p *positionAdjuster) execGit(ctx context.Context, args []string) ([]byte, error) {
+	cachedRepo, err := backend.CachedGitRepo(ctx, p.repo)
+	if err != nil {
+		return nil, err
 	}
 
-	diff, err := diff.NewFileDiffReader(bytes.NewReader(output)).Read()
+	reader, err := git.ExecReader(ctx, *cachedRepo, args)
 	if err != nil {
 		return nil, err
 	}
-	return diff.Hunks, nil
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
+
+// countLines returns the number of lines in the given file contents.
+func countLines(contents []byte) int {
+	lines := 0
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(contents)+1)
+	for scanner.Scan() {
+		lines++
+	}
+
+	return lines
+}
+
+// countChangedLines returns the number of lines added and removed by the given hunks.
+func countChangedLines(hunks []*diff.Hunk) (added, removed int) {
+	for _, hunk := range hunks {
+		for _, deltaLine := range strings.Split(string(hunk.Body), "\n") {
+			if strings.HasPrefix(deltaLine, "+") {
+				added++
+			} else if strings.HasPrefix(deltaLine, "-") {
+				removed++
+			}
+		}
+	}
+
+	return added, removed
+}
+
+// exceedsChangedFraction returns true if the given number of changed lines is more than
+// MaxChangedFraction of the given total number of lines.
+func exceedsChangedFraction(changed, total int) bool {
+	if changed == 0 {
+		return false
+	}
+	if total <= 0 {
+		return true
+	}
+
+	return float64(changed)/float64(total) > MaxChangedFraction
+}
+
+// invertHunks returns the hunks that undo the given hunks. Additions become deletions and
+// deletions become additions.
+func invertHunks(hunks []*diff.Hunk) []*diff.Hunk {
+	inverted := make([]*diff.Hunk, 0, len(hunks))
+	for _, hunk := range hunks {
+		deltaLines := strings.Split(string(hunk.Body), "\n")
+		for i, deltaLine := range deltaLines {
+			if strings.HasPrefix(deltaLine, "+") {
+				deltaLines[i] = "-" + deltaLine[1:]
+			} else if strings.HasPrefix(deltaLine, "-") {
+				deltaLines[i] = "+" + deltaLine[1:]
+			}
+		}
+
+		inverted = append(inverted, &diff.Hunk{
+			OrigStartLine: hunk.NewStartLine,
+			OrigLines:     hunk.NewLines,
+			NewStartLine:  hunk.OrigStartLine,
+			NewLines:      hunk.OrigLines,
+			Section:       hunk.Section,
+			Body:          []byte(strings.Join(deltaLines, "\n")),
+		})
+	}
+
+	return inverted
 }
 
 // adjustPosition translates the given position by adjusting the line number based on the
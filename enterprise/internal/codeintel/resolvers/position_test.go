@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -34,19 +35,12 @@ func TestAdjustPosition(t *testing.T) {
 	t.Cleanup(func() {
 		git.Mocks.ExecReader = nil
 	})
-	git.Mocks.ExecReader = func(args []string) (reader io.ReadCloser, err error) {
-		expectedArgs := []string{"diff", "deadbeef1", "deadbeef2", "--", "/foo/bar.go"}
-		if diff := cmp.Diff(expectedArgs, args); diff != "" {
-			t.Errorf("unexpected exec reader args (-want +got):\n%s", diff)
-		}
-
-		return ioutil.NopCloser(bytes.NewReader([]byte(hugoDiff))), nil
-	}
+	git.Mocks.ExecReader = mockHugoExecReader(t, 400)
 
 	posIn := bundles.Position{Line: 302, Character: 15}
 
 	adjuster := NewPositionAdjuster(&types.Repo{ID: 50}, "deadbeef1")
-	path, posOut, ok, err := adjuster.AdjustPosition(context.Background(), "deadbeef2", "/foo/bar.go", posIn, false)
+	path, posOut, confidence, ok, err := adjuster.AdjustPosition(context.Background(), "deadbeef2", "/foo/bar.go", posIn, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -57,6 +51,9 @@ func TestAdjustPosition(t *testing.T) {
 	if path != "/foo/bar.go" {
 		t.Errorf("unexpected path. want=%s have=%s", "/foo/bar.go", path)
 	}
+	if confidence != ConfidenceAdjusted {
+		t.Errorf("unexpected confidence. want=%s have=%s", ConfidenceAdjusted, confidence)
+	}
 
 	expectedPos := bundles.Position{Line: 294, Character: 15}
 	if diff := cmp.Diff(expectedPos, posOut); diff != "" {
@@ -75,7 +72,7 @@ func TestAdjustPositionEmptyDiff(t *testing.T) {
 	posIn := bundles.Position{Line: 10, Character: 15}
 
 	adjuster := NewPositionAdjuster(&types.Repo{ID: 50}, "deadbeef1")
-	path, posOut, ok, err := adjuster.AdjustPosition(context.Background(), "deadbeef2", "/foo/bar.go", posIn, false)
+	path, posOut, confidence, ok, err := adjuster.AdjustPosition(context.Background(), "deadbeef2", "/foo/bar.go", posIn, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -86,6 +83,9 @@ func TestAdjustPositionEmptyDiff(t *testing.T) {
 	if path != "/foo/bar.go" {
 		t.Errorf("unexpected path. want=%s have=%s", "/foo/bar.go", path)
 	}
+	if confidence != ConfidenceExact {
+		t.Errorf("unexpected confidence. want=%s have=%s", ConfidenceExact, confidence)
+	}
 	if diff := cmp.Diff(posOut, posIn); diff != "" {
 		t.Errorf("unexpected position (-want +got):\n%s", diff)
 	}
@@ -95,19 +95,12 @@ func TestAdjustPositionReverse(t *testing.T) {
 	t.Cleanup(func() {
 		git.Mocks.ExecReader = nil
 	})
-	git.Mocks.ExecReader = func(args []string) (reader io.ReadCloser, err error) {
-		expectedArgs := []string{"diff", "deadbeef2", "deadbeef1", "--", "/foo/bar.go"}
-		if diff := cmp.Diff(expectedArgs, args); diff != "" {
-			t.Errorf("unexpected exec reader args (-want +got):\n%s", diff)
-		}
-
-		return ioutil.NopCloser(bytes.NewReader([]byte(hugoDiff))), nil
-	}
+	git.Mocks.ExecReader = mockHugoExecReader(t, 400)
 
-	posIn := bundles.Position{Line: 302, Character: 15}
+	posIn := bundles.Position{Line: 294, Character: 15}
 
 	adjuster := NewPositionAdjuster(&types.Repo{ID: 50}, "deadbeef1")
-	path, posOut, ok, err := adjuster.AdjustPosition(context.Background(), "deadbeef2", "/foo/bar.go", posIn, true)
+	path, posOut, confidence, ok, err := adjuster.AdjustPosition(context.Background(), "deadbeef2", "/foo/bar.go", posIn, true)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -118,8 +111,11 @@ func TestAdjustPositionReverse(t *testing.T) {
 	if path != "/foo/bar.go" {
 		t.Errorf("unexpected path. want=%s have=%s", "/foo/bar.go", path)
 	}
+	if confidence != ConfidenceAdjusted {
+		t.Errorf("unexpected confidence. want=%s have=%s", ConfidenceAdjusted, confidence)
+	}
 
-	expectedPos := bundles.Position{Line: 294, Character: 15}
+	expectedPos := bundles.Position{Line: 302, Character: 15}
 	if diff := cmp.Diff(expectedPos, posOut); diff != "" {
 		t.Errorf("unexpected position (-want +got):\n%s", diff)
 	}
@@ -129,14 +125,7 @@ func TestAdjustRange(t *testing.T) {
 	t.Cleanup(func() {
 		git.Mocks.ExecReader = nil
 	})
-	git.Mocks.ExecReader = func(args []string) (reader io.ReadCloser, err error) {
-		expectedArgs := []string{"diff", "deadbeef1", "deadbeef2", "--", "/foo/bar.go"}
-		if diff := cmp.Diff(expectedArgs, args); diff != "" {
-			t.Errorf("unexpected exec reader args (-want +got):\n%s", diff)
-		}
-
-		return ioutil.NopCloser(bytes.NewReader([]byte(hugoDiff))), nil
-	}
+	git.Mocks.ExecReader = mockHugoExecReader(t, 400)
 
 	rIn := bundles.Range{
 		Start: bundles.Position{Line: 302, Character: 15},
@@ -144,7 +133,7 @@ func TestAdjustRange(t *testing.T) {
 	}
 
 	adjuster := NewPositionAdjuster(&types.Repo{ID: 50}, "deadbeef1")
-	path, rOut, ok, err := adjuster.AdjustRange(context.Background(), "deadbeef2", "/foo/bar.go", rIn, false)
+	path, rOut, confidence, ok, err := adjuster.AdjustRange(context.Background(), "deadbeef2", "/foo/bar.go", rIn, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -155,6 +144,9 @@ func TestAdjustRange(t *testing.T) {
 	if path != "/foo/bar.go" {
 		t.Errorf("unexpected path. want=%s have=%s", "/foo/bar.go", path)
 	}
+	if confidence != ConfidenceAdjusted {
+		t.Errorf("unexpected confidence. want=%s have=%s", ConfidenceAdjusted, confidence)
+	}
 
 	expectedRange := bundles.Range{
 		Start: bundles.Position{Line: 294, Character: 15},
@@ -179,7 +171,7 @@ func TestAdjustRangeEmptyDiff(t *testing.T) {
 	}
 
 	adjuster := NewPositionAdjuster(&types.Repo{ID: 50}, "deadbeef1")
-	path, rOut, ok, err := adjuster.AdjustRange(context.Background(), "deadbeef2", "/foo/bar.go", rIn, false)
+	path, rOut, confidence, ok, err := adjuster.AdjustRange(context.Background(), "deadbeef2", "/foo/bar.go", rIn, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -190,6 +182,9 @@ func TestAdjustRangeEmptyDiff(t *testing.T) {
 	if path != "/foo/bar.go" {
 		t.Errorf("unexpected path. want=%s have=%s", "/foo/bar.go", path)
 	}
+	if confidence != ConfidenceExact {
+		t.Errorf("unexpected confidence. want=%s have=%s", ConfidenceExact, confidence)
+	}
 	if diff := cmp.Diff(rOut, rIn); diff != "" {
 		t.Errorf("unexpected position (-want +got):\n%s", diff)
 	}
@@ -199,22 +194,15 @@ func TestAdjustRangeReverse(t *testing.T) {
 	t.Cleanup(func() {
 		git.Mocks.ExecReader = nil
 	})
-	git.Mocks.ExecReader = func(args []string) (reader io.ReadCloser, err error) {
-		expectedArgs := []string{"diff", "deadbeef2", "deadbeef1", "--", "/foo/bar.go"}
-		if diff := cmp.Diff(expectedArgs, args); diff != "" {
-			t.Errorf("unexpected exec reader args (-want +got):\n%s", diff)
-		}
-
-		return ioutil.NopCloser(bytes.NewReader([]byte(hugoDiff))), nil
-	}
+	git.Mocks.ExecReader = mockHugoExecReader(t, 400)
 
 	rIn := bundles.Range{
-		Start: bundles.Position{Line: 302, Character: 15},
-		End:   bundles.Position{Line: 305, Character: 20},
+		Start: bundles.Position{Line: 294, Character: 15},
+		End:   bundles.Position{Line: 297, Character: 20},
 	}
 
 	adjuster := NewPositionAdjuster(&types.Repo{ID: 50}, "deadbeef1")
-	path, rOut, ok, err := adjuster.AdjustRange(context.Background(), "deadbeef2", "/foo/bar.go", rIn, true)
+	path, rOut, confidence, ok, err := adjuster.AdjustRange(context.Background(), "deadbeef2", "/foo/bar.go", rIn, true)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -225,16 +213,163 @@ func TestAdjustRangeReverse(t *testing.T) {
 	if path != "/foo/bar.go" {
 		t.Errorf("unexpected path. want=%s have=%s", "/foo/bar.go", path)
 	}
+	if confidence != ConfidenceAdjusted {
+		t.Errorf("unexpected confidence. want=%s have=%s", ConfidenceAdjusted, confidence)
+	}
 
 	expectedRange := bundles.Range{
-		Start: bundles.Position{Line: 294, Character: 15},
-		End:   bundles.Position{Line: 297, Character: 20},
+		Start: bundles.Position{Line: 302, Character: 15},
+		End:   bundles.Position{Line: 305, Character: 20},
 	}
 	if diff := cmp.Diff(expectedRange, rOut); diff != "" {
 		t.Errorf("unexpected position (-want +got):\n%s", diff)
 	}
 }
 
+func TestAdjustPositionFileChangedTooMuch(t *testing.T) {
+	t.Cleanup(func() {
+		git.Mocks.ExecReader = nil
+	})
+	git.Mocks.ExecReader = mockHugoExecReader(t, 12)
+
+	posIn := bundles.Position{Line: 302, Character: 15}
+
+	adjuster := NewPositionAdjuster(&types.Repo{ID: 50}, "deadbeef1")
+	_, posOut, confidence, ok, err := adjuster.AdjustPosition(context.Background(), "deadbeef2", "/foo/bar.go", posIn, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !ok {
+		t.Errorf("expected translation to succeed")
+	}
+	if confidence != ConfidenceFileChangedTooMuch {
+		t.Errorf("unexpected confidence. want=%s have=%s", ConfidenceFileChangedTooMuch, confidence)
+	}
+
+	expectedPos := bundles.Position{Line: 294, Character: 15}
+	if diff := cmp.Diff(expectedPos, posOut); diff != "" {
+		t.Errorf("unexpected position (-want +got):\n%s", diff)
+	}
+}
+
+func TestAdjustPositionFileAdded(t *testing.T) {
+	t.Cleanup(func() {
+		git.Mocks.ExecReader = nil
+	})
+	git.Mocks.ExecReader = func(args []string) (io.ReadCloser, error) {
+		if args[0] == "show" {
+			return nil, fmt.Errorf("fatal: path '/foo/bar.go' does not exist in 'deadbeef1'")
+		}
+
+		return ioutil.NopCloser(strings.NewReader(addedFileDiff)), nil
+	}
+
+	adjuster := NewPositionAdjuster(&types.Repo{ID: 50}, "deadbeef1")
+	_, posOut, confidence, ok, err := adjuster.AdjustPosition(context.Background(), "deadbeef2", "/foo/bar.go", bundles.Position{Line: 1, Character: 5}, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if ok {
+		t.Errorf("expected translation to fail. have=%v", posOut)
+	}
+	if confidence != ConfidenceFileChangedTooMuch {
+		t.Errorf("unexpected confidence. want=%s have=%s", ConfidenceFileChangedTooMuch, confidence)
+	}
+}
+
+const addedFileDiff = `diff --git a/foo/bar.go b/foo/bar.go
+new file mode 100644
+index 0000000..e69de29
+--- /dev/null
++++ b/foo/bar.go
+@@ -0,0 +1,3 @@
++package foo
++
++func bar() {}
+`
+
+func TestAdjustPositionCachesDiff(t *testing.T) {
+	t.Cleanup(func() {
+		git.Mocks.ExecReader = nil
+	})
+
+	var calls [][]string
+	mock := mockHugoExecReader(t, 400)
+	git.Mocks.ExecReader = func(args []string) (io.ReadCloser, error) {
+		calls = append(calls, args)
+		return mock(args)
+	}
+
+	adjuster := NewPositionAdjuster(&types.Repo{ID: 50}, "deadbeef1")
+	for _, reverse := range []bool{false, true, false, true} {
+		if _, _, _, _, err := adjuster.AdjustPosition(context.Background(), "deadbeef2", "/foo/bar.go", bundles.Position{Line: 10}, reverse); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	expectedCalls := [][]string{
+		{"diff", "deadbeef1", "deadbeef2", "--", "/foo/bar.go"},
+		{"show", "deadbeef1:/foo/bar.go"},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected exec reader calls (-want +got):\n%s", diff)
+	}
+}
+
+func TestInvertHunks(t *testing.T) {
+	for _, testCase := range append(append([]adjustPositionTestCase(nil), hugoTestCases...), prometheusTestCases...) {
+		if !testCase.expectedOk {
+			continue
+		}
+
+		name := fmt.Sprintf("%s : %s", testCase.diffName, testCase.description)
+
+		t.Run(name, func(t *testing.T) {
+			diff, err := diff.NewFileDiffReader(bytes.NewReader([]byte(testCase.diff))).Read()
+			if err != nil {
+				t.Fatalf("unexpected error reading file diff: %s", err)
+			}
+			hunks := invertHunks(diff.Hunks)
+
+			pos := bundles.Position{
+				Line:      testCase.expectedLine - 1, // 1-index -> 0-index
+				Character: 10,
+			}
+
+			// Translating the adjusted line through the inverted hunks should yield the original line
+			if adjusted, ok := adjustPosition(hunks, pos); !ok {
+				t.Errorf("expected translation to succeed")
+			} else if adjusted.Line+1 != testCase.line {
+				t.Errorf("unexpected line. want=%d have=%d", testCase.line, adjusted.Line+1) // 0-index -> 1-index
+			}
+		})
+	}
+}
+
+// mockHugoExecReader returns a git exec reader mock that returns hugoDiff for the diff between
+// deadbeef1 and deadbeef2 and a file with the given number of lines at deadbeef1.
+func mockHugoExecReader(t *testing.T, lines int) func(args []string) (io.ReadCloser, error) {
+	return func(args []string) (io.ReadCloser, error) {
+		if len(args) > 0 && args[0] == "show" {
+			expectedArgs := []string{"show", "deadbeef1:/foo/bar.go"}
+			if diff := cmp.Diff(expectedArgs, args); diff != "" {
+				t.Errorf("unexpected exec reader args (-want +got):\n%s", diff)
+			}
+
+			return ioutil.NopCloser(strings.NewReader(strings.Repeat("line\n", lines))), nil
+		}
+
+		expectedArgs := []string{"diff", "deadbeef1", "deadbeef2", "--", "/foo/bar.go"}
+		if diff := cmp.Diff(expectedArgs, args); diff != "" {
+			t.Errorf("unexpected exec reader args (-want +got):\n%s", diff)
+		}
+
+		return ioutil.NopCloser(bytes.NewReader([]byte(hugoDiff))), nil
+	}
+}
+
 type adjustPositionTestCase struct {
 	diff         string // The git diff output
 	diffName     string // The git diff output name
@@ -17,6 +17,7 @@ type AdjustedLocation struct {
 	Path           string
 	AdjustedCommit string
 	AdjustedRange  bundles.Range
+	Confidence     AdjustmentConfidence
 }
 
 // AdjustedDiagnostic is similar to a codeintelapi.ResolvedDiagnostic, but with fields denoting
@@ -26,6 +27,7 @@ type AdjustedDiagnostic struct {
 	Dump           store.Dump
 	AdjustedCommit string
 	AdjustedRange  bundles.Range
+	Confidence     AdjustmentConfidence
 }
 
 // QueryResolver is the main interface to bundle-related operations exposed to the GraphQL API.
@@ -36,7 +38,8 @@ type AdjustedDiagnostic struct {
 type QueryResolver interface {
 	Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
 	References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
-	Hover(ctx context.Context, line, character int) (string, bundles.Range, bool, error)
+	Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+	Hover(ctx context.Context, line, character int) (string, bundles.Range, AdjustmentConfidence, bool, error)
 	Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error)
 }
 
@@ -49,11 +52,14 @@ type queryResolver struct {
 	commit              string
 	path                string
 	uploads             []store.Dump
+	onlyExact           bool
 }
 
 // NewQueryResolver create a new query resolver with the given services. The methods of this
 // struct return queries for the given repository, commit, and path, and will query only the
-// bundles associated with the given dump objects.
+// bundles associated with the given dump objects. If onlyExact is true, results are returned only
+// from uploads for which the requested position did not need to be adjusted, and locations that
+// cannot be adjusted exactly are returned relative to the commit of the upload that produced them.
 func NewQueryResolver(
 	store store.Store,
 	bundleManagerClient bundles.BundleManagerClient,
@@ -63,6 +69,7 @@ func NewQueryResolver(
 	commit string,
 	path string,
 	uploads []store.Dump,
+	onlyExact bool,
 ) QueryResolver {
 	return &queryResolver{
 		store:               store,
@@ -73,9 +80,15 @@ func NewQueryResolver(
 		commit:              commit,
 		path:                path,
 		uploads:             uploads,
+		onlyExact:           onlyExact,
 	}
 }
 
+// acceptable returns true if a result adjusted with the given confidence can be returned.
+func (r *queryResolver) acceptable(confidence AdjustmentConfidence) bool {
+	return !r.onlyExact || confidence == ConfidenceExact
+}
+
 // Definitions returns the list of source locations that define the symbol at the given position.
 // This may include remote definitions if the remote repository is also indexed. If there are multiple
 // bundles associated with this resolver, the definitions from the first bundle with any results will
@@ -84,11 +97,11 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) ([
 	position := bundles.Position{Line: line, Character: character}
 
 	for i := range r.uploads {
-		adjustedPath, adjustedPosition, ok, err := r.positionAdjuster.AdjustPosition(ctx, r.uploads[i].Commit, r.path, position, false)
+		adjustedPath, adjustedPosition, confidence, ok, err := r.positionAdjuster.AdjustPosition(ctx, r.uploads[i].Commit, r.path, position, false)
 		if err != nil {
 			return nil, err
 		}
-		if !ok {
+		if !ok || !r.acceptable(confidence) {
 			continue
 		}
 
@@ -100,7 +113,7 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) ([
 			continue
 		}
 
-		return r.adjustLocations(ctx, locations)
+		return r.adjustLocations(ctx, locations, confidence)
 	}
 
 	return nil, nil
@@ -110,6 +123,25 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) ([
 // This may include references from other dumps and repositories. If there are multiple bundles
 // associated with this resolver, results from all bundles will be concatenated and returned.
 func (r *queryResolver) References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
+	return r.pageLocations(ctx, line, character, limit, rawCursor, r.codeIntelAPI.References)
+}
+
+// Implementations returns the list of source locations that implement the symbol at the given
+// position. This may include implementations from other dumps and repositories. If there are
+// multiple bundles associated with this resolver, results from all bundles will be concatenated
+// and returned.
+func (r *queryResolver) Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
+	return r.pageLocations(ctx, line, character, limit, rawCursor, r.codeIntelAPI.Implementations)
+}
+
+// pageFunc returns a page of locations for the given cursor. Both CodeIntelAPI.References and
+// CodeIntelAPI.Implementations satisfy this signature.
+type pageFunc func(ctx context.Context, repositoryID int, commit string, limit int, cursor codeintelapi.Cursor) ([]codeintelapi.ResolvedLocation, codeintelapi.Cursor, bool, error)
+
+// pageLocations returns the next page of locations from each upload associated with this resolver
+// using the given page function. The returned cursor encodes the next cursor of every upload with
+// remaining results.
+func (r *queryResolver) pageLocations(ctx context.Context, line, character, limit int, rawCursor string, page pageFunc) ([]AdjustedLocation, string, error) {
 	position := bundles.Position{Line: line, Character: character}
 
 	// Decode a map of upload ids to the next url that serves
@@ -126,7 +158,7 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 	// this request.
 	newCursors := map[int]string{}
 
-	var allLocations []codeintelapi.ResolvedLocation
+	var allLocations []AdjustedLocation
 	for i := range r.uploads {
 		rawCursor := ""
 		if cursor, ok := cursors[r.uploads[i].ID]; ok {
@@ -138,11 +170,11 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 			continue
 		}
 
-		adjustedPath, adjustedPosition, ok, err := r.positionAdjuster.AdjustPosition(ctx, r.uploads[i].Commit, r.path, position, false)
+		adjustedPath, adjustedPosition, confidence, ok, err := r.positionAdjuster.AdjustPosition(ctx, r.uploads[i].Commit, r.path, position, false)
 		if err != nil {
 			return nil, "", err
 		}
-		if !ok {
+		if !ok || !r.acceptable(confidence) {
 			continue
 		}
 
@@ -151,12 +183,17 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 			return nil, "", err
 		}
 
-		locations, newCursor, hasNewCursor, err := r.codeIntelAPI.References(ctx, r.repositoryID, r.commit, limit, cursor)
+		locations, newCursor, hasNewCursor, err := page(ctx, r.repositoryID, r.commit, limit, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		adjustedLocations, err := r.adjustLocations(ctx, locations, confidence)
 		if err != nil {
 			return nil, "", err
 		}
 
-		allLocations = append(allLocations, locations...)
+		allLocations = append(allLocations, adjustedLocations...)
 		if hasNewCursor {
 			newCursors[r.uploads[i].ID] = codeintelapi.EncodeCursor(newCursor)
 		}
@@ -167,41 +204,37 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 		return nil, "", err
 	}
 
-	adjustedLocations, err := r.adjustLocations(ctx, allLocations)
-	if err != nil {
-		return nil, "", err
-	}
-
-	return adjustedLocations, endCursor, nil
+	return allLocations, endCursor, nil
 }
 
 // Hover returns the hover text and range for the symbol at the given position. If there are
 // multiple bundles associated with this resolver, the hover text and range from the first
-// bundle with any results will be returned.
-func (r *queryResolver) Hover(ctx context.Context, line, character int) (string, bundles.Range, bool, error) {
+// bundle with any results will be returned. The returned confidence is the lower of the confidences
+// of the adjusted hover position and the adjusted hover range.
+func (r *queryResolver) Hover(ctx context.Context, line, character int) (string, bundles.Range, AdjustmentConfidence, bool, error) {
 	position := bundles.Position{Line: line, Character: character}
 
 	for i := range r.uploads {
-		adjustedPath, adjustedPosition, ok, err := r.positionAdjuster.AdjustPosition(ctx, r.uploads[i].Commit, r.path, position, false)
+		adjustedPath, adjustedPosition, confidence, ok, err := r.positionAdjuster.AdjustPosition(ctx, r.uploads[i].Commit, r.path, position, false)
 		if err != nil {
-			return "", bundles.Range{}, false, err
+			return "", bundles.Range{}, 0, false, err
 		}
-		if !ok {
+		if !ok || !r.acceptable(confidence) {
 			continue
 		}
 
 		text, rn, exists, err := r.codeIntelAPI.Hover(ctx, adjustedPath, adjustedPosition.Line, adjustedPosition.Character, r.uploads[i].ID)
 		if err != nil {
-			return "", bundles.Range{}, false, err
+			return "", bundles.Range{}, 0, false, err
 		}
 		if !exists || text == "" {
 			continue
 		}
 
-		if _, adjustedRange, ok, err := r.positionAdjuster.AdjustRange(ctx, r.uploads[i].Commit, r.path, rn, true); err != nil {
-			return "", bundles.Range{}, false, err
-		} else if ok {
-			return text, adjustedRange, true, nil
+		if _, adjustedRange, rangeConfidence, ok, err := r.positionAdjuster.AdjustRange(ctx, r.uploads[i].Commit, r.path, rn, true); err != nil {
+			return "", bundles.Range{}, 0, false, err
+		} else if ok && r.acceptable(rangeConfidence) {
+			return text, adjustedRange, lowestConfidence(confidence, rangeConfidence), true, nil
 		}
 
 		// Failed to adjust range. This _might_ happen in cases where the LSIF range
@@ -212,7 +245,7 @@ func (r *queryResolver) Hover(ctx context.Context, line, character int) (string,
 		continue
 	}
 
-	return "", bundles.Range{}, false, nil
+	return "", bundles.Range{}, 0, false, nil
 }
 
 // Diagnostics returns the diagnostics for documents with the given path prefix. If there are
@@ -251,7 +284,7 @@ func (r *queryResolver) Diagnostics(ctx context.Context, limit int) ([]AdjustedD
 			End:   client.Position{Line: allDiagnostics[i].Diagnostic.EndLine, Character: allDiagnostics[i].Diagnostic.EndCharacter},
 		}
 
-		adjustedCommit, adjustedRange, err := r.adjustRange(ctx, allDiagnostics[i].Dump.RepositoryID, allDiagnostics[i].Dump.Commit, allDiagnostics[i].Diagnostic.Path, clientRange)
+		adjustedCommit, adjustedRange, confidence, err := r.adjustRange(ctx, allDiagnostics[i].Dump.RepositoryID, allDiagnostics[i].Dump.Commit, allDiagnostics[i].Diagnostic.Path, clientRange)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -261,6 +294,7 @@ func (r *queryResolver) Diagnostics(ctx context.Context, limit int) ([]AdjustedD
 			Dump:           allDiagnostics[i].Dump,
 			AdjustedCommit: adjustedCommit,
 			AdjustedRange:  adjustedRange,
+			Confidence:     confidence,
 		})
 	}
 
@@ -268,11 +302,12 @@ func (r *queryResolver) Diagnostics(ctx context.Context, limit int) ([]AdjustedD
 }
 
 // adjustLocations translates a list of resolved locations (relative to the indexed commit) into a list of
-// equivalent locations in the requested commit.
-func (r *queryResolver) adjustLocations(ctx context.Context, locations []codeintelapi.ResolvedLocation) ([]AdjustedLocation, error) {
+// equivalent locations in the requested commit. The confidence of each location is no higher than the given
+// confidence of the adjusted query position.
+func (r *queryResolver) adjustLocations(ctx context.Context, locations []codeintelapi.ResolvedLocation, queryConfidence AdjustmentConfidence) ([]AdjustedLocation, error) {
 	adjustedLocations := make([]AdjustedLocation, 0, len(locations))
 	for i := range locations {
-		adjustedCommit, adjustedRange, err := r.adjustRange(ctx, locations[i].Dump.RepositoryID, locations[i].Dump.Commit, locations[i].Path, locations[i].Range)
+		adjustedCommit, adjustedRange, confidence, err := r.adjustRange(ctx, locations[i].Dump.RepositoryID, locations[i].Dump.Commit, locations[i].Path, locations[i].Range)
 		if err != nil {
 			return nil, err
 		}
@@ -282,6 +317,7 @@ func (r *queryResolver) adjustLocations(ctx context.Context, locations []codeint
 			Path:           locations[i].Path,
 			AdjustedCommit: adjustedCommit,
 			AdjustedRange:  adjustedRange,
+			Confidence:     lowestConfidence(queryConfidence, confidence),
 		})
 	}
 
@@ -289,19 +325,21 @@ func (r *queryResolver) adjustLocations(ctx context.Context, locations []codeint
 }
 
 // adjustRange translates a range (relative to the indexed commit) into an equivalent range in the requested commit.
-func (r *queryResolver) adjustRange(ctx context.Context, repositoryID int, commit, path string, rx bundles.Range) (string, bundles.Range, error) {
+// If the range cannot be translated with an acceptable confidence, the original range in the indexed commit, which
+// is exact, is returned instead.
+func (r *queryResolver) adjustRange(ctx context.Context, repositoryID int, commit, path string, rx bundles.Range) (string, bundles.Range, AdjustmentConfidence, error) {
 	if repositoryID != r.repositoryID {
 		// No diffs exist for translation between repos
-		return commit, rx, nil
+		return commit, rx, ConfidenceExact, nil
 	}
 
-	if _, adjustedRange, ok, err := r.positionAdjuster.AdjustRange(ctx, commit, path, rx, true); err != nil {
-		return "", bundles.Range{}, err
-	} else if ok {
-		return r.commit, adjustedRange, nil
+	if _, adjustedRange, confidence, ok, err := r.positionAdjuster.AdjustRange(ctx, commit, path, rx, true); err != nil {
+		return "", bundles.Range{}, 0, err
+	} else if ok && r.acceptable(confidence) {
+		return r.commit, adjustedRange, confidence, nil
 	}
 
-	return commit, rx, nil
+	return commit, rx, ConfidenceExact, nil
 }
 
 // readCursor decodes a cursor into a map from upload ids to URLs that serves the next page of results.
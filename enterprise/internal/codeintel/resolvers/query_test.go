@@ -20,10 +20,10 @@ func TestDefinitions(t *testing.T) {
 	mockPositionAdjuster := NewMockPositionAdjuster()
 
 	// position can be translated for subsequent dumps
-	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("", bundles.Position{Line: 20, Character: 15}, true, nil)
+	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("", bundles.Position{Line: 20, Character: 15}, ConfidenceExact, true, nil)
 
 	// first requested dump (dump 42) has no equivalent position
-	mockPositionAdjuster.AdjustPositionFunc.PushReturn("", bundles.Position{}, false, nil)
+	mockPositionAdjuster.AdjustPositionFunc.PushReturn("", bundles.Position{}, 0, false, nil)
 
 	mockCodeIntelAPI.DefinitionsFunc.SetDefaultReturn([]codeintelapi.ResolvedLocation{
 		{
@@ -55,11 +55,11 @@ func TestDefinitions(t *testing.T) {
 	// first requested dump (dump 43) has no definitions
 	mockCodeIntelAPI.DefinitionsFunc.PushReturn(nil, nil)
 
-	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, bool, error) {
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, AdjustmentConfidence, bool, error) {
 		return path, bundles.Range{
 			Start: bundles.Position{Line: r.Start.Line * 10, Character: r.Start.Character * 10},
 			End:   bundles.Position{Line: r.End.Line * 10, Character: r.End.Character * 10},
-		}, true, nil
+		}, ConfidenceAdjusted, true, nil
 	})
 
 	queryResolver := NewQueryResolver(
@@ -76,6 +76,7 @@ func TestDefinitions(t *testing.T) {
 			{ID: 44, RepositoryID: 50, Commit: "deadbeef1"},
 			{ID: 45, RepositoryID: 50, Commit: "deadbeef1"},
 		},
+		false,
 	)
 
 	definitions, err := queryResolver.Definitions(context.Background(), 10, 15)
@@ -92,6 +93,7 @@ func TestDefinitions(t *testing.T) {
 				Start: bundles.Position{Line: 110, Character: 120},
 				End:   bundles.Position{Line: 130, Character: 140},
 			},
+			Confidence: ConfidenceAdjusted,
 		},
 		{
 			Dump:           store.Dump{ID: 44, RepositoryID: 50},
@@ -101,6 +103,7 @@ func TestDefinitions(t *testing.T) {
 				Start: bundles.Position{Line: 210, Character: 220},
 				End:   bundles.Position{Line: 230, Character: 240},
 			},
+			Confidence: ConfidenceAdjusted,
 		},
 		{
 			Dump:           store.Dump{ID: 44, RepositoryID: 50},
@@ -110,6 +113,101 @@ func TestDefinitions(t *testing.T) {
 				Start: bundles.Position{Line: 310, Character: 320},
 				End:   bundles.Position{Line: 330, Character: 340},
 			},
+			Confidence: ConfidenceAdjusted,
+		},
+	}
+	if diff := cmp.Diff(expectedDefinitions, definitions); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+}
+
+func TestDefinitionsOnlyExact(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockCodeIntelAPI := apimocks.NewMockCodeIntelAPI()
+	mockPositionAdjuster := NewMockPositionAdjuster()
+
+	// position is unchanged for subsequent dumps
+	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("", bundles.Position{Line: 10, Character: 15}, ConfidenceExact, true, nil)
+
+	// first requested dump (dump 42) is from a file that changed too much
+	mockPositionAdjuster.AdjustPositionFunc.PushReturn("", bundles.Position{Line: 20, Character: 15}, ConfidenceFileChangedTooMuch, true, nil)
+
+	mockCodeIntelAPI.DefinitionsFunc.SetDefaultReturn([]codeintelapi.ResolvedLocation{
+		{
+			Dump: store.Dump{ID: 43, RepositoryID: 50, Commit: "deadbeef1"},
+			Path: "p1.go",
+			Range: bundles.Range{
+				Start: bundles.Position{Line: 11, Character: 12},
+				End:   bundles.Position{Line: 13, Character: 14},
+			},
+		},
+		{
+			Dump: store.Dump{ID: 43, RepositoryID: 50, Commit: "deadbeef1"},
+			Path: "p2.go",
+			Range: bundles.Range{
+				Start: bundles.Position{Line: 21, Character: 22},
+				End:   bundles.Position{Line: 23, Character: 24},
+			},
+		},
+	}, nil)
+
+	// first location is unchanged, second location was moved
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, commit, path string, r bundles.Range, reverse bool) (string, bundles.Range, AdjustmentConfidence, bool, error) {
+		if path == "p1.go" {
+			return path, r, ConfidenceExact, true, nil
+		}
+
+		return path, bundles.Range{
+			Start: bundles.Position{Line: r.Start.Line * 10, Character: r.Start.Character * 10},
+			End:   bundles.Position{Line: r.End.Line * 10, Character: r.End.Character * 10},
+		}, ConfidenceAdjusted, true, nil
+	})
+
+	queryResolver := NewQueryResolver(
+		mockStore,
+		mockBundleManagerClient,
+		mockCodeIntelAPI,
+		mockPositionAdjuster,
+		50,
+		"deadbeef2",
+		"/foo/bar.go",
+		[]store.Dump{
+			{ID: 42, RepositoryID: 50, Commit: "deadbeef1"},
+			{ID: 43, RepositoryID: 50, Commit: "deadbeef1"},
+		},
+		true,
+	)
+
+	definitions, err := queryResolver.Definitions(context.Background(), 10, 15)
+	if err != nil {
+		t.Fatalf("unexpected error resolving definitions: %s", err)
+	}
+
+	if history := mockCodeIntelAPI.DefinitionsFunc.History(); len(history) != 1 || history[0].Arg4 != 43 {
+		t.Errorf("expected definitions to be requested only from dump 43")
+	}
+
+	expectedDefinitions := []AdjustedLocation{
+		{
+			Dump:           store.Dump{ID: 43, RepositoryID: 50, Commit: "deadbeef1"},
+			Path:           "p1.go",
+			AdjustedCommit: "deadbeef2",
+			AdjustedRange: bundles.Range{
+				Start: bundles.Position{Line: 11, Character: 12},
+				End:   bundles.Position{Line: 13, Character: 14},
+			},
+			Confidence: ConfidenceExact,
+		},
+		{
+			Dump:           store.Dump{ID: 43, RepositoryID: 50, Commit: "deadbeef1"},
+			Path:           "p2.go",
+			AdjustedCommit: "deadbeef1",
+			AdjustedRange: bundles.Range{
+				Start: bundles.Position{Line: 21, Character: 22},
+				End:   bundles.Position{Line: 23, Character: 24},
+			},
+			Confidence: ConfidenceExact,
 		},
 	}
 	if diff := cmp.Diff(expectedDefinitions, definitions); diff != "" {
@@ -133,10 +231,10 @@ func TestReferences(t *testing.T) {
 	mockBundleClient.MonikersByPositionFunc.SetDefaultReturn([][]bundles.MonikerData{{testMoniker1, testMoniker2}}, nil)
 
 	// position can be translated for subsequent dumps
-	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("", bundles.Position{Line: 20, Character: 15}, true, nil)
+	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("", bundles.Position{Line: 20, Character: 15}, ConfidenceExact, true, nil)
 
 	// first requested dump (dump 42) has no equivalent position
-	mockPositionAdjuster.AdjustPositionFunc.PushReturn("", bundles.Position{}, false, nil)
+	mockPositionAdjuster.AdjustPositionFunc.PushReturn("", bundles.Position{}, 0, false, nil)
 
 	// default behavior is empty result set
 	mockCodeIntelAPI.ReferencesFunc.SetDefaultReturn(nil, codeintelapi.Cursor{}, false, nil)
@@ -185,11 +283,11 @@ func TestReferences(t *testing.T) {
 		},
 	}, cursorOut3, true, nil)
 
-	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, bool, error) {
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, AdjustmentConfidence, bool, error) {
 		return path, bundles.Range{
 			Start: bundles.Position{Line: r.Start.Line * 10, Character: r.Start.Character * 10},
 			End:   bundles.Position{Line: r.End.Line * 10, Character: r.End.Character * 10},
-		}, true, nil
+		}, ConfidenceAdjusted, true, nil
 	})
 
 	queryResolver := NewQueryResolver(
@@ -207,6 +305,7 @@ func TestReferences(t *testing.T) {
 			{ID: 45, RepositoryID: 50, Commit: "deadbeef1"},
 			{ID: 46, RepositoryID: 50, Commit: "deadbeef1"},
 		},
+		false,
 	)
 
 	cursor, err := makeCursor(map[int]string{
@@ -246,6 +345,7 @@ func TestReferences(t *testing.T) {
 				Start: bundles.Position{Line: 110, Character: 120},
 				End:   bundles.Position{Line: 130, Character: 140},
 			},
+			Confidence: ConfidenceAdjusted,
 		},
 		{
 			Dump:           store.Dump{ID: 44, RepositoryID: 50},
@@ -255,6 +355,7 @@ func TestReferences(t *testing.T) {
 				Start: bundles.Position{Line: 210, Character: 220},
 				End:   bundles.Position{Line: 230, Character: 240},
 			},
+			Confidence: ConfidenceAdjusted,
 		},
 		{
 			Dump:           store.Dump{ID: 46, RepositoryID: 50},
@@ -264,6 +365,7 @@ func TestReferences(t *testing.T) {
 				Start: bundles.Position{Line: 310, Character: 320},
 				End:   bundles.Position{Line: 330, Character: 340},
 			},
+			Confidence: ConfidenceAdjusted,
 		},
 	}
 	if diff := cmp.Diff(expectedReferences, references); diff != "" {
@@ -293,6 +395,96 @@ func TestReferences(t *testing.T) {
 	}
 }
 
+func TestImplementations(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient := bundlemocks.NewMockBundleClient()
+	mockCodeIntelAPI := apimocks.NewMockCodeIntelAPI()
+	mockPositionAdjuster := NewMockPositionAdjuster()
+
+	testMoniker := bundles.MonikerData{Kind: "import", Scheme: "gomod", Identifier: "pad", PackageInformationID: "1234"}
+
+	// Cursor decoding
+	mockStore.GetDumpByIDFunc.SetDefaultHook(func(ctx context.Context, id int) (store.Dump, bool, error) { return store.Dump{ID: id}, true, nil })
+	mockBundleManagerClient.BundleClientFunc.SetDefaultReturn(mockBundleClient)
+	mockBundleClient.MonikersByPositionFunc.SetDefaultReturn([][]bundles.MonikerData{{testMoniker}}, nil)
+	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("", bundles.Position{Line: 20, Character: 15}, ConfidenceExact, true, nil)
+
+	cursorOut := codeintelapi.Cursor{Phase: "p2"}
+
+	// first requested dump (dump 42) returns partial implementations
+	mockCodeIntelAPI.ImplementationsFunc.PushReturn([]codeintelapi.ResolvedLocation{
+		{
+			Dump: store.Dump{ID: 42, RepositoryID: 50},
+			Path: "p1.go",
+			Range: bundles.Range{
+				Start: bundles.Position{Line: 11, Character: 12},
+				End:   bundles.Position{Line: 13, Character: 14},
+			},
+		},
+	}, cursorOut, true, nil)
+
+	// second requested dump (dump 43) has no implementations
+	mockCodeIntelAPI.ImplementationsFunc.PushReturn(nil, codeintelapi.Cursor{}, false, nil)
+
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, AdjustmentConfidence, bool, error) {
+		return path, r, ConfidenceExact, true, nil
+	})
+
+	queryResolver := NewQueryResolver(
+		mockStore,
+		mockBundleManagerClient,
+		mockCodeIntelAPI,
+		mockPositionAdjuster,
+		50,
+		"deadbeef2",
+		"/foo/bar.go",
+		[]store.Dump{
+			{ID: 42, RepositoryID: 50, Commit: "deadbeef1"},
+			{ID: 43, RepositoryID: 50, Commit: "deadbeef1"},
+		},
+		false,
+	)
+
+	implementations, nextCursor, err := queryResolver.Implementations(context.Background(), 10, 15, 3, "")
+	if err != nil {
+		t.Fatalf("unexpected error resolving implementations: %s", err)
+	}
+
+	expectedCursor, err := makeCursor(map[int]string{
+		42: codeintelapi.EncodeCursor(cursorOut),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating cursor: %s", err)
+	}
+
+	if nextCursor != expectedCursor {
+		t.Errorf("unexpected cursor. want=%q have=%q", expectedCursor, nextCursor)
+	}
+
+	expectedImplementations := []AdjustedLocation{
+		{
+			Dump:           store.Dump{ID: 42, RepositoryID: 50},
+			Path:           "p1.go",
+			AdjustedCommit: "deadbeef2",
+			AdjustedRange: bundles.Range{
+				Start: bundles.Position{Line: 11, Character: 12},
+				End:   bundles.Position{Line: 13, Character: 14},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedImplementations, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+
+	if val := len(mockCodeIntelAPI.ImplementationsFunc.History()); val != 2 {
+		t.Errorf("unexpected call count. want=%d have=%d", 2, val)
+	}
+	if val := len(mockCodeIntelAPI.ReferencesFunc.History()); val != 0 {
+		t.Errorf("unexpected call count. want=%d have=%d", 0, val)
+	}
+}
+
 func TestHover(t *testing.T) {
 	mockStore := storemocks.NewMockStore()
 	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
@@ -300,10 +492,10 @@ func TestHover(t *testing.T) {
 	mockPositionAdjuster := NewMockPositionAdjuster()
 
 	// position can be translated for subsequent dumps
-	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("", bundles.Position{Line: 20, Character: 15}, true, nil)
+	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("", bundles.Position{Line: 20, Character: 15}, ConfidenceExact, true, nil)
 
 	// first requested dump (dump 42) has no equivalent position
-	mockPositionAdjuster.AdjustPositionFunc.PushReturn("", bundles.Position{}, false, nil)
+	mockPositionAdjuster.AdjustPositionFunc.PushReturn("", bundles.Position{}, 0, false, nil)
 
 	mockCodeIntelAPI.HoverFunc.SetDefaultReturn("hover text", bundles.Range{
 		Start: bundles.Position{Line: 11, Character: 12},
@@ -313,11 +505,11 @@ func TestHover(t *testing.T) {
 	// first requested dump (dump 43) has no defined hover
 	mockCodeIntelAPI.HoverFunc.PushReturn("", bundles.Range{}, false, nil)
 
-	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, bool, error) {
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, AdjustmentConfidence, bool, error) {
 		return path, bundles.Range{
 			Start: bundles.Position{Line: r.Start.Line * 10, Character: r.Start.Character * 10},
 			End:   bundles.Position{Line: r.End.Line * 10, Character: r.End.Character * 10},
-		}, true, nil
+		}, ConfidenceAdjusted, true, nil
 	})
 
 	queryResolver := NewQueryResolver(
@@ -334,9 +526,10 @@ func TestHover(t *testing.T) {
 			{ID: 44, RepositoryID: 50, Commit: "deadbeef1"},
 			{ID: 45, RepositoryID: 50, Commit: "deadbeef1"},
 		},
+		false,
 	)
 
-	text, r, ok, err := queryResolver.Hover(context.Background(), 10, 15)
+	text, r, confidence, ok, err := queryResolver.Hover(context.Background(), 10, 15)
 	if err != nil {
 		t.Fatalf("unexpected error resolving hover: %s", err)
 	}
@@ -347,6 +540,9 @@ func TestHover(t *testing.T) {
 	if text != "hover text" {
 		t.Errorf("unexpected text. want=%q have=%q", "hover text", text)
 	}
+	if confidence != ConfidenceAdjusted {
+		t.Errorf("unexpected confidence. want=%s have=%s", ConfidenceAdjusted, confidence)
+	}
 
 	expectedRange := bundles.Range{
 		Start: bundles.Position{Line: 110, Character: 120},
@@ -422,11 +618,11 @@ func TestDiagnostics(t *testing.T) {
 	// third requested dump (dump 45) returns only total count
 	mockCodeIntelAPI.DiagnosticsFunc.SetDefaultReturn(nil, 3, nil)
 
-	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, bool, error) {
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, AdjustmentConfidence, bool, error) {
 		return path, bundles.Range{
 			Start: bundles.Position{Line: r.Start.Line * 10, Character: r.Start.Character * 10},
 			End:   bundles.Position{Line: r.End.Line * 10, Character: r.End.Character * 10},
-		}, true, nil
+		}, ConfidenceAdjusted, true, nil
 	})
 
 	queryResolver := NewQueryResolver(
@@ -443,6 +639,7 @@ func TestDiagnostics(t *testing.T) {
 			{ID: 44, RepositoryID: 50, Commit: "deadbeef1"},
 			{ID: 45, RepositoryID: 50, Commit: "deadbeef1"},
 		},
+		false,
 	)
 
 	diagnostics, totalCount, err := queryResolver.Diagnostics(context.Background(), 3)
@@ -473,6 +670,7 @@ func TestDiagnostics(t *testing.T) {
 				Start: bundles.Position{Line: 110, Character: 120},
 				End:   bundles.Position{Line: 130, Character: 140},
 			},
+			Confidence: ConfidenceAdjusted,
 		},
 		{
 			Diagnostic: bundles.Diagnostic{
@@ -492,6 +690,7 @@ func TestDiagnostics(t *testing.T) {
 				Start: bundles.Position{Line: 210, Character: 220},
 				End:   bundles.Position{Line: 230, Character: 240},
 			},
+			Confidence: ConfidenceAdjusted,
 		},
 		{
 			Diagnostic: bundles.Diagnostic{
@@ -511,6 +710,7 @@ func TestDiagnostics(t *testing.T) {
 				Start: bundles.Position{Line: 310, Character: 320},
 				End:   bundles.Position{Line: 330, Character: 340},
 			},
+			Confidence: ConfidenceAdjusted,
 		},
 	}
 	if diff := cmp.Diff(expectedDiagnostics, diagnostics); diff != "" {
@@ -21,7 +21,11 @@ type Resolver interface {
 	GetIndexByID(ctx context.Context, id int) (store.Index, bool, error)
 	UploadConnectionResolver(opts store.GetUploadsOptions) *UploadsResolver
 	IndexConnectionResolver(opts store.GetIndexesOptions) *IndexesResolver
+	PackageDependentsConnectionResolver(opts store.GetPackageDependentsOptions) *PackageUsagesResolver
+	PackageDependenciesConnectionResolver(opts store.GetPackageDependenciesOptions) *PackageUsagesResolver
+	GetPackageProvider(ctx context.Context, scheme, name, version string) (store.Upload, bool, error)
 	DeleteUploadByID(ctx context.Context, uploadID int) error
+	UploadRetentionPreview(ctx context.Context, repositoryID int) ([]UploadExpiration, error)
 	DeleteIndexByID(ctx context.Context, id int) error
 	QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (QueryResolver, error)
 }
@@ -57,6 +61,25 @@ func (r *resolver) IndexConnectionResolver(opts store.GetIndexesOptions) *Indexe
 	return NewIndexesResolver(r.store, opts)
 }
 
+func (r *resolver) PackageDependentsConnectionResolver(opts store.GetPackageDependentsOptions) *PackageUsagesResolver {
+	return NewPackageDependentsResolver(r.store, opts)
+}
+
+func (r *resolver) PackageDependenciesConnectionResolver(opts store.GetPackageDependenciesOptions) *PackageUsagesResolver {
+	return NewPackageDependenciesResolver(r.store, opts)
+}
+
+// GetPackageProvider returns the most recent upload that provides the package with the given
+// scheme, name, and version and a flag indicating its existence.
+func (r *resolver) GetPackageProvider(ctx context.Context, scheme, name, version string) (store.Upload, bool, error) {
+	dump, exists, err := r.store.GetPackage(ctx, scheme, name, version)
+	if err != nil || !exists {
+		return store.Upload{}, false, err
+	}
+
+	return r.store.GetUploadByID(ctx, dump.ID)
+}
+
 func (r *resolver) DeleteUploadByID(ctx context.Context, uploadID int) error {
 	_, err := r.store.DeleteUploadByID(ctx, uploadID, r.getTipCommit)
 	return err
@@ -92,6 +115,7 @@ func (r *resolver) QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataA
 		string(args.Commit),
 		args.Path,
 		dumps,
+		args.OnlyExact,
 	), nil
 }
 
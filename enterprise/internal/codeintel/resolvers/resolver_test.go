@@ -4,10 +4,12 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	apimocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/api/mocks"
 	bundlemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 )
@@ -33,3 +35,50 @@ func TestQueryResolver(t *testing.T) {
 		t.Errorf("expected nil-valued resolver")
 	}
 }
+
+func TestPackageDependentsConnectionResolver(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockStore.GetPackageDependentsFunc.SetDefaultReturn([]store.PackageUsage{{DumpID: 1}, {DumpID: 2}}, 5, nil)
+
+	resolver := NewResolver(mockStore, bundlemocks.NewMockBundleManagerClient(), apimocks.NewMockCodeIntelAPI())
+	opts := store.GetPackageDependentsOptions{RepositoryID: 50, Name: "leftpad", Version: "0.1.0", Limit: 2, Offset: 1}
+	usagesResolver := resolver.PackageDependentsConnectionResolver(opts)
+
+	if err := usagesResolver.Resolve(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(usagesResolver.Usages) != 2 {
+		t.Errorf("unexpected usage count. want=%d have=%d", 2, len(usagesResolver.Usages))
+	}
+	if usagesResolver.TotalCount != 5 {
+		t.Errorf("unexpected total count. want=%d have=%d", 5, usagesResolver.TotalCount)
+	}
+	if usagesResolver.NextOffset == nil || *usagesResolver.NextOffset != 3 {
+		t.Errorf("unexpected next offset. want=%d have=%v", 3, usagesResolver.NextOffset)
+	}
+
+	if len(mockStore.GetPackageDependentsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockStore.GetPackageDependentsFunc.History()))
+	}
+	if diff := cmp.Diff(opts, mockStore.GetPackageDependentsFunc.History()[0].Arg1); diff != "" {
+		t.Errorf("unexpected opts (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetPackageProvider(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockStore.GetPackageFunc.SetDefaultReturn(store.Dump{ID: 42}, true, nil)
+	mockStore.GetUploadByIDFunc.SetDefaultReturn(store.Upload{ID: 42}, true, nil)
+
+	resolver := NewResolver(mockStore, bundlemocks.NewMockBundleManagerClient(), apimocks.NewMockCodeIntelAPI())
+	upload, exists, err := resolver.GetPackageProvider(context.Background(), "gomod", "leftpad", "0.1.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !exists || upload.ID != 42 {
+		t.Errorf("unexpected provider. want=%d have=%d (exists=%v)", 42, upload.ID, exists)
+	}
+	if val := mockStore.GetUploadByIDFunc.History()[0].Arg1; val != 42 {
+		t.Errorf("unexpected upload id. want=%d have=%d", 42, val)
+	}
+}
@@ -0,0 +1,55 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/retention"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// UploadExpiration is an upload that is no longer retained by the retention policy of its repository.
+type UploadExpiration struct {
+	Upload store.Upload
+	Reason string
+}
+
+// UploadRetentionPreview returns the uploads of the given repository that would be removed by the
+// currently configured retention policy. No uploads are removed.
+func (r *resolver) UploadRetentionPreview(ctx context.Context, repositoryID int) ([]UploadExpiration, error) {
+	repositoryName, err := r.store.RepoName(ctx, repositoryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.RepoName")
+	}
+
+	policy, ok, err := retention.PolicyForRepository(conf.Get().CodeIntelRetentionPolicies, repositoryName)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	expirations, err := retention.ExpiredUploads(ctx, r.store, gitserver.DefaultClient, repositoryID, policy, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, "retention.ExpiredUploads")
+	}
+
+	uploadExpirations := make([]UploadExpiration, 0, len(expirations))
+	for _, expiration := range expirations {
+		upload, exists, err := r.store.GetUploadByID(ctx, expiration.Dump.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "store.GetUploadByID")
+		}
+		if !exists {
+			continue
+		}
+
+		uploadExpirations = append(uploadExpirations, UploadExpiration{
+			Upload: upload,
+			Reason: string(expiration.Reason),
+		})
+	}
+
+	return uploadExpirations, nil
+}
@@ -0,0 +1,150 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+// ExpirationReason describes why an upload is no longer retained by a policy.
+type ExpirationReason string
+
+const (
+	// ExpirationReasonBranchLimit marks an upload that is not among the most recent uploads
+	// of any branch from which its commit is reachable.
+	ExpirationReasonBranchLimit ExpirationReason = "BRANCH_LIMIT"
+
+	// ExpirationReasonUnreferencedAge marks an upload for a commit that is not reachable from
+	// any branch or tag and that is older than the unreferenced max age.
+	ExpirationReasonUnreferencedAge ExpirationReason = "UNREFERENCED_AGE"
+)
+
+// Expiration is an upload that is no longer retained by a policy.
+type Expiration struct {
+	Dump   store.Dump
+	Reason ExpirationReason
+}
+
+// ExpiredUploads returns the uploads of the given repository that are not retained by the given
+// policy. An upload visible at the tip of the default branch is never expired, nor is an upload
+// whose commit is unknown to gitserver, as its reachability from branches and tags is unknown.
+func ExpiredUploads(ctx context.Context, s store.Store, gitserverClient gitserver.Client, repositoryID int, policy Policy, now time.Time) ([]Expiration, error) {
+	if !policy.Enabled() {
+		return nil, nil
+	}
+
+	dumps, err := s.GetDumpsByRepositoryID(ctx, repositoryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.GetDumpsByRepositoryID")
+	}
+
+	refsByCommit := map[string][]gitserver.Ref{}
+	unknownCommits := map[string]struct{}{}
+	for _, dump := range dumps {
+		if _, ok := refsByCommit[dump.Commit]; ok {
+			continue
+		}
+		if _, ok := unknownCommits[dump.Commit]; ok {
+			continue
+		}
+
+		refs, exists, err := gitserverClient.RefsContaining(ctx, s, repositoryID, dump.Commit)
+		if err != nil {
+			return nil, errors.Wrap(err, "gitserver.RefsContaining")
+		}
+		if !exists {
+			unknownCommits[dump.Commit] = struct{}{}
+			continue
+		}
+
+		refsByCommit[dump.Commit] = refs
+	}
+
+	return expiredUploads(dumps, refsByCommit, policy, now), nil
+}
+
+type branchKey struct {
+	branch  string
+	root    string
+	indexer string
+}
+
+// expiredUploads determines the expired uploads from the given dumps, which are ordered from newest
+// to oldest, and the refs from which the commit of each dump is reachable. Dumps whose commit is not
+// a key of refsByCommit are never expired.
+func expiredUploads(dumps []store.Dump, refsByCommit map[string][]gitserver.Ref, policy Policy, now time.Time) []Expiration {
+	// Count the uploads of each branch from newest to oldest. An upload is within the branch
+	// limit if it falls within the first KeepLatestPerBranch uploads of any containing branch.
+	withinBranchLimit := map[int]bool{}
+	if policy.KeepLatestPerBranch > 0 {
+		counts := map[branchKey]int{}
+		for _, dump := range dumps {
+			for _, ref := range refsByCommit[dump.Commit] {
+				if ref.Type != gitserver.RefTypeBranch {
+					continue
+				}
+
+				key := branchKey{branch: ref.Name, root: dump.Root, indexer: dump.Indexer}
+				if counts[key]++; counts[key] <= policy.KeepLatestPerBranch {
+					withinBranchLimit[dump.ID] = true
+				}
+			}
+		}
+	}
+
+	var expirations []Expiration
+	for _, dump := range dumps {
+		if dump.VisibleAtTip {
+			continue
+		}
+
+		refs, ok := refsByCommit[dump.Commit]
+		if !ok {
+			// Commit is unknown to gitserver
+			continue
+		}
+
+		if len(refs) == 0 {
+			if policy.UnreferencedMaxAge > 0 && now.Sub(dump.UploadedAt) > policy.UnreferencedMaxAge {
+				expirations = append(expirations, Expiration{Dump: dump, Reason: ExpirationReasonUnreferencedAge})
+			}
+
+			continue
+		}
+
+		if policy.KeepLatestPerBranch == 0 || withinBranchLimit[dump.ID] || (policy.KeepTaggedCommits && isTagged(dump.Commit, refs)) {
+			continue
+		}
+
+		if hasBranch(refs) {
+			expirations = append(expirations, Expiration{Dump: dump, Reason: ExpirationReasonBranchLimit})
+		}
+	}
+
+	return expirations
+}
+
+// isTagged returns true if one of the given refs is a tag pointing directly at the given commit.
+func isTagged(commit string, refs []gitserver.Ref) bool {
+	for _, ref := range refs {
+		if ref.Type == gitserver.RefTypeTag && ref.Commit == commit {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasBranch returns true if one of the given refs is a branch.
+func hasBranch(refs []gitserver.Ref) bool {
+	for _, ref := range refs {
+		if ref.Type == gitserver.RefTypeBranch {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,117 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	gitservermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+)
+
+func TestExpiredUploads(t *testing.T) {
+	now := time.Unix(1587396557, 0).UTC()
+	day := 24 * time.Hour
+
+	dumps := []store.Dump{
+		{ID: 1, Commit: "c1", UploadedAt: now.Add(-1 * day), Indexer: "lsif-go", VisibleAtTip: true},
+		{ID: 2, Commit: "c2", UploadedAt: now.Add(-2 * day), Indexer: "lsif-go"},
+		{ID: 3, Commit: "c3", UploadedAt: now.Add(-3 * day), Indexer: "lsif-go"},
+		{ID: 4, Commit: "c3", UploadedAt: now.Add(-3 * day), Indexer: "lsif-tsc"},
+		{ID: 5, Commit: "c4", UploadedAt: now.Add(-4 * day), Indexer: "lsif-go"},
+		{ID: 6, Commit: "c5", UploadedAt: now.Add(-5 * day), Indexer: "lsif-go"},
+		{ID: 7, Commit: "c6", UploadedAt: now.Add(-6 * day), Indexer: "lsif-go"},
+		{ID: 8, Commit: "c7", UploadedAt: now.Add(-40 * day), Indexer: "lsif-go"},
+		{ID: 9, Commit: "c8", UploadedAt: now.Add(-10 * day), Indexer: "lsif-go"},
+		{ID: 10, Commit: "c9", UploadedAt: now.Add(-50 * day), Indexer: "lsif-go"},
+	}
+
+	refs := map[string][]gitserver.Ref{
+		"c1": {{Name: "main", Type: gitserver.RefTypeBranch, Commit: "c1"}},
+		"c2": {{Name: "main", Type: gitserver.RefTypeBranch, Commit: "c1"}},
+		"c3": {{Name: "main", Type: gitserver.RefTypeBranch, Commit: "c1"}},
+		"c4": {
+			{Name: "main", Type: gitserver.RefTypeBranch, Commit: "c1"},
+			{Name: "feature", Type: gitserver.RefTypeBranch, Commit: "c4"},
+		},
+		"c5": {
+			{Name: "main", Type: gitserver.RefTypeBranch, Commit: "c1"},
+			{Name: "v1.0.0", Type: gitserver.RefTypeTag, Commit: "c5"},
+		},
+		"c6": {
+			{Name: "main", Type: gitserver.RefTypeBranch, Commit: "c1"},
+			{Name: "v1.0.0", Type: gitserver.RefTypeTag, Commit: "c5"},
+		},
+	}
+
+	mockStore := storemocks.NewMockStore()
+	mockStore.GetDumpsByRepositoryIDFunc.SetDefaultReturn(dumps, nil)
+	gitserverClient := gitservermocks.NewMockClient()
+	gitserverClient.RefsContainingFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int, commit string) ([]gitserver.Ref, bool, error) {
+		// c9 is unknown to gitserver
+		return refs[commit], commit != "c9", nil
+	})
+
+	policy := Policy{KeepLatestPerBranch: 2, KeepTaggedCommits: true, UnreferencedMaxAge: 30 * day}
+	expirations, err := ExpiredUploads(context.Background(), mockStore, gitserverClient, 50, policy, now)
+	if err != nil {
+		t.Fatalf("unexpected error getting expired uploads: %s", err)
+	}
+
+	// 1 and 2 are the latest lsif-go uploads of main; 4 is the latest lsif-tsc upload
+	// of main; 5 is the latest upload of feature; 6 is tagged; 9 is not old enough; 10
+	// has an unknown commit.
+	expected := []Expiration{
+		{Dump: dumps[2], Reason: ExpirationReasonBranchLimit},
+		{Dump: dumps[6], Reason: ExpirationReasonBranchLimit},
+		{Dump: dumps[7], Reason: ExpirationReasonUnreferencedAge},
+	}
+	if diff := cmp.Diff(expected, expirations); diff != "" {
+		t.Errorf("unexpected expirations (-want +got):\n%s", diff)
+	}
+
+	if len(gitserverClient.RefsContainingFunc.History()) != 9 {
+		t.Errorf("unexpected call count. want=%d have=%d", 9, len(gitserverClient.RefsContainingFunc.History()))
+	}
+}
+
+func TestExpiredUploadsDisabledPolicy(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	gitserverClient := gitservermocks.NewMockClient()
+
+	expirations, err := ExpiredUploads(context.Background(), mockStore, gitserverClient, 50, Policy{KeepTaggedCommits: true}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error getting expired uploads: %s", err)
+	}
+	if len(expirations) != 0 {
+		t.Errorf("unexpected expirations: %v", expirations)
+	}
+	if len(mockStore.GetDumpsByRepositoryIDFunc.History()) != 0 {
+		t.Errorf("unexpected call count. want=%d have=%d", 0, len(mockStore.GetDumpsByRepositoryIDFunc.History()))
+	}
+}
+
+func TestExpiredUploadsUntaggedCommits(t *testing.T) {
+	now := time.Unix(1587396557, 0).UTC()
+
+	dumps := []store.Dump{
+		{ID: 1, Commit: "c1", UploadedAt: now.Add(-time.Hour), Indexer: "lsif-go"},
+		{ID: 2, Commit: "c2", UploadedAt: now.Add(-time.Hour * 2), Indexer: "lsif-go"},
+	}
+	refs := map[string][]gitserver.Ref{
+		"c1": {{Name: "main", Type: gitserver.RefTypeBranch, Commit: "c1"}},
+		"c2": {
+			{Name: "main", Type: gitserver.RefTypeBranch, Commit: "c1"},
+			{Name: "v1.0.0", Type: gitserver.RefTypeTag, Commit: "c2"},
+		},
+	}
+
+	policy := Policy{KeepLatestPerBranch: 1, KeepTaggedCommits: false}
+	expected := []Expiration{{Dump: dumps[1], Reason: ExpirationReasonBranchLimit}}
+	if diff := cmp.Diff(expected, expiredUploads(dumps, refs, policy, now)); diff != "" {
+		t.Errorf("unexpected expirations (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,69 @@
+package retention
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Policy determines which uploads of a repository are retained.
+type Policy struct {
+	// KeepLatestPerBranch is the number of uploads (per root and indexer) retained for each
+	// branch from which the upload's commit is reachable. Zero disables the limit.
+	KeepLatestPerBranch int
+
+	// KeepTaggedCommits indicates that uploads for a commit that is pointed to directly by a
+	// tag are never expired by the branch limit.
+	KeepTaggedCommits bool
+
+	// UnreferencedMaxAge is the age after which an upload for a commit that is not reachable
+	// from any branch or tag expires. Zero disables expiration of unreferenced uploads.
+	UnreferencedMaxAge time.Duration
+}
+
+// Enabled returns true if the policy can expire any upload.
+func (p Policy) Enabled() bool {
+	return p.KeepLatestPerBranch > 0 || p.UnreferencedMaxAge > 0
+}
+
+// PolicyForRepository returns the retention policy that applies to the repository with the given
+// name. The policy of the first repository entry with a matching pattern is used, falling back to
+// the global policy. The returned flag is false if no policy applies to the repository.
+func PolicyForRepository(config *schema.CodeIntelRetentionPolicies, repositoryName string) (Policy, bool, error) {
+	if config == nil {
+		return Policy{}, false, nil
+	}
+
+	for _, repositoryPolicy := range config.Repositories {
+		matched, err := regexp.MatchString(repositoryPolicy.Pattern, repositoryName)
+		if err != nil {
+			return Policy{}, false, errors.Wrapf(err, "invalid retention policy pattern %q", repositoryPolicy.Pattern)
+		}
+
+		if matched {
+			return convertPolicy(repositoryPolicy.Policy), true, nil
+		}
+	}
+
+	if config.Global == nil {
+		return Policy{}, false, nil
+	}
+
+	return convertPolicy(*config.Global), true, nil
+}
+
+// convertPolicy converts a site configuration retention policy into a Policy.
+func convertPolicy(policy schema.CodeIntelRetentionPolicy) Policy {
+	keepTaggedCommits := true
+	if policy.KeepTaggedCommits != nil {
+		keepTaggedCommits = *policy.KeepTaggedCommits
+	}
+
+	return Policy{
+		KeepLatestPerBranch: policy.KeepLatestPerBranch,
+		KeepTaggedCommits:   keepTaggedCommits,
+		UnreferencedMaxAge:  time.Duration(policy.UnreferencedMaxAgeDays) * 24 * time.Hour,
+	}
+}
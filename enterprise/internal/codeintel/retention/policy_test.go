@@ -0,0 +1,75 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPolicyForRepository(t *testing.T) {
+	no := false
+	config := &schema.CodeIntelRetentionPolicies{
+		Global: &schema.CodeIntelRetentionPolicy{
+			KeepLatestPerBranch:    5,
+			UnreferencedMaxAgeDays: 30,
+		},
+		Repositories: []*schema.CodeIntelRepositoryRetentionPolicy{
+			{Pattern: "^github.com/sourcegraph/", Policy: schema.CodeIntelRetentionPolicy{KeepLatestPerBranch: 10, KeepTaggedCommits: &no}},
+			{Pattern: "sourcegraph", Policy: schema.CodeIntelRetentionPolicy{KeepLatestPerBranch: 20}},
+		},
+	}
+
+	testCases := []struct {
+		repositoryName string
+		expected       Policy
+	}{
+		{"github.com/sourcegraph/sourcegraph", Policy{KeepLatestPerBranch: 10, KeepTaggedCommits: false}},
+		{"github.com/other/sourcegraph-fork", Policy{KeepLatestPerBranch: 20, KeepTaggedCommits: true}},
+		{"github.com/other/repo", Policy{KeepLatestPerBranch: 5, KeepTaggedCommits: true, UnreferencedMaxAge: 30 * 24 * time.Hour}},
+	}
+
+	for _, testCase := range testCases {
+		policy, ok, err := PolicyForRepository(config, testCase.repositoryName)
+		if err != nil {
+			t.Fatalf("unexpected error getting policy: %s", err)
+		}
+		if !ok {
+			t.Fatalf("expected policy for %s", testCase.repositoryName)
+		}
+		if diff := cmp.Diff(testCase.expected, policy); diff != "" {
+			t.Errorf("unexpected policy for %s (-want +got):\n%s", testCase.repositoryName, diff)
+		}
+	}
+}
+
+func TestPolicyForRepositoryNoPolicy(t *testing.T) {
+	if _, ok, err := PolicyForRepository(nil, "github.com/sourcegraph/sourcegraph"); err != nil {
+		t.Fatalf("unexpected error getting policy: %s", err)
+	} else if ok {
+		t.Errorf("unexpected policy")
+	}
+
+	config := &schema.CodeIntelRetentionPolicies{
+		Repositories: []*schema.CodeIntelRepositoryRetentionPolicy{
+			{Pattern: "^github.com/sourcegraph/", Policy: schema.CodeIntelRetentionPolicy{KeepLatestPerBranch: 10}},
+		},
+	}
+	if _, ok, err := PolicyForRepository(config, "github.com/other/repo"); err != nil {
+		t.Fatalf("unexpected error getting policy: %s", err)
+	} else if ok {
+		t.Errorf("unexpected policy")
+	}
+}
+
+func TestPolicyForRepositoryInvalidPattern(t *testing.T) {
+	config := &schema.CodeIntelRetentionPolicies{
+		Repositories: []*schema.CodeIntelRepositoryRetentionPolicy{
+			{Pattern: "(", Policy: schema.CodeIntelRetentionPolicy{KeepLatestPerBranch: 10}},
+		},
+	}
+	if _, _, err := PolicyForRepository(config, "github.com/sourcegraph/sourcegraph"); err == nil {
+		t.Fatalf("expected error getting policy")
+	}
+}
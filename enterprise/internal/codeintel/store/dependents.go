@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+)
+
+// PackageUsage describes an upload that references a package.
+type PackageUsage struct {
+	DumpID       int
+	RepositoryID int
+	Commit       string
+	Root         string
+	Indexer      string
+	Scheme       string
+	Name         string
+	Version      string
+
+	// IdentifierCount is the number of distinct identifiers of the package referenced by the
+	// upload. This value is nil for uploads processed before identifiers were counted.
+	IdentifierCount *int
+}
+
+// scanPackageUsages scans a slice of package usages from the return value of `*store.query`.
+func scanPackageUsages(rows *sql.Rows, queryErr error) (_ []PackageUsage, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = closeRows(rows, err) }()
+
+	var usages []PackageUsage
+	for rows.Next() {
+		var usage PackageUsage
+		if err := rows.Scan(
+			&usage.DumpID,
+			&usage.RepositoryID,
+			&usage.Commit,
+			&usage.Root,
+			&usage.Indexer,
+			&usage.Scheme,
+			&usage.Name,
+			&usage.Version,
+			&usage.IdentifierCount,
+		); err != nil {
+			return nil, err
+		}
+
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+type GetPackageDependentsOptions struct {
+	RepositoryID int
+	Name         string
+	Version      string
+	VisibleAtTip bool
+	Limit        int
+	Offset       int
+}
+
+// GetPackageDependents returns the uploads of other repositories that reference the package with the given
+// name and version published by the given repository, and the total count of such uploads. Uploads are
+// ordered by repository, commit, and root.
+func (s *store) GetPackageDependents(ctx context.Context, opts GetPackageDependentsOptions) (_ []PackageUsage, _ int, err error) {
+	conds := []*sqlf.Query{
+		sqlf.Sprintf(`(r.scheme, r.name, r.version) IN (
+			SELECT p.scheme, p.name, p.version FROM lsif_packages p
+			JOIN lsif_dumps pd ON pd.id = p.dump_id
+			WHERE pd.repository_id = %s AND p.name = %s AND p.version = %s
+		)`, opts.RepositoryID, opts.Name, opts.Version),
+		sqlf.Sprintf("d.repository_id != %s", opts.RepositoryID),
+	}
+	if opts.VisibleAtTip {
+		conds = append(conds, sqlf.Sprintf("d.visible_at_tip = true"))
+	}
+
+	return s.getPackageUsages(ctx, conds, opts.Limit, opts.Offset)
+}
+
+type GetPackageDependenciesOptions struct {
+	RepositoryID int
+	Commit       string
+	VisibleAtTip bool
+	Limit        int
+	Offset       int
+}
+
+// GetPackageDependencies returns the packages referenced by the uploads of the given repository, and the
+// total count of such references. References are ordered by commit, root, and package.
+func (s *store) GetPackageDependencies(ctx context.Context, opts GetPackageDependenciesOptions) (_ []PackageUsage, _ int, err error) {
+	conds := []*sqlf.Query{
+		sqlf.Sprintf("d.repository_id = %s", opts.RepositoryID),
+	}
+	if opts.Commit != "" {
+		conds = append(conds, sqlf.Sprintf("d.commit = %s", opts.Commit))
+	}
+	if opts.VisibleAtTip {
+		conds = append(conds, sqlf.Sprintf("d.visible_at_tip = true"))
+	}
+
+	return s.getPackageUsages(ctx, conds, opts.Limit, opts.Offset)
+}
+
+// getPackageUsages returns a page of the package references matching the given conditions and the total
+// count of matching references.
+func (s *store) getPackageUsages(ctx context.Context, conds []*sqlf.Query, limit, offset int) (_ []PackageUsage, _ int, err error) {
+	tx, started, err := s.transact(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if started {
+		defer func() { err = tx.Done(err) }()
+	}
+
+	count, _, err := scanFirstInt(tx.query(
+		ctx,
+		sqlf.Sprintf(`
+			SELECT COUNT(*) FROM lsif_references r
+			JOIN lsif_dumps d ON r.dump_id = d.id
+			WHERE %s
+		`, sqlf.Join(conds, " AND ")),
+	))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	usages, err := scanPackageUsages(tx.query(
+		ctx,
+		sqlf.Sprintf(`
+			SELECT
+				d.id,
+				d.repository_id,
+				d.commit,
+				d.root,
+				d.indexer,
+				r.scheme,
+				r.name,
+				COALESCE(r.version, ''),
+				r.identifier_count
+			FROM lsif_references r
+			JOIN lsif_dumps d ON r.dump_id = d.id
+			WHERE %s
+			ORDER BY d.repository_id, d.commit, d.root, r.scheme, r.name, r.version, d.id
+			LIMIT %d OFFSET %d
+		`, sqlf.Join(conds, " AND "), limit, offset),
+	))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return usages, count, nil
+}
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestGetPackageDependents(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, RepositoryID: 50, Commit: makeCommit(1), VisibleAtTip: true},
+		Upload{ID: 2, RepositoryID: 50, Commit: makeCommit(2), Root: "sub/"},
+		Upload{ID: 3, RepositoryID: 51, Commit: makeCommit(3), VisibleAtTip: true},
+		Upload{ID: 4, RepositoryID: 51, Commit: makeCommit(4)},
+		Upload{ID: 5, RepositoryID: 52, Commit: makeCommit(5), VisibleAtTip: true},
+		Upload{ID: 6, RepositoryID: 53, Commit: makeCommit(6), VisibleAtTip: true},
+	)
+
+	if err := store.UpdatePackages(context.Background(), []types.Package{
+		{DumpID: 1, Scheme: "gomod", Name: "leftpad", Version: "0.1.0"},
+		{DumpID: 6, Scheme: "npm", Name: "leftpad", Version: "0.1.0"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+
+	insertPackageReferences(t, store, []types.PackageReference{
+		{DumpID: 2, Scheme: "gomod", Name: "leftpad", Version: "0.1.0", Filter: []byte("f2"), IdentifierCount: 1},
+		{DumpID: 3, Scheme: "gomod", Name: "leftpad", Version: "0.1.0", Filter: []byte("f3"), IdentifierCount: 3},
+		{DumpID: 4, Scheme: "gomod", Name: "leftpad", Version: "0.1.0", Filter: []byte("f4"), IdentifierCount: 2},
+		{DumpID: 5, Scheme: "gomod", Name: "leftpad", Version: "0.2.0", Filter: []byte("f5"), IdentifierCount: 4},
+		{DumpID: 5, Scheme: "npm", Name: "leftpad", Version: "0.1.0", Filter: []byte("f6"), IdentifierCount: 5},
+	})
+
+	usages, totalCount, err := store.GetPackageDependents(context.Background(), GetPackageDependentsOptions{
+		RepositoryID: 50,
+		Name:         "leftpad",
+		Version:      "0.1.0",
+		Limit:        5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error getting package dependents: %s", err)
+	}
+	if totalCount != 2 {
+		t.Errorf("unexpected total count. want=%d have=%d", 2, totalCount)
+	}
+
+	three, two := 3, 2
+	expected := []PackageUsage{
+		{DumpID: 3, RepositoryID: 51, Commit: makeCommit(3), Indexer: "lsif-go", Scheme: "gomod", Name: "leftpad", Version: "0.1.0", IdentifierCount: &three},
+		{DumpID: 4, RepositoryID: 51, Commit: makeCommit(4), Indexer: "lsif-go", Scheme: "gomod", Name: "leftpad", Version: "0.1.0", IdentifierCount: &two},
+	}
+	if diff := cmp.Diff(expected, usages); diff != "" {
+		t.Errorf("unexpected package usages (-want +got):\n%s", diff)
+	}
+
+	usages, totalCount, err = store.GetPackageDependents(context.Background(), GetPackageDependentsOptions{
+		RepositoryID: 50,
+		Name:         "leftpad",
+		Version:      "0.1.0",
+		VisibleAtTip: true,
+		Limit:        5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error getting package dependents: %s", err)
+	}
+	if totalCount != 1 {
+		t.Errorf("unexpected total count. want=%d have=%d", 1, totalCount)
+	}
+	if diff := cmp.Diff(expected[:1], usages); diff != "" {
+		t.Errorf("unexpected package usages (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetPackageDependencies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, RepositoryID: 50, Commit: makeCommit(1), VisibleAtTip: true},
+		Upload{ID: 2, RepositoryID: 50, Commit: makeCommit(2)},
+		Upload{ID: 3, RepositoryID: 51, Commit: makeCommit(3), VisibleAtTip: true},
+	)
+
+	insertPackageReferences(t, store, []types.PackageReference{
+		{DumpID: 1, Scheme: "gomod", Name: "leftpad", Version: "0.1.0", Filter: []byte("f1"), IdentifierCount: 1},
+		{DumpID: 1, Scheme: "gomod", Name: "rightpad", Version: "0.2.0", Filter: []byte("f2"), IdentifierCount: 2},
+		{DumpID: 2, Scheme: "gomod", Name: "leftpad", Version: "0.0.9", Filter: []byte("f3"), IdentifierCount: 3},
+		{DumpID: 3, Scheme: "gomod", Name: "leftpad", Version: "0.1.0", Filter: []byte("f4"), IdentifierCount: 4},
+	})
+
+	testCases := []struct {
+		opts               GetPackageDependenciesOptions
+		expectedTotalCount int
+		expectedIDs        []string
+	}{
+		{GetPackageDependenciesOptions{RepositoryID: 50, Limit: 5}, 3, []string{"leftpad@0.1.0", "rightpad@0.2.0", "leftpad@0.0.9"}},
+		{GetPackageDependenciesOptions{RepositoryID: 50, Limit: 1, Offset: 1}, 3, []string{"rightpad@0.2.0"}},
+		{GetPackageDependenciesOptions{RepositoryID: 50, Commit: makeCommit(2), Limit: 5}, 1, []string{"leftpad@0.0.9"}},
+		{GetPackageDependenciesOptions{RepositoryID: 50, VisibleAtTip: true, Limit: 5}, 2, []string{"leftpad@0.1.0", "rightpad@0.2.0"}},
+	}
+
+	for _, testCase := range testCases {
+		usages, totalCount, err := store.GetPackageDependencies(context.Background(), testCase.opts)
+		if err != nil {
+			t.Fatalf("unexpected error getting package dependencies: %s", err)
+		}
+		if totalCount != testCase.expectedTotalCount {
+			t.Errorf("unexpected total count. want=%d have=%d", testCase.expectedTotalCount, totalCount)
+		}
+
+		var ids []string
+		for _, usage := range usages {
+			ids = append(ids, usage.Name+"@"+usage.Version)
+		}
+		if diff := cmp.Diff(testCase.expectedIDs, ids); diff != "" {
+			t.Errorf("unexpected package usages (-want +got):\n%s", diff)
+		}
+	}
+}
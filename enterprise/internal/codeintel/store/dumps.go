@@ -95,6 +95,32 @@ func (s *store) GetDumpByID(ctx context.Context, id int) (Dump, bool, error) {
 	`, id)))
 }
 
+// GetDumpRepositoryIDs returns the identifiers of all repositories with at least one dump.
+func (s *store) GetDumpRepositoryIDs(ctx context.Context) ([]int, error) {
+	return scanInts(s.query(ctx, sqlf.Sprintf(`SELECT DISTINCT d.repository_id FROM lsif_dumps d ORDER BY d.repository_id`)))
+}
+
+// GetDumpsByRepositoryID returns all dumps of the given repository ordered from newest to oldest.
+func (s *store) GetDumpsByRepositoryID(ctx context.Context, repositoryID int) ([]Dump, error) {
+	return scanDumps(s.query(ctx, sqlf.Sprintf(`
+		SELECT
+			d.id,
+			d.commit,
+			d.root,
+			d.visible_at_tip,
+			d.uploaded_at,
+			d.state,
+			d.failure_message,
+			d.started_at,
+			d.finished_at,
+			d.process_after,
+			d.num_resets,
+			d.repository_id,
+			d.indexer
+		FROM lsif_dumps d WHERE d.repository_id = %s ORDER BY d.uploaded_at DESC, d.id DESC
+	`, repositoryID)))
+}
+
 // FindClosestDumps returns the set of dumps that can most accurately answer queries for the given repository, commit, file, and optional indexer.
 func (s *store) FindClosestDumps(ctx context.Context, repositoryID int, commit, file, indexer string) (_ []Dump, err error) {
 	tx, started, err := s.transact(ctx)
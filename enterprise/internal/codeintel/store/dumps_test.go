@@ -100,6 +100,61 @@ func TestGetDumpByID(t *testing.T) {
 	}
 }
 
+func TestGetDumpRepositoryIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, RepositoryID: 50},
+		Upload{ID: 2, RepositoryID: 51},
+		Upload{ID: 3, RepositoryID: 50},
+		Upload{ID: 4, RepositoryID: 52, State: "queued"},
+		Upload{ID: 5, RepositoryID: 53, State: "errored"},
+	)
+
+	if ids, err := store.GetDumpRepositoryIDs(context.Background()); err != nil {
+		t.Fatalf("unexpected error getting dump repository ids: %s", err)
+	} else if diff := cmp.Diff([]int{50, 51}, ids); diff != "" {
+		t.Errorf("unexpected repository ids (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetDumpsByRepositoryID(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Minute)
+	t3 := t1.Add(time.Minute * 2)
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, UploadedAt: t1},
+		Upload{ID: 2, UploadedAt: t3},
+		Upload{ID: 3, UploadedAt: t2},
+		Upload{ID: 4, UploadedAt: t3, RepositoryID: 51},
+		Upload{ID: 5, UploadedAt: t3, State: "queued"},
+	)
+
+	dumps, err := store.GetDumpsByRepositoryID(context.Background(), 50)
+	if err != nil {
+		t.Fatalf("unexpected error getting dumps: %s", err)
+	}
+
+	var ids []int
+	for _, dump := range dumps {
+		ids = append(ids, dump.ID)
+	}
+	if diff := cmp.Diff([]int{2, 3, 1}, ids); diff != "" {
+		t.Errorf("unexpected dump ids (-want +got):\n%s", diff)
+	}
+}
+
 func TestFindClosestDumps(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -48,6 +48,12 @@ type MockStore struct {
 	// GetDumpIDsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetDumpIDs.
 	GetDumpIDsFunc *StoreGetDumpIDsFunc
+	// GetDumpRepositoryIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDumpRepositoryIDs.
+	GetDumpRepositoryIDsFunc *StoreGetDumpRepositoryIDsFunc
+	// GetDumpsByRepositoryIDFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDumpsByRepositoryID.
+	GetDumpsByRepositoryIDFunc *StoreGetDumpsByRepositoryIDFunc
 	// GetIndexByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexByID.
 	GetIndexByIDFunc *StoreGetIndexByIDFunc
@@ -57,6 +63,12 @@ type MockStore struct {
 	// GetPackageFunc is an instance of a mock function object controlling
 	// the behavior of the method GetPackage.
 	GetPackageFunc *StoreGetPackageFunc
+	// GetPackageDependenciesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPackageDependencies.
+	GetPackageDependenciesFunc *StoreGetPackageDependenciesFunc
+	// GetPackageDependentsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPackageDependents.
+	GetPackageDependentsFunc *StoreGetPackageDependentsFunc
 	// GetStatesFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStates.
 	GetStatesFunc *StoreGetStatesFunc
@@ -213,6 +225,16 @@ func NewMockStore() *MockStore {
 				return nil, nil
 			},
 		},
+		GetDumpRepositoryIDsFunc: &StoreGetDumpRepositoryIDsFunc{
+			defaultHook: func(context.Context) ([]int, error) {
+				return nil, nil
+			},
+		},
+		GetDumpsByRepositoryIDFunc: &StoreGetDumpsByRepositoryIDFunc{
+			defaultHook: func(context.Context, int) ([]store.Dump, error) {
+				return nil, nil
+			},
+		},
 		GetIndexByIDFunc: &StoreGetIndexByIDFunc{
 			defaultHook: func(context.Context, int) (store.Index, bool, error) {
 				return store.Index{}, false, nil
@@ -228,6 +250,16 @@ func NewMockStore() *MockStore {
 				return store.Dump{}, false, nil
 			},
 		},
+		GetPackageDependenciesFunc: &StoreGetPackageDependenciesFunc{
+			defaultHook: func(context.Context, store.GetPackageDependenciesOptions) ([]store.PackageUsage, int, error) {
+				return nil, 0, nil
+			},
+		},
+		GetPackageDependentsFunc: &StoreGetPackageDependentsFunc{
+			defaultHook: func(context.Context, store.GetPackageDependentsOptions) ([]store.PackageUsage, int, error) {
+				return nil, 0, nil
+			},
+		},
 		GetStatesFunc: &StoreGetStatesFunc{
 			defaultHook: func(context.Context, []int) (map[int]string, error) {
 				return nil, nil
@@ -423,6 +455,12 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetDumpIDsFunc: &StoreGetDumpIDsFunc{
 			defaultHook: i.GetDumpIDs,
 		},
+		GetDumpRepositoryIDsFunc: &StoreGetDumpRepositoryIDsFunc{
+			defaultHook: i.GetDumpRepositoryIDs,
+		},
+		GetDumpsByRepositoryIDFunc: &StoreGetDumpsByRepositoryIDFunc{
+			defaultHook: i.GetDumpsByRepositoryID,
+		},
 		GetIndexByIDFunc: &StoreGetIndexByIDFunc{
 			defaultHook: i.GetIndexByID,
 		},
@@ -432,6 +470,12 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetPackageFunc: &StoreGetPackageFunc{
 			defaultHook: i.GetPackage,
 		},
+		GetPackageDependenciesFunc: &StoreGetPackageDependenciesFunc{
+			defaultHook: i.GetPackageDependencies,
+		},
+		GetPackageDependentsFunc: &StoreGetPackageDependentsFunc{
+			defaultHook: i.GetPackageDependents,
+		},
 		GetStatesFunc: &StoreGetStatesFunc{
 			defaultHook: i.GetStates,
 		},
@@ -1740,6 +1784,222 @@ func (c StoreGetDumpIDsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetDumpRepositoryIDsFunc describes the behavior when the
+// GetDumpRepositoryIDs method of the parent MockStore instance is invoked.
+type StoreGetDumpRepositoryIDsFunc struct {
+	defaultHook func(context.Context) ([]int, error)
+	hooks       []func(context.Context) ([]int, error)
+	history     []StoreGetDumpRepositoryIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDumpRepositoryIDs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetDumpRepositoryIDs(v0 context.Context) ([]int, error) {
+	r0, r1 := m.GetDumpRepositoryIDsFunc.nextHook()(v0)
+	m.GetDumpRepositoryIDsFunc.appendCall(StoreGetDumpRepositoryIDsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDumpRepositoryIDs
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetDumpRepositoryIDsFunc) SetDefaultHook(hook func(context.Context) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDumpRepositoryIDs method of the parent MockStore instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetDumpRepositoryIDsFunc) PushHook(hook func(context.Context) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreGetDumpRepositoryIDsFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreGetDumpRepositoryIDsFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetDumpRepositoryIDsFunc) nextHook() func(context.Context) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetDumpRepositoryIDsFunc) appendCall(r0 StoreGetDumpRepositoryIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetDumpRepositoryIDsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetDumpRepositoryIDsFunc) History() []StoreGetDumpRepositoryIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetDumpRepositoryIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetDumpRepositoryIDsFuncCall is an object that describes an
+// invocation of method GetDumpRepositoryIDs on an instance of MockStore.
+type StoreGetDumpRepositoryIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetDumpRepositoryIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetDumpRepositoryIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetDumpsByRepositoryIDFunc describes the behavior when the
+// GetDumpsByRepositoryID method of the parent MockStore instance is
+// invoked.
+type StoreGetDumpsByRepositoryIDFunc struct {
+	defaultHook func(context.Context, int) ([]store.Dump, error)
+	hooks       []func(context.Context, int) ([]store.Dump, error)
+	history     []StoreGetDumpsByRepositoryIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDumpsByRepositoryID delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetDumpsByRepositoryID(v0 context.Context, v1 int) ([]store.Dump, error) {
+	r0, r1 := m.GetDumpsByRepositoryIDFunc.nextHook()(v0, v1)
+	m.GetDumpsByRepositoryIDFunc.appendCall(StoreGetDumpsByRepositoryIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetDumpsByRepositoryID method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreGetDumpsByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int) ([]store.Dump, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDumpsByRepositoryID method of the parent MockStore instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetDumpsByRepositoryIDFunc) PushHook(hook func(context.Context, int) ([]store.Dump, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreGetDumpsByRepositoryIDFunc) SetDefaultReturn(r0 []store.Dump, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]store.Dump, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreGetDumpsByRepositoryIDFunc) PushReturn(r0 []store.Dump, r1 error) {
+	f.PushHook(func(context.Context, int) ([]store.Dump, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetDumpsByRepositoryIDFunc) nextHook() func(context.Context, int) ([]store.Dump, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetDumpsByRepositoryIDFunc) appendCall(r0 StoreGetDumpsByRepositoryIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetDumpsByRepositoryIDFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetDumpsByRepositoryIDFunc) History() []StoreGetDumpsByRepositoryIDFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetDumpsByRepositoryIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetDumpsByRepositoryIDFuncCall is an object that describes an
+// invocation of method GetDumpsByRepositoryID on an instance of MockStore.
+type StoreGetDumpsByRepositoryIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.Dump
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetDumpsByRepositoryIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetDumpsByRepositoryIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetIndexByIDFunc describes the behavior when the GetIndexByID method
 // of the parent MockStore instance is invoked.
 type StoreGetIndexByIDFunc struct {
@@ -2079,6 +2339,231 @@ func (c StoreGetPackageFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreGetPackageDependenciesFunc describes the behavior when the
+// GetPackageDependencies method of the parent MockStore instance is
+// invoked.
+type StoreGetPackageDependenciesFunc struct {
+	defaultHook func(context.Context, store.GetPackageDependenciesOptions) ([]store.PackageUsage, int, error)
+	hooks       []func(context.Context, store.GetPackageDependenciesOptions) ([]store.PackageUsage, int, error)
+	history     []StoreGetPackageDependenciesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPackageDependencies delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetPackageDependencies(v0 context.Context, v1 store.GetPackageDependenciesOptions) ([]store.PackageUsage, int, error) {
+	r0, r1, r2 := m.GetPackageDependenciesFunc.nextHook()(v0, v1)
+	m.GetPackageDependenciesFunc.appendCall(StoreGetPackageDependenciesFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetPackageDependencies method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreGetPackageDependenciesFunc) SetDefaultHook(hook func(context.Context, store.GetPackageDependenciesOptions) ([]store.PackageUsage, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPackageDependencies method of the parent MockStore instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetPackageDependenciesFunc) PushHook(hook func(context.Context, store.GetPackageDependenciesOptions) ([]store.PackageUsage, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreGetPackageDependenciesFunc) SetDefaultReturn(r0 []store.PackageUsage, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, store.GetPackageDependenciesOptions) ([]store.PackageUsage, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreGetPackageDependenciesFunc) PushReturn(r0 []store.PackageUsage, r1 int, r2 error) {
+	f.PushHook(func(context.Context, store.GetPackageDependenciesOptions) ([]store.PackageUsage, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetPackageDependenciesFunc) nextHook() func(context.Context, store.GetPackageDependenciesOptions) ([]store.PackageUsage, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetPackageDependenciesFunc) appendCall(r0 StoreGetPackageDependenciesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetPackageDependenciesFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetPackageDependenciesFunc) History() []StoreGetPackageDependenciesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetPackageDependenciesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetPackageDependenciesFuncCall is an object that describes an
+// invocation of method GetPackageDependencies on an instance of MockStore.
+type StoreGetPackageDependenciesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.GetPackageDependenciesOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.PackageUsage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetPackageDependenciesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetPackageDependenciesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreGetPackageDependentsFunc describes the behavior when the
+// GetPackageDependents method of the parent MockStore instance is invoked.
+type StoreGetPackageDependentsFunc struct {
+	defaultHook func(context.Context, store.GetPackageDependentsOptions) ([]store.PackageUsage, int, error)
+	hooks       []func(context.Context, store.GetPackageDependentsOptions) ([]store.PackageUsage, int, error)
+	history     []StoreGetPackageDependentsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPackageDependents delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetPackageDependents(v0 context.Context, v1 store.GetPackageDependentsOptions) ([]store.PackageUsage, int, error) {
+	r0, r1, r2 := m.GetPackageDependentsFunc.nextHook()(v0, v1)
+	m.GetPackageDependentsFunc.appendCall(StoreGetPackageDependentsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetPackageDependents
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetPackageDependentsFunc) SetDefaultHook(hook func(context.Context, store.GetPackageDependentsOptions) ([]store.PackageUsage, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPackageDependents method of the parent MockStore instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetPackageDependentsFunc) PushHook(hook func(context.Context, store.GetPackageDependentsOptions) ([]store.PackageUsage, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreGetPackageDependentsFunc) SetDefaultReturn(r0 []store.PackageUsage, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, store.GetPackageDependentsOptions) ([]store.PackageUsage, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreGetPackageDependentsFunc) PushReturn(r0 []store.PackageUsage, r1 int, r2 error) {
+	f.PushHook(func(context.Context, store.GetPackageDependentsOptions) ([]store.PackageUsage, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetPackageDependentsFunc) nextHook() func(context.Context, store.GetPackageDependentsOptions) ([]store.PackageUsage, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetPackageDependentsFunc) appendCall(r0 StoreGetPackageDependentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetPackageDependentsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetPackageDependentsFunc) History() []StoreGetPackageDependentsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetPackageDependentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetPackageDependentsFuncCall is an object that describes an
+// invocation of method GetPackageDependents on an instance of MockStore.
+type StoreGetPackageDependentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.GetPackageDependentsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.PackageUsage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetPackageDependentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetPackageDependentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetStatesFunc describes the behavior when the GetStates method of
 // the parent MockStore instance is invoked.
 type StoreGetStatesFunc struct {
@@ -30,6 +30,8 @@ type ObservedStore struct {
 	resetStalledOperation              *observation.Operation
 	getDumpIDsOperation                *observation.Operation
 	getDumpByIDOperation               *observation.Operation
+	getDumpRepositoryIDsOperation      *observation.Operation
+	getDumpsByRepositoryIDOperation    *observation.Operation
 	findClosestDumpsOperation          *observation.Operation
 	deleteOldestDumpOperation          *observation.Operation
 	updateDumpsVisibleFromTipOperation *observation.Operation
@@ -39,6 +41,8 @@ type ObservedStore struct {
 	sameRepoPagerOperation             *observation.Operation
 	updatePackageReferencesOperation   *observation.Operation
 	packageReferencePagerOperation     *observation.Operation
+	getPackageDependentsOperation      *observation.Operation
+	getPackageDependenciesOperation    *observation.Operation
 	hasCommitOperation                 *observation.Operation
 	updateCommitsOperation             *observation.Operation
 	indexableRepositoriesOperation     *observation.Operation
@@ -161,6 +165,16 @@ func NewObserved(store Store, observationContext *observation.Context) Store {
 			MetricLabels: []string{"get_dump_by_id"},
 			Metrics:      metrics,
 		}),
+		getDumpRepositoryIDsOperation: observationContext.Operation(observation.Op{
+			Name:         "store.GetDumpRepositoryIDs",
+			MetricLabels: []string{"get_dump_repository_ids"},
+			Metrics:      metrics,
+		}),
+		getDumpsByRepositoryIDOperation: observationContext.Operation(observation.Op{
+			Name:         "store.GetDumpsByRepositoryID",
+			MetricLabels: []string{"get_dumps_by_repository_id"},
+			Metrics:      metrics,
+		}),
 		findClosestDumpsOperation: observationContext.Operation(observation.Op{
 			Name:         "store.FindClosestDumps",
 			MetricLabels: []string{"find_closest_dumps"},
@@ -206,6 +220,16 @@ func NewObserved(store Store, observationContext *observation.Context) Store {
 			MetricLabels: []string{"package_reference_pager"},
 			Metrics:      metrics,
 		}),
+		getPackageDependentsOperation: observationContext.Operation(observation.Op{
+			Name:         "store.GetPackageDependents",
+			MetricLabels: []string{"get_package_dependents"},
+			Metrics:      metrics,
+		}),
+		getPackageDependenciesOperation: observationContext.Operation(observation.Op{
+			Name:         "store.GetPackageDependencies",
+			MetricLabels: []string{"get_package_dependencies"},
+			Metrics:      metrics,
+		}),
 		hasCommitOperation: observationContext.Operation(observation.Op{
 			Name:         "store.HasCommit",
 			MetricLabels: []string{"has_commit"},
@@ -320,6 +344,8 @@ func (s *ObservedStore) wrap(other Store) Store {
 		resetStalledOperation:              s.resetStalledOperation,
 		getDumpIDsOperation:                s.getDumpIDsOperation,
 		getDumpByIDOperation:               s.getDumpByIDOperation,
+		getDumpRepositoryIDsOperation:      s.getDumpRepositoryIDsOperation,
+		getDumpsByRepositoryIDOperation:    s.getDumpsByRepositoryIDOperation,
 		findClosestDumpsOperation:          s.findClosestDumpsOperation,
 		deleteOldestDumpOperation:          s.deleteOldestDumpOperation,
 		updateDumpsVisibleFromTipOperation: s.updateDumpsVisibleFromTipOperation,
@@ -329,6 +355,8 @@ func (s *ObservedStore) wrap(other Store) Store {
 		sameRepoPagerOperation:             s.sameRepoPagerOperation,
 		updatePackageReferencesOperation:   s.updatePackageReferencesOperation,
 		packageReferencePagerOperation:     s.packageReferencePagerOperation,
+		getPackageDependentsOperation:      s.getPackageDependentsOperation,
+		getPackageDependenciesOperation:    s.getPackageDependenciesOperation,
 		hasCommitOperation:                 s.hasCommitOperation,
 		updateCommitsOperation:             s.updateCommitsOperation,
 		indexableRepositoriesOperation:     s.indexableRepositoriesOperation,
@@ -494,6 +522,20 @@ func (s *ObservedStore) GetDumpByID(ctx context.Context, id int) (_ Dump, _ bool
 	return s.store.GetDumpByID(ctx, id)
 }
 
+// GetDumpRepositoryIDs calls into the inner store and registers the observed results.
+func (s *ObservedStore) GetDumpRepositoryIDs(ctx context.Context) (ids []int, err error) {
+	ctx, endObservation := s.getDumpRepositoryIDsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(ids)), observation.Args{}) }()
+	return s.store.GetDumpRepositoryIDs(ctx)
+}
+
+// GetDumpsByRepositoryID calls into the inner store and registers the observed results.
+func (s *ObservedStore) GetDumpsByRepositoryID(ctx context.Context, repositoryID int) (dumps []Dump, err error) {
+	ctx, endObservation := s.getDumpsByRepositoryIDOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(dumps)), observation.Args{}) }()
+	return s.store.GetDumpsByRepositoryID(ctx, repositoryID)
+}
+
 // FindClosestDumps calls into the inner store and registers the observed results.
 func (s *ObservedStore) FindClosestDumps(ctx context.Context, repositoryID int, commit, file, indexer string) (dumps []Dump, err error) {
 	ctx, endObservation := s.findClosestDumpsOperation.With(ctx, &err, observation.Args{})
@@ -557,6 +599,20 @@ func (s *ObservedStore) PackageReferencePager(ctx context.Context, scheme, name,
 	return s.store.PackageReferencePager(ctx, scheme, name, version, repositoryID, limit)
 }
 
+// GetPackageDependents calls into the inner store and registers the observed results.
+func (s *ObservedStore) GetPackageDependents(ctx context.Context, opts GetPackageDependentsOptions) (usages []PackageUsage, _ int, err error) {
+	ctx, endObservation := s.getPackageDependentsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(usages)), observation.Args{}) }()
+	return s.store.GetPackageDependents(ctx, opts)
+}
+
+// GetPackageDependencies calls into the inner store and registers the observed results.
+func (s *ObservedStore) GetPackageDependencies(ctx context.Context, opts GetPackageDependenciesOptions) (usages []PackageUsage, _ int, err error) {
+	ctx, endObservation := s.getPackageDependenciesOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(usages)), observation.Args{}) }()
+	return s.store.GetPackageDependencies(ctx, opts)
+}
+
 // HasCommit calls into the inner store and registers the observed results.
 func (s *ObservedStore) HasCommit(ctx context.Context, repositoryID int, commit string) (_ bool, err error) {
 	ctx, endObservation := s.hasCommitOperation.With(ctx, &err, observation.Args{})
@@ -140,11 +140,11 @@ func (s *store) UpdatePackageReferences(ctx context.Context, references []types.
 
 	var values []*sqlf.Query
 	for _, r := range references {
-		values = append(values, sqlf.Sprintf("(%s, %s, %s, %s, %s)", r.DumpID, r.Scheme, r.Name, r.Version, r.Filter))
+		values = append(values, sqlf.Sprintf("(%s, %s, %s, %s, %s, %s)", r.DumpID, r.Scheme, r.Name, r.Version, r.Filter, r.IdentifierCount))
 	}
 
 	return s.queryForEffect(ctx, sqlf.Sprintf(`
-		INSERT INTO lsif_references (dump_id, scheme, name, version, filter)
+		INSERT INTO lsif_references (dump_id, scheme, name, version, filter, identifier_count)
 		VALUES %s
 	`, sqlf.Join(values, ",")))
 }
@@ -87,6 +87,12 @@ type Store interface {
 	// GetDumpByID returns a dump by its identifier and boolean flag indicating its existence.
 	GetDumpByID(ctx context.Context, id int) (Dump, bool, error)
 
+	// GetDumpRepositoryIDs returns the identifiers of all repositories with at least one dump.
+	GetDumpRepositoryIDs(ctx context.Context) ([]int, error)
+
+	// GetDumpsByRepositoryID returns all dumps of the given repository ordered from newest to oldest.
+	GetDumpsByRepositoryID(ctx context.Context, repositoryID int) ([]Dump, error)
+
 	// FindClosestDumps returns the set of dumps that can most accurately answer queries for the given repository, commit, file, and optional indexer.
 	FindClosestDumps(ctx context.Context, repositoryID int, commit, file, indexer string) ([]Dump, error)
 
@@ -120,6 +126,14 @@ type Store interface {
 	// default branch.
 	PackageReferencePager(ctx context.Context, scheme, name, version string, repositoryID, limit int) (int, ReferencePager, error)
 
+	// GetPackageDependents returns the uploads of other repositories that reference the package with the given
+	// name and version published by the given repository, and the total count of such uploads.
+	GetPackageDependents(ctx context.Context, opts GetPackageDependentsOptions) ([]PackageUsage, int, error)
+
+	// GetPackageDependencies returns the packages referenced by the uploads of the given repository, and the
+	// total count of such references.
+	GetPackageDependencies(ctx context.Context, opts GetPackageDependenciesOptions) ([]PackageUsage, int, error)
+
 	// HasCommit determines if the given commit is known for the given repository.
 	HasCommit(ctx context.Context, repositoryID int, commit string) (bool, error)
 
@@ -164,6 +164,7 @@ func (s *store) GetUploadByID(ctx context.Context, id int) (Upload, bool, error)
 
 type GetUploadsOptions struct {
 	RepositoryID int
+	Commit       string
 	State        string
 	Term         string
 	VisibleAtTip bool
@@ -186,6 +187,9 @@ func (s *store) GetUploads(ctx context.Context, opts GetUploadsOptions) (_ []Upl
 	if opts.RepositoryID != 0 {
 		conds = append(conds, sqlf.Sprintf("u.repository_id = %s", opts.RepositoryID))
 	}
+	if opts.Commit != "" {
+		conds = append(conds, sqlf.Sprintf("u.commit = %s", opts.Commit))
+	}
 	if opts.Term != "" {
 		conds = append(conds, makeSearchCondition(opts.Term))
 	}
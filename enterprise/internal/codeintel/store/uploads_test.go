@@ -142,6 +142,7 @@ func TestGetUploads(t *testing.T) {
 	)
 
 	testCases := []struct {
+		commit       string
 		state        string
 		term         string
 		visibleAtTip bool
@@ -154,10 +155,12 @@ func TestGetUploads(t *testing.T) {
 		{term: "333", expectedIDs: []int{1, 2, 3, 5}},        // searches commits and failure message
 		{term: "tsc", expectedIDs: []int{2, 5, 7, 8, 10}},    // searches indexer
 		{visibleAtTip: true, expectedIDs: []int{2, 5, 7, 8}},
+		{commit: makeCommit(3333), expectedIDs: []int{3, 5}},
+		{commit: makeCommit(3333), state: "processing", expectedIDs: []int{5}},
 	}
 
 	for _, testCase := range testCases {
-		name := fmt.Sprintf("state=%s term=%s visibleAtTip=%v", testCase.state, testCase.term, testCase.visibleAtTip)
+		name := fmt.Sprintf("commit=%s state=%s term=%s visibleAtTip=%v", testCase.commit, testCase.state, testCase.term, testCase.visibleAtTip)
 
 		t.Run(name, func(t *testing.T) {
 			for lo := 0; lo < len(testCase.expectedIDs); lo++ {
@@ -168,6 +171,7 @@ func TestGetUploads(t *testing.T) {
 
 				uploads, totalCount, err := store.GetUploads(context.Background(), GetUploadsOptions{
 					RepositoryID: 50,
+					Commit:       testCase.commit,
 					State:        testCase.state,
 					Term:         testCase.term,
 					VisibleAtTip: testCase.visibleAtTip,
@@ -0,0 +1,268 @@
+package symbols
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+	"github.com/src-d/enry/v2"
+)
+
+// SymbolPageSize is the number of symbols requested from a bundle at a time.
+const SymbolPageSize = 100
+
+// MaxUploads is the maximum number of completed uploads of a single commit consulted for
+// a single search.
+const MaxUploads = 100
+
+// MaxSymbols is the maximum number of symbols returned for a single search. This matches
+// the maximum imposed by the symbols service.
+const MaxSymbols = 500
+
+// Searcher answers symbol search queries from the document symbols of the precise code
+// intelligence uploads of a particular commit.
+type Searcher struct {
+	store               store.Store
+	bundleManagerClient bundles.BundleManagerClient
+}
+
+// NewSearcher creates a new Searcher backed by the given store and bundle manager client.
+func NewSearcher(store store.Store, bundleManagerClient bundles.BundleManagerClient) *Searcher {
+	return &Searcher{
+		store:               store,
+		bundleManagerClient: bundleManagerClient,
+	}
+}
+
+// Search returns the symbols in the given repository and commit that match the given parameters.
+// The query and path patterns are interpreted exactly as they are by the symbols service. This
+// method also returns the roots of the uploads that were searched. Only symbols of paths under
+// one of these roots are returned, so the caller should fall back to another source of symbols
+// for all other paths (and for all paths when no roots are returned).
+func (s *Searcher) Search(ctx context.Context, args search.SymbolsParameters) ([]protocol.Symbol, []string, error) {
+	repo, err := backend.Repos.GetByName(ctx, args.Repo)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "backend.Repos.GetByName")
+	}
+
+	uploads, _, err := s.store.GetUploads(ctx, store.GetUploadsOptions{
+		RepositoryID: int(repo.ID),
+		Commit:       string(args.CommitID),
+		State:        "completed",
+		Limit:        MaxUploads,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "store.GetUploads")
+	}
+	if len(uploads) == 0 {
+		return nil, nil, nil
+	}
+
+	matcher, err := newMatcher(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roots := make([]string, 0, len(uploads))
+	for _, upload := range uploads {
+		roots = append(roots, upload.Root)
+	}
+
+	first := args.First
+	if first <= 0 || first > MaxSymbols {
+		first = MaxSymbols
+	}
+
+	var symbols []protocol.Symbol
+	seen := map[string]struct{}{}
+	for _, upload := range uploads {
+		bundleSymbols, err := s.searchBundle(ctx, upload, matcher, first-len(symbols))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, symbol := range bundleSymbols {
+			// Multiple uploads for the same commit may index the same files
+			key := fmt.Sprintf("%s:%d:%s:%s", symbol.Path, symbol.Line, symbol.Kind, symbol.Name)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			symbols = append(symbols, symbol)
+		}
+
+		if len(symbols) >= first {
+			break
+		}
+	}
+
+	sort.SliceStable(symbols, func(i, j int) bool {
+		if symbols[i].Path != symbols[j].Path {
+			return symbols[i].Path < symbols[j].Path
+		}
+		return symbols[i].Line < symbols[j].Line
+	})
+
+	return symbols, roots, nil
+}
+
+// searchBundle returns at most limit symbols from the bundle of the given upload that are
+// accepted by the given matcher.
+func (s *Searcher) searchBundle(ctx context.Context, upload store.Upload, matcher *matcher, limit int) ([]protocol.Symbol, error) {
+	bundleClient := s.bundleManagerClient.BundleClient(upload.ID)
+
+	var symbols []protocol.Symbol
+	for skip := 0; len(symbols) < limit; skip += SymbolPageSize {
+		page, totalCount, err := bundleClient.SearchSymbols(ctx, matcher.literal, skip, SymbolPageSize)
+		if err != nil {
+			if err == bundles.ErrNotFound {
+				log15.Warn("Bundle does not exist")
+				return nil, nil
+			}
+			return nil, errors.Wrap(err, "bundleClient.SearchSymbols")
+		}
+
+		for _, symbol := range page {
+			path := upload.Root + symbol.Path
+			if !matcher.match(symbol.Name, path) {
+				continue
+			}
+
+			symbols = append(symbols, convertSymbol(symbol, path))
+			if len(symbols) >= limit {
+				break
+			}
+		}
+
+		if len(page) == 0 || skip+len(page) >= totalCount {
+			break
+		}
+	}
+
+	return symbols, nil
+}
+
+// matcher filters symbols by name and path in the same way as the symbols service.
+type matcher struct {
+	// literal is a substring of every matching symbol name. This is used to narrow
+	// the set of symbols requested from the bundle manager.
+	literal         string
+	name            *regexp.Regexp
+	includePatterns []*regexp.Regexp
+	excludePattern  *regexp.Regexp
+}
+
+func newMatcher(args search.SymbolsParameters) (*matcher, error) {
+	compile := func(pattern string) (*regexp.Regexp, error) {
+		if pattern == "" {
+			return nil, nil
+		}
+		if !args.IsCaseSensitive {
+			pattern = "(?i:" + pattern + ")"
+		}
+
+		return regexp.Compile(pattern)
+	}
+
+	m := &matcher{}
+	if args.Query != "" {
+		query, err := regexp.Compile(args.Query)
+		if err != nil {
+			return nil, err
+		}
+
+		// The bundle manager matches names case-insensitively, so a literal prefix of the
+		// case-sensitive pattern is a valid filter in either case.
+		m.literal, _ = query.LiteralPrefix()
+	}
+
+	var err error
+	if m.name, err = compile(args.Query); err != nil {
+		return nil, err
+	}
+	for _, includePattern := range args.IncludePatterns {
+		pattern, err := compile(includePattern)
+		if err != nil {
+			return nil, err
+		}
+		if pattern != nil {
+			m.includePatterns = append(m.includePatterns, pattern)
+		}
+	}
+	if m.excludePattern, err = compile(args.ExcludePattern); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *matcher) match(name, path string) bool {
+	if m.name != nil && !m.name.MatchString(name) {
+		return false
+	}
+	for _, includePattern := range m.includePatterns {
+		if !includePattern.MatchString(path) {
+			return false
+		}
+	}
+	if m.excludePattern != nil && m.excludePattern.MatchString(path) {
+		return false
+	}
+
+	return true
+}
+
+// convertSymbol converts a bundle symbol into the format returned by the symbols service.
+// Symbol lines are one-based.
+func convertSymbol(symbol bundles.Symbol, path string) protocol.Symbol {
+	language, _ := enry.GetLanguageByExtension(path)
+
+	return protocol.Symbol{
+		Name:     symbol.Name,
+		Path:     path,
+		Line:     symbol.Range.Start.Line + 1,
+		Kind:     symbolKindNames[symbol.Kind],
+		Language: language,
+		Parent:   symbol.ContainerName,
+	}
+}
+
+// symbolKindNames maps LSP SymbolKind values to the equivalent ctags kind names understood
+// by the frontend.
+var symbolKindNames = map[int]string{
+	1:  "file",
+	2:  "module",
+	3:  "namespace",
+	4:  "package",
+	5:  "class",
+	6:  "method",
+	7:  "property",
+	8:  "field",
+	9:  "constructor",
+	10: "enum",
+	11: "interface",
+	12: "function",
+	13: "variable",
+	14: "constant",
+	15: "string",
+	16: "number",
+	17: "boolean",
+	18: "array",
+	19: "object",
+	20: "key",
+	21: "null",
+	22: "enum member",
+	23: "struct",
+	24: "event",
+	25: "operator",
+	26: "type parameter",
+}
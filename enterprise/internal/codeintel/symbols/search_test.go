@@ -0,0 +1,187 @@
+package symbols
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	bundlemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+func TestSearch(t *testing.T) {
+	setMockRepo(t)
+
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient1 := bundlemocks.NewMockBundleClient()
+	mockBundleClient2 := bundlemocks.NewMockBundleClient()
+
+	mockStore.GetUploadsFunc.SetDefaultReturn([]store.Upload{{ID: 42, Root: ""}, {ID: 50, Root: "sub/"}}, 2, nil)
+	mockBundleManagerClient.BundleClientFunc.SetDefaultHook(func(bundleID int) bundles.BundleClient {
+		if bundleID == 42 {
+			return mockBundleClient1
+		}
+		return mockBundleClient2
+	})
+	mockBundleClient1.SearchSymbolsFunc.SetDefaultReturn([]bundles.Symbol{
+		{Path: "main.go", Name: "Server", Kind: 23, Range: bundles.Range{Start: bundles.Position{Line: 10}}},
+		{Path: "main.go", Name: "Serve", Kind: 6, ContainerName: "Server", Range: bundles.Range{Start: bundles.Position{Line: 15}}},
+		{Path: "main.go", Name: "observer", Kind: 13, Range: bundles.Range{Start: bundles.Position{Line: 3}}},
+	}, 3, nil)
+	mockBundleClient2.SearchSymbolsFunc.SetDefaultReturn([]bundles.Symbol{
+		{Path: "server.go", Name: "NewServer", Kind: 12, Range: bundles.Range{Start: bundles.Position{Line: 4}}},
+	}, 1, nil)
+
+	symbols, roots, err := NewSearcher(mockStore, mockBundleManagerClient).Search(context.Background(), search.SymbolsParameters{
+		Repo:     "github.com/test/test",
+		CommitID: "deadbeef",
+		Query:    "^Serve",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error searching symbols: %s", err)
+	}
+	if diff := cmp.Diff([]string{"", "sub/"}, roots); diff != "" {
+		t.Errorf("unexpected roots (-want +got):\n%s", diff)
+	}
+
+	expectedSymbols := []protocol.Symbol{
+		{Name: "Server", Path: "main.go", Line: 11, Kind: "struct", Language: "Go"},
+		{Name: "Serve", Path: "main.go", Line: 16, Kind: "method", Language: "Go", Parent: "Server"},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	if calls := mockStore.GetUploadsFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of GetUploads calls. want=%d have=%d", 1, len(calls))
+	} else {
+		expectedOpts := store.GetUploadsOptions{RepositoryID: 50, Commit: "deadbeef", State: "completed", Limit: MaxUploads}
+		if diff := cmp.Diff(expectedOpts, calls[0].Arg1); diff != "" {
+			t.Errorf("unexpected options (-want +got):\n%s", diff)
+		}
+	}
+
+	if calls := mockBundleClient1.SearchSymbolsFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of SearchSymbols calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != "Serve" {
+		t.Errorf("unexpected query. want=%q have=%q", "Serve", calls[0].Arg1)
+	}
+}
+
+func init() {
+	dbtesting.DBNameSuffix = "codeintelsymbols"
+}
+
+func TestSearchStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	setMockRepo(t)
+
+	for i, root := range []string{"", "web/"} {
+		if _, err := dbconn.Global.Exec(`
+			INSERT INTO lsif_uploads (id, commit, root, uploaded_at, state, repository_id, indexer, num_parts, uploaded_parts)
+			VALUES ($1, $2, $3, $4, 'completed', 50, 'lsif-go', 1, '{}')
+		`, 42+i, "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", root, time.Unix(1587396557-int64(i)*60, 0).UTC()); err != nil {
+			t.Fatalf("unexpected error inserting upload: %s", err)
+		}
+	}
+
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient := bundlemocks.NewMockBundleClient()
+	mockBundleManagerClient.BundleClientFunc.SetDefaultReturn(mockBundleClient)
+	mockBundleClient.SearchSymbolsFunc.SetDefaultReturn([]bundles.Symbol{
+		{Path: "main.go", Name: "foo", Kind: 12},
+	}, 1, nil)
+
+	symbols, roots, err := NewSearcher(store.NewWithHandle(dbconn.Global), mockBundleManagerClient).Search(context.Background(), search.SymbolsParameters{
+		Repo:     "github.com/test/test",
+		CommitID: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		Query:    "foo",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error searching symbols: %s", err)
+	}
+	if diff := cmp.Diff([]string{"", "web/"}, roots); diff != "" {
+		t.Errorf("unexpected roots (-want +got):\n%s", diff)
+	}
+
+	expectedSymbols := []protocol.Symbol{
+		{Name: "foo", Path: "main.go", Line: 1, Kind: "function", Language: "Go"},
+		{Name: "foo", Path: "web/main.go", Line: 1, Kind: "function", Language: "Go"},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchPathPatterns(t *testing.T) {
+	setMockRepo(t)
+
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient := bundlemocks.NewMockBundleClient()
+
+	mockStore.GetUploadsFunc.SetDefaultReturn([]store.Upload{{ID: 42, Root: "sub/"}}, 1, nil)
+	mockBundleManagerClient.BundleClientFunc.SetDefaultReturn(mockBundleClient)
+	mockBundleClient.SearchSymbolsFunc.SetDefaultReturn([]bundles.Symbol{
+		{Path: "a/main.go", Name: "foo", Kind: 12},
+		{Path: "a/main_test.go", Name: "foo", Kind: 12},
+		{Path: "b/main.go", Name: "foo", Kind: 12},
+	}, 3, nil)
+
+	symbols, _, err := NewSearcher(mockStore, mockBundleManagerClient).Search(context.Background(), search.SymbolsParameters{
+		Repo:            "github.com/test/test",
+		CommitID:        "deadbeef",
+		Query:           "FOO",
+		IncludePatterns: []string{"^sub/a/"},
+		ExcludePattern:  "_test\\.go$",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error searching symbols: %s", err)
+	}
+
+	expectedSymbols := []protocol.Symbol{
+		{Name: "foo", Path: "sub/a/main.go", Line: 1, Kind: "function", Language: "Go"},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchNoUploads(t *testing.T) {
+	setMockRepo(t)
+
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+
+	_, roots, err := NewSearcher(mockStore, mockBundleManagerClient).Search(context.Background(), search.SymbolsParameters{
+		Repo:     "github.com/test/test",
+		CommitID: "deadbeef",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error searching symbols: %s", err)
+	}
+	if len(roots) != 0 {
+		t.Errorf("expected no precise symbols. have roots=%v", roots)
+	}
+}
+
+func setMockRepo(t *testing.T) {
+	backend.Mocks.Repos.GetByName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{ID: 50, Name: name}, nil
+	}
+	t.Cleanup(func() { backend.Mocks.Repos.GetByName = nil })
+}
@@ -98,6 +98,9 @@ type ConfigSavedQuery struct {
 	UserID          *int32  `json:"userID"`
 	OrgID           *int32  `json:"orgID"`
 	SlackWebhookURL *string `json:"slackWebhookURL"`
+	NotifyWebhook   bool    `json:"notifyWebhook,omitempty"`
+	WebhookURL      *string `json:"webhookURL,omitempty"`
+	WebhookSecret   *string `json:"webhookSecret,omitempty"`
 }
 
 func (sq ConfigSavedQuery) Equals(other ConfigSavedQuery) bool {
@@ -148,6 +151,12 @@ type SavedQueryInfo struct {
 
 	// ExecDuration is the amount of time it took for the query to execute.
 	ExecDuration time.Duration
+
+	// ResultFingerprint is the fingerprint of the latest result set of the
+	// search query. It is only set for queries whose notifications are based on
+	// the difference between consecutive result sets, and is opaque to
+	// everyone but the query-runner.
+	ResultFingerprint []byte
 }
 
 // SavedQueriesGetInfo gets the info from the DB for the given saved query. nil
@@ -170,6 +179,40 @@ func (c *internalClient) SavedQueriesDeleteInfo(ctx context.Context, query strin
 	return c.postInternal(ctx, "saved-queries/delete-info", query, nil)
 }
 
+// SavedQueryWebhookDelivery describes an attempt to post a saved query
+// notification to the webhook of the saved query.
+type SavedQueryWebhookDelivery struct {
+	// SavedSearchID is the ID of the saved search that the notification is for.
+	SavedSearchID int32
+
+	// URL is the webhook URL that the notification was posted to.
+	URL string
+
+	// Attempt is the 1-based number of the delivery attempt.
+	Attempt int
+
+	// StatusCode is the HTTP status code of the response, or 0 if no response
+	// was received.
+	StatusCode int
+
+	// Error describes why the delivery failed, if it did.
+	Error string
+
+	// Duration is the amount of time the delivery attempt took.
+	Duration time.Duration
+}
+
+var MockSavedQueriesLogWebhookDelivery func(delivery *SavedQueryWebhookDelivery) error
+
+// SavedQueriesLogWebhookDelivery records a webhook delivery attempt in the DB,
+// so that it is visible to the owner of the saved query.
+func (c *internalClient) SavedQueriesLogWebhookDelivery(ctx context.Context, delivery *SavedQueryWebhookDelivery) error {
+	if MockSavedQueriesLogWebhookDelivery != nil {
+		return MockSavedQueriesLogWebhookDelivery(delivery)
+	}
+	return c.postInternal(ctx, "saved-queries/log-webhook-delivery", delivery, nil)
+}
+
 func (c *internalClient) SettingsGetForSubject(ctx context.Context, subject SettingsSubject) (parsed *schema.Settings, settings *Settings, err error) {
 	err = c.postInternal(ctx, "settings/get-for-subject", subject, &settings)
 	if err == nil {
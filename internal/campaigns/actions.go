@@ -0,0 +1,173 @@
+package campaigns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+)
+
+// Action is the definition of a campaign action: a search query that selects
+// the repositories to run in and the steps that are run in each of them to
+// produce a patch. It has the same format as the action files that src-cli
+// executes.
+type Action struct {
+	ScopeQuery string       `json:"scopeQuery"`
+	Steps      []ActionStep `json:"steps"`
+}
+
+// ActionStepType is the type of a single ActionStep.
+type ActionStepType string
+
+// ActionStepType constants.
+const (
+	// ActionStepTypeDocker steps run Image with Args in a Docker container,
+	// with the repository mounted at /work.
+	ActionStepTypeDocker ActionStepType = "docker"
+	// ActionStepTypeCommand steps run Args as a command in the repository's
+	// working directory.
+	ActionStepTypeCommand ActionStepType = "command"
+)
+
+// ActionStep is a single step of an Action.
+type ActionStep struct {
+	Type  ActionStepType `json:"type"`
+	Image string         `json:"image,omitempty"`
+	Args  []string       `json:"args,omitempty"`
+}
+
+// ParseAction parses and validates the given action definition, which may
+// contain comments and trailing commas.
+func ParseAction(definition string) (*Action, error) {
+	var a Action
+	if err := jsonc.Unmarshal(definition, &a); err != nil {
+		return nil, errors.Wrap(err, "parsing action definition")
+	}
+
+	if strings.TrimSpace(a.ScopeQuery) == "" {
+		return nil, errors.New("action has no scopeQuery")
+	}
+	if len(a.Steps) == 0 {
+		return nil, errors.New("action has no steps")
+	}
+	for i, step := range a.Steps {
+		switch step.Type {
+		case ActionStepTypeDocker:
+			if step.Image == "" {
+				return nil, fmt.Errorf("step %d: docker step has no image", i+1)
+			}
+			if err := ValidateActionImage(step.Image); err != nil {
+				return nil, fmt.Errorf("step %d: %s", i+1, err)
+			}
+		case ActionStepTypeCommand:
+			if len(step.Args) == 0 {
+				return nil, fmt.Errorf("step %d: command step has no args", i+1)
+			}
+		default:
+			return nil, fmt.Errorf("step %d: unknown step type %q", i+1, step.Type)
+		}
+	}
+
+	return &a, nil
+}
+
+// ValidateActionImage returns an error if the given image of a docker step
+// could be mistaken for a flag of the docker CLI.
+func ValidateActionImage(image string) error {
+	if strings.HasPrefix(image, "-") {
+		return fmt.Errorf("invalid image %q", image)
+	}
+	return nil
+}
+
+// An ActionExecution is a server-side run of an Action. It consists of one
+// ActionJob per repository matched by the Action's scope query. Once all of
+// its jobs have finished, a PatchSet is created from their diffs.
+type ActionExecution struct {
+	ID     int64
+	UserID int32
+
+	// Definition is the JSON definition of the Action, as given by the user.
+	Definition string
+
+	// Only set once all jobs have finished.
+	PatchSetID int64
+
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Clone returns a clone of an ActionExecution.
+func (e *ActionExecution) Clone() *ActionExecution {
+	ee := *e
+	return &ee
+}
+
+// An ActionJob runs the steps of an ActionExecution's Action in a single
+// repository.
+type ActionJob struct {
+	ID          int64
+	ExecutionID int64
+
+	RepoID api.RepoID
+	// Rev and BaseRef are resolved when the job is started.
+	Rev     api.CommitID
+	BaseRef string
+
+	// Diff is the diff produced by the steps, in unified diff format without
+	// a/ and b/ prefixes. It is empty if the steps didn't change anything.
+	Diff string
+	// Log is the combined output of the steps of the last attempt.
+	Log   string
+	Error string
+
+	// Attempts is the number of times the job has been run.
+	Attempts int32
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of an ActionJob.
+func (j *ActionJob) Clone() *ActionJob {
+	jj := *j
+	return &jj
+}
+
+// Completed returns true for jobs that have completed, regardless of whether
+// that was successful or not.
+func (j *ActionJob) Completed() bool {
+	return !j.FinishedAt.IsZero()
+}
+
+// ActionJobState defines the possible states of an ActionJob.
+type ActionJobState string
+
+// ActionJobState constants.
+const (
+	ActionJobStateQueued     ActionJobState = "QUEUED"
+	ActionJobStateProcessing ActionJobState = "PROCESSING"
+	ActionJobStateErrored    ActionJobState = "ERRORED"
+	ActionJobStateCompleted  ActionJobState = "COMPLETED"
+)
+
+// State returns the ActionJobState of the job.
+func (j *ActionJob) State() ActionJobState {
+	switch {
+	case j.Completed() && j.Error != "":
+		return ActionJobStateErrored
+	case j.Completed():
+		return ActionJobStateCompleted
+	case !j.StartedAt.IsZero():
+		return ActionJobStateProcessing
+	default:
+		return ActionJobStateQueued
+	}
+}
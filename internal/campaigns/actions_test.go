@@ -0,0 +1,108 @@
+package campaigns
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseAction(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		definition string
+		want       *Action
+		wantErr    string
+	}{
+		{
+			name: "valid",
+			definition: `{
+				// Comments and trailing commas are allowed.
+				"scopeQuery": "repohasfile:go.mod",
+				"steps": [
+					{"type": "docker", "image": "golang:1.14-alpine", "args": ["gofmt", "-w", "."]},
+					{"type": "command", "args": ["sh", "-c", "echo hi"]},
+				],
+			}`,
+			want: &Action{
+				ScopeQuery: "repohasfile:go.mod",
+				Steps: []ActionStep{
+					{Type: ActionStepTypeDocker, Image: "golang:1.14-alpine", Args: []string{"gofmt", "-w", "."}},
+					{Type: ActionStepTypeCommand, Args: []string{"sh", "-c", "echo hi"}},
+				},
+			},
+		},
+		{
+			name:       "invalid JSON",
+			definition: `{"scopeQuery": `,
+			wantErr:    "parsing action definition",
+		},
+		{
+			name:       "no scope query",
+			definition: `{"steps": [{"type": "command", "args": ["true"]}]}`,
+			wantErr:    "action has no scopeQuery",
+		},
+		{
+			name:       "no steps",
+			definition: `{"scopeQuery": "repo:foo"}`,
+			wantErr:    "action has no steps",
+		},
+		{
+			name:       "docker step without image",
+			definition: `{"scopeQuery": "repo:foo", "steps": [{"type": "docker"}]}`,
+			wantErr:    "step 1: docker step has no image",
+		},
+		{
+			name:       "docker step with flag as image",
+			definition: `{"scopeQuery": "repo:foo", "steps": [{"type": "docker", "image": "--privileged"}]}`,
+			wantErr:    `step 1: invalid image "--privileged"`,
+		},
+		{
+			name:       "command step without args",
+			definition: `{"scopeQuery": "repo:foo", "steps": [{"type": "command", "args": ["true"]}, {"type": "command"}]}`,
+			wantErr:    "step 2: command step has no args",
+		},
+		{
+			name:       "unknown step type",
+			definition: `{"scopeQuery": "repo:foo", "steps": [{"type": "shell"}]}`,
+			wantErr:    `step 1: unknown step type "shell"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := ParseAction(tc.definition)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("have error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, tc.want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestActionJob_State(t *testing.T) {
+	now := time.Now()
+
+	for _, tc := range []struct {
+		job  ActionJob
+		want ActionJobState
+	}{
+		{ActionJob{}, ActionJobStateQueued},
+		{ActionJob{Error: "failed on first attempt", Attempts: 1}, ActionJobStateQueued},
+		{ActionJob{StartedAt: now}, ActionJobStateProcessing},
+		{ActionJob{StartedAt: now, FinishedAt: now}, ActionJobStateCompleted},
+		{ActionJob{StartedAt: now, FinishedAt: now, Error: "exit status 1"}, ActionJobStateErrored},
+	} {
+		if have := tc.job.State(); have != tc.want {
+			t.Errorf("%+v: have state %q, want %q", tc.job, have, tc.want)
+		}
+	}
+}
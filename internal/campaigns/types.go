@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
@@ -27,6 +28,7 @@ import (
 var SupportedExternalServices = map[string]struct{}{
 	extsvc.TypeGitHub:          {},
 	extsvc.TypeBitbucketServer: {},
+	extsvc.TypeGitLab:          {},
 }
 
 // IsRepoSupported returns whether the given ExternalRepoSpec is supported by
@@ -383,6 +385,12 @@ func (c *Changeset) SetMetadata(meta interface{}) error {
 		c.ExternalServiceType = extsvc.TypeBitbucketServer
 		c.ExternalBranch = git.AbbreviateRef(pr.FromRef.ID)
 		c.ExternalUpdatedAt = unixMilliToTime(int64(pr.UpdatedDate))
+	case *gitlab.MergeRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.IID)
+		c.ExternalServiceType = extsvc.TypeGitLab
+		c.ExternalBranch = pr.SourceBranch
+		c.ExternalUpdatedAt = pr.UpdatedAt
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -406,6 +414,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bitbucketserver.PullRequest:
 		return m.Title, nil
+	case *gitlab.MergeRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -420,6 +430,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt
 	case *bitbucketserver.PullRequest:
 		return unixMilliToTime(int64(m.CreatedDate))
+	case *gitlab.MergeRequest:
+		return m.CreatedAt
 	default:
 		return time.Time{}
 	}
@@ -432,6 +444,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Body, nil
 	case *bitbucketserver.PullRequest:
 		return m.Description, nil
+	case *gitlab.MergeRequest:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -465,6 +479,8 @@ func (c *Changeset) state() (s ChangesetState, err error) {
 		} else {
 			s = ChangesetState(m.State)
 		}
+	case *gitlab.MergeRequest:
+		s = gitLabMergeRequestState(m.State)
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -487,6 +503,8 @@ func (c *Changeset) URL() (s string, err error) {
 		}
 		selfLink := m.Links.Self[0]
 		return selfLink.Href, nil
+	case *gitlab.MergeRequest:
+		return m.WebURL, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -585,6 +603,26 @@ func (c *Changeset) Events() (events []*ChangesetEvent) {
 			addEvent(s)
 		}
 
+	case *gitlab.MergeRequest:
+		events = make([]*ChangesetEvent, 0, len(m.Notes)+len(m.Pipelines))
+		addEvent := func(e Keyer) {
+			events = append(events, &ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         e.Key(),
+				Kind:        ChangesetEventKindFor(e),
+				Metadata:    e,
+			})
+		}
+		for _, n := range m.Notes {
+			// Only system notes recording approvals and state changes are
+			// turned into events.
+			if e, ok := n.ToEvent().(Keyer); ok {
+				addEvent(e)
+			}
+		}
+		for _, p := range m.Pipelines {
+			addEvent(p)
+		}
 	}
 	return events
 }
@@ -598,6 +636,8 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.HeadRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *gitlab.MergeRequest:
+		return m.DiffRefs.HeadSHA, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -611,6 +651,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.HeadRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.FromRef.ID, nil
+	case *gitlab.MergeRequest:
+		return "refs/heads/" + m.SourceBranch, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -625,6 +667,8 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.BaseRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *gitlab.MergeRequest:
+		return m.DiffRefs.BaseSHA, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -638,6 +682,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.BaseRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.ToRef.ID, nil
+	case *gitlab.MergeRequest:
+		return "refs/heads/" + m.TargetBranch, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -709,6 +755,16 @@ func (e *ChangesetEvent) Actor() string {
 		a = e.Actor.Login
 	case *github.LabelEvent:
 		a = e.Actor.Login
+	case *gitlab.ReviewApprovedEvent:
+		a = e.Author.Username
+	case *gitlab.ReviewUnapprovedEvent:
+		a = e.Author.Username
+	case *gitlab.MergeRequestClosedEvent:
+		a = e.Author.Username
+	case *gitlab.MergeRequestReopenedEvent:
+		a = e.Author.Username
+	case *gitlab.MergeRequestMergedEvent:
+		a = e.Author.Username
 	}
 
 	return a
@@ -745,6 +801,20 @@ func (e *ChangesetEvent) ReviewAuthor() (string, error) {
 		}
 		return username, nil
 
+	case *gitlab.ReviewApprovedEvent:
+		username := meta.Author.Username
+		if username == "" {
+			return "", errors.New("approval author is blank")
+		}
+		return username, nil
+
+	case *gitlab.ReviewUnapprovedEvent:
+		username := meta.Author.Username
+		if username == "" {
+			return "", errors.New("unapproval author is blank")
+		}
+		return username, nil
+
 	default:
 		return "", nil
 	}
@@ -753,7 +823,8 @@ func (e *ChangesetEvent) ReviewAuthor() (string, error) {
 // ReviewState returns the review state of the ChangesetEvent if it is a review event.
 func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	switch e.Kind {
-	case ChangesetEventKindBitbucketServerApproved:
+	case ChangesetEventKindBitbucketServerApproved,
+		ChangesetEventKindGitLabApproved:
 		return ChangesetReviewStateApproved, nil
 
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
@@ -777,7 +848,8 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 
 	case ChangesetEventKindGitHubReviewDismissed,
 		ChangesetEventKindBitbucketServerUnapproved,
-		ChangesetEventKindBitbucketServerDismissed:
+		ChangesetEventKindBitbucketServerDismissed,
+		ChangesetEventKindGitLabUnapproved:
 		return ChangesetReviewStateDismissed, nil
 
 	default:
@@ -839,6 +911,18 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = unixMilliToTime(int64(e.CreatedDate))
 	case *bitbucketserver.CommitStatus:
 		t = unixMilliToTime(int64(e.Status.DateAdded))
+	case *gitlab.ReviewApprovedEvent:
+		t = e.CreatedAt
+	case *gitlab.ReviewUnapprovedEvent:
+		t = e.CreatedAt
+	case *gitlab.MergeRequestClosedEvent:
+		t = e.CreatedAt
+	case *gitlab.MergeRequestReopenedEvent:
+		t = e.CreatedAt
+	case *gitlab.MergeRequestMergedEvent:
+		t = e.CreatedAt
+	case *gitlab.Pipeline:
+		t = e.UpdatedAt
 	}
 
 	return t
@@ -1175,6 +1259,22 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) {
 		}
 		e.CheckRuns = o.CheckRuns
 
+	// GitLab events are immutable once recorded, except for pipelines, for
+	// which we always get the full, latest state. So it's safe to replace
+	// them.
+	case *gitlab.ReviewApprovedEvent:
+		*e = *o.Metadata.(*gitlab.ReviewApprovedEvent)
+	case *gitlab.ReviewUnapprovedEvent:
+		*e = *o.Metadata.(*gitlab.ReviewUnapprovedEvent)
+	case *gitlab.MergeRequestClosedEvent:
+		*e = *o.Metadata.(*gitlab.MergeRequestClosedEvent)
+	case *gitlab.MergeRequestReopenedEvent:
+		*e = *o.Metadata.(*gitlab.MergeRequestReopenedEvent)
+	case *gitlab.MergeRequestMergedEvent:
+		*e = *o.Metadata.(*gitlab.MergeRequestMergedEvent)
+	case *gitlab.Pipeline:
+		*e = *o.Metadata.(*gitlab.Pipeline)
+
 	default:
 		panic(errors.Errorf("unknown changeset event metadata %T", e))
 	}
@@ -1308,6 +1408,18 @@ func ChangesetEventKindFor(e interface{}) ChangesetEventKind {
 		return ChangesetEventKind("bitbucketserver:participant_status:" + strings.ToLower(string(e.Action)))
 	case *bitbucketserver.CommitStatus:
 		return ChangesetEventKindBitbucketServerCommitStatus
+	case *gitlab.ReviewApprovedEvent:
+		return ChangesetEventKindGitLabApproved
+	case *gitlab.ReviewUnapprovedEvent:
+		return ChangesetEventKindGitLabUnapproved
+	case *gitlab.MergeRequestClosedEvent:
+		return ChangesetEventKindGitLabClosed
+	case *gitlab.MergeRequestReopenedEvent:
+		return ChangesetEventKindGitLabReopened
+	case *gitlab.MergeRequestMergedEvent:
+		return ChangesetEventKindGitLabMerged
+	case *gitlab.Pipeline:
+		return ChangesetEventKindGitLabPipeline
 	default:
 		panic(errors.Errorf("unknown changeset event kind for %T", e))
 	}
@@ -1326,6 +1438,21 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		default:
 			return new(bitbucketserver.Activity), nil
 		}
+	case strings.HasPrefix(string(k), "gitlab"):
+		switch k {
+		case ChangesetEventKindGitLabApproved:
+			return new(gitlab.ReviewApprovedEvent), nil
+		case ChangesetEventKindGitLabUnapproved:
+			return new(gitlab.ReviewUnapprovedEvent), nil
+		case ChangesetEventKindGitLabClosed:
+			return new(gitlab.MergeRequestClosedEvent), nil
+		case ChangesetEventKindGitLabReopened:
+			return new(gitlab.MergeRequestReopenedEvent), nil
+		case ChangesetEventKindGitLabMerged:
+			return new(gitlab.MergeRequestMergedEvent), nil
+		case ChangesetEventKindGitLabPipeline:
+			return new(gitlab.Pipeline), nil
+		}
 	case strings.HasPrefix(string(k), "github"):
 		switch k {
 		case ChangesetEventKindGitHubAssigned:
@@ -1410,8 +1537,31 @@ const (
 	// BitbucketServer calls this an Unapprove event but we've called it Dismissed to more
 	// clearly convey that it only occurs when a request for changes has been dismissed.
 	ChangesetEventKindBitbucketServerDismissed ChangesetEventKind = "bitbucketserver:participant_status:unapproved"
+
+	ChangesetEventKindGitLabApproved   ChangesetEventKind = "gitlab:approved"
+	ChangesetEventKindGitLabUnapproved ChangesetEventKind = "gitlab:unapproved"
+	ChangesetEventKindGitLabClosed     ChangesetEventKind = "gitlab:closed"
+	ChangesetEventKindGitLabReopened   ChangesetEventKind = "gitlab:reopened"
+	ChangesetEventKindGitLabMerged     ChangesetEventKind = "gitlab:merged"
+	ChangesetEventKindGitLabPipeline   ChangesetEventKind = "gitlab:pipeline"
 )
 
+// gitLabMergeRequestState maps the state of a GitLab merge request to a
+// ChangesetState. Locked merge requests can't be merged or commented on, so
+// they're treated as closed.
+func gitLabMergeRequestState(s gitlab.MergeRequestState) ChangesetState {
+	switch s {
+	case gitlab.MergeRequestStateOpened:
+		return ChangesetStateOpen
+	case gitlab.MergeRequestStateClosed, gitlab.MergeRequestStateLocked:
+		return ChangesetStateClosed
+	case gitlab.MergeRequestStateMerged:
+		return ChangesetStateMerged
+	default:
+		return ChangesetState(s)
+	}
+}
+
 // ChangesetSyncData represents data about the sync status of a changeset
 type ChangesetSyncData struct {
 	ChangesetID int64
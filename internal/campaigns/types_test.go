@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
 
 func TestChangesetMetadata(t *testing.T) {
@@ -282,6 +283,40 @@ func TestChangesetEvents(t *testing.T) {
 		})
 	}
 
+	{ // GitLab
+		now := time.Now().UTC()
+		approved := &gitlab.Note{ID: 1, Body: "approved this merge request", System: true, CreatedAt: now}
+		comment := &gitlab.Note{ID: 2, Body: "lgtm", CreatedAt: now}
+		merged := &gitlab.Note{ID: 3, Body: "merged", System: true, CreatedAt: now}
+		pipeline := &gitlab.Pipeline{ID: 4, Status: gitlab.PipelineStatusSuccess}
+
+		cases = append(cases, testCase{"gitlab",
+			Changeset{
+				ID: 25,
+				Metadata: &gitlab.MergeRequest{
+					Notes:     []*gitlab.Note{approved, comment, merged},
+					Pipelines: []*gitlab.Pipeline{pipeline},
+				},
+			},
+			[]*ChangesetEvent{{
+				ChangesetID: 25,
+				Kind:        ChangesetEventKindGitLabApproved,
+				Key:         approved.Key(),
+				Metadata:    &gitlab.ReviewApprovedEvent{Note: *approved},
+			}, {
+				ChangesetID: 25,
+				Kind:        ChangesetEventKindGitLabMerged,
+				Key:         merged.Key(),
+				Metadata:    &gitlab.MergeRequestMergedEvent{Note: *merged},
+			}, {
+				ChangesetID: 25,
+				Kind:        ChangesetEventKindGitLabPipeline,
+				Key:         pipeline.Key(),
+				Metadata:    pipeline,
+			}},
+		})
+	}
+
 	for _, tc := range cases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -11,6 +11,16 @@ type ServiceConnections struct {
 	// to.
 	GitServers []string `json:"gitServers"`
 
+	// GitServerReplicationFactor is the number of additional gitserver
+	// instances each repository is replicated to. 0 disables replication.
+	GitServerReplicationFactor int `json:"gitServerReplicationFactor"`
+
+	// GitServersPrevious is the addresses of gitserver instances before the
+	// set of gitservers was changed. If non-empty, gitservers transfer
+	// repositories assigned to them from their previous owner rather than
+	// cloning them from the code host.
+	GitServersPrevious []string `json:"gitServersPrevious"`
+
 	// PostgresDSN is the PostgreSQL DB data source name.
 	// eg: "postgres://sg@pgsql/sourcegraph?sslmode=false"
 	PostgresDSN string `json:"postgresDSN"`
@@ -0,0 +1,136 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	eventTypeHeader = "X-Gitlab-Event"
+	tokenHeader     = "X-Gitlab-Token"
+)
+
+// WebhookEventType returns the type of the GitLab webhook event sent in r.
+func WebhookEventType(r *http.Request) string {
+	return r.Header.Get(eventTypeHeader)
+}
+
+// WebhookToken returns the secret token GitLab sent with the webhook event in
+// r.
+func WebhookToken(r *http.Request) string {
+	return r.Header.Get(tokenHeader)
+}
+
+// ParseWebhookEvent parses the payload of a GitLab webhook event of the given
+// type. See https://docs.gitlab.com/ee/user/project/integrations/webhooks.html.
+func ParseWebhookEvent(eventType string, payload []byte) (e interface{}, err error) {
+	switch eventType {
+	case "Merge Request Hook":
+		e = &MergeRequestEvent{}
+		return e, json.Unmarshal(payload, e)
+	case "Pipeline Hook":
+		e = &PipelineEvent{}
+		return e, json.Unmarshal(payload, e)
+	default:
+		return nil, fmt.Errorf("unknown webhook event type: %q", eventType)
+	}
+}
+
+// MergeRequestEvent is sent when a merge request is created, updated,
+// approved, closed, reopened or merged.
+type MergeRequestEvent struct {
+	User             User          `json:"user"`
+	Project          ProjectCommon `json:"project"`
+	ObjectAttributes struct {
+		ID        int               `json:"id"`
+		IID       int               `json:"iid"`
+		State     MergeRequestState `json:"state"`
+		Action    string            `json:"action"`
+		UpdatedAt WebhookTime       `json:"updated_at"`
+	} `json:"object_attributes"`
+}
+
+// ToEvent returns the typed event for the merge request change, or nil if the
+// action is not one we record.
+func (e *MergeRequestEvent) ToEvent() interface{} {
+	var body string
+	switch e.ObjectAttributes.Action {
+	case "approved":
+		body = systemNoteBodyApproved
+	case "unapproved":
+		body = systemNoteBodyUnapproved
+	case "close":
+		body = systemNoteBodyClosed
+	case "reopen":
+		body = systemNoteBodyReopened
+	case "merge":
+		body = systemNoteBodyMerged
+	default:
+		return nil
+	}
+
+	n := &Note{
+		Body:      body,
+		Author:    User{ID: e.User.ID, Name: e.User.Name, Username: e.User.Username},
+		CreatedAt: e.ObjectAttributes.UpdatedAt.Time,
+		System:    true,
+	}
+	return n.ToEvent()
+}
+
+// PipelineEvent is sent when the status of a pipeline changes.
+type PipelineEvent struct {
+	Project          ProjectCommon `json:"project"`
+	ObjectAttributes struct {
+		ID         int            `json:"id"`
+		Ref        string         `json:"ref"`
+		SHA        string         `json:"sha"`
+		Status     PipelineStatus `json:"status"`
+		CreatedAt  WebhookTime    `json:"created_at"`
+		FinishedAt *WebhookTime   `json:"finished_at"`
+	} `json:"object_attributes"`
+	// MergeRequest is nil if the pipeline didn't run for a merge request.
+	MergeRequest *struct {
+		ID  int `json:"id"`
+		IID int `json:"iid"`
+	} `json:"merge_request"`
+}
+
+// Pipeline returns the pipeline the event was sent for.
+func (e *PipelineEvent) Pipeline() *Pipeline {
+	p := &Pipeline{
+		ID:        e.ObjectAttributes.ID,
+		SHA:       e.ObjectAttributes.SHA,
+		Ref:       e.ObjectAttributes.Ref,
+		Status:    e.ObjectAttributes.Status,
+		CreatedAt: e.ObjectAttributes.CreatedAt.Time,
+		UpdatedAt: e.ObjectAttributes.CreatedAt.Time,
+	}
+	if e.ObjectAttributes.FinishedAt != nil {
+		p.UpdatedAt = e.ObjectAttributes.FinishedAt.Time
+	}
+	return p
+}
+
+// WebhookTime is a timestamp in a webhook payload. Depending on the GitLab
+// version and event type, these are either RFC 3339 timestamps or of the form
+// "2006-01-02 15:04:05 UTC".
+type WebhookTime struct{ time.Time }
+
+func (t *WebhookTime) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05 MST"} {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid GitLab webhook timestamp %q", s)
+}
@@ -0,0 +1,348 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/peterhellberg/link"
+	"github.com/pkg/errors"
+)
+
+// MergeRequestState is the state of a GitLab merge request.
+type MergeRequestState string
+
+const (
+	MergeRequestStateOpened MergeRequestState = "opened"
+	MergeRequestStateClosed MergeRequestState = "closed"
+	MergeRequestStateLocked MergeRequestState = "locked"
+	MergeRequestStateMerged MergeRequestState = "merged"
+)
+
+// MergeRequest is a GitLab merge request (equivalent to a GitHub pull request).
+type MergeRequest struct {
+	ID           int               `json:"id"`
+	IID          int               `json:"iid"` // the project-scoped ID shown in the UI ("!123")
+	ProjectID    int               `json:"project_id"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	State        MergeRequestState `json:"state"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	WebURL       string            `json:"web_url"`
+	SourceBranch string            `json:"source_branch"`
+	TargetBranch string            `json:"target_branch"`
+	SHA          string            `json:"sha"`
+	DiffRefs     DiffRefs          `json:"diff_refs"`
+	Author       User              `json:"author"`
+
+	// Notes and Pipelines are not returned by the merge request endpoints of
+	// the GitLab API, but loaded separately by LoadMergeRequestNotes and
+	// LoadMergeRequestPipelines.
+	Notes     []*Note     `json:"notes,omitempty"`
+	Pipelines []*Pipeline `json:"pipelines,omitempty"`
+}
+
+// DiffRefs are the commits a merge request's diff is computed between.
+type DiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+// Note is a comment on a merge request. System notes are created by GitLab
+// itself to record changes to a merge request, such as approvals or state
+// changes.
+type Note struct {
+	ID        int       `json:"id"`
+	Body      string    `json:"body"`
+	Author    User      `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	System    bool      `json:"system"`
+}
+
+// Key identifies the change recorded by the note. It's derived from the author
+// and time of the change instead of the note ID, so that the same change
+// received through a webhook (which carries no note ID) yields the same key.
+func (n *Note) Key() string {
+	return fmt.Sprintf("%d:%d", n.Author.ID, n.CreatedAt.Unix())
+}
+
+// The bodies of the system notes GitLab creates for merge request changes
+// that are relevant to campaigns.
+const (
+	systemNoteBodyApproved   = "approved this merge request"
+	systemNoteBodyUnapproved = "unapproved this merge request"
+	systemNoteBodyClosed     = "closed"
+	systemNoteBodyReopened   = "reopened"
+	systemNoteBodyMerged     = "merged"
+)
+
+// ToEvent returns the typed event recorded by a system note, or nil if the
+// note is not a system note or records a change we don't care about.
+func (n *Note) ToEvent() interface{} {
+	if !n.System {
+		return nil
+	}
+
+	switch n.Body {
+	case systemNoteBodyApproved:
+		return &ReviewApprovedEvent{Note: *n}
+	case systemNoteBodyUnapproved:
+		return &ReviewUnapprovedEvent{Note: *n}
+	case systemNoteBodyClosed:
+		return &MergeRequestClosedEvent{Note: *n}
+	case systemNoteBodyReopened:
+		return &MergeRequestReopenedEvent{Note: *n}
+	case systemNoteBodyMerged:
+		return &MergeRequestMergedEvent{Note: *n}
+	}
+
+	return nil
+}
+
+// ReviewApprovedEvent is recorded when a user approves a merge request.
+type ReviewApprovedEvent struct{ Note }
+
+// ReviewUnapprovedEvent is recorded when a user revokes their approval of a
+// merge request.
+type ReviewUnapprovedEvent struct{ Note }
+
+// MergeRequestClosedEvent is recorded when a merge request is closed.
+type MergeRequestClosedEvent struct{ Note }
+
+// MergeRequestReopenedEvent is recorded when a merge request is reopened.
+type MergeRequestReopenedEvent struct{ Note }
+
+// MergeRequestMergedEvent is recorded when a merge request is merged.
+type MergeRequestMergedEvent struct{ Note }
+
+// PipelineStatus is the status of a GitLab CI pipeline.
+type PipelineStatus string
+
+const (
+	PipelineStatusCreated            PipelineStatus = "created"
+	PipelineStatusWaitingForResource PipelineStatus = "waiting_for_resource"
+	PipelineStatusPreparing          PipelineStatus = "preparing"
+	PipelineStatusPending            PipelineStatus = "pending"
+	PipelineStatusRunning            PipelineStatus = "running"
+	PipelineStatusSuccess            PipelineStatus = "success"
+	PipelineStatusFailed             PipelineStatus = "failed"
+	PipelineStatusCanceled           PipelineStatus = "canceled"
+	PipelineStatusSkipped            PipelineStatus = "skipped"
+	PipelineStatusManual             PipelineStatus = "manual"
+	PipelineStatusScheduled          PipelineStatus = "scheduled"
+)
+
+// Pipeline is a GitLab CI pipeline that ran for a merge request.
+type Pipeline struct {
+	ID        int            `json:"id"`
+	SHA       string         `json:"sha"`
+	Ref       string         `json:"ref"`
+	Status    PipelineStatus `json:"status"`
+	WebURL    string         `json:"web_url"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func (p *Pipeline) Key() string { return strconv.Itoa(p.ID) }
+
+// ErrMergeRequestAlreadyExists is returned by CreateMergeRequest when an open
+// merge request already exists for the source branch.
+var ErrMergeRequestAlreadyExists = errors.New("merge request already exists")
+
+// CreateMergeRequestOpts are the options to create a merge request with.
+type CreateMergeRequestOpts struct {
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	Title        string `json:"title"`
+	Description  string `json:"description,omitempty"`
+}
+
+// CreateMergeRequest creates a merge request in the given project. If an open
+// merge request for the same source branch already exists,
+// ErrMergeRequestAlreadyExists is returned.
+func (c *Client) CreateMergeRequest(ctx context.Context, project *Project, opts CreateMergeRequestOpts) (*MergeRequest, error) {
+	if MockCreateMergeRequest != nil {
+		return MockCreateMergeRequest(c, ctx, project, opts)
+	}
+
+	req, err := newJSONRequest("POST", fmt.Sprintf("projects/%d/merge_requests", project.ID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var mr MergeRequest
+	if _, err := c.do(ctx, req, &mr); err != nil {
+		if HTTPErrorCode(err) == http.StatusConflict {
+			return nil, ErrMergeRequestAlreadyExists
+		}
+		return nil, err
+	}
+	return &mr, nil
+}
+
+// GetMergeRequest gets the merge request with the given IID in the given
+// project.
+func (c *Client) GetMergeRequest(ctx context.Context, project *Project, iid int) (*MergeRequest, error) {
+	if MockGetMergeRequest != nil {
+		return MockGetMergeRequest(c, ctx, project, iid)
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/merge_requests/%d", project.ID, iid), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var mr MergeRequest
+	if _, err := c.do(ctx, req, &mr); err != nil {
+		return nil, err
+	}
+	return &mr, nil
+}
+
+// GetOpenMergeRequestByRefs gets the open merge request from source into
+// target in the given project. If there is none, an error for which
+// IsNotFound returns true is returned.
+func (c *Client) GetOpenMergeRequestByRefs(ctx context.Context, project *Project, source, target string) (*MergeRequest, error) {
+	if MockGetOpenMergeRequestByRefs != nil {
+		return MockGetOpenMergeRequestByRefs(c, ctx, project, source, target)
+	}
+
+	q := url.Values{}
+	q.Set("state", string(MergeRequestStateOpened))
+	q.Set("source_branch", source)
+	q.Set("target_branch", target)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/merge_requests?%s", project.ID, q.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var mrs []*MergeRequest
+	if _, err := c.do(ctx, req, &mrs); err != nil {
+		return nil, err
+	}
+	if len(mrs) == 0 {
+		return nil, errors.Wrapf(httpError(http.StatusNotFound), "no open merge request from %q into %q", source, target)
+	}
+	return mrs[0], nil
+}
+
+// UpdateMergeRequestStateEvent changes the state of a merge request.
+type UpdateMergeRequestStateEvent string
+
+const (
+	UpdateMergeRequestStateEventClose  UpdateMergeRequestStateEvent = "close"
+	UpdateMergeRequestStateEventReopen UpdateMergeRequestStateEvent = "reopen"
+)
+
+// UpdateMergeRequestOpts are the options to update a merge request with.
+// Blank fields are left unchanged.
+type UpdateMergeRequestOpts struct {
+	TargetBranch string                       `json:"target_branch,omitempty"`
+	Title        string                       `json:"title,omitempty"`
+	Description  string                       `json:"description,omitempty"`
+	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+}
+
+// UpdateMergeRequest updates the given merge request and returns the updated
+// merge request.
+func (c *Client) UpdateMergeRequest(ctx context.Context, project *Project, mr *MergeRequest, opts UpdateMergeRequestOpts) (*MergeRequest, error) {
+	if MockUpdateMergeRequest != nil {
+		return MockUpdateMergeRequest(c, ctx, project, mr, opts)
+	}
+
+	req, err := newJSONRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d", project.ID, mr.IID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated MergeRequest
+	if _, err := c.do(ctx, req, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// LoadMergeRequestNotes loads all notes of the given merge request into
+// mr.Notes.
+func (c *Client) LoadMergeRequestNotes(ctx context.Context, project *Project, mr *MergeRequest) error {
+	if MockLoadMergeRequestNotes != nil {
+		return MockLoadMergeRequestNotes(c, ctx, project, mr)
+	}
+
+	var notes []*Note
+	err := c.getAllPages(ctx, fmt.Sprintf("projects/%d/merge_requests/%d/notes?per_page=100", project.ID, mr.IID), func() interface{} {
+		var page []*Note
+		return &page
+	}, func(page interface{}) {
+		notes = append(notes, *page.(*[]*Note)...)
+	})
+	if err != nil {
+		return err
+	}
+
+	mr.Notes = notes
+	return nil
+}
+
+// LoadMergeRequestPipelines loads all pipelines of the given merge request
+// into mr.Pipelines.
+func (c *Client) LoadMergeRequestPipelines(ctx context.Context, project *Project, mr *MergeRequest) error {
+	if MockLoadMergeRequestPipelines != nil {
+		return MockLoadMergeRequestPipelines(c, ctx, project, mr)
+	}
+
+	var pipelines []*Pipeline
+	err := c.getAllPages(ctx, fmt.Sprintf("projects/%d/merge_requests/%d/pipelines?per_page=100", project.ID, mr.IID), func() interface{} {
+		var page []*Pipeline
+		return &page
+	}, func(page interface{}) {
+		pipelines = append(pipelines, *page.(*[]*Pipeline)...)
+	})
+	if err != nil {
+		return err
+	}
+
+	mr.Pipelines = pipelines
+	return nil
+}
+
+// getAllPages follows the pagination links starting at urlStr, decoding each
+// page into a value created by newPage and passing it to addPage. See
+// https://docs.gitlab.com/ee/api/README.html#pagination-link-header.
+func (c *Client) getAllPages(ctx context.Context, urlStr string, newPage func() interface{}, addPage func(interface{})) error {
+	for urlStr != "" {
+		req, err := http.NewRequest("GET", urlStr, nil)
+		if err != nil {
+			return err
+		}
+
+		page := newPage()
+		respHeader, err := c.do(ctx, req, page)
+		if err != nil {
+			return err
+		}
+		addPage(page)
+
+		urlStr = ""
+		if l := link.Parse(respHeader.Get("Link"))["next"]; l != nil {
+			urlStr = l.URI
+		}
+	}
+	return nil
+}
+
+func newJSONRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	bs, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling request body")
+	}
+	return http.NewRequest(method, urlStr, bytes.NewReader(bs))
+}
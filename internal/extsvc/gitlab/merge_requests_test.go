@@ -0,0 +1,186 @@
+package gitlab
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+type mockHTTPResponses struct {
+	requests  []*http.Request
+	responses []*http.Response
+}
+
+func (s *mockHTTPResponses) Do(req *http.Request) (*http.Response, error) {
+	resp := s.responses[len(s.requests)]
+	s.requests = append(s.requests, req)
+	resp.Request = req
+	return resp, nil
+}
+
+func newMockResponse(code int, header http.Header, body string) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Header:     header,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func newMergeRequestTestClient(doer *mockHTTPResponses) *Client {
+	return &Client{
+		baseURL:          &url.URL{Scheme: "https", Host: "gitlab.example.com", Path: "/api/v4/"},
+		httpClient:       doer,
+		RateLimitMonitor: &ratelimit.Monitor{},
+	}
+}
+
+func TestClient_CreateMergeRequest(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{ProjectCommon: ProjectCommon{ID: 42}}
+	opts := CreateMergeRequestOpts{SourceBranch: "campaign", TargetBranch: "master", Title: "t"}
+
+	t.Run("created", func(t *testing.T) {
+		doer := &mockHTTPResponses{responses: []*http.Response{
+			newMockResponse(http.StatusCreated, nil, `{"id": 1, "iid": 2, "project_id": 42, "state": "opened"}`),
+		}}
+		mr, err := newMergeRequestTestClient(doer).CreateMergeRequest(ctx, project, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &MergeRequest{ID: 1, IID: 2, ProjectID: 42, State: MergeRequestStateOpened}
+		if !reflect.DeepEqual(mr, want) {
+			t.Errorf("got %+v, want %+v", mr, want)
+		}
+		if have, want := doer.requests[0].URL.String(), "https://gitlab.example.com/api/v4/projects/42/merge_requests"; have != want {
+			t.Errorf("got URL %q, want %q", have, want)
+		}
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		doer := &mockHTTPResponses{responses: []*http.Response{
+			newMockResponse(http.StatusConflict, nil, `{"message": ["Another open merge request already exists for this source branch: !2"]}`),
+		}}
+		_, err := newMergeRequestTestClient(doer).CreateMergeRequest(ctx, project, opts)
+		if err != ErrMergeRequestAlreadyExists {
+			t.Errorf("got error %v, want %v", err, ErrMergeRequestAlreadyExists)
+		}
+	})
+}
+
+func TestClient_LoadMergeRequestNotes(t *testing.T) {
+	doer := &mockHTTPResponses{responses: []*http.Response{
+		newMockResponse(http.StatusOK, http.Header{
+			"Link": []string{`<https://gitlab.example.com/api/v4/projects/42/merge_requests/2/notes?page=2&per_page=100>; rel="next"`},
+		}, `[{"id": 1, "body": "approved this merge request", "system": true}]`),
+		newMockResponse(http.StatusOK, nil, `[{"id": 2, "body": "lgtm"}]`),
+	}}
+
+	mr := &MergeRequest{IID: 2}
+	project := &Project{ProjectCommon: ProjectCommon{ID: 42}}
+	if err := newMergeRequestTestClient(doer).LoadMergeRequestNotes(context.Background(), project, mr); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Note{
+		{ID: 1, Body: "approved this merge request", System: true},
+		{ID: 2, Body: "lgtm"},
+	}
+	if !reflect.DeepEqual(mr.Notes, want) {
+		t.Errorf("got notes %+v, want %+v", mr.Notes, want)
+	}
+	if len(doer.requests) != 2 {
+		t.Errorf("got %d requests, want 2", len(doer.requests))
+	}
+}
+
+func TestNote_ToEvent(t *testing.T) {
+	for _, tc := range []struct {
+		note *Note
+		want interface{}
+	}{
+		{&Note{Body: "approved this merge request"}, nil},
+		{&Note{Body: "lgtm", System: true}, nil},
+		{&Note{Body: "approved this merge request", System: true}, &ReviewApprovedEvent{Note{Body: "approved this merge request", System: true}}},
+		{&Note{Body: "unapproved this merge request", System: true}, &ReviewUnapprovedEvent{Note{Body: "unapproved this merge request", System: true}}},
+		{&Note{Body: "closed", System: true}, &MergeRequestClosedEvent{Note{Body: "closed", System: true}}},
+		{&Note{Body: "reopened", System: true}, &MergeRequestReopenedEvent{Note{Body: "reopened", System: true}}},
+		{&Note{Body: "merged", System: true}, &MergeRequestMergedEvent{Note{Body: "merged", System: true}}},
+	} {
+		if have := tc.note.ToEvent(); !reflect.DeepEqual(have, tc.want) {
+			t.Errorf("%+v: got %#v, want %#v", tc.note, have, tc.want)
+		}
+	}
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	t.Run("merge request", func(t *testing.T) {
+		e, err := ParseWebhookEvent("Merge Request Hook", []byte(`{
+			"object_kind": "merge_request",
+			"user": {"id": 7, "name": "Alice", "username": "alice"},
+			"project": {"id": 42},
+			"object_attributes": {"id": 1, "iid": 2, "state": "opened", "action": "approved", "updated_at": "2020-05-01 10:00:00 UTC"}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mre := e.(*MergeRequestEvent)
+		if mre.Project.ID != 42 || mre.ObjectAttributes.IID != 2 {
+			t.Errorf("unexpected event %+v", mre)
+		}
+
+		want := &ReviewApprovedEvent{Note{
+			Body:      "approved this merge request",
+			Author:    User{ID: 7, Name: "Alice", Username: "alice"},
+			CreatedAt: time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+			System:    true,
+		}}
+		have := mre.ToEvent().(*ReviewApprovedEvent)
+		if !have.CreatedAt.Equal(want.CreatedAt) || have.Key() != want.Key() || have.Body != want.Body {
+			t.Errorf("got event %+v, want %+v", have, want)
+		}
+	})
+
+	t.Run("pipeline", func(t *testing.T) {
+		e, err := ParseWebhookEvent("Pipeline Hook", []byte(`{
+			"object_kind": "pipeline",
+			"project": {"id": 42},
+			"object_attributes": {"id": 31, "ref": "campaign", "sha": "abc", "status": "failed", "created_at": "2020-05-01T10:00:00Z", "finished_at": "2020-05-01T10:05:00Z"},
+			"merge_request": {"id": 1, "iid": 2}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pe := e.(*PipelineEvent)
+		if pe.MergeRequest == nil || pe.MergeRequest.IID != 2 {
+			t.Fatalf("unexpected merge request %+v", pe.MergeRequest)
+		}
+
+		want := &Pipeline{
+			ID:        31,
+			SHA:       "abc",
+			Ref:       "campaign",
+			Status:    PipelineStatusFailed,
+			CreatedAt: time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2020, 5, 1, 10, 5, 0, 0, time.UTC),
+		}
+		if have := pe.Pipeline(); !reflect.DeepEqual(have, want) {
+			t.Errorf("got pipeline %+v, want %+v", have, want)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if _, err := ParseWebhookEvent("Push Hook", []byte(`{}`)); err == nil {
+			t.Error("expected error for unknown event type")
+		}
+	})
+}
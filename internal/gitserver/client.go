@@ -60,6 +60,12 @@ func NewClient(cli httpcli.Doer) *Client {
 		Addrs: func(ctx context.Context) []string {
 			return conf.Get().ServiceConnections.GitServers
 		},
+		ReplicationFactor: func() int {
+			return conf.Get().ServiceConnections.GitServerReplicationFactor
+		},
+		PreviousAddrs: func(ctx context.Context) []string {
+			return conf.Get().ServiceConnections.GitServersPrevious
+		},
 		HTTPClient:  cli,
 		HTTPLimiter: parallel.NewRun(500),
 		// Use the binary name for UserAgent. This should effectively identify
@@ -82,6 +88,17 @@ type Client struct {
 	// concurrent use. It may return different results at different times.
 	Addrs func(ctx context.Context) []string
 
+	// ReplicationFactor is a function which returns the number of additional
+	// gitservers each repository is replicated to. A repository is replicated
+	// to the gitservers following its primary gitserver in Addrs. If nil,
+	// repositories are not replicated.
+	ReplicationFactor func() int
+
+	// PreviousAddrs is a function which returns the addresses of gitservers
+	// before the set of gitservers was changed. It returns nothing unless
+	// repositories are being rebalanced across gitservers. May be nil.
+	PreviousAddrs func(ctx context.Context) []string
+
 	// UserAgent is a string identifing who the client is. It will be logged in
 	// the telemetry in gitserver.
 	UserAgent string
@@ -104,9 +121,78 @@ func (c *Client) addrForKey(ctx context.Context, key string) string {
 }
 
 func addrForKey(addrs []string, key string) string {
+	return addrs[serverIndex(addrs, key)]
+}
+
+// AddrsForRepo returns the gitserver addresses which hold the given repo. The
+// first address is the primary gitserver of the repo, followed by its
+// replicas.
+func (c *Client) AddrsForRepo(ctx context.Context, repo api.RepoName) []string {
+	repo = protocol.NormalizeRepo(repo) // in case the caller didn't already normalize it
+	addrs := c.Addrs(ctx)
+	if len(addrs) == 0 {
+		panic("unexpected state: no gitserver addresses")
+	}
+	return addrsForKey(addrs, string(repo), c.replicationFactor())
+}
+
+// PreviousAddrsForRepo returns the addresses of the gitservers which held the
+// given repo before the set of gitservers was changed, but no longer hold it.
+// It returns nothing unless repositories are being rebalanced.
+func (c *Client) PreviousAddrsForRepo(ctx context.Context, repo api.RepoName) []string {
+	if c.PreviousAddrs == nil {
+		return nil
+	}
+	prev := c.PreviousAddrs(ctx)
+	if len(prev) == 0 || len(c.Addrs(ctx)) == 0 {
+		return nil
+	}
+	repo = protocol.NormalizeRepo(repo)
+	current := c.AddrsForRepo(ctx, repo)
+
+	var addrs []string
+	for _, addr := range addrsForKey(prev, string(repo), c.replicationFactor()) {
+		held := false
+		for _, a := range current {
+			if a == addr {
+				held = true
+				break
+			}
+		}
+		if !held {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+func (c *Client) replicationFactor() int {
+	if c.ReplicationFactor == nil {
+		return 0
+	}
+	return c.ReplicationFactor()
+}
+
+// addrsForKey returns the address key is sharded to followed by the next n
+// addresses, wrapping around at the end of addrs.
+func addrsForKey(addrs []string, key string, n int) []string {
+	if n > len(addrs)-1 {
+		n = len(addrs) - 1
+	}
+	if n < 0 {
+		n = 0
+	}
+	i := serverIndex(addrs, key)
+	replicas := make([]string, 0, n+1)
+	for j := 0; j <= n; j++ {
+		replicas = append(replicas, addrs[(i+j)%len(addrs)])
+	}
+	return replicas
+}
+
+func serverIndex(addrs []string, key string) int {
 	sum := md5.Sum([]byte(key))
-	serverIndex := binary.BigEndian.Uint64(sum[:]) % uint64(len(addrs))
-	return addrs[serverIndex]
+	return int(binary.BigEndian.Uint64(sum[:]) % uint64(len(addrs)))
 }
 
 // ArchiveOptions contains options for the Archive func.
@@ -181,8 +267,10 @@ func (c *Client) Archive(ctx context.Context, repo Repo, opt ArchiveOptions) (_
 		return nil, err
 	}
 
+	// Request the archive by op rather than by ArchiveURL so that we fail over
+	// to a replica if the primary gitserver of the repo is unavailable.
 	u := c.ArchiveURL(ctx, repo, opt)
-	resp, err := c.do(ctx, repo.Name, "GET", u.String(), nil)
+	resp, err := c.do(ctx, repo.Name, "GET", "archive?"+u.RawQuery, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -476,40 +564,51 @@ func (c *Client) ListGitolite(ctx context.Context, gitoliteHost string) (list []
 	return list, err
 }
 
-// ListCloned lists all cloned repositories
+// ListCloned lists all cloned repositories.
+//
+// If repositories are replicated, a repository is only listed once it is
+// cloned on its primary gitserver and all of its replicas. This ensures that
+// repositories missing on a gitserver (e.g. because it came back with an empty
+// disk) are treated as uncloned, and are re-replicated by the next update.
 func (c *Client) ListCloned(ctx context.Context) ([]string, error) {
 	var (
-		wg    sync.WaitGroup
-		mu    sync.Mutex
-		err   error
-		repos []string
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		err    error
+		copies = map[string]int{}
 	)
 	addrs := c.Addrs(ctx)
+	n := c.replicationFactor()
 	for _, addr := range addrs {
 		wg.Add(1)
 		go func(addr string) {
 			defer wg.Done()
 			r, e := c.doListOne(ctx, "?cloned", addr)
 
-			// Only include repos that belong on addr.
-			if len(r) > 0 {
-				filtered := r[:0]
-				for _, repo := range r {
-					if addrForKey(addrs, repo) == addr {
-						filtered = append(filtered, repo)
-					}
-				}
-				r = filtered
-			}
 			mu.Lock()
+			defer mu.Unlock()
 			if e != nil {
 				err = e
 			}
-			repos = append(repos, r...)
-			mu.Unlock()
+			// Only count repos that belong on addr.
+			for _, repo := range r {
+				for _, a := range addrsForKey(addrs, repo, n) {
+					if a == addr {
+						copies[repo]++
+						break
+					}
+				}
+			}
 		}(addr)
 	}
 	wg.Wait()
+
+	var repos []string
+	for repo, count := range copies {
+		if count == len(addrsForKey(addrs, repo, n)) {
+			repos = append(repos, repo)
+		}
+	}
 	return repos, err
 }
 
@@ -559,13 +658,35 @@ func (c *Client) doListOne(ctx context.Context, urlSuffix, addr string) ([]strin
 // Repo updates are not guaranteed to occur. If a repo has been updated
 // recently (within the Since duration specified in the request), the
 // update won't happen.
+//
+// If the repo is replicated, the update is requested on all of its replicas,
+// cloning it where it is missing. The response of the primary gitserver of the
+// repo is returned; failures on replicas are only logged.
 func (c *Client) RequestRepoUpdate(ctx context.Context, repo Repo, since time.Duration) (*protocol.RepoUpdateResponse, error) {
 	req := &protocol.RepoUpdateRequest{
 		Repo:  repo.Name,
 		URL:   repo.URL,
 		Since: since,
 	}
-	resp, err := c.httpPost(ctx, repo.Name, "repo-update", req)
+
+	addrs := c.AddrsForRepo(ctx, repo.Name)
+	var wg sync.WaitGroup
+	for _, addr := range addrs[1:] {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			if _, err := c.requestRepoUpdate(ctx, addr, req); err != nil {
+				log15.Warn("failed to update gitserver replica", "repo", repo.Name, "addr", addr, "error", err)
+			}
+		}(addr)
+	}
+	defer wg.Wait()
+
+	return c.requestRepoUpdate(ctx, addrs[0], req)
+}
+
+func (c *Client) requestRepoUpdate(ctx context.Context, addr string, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
+	resp, err := c.do(ctx, req.Repo, "POST", "http://"+addr+"/repo-update", req)
 	if err != nil {
 		return nil, err
 	}
@@ -804,12 +925,21 @@ func (c *Client) RepoInfo(ctx context.Context, repos ...api.RepoName) (*protocol
 	return &res, err.ErrorOrNil()
 }
 
-// Remove removes the repository clone from gitserver.
+// Remove removes the repository clone from gitserver and all of its
+// replicas.
 func (c *Client) Remove(ctx context.Context, repo api.RepoName) error {
 	req := &protocol.RepoDeleteRequest{
 		Repo: repo,
 	}
-	resp, err := c.httpPost(ctx, repo, "delete", req)
+	var err *multierror.Error
+	for _, addr := range c.AddrsForRepo(ctx, repo) {
+		err = multierror.Append(err, c.remove(ctx, addr, req))
+	}
+	return err.ErrorOrNil()
+}
+
+func (c *Client) remove(ctx context.Context, addr string, req *protocol.RepoDeleteRequest) error {
+	resp, err := c.do(ctx, req.Repo, "POST", "http://"+addr+"/delete", req)
 	if err != nil {
 		return err
 	}
@@ -822,16 +952,96 @@ func (c *Client) Remove(ctx context.Context, repo api.RepoName) error {
 	return nil
 }
 
+// RepoTransferred tells the gitserver at addr that repo has been transferred
+// to its new owner, so that addr can remove its copy of repo.
+func (c *Client) RepoTransferred(ctx context.Context, addr string, repo api.RepoName) error {
+	req := &protocol.RepoTransferredRequest{
+		Repo: repo,
+	}
+	resp, err := c.do(ctx, repo, "POST", "http://"+addr+"/repo-transferred", req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// best-effort inclusion of body in error message
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 200))
+		return &url.Error{URL: resp.Request.URL.String(), Op: "RepoTransferred", Err: fmt.Errorf("RepoTransferred: http status %d: %s", resp.StatusCode, string(body))}
+	}
+	return nil
+}
+
 func (c *Client) httpPost(ctx context.Context, repo api.RepoName, op string, payload interface{}) (resp *http.Response, err error) {
 	return c.do(ctx, repo, "POST", op, payload)
 }
 
+// failoverOps are the gitserver endpoints which are served by a replica of a
+// repository if its primary gitserver is unavailable. They only read from the
+// repository, so it does not matter which replica serves them.
+var failoverOps = map[string]bool{
+	"exec":    true,
+	"archive": true,
+	"repos":   true,
+}
+
+var failoverCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_client_failover_total",
+	Help: "Times that a request to gitserver was retried against a replica.",
+}, []string{"op"})
+
+func init() {
+	prometheus.MustRegister(failoverCounter)
+}
+
 // do performs a request to a gitserver, sharding based on the given
-// repo name (the repo name is otherwise not used).
+// repo name (the repo name is otherwise not used). If op is a failover
+// operation and the primary gitserver of the repo is unavailable, the request
+// is retried against its replicas.
 func (c *Client) do(ctx context.Context, repo api.RepoName, method, op string, payload interface{}) (resp *http.Response, err error) {
+	if strings.HasPrefix(op, "http") {
+		return c.doAddr(ctx, repo, method, op, payload)
+	}
+
+	addrs := c.AddrsForRepo(ctx, repo)
+	name := op
+	if i := strings.IndexByte(name, '?'); i >= 0 {
+		name = name[:i]
+	}
+	if !failoverOps[name] {
+		addrs = addrs[:1]
+	}
+
+	for i, addr := range addrs {
+		resp, err = c.doAddr(ctx, repo, method, "http://"+addr+"/"+op, payload)
+		if i == len(addrs)-1 || !shouldFailover(ctx, resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		failoverCounter.WithLabelValues(name).Inc()
+		log15.Warn("gitserver unavailable, failing over to replica", "repo", repo, "op", name, "addr", addr, "replica", addrs[i+1], "error", err)
+	}
+	return resp, err
+}
+
+// shouldFailover reports whether a request to a gitserver which resulted in
+// resp and err should be retried against a replica.
+func shouldFailover(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500
+}
+
+// doAddr performs a request to the gitserver at uri.
+func (c *Client) doAddr(ctx context.Context, repo api.RepoName, method, uri string, payload interface{}) (resp *http.Response, err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Client.do")
 	defer func() {
-		span.LogKV("repo", string(repo), "method", method, "op", op)
+		span.LogKV("repo", string(repo), "method", method, "uri", uri)
 		if err != nil {
 			ext.Error.Set(span, true)
 			span.SetTag("err", err.Error())
@@ -844,11 +1054,6 @@ func (c *Client) do(ctx context.Context, repo api.RepoName, method, op string, p
 		return nil, err
 	}
 
-	uri := op
-	if !strings.HasPrefix(op, "http") {
-		uri = "http://" + c.AddrForRepo(ctx, repo) + "/" + op
-	}
-
 	req, err := http.NewRequest(method, uri, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, err
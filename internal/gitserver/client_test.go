@@ -14,6 +14,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -57,6 +58,168 @@ func TestClient_ListCloned(t *testing.T) {
 	}
 }
 
+func TestClient_ListCloned_Replicated(t *testing.T) {
+	cli := &gitserver.Client{
+		Addrs:             func(ctx context.Context) []string { return []string{"gitserver-0", "gitserver-1"} },
+		ReplicationFactor: func() int { return 1 },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.String() {
+			case "http://gitserver-0/list?cloned":
+				return &http.Response{
+					Body: ioutil.NopCloser(bytes.NewBufferString(`["repo-a", "repo-b"]`)),
+				}, nil
+			case "http://gitserver-1/list?cloned":
+				// gitserver-1 came back with an empty disk and has only
+				// re-replicated repo-a so far.
+				return &http.Response{
+					Body: ioutil.NopCloser(bytes.NewBufferString(`["repo-a"]`)),
+				}, nil
+			default:
+				return nil, fmt.Errorf("unexpected url: %s", r.URL.String())
+			}
+		}),
+	}
+
+	want := []string{"repo-a"}
+	got, err := cli.ListCloned(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+		t.Errorf("mismatch for (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestClient_AddrsForRepo(t *testing.T) {
+	addrs := []string{"gitserver-0", "gitserver-1", "gitserver-2"}
+	for _, n := range []int{0, 1, 2, 5} {
+		cli := &gitserver.Client{
+			Addrs:             func(ctx context.Context) []string { return addrs },
+			ReplicationFactor: func() int { return n },
+		}
+		got := cli.AddrsForRepo(context.Background(), "github.com/foo/bar")
+		want := n + 1
+		if want > len(addrs) {
+			want = len(addrs)
+		}
+		if len(got) != want {
+			t.Fatalf("replication factor %d: got %d addrs %q, want %d", n, len(got), got, want)
+		}
+		if primary := cli.AddrForRepo(context.Background(), "github.com/foo/bar"); got[0] != primary {
+			t.Errorf("replication factor %d: got primary %q, want %q", n, got[0], primary)
+		}
+		seen := map[string]bool{}
+		for _, addr := range got {
+			if seen[addr] {
+				t.Errorf("replication factor %d: duplicate addr %q in %q", n, addr, got)
+			}
+			seen[addr] = true
+		}
+	}
+}
+
+func TestClient_PreviousAddrsForRepo(t *testing.T) {
+	ctx := context.Background()
+	cli := &gitserver.Client{
+		Addrs: func(ctx context.Context) []string { return []string{"gitserver-0", "gitserver-1", "gitserver-2"} },
+	}
+	if got := cli.PreviousAddrsForRepo(ctx, "github.com/foo/bar"); len(got) != 0 {
+		t.Errorf("expected no previous addrs outside of rebalancing, got %q", got)
+	}
+
+	// When growing from one to three gitservers, repos which moved off
+	// gitserver-0 are transferred from it.
+	cli.PreviousAddrs = func(ctx context.Context) []string { return []string{"gitserver-0"} }
+	moved := 0
+	for i := 0; i < 20; i++ {
+		repo := api.RepoName(fmt.Sprintf("github.com/foo/repo-%d", i))
+		got := cli.PreviousAddrsForRepo(ctx, repo)
+		if cli.AddrForRepo(ctx, repo) == "gitserver-0" {
+			if len(got) != 0 {
+				t.Errorf("%s: expected no previous addrs for repo which did not move, got %q", repo, got)
+			}
+			continue
+		}
+		moved++
+		if want := []string{"gitserver-0"}; !cmp.Equal(want, got) {
+			t.Errorf("%s: mismatch for (-want +got):\n%s", repo, cmp.Diff(want, got))
+		}
+	}
+	if moved == 0 {
+		t.Error("expected some repos to move")
+	}
+}
+
+func TestClient_Failover(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	var down string
+	cli := &gitserver.Client{
+		Addrs:             func(ctx context.Context) []string { return []string{"gitserver-0", "gitserver-1", "gitserver-2"} },
+		ReplicationFactor: func() int { return 1 },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requests = append(requests, r.URL.Host+r.URL.Path)
+			mu.Unlock()
+			if r.URL.Host == down {
+				return nil, errors.New("connection refused")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("out")),
+				Trailer:    http.Header{"X-Exec-Exit-Status": {"0"}},
+			}, nil
+		}),
+	}
+
+	ctx := context.Background()
+	repo := api.RepoName("github.com/foo/bar")
+	addrs := cli.AddrsForRepo(ctx, repo)
+	primary, replica := addrs[0], addrs[1]
+	down = primary
+
+	t.Run("exec", func(t *testing.T) {
+		requests = nil
+		cmd := cli.Command("git", "log")
+		cmd.Repo = gitserver.Repo{Name: repo}
+		out, err := cmd.Output(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != "out" {
+			t.Errorf("got output %q, want %q", out, "out")
+		}
+		if want := []string{primary + "/exec", replica + "/exec"}; !cmp.Equal(want, requests) {
+			t.Errorf("mismatch for (-want +got):\n%s", cmp.Diff(want, requests))
+		}
+	})
+
+	t.Run("no failover for writes", func(t *testing.T) {
+		requests = nil
+		if err := cli.IsRepoCloneable(ctx, gitserver.Repo{Name: repo}); err == nil {
+			t.Fatal("expected error")
+		}
+		if want := []string{primary + "/is-repo-cloneable"}; !cmp.Equal(want, requests) {
+			t.Errorf("mismatch for (-want +got):\n%s", cmp.Diff(want, requests))
+		}
+	})
+
+	t.Run("updates are sent to all replicas", func(t *testing.T) {
+		requests = nil
+		down = ""
+		if _, err := cli.RequestRepoUpdate(ctx, gitserver.Repo{Name: repo}, 0); err == nil {
+			// The fake response body is not a valid RepoUpdateResponse.
+			t.Fatal("expected decoding error")
+		}
+		sort.Strings(requests)
+		want := []string{primary + "/repo-update", replica + "/repo-update"}
+		sort.Strings(want)
+		if !cmp.Equal(want, requests) {
+			t.Errorf("mismatch for (-want +got):\n%s", cmp.Diff(want, requests))
+		}
+	})
+}
+
 func TestClient_Archive(t *testing.T) {
 	root, err := ioutil.TempDir("", t.Name())
 	if err != nil {
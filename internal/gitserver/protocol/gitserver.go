@@ -102,6 +102,14 @@ type RepoDeleteRequest struct {
 	Repo api.RepoName
 }
 
+// RepoTransferredRequest is a request sent by the new owner of a repository
+// to a gitserver which previously held it, once the repository has been
+// transferred to the new owner.
+type RepoTransferredRequest struct {
+	// Repo is the repository which was transferred.
+	Repo api.RepoName
+}
+
 // RepoInfoRequest is a request for information about multiple repositories on gitserver.
 type RepoInfoRequest struct {
 	// Repos are the repositories to get information about.
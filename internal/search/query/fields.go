@@ -20,6 +20,7 @@ var allFields = map[string]struct{}{
 	FieldContent:            empty,
 	FieldRepoHasFile:        empty,
 	FieldRepoHasCommitAfter: empty,
+	FieldContext:            empty,
 	FieldBefore:             empty,
 	"until":                 empty,
 	FieldAfter:              empty,
@@ -36,4 +37,5 @@ var allFields = map[string]struct{}{
 	FieldTimeout:            empty,
 	FieldReplace:            empty,
 	FieldCombyRule:          empty,
+	FieldSelect:             empty,
 }
@@ -104,14 +104,34 @@ func (p *parser) ParsePatternLiteral() Pattern {
 // parseParameterParameterList scans for consecutive leaf nodes.
 func (p *parser) parseParameterListLiteral() ([]Node, error) {
 	var nodes []Node
+	// negated is set if the next node is preceded by NOT. It is applied to
+	// the node at index start once that node is parsed.
+	var negated bool
+	var start int
+	applyNegation := func() (err error) {
+		if negated && len(nodes) > start {
+			nodes[start], err = negate(nodes[start])
+			negated = false
+		}
+		return err
+	}
 loop:
 	for {
+		if err := applyNegation(); err != nil {
+			return nil, err
+		}
 		if err := p.skipSpaces(); err != nil {
 			return nil, err
 		}
 		if p.done() {
 			break loop
 		}
+		if p.matchUnaryKeyword(NOT) {
+			p.pos += len(NOT)
+			negated = !negated
+			start = len(nodes)
+			continue
+		}
 		switch {
 		case p.match(LPAREN):
 			if value, advance, ok := ScanBalancedPatternLiteral(p.buf[p.pos:]); ok {
@@ -200,6 +220,12 @@ loop:
 			}
 		}
 	}
+	if err := applyNegation(); err != nil {
+		return nil, err
+	}
+	if negated {
+		return nil, &ExpectedOperand{Msg: fmt.Sprintf("expected operand after NOT at %d", p.pos)}
+	}
 	return partitionParameters(nodes), nil
 }
 
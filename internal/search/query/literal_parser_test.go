@@ -353,6 +353,21 @@ func TestParseAndOrLiteral(t *testing.T) {
 			WantError:  `i'm having trouble understanding that query. The combination of parentheses is the problem. Try using the content: filter to quote patterns that contain parentheses`,
 			WantLabels: "None",
 		},
+		{
+			Input:      `foo( and not bar(`,
+			Want:       `(and "foo(" "NOT bar(")`,
+			WantLabels: "HeuristicHoisted,Literal",
+		},
+		{
+			Input:      `foo bar not baz`,
+			Want:       `(and (concat "foo" "bar") "NOT baz")`,
+			WantLabels: "HeuristicHoisted,Literal",
+		},
+		{
+			Input:      `foo and not (bar or baz)`,
+			WantError:  "the NOT operator can only be used on search patterns and filters, not on groups of expressions",
+			WantLabels: "None",
+		},
 		// This test input should error because the single quote in 'after' is unclosed.
 		{
 			Input:      `type:commit message:'a commit message' after:'10 days ago" test test2`,
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ownerPredicate is the prefix of file: values that match the files owned by
+// an owner according to the CODEOWNERS file of their repository, as in
+// "file:has.owner(@team)".
+const ownerPredicate = "has.owner("
+
+// ParseOwnerPredicate returns the owner of a file: value of the form
+// "has.owner(<owner>)". It returns ok=false if the value isn't of that form,
+// in which case it is a regular file path pattern.
+func ParseOwnerPredicate(value string) (owner string, ok bool, err error) {
+	if len(value) < len(ownerPredicate) || !strings.EqualFold(value[:len(ownerPredicate)], ownerPredicate) || !strings.HasSuffix(value, ")") {
+		return "", false, nil
+	}
+	owner = strings.TrimSpace(value[len(ownerPredicate) : len(value)-1])
+	if owner == "" || strings.ContainsAny(owner, "()") {
+		return "", true, fmt.Errorf("invalid value file:%s, specify an owner like file:has.owner(@team) or file:has.owner(alice@example.com)", value)
+	}
+	return owner, true, nil
+}
+
+// scanOwnerPredicate scans a field value of the form "has.owner(<owner>)",
+// which contains parentheses that otherwise end field values. It returns the
+// value, how much was advanced, and whether buf starts with such a value.
+func scanOwnerPredicate(buf []byte) (string, int, bool) {
+	if len(buf) < len(ownerPredicate) || !bytes.EqualFold(buf[:len(ownerPredicate)], []byte(ownerPredicate)) {
+		return "", 0, false
+	}
+	end := bytes.IndexByte(buf, ')')
+	if end < 0 {
+		return "", 0, false
+	}
+	advance := end + 1
+	// The value must end at the closing parenthesis.
+	if r, _ := utf8.DecodeRune(buf[advance:]); advance < len(buf) && !unicode.IsSpace(r) && r != ')' {
+		return "", 0, false
+	}
+	return string(buf[:advance]), advance, true
+}
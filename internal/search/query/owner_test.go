@@ -0,0 +1,46 @@
+package query
+
+import (
+	"testing"
+)
+
+func TestParseOwnerPredicate(t *testing.T) {
+	for _, tc := range []struct {
+		input   string
+		want    string
+		wantOk  bool
+		wantErr bool
+	}{
+		{input: "has.owner(@org/team)", want: "@org/team", wantOk: true},
+		{input: "Has.Owner(alice@example.com)", want: "alice@example.com", wantOk: true},
+		{input: "has.owner()", wantOk: true, wantErr: true},
+		{input: `\.go$`},
+		{input: "has.owner"},
+	} {
+		got, ok, err := ParseOwnerPredicate(tc.input)
+		if got != tc.want || ok != tc.wantOk || (err != nil) != tc.wantErr {
+			t.Errorf("%q: got (%q, %v, %v), want (%q, %v, error %v)", tc.input, got, ok, err, tc.want, tc.wantOk, tc.wantErr)
+		}
+	}
+}
+
+func TestParseAndOr_ownerPredicate(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  string
+	}{
+		{input: "foo file:has.owner(@team)", want: `(and "file:has.owner(@team)" "foo")`},
+		{input: "(a or b) -file:has.owner(@org/team)", want: `(and "-file:has.owner(@org/team)" (or "a" "b"))`},
+		{input: "(a file:has.owner(@team))", want: `(and "file:has.owner(@team)" "a")`},
+		// Only values that end at the closing parenthesis are predicates.
+		{input: "file:has.owner(a)b", want: `(and "file:has.owner" "(a)b")`},
+	} {
+		nodes, err := ParseAndOr(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := prettyPrint(nodes); got != tc.want {
+			t.Errorf("%q: got %s, want %s", tc.input, got, tc.want)
+		}
+	}
+}
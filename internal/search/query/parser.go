@@ -16,7 +16,8 @@ Parser implements a parser for the following grammar:
 OrTerm     → AndTerm { OR AndTerm }
 AndTerm    → Term { AND Term }
 Term       → (OrTerm) | Parameters
-Parameters → Parameter { " " Parameter }
+Parameters → Operand { " " Operand }
+Operand    → [ NOT ] Parameter
 */
 
 type Node interface {
@@ -110,6 +111,7 @@ type keyword string
 const (
 	AND    keyword = "and"
 	OR     keyword = "or"
+	NOT    keyword = "not"
 	LPAREN keyword = "("
 	RPAREN keyword = ")"
 	SQUOTE keyword = "'"
@@ -236,6 +238,29 @@ func (p *parser) matchKeyword(keyword keyword) bool {
 	return strings.EqualFold(v, string(keyword))
 }
 
+// matchUnaryKeyword is like match but expects the keyword to be followed by
+// whitespace, and to start the input or a group, or be preceded by whitespace.
+func (p *parser) matchUnaryKeyword(keyword keyword) bool {
+	// Skip over parentheses opening groups, and check that they start the
+	// input or follow whitespace. This avoids matching "(not" in "foo(not bar)".
+	start := p.pos
+	for start > 0 && p.buf[start-1] == '(' {
+		start--
+	}
+	if start > 0 && !isSpace(p.buf[start-1:start]) {
+		return false
+	}
+	v, err := p.peek(len(string(keyword)))
+	if err != nil {
+		return false
+	}
+	after := p.pos + len(string(keyword))
+	if after >= len(p.buf) || !isSpace(p.buf[after:after+1]) {
+		return false
+	}
+	return strings.EqualFold(v, string(keyword))
+}
+
 // skipSpaces advances the input and places the parser position at the next
 // non-space value.
 func (p *parser) skipSpaces() error {
@@ -587,6 +612,10 @@ func (p *parser) ParseFieldValue() (string, error) {
 	if p.match(DQUOTE) {
 		return delimited('"')
 	}
+	if value, advance, ok := scanOwnerPredicate(p.buf[p.pos:]); ok {
+		p.pos += advance
+		return value, nil
+	}
 	value, advance, _ := ScanValue(p.buf[p.pos:], isSet(p.heuristics, allowDanglingParens))
 	p.pos += advance
 	return value, nil
@@ -649,12 +678,19 @@ func (p *parser) ParseParameter() (Parameter, bool, error) {
 // are concatenated in order.
 // (2) Any nonterminal node is concatenated (ordered in the tree) if its
 // descendents contain one or more search patterns.
+//
+// Negated search patterns are never concatenated: "foo bar NOT baz" means
+// "foo bar" AND NOT "baz".
 func partitionParameters(nodes []Node) []Node {
-	var patterns, unorderedParams []Node
+	var patterns, negatedPatterns, unorderedParams []Node
 	for _, n := range nodes {
-		switch n.(type) {
+		switch v := n.(type) {
 		case Pattern:
-			patterns = append(patterns, n)
+			if v.Negated {
+				negatedPatterns = append(negatedPatterns, n)
+			} else {
+				patterns = append(patterns, n)
+			}
 		case Parameter:
 			unorderedParams = append(unorderedParams, n)
 		case Operator:
@@ -666,23 +702,60 @@ func partitionParameters(nodes []Node) []Node {
 		}
 	}
 	if len(patterns) > 1 {
-		orderedPatterns := newOperator(patterns, Concat)
-		return newOperator(append(unorderedParams, orderedPatterns...), And)
+		patterns = newOperator(patterns, Concat)
+	}
+	nodes = append(unorderedParams, patterns...)
+	return newOperator(append(nodes, negatedPatterns...), And)
+}
+
+// negate negates a node preceded by the NOT keyword. Only search patterns and
+// parameters can be negated.
+func negate(node Node) (Node, error) {
+	switch v := node.(type) {
+	case Pattern:
+		v.Negated = !v.Negated
+		return v, nil
+	case Parameter:
+		// An empty parameter denotes an empty group "()".
+		if v.Field != "" {
+			v.Negated = !v.Negated
+			return v, nil
+		}
 	}
-	return newOperator(append(unorderedParams, patterns...), And)
+	return nil, &UnsupportedError{Msg: "the NOT operator can only be used on search patterns and filters, not on groups of expressions"}
 }
 
 // parseParameterParameterList scans for consecutive leaf nodes.
 func (p *parser) parseParameterList() ([]Node, error) {
 	var nodes []Node
+	// negated is set if the next node is preceded by NOT. It is applied to
+	// the node at index start once that node is parsed.
+	var negated bool
+	var start int
+	applyNegation := func() (err error) {
+		if negated && len(nodes) > start {
+			nodes[start], err = negate(nodes[start])
+			negated = false
+		}
+		return err
+	}
 loop:
 	for {
+		if err := applyNegation(); err != nil {
+			return nil, err
+		}
 		if err := p.skipSpaces(); err != nil {
 			return nil, err
 		}
 		if p.done() {
 			break loop
 		}
+		if p.matchUnaryKeyword(NOT) {
+			p.pos += len(NOT)
+			negated = !negated
+			start = len(nodes)
+			continue
+		}
 		switch {
 		case p.match(LPAREN) && !isSet(p.heuristics, allowDanglingParens):
 			// First try parse a parameter as a search pattern containing parens.
@@ -735,6 +808,12 @@ loop:
 			}
 		}
 	}
+	if err := applyNegation(); err != nil {
+		return nil, err
+	}
+	if negated {
+		return nil, &ExpectedOperand{Msg: fmt.Sprintf("expected operand after NOT at %d", p.pos)}
+	}
 	return partitionParameters(nodes), nil
 }
 
@@ -874,6 +953,10 @@ func ParseAndOr(in string) ([]Node, error) {
 
 	nodes, err := parser.parseOr()
 	if err != nil {
+		if _, ok := err.(*UnsupportedError); ok {
+			// The query is well-formed, but we can't evaluate it.
+			return nil, err
+		}
 		if nodes, err := tryFallbackParser(in); err == nil {
 			return nodes, nil
 		}
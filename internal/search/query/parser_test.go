@@ -662,6 +662,59 @@ func TestParse(t *testing.T) {
 			WantGrammar:   `(and "repo:foo bar" ":\\")`,
 			WantHeuristic: Same,
 		},
+		{
+			Name:          "Not",
+			Input:         "a and not b",
+			WantGrammar:   `(and "a" "NOT b")`,
+			WantHeuristic: Same,
+		},
+		{
+			Name:          "Not without and",
+			Input:         "a b NOT c",
+			WantGrammar:   `(and (concat "a" "b") "NOT c")`,
+			WantHeuristic: Same,
+		},
+		{
+			Name:          "Not in group",
+			Input:         "(a and not b) or c",
+			WantGrammar:   `(or (and "a" "NOT b") "c")`,
+			WantHeuristic: Same,
+		},
+		{
+			Name:          "Not on parameter",
+			Input:         "not repo:foo a",
+			WantGrammar:   `(and "-repo:foo" "a")`,
+			WantHeuristic: Same,
+		},
+		{
+			Name:          "Double not",
+			Input:         "a and not not b",
+			WantGrammar:   `(and "a" "b")`,
+			WantHeuristic: Same,
+		},
+		{
+			Name:          "Not on group",
+			Input:         "a and not (b or c)",
+			WantGrammar:   "the NOT operator can only be used on search patterns and filters, not on groups of expressions",
+			WantHeuristic: Same,
+		},
+		{
+			Name:          "Dangling not",
+			Input:         "a and not ",
+			WantGrammar:   "expected operand after NOT at 10",
+			WantHeuristic: Same,
+		},
+		{
+			Name:          "Not is a pattern if it is not a keyword",
+			Input:         "a and not",
+			WantGrammar:   `(and "a" "not")`,
+			WantHeuristic: Same,
+		},
+		{
+			Input:         "foo(not bar)",
+			WantGrammar:   Spec(`(concat "foo" "not" "bar")`),
+			WantHeuristic: Diff(`(concat "foo(not" "bar)")`),
+		},
 	}
 	for _, tt := range cases {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -26,6 +26,7 @@ const (
 	FieldPatternType        = "patterntype"
 	FieldContent            = "content"
 	FieldVisibility         = "visibility"
+	FieldContext            = "context"
 
 	// For diff and commit search only:
 	FieldBefore    = "before"
@@ -42,6 +43,7 @@ const (
 	FieldTimeout   = "timeout"
 	FieldReplace   = "replace"
 	FieldCombyRule = "rule"
+	FieldSelect    = "select" // Projects results to repositories, files, symbols or a capture group of the search pattern.
 )
 
 var (
@@ -62,6 +64,7 @@ var (
 			FieldPatternType: {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 			FieldContent:     {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 			FieldVisibility:  {Literal: types.StringType, Quoted: types.StringType, Singular: true},
+			FieldContext:     {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 
 			FieldRepoHasFile:        regexpNegatableFieldType,
 			FieldRepoHasCommitAfter: {Literal: types.StringType, Quoted: types.StringType, Singular: true},
@@ -80,6 +83,7 @@ var (
 			FieldTimeout:   {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 			FieldReplace:   {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 			FieldCombyRule: {Literal: types.StringType, Quoted: types.StringType, Singular: true},
+			FieldSelect:    {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 		},
 		FieldAliases: map[string]string{
 			"r":        FieldRepo,
@@ -166,6 +170,11 @@ func (e *ValidationError) Error() string {
 // Validate validates legal combinations of fields and search patterns of a
 // successfully parsed query.
 func Validate(q QueryInfo, searchType SearchType) error {
+	if value, _ := q.StringValue(FieldSelect); value != "" {
+		if _, err := ParseSelect(value); err != nil {
+			return err
+		}
+	}
 	if searchType == SearchTypeStructural {
 		if q.Fields()[FieldCase] != nil {
 			return errors.New(`the parameter "case:" is not valid for structural search, matching is always case-sensitive`)
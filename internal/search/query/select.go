@@ -0,0 +1,46 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type SelectType string
+
+const (
+	SelectRepo   SelectType = "repo"
+	SelectFile   SelectType = "file"
+	SelectSymbol SelectType = "symbol"
+	SelectGroup  SelectType = "group"
+)
+
+// Select is the projection of search results requested with "select:". For
+// example, "select:repo" returns each repository containing results once, and
+// "select:group.1" returns each distinct value of the first capture group of
+// the search pattern.
+type Select struct {
+	Type SelectType
+
+	// Group is the number or name of the capture group selected by
+	// "select:group.<group>".
+	Group string
+}
+
+var validCaptureGroupName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// ParseSelect parses the value of a "select:" field.
+func ParseSelect(s string) (*Select, error) {
+	switch v := strings.ToLower(s); v {
+	case string(SelectRepo), string(SelectFile), string(SelectSymbol):
+		return &Select{Type: SelectType(v)}, nil
+	}
+	if strings.HasPrefix(strings.ToLower(s), string(SelectGroup)+".") {
+		group := s[len(SelectGroup)+1:]
+		if !validCaptureGroupName.MatchString(group) {
+			return nil, fmt.Errorf("invalid capture group %q in select:%s, specify a capture group number or name like select:group.1", group, s)
+		}
+		return &Select{Type: SelectGroup, Group: group}, nil
+	}
+	return nil, fmt.Errorf("invalid value select:%s, valid values are select:repo, select:file, select:symbol and select:group.<number or name>", s)
+}
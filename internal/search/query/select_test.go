@@ -0,0 +1,32 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSelect(t *testing.T) {
+	for _, tc := range []struct {
+		input   string
+		want    *Select
+		wantErr bool
+	}{
+		{input: "repo", want: &Select{Type: SelectRepo}},
+		{input: "File", want: &Select{Type: SelectFile}},
+		{input: "symbol", want: &Select{Type: SelectSymbol}},
+		{input: "group.1", want: &Select{Type: SelectGroup, Group: "1"}},
+		{input: "group.pkgName", want: &Select{Type: SelectGroup, Group: "pkgName"}},
+		{input: "group.", wantErr: true},
+		{input: "group.a-b", wantErr: true},
+		{input: "content", wantErr: true},
+	} {
+		got, err := ParseSelect(tc.input)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%q: got error %v, want error %v", tc.input, err, tc.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: got %+v, want %+v", tc.input, got, tc.want)
+		}
+	}
+}
@@ -116,11 +116,15 @@ func (q AndOrQuery) StringValue(field string) (value, negatedValue string) {
 	return value, negatedValue
 }
 
+// Values returns the values of field. For the default field, it returns the
+// values of search patterns that are not negated.
 func (q AndOrQuery) Values(field string) []*types.Value {
 	var values []*types.Value
 	if field == "" {
-		VisitPattern(q.Query, func(value string, _ bool, annotation Annotation) {
-			values = append(values, q.valueToTypedValue(field, value, annotation.Labels)...)
+		VisitPattern(q.Query, func(value string, negated bool, annotation Annotation) {
+			if !negated {
+				values = append(values, q.valueToTypedValue(field, value, annotation.Labels)...)
+			}
 		})
 	} else {
 		VisitField(q.Query, field, func(value string, _ bool) {
@@ -130,10 +134,23 @@ func (q AndOrQuery) Values(field string) []*types.Value {
 	return values
 }
 
+// NegatedPatternValues returns the values of negated search patterns.
+func (q AndOrQuery) NegatedPatternValues() []*types.Value {
+	var values []*types.Value
+	VisitPattern(q.Query, func(value string, negated bool, annotation Annotation) {
+		if negated {
+			values = append(values, q.valueToTypedValue("", value, annotation.Labels)...)
+		}
+	})
+	return values
+}
+
 func (q AndOrQuery) Fields() map[string][]*types.Value {
 	fields := make(map[string][]*types.Value)
-	VisitPattern(q.Query, func(value string, _ bool, _ Annotation) {
-		fields[""] = q.Values("")
+	VisitPattern(q.Query, func(value string, negated bool, _ Annotation) {
+		if !negated {
+			fields[""] = q.Values("")
+		}
 	})
 	VisitParameter(q.Query, func(field, _ string, _ bool) {
 		fields[field] = q.Values(field)
@@ -240,6 +257,7 @@ func (q AndOrQuery) valueToTypedValue(field, value string, label labels) []*type
 
 	case
 		FieldRepoHasCommitAfter,
+		FieldContext,
 		FieldBefore, "until",
 		FieldAfter, "since":
 		return []*types.Value{{String: &value}}
@@ -256,7 +274,8 @@ func (q AndOrQuery) valueToTypedValue(field, value string, label labels) []*type
 		FieldMax,
 		FieldTimeout,
 		FieldReplace,
-		FieldCombyRule:
+		FieldCombyRule,
+		FieldSelect:
 		return []*types.Value{{String: &value}}
 	}
 	return []*types.Value{{String: &value}}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -63,12 +64,17 @@ func containsAndOrExpression(nodes []Node) bool {
 	})
 }
 
-// ContainsAndOrKeyword returns true if this query contains or- or and-
+// ContainsAndOrKeyword returns true if this query contains or-, and- or not-
 // keywords. It is a temporary signal to determine whether we can fallback to
 // the older existing search functionality.
 func ContainsAndOrKeyword(input string) bool {
 	lower := strings.ToLower(input)
-	return strings.Contains(lower, " and ") || strings.Contains(lower, " or ")
+	return strings.Contains(lower, " and ") ||
+		strings.Contains(lower, " or ") ||
+		strings.Contains(lower, " not ") ||
+		strings.Contains(lower, " (not ") ||
+		strings.HasPrefix(lower, "not ") ||
+		strings.HasPrefix(lower, "(not ")
 }
 
 // ContainsRegexpMetasyntax returns true if a string is a valid regular
@@ -232,6 +238,11 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return nil
 	}
 
+	isSelect := func() error {
+		_, err := ParseSelect(value)
+		return err
+	}
+
 	isUnrecognizedField := func() error {
 		return fmt.Errorf("unrecognized field %q", field)
 	}
@@ -279,7 +290,8 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldRepoHasFile:
 		return satisfies(isValidRegexp)
 	case
-		FieldRepoHasCommitAfter:
+		FieldRepoHasCommitAfter,
+		FieldContext:
 		return satisfies(isSingular, isNotNegated)
 	case
 		FieldBefore,
@@ -305,12 +317,63 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldReplace,
 		FieldCombyRule:
 		return satisfies(isSingular, isNotNegated)
+	case
+		FieldSelect:
+		return satisfies(isSingular, isSelect, isNotNegated)
 	default:
 		return isUnrecognizedField()
 	}
 	return nil
 }
 
+// isPositivePatternExpression returns whether node contains search patterns,
+// and whether it can be evaluated by searching for them. Expressions that are
+// satisfied by every file not matching a negated pattern, like "NOT foo" or
+// "foo OR NOT bar", can't be evaluated.
+func isPositivePatternExpression(node Node) (positive, hasPattern bool) {
+	switch v := node.(type) {
+	case Pattern:
+		return !v.Negated, true
+	case Operator:
+		// And-expressions are positive if any operand is. Or-expressions
+		// and concatenations are positive if all operands are.
+		positive = v.Kind != And
+		for _, operand := range v.Operands {
+			operandPositive, operandHasPattern := isPositivePatternExpression(operand)
+			if !operandHasPattern {
+				continue
+			}
+			hasPattern = true
+			if v.Kind == And {
+				positive = positive || operandPositive
+			} else {
+				positive = positive && operandPositive
+			}
+		}
+		return positive && hasPattern, hasPattern
+	}
+	return false, false
+}
+
+// validateNegatedPatterns validates that every negated search pattern is part
+// of an and-expression that also contains a non-negated search pattern, so that
+// it can be evaluated as a set difference.
+func validateNegatedPatterns(nodes []Node) error {
+	valid := func(kind operatorKind, operands []Node) bool {
+		positive, hasPattern := isPositivePatternExpression(Operator{Kind: kind, Operands: operands})
+		return positive || !hasPattern
+	}
+	// The top level of a query is an implicit and-expression.
+	ok := valid(And, nodes)
+	VisitOperator(nodes, func(kind operatorKind, operands []Node) {
+		ok = ok && valid(kind, operands)
+	})
+	if !ok {
+		return errors.New("negated search patterns must be combined with a search pattern that is not negated using AND, as in 'foo AND NOT bar'")
+	}
+	return nil
+}
+
 func validate(nodes []Node) error {
 	var err error
 	seen := map[string]struct{}{}
@@ -321,5 +384,8 @@ func validate(nodes []Node) error {
 		err = validateField(field, value, negated, seen)
 		seen[field] = struct{}{}
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	return validateNegatedPatterns(nodes)
 }
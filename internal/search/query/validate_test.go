@@ -45,6 +45,30 @@ func TestAndOrQuery_Validation(t *testing.T) {
 			input: "count:-1",
 			want:  "field count requires a positive number",
 		},
+		{
+			input: "context:a context:b",
+			want:  `field "context" may not be used more than once`,
+		},
+		{
+			input: "select:commit",
+			want:  "invalid value select:commit, valid values are select:repo, select:file, select:symbol and select:group.<number or name>",
+		},
+		{
+			input: "select:repo select:file",
+			want:  `field "select" may not be used more than once`,
+		},
+		{
+			input: "not foo",
+			want:  "negated search patterns must be combined with a search pattern that is not negated using AND, as in 'foo AND NOT bar'",
+		},
+		{
+			input: "foo or not bar",
+			want:  "negated search patterns must be combined with a search pattern that is not negated using AND, as in 'foo AND NOT bar'",
+		},
+		{
+			input: "foo and (bar or not baz)",
+			want:  "negated search patterns must be combined with a search pattern that is not negated using AND, as in 'foo AND NOT bar'",
+		},
 	}
 	for _, c := range cases {
 		t.Run("validate and/or query", func(t *testing.T) {
@@ -249,9 +273,39 @@ func TestContainsAndOrKeyword(t *testing.T) {
 	if !ContainsAndOrKeyword("repo:foo AND bar") {
 		t.Errorf("Expected query to contain keyword")
 	}
+	if !ContainsAndOrKeyword("foo NOT bar") {
+		t.Errorf("Expected query to contain keyword")
+	}
+	if !ContainsAndOrKeyword("foo and (not bar)") {
+		t.Errorf("Expected query to contain keyword")
+	}
 	if ContainsAndOrKeyword("repo:foo bar") {
 		t.Errorf("Did not expect query to contain keyword")
 	}
+	if ContainsAndOrKeyword("foo(not bar)") {
+		t.Errorf("Did not expect query to contain keyword")
+	}
+}
+
+func TestAndOrQuery_NegatedPatterns(t *testing.T) {
+	q, err := ProcessAndOr("repo:foo a and not b and not c", SearchTypeLiteral)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var values, negatedValues []string
+	for _, v := range q.Values(FieldDefault) {
+		values = append(values, v.ToString())
+	}
+	for _, v := range q.(*AndOrQuery).NegatedPatternValues() {
+		negatedValues = append(negatedValues, v.ToString())
+	}
+	if diff := cmp.Diff([]string{"a"}, values); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"b", "c"}, negatedValues); diff != "" {
+		t.Error(diff)
+	}
 }
 
 func TestForAll(t *testing.T) {
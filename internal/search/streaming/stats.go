@@ -0,0 +1,40 @@
+// Package streaming contains the types shared by the producers and the consumers
+// of search results that are streamed to clients as they are found.
+package streaming
+
+import "github.com/sourcegraph/sourcegraph/internal/api"
+
+// Stats describes the repositories searched to produce a batch of streamed
+// search results. The stats of a whole search are the union of the stats of
+// all of its batches.
+type Stats struct {
+	// LimitHit is true if results were omitted because a limit on the number
+	// of results was hit.
+	LimitHit bool
+
+	Searched []api.RepoName // repos that were searched
+	Indexed  []api.RepoName // repos that were searched using an index
+	Cloning  []api.RepoName // repos that could not be searched because they were still being cloned
+	Missing  []api.RepoName // repos that could not be searched because they do not exist
+	Timedout []api.RepoName // repos that could not be searched before the deadline
+}
+
+// Update merges other into s.
+func (s *Stats) Update(other Stats) {
+	s.LimitHit = s.LimitHit || other.LimitHit
+	s.Searched = append(s.Searched, other.Searched...)
+	s.Indexed = append(s.Indexed, other.Indexed...)
+	s.Cloning = append(s.Cloning, other.Cloning...)
+	s.Missing = append(s.Missing, other.Missing...)
+	s.Timedout = append(s.Timedout, other.Timedout...)
+}
+
+// Zero returns true if s does not describe any repository.
+func (s *Stats) Zero() bool {
+	return !s.LimitHit &&
+		len(s.Searched) == 0 &&
+		len(s.Indexed) == 0 &&
+		len(s.Cloning) == 0 &&
+		len(s.Missing) == 0 &&
+		len(s.Timedout) == 0
+}
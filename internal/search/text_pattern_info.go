@@ -6,7 +6,7 @@ import (
 )
 
 func (p *TextPatternInfo) IsEmpty() bool {
-	return p.Pattern == "" && p.ExcludePattern == "" && len(p.IncludePatterns) == 0
+	return p.Pattern == "" && p.ExcludePattern == "" && len(p.IncludePatterns) == 0 && len(p.IncludeOwners) == 0 && len(p.ExcludeOwners) == 0
 }
 
 func (p *TextPatternInfo) Validate() error {
@@ -119,9 +119,19 @@ type TextPatternInfo struct {
 	IncludePatterns []string
 	ExcludePattern  string
 
+	// ExcludeContentPatterns are regular expressions that may not match the
+	// content of returned files. They correspond to negated search patterns.
+	ExcludeContentPatterns []string
+
 	FilePatternsReposMustInclude []string
 	FilePatternsReposMustExclude []string
 
+	// IncludeOwners and ExcludeOwners are the owners that files must and must
+	// not have according to the CODEOWNERS file of their repository, from
+	// file:has.owner(...) and -file:has.owner(...) filters.
+	IncludeOwners []string
+	ExcludeOwners []string
+
 	PathPatternsAreRegExps       bool
 	PathPatternsAreCaseSensitive bool
 
@@ -168,6 +178,12 @@ func (p *TextPatternInfo) String() string {
 	for _, dec := range p.FilePatternsReposMustExclude {
 		args = append(args, fmt.Sprintf("-repositoryPathPattern:%s", dec))
 	}
+	for _, owner := range p.IncludeOwners {
+		args = append(args, fmt.Sprintf("owner:%s", owner))
+	}
+	for _, owner := range p.ExcludeOwners {
+		args = append(args, fmt.Sprintf("-owner:%s", owner))
+	}
 
 	path := "glob"
 	if p.PathPatternsAreRegExps {
@@ -249,6 +249,12 @@ func TestRepository_Commits_options(t *testing.T) {
 			wantCommits: wantGitCommits,
 			wantTotal:   1,
 		},
+		"git cmd Before": {
+			repo:        MakeGitRepository(t, gitCommands...),
+			opt:         CommitsOptions{Range: "ade564eba4cf904492fb56dcd287ac633e6e082c", N: 1, Before: "2006-01-02T15:04:07Z"},
+			wantCommits: wantGitCommits,
+			wantTotal:   1,
+		},
 		"git cmd Head": {
 			repo: MakeGitRepository(t, gitCommands...),
 			opt: CommitsOptions{
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE query_runner_state ADD COLUMN IF NOT EXISTS result_fingerprint bytea;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS action_jobs;
+DROP TABLE IF EXISTS action_executions;
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS action_executions (
+  id bigserial PRIMARY KEY,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  definition text NOT NULL,
+  patch_set_id bigint REFERENCES patch_sets(id) ON DELETE SET NULL DEFERRABLE,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+  finished_at timestamp with time zone
+);
+
+CREATE TABLE IF NOT EXISTS action_jobs (
+  id bigserial PRIMARY KEY,
+  execution_id bigint NOT NULL REFERENCES action_executions(id) ON DELETE CASCADE DEFERRABLE,
+  repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+  rev text NOT NULL DEFAULT '',
+  base_ref text NOT NULL DEFAULT '',
+  diff text NOT NULL DEFAULT '',
+  log text NOT NULL DEFAULT '',
+  error text NOT NULL DEFAULT '',
+  attempts integer NOT NULL DEFAULT 0,
+  started_at timestamp with time zone,
+  finished_at timestamp with time zone,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+  CONSTRAINT action_jobs_unique UNIQUE (execution_id, repo_id)
+);
+
+CREATE INDEX IF NOT EXISTS action_jobs_started_at ON action_jobs (started_at);
+CREATE INDEX IF NOT EXISTS action_jobs_finished_at ON action_jobs (finished_at);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS search_trend_points;
+DROP TABLE IF EXISTS search_trend_series;
+
+COMMIT;
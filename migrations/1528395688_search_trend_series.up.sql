@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS search_trend_series (
+  id serial PRIMARY KEY,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  query text NOT NULL,
+  start_time timestamp with time zone NOT NULL,
+  interval text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS search_trend_series_user_id ON search_trend_series (user_id);
+
+CREATE TABLE IF NOT EXISTS search_trend_points (
+  series_id integer NOT NULL REFERENCES search_trend_series(id) ON DELETE CASCADE DEFERRABLE,
+  repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+  time timestamp with time zone NOT NULL,
+  commit text NOT NULL,
+  count integer NOT NULL,
+  recorded_at timestamp with time zone NOT NULL DEFAULT now(),
+  PRIMARY KEY (series_id, repo_id, time)
+);
+
+COMMIT;
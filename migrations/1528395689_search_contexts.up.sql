@@ -0,0 +1,29 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS search_contexts (
+  id serial PRIMARY KEY,
+  name citext NOT NULL,
+  description text NOT NULL DEFAULT '',
+  user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+  public boolean NOT NULL DEFAULT false,
+  query text NOT NULL DEFAULT '',
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+  CONSTRAINT search_contexts_has_at_most_one_owner CHECK (user_id IS NULL OR org_id IS NULL),
+  CONSTRAINT search_contexts_name_valid_chars CHECK (name ~ '^[a-zA-Z0-9_.-]+$'),
+  CONSTRAINT search_contexts_name_max_length CHECK (char_length(name) <= 255)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS search_contexts_name_user_id_unique ON search_contexts (name, user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS search_contexts_name_org_id_unique ON search_contexts (name, org_id) WHERE org_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS search_contexts_name_global_unique ON search_contexts (name) WHERE user_id IS NULL AND org_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS search_context_repos (
+  search_context_id integer NOT NULL REFERENCES search_contexts(id) ON DELETE CASCADE DEFERRABLE,
+  repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+  revision text NOT NULL,
+  PRIMARY KEY (search_context_id, repo_id, revision)
+);
+
+COMMIT;
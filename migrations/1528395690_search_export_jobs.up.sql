@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS search_export_jobs (
+  id serial PRIMARY KEY,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  query text NOT NULL,
+  format text NOT NULL,
+  state text NOT NULL DEFAULT 'QUEUED',
+  cursor text,
+  result_count integer NOT NULL DEFAULT 0,
+  repositories_searched integer NOT NULL DEFAULT 0,
+  file_size bigint NOT NULL DEFAULT 0,
+  failure_message text,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+  started_at timestamp with time zone,
+  finished_at timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS search_export_jobs_user_id ON search_export_jobs (user_id);
+CREATE INDEX IF NOT EXISTS search_export_jobs_state ON search_export_jobs (state);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS lsif_packages_dump_id;
+DROP INDEX IF EXISTS lsif_references_dump_id;
+
+ALTER TABLE lsif_references DROP COLUMN IF EXISTS identifier_count;
+
+COMMIT;
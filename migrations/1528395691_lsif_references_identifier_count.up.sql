@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE lsif_references ADD COLUMN IF NOT EXISTS identifier_count integer;
+
+CREATE INDEX IF NOT EXISTS lsif_references_dump_id ON lsif_references (dump_id);
+CREATE INDEX IF NOT EXISTS lsif_packages_dump_id ON lsif_packages (dump_id);
+
+COMMIT;
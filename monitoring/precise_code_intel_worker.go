@@ -98,6 +98,32 @@ func PreciseCodeIntelWorker() *Container {
 					},
 				},
 			},
+			{
+				Title:  "Retention enforcer - removes uploads no longer retained by a retention policy",
+				Hidden: true,
+				Rows: []Row{
+					{
+						{
+							Name:              "expired_uploads_removed",
+							Description:       "expired uploads removed every 5m",
+							Query:             `sum(increase(src_retention_enforcer_uploads_removed_total[5m]))`,
+							DataMayNotExist:   true,
+							Warning:           Alert{GreaterOrEqual: 1000},
+							PanelOptions:      PanelOptions().LegendFormat("uploads"),
+							PossibleSolutions: "none",
+						},
+						{
+							Name:              "retention_enforcer_errors",
+							Description:       "retention enforcer errors every 5m",
+							Query:             `sum(increase(src_retention_enforcer_errors_total[5m]))`,
+							DataMayNotExist:   true,
+							Warning:           Alert{GreaterOrEqual: 20},
+							PanelOptions:      PanelOptions().LegendFormat("errors"),
+							PossibleSolutions: "none",
+						},
+					},
+				},
+			},
 			{
 				Title:  "Internal service requests",
 				Hidden: true,
@@ -154,6 +154,24 @@ const GitLabSchemaJSON = `{
       "description": "Defines whether repositories from this GitLab instance should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable GitLab repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately (subject to rate limiting by GitLab); site admins can still disable them explicitly, and they'll remain disabled.",
       "type": "boolean"
     },
+    "webhooks": {
+      "description": "An array of configurations defining existing GitLab webhooks that send merge request and pipeline updates back to Sourcegraph.",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "title": "GitLabWebhook",
+        "additionalProperties": false,
+        "required": ["secret"],
+        "properties": {
+          "secret": {
+            "description": "The secret token configured for the webhook in GitLab. It is sent in the X-Gitlab-Token header of every webhook payload.",
+            "type": "string",
+            "minLength": 1
+          }
+        }
+      },
+      "examples": [[{ "secret": "webhook-secret" }]]
+    },
     "authorization": {
       "title": "GitLabAuthorization",
       "description": "If non-null, enforces GitLab repository permissions. This requires that there be an item in the ` + "`" + `auth.providers` + "`" + ` field of type \"gitlab\" with the same ` + "`" + `url` + "`" + ` field as specified in this ` + "`" + `GitLabConnection` + "`" + `.",
@@ -319,6 +319,31 @@ type CloneURLToRepositoryName struct {
 	To string `json:"to"`
 }
 
+// CodeIntelRepositoryRetentionPolicy description: A retention policy for precise code intelligence uploads of the repositories whose names match a pattern.
+type CodeIntelRepositoryRetentionPolicy struct {
+	// Pattern description: A regular expression matched against repository names.
+	Pattern string                   `json:"pattern"`
+	Policy  CodeIntelRetentionPolicy `json:"policy"`
+}
+
+// CodeIntelRetentionPolicies description: Retention policies for precise code intelligence (LSIF) uploads. A background job deletes the uploads of each repository that its policy does not keep. The first repository-specific policy whose pattern matches the repository name is used, and the global policy applies to all other repositories. Repositories without a policy keep all of their uploads.
+type CodeIntelRetentionPolicies struct {
+	// Global description: The policy for repositories that do not match a repository-specific policy.
+	Global *CodeIntelRetentionPolicy `json:"global,omitempty"`
+	// Repositories description: Policies for repositories whose names match a pattern.
+	Repositories []*CodeIntelRepositoryRetentionPolicy `json:"repositories,omitempty"`
+}
+
+// CodeIntelRetentionPolicy description: A retention policy for precise code intelligence uploads. Uploads that are visible at the tip of the default branch are never deleted.
+type CodeIntelRetentionPolicy struct {
+	// KeepLatestPerBranch description: The number of most recently uploaded uploads to keep for each branch, root, and indexer. An upload for a commit reachable from a branch is deleted when it is not among these uploads on any branch that contains its commit. 0 keeps every upload for a commit reachable from a branch.
+	KeepLatestPerBranch int `json:"keepLatestPerBranch,omitempty"`
+	// KeepTaggedCommits description: Keep uploads for commits that a tag points to forever.
+	KeepTaggedCommits *bool `json:"keepTaggedCommits,omitempty"`
+	// UnreferencedMaxAgeDays description: The number of days after which uploads for commits that are not reachable from any branch or tag are deleted. 0 keeps these uploads forever.
+	UnreferencedMaxAgeDays int `json:"unreferencedMaxAgeDays,omitempty"`
+}
+
 // CustomGitFetchMapping description: Mapping from Git clone URl domain/path to git fetch command. The `domainPath` field contains the Git clone URL domain/path part. The `fetch` field contains the custom git fetch command.
 type CustomGitFetchMapping struct {
 	// DomainPath description: Git clone URL domain/path
@@ -591,6 +616,8 @@ type GitLabConnection struct {
 	Token string `json:"token"`
 	// Url description: URL of a GitLab instance, such as https://gitlab.example.com or (for GitLab.com) https://gitlab.com.
 	Url string `json:"url"`
+	// Webhooks description: An array of configurations defining existing GitLab webhooks that send merge request and pipeline updates back to Sourcegraph.
+	Webhooks []*GitLabWebhook `json:"webhooks,omitempty"`
 }
 type GitLabNameTransformation struct {
 	// Regex description: The regex to match for the occurrences of its replacement.
@@ -612,6 +639,10 @@ type GitLabRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+type GitLabWebhook struct {
+	// Secret description: The secret token configured for the webhook in GitLab. It is sent in the X-Gitlab-Token header of every webhook payload.
+	Secret string `json:"secret"`
+}
 
 // GitoliteConnection description: Configuration for a connection to Gitolite.
 type GitoliteConnection struct {
@@ -1056,8 +1087,12 @@ type SiteConfiguration struct {
 	//
 	// Only available in Sourcegraph Enterprise.
 	Branding *Branding `json:"branding,omitempty"`
+	// CampaignsActionExecutionEnabled description: Enables server-side execution of campaign actions. When enabled, site admins can launch an action from the web UI and Sourcegraph runs its steps against every matched repository (in Docker containers for "docker" steps, or in a sandboxed working directory for "command" steps) to create a patch set. The Docker CLI must be available to repo-updater for "docker" steps.
+	CampaignsActionExecutionEnabled bool `json:"campaigns.actionExecution.enabled,omitempty"`
 	// CampaignsReadAccessEnabled description: Enables read-only access to campaigns for non-site-admin users. This is a setting for the experimental campaigns feature. These will only have an effect when campaigns is enabled with `{"experimentalFeatures": {"automation": "enabled"}}`.
 	CampaignsReadAccessEnabled *bool `json:"campaigns.readAccess.enabled,omitempty"`
+	// CodeIntelRetentionPolicies description: Retention policies for precise code intelligence (LSIF) uploads. A background job deletes the uploads of each repository that its policy does not keep. The first repository-specific policy whose pattern matches the repository name is used, and the global policy applies to all other repositories. Repositories without a policy keep all of their uploads.
+	CodeIntelRetentionPolicies *CodeIntelRetentionPolicies `json:"codeIntel.retentionPolicies,omitempty"`
 	// CorsOrigin description: Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.
 	CorsOrigin string `json:"corsOrigin,omitempty"`
 	// DebugSearchSymbolsParallelism description: (debug) controls the amount of symbol search parallelism. Defaults to 20. It is not recommended to change this outside of debugging scenarios. This option will be removed in a future version.
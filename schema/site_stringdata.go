@@ -249,6 +249,12 @@ const SiteSchemaJSON = `{
       "!go": { "pointer": true },
       "group": "Campaigns"
     },
+    "campaigns.actionExecution.enabled": {
+      "description": "Enables server-side execution of campaign actions. When enabled, site admins can launch an action from the web UI and Sourcegraph runs its steps against every matched repository (in Docker containers for \"docker\" steps, or in a sandboxed working directory for \"command\" steps) to create a patch set. The Docker CLI must be available to repo-updater for \"docker\" steps.",
+      "type": "boolean",
+      "default": false,
+      "group": "Campaigns"
+    },
     "corsOrigin": {
       "description": "Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.",
       "type": "string",
@@ -262,6 +268,29 @@ const SiteSchemaJSON = `{
       "default": false,
       "group": "Security"
     },
+    "codeIntel.retentionPolicies": {
+      "description": "Retention policies for precise code intelligence (LSIF) uploads. A background job deletes the uploads of each repository that its policy does not keep. The first repository-specific policy whose pattern matches the repository name is used, and the global policy applies to all other repositories. Repositories without a policy keep all of their uploads.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "global": {
+          "description": "The policy for repositories that do not match a repository-specific policy.",
+          "$ref": "#/definitions/CodeIntelRetentionPolicy"
+        },
+        "repositories": {
+          "description": "Policies for repositories whose names match a pattern.",
+          "type": "array",
+          "items": { "$ref": "#/definitions/CodeIntelRepositoryRetentionPolicy" }
+        }
+      },
+      "examples": [
+        {
+          "global": { "keepLatestPerBranch": 10, "keepTaggedCommits": true, "unreferencedMaxAgeDays": 30 },
+          "repositories": [{ "pattern": "^github\\.com/myorg/monorepo$", "policy": { "keepLatestPerBranch": 50 } }]
+        }
+      ],
+      "group": "Misc."
+    },
     "disableNonCriticalTelemetry": {
       "description": "Disable aggregated event counts from being sent to Sourcegraph.com via pings.",
       "type": "boolean",
@@ -787,6 +816,47 @@ const SiteSchemaJSON = `{
         }
       }
     },
+    "CodeIntelRetentionPolicy": {
+      "description": "A retention policy for precise code intelligence uploads. Uploads that are visible at the tip of the default branch are never deleted.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "keepLatestPerBranch": {
+          "description": "The number of most recently uploaded uploads to keep for each branch, root, and indexer. An upload for a commit reachable from a branch is deleted when it is not among these uploads on any branch that contains its commit. 0 keeps every upload for a commit reachable from a branch.",
+          "type": "integer",
+          "minimum": 0,
+          "default": 0
+        },
+        "keepTaggedCommits": {
+          "description": "Keep uploads for commits that a tag points to forever.",
+          "type": "boolean",
+          "default": true,
+          "!go": { "pointer": true }
+        },
+        "unreferencedMaxAgeDays": {
+          "description": "The number of days after which uploads for commits that are not reachable from any branch or tag are deleted. 0 keeps these uploads forever.",
+          "type": "integer",
+          "minimum": 0,
+          "default": 0
+        }
+      }
+    },
+    "CodeIntelRepositoryRetentionPolicy": {
+      "description": "A retention policy for precise code intelligence uploads of the repositories whose names match a pattern.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["pattern", "policy"],
+      "properties": {
+        "pattern": {
+          "description": "A regular expression matched against repository names.",
+          "type": "string",
+          "minLength": 1
+        },
+        "policy": {
+          "$ref": "#/definitions/CodeIntelRetentionPolicy"
+        }
+      }
+    },
     "OpenIDConnectAuthProvider": {
       "description": "Configures the OpenID Connect authentication provider for SSO.",
       "type": "object",